@@ -125,4 +125,13 @@ protoc \
 cp -r ${ZITADEL_PATH}/pkg/grpc/session/zitadel/* ${ZITADEL_PATH}/pkg/grpc
 rm -r ${ZITADEL_PATH}/pkg/grpc/session/zitadel
 
+# validations of messages used by the v2alpha services
+protoc \
+  -I=/proto/include \
+  --validate_out=lang=go:${GOPATH}/src \
+  ${PROTO_PATH}/session/v2alpha/session.proto \
+  ${PROTO_PATH}/session/v2alpha/challenge.proto \
+  ${PROTO_PATH}/user/v2alpha/user.proto \
+  ${PROTO_PATH}/user/v2alpha/query.proto
+
 echo "done generating grpc"
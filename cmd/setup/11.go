@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 11.sql
+	addTokenDPoPJKT string
+)
+
+type TokenDPoPJKT struct {
+	dbClient *sql.DB
+}
+
+func (mig *TokenDPoPJKT) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addTokenDPoPJKT)
+	return err
+}
+
+func (mig *TokenDPoPJKT) String() string {
+	return "11_token_dpop_jkt"
+}
@@ -0,0 +1 @@
+ALTER TABLE auth.tokens ADD COLUMN IF NOT EXISTS actor_user_id TEXT;
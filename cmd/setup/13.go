@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 13.sql
+	addUserPushDevices string
+)
+
+type UserPushDevices struct {
+	dbClient *sql.DB
+}
+
+func (mig *UserPushDevices) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addUserPushDevices)
+	return err
+}
+
+func (mig *UserPushDevices) String() string {
+	return "13_user_push_devices"
+}
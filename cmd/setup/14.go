@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 14.sql
+	createACMECertificates string
+)
+
+type ACMECertificates struct {
+	dbClient *sql.DB
+}
+
+func (mig *ACMECertificates) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createACMECertificates)
+	return err
+}
+
+func (mig *ACMECertificates) String() string {
+	return "14_acme_certificates"
+}
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS system.acme_certificates (
+    name TEXT NOT NULL,
+    data JSONB,
+    updated_at TIMESTAMPTZ,
+
+    PRIMARY KEY (name)
+);
@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 15.sql
+	createDPoPProofs string
+)
+
+type DPoPProofs struct {
+	dbClient *sql.DB
+}
+
+func (mig *DPoPProofs) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createDPoPProofs)
+	return err
+}
+
+func (mig *DPoPProofs) String() string {
+	return "15_dpop_proofs"
+}
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS auth.dpop_proofs (
+    instance_id TEXT NOT NULL,
+    jti TEXT NOT NULL,
+    expiration TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (instance_id, jti)
+);
+
+CREATE INDEX IF NOT EXISTS dpop_proofs_expiration_idx ON auth.dpop_proofs (instance_id, expiration);
@@ -66,6 +66,11 @@ type Steps struct {
 	s8AuthTokens              *AuthTokenIndexes
 	s9EventstoreIndexes2      *EventstoreIndexesNew
 	s10EventstoreCreationDate *CorrectCreationDate
+	s11TokenDPoPJKT           *TokenDPoPJKT
+	s12TokenActor             *TokenActor
+	s13UserPushDevices        *UserPushDevices
+	s14ACMECertificates       *ACMECertificates
+	s15DPoPProofs             *DPoPProofs
 }
 
 type encryptionKeyConfig struct {
@@ -89,6 +89,11 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.s8AuthTokens = &AuthTokenIndexes{dbClient: dbClient}
 	steps.s9EventstoreIndexes2 = New09(dbClient)
 	steps.s10EventstoreCreationDate = &CorrectCreationDate{dbClient: dbClient}
+	steps.s11TokenDPoPJKT = &TokenDPoPJKT{dbClient: dbClient.DB}
+	steps.s12TokenActor = &TokenActor{dbClient: dbClient.DB}
+	steps.s13UserPushDevices = &UserPushDevices{dbClient: dbClient.DB}
+	steps.s14ACMECertificates = &ACMECertificates{dbClient: dbClient.DB}
+	steps.s15DPoPProofs = &DPoPProofs{dbClient: dbClient.DB}
 
 	err = projection.Create(ctx, dbClient, eventstoreClient, config.Projections, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -126,6 +131,16 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	logging.OnError(err).Fatal("unable to migrate step 9")
 	err = migration.Migrate(ctx, eventstoreClient, steps.s10EventstoreCreationDate)
 	logging.OnError(err).Fatal("unable to migrate step 10")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s11TokenDPoPJKT)
+	logging.OnError(err).Fatal("unable to migrate step 11")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s12TokenActor)
+	logging.OnError(err).Fatal("unable to migrate step 12")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s13UserPushDevices)
+	logging.OnError(err).Fatal("unable to migrate step 13")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s14ACMECertificates)
+	logging.OnError(err).Fatal("unable to migrate step 14")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s15DPoPProofs)
+	logging.OnError(err).Fatal("unable to migrate step 15")
 
 	for _, repeatableStep := range repeatableSteps {
 		err = migration.Migrate(ctx, eventstoreClient, repeatableStep)
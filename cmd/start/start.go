@@ -24,6 +24,7 @@ import (
 
 	"github.com/zitadel/zitadel/cmd/key"
 	cmd_tls "github.com/zitadel/zitadel/cmd/tls"
+	"github.com/zitadel/zitadel/internal/acme"
 	"github.com/zitadel/zitadel/internal/actions"
 	admin_es "github.com/zitadel/zitadel/internal/admin/repository/eventsourcing"
 	"github.com/zitadel/zitadel/internal/api"
@@ -44,9 +45,12 @@ import (
 	auth_es "github.com/zitadel/zitadel/internal/auth/repository/eventsourcing"
 	"github.com/zitadel/zitadel/internal/authz"
 	authz_repo "github.com/zitadel/zitadel/internal/authz/repository"
+	authz_es "github.com/zitadel/zitadel/internal/authz/repository/eventsourcing/eventstore"
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
 	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/logstore"
@@ -110,7 +114,11 @@ func startZitadel(config *Config, masterKey string) error {
 		return fmt.Errorf("cannot start eventstore for queries: %w", err)
 	}
 
-	queries, err := query.StartQueries(ctx, eventstoreClient, dbClient, config.Projections, config.SystemDefaults, keys.IDPConfig, keys.OTP, keys.OIDC, keys.SAML, config.InternalAuthZ.RolePermissionMappings)
+	queries, err := query.StartQueries(ctx, eventstoreClient, dbClient, config.Projections, config.SystemDefaults, keys.IDPConfig, keys.OTP, keys.OIDC, keys.SAML, config.InternalAuthZ.RolePermissionMappings, func(q *query.Queries) domain.PermissionCheck {
+		return func(ctx context.Context, permission, orgID, resourceID string) (err error) {
+			return internal_authz.CheckPermission(ctx, &authz_es.UserMembershipRepo{Queries: q}, config.InternalAuthZ, permission, orgID, resourceID)
+		}
+	})
 	if err != nil {
 		return fmt.Errorf("cannot start queries: %w", err)
 	}
@@ -146,6 +154,9 @@ func startZitadel(config *Config, masterKey string) error {
 		keys.OIDC,
 		keys.SAML,
 		&http.Client{},
+		func(ctx context.Context, permission, orgID, resourceID string) (err error) {
+			return internal_authz.CheckPermission(ctx, authZRepo, config.InternalAuthZ, permission, orgID, resourceID)
+		},
 	)
 	if err != nil {
 		return fmt.Errorf("cannot start commands: %w", err)
@@ -168,20 +179,44 @@ func startZitadel(config *Config, masterKey string) error {
 	}
 	actions.SetLogstoreService(actionsLogstoreSvc)
 
-	notification.Start(ctx, config.Projections.Customizations["notifications"], config.Projections.Customizations["notificationsquotas"], config.ExternalPort, config.ExternalSecure, commands, queries, eventstoreClient, assets.AssetAPIFromDomain(config.ExternalSecure, config.ExternalPort), config.SystemDefaults.Notifications.FileSystemPath, keys.User, keys.SMTP, keys.SMS)
+	notification.Start(ctx, config.Projections.Customizations["notifications"], config.Projections.Customizations["notificationsquotas"], config.ExternalPort, config.ExternalSecure, commands, queries, eventstoreClient, assets.AssetAPIFromDomain(config.ExternalSecure, config.ExternalPort), config.SystemDefaults.Notifications.FileSystemPath, config.SystemDefaults.Notifications.Push, keys.User, keys.SMTP, keys.SMS, storage)
 
 	router := mux.NewRouter()
 	tlsConfig, err := config.TLS.Config()
 	if err != nil {
 		return err
 	}
-	err = startAPIs(ctx, clock, router, commands, queries, eventstoreClient, dbClient, config, storage, authZRepo, keys, queries, usageReporter)
+	if tlsConfig != nil && config.TLS.ACME.Enabled {
+		tlsConfig, err = startACME(&config.TLS.ACME, dbClient, keys.ACME, queries, tlsConfig)
+		if err != nil {
+			return err
+		}
+	}
+	err = startAPIs(ctx, clock, router, commands, queries, eventstoreClient, dbClient, config, storage, authZRepo, keys, queries, usageReporter, tlsConfig)
 	if err != nil {
 		return err
 	}
 	return listen(ctx, router, config.Port, tlsConfig)
 }
 
+// startACME serves the HTTP-01 challenges and returns the TLS config serving the issued certificates
+func startACME(config *acme.Config, dbClient *database.DB, encryption crypto.EncryptionAlgorithm, queries *query.Queries, tlsConfig *tls.Config) (*tls.Config, error) {
+	manager, err := acme.NewManager(config, acme.NewStorage(dbClient.DB, encryption), acme.HostPolicy(queries.InstanceByHost))
+	if err != nil {
+		return nil, fmt.Errorf("cannot start acme manager: %w", err)
+	}
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", config.HTTPPort))
+	if err != nil {
+		return nil, fmt.Errorf("tcp listener for acme challenges on %d failed: %w", config.HTTPPort, err)
+	}
+	go func() {
+		logging.Infof("acme challenges are served on %s", lis.Addr().String())
+		err := http.Serve(lis, manager.HTTPHandler(nil))
+		logging.OnError(err).Error("serving acme challenges failed")
+	}()
+	return acme.TLSConfig(manager, tlsConfig), nil
+}
+
 func startAPIs(
 	ctx context.Context,
 	clock clockpkg.Clock,
@@ -196,6 +231,7 @@ func startAPIs(
 	keys *encryptionKeys,
 	quotaQuerier logstore.QuotaQuerier,
 	usageReporter logstore.UsageReporter,
+	tlsConfig *tls.Config,
 ) error {
 	repo := struct {
 		authz_repo.Repository
@@ -205,10 +241,6 @@ func startAPIs(
 		queries,
 	}
 	verifier := internal_authz.Start(repo, http_util.BuildHTTP(config.ExternalDomain, config.ExternalPort, config.ExternalSecure), config.SystemAPIUsers)
-	tlsConfig, err := config.TLS.Config()
-	if err != nil {
-		return err
-	}
 
 	accessStdoutEmitter, err := logstore.NewEmitter(ctx, clock, config.LogStore.Access.Stdout, stdout.NewStdoutEmitter())
 	if err != nil {
@@ -242,16 +274,17 @@ func startAPIs(
 	if err := apis.RegisterServer(ctx, admin.CreateServer(config.Database.DatabaseName(), commands, queries, config.SystemDefaults, adminRepo, config.ExternalSecure, keys.User, config.AuditLogRetention)); err != nil {
 		return err
 	}
-	if err := apis.RegisterServer(ctx, management.CreateServer(commands, queries, config.SystemDefaults, keys.User, config.ExternalSecure, config.AuditLogRetention)); err != nil {
+	messagePreviewer, err := notification.NewMessagePreviewer(queries, config.ExternalPort, config.ExternalSecure, assets.AssetAPIFromDomain(config.ExternalSecure, config.ExternalPort), store)
+	if err != nil {
 		return err
 	}
-	if err := apis.RegisterServer(ctx, auth.CreateServer(commands, queries, authRepo, config.SystemDefaults, keys.User, config.ExternalSecure, config.AuditLogRetention)); err != nil {
+	if err := apis.RegisterServer(ctx, management.CreateServer(commands, queries, config.SystemDefaults, keys.User, config.ExternalSecure, config.AuditLogRetention, messagePreviewer)); err != nil {
 		return err
 	}
-	if err := apis.RegisterService(ctx, user.CreateServer(commands, queries)); err != nil {
+	if err := apis.RegisterServer(ctx, auth.CreateServer(commands, queries, authRepo, config.SystemDefaults, keys.User, config.ExternalSecure, config.AuditLogRetention)); err != nil {
 		return err
 	}
-	if err := apis.RegisterService(ctx, session.CreateServer(commands, queries)); err != nil {
+	if err := apis.RegisterService(ctx, user.CreateServer(commands, queries, keys.User, config.ExternalSecure)); err != nil {
 		return err
 	}
 	instanceInterceptor := middleware.InstanceInterceptor(queries, config.HTTP1HostHeader, login.IgnoreInstanceEndpoints...)
@@ -270,16 +303,26 @@ func startAPIs(
 	}
 	apis.RegisterHandlerOnPrefix(openapi.HandlerPrefix, openAPIHandler)
 
-	oidcProvider, err := oidc.NewProvider(config.OIDC, login.DefaultLoggedOutPath, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, keys.OIDCKey, eventstore, dbClient, userAgentInterceptor, instanceInterceptor.Handler, accessInterceptor.Handle)
+	oidcProvider, err := oidc.NewProvider(config.OIDC, login.DefaultLoggedOutPath, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, keys.OIDCKey, eventstore, dbClient, authZRepo, userAgentInterceptor, instanceInterceptor.Handler, accessInterceptor.Handle)
 	if err != nil {
 		return fmt.Errorf("unable to start oidc provider: %w", err)
 	}
+	if config.OIDC.DynamicClientRegistration {
+		// must be registered before the provider, which handles all other endpoints of /oauth/v2
+		apis.RegisterHandlerPrefixes(oidc.NewClientRegistrationHandler(commands, queries, crypto.NewBCrypt(config.SystemDefaults.SecretGenerators.PasswordSaltCost), verifier, config.ExternalSecure, middleware.CallDurationHandler, instanceInterceptor.Handler, accessInterceptor.Handle), oidc.RegistrationEndpoint)
+	}
 	apis.RegisterHandlerPrefixes(oidcProvider.HttpHandler(), "/.well-known/openid-configuration", "/oidc/v1", "/oauth/v2")
+	// the session service links sessions to auth requests and therefore requires the oidc provider
+	if err := apis.RegisterService(ctx, session.CreateServer(commands, queries, authRepo, config.ExternalSecure, op.AuthCallbackURL(oidcProvider))); err != nil {
+		return err
+	}
 
 	samlProvider, err := saml.NewProvider(config.SAML, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, keys.SAML, eventstore, dbClient, instanceInterceptor.Handler, userAgentInterceptor, accessInterceptor.Handle)
 	if err != nil {
 		return fmt.Errorf("unable to start saml provider: %w", err)
 	}
+	// must be registered before the provider, which handles all other endpoints of /saml/v2
+	apis.RegisterHandlerPrefixes(saml.NewIDPInitiatedHandler(queries, authRepo, middleware.CallDurationHandler, instanceInterceptor.Handler, userAgentInterceptor, accessInterceptor.Handle), saml.IDPInitiatedEndpoint)
 	apis.RegisterHandlerOnPrefix(saml.HandlerPrefix, samlProvider.HttpHandler())
 
 	c, err := console.Start(config.Console, config.ExternalSecure, oidcProvider.IssuerFromRequest, middleware.CallDurationHandler, instanceInterceptor.Handler, accessInterceptor.Handle, config.CustomerPortal)
@@ -110,13 +110,14 @@ export function SetAuthRequest() {
     "offline_access",
     "urn:zitadel:iam:org:project:id:zitadel:aud",
     "urn:zitadel:iam:user:metadata",
+    "urn:zitadel:iam:user:groups",
     `urn:zitadel:iam:org:id:${
       organizationId ? organizationId : "[organizationId]"
     }`,
   ];
 
   const [scopeState, setScopeState] = useState(
-    [true, true, true, false, false, false, false, false]
+    [true, true, true, false, false, false, false, false, false]
     // new Array(allScopes.length).fill(false)
   );
 
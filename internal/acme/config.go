@@ -0,0 +1,23 @@
+package acme
+
+import (
+	"time"
+)
+
+type Config struct {
+	// If enabled, ZITADEL issues the TLS certificates of the instance and organization login domains
+	// on the first request of the domain by ACME (HTTP-01 challenge)
+	Enabled bool
+	// URL of the directory of the ACME server, e.g. Let's Encrypt or a local Pebble for testing
+	DirectoryURL string
+	// Contact email of the ACME account, it's used by the CA for notifications about the certificates
+	Email string
+	// Path to the root certificate of the ACME server,
+	// it's only needed if the ACME server isn't trusted by the system (e.g. Pebble)
+	CACertPath string
+	// Port on which the HTTP-01 challenges are served,
+	// port 80 of all domains must be forwarded to it
+	HTTPPort uint16
+	// Time before the expiration of a certificate, when it's renewed
+	RenewBefore time.Duration
+}
@@ -0,0 +1,83 @@
+package acme
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"os"
+
+	"github.com/zitadel/logging"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+)
+
+// NewManager creates the manager, which issues and renews the certificates of the allowed hosts
+func NewManager(config *Config, cache autocert.Cache, hostPolicy autocert.HostPolicy) (*autocert.Manager, error) {
+	client := &acme.Client{
+		DirectoryURL: config.DirectoryURL,
+	}
+	if config.CACertPath != "" {
+		httpClient, err := httpClientWithRootCA(config.CACertPath)
+		if err != nil {
+			return nil, err
+		}
+		client.HTTPClient = httpClient
+	}
+	return &autocert.Manager{
+		Prompt:      autocert.AcceptTOS,
+		Cache:       cache,
+		HostPolicy:  hostPolicy,
+		RenewBefore: config.RenewBefore,
+		Client:      client,
+		Email:       config.Email,
+	}, nil
+}
+
+// HostPolicy only allows certificates for the domains of the instances
+// and the login domains of their organizations
+func HostPolicy(instanceByHost func(ctx context.Context, host string) (authz.Instance, error)) autocert.HostPolicy {
+	return func(ctx context.Context, host string) error {
+		_, err := instanceByHost(ctx, host)
+		return err
+	}
+}
+
+// TLSConfig serves the certificates of the manager,
+// the certificate of the static config is served if the manager can't provide one (e.g. requests by IP address)
+func TLSConfig(manager *autocert.Manager, static *tls.Config) *tls.Config {
+	config := manager.TLSConfig()
+	if static == nil || len(static.Certificates) == 0 {
+		return config
+	}
+	getCertificate := config.GetCertificate
+	config.GetCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		cert, err := getCertificate(hello)
+		if err == nil {
+			return cert, nil
+		}
+		logging.WithFields("host", hello.ServerName).WithError(err).Debug("no acme certificate, static certificate served")
+		return &static.Certificates[0], nil
+	}
+	return config
+}
+
+func httpClientWithRootCA(path string) (*http.Client, error) {
+	cert, err := os.ReadFile(path)
+	if err != nil {
+		return nil, caos_errors.ThrowInternal(err, "ACME-Ma1rf", "unable to read root certificate of acme server")
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(cert) {
+		return nil, caos_errors.ThrowInternal(nil, "ACME-Ma1pm", "invalid root certificate of acme server")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return &http.Client{Transport: transport}, nil
+}
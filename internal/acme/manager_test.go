@@ -0,0 +1,27 @@
+package acme
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestHostPolicy(t *testing.T) {
+	policy := HostPolicy(func(ctx context.Context, host string) (authz.Instance, error) {
+		if host != "login.customer.com" {
+			return nil, errors.ThrowNotFound(nil, "id", "Errors.IAM.NotFound")
+		}
+		return authz.GetInstance(authz.WithRequestedDomain(ctx, host)), nil
+	})
+	assert.NoError(t, policy(context.Background(), "login.customer.com"))
+	assert.True(t, errors.IsNotFound(policy(context.Background(), "unknown.customer.com")))
+}
+
+func TestNewManager_invalidRootCA(t *testing.T) {
+	_, err := NewManager(&Config{CACertPath: "/does/not/exist.pem"}, nil, nil)
+	assert.True(t, errors.IsInternal(err))
+}
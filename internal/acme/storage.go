@@ -0,0 +1,97 @@
+package acme
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ autocert.Cache = (*crdbStorage)(nil)
+
+const (
+	certificatesTable       = "system.acme_certificates"
+	CertificateColName      = "name"
+	CertificateColData      = "data"
+	CertificateColUpdatedAt = "updated_at"
+)
+
+// crdbStorage stores the certificates, the account key and the pending challenges
+// of the ACME manager encrypted in the database,
+// so all ZITADEL instances serve the same certificates and are able to answer the challenges
+type crdbStorage struct {
+	client     *sql.DB
+	encryption crypto.EncryptionAlgorithm
+}
+
+func NewStorage(client *sql.DB, encryption crypto.EncryptionAlgorithm) autocert.Cache {
+	return &crdbStorage{client: client, encryption: encryption}
+}
+
+func (c *crdbStorage) Get(ctx context.Context, name string) ([]byte, error) {
+	query, args, err := squirrel.Select(CertificateColData).
+		From(certificatesTable).
+		Where(squirrel.Eq{
+			CertificateColName: name,
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, caos_errors.ThrowInternal(err, "ACME-Ce1sq", "Errors.Internal")
+	}
+	data := new(crypto.CryptoValue)
+	err = c.client.QueryRowContext(ctx, query, args...).Scan(data)
+	if errs.Is(err, sql.ErrNoRows) {
+		return nil, autocert.ErrCacheMiss
+	}
+	if err != nil {
+		return nil, caos_errors.ThrowInternal(err, "ACME-Ce1qe", "Errors.Internal")
+	}
+	return crypto.Decrypt(data, c.encryption)
+}
+
+func (c *crdbStorage) Put(ctx context.Context, name string, data []byte) error {
+	encrypted, err := crypto.Encrypt(data, c.encryption)
+	if err != nil {
+		return err
+	}
+	stmt, args, err := squirrel.Insert(certificatesTable).
+		Columns(CertificateColName, CertificateColData, CertificateColUpdatedAt).
+		Values(name, encrypted, "now()").
+		Suffix(fmt.Sprintf(
+			"ON CONFLICT (%s) DO UPDATE"+
+				" SET %s = $2, %s = $3", CertificateColName, CertificateColData, CertificateColUpdatedAt)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return caos_errors.ThrowInternal(err, "ACME-Ce2sq", "Errors.Internal")
+	}
+	_, err = c.client.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return caos_errors.ThrowInternal(err, "ACME-Ce2ex", "Errors.Internal")
+	}
+	return nil
+}
+
+func (c *crdbStorage) Delete(ctx context.Context, name string) error {
+	stmt, args, err := squirrel.Delete(certificatesTable).
+		Where(squirrel.Eq{
+			CertificateColName: name,
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return caos_errors.ThrowInternal(err, "ACME-Ce3sq", "Errors.Internal")
+	}
+	_, err = c.client.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return caos_errors.ThrowInternal(err, "ACME-Ce3ex", "Errors.Internal")
+	}
+	return nil
+}
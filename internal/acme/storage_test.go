@@ -0,0 +1,115 @@
+package acme
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+const (
+	getCertificateStmt = "SELECT data FROM system.acme_certificates" +
+		" WHERE name = $1"
+	putCertificateStmt = "INSERT INTO system.acme_certificates" +
+		" (name,data,updated_at)" +
+		" VALUES ($1,$2,$3)" +
+		" ON CONFLICT (name) DO UPDATE" +
+		" SET data = $2, updated_at = $3"
+	deleteCertificateStmt = "DELETE FROM system.acme_certificates" +
+		" WHERE name = $1"
+)
+
+func Test_crdbStorage_Get(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(m sqlmock.Sqlmock)
+		want    []byte
+		wantErr error
+	}{
+		{
+			name: "not found, cache miss",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(getCertificateStmt)).
+					WithArgs("login.customer.com").
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: autocert.ErrCacheMiss,
+		},
+		{
+			name: "found, decrypted",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(getCertificateStmt)).
+					WithArgs("login.customer.com").
+					WillReturnRows(sqlmock.NewRows([]string{"data"}).AddRow(encryptedValue(t, "certificate")))
+			},
+			want: []byte("certificate"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("unable to create sql mock: %v", err)
+			}
+			tt.prepare(mock)
+			c := NewStorage(client, crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+			got, err := c.Get(context.Background(), "login.customer.com")
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_crdbStorage_Put(t *testing.T) {
+	client, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unable to create sql mock: %v", err)
+	}
+	mock.ExpectExec(regexp.QuoteMeta(putCertificateStmt)).
+		WithArgs("login.customer.com", encryptedValue(t, "certificate"), "now()").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	c := NewStorage(client, crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+	err = c.Put(context.Background(), "login.customer.com", []byte("certificate"))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_crdbStorage_Delete(t *testing.T) {
+	client, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unable to create sql mock: %v", err)
+	}
+	mock.ExpectExec(regexp.QuoteMeta(deleteCertificateStmt)).
+		WithArgs("login.customer.com").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	c := NewStorage(client, crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+	err = c.Delete(context.Background(), "login.customer.com")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// encryptedValue is the value stored by the mock encryption
+func encryptedValue(t *testing.T, value string) driver.Value {
+	data, err := json.Marshal(&crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte(value),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
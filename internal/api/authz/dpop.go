@@ -0,0 +1,152 @@
+package authz
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	DPoPPrefix = "DPoP "
+	DPoPHeader = "dpop"
+
+	dpopProofType = "dpop+jwt"
+	// DefaultDPoPProofMaxAge is the time window in which a proof is accepted after its creation (iat)
+	DefaultDPoPProofMaxAge = 5 * time.Minute
+)
+
+var (
+	dpopSigningAlgorithms = []jose.SignatureAlgorithm{
+		jose.RS256, jose.RS384, jose.RS512,
+		jose.PS256, jose.PS384, jose.PS512,
+		jose.ES256, jose.ES384, jose.ES512,
+		jose.EdDSA,
+	}
+)
+
+// DPoPProofStore remembers the used DPoP proofs to prevent their replay.
+// It has to be shared by all instances of ZITADEL.
+type DPoPProofStore interface {
+	// AddDPoPProof stores the jti of the proof until its expiration
+	// and returns false if the jti was already used and has not yet expired
+	AddDPoPProof(ctx context.Context, jti string, expiration time.Time) (bool, error)
+}
+
+// DPoPRequest holds the DPoP proof sent by the client
+// and the http method and target it was sent to (RFC 9449)
+type DPoPRequest struct {
+	Proof  string
+	Method string
+	Host   string
+	Path   string
+}
+
+type dpopProofClaims struct {
+	JWTID           string `json:"jti"`
+	HTTPMethod      string `json:"htm"`
+	HTTPURI         string `json:"htu"`
+	IssuedAt        int64  `json:"iat"`
+	AccessTokenHash string `json:"ath,omitempty"`
+}
+
+func SetDPoPRequest(ctx context.Context, request *DPoPRequest) context.Context {
+	return context.WithValue(ctx, dpopKey, request)
+}
+
+func GetDPoPRequest(ctx context.Context) *DPoPRequest {
+	request, _ := ctx.Value(dpopKey).(*DPoPRequest)
+	return request
+}
+
+// VerifyDPoPProof verifies the proof of the request and returns the base64url encoded
+// SHA-256 thumbprint (jkt) of the public key the proof was signed with.
+// If an accessToken is provided, the proof has to contain its hash (ath).
+func VerifyDPoPProof(ctx context.Context, proofs DPoPProofStore, request *DPoPRequest, accessToken string, maxAge time.Duration) (jkt string, err error) {
+	if request == nil || request.Proof == "" {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp0P1", "dpop proof missing")
+	}
+	if maxAge == 0 {
+		maxAge = DefaultDPoPProofMaxAge
+	}
+	jws, err := jose.ParseSigned(request.Proof)
+	if err != nil || len(jws.Signatures) != 1 {
+		return "", caos_errs.ThrowUnauthenticated(err, "AUTHZ-Dp0P2", "invalid dpop proof")
+	}
+	header := jws.Signatures[0].Protected
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); !strings.EqualFold(typ, dpopProofType) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp0P3", "invalid dpop proof")
+	}
+	if !isDPoPSigningAlgorithm(header.Algorithm) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp0P4", "invalid dpop proof")
+	}
+	key := header.JSONWebKey
+	if key == nil || !key.Valid() || !key.IsPublic() {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp0P5", "invalid dpop proof")
+	}
+	payload, err := jws.Verify(key)
+	if err != nil {
+		return "", caos_errs.ThrowUnauthenticated(err, "AUTHZ-Dp0P6", "invalid dpop proof")
+	}
+	claims := new(dpopProofClaims)
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return "", caos_errs.ThrowUnauthenticated(err, "AUTHZ-Dp0P7", "invalid dpop proof")
+	}
+	if err = claims.verify(request, accessToken, maxAge); err != nil {
+		return "", err
+	}
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", caos_errs.ThrowUnauthenticated(err, "AUTHZ-Dp0P8", "invalid dpop proof")
+	}
+	jkt = base64.RawURLEncoding.EncodeToString(thumbprint)
+	added, err := proofs.AddDPoPProof(ctx, jkt+":"+claims.JWTID, time.Unix(claims.IssuedAt, 0).Add(maxAge))
+	if err != nil {
+		return "", err
+	}
+	if !added {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp0P9", "dpop proof already used")
+	}
+	return jkt, nil
+}
+
+func (c *dpopProofClaims) verify(request *DPoPRequest, accessToken string, maxAge time.Duration) error {
+	if c.JWTID == "" || !strings.EqualFold(c.HTTPMethod, request.Method) {
+		return caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp1P1", "invalid dpop proof")
+	}
+	target, err := url.Parse(c.HTTPURI)
+	if err != nil || !strings.EqualFold(target.Host, request.Host) || target.Path != request.Path {
+		return caos_errs.ThrowUnauthenticated(err, "AUTHZ-Dp1P2", "invalid dpop proof")
+	}
+	issuedAt := time.Unix(c.IssuedAt, 0)
+	now := time.Now()
+	// allow some clock skew for proofs issued in the (near) future
+	if issuedAt.Add(maxAge).Before(now) || issuedAt.After(now.Add(time.Minute)) {
+		return caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp1P3", "dpop proof expired")
+	}
+	if accessToken == "" {
+		return nil
+	}
+	hash := sha256.Sum256([]byte(accessToken))
+	if c.AccessTokenHash != base64.RawURLEncoding.EncodeToString(hash[:]) {
+		return caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dp1P4", "invalid dpop proof")
+	}
+	return nil
+}
+
+func isDPoPSigningAlgorithm(alg string) bool {
+	for _, supported := range dpopSigningAlgorithms {
+		if string(supported) == alg {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package authz
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func Test_VerifyDPoPProof(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	type args struct {
+		request     func(proof string) *DPoPRequest
+		claims      *dpopProofClaims
+		typ         string
+		accessToken string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "missing proof, error",
+			args: args{
+				request: func(string) *DPoPRequest {
+					return &DPoPRequest{Method: "POST", Host: "issuer.com", Path: "/oauth/v2/token"}
+				},
+				claims: validDPoPClaims("jti1"),
+				typ:    dpopProofType,
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong type, error",
+			args: args{
+				request: tokenEndpointRequest,
+				claims:  validDPoPClaims("jti2"),
+				typ:     "JWT",
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong method, error",
+			args: args{
+				request: func(proof string) *DPoPRequest {
+					return &DPoPRequest{Proof: proof, Method: "GET", Host: "issuer.com", Path: "/oauth/v2/token"}
+				},
+				claims: validDPoPClaims("jti3"),
+				typ:    dpopProofType,
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong target, error",
+			args: args{
+				request: func(proof string) *DPoPRequest {
+					return &DPoPRequest{Proof: proof, Method: "POST", Host: "issuer.com", Path: "/oidc/v1/userinfo"}
+				},
+				claims: validDPoPClaims("jti4"),
+				typ:    dpopProofType,
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired, error",
+			args: args{
+				request: tokenEndpointRequest,
+				claims: &dpopProofClaims{
+					JWTID:      "jti5",
+					HTTPMethod: "POST",
+					HTTPURI:    "https://issuer.com/oauth/v2/token",
+					IssuedAt:   time.Now().Add(-time.Hour).Unix(),
+				},
+				typ: dpopProofType,
+			},
+			wantErr: true,
+		},
+		{
+			name: "access token hash missing, error",
+			args: args{
+				request:     tokenEndpointRequest,
+				claims:      validDPoPClaims("jti6"),
+				typ:         dpopProofType,
+				accessToken: "token",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid proof for access token, ok",
+			args: args{
+				request: tokenEndpointRequest,
+				claims: func() *dpopProofClaims {
+					claims := validDPoPClaims("jti7")
+					hash := sha256.Sum256([]byte("token"))
+					claims.AccessTokenHash = base64.RawURLEncoding.EncodeToString(hash[:])
+					return claims
+				}(),
+				typ:         dpopProofType,
+				accessToken: "token",
+			},
+		},
+		{
+			name: "valid proof, ok",
+			args: args{
+				request: tokenEndpointRequest,
+				claims:  validDPoPClaims("jti8"),
+				typ:     dpopProofType,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proof := signDPoPProof(t, key, tt.args.typ, tt.args.claims)
+			proofs := make(dpopProofStoreMock)
+			jkt, err := VerifyDPoPProof(context.Background(), proofs, tt.args.request(proof), tt.args.accessToken, DefaultDPoPProofMaxAge)
+			if tt.wantErr {
+				assert.True(t, errors.IsUnauthenticated(err), "got wrong err: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			thumbprint, err := (&jose.JSONWebKey{Key: key.Public()}).Thumbprint(crypto.SHA256)
+			require.NoError(t, err)
+			assert.Equal(t, base64.RawURLEncoding.EncodeToString(thumbprint), jkt)
+
+			_, err = VerifyDPoPProof(context.Background(), proofs, tt.args.request(proof), tt.args.accessToken, DefaultDPoPProofMaxAge)
+			assert.True(t, errors.IsUnauthenticated(err), "replayed proof must not be accepted: %v", err)
+		})
+	}
+}
+
+func Test_VerifyDPoPProof_storeError(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	proof := signDPoPProof(t, key, dpopProofType, validDPoPClaims("jti1"))
+	_, err = VerifyDPoPProof(context.Background(), dpopProofStoreErr{}, tokenEndpointRequest(proof), "", DefaultDPoPProofMaxAge)
+	assert.True(t, errors.IsInternal(err), "got wrong err: %v", err)
+}
+
+type dpopProofStoreMock map[string]time.Time
+
+func (m dpopProofStoreMock) AddDPoPProof(_ context.Context, jti string, expiration time.Time) (bool, error) {
+	if exp, ok := m[jti]; ok && exp.After(time.Now()) {
+		return false, nil
+	}
+	m[jti] = expiration
+	return true, nil
+}
+
+type dpopProofStoreErr struct{}
+
+func (dpopProofStoreErr) AddDPoPProof(context.Context, string, time.Time) (bool, error) {
+	return false, errors.ThrowInternal(nil, "id", "store unavailable")
+}
+
+func tokenEndpointRequest(proof string) *DPoPRequest {
+	return &DPoPRequest{Proof: proof, Method: "POST", Host: "issuer.com", Path: "/oauth/v2/token"}
+}
+
+func validDPoPClaims(jti string) *dpopProofClaims {
+	return &dpopProofClaims{
+		JWTID:      jti,
+		HTTPMethod: "POST",
+		HTTPURI:    "https://issuer.com/oauth/v2/token",
+		IssuedAt:   time.Now().Unix(),
+	}
+}
+
+func signDPoPProof(t *testing.T, key *ecdsa.PrivateKey, typ string, claims *dpopProofClaims) string {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: key},
+		&jose.SignerOptions{
+			EmbedJWK: true,
+			ExtraHeaders: map[jose.HeaderKey]interface{}{
+				jose.HeaderType: typ,
+			},
+		},
+	)
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	jws, err := signer.Sign(payload)
+	require.NoError(t, err)
+	proof, err := jws.CompactSerialize()
+	require.NoError(t, err)
+	return proof
+}
@@ -93,3 +93,39 @@ func roleWithContext(membership *Membership) (roles []string, ctxID string) {
 	}
 	return membership.Roles, ""
 }
+
+type MembershipsResolver interface {
+	SearchMyMemberships(ctx context.Context) ([]*Membership, error)
+}
+
+// CheckPermission checks if the user of the context has the permission on the organisation or on the specific resource
+// it is used by the resource based APIs, which do not rely on the organisation of the request header
+func CheckPermission(ctx context.Context, resolver MembershipsResolver, authConfig Config, permission, orgID, resourceID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	ctxData := GetCtxData(ctx)
+	if ctxData.UserID == "" {
+		return errors.ThrowUnauthenticated(nil, "AUTH-Sfw3b", "context missing")
+	}
+	ctxData.OrgID = orgID
+	memberships, err := resolver.SearchMyMemberships(context.WithValue(ctx, dataKey, ctxData))
+	if err != nil {
+		return err
+	}
+	requestedPermissions, _ := mapMembershipsToPermissions(permission, memberships, authConfig)
+	if !hasPermissionOnResource(requestedPermissions, resourceID) {
+		return errors.ThrowPermissionDenied(nil, "AUTH-Sfw3c", "Errors.PermissionDenied")
+	}
+	return nil
+}
+
+func hasPermissionOnResource(requestedPermissions []string, resourceID string) bool {
+	for _, perm := range requestedPermissions {
+		_, ctxID := SplitPermission(perm)
+		if ctxID == "" || ctxID == resourceID {
+			return true
+		}
+	}
+	return false
+}
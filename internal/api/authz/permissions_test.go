@@ -3,6 +3,7 @@ package authz
 import (
 	"context"
 	"testing"
+	"time"
 
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 )
@@ -15,7 +16,7 @@ type testVerifier struct {
 	memberships []*Membership
 }
 
-func (v *testVerifier) VerifyAccessToken(ctx context.Context, token, clientID, projectID string) (string, string, string, string, string, error) {
+func (v *testVerifier) VerifyAccessToken(ctx context.Context, token, clientID, projectID, dpopJKT string) (string, string, string, string, string, error) {
 	return "userID", "agentID", "clientID", "de", "orgID", nil
 }
 func (v *testVerifier) SearchMyMemberships(ctx context.Context) ([]*Membership, error) {
@@ -30,6 +31,10 @@ func (v *testVerifier) ExistsOrg(ctx context.Context, orgID string) error {
 	return nil
 }
 
+func (v *testVerifier) AddDPoPProof(ctx context.Context, jti string, expiration time.Time) (bool, error) {
+	return true, nil
+}
+
 func (v *testVerifier) VerifierClientID(ctx context.Context, appName string) (string, string, error) {
 	return "clientID", "projectID", nil
 }
@@ -519,3 +524,134 @@ func Test_ExistisPerm(t *testing.T) {
 		})
 	}
 }
+
+func Test_CheckPermission(t *testing.T) {
+	authConfig := Config{
+		RolePermissionMappings: []RoleMapping{
+			{
+				Role:        "IAM_OWNER",
+				Permissions: []string{"user.write"},
+			},
+			{
+				Role:        "ORG_OWNER",
+				Permissions: []string{"user.write", "user.read"},
+			},
+			{
+				Role:        "PROJECT_OWNER",
+				Permissions: []string{"user.read"},
+			},
+		},
+	}
+	type args struct {
+		ctx        context.Context
+		resolver   MembershipsResolver
+		permission string
+		orgID      string
+		resourceID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		errFunc func(err error) bool
+	}{
+		{
+			name: "missing context, unauthenticated error",
+			args: args{
+				ctx:        context.Background(),
+				resolver:   &testVerifier{},
+				permission: "user.write",
+				orgID:      "org",
+				resourceID: "user",
+			},
+			errFunc: caos_errs.IsUnauthenticated,
+		},
+		{
+			name: "no membership, permission denied error",
+			args: args{
+				ctx:        getTestCtx("userID", "orgID"),
+				resolver:   &testVerifier{},
+				permission: "user.write",
+				orgID:      "org",
+				resourceID: "user",
+			},
+			errFunc: caos_errs.IsPermissionDenied,
+		},
+		{
+			name: "permission on other resource, permission denied error",
+			args: args{
+				ctx: getTestCtx("userID", "orgID"),
+				resolver: &testVerifier{memberships: []*Membership{
+					{
+						MemberType: MemberTypeProject,
+						ObjectID:   "project",
+						Roles:      []string{"PROJECT_OWNER"},
+					},
+				}},
+				permission: "user.read",
+				orgID:      "org",
+				resourceID: "user",
+			},
+			errFunc: caos_errs.IsPermissionDenied,
+		},
+		{
+			name: "missing permission, permission denied error",
+			args: args{
+				ctx: getTestCtx("userID", "orgID"),
+				resolver: &testVerifier{memberships: []*Membership{
+					{
+						MemberType: MemberTypeOrganisation,
+						ObjectID:   "org",
+						Roles:      []string{"PROJECT_OWNER"},
+					},
+				}},
+				permission: "user.write",
+				orgID:      "org",
+				resourceID: "user",
+			},
+			errFunc: caos_errs.IsPermissionDenied,
+		},
+		{
+			name: "permission on resource, ok",
+			args: args{
+				ctx: getTestCtx("userID", "orgID"),
+				resolver: &testVerifier{memberships: []*Membership{
+					{
+						MemberType: MemberTypeProject,
+						ObjectID:   "user",
+						Roles:      []string{"PROJECT_OWNER"},
+					},
+				}},
+				permission: "user.read",
+				orgID:      "org",
+				resourceID: "user",
+			},
+		},
+		{
+			name: "organisation permission, ok",
+			args: args{
+				ctx: getTestCtx("userID", "orgID"),
+				resolver: &testVerifier{memberships: []*Membership{
+					{
+						MemberType: MemberTypeOrganisation,
+						ObjectID:   "org",
+						Roles:      []string{"ORG_OWNER"},
+					},
+				}},
+				permission: "user.write",
+				orgID:      "org",
+				resourceID: "user",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckPermission(tt.args.ctx, tt.args.resolver, authConfig, tt.args.permission, tt.args.orgID, tt.args.resourceID)
+			if tt.errFunc == nil && err != nil {
+				t.Errorf("got wrong result, should not get err: actual: %v ", err)
+			}
+			if tt.errFunc != nil && !tt.errFunc(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
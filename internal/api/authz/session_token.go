@@ -0,0 +1,40 @@
+package authz
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// SessionTokenFormat is the plain text of a session token before it gets encrypted:
+// the id of the session and the id of its current token
+const SessionTokenFormat = "%s:%s"
+
+// SessionTokenVerifier returns a function, which checks that the session token
+// was issued for the session with the current token id
+func SessionTokenVerifier(algorithm crypto.EncryptionAlgorithm) func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error) {
+	return func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error) {
+		_, span := tracing.NewSpan(ctx)
+		defer func() { span.EndWithError(err) }()
+
+		decodedToken, err := base64.RawURLEncoding.DecodeString(sessionToken)
+		if err != nil {
+			return errors.ThrowPermissionDenied(err, "AUTHZ-Sg3ba", "Errors.Session.Token.Invalid")
+		}
+		var token string
+		for _, keyID := range algorithm.DecryptionKeyIDs() {
+			token, err = algorithm.DecryptString(decodedToken, keyID)
+			if err == nil {
+				break
+			}
+		}
+		if err != nil || token != fmt.Sprintf(SessionTokenFormat, sessionID, tokenID) {
+			return errors.ThrowPermissionDenied(err, "AUTHZ-Wo2nv", "Errors.Session.Token.Invalid")
+		}
+		return nil
+	}
+}
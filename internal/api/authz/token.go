@@ -28,11 +28,12 @@ type TokenVerifier struct {
 }
 
 type authZRepo interface {
-	VerifyAccessToken(ctx context.Context, token, verifierClientID, projectID string) (userID, agentID, clientID, prefLang, resourceOwner string, err error)
+	VerifyAccessToken(ctx context.Context, token, verifierClientID, projectID, dpopJKT string) (userID, agentID, clientID, prefLang, resourceOwner string, err error)
 	VerifierClientID(ctx context.Context, name string) (clientID, projectID string, err error)
 	SearchMyMemberships(ctx context.Context) ([]*Membership, error)
 	ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (projectID string, origins []string, err error)
 	ExistsOrg(ctx context.Context, orgID string) error
+	DPoPProofStore
 }
 
 func Start(authZRepo authZRepo, issuer string, keys map[string]*SystemAPIUser) (v *TokenVerifier) {
@@ -51,14 +52,31 @@ func Start(authZRepo authZRepo, issuer string, keys map[string]*SystemAPIUser) (
 }
 
 func (v *TokenVerifier) VerifyAccessToken(ctx context.Context, token string, method string) (userID, clientID, agentID, prefLang, resourceOwner string, err error) {
+	return v.verifyAccessToken(ctx, token, method, "")
+}
+
+// VerifyDPoPAccessToken verifies the DPoP proof of the request in the context
+// and ensures the access token is bound to the key of the proof
+func (v *TokenVerifier) VerifyDPoPAccessToken(ctx context.Context, token string, method string) (userID, clientID, agentID, prefLang, resourceOwner string, err error) {
+	jkt, err := VerifyDPoPProof(ctx, v.authZRepo, GetDPoPRequest(ctx), token, DefaultDPoPProofMaxAge)
+	if err != nil {
+		return "", "", "", "", "", err
+	}
+	return v.verifyAccessToken(ctx, token, method, jkt)
+}
+
+func (v *TokenVerifier) verifyAccessToken(ctx context.Context, token, method, dpopJKT string) (userID, clientID, agentID, prefLang, resourceOwner string, err error) {
 	if strings.HasPrefix(method, "/zitadel.system.v1.SystemService") {
+		if dpopJKT != "" {
+			return "", "", "", "", "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Dfg3q", "dpop is not supported for the system api")
+		}
 		userID, err := v.verifySystemToken(ctx, token)
 		if err != nil {
 			return "", "", "", "", "", err
 		}
 		return userID, "", "", "", "", nil
 	}
-	userID, agentID, clientID, prefLang, resourceOwner, err = v.authZRepo.VerifyAccessToken(ctx, token, "", GetInstance(ctx).ProjectID())
+	userID, agentID, clientID, prefLang, resourceOwner, err = v.authZRepo.VerifyAccessToken(ctx, token, "", GetInstance(ctx).ProjectID(), dpopJKT)
 	return userID, clientID, agentID, prefLang, resourceOwner, err
 }
 
@@ -155,6 +173,9 @@ func verifyAccessToken(ctx context.Context, token string, t *TokenVerifier, meth
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	if strings.HasPrefix(token, DPoPPrefix) {
+		return t.VerifyDPoPAccessToken(ctx, strings.TrimPrefix(token, DPoPPrefix), method)
+	}
 	parts := strings.Split(token, BearerPrefix)
 	if len(parts) != 2 {
 		return "", "", "", "", "", caos_errs.ThrowUnauthenticated(nil, "AUTH-7fs1e", "invalid auth header")
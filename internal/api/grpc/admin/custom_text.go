@@ -300,6 +300,342 @@ func (s *Server) ResetCustomPasswordChangeMessageTextToDefault(ctx context.Conte
 	}, nil
 }
 
+func (s *Server) GetDefaultMagicLinkMessageText(ctx context.Context, req *admin_pb.GetDefaultMagicLinkMessageTextRequest) (*admin_pb.GetDefaultMagicLinkMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.MagicLinkMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultMagicLinkMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomMagicLinkMessageText(ctx context.Context, req *admin_pb.GetCustomMagicLinkMessageTextRequest) (*admin_pb.GetCustomMagicLinkMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.MagicLinkMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomMagicLinkMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultMagicLinkMessageText(ctx context.Context, req *admin_pb.SetDefaultMagicLinkMessageTextRequest) (*admin_pb.SetDefaultMagicLinkMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetMagicLinkCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultMagicLinkMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomMagicLinkMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomMagicLinkMessageTextToDefaultRequest) (*admin_pb.ResetCustomMagicLinkMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.MagicLinkMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomMagicLinkMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultPhoneOTPMessageText(ctx context.Context, req *admin_pb.GetDefaultPhoneOTPMessageTextRequest) (*admin_pb.GetDefaultPhoneOTPMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.PhoneOTPMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultPhoneOTPMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomPhoneOTPMessageText(ctx context.Context, req *admin_pb.GetCustomPhoneOTPMessageTextRequest) (*admin_pb.GetCustomPhoneOTPMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.PhoneOTPMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomPhoneOTPMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultPhoneOTPMessageText(ctx context.Context, req *admin_pb.SetDefaultPhoneOTPMessageTextRequest) (*admin_pb.SetDefaultPhoneOTPMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetPhoneOTPCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultPhoneOTPMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomPhoneOTPMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomPhoneOTPMessageTextToDefaultRequest) (*admin_pb.ResetCustomPhoneOTPMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.PhoneOTPMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomPhoneOTPMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultNewDeviceLoginMessageText(ctx context.Context, req *admin_pb.GetDefaultNewDeviceLoginMessageTextRequest) (*admin_pb.GetDefaultNewDeviceLoginMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.NewDeviceLoginMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultNewDeviceLoginMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomNewDeviceLoginMessageText(ctx context.Context, req *admin_pb.GetCustomNewDeviceLoginMessageTextRequest) (*admin_pb.GetCustomNewDeviceLoginMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.NewDeviceLoginMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomNewDeviceLoginMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultNewDeviceLoginMessageText(ctx context.Context, req *admin_pb.SetDefaultNewDeviceLoginMessageTextRequest) (*admin_pb.SetDefaultNewDeviceLoginMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetNewDeviceLoginCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultNewDeviceLoginMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomNewDeviceLoginMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomNewDeviceLoginMessageTextToDefaultRequest) (*admin_pb.ResetCustomNewDeviceLoginMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.NewDeviceLoginMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomNewDeviceLoginMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultMFAAddedMessageText(ctx context.Context, req *admin_pb.GetDefaultMFAAddedMessageTextRequest) (*admin_pb.GetDefaultMFAAddedMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.MFAAddedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultMFAAddedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomMFAAddedMessageText(ctx context.Context, req *admin_pb.GetCustomMFAAddedMessageTextRequest) (*admin_pb.GetCustomMFAAddedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.MFAAddedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomMFAAddedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultMFAAddedMessageText(ctx context.Context, req *admin_pb.SetDefaultMFAAddedMessageTextRequest) (*admin_pb.SetDefaultMFAAddedMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetMFAAddedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultMFAAddedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomMFAAddedMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomMFAAddedMessageTextToDefaultRequest) (*admin_pb.ResetCustomMFAAddedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.MFAAddedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomMFAAddedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultMFARemovedMessageText(ctx context.Context, req *admin_pb.GetDefaultMFARemovedMessageTextRequest) (*admin_pb.GetDefaultMFARemovedMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.MFARemovedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultMFARemovedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomMFARemovedMessageText(ctx context.Context, req *admin_pb.GetCustomMFARemovedMessageTextRequest) (*admin_pb.GetCustomMFARemovedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.MFARemovedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomMFARemovedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultMFARemovedMessageText(ctx context.Context, req *admin_pb.SetDefaultMFARemovedMessageTextRequest) (*admin_pb.SetDefaultMFARemovedMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetMFARemovedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultMFARemovedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomMFARemovedMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomMFARemovedMessageTextToDefaultRequest) (*admin_pb.ResetCustomMFARemovedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.MFARemovedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomMFARemovedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultEmailChangedMessageText(ctx context.Context, req *admin_pb.GetDefaultEmailChangedMessageTextRequest) (*admin_pb.GetDefaultEmailChangedMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.EmailChangedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultEmailChangedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomEmailChangedMessageText(ctx context.Context, req *admin_pb.GetCustomEmailChangedMessageTextRequest) (*admin_pb.GetCustomEmailChangedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.EmailChangedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomEmailChangedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultEmailChangedMessageText(ctx context.Context, req *admin_pb.SetDefaultEmailChangedMessageTextRequest) (*admin_pb.SetDefaultEmailChangedMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetEmailChangedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultEmailChangedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomEmailChangedMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomEmailChangedMessageTextToDefaultRequest) (*admin_pb.ResetCustomEmailChangedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.EmailChangedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomEmailChangedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultAccountLockedMessageText(ctx context.Context, req *admin_pb.GetDefaultAccountLockedMessageTextRequest) (*admin_pb.GetDefaultAccountLockedMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.AccountLockedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultAccountLockedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomAccountLockedMessageText(ctx context.Context, req *admin_pb.GetCustomAccountLockedMessageTextRequest) (*admin_pb.GetCustomAccountLockedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.AccountLockedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomAccountLockedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultAccountLockedMessageText(ctx context.Context, req *admin_pb.SetDefaultAccountLockedMessageTextRequest) (*admin_pb.SetDefaultAccountLockedMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetAccountLockedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultAccountLockedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomAccountLockedMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomAccountLockedMessageTextToDefaultRequest) (*admin_pb.ResetCustomAccountLockedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.AccountLockedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomAccountLockedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
 func (s *Server) GetDefaultPasswordlessRegistrationMessageText(ctx context.Context, req *admin_pb.GetDefaultPasswordlessRegistrationMessageTextRequest) (*admin_pb.GetDefaultPasswordlessRegistrationMessageTextResponse, error) {
 	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.PasswordlessRegistrationMessageType, req.Language)
 	if err != nil {
@@ -394,3 +730,47 @@ func (s *Server) ResetCustomLoginTextToDefault(ctx context.Context, req *admin_p
 		),
 	}, nil
 }
+
+func (s *Server) ExportCustomTexts(ctx context.Context, req *admin_pb.ExportCustomTextsRequest) (*admin_pb.ExportCustomTextsResponse, error) {
+	lang, err := text_grpc.ParseCustomTextLanguage(req.Language)
+	if err != nil {
+		return nil, err
+	}
+	translations, err := s.query.CustomTextTranslations(ctx, authz.GetInstance(ctx).InstanceID(), lang)
+	if err != nil {
+		return nil, err
+	}
+	data, err := text_grpc.CustomTextTranslationsToFile(translations, req.Format)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ExportCustomTextsResponse{
+		Data: data,
+	}, nil
+}
+
+func (s *Server) ImportCustomTexts(ctx context.Context, req *admin_pb.ImportCustomTextsRequest) (*admin_pb.ImportCustomTextsResponse, error) {
+	file, err := text_grpc.CustomTextFileToTranslationFile(req.Data, req.Format, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	translations, err := s.query.CustomTextTranslations(ctx, authz.GetInstance(ctx).InstanceID(), file.TargetLanguage)
+	if err != nil {
+		return nil, err
+	}
+	texts, err := translations.Changes(file)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.command.ImportInstanceCustomTexts(ctx, file.TargetLanguage, texts)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ImportCustomTextsResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
@@ -486,6 +486,8 @@ func (s *Server) getLockoutPolicy(ctx context.Context, orgID string) (_ *managem
 	if !queriedLockout.IsDefault {
 		return &management_pb.AddCustomLockoutPolicyRequest{
 			MaxPasswordAttempts: uint32(queriedLockout.MaxPasswordAttempts),
+			MaxOtpAttempts:      uint32(queriedLockout.MaxOTPAttempts),
+			LockoutDuration:     durationpb.New(queriedLockout.LockoutDuration),
 		}, nil
 	}
 	return nil, nil
@@ -801,6 +803,7 @@ func (s *Server) getProjectsAndApps(ctx context.Context, org string) ([]*v1_pb.D
 						ClockSkew:                durationpb.New(app.OIDCConfig.ClockSkew),
 						AdditionalOrigins:        app.OIDCConfig.AdditionalOrigins,
 						SkipNativeAppSuccessPage: app.OIDCConfig.SkipNativeAppSuccessPage,
+						RequireConsent:           app.OIDCConfig.RequireConsent,
 					},
 				})
 			}
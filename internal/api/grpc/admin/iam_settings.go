@@ -3,8 +3,9 @@ package admin
 import (
 	"context"
 
-	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 )
 
@@ -46,7 +47,11 @@ func (s *Server) UpdateSecretGenerator(ctx context.Context, req *admin_pb.Update
 }
 
 func (s *Server) GetSMTPConfig(ctx context.Context, req *admin_pb.GetSMTPConfigRequest) (*admin_pb.GetSMTPConfigResponse, error) {
-	smtp, err := s.query.SMTPConfigByAggregateID(ctx, authz.GetInstance(ctx).InstanceID())
+	id, err := s.smtpConfigID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	smtp, err := s.query.SMTPConfigByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -55,8 +60,19 @@ func (s *Server) GetSMTPConfig(ctx context.Context, req *admin_pb.GetSMTPConfigR
 	}, nil
 }
 
+func (s *Server) ListSMTPConfigs(ctx context.Context, req *admin_pb.ListSMTPConfigsRequest) (*admin_pb.ListSMTPConfigsResponse, error) {
+	result, err := s.query.SearchSMTPConfigs(ctx, listSMTPConfigsToModel(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListSMTPConfigsResponse{
+		Details: object.ToListDetails(result.Count, result.Sequence, result.Timestamp),
+		Result:  SMTPConfigsToPb(result.Configs),
+	}, nil
+}
+
 func (s *Server) AddSMTPConfig(ctx context.Context, req *admin_pb.AddSMTPConfigRequest) (*admin_pb.AddSMTPConfigResponse, error) {
-	details, err := s.command.AddSMTPConfig(ctx, AddSMTPToConfig(req))
+	id, details, err := s.command.AddSMTPConfig(ctx, AddSMTPToConfig(req))
 	if err != nil {
 		return nil, err
 	}
@@ -65,11 +81,16 @@ func (s *Server) AddSMTPConfig(ctx context.Context, req *admin_pb.AddSMTPConfigR
 			details.Sequence,
 			details.EventDate,
 			details.ResourceOwner),
+		Id: id,
 	}, nil
 }
 
 func (s *Server) UpdateSMTPConfig(ctx context.Context, req *admin_pb.UpdateSMTPConfigRequest) (*admin_pb.UpdateSMTPConfigResponse, error) {
-	details, err := s.command.ChangeSMTPConfig(ctx, UpdateSMTPToConfig(req))
+	id, err := s.smtpConfigID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.ChangeSMTPConfig(ctx, UpdateSMTPToConfig(id, req))
 	if err != nil {
 		return nil, err
 	}
@@ -81,8 +102,12 @@ func (s *Server) UpdateSMTPConfig(ctx context.Context, req *admin_pb.UpdateSMTPC
 	}, nil
 }
 
-func (s *Server) RemoveSMTPConfig(ctx context.Context, _ *admin_pb.RemoveSMTPConfigRequest) (*admin_pb.RemoveSMTPConfigResponse, error) {
-	details, err := s.command.RemoveSMTPConfig(ctx)
+func (s *Server) RemoveSMTPConfig(ctx context.Context, req *admin_pb.RemoveSMTPConfigRequest) (*admin_pb.RemoveSMTPConfigResponse, error) {
+	id, err := s.smtpConfigID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.RemoveSMTPConfig(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +120,11 @@ func (s *Server) RemoveSMTPConfig(ctx context.Context, _ *admin_pb.RemoveSMTPCon
 }
 
 func (s *Server) UpdateSMTPConfigPassword(ctx context.Context, req *admin_pb.UpdateSMTPConfigPasswordRequest) (*admin_pb.UpdateSMTPConfigPasswordResponse, error) {
-	details, err := s.command.ChangeSMTPConfigPassword(ctx, req.Password)
+	id, err := s.smtpConfigID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.ChangeSMTPConfigPassword(ctx, id, req.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +136,59 @@ func (s *Server) UpdateSMTPConfigPassword(ctx context.Context, req *admin_pb.Upd
 	}, nil
 }
 
+func (s *Server) UpdateSMTPConfigCACertificate(ctx context.Context, req *admin_pb.UpdateSMTPConfigCACertificateRequest) (*admin_pb.UpdateSMTPConfigCACertificateResponse, error) {
+	id, err := s.smtpConfigID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.ChangeSMTPConfigCACertificate(ctx, id, req.CaCertificate)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateSMTPConfigCACertificateResponse{
+		Details: object.ChangeToDetailsPb(
+			details.Sequence,
+			details.EventDate,
+			details.ResourceOwner),
+	}, nil
+}
+
+func (s *Server) TestSMTPConfig(ctx context.Context, req *admin_pb.TestSMTPConfigRequest) (*admin_pb.TestSMTPConfigResponse, error) {
+	id, err := s.smtpConfigID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	err = s.command.TestSMTPConfig(ctx, id, req.ReceiverAddress)
+	if errors.IsErrorInvalidArgument(err) || errors.IsNotFound(err) {
+		return nil, err
+	}
+	return &admin_pb.TestSMTPConfigResponse{
+		Error: SMTPTestErrorToPb(err),
+	}, nil
+}
+
+// smtpConfigID returns the id of the instance config with the highest priority if no id is requested
+func (s *Server) smtpConfigID(ctx context.Context, id string) (string, error) {
+	if id != "" {
+		return id, nil
+	}
+	orgIDQuery, err := query.NewSMTPConfigOrgIDSearchQuery("")
+	if err != nil {
+		return "", err
+	}
+	configs, err := s.query.SearchSMTPConfigs(ctx, &query.SMTPConfigsSearchQueries{
+		SearchRequest: query.SearchRequest{Limit: 1},
+		Queries:       []query.SearchQuery{orgIDQuery},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(configs.Configs) == 0 {
+		return "", errors.ThrowNotFound(nil, "ADMIN-Sfw4a", "Errors.SMTPConfig.NotFound")
+	}
+	return configs.Configs[0].ID, nil
+}
+
 func (s *Server) GetSecurityPolicy(ctx context.Context, req *admin_pb.GetSecurityPolicyRequest) (*admin_pb.GetSecurityPolicyResponse, error) {
 	policy, err := s.query.SecurityPolicy(ctx)
 	if err != nil {
@@ -1,10 +1,13 @@
 package admin
 
 import (
+	"strings"
+
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -113,43 +116,165 @@ func SecretGeneratorTypeToDomain(generatorType settings_pb.SecretGeneratorType)
 	}
 }
 
-func AddSMTPToConfig(req *admin_pb.AddSMTPConfigRequest) *smtp.Config {
-	return &smtp.Config{
-		Tls:      req.Tls,
-		From:     req.SenderAddress,
-		FromName: req.SenderName,
-		SMTP: smtp.SMTP{
-			Host:     req.Host,
-			User:     req.User,
-			Password: req.Password,
+func listSMTPConfigsToModel(req *admin_pb.ListSMTPConfigsRequest) *query.SMTPConfigsSearchQueries {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.SMTPConfigsSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+	}
+}
+
+func AddSMTPToConfig(req *admin_pb.AddSMTPConfigRequest) *command.SMTPConfig {
+	return &command.SMTPConfig{
+		OrgID:       req.OrgId,
+		Description: req.Description,
+		Priority:    req.Priority,
+		Config: smtp.Config{
+			Tls:           req.Tls,
+			TLSMode:       smtpTLSModeToDomain(req.TlsMode),
+			From:          req.SenderAddress,
+			FromName:      req.SenderName,
+			CACertificate: req.CaCertificate,
+			SMTP: smtp.SMTP{
+				Host:     req.Host,
+				User:     req.User,
+				Password: req.Password,
+				AuthType: smtpAuthTypeToDomain(req.AuthType),
+				XOAuth2:  smtpXOAuth2ToConfig(req.Xoauth2),
+			},
 		},
 	}
 }
 
-func UpdateSMTPToConfig(req *admin_pb.UpdateSMTPConfigRequest) *smtp.Config {
-	return &smtp.Config{
-		Tls:      req.Tls,
-		From:     req.SenderAddress,
-		FromName: req.SenderName,
-		SMTP: smtp.SMTP{
-			Host: req.Host,
-			User: req.User,
+func UpdateSMTPToConfig(id string, req *admin_pb.UpdateSMTPConfigRequest) *command.SMTPConfig {
+	return &command.SMTPConfig{
+		ID:          id,
+		Description: req.Description,
+		Priority:    req.Priority,
+		Config: smtp.Config{
+			Tls:      req.Tls,
+			TLSMode:  smtpTLSModeToDomain(req.TlsMode),
+			From:     req.SenderAddress,
+			FromName: req.SenderName,
+			SMTP: smtp.SMTP{
+				Host:     req.Host,
+				User:     req.User,
+				AuthType: smtpAuthTypeToDomain(req.AuthType),
+				XOAuth2:  smtpXOAuth2ToConfig(req.Xoauth2),
+			},
 		},
 	}
 }
 
+func smtpXOAuth2ToConfig(xoauth2 *admin_pb.SMTPXOAuth2Config) *smtp.XOAuth2 {
+	if xoauth2 == nil {
+		return nil
+	}
+	return &smtp.XOAuth2{
+		TokenEndpoint: xoauth2.TokenEndpoint,
+		ClientID:      xoauth2.ClientId,
+		ClientSecret:  xoauth2.ClientSecret,
+		Scopes:        xoauth2.Scopes,
+	}
+}
+
+func smtpTLSModeToDomain(mode settings_pb.SMTPTLSMode) domain.SMTPTLSMode {
+	switch mode {
+	case settings_pb.SMTPTLSMode_SMTP_TLS_MODE_IMPLICIT:
+		return domain.SMTPTLSModeImplicit
+	case settings_pb.SMTPTLSMode_SMTP_TLS_MODE_STARTTLS_REQUIRED:
+		return domain.SMTPTLSModeStartTLSRequired
+	case settings_pb.SMTPTLSMode_SMTP_TLS_MODE_STARTTLS_OPPORTUNISTIC:
+		return domain.SMTPTLSModeStartTLSOpportunistic
+	default:
+		return domain.SMTPTLSModeUnspecified
+	}
+}
+
+func smtpTLSModeToPb(mode domain.SMTPTLSMode) settings_pb.SMTPTLSMode {
+	switch mode {
+	case domain.SMTPTLSModeImplicit:
+		return settings_pb.SMTPTLSMode_SMTP_TLS_MODE_IMPLICIT
+	case domain.SMTPTLSModeStartTLSRequired:
+		return settings_pb.SMTPTLSMode_SMTP_TLS_MODE_STARTTLS_REQUIRED
+	case domain.SMTPTLSModeStartTLSOpportunistic:
+		return settings_pb.SMTPTLSMode_SMTP_TLS_MODE_STARTTLS_OPPORTUNISTIC
+	default:
+		return settings_pb.SMTPTLSMode_SMTP_TLS_MODE_UNSPECIFIED
+	}
+}
+
+func smtpAuthTypeToDomain(authType settings_pb.SMTPAuthType) domain.SMTPAuthType {
+	switch authType {
+	case settings_pb.SMTPAuthType_SMTP_AUTH_TYPE_XOAUTH2:
+		return domain.SMTPAuthTypeXOAuth2
+	default:
+		return domain.SMTPAuthTypeUnspecified
+	}
+}
+
+func smtpAuthTypeToPb(authType domain.SMTPAuthType) settings_pb.SMTPAuthType {
+	switch authType {
+	case domain.SMTPAuthTypeXOAuth2:
+		return settings_pb.SMTPAuthType_SMTP_AUTH_TYPE_XOAUTH2
+	default:
+		return settings_pb.SMTPAuthType_SMTP_AUTH_TYPE_UNSPECIFIED
+	}
+}
+
+func SMTPConfigsToPb(configs []*query.SMTPConfig) []*settings_pb.SMTPConfig {
+	c := make([]*settings_pb.SMTPConfig, len(configs))
+	for i, config := range configs {
+		c[i] = SMTPConfigToPb(config)
+	}
+	return c
+}
+
 func SMTPConfigToPb(smtp *query.SMTPConfig) *settings_pb.SMTPConfig {
 	mapped := &settings_pb.SMTPConfig{
-		Tls:           smtp.TLS,
-		SenderAddress: smtp.SenderAddress,
-		SenderName:    smtp.SenderName,
-		Host:          smtp.Host,
-		User:          smtp.User,
-		Details:       obj_grpc.ToViewDetailsPb(smtp.Sequence, smtp.CreationDate, smtp.ChangeDate, smtp.AggregateID),
+		Id:               smtp.ID,
+		OrgId:            smtp.OrgID,
+		Description:      smtp.Description,
+		Priority:         smtp.Priority,
+		Tls:              smtp.TLS,
+		SenderAddress:    smtp.SenderAddress,
+		SenderName:       smtp.SenderName,
+		Host:             smtp.Host,
+		User:             smtp.User,
+		TlsMode:          smtpTLSModeToPb(smtp.TLSMode),
+		AuthType:         smtpAuthTypeToPb(smtp.AuthType),
+		HasCaCertificate: smtp.CACertificate != nil,
+		Details:          obj_grpc.ToViewDetailsPb(smtp.Sequence, smtp.CreationDate, smtp.ChangeDate, smtp.AggregateID),
+	}
+	if smtp.AuthType == domain.SMTPAuthTypeXOAuth2 {
+		mapped.Xoauth2 = &settings_pb.SMTPXOAuth2{
+			TokenEndpoint: smtp.XOAuth2TokenEndpoint,
+			ClientId:      smtp.XOAuth2ClientID,
+			Scopes:        smtp.XOAuth2Scopes,
+		}
 	}
 	return mapped
 }
 
+// SMTPTestErrorToPb returns the messages of the error chain,
+// so the error of the smtp server is visible to the user
+func SMTPTestErrorToPb(err error) string {
+	messages := make([]string, 0, 2)
+	for err != nil {
+		caosErr, ok := err.(errors.Error)
+		if !ok {
+			messages = append(messages, err.Error())
+			break
+		}
+		messages = append(messages, caosErr.GetMessage())
+		err = caosErr.GetParent()
+	}
+	return strings.Join(messages, ": ")
+}
+
 func SecurityPolicyToPb(policy *query.SecurityPolicy) *settings_pb.SecurityPolicy {
 	return &settings_pb.SecurityPolicy{
 		Details:               obj_grpc.ToViewDetailsPb(policy.Sequence, policy.CreationDate, policy.ChangeDate, policy.AggregateID),
@@ -0,0 +1,34 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/grpc/settings"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListNotificationDeliveries(ctx context.Context, req *admin_pb.ListNotificationDeliveriesRequest) (*admin_pb.ListNotificationDeliveriesResponse, error) {
+	queries, err := listNotificationDeliveriesToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.query.SearchNotificationDeliveries(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListNotificationDeliveriesResponse{
+		Details: object.ToListDetails(result.Count, result.Sequence, result.Timestamp),
+		Result:  settings.NotificationDeliveriesToPb(result.Deliveries),
+	}, nil
+}
+
+func (s *Server) ResendNotification(ctx context.Context, req *admin_pb.ResendNotificationRequest) (*admin_pb.ResendNotificationResponse, error) {
+	details, err := s.command.ResendNotification(ctx, "", "", req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResendNotificationResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -0,0 +1,37 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/grpc/settings"
+	"github.com/zitadel/zitadel/internal/query"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+	settings_pb "github.com/zitadel/zitadel/pkg/grpc/settings"
+)
+
+func listNotificationDeliveriesToModel(req *admin_pb.ListNotificationDeliveriesRequest) (*query.NotificationDeliverySearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries := make([]query.SearchQuery, 0, 2)
+	if req.UserId != "" {
+		userID, err := query.NewNotificationDeliveryUserIDSearchQuery(req.UserId)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, userID)
+	}
+	if req.State != settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_UNSPECIFIED {
+		state, err := query.NewNotificationDeliveryStateSearchQuery(settings.NotificationDeliveryStateToDomain(req.State))
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, state)
+	}
+	return &query.NotificationDeliverySearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.NotificationOutboxColumnCreationDate,
+		},
+		Queries: queries,
+	}, nil
+}
@@ -0,0 +1,28 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/command"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func addNotificationPolicyToCommand(req *admin_pb.AddNotificationPolicyRequest) *command.NotificationPolicy {
+	return &command.NotificationPolicy{
+		PasswordChange: req.GetPasswordChange(),
+		NewDeviceLogin: req.GetNewDeviceLogin(),
+		MFAAdded:       req.GetMfaAdded(),
+		MFARemoved:     req.GetMfaRemoved(),
+		EmailChange:    req.GetEmailChange(),
+		AccountLocked:  req.GetAccountLocked(),
+	}
+}
+
+func updateNotificationPolicyToCommand(req *admin_pb.UpdateNotificationPolicyRequest) *command.NotificationPolicy {
+	return &command.NotificationPolicy{
+		PasswordChange: req.GetPasswordChange(),
+		NewDeviceLogin: req.GetNewDeviceLogin(),
+		MFAAdded:       req.GetMfaAdded(),
+		MFARemoved:     req.GetMfaRemoved(),
+		EmailChange:    req.GetEmailChange(),
+		AccountLocked:  req.GetAccountLocked(),
+	}
+}
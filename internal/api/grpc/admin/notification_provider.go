@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/api/grpc/settings"
 	"github.com/zitadel/zitadel/internal/domain"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
@@ -30,3 +31,43 @@ func (s *Server) GetLogNotificationProvider(ctx context.Context, req *admin_pb.G
 		Provider: settings.NotificationProviderToPb(result),
 	}, nil
 }
+
+func (s *Server) GetNotificationWebhookProvider(ctx context.Context, req *admin_pb.GetNotificationWebhookProviderRequest) (*admin_pb.GetNotificationWebhookProviderResponse, error) {
+	result, err := s.query.NotificationWebhookProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetNotificationWebhookProviderResponse{
+		Provider: settings.NotificationWebhookProviderToPb(result),
+	}, nil
+}
+
+func (s *Server) AddNotificationWebhookProvider(ctx context.Context, req *admin_pb.AddNotificationWebhookProviderRequest) (*admin_pb.AddNotificationWebhookProviderResponse, error) {
+	result, err := s.command.AddNotificationWebhookProvider(ctx, addNotificationWebhookProviderToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddNotificationWebhookProviderResponse{
+		Details: object.DomainToAddDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) UpdateNotificationWebhookProvider(ctx context.Context, req *admin_pb.UpdateNotificationWebhookProviderRequest) (*admin_pb.UpdateNotificationWebhookProviderResponse, error) {
+	result, err := s.command.ChangeNotificationWebhookProvider(ctx, updateNotificationWebhookProviderToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateNotificationWebhookProviderResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) RemoveNotificationWebhookProvider(ctx context.Context, req *admin_pb.RemoveNotificationWebhookProviderRequest) (*admin_pb.RemoveNotificationWebhookProviderResponse, error) {
+	result, err := s.command.RemoveNotificationWebhookProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveNotificationWebhookProviderResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
@@ -0,0 +1,24 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func addNotificationWebhookProviderToConfig(req *admin_pb.AddNotificationWebhookProviderRequest) *webhook.Config {
+	return &webhook.Config{
+		CallURL:    req.CallUrl,
+		Method:     req.Method,
+		Headers:    req.Headers,
+		SigningKey: req.SigningKey,
+	}
+}
+
+func updateNotificationWebhookProviderToConfig(req *admin_pb.UpdateNotificationWebhookProviderRequest) *webhook.Config {
+	return &webhook.Config{
+		CallURL:    req.CallUrl,
+		Method:     req.Method,
+		Headers:    req.Headers,
+		SigningKey: req.SigningKey,
+	}
+}
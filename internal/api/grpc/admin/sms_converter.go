@@ -3,6 +3,7 @@ package admin
 import (
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/query"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
@@ -41,6 +42,9 @@ func SMSConfigToPb(config *query.SMSConfig) settings_pb.SMSConfig {
 	if config.TwilioConfig != nil {
 		return TwilioConfigToPb(config.TwilioConfig)
 	}
+	if config.HTTPConfig != nil {
+		return HTTPConfigToPb(config.HTTPConfig)
+	}
 	return nil
 }
 
@@ -53,6 +57,19 @@ func TwilioConfigToPb(twilio *query.Twilio) *settings_pb.SMSProvider_Twilio {
 	}
 }
 
+func HTTPConfigToPb(http *query.HTTP) *settings_pb.SMSProvider_Http {
+	return &settings_pb.SMSProvider_Http{
+		Http: &settings_pb.HTTPConfig{
+			Endpoint:     http.Endpoint,
+			Method:       http.Method,
+			Headers:      http.Headers,
+			BodyTemplate: http.BodyTemplate,
+			Username:     http.Username,
+			APIKeyHeader: http.APIKeyHeader,
+		},
+	}
+}
+
 func smsStateToPb(state domain.SMSConfigState) settings_pb.SMSProviderConfigState {
 	switch state {
 	case domain.SMSConfigStateInactive:
@@ -78,3 +95,29 @@ func UpdateSMSConfigTwilioToConfig(req *admin_pb.UpdateSMSProviderTwilioRequest)
 		SenderNumber: req.SenderNumber,
 	}
 }
+
+func AddSMSConfigHTTPToConfig(req *admin_pb.AddSMSProviderHTTPRequest) *httpsms.Config {
+	return &httpsms.Config{
+		Endpoint:     req.Endpoint,
+		Method:       req.Method,
+		Headers:      req.Headers,
+		BodyTemplate: req.BodyTemplate,
+		Username:     req.Username,
+		Password:     req.Password,
+		APIKeyHeader: req.ApiKeyHeader,
+		APIKey:       req.ApiKey,
+	}
+}
+
+func UpdateSMSConfigHTTPToConfig(req *admin_pb.UpdateSMSProviderHTTPRequest) *httpsms.Config {
+	return &httpsms.Config{
+		Endpoint:     req.Endpoint,
+		Method:       req.Method,
+		Headers:      req.Headers,
+		BodyTemplate: req.BodyTemplate,
+		Username:     req.Username,
+		Password:     req.Password,
+		APIKeyHeader: req.ApiKeyHeader,
+		APIKey:       req.ApiKey,
+	}
+}
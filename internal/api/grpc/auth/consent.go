@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	auth_pb "github.com/zitadel/zitadel/pkg/grpc/auth"
+	user_pb "github.com/zitadel/zitadel/pkg/grpc/user"
+)
+
+func (s *Server) ListMyConsents(ctx context.Context, req *auth_pb.ListMyConsentsRequest) (*auth_pb.ListMyConsentsResponse, error) {
+	queries, err := ListMyConsentsRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchUserConsents(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &auth_pb.ListMyConsentsResponse{
+		Result:  ConsentsToPb(res.Consents),
+		Details: object.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+	}, nil
+}
+
+func (s *Server) RevokeMyConsent(ctx context.Context, req *auth_pb.RevokeMyConsentRequest) (*auth_pb.RevokeMyConsentResponse, error) {
+	ctxData := authz.GetCtxData(ctx)
+	tokens, err := s.repo.SearchMyRefreshTokens(ctx, ctxData.UserID, ListMyRefreshTokensRequestToModel(nil))
+	if err != nil {
+		return nil, err
+	}
+	tokenIDs := make([]string, 0, len(tokens.Result))
+	for _, token := range tokens.Result {
+		if token.ClientID == req.ClientId {
+			tokenIDs = append(tokenIDs, token.ID)
+		}
+	}
+	details, err := s.command.RevokeHumanConsent(ctx, ctxData.UserID, ctxData.ResourceOwner, req.ClientId, tokenIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &auth_pb.RevokeMyConsentResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func ListMyConsentsRequestToQuery(ctx context.Context, req *auth_pb.ListMyConsentsRequest) (*query.UserConsentSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	userIDQuery, err := query.NewUserConsentUserIDSearchQuery(authz.GetCtxData(ctx).UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &query.UserConsentSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: []query.SearchQuery{
+			userIDQuery,
+		},
+	}, nil
+}
+
+func ConsentsToPb(consents []*query.UserConsent) []*user_pb.Consent {
+	result := make([]*user_pb.Consent, len(consents))
+	for i, consent := range consents {
+		result[i] = &user_pb.Consent{
+			Details:     object.ToViewDetailsPb(consent.Sequence, consent.CreationDate, consent.ChangeDate, consent.ResourceOwner),
+			ClientId:    consent.ClientID,
+			AppId:       consent.AppID,
+			AppName:     consent.AppName,
+			ProjectId:   consent.ProjectID,
+			ProjectName: consent.ProjectName,
+			Scopes:      consent.Scopes,
+		}
+	}
+	return result
+}
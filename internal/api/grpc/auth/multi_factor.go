@@ -113,3 +113,58 @@ func (s *Server) RemoveMyAuthFactorU2F(ctx context.Context, req *auth_pb.RemoveM
 		Details: object.DomainToChangeDetailsPb(objectDetails),
 	}, nil
 }
+
+func (s *Server) AddMyAuthFactorPush(ctx context.Context, req *auth_pb.AddMyAuthFactorPushRequest) (*auth_pb.AddMyAuthFactorPushResponse, error) {
+	ctxData := authz.GetCtxData(ctx)
+	device, err := s.command.AddHumanPushDevice(ctx, ctxData.UserID, ctxData.ResourceOwner, &domain.PushDevice{
+		Name:      req.Name,
+		Platform:  user_grpc.PushPlatformToDomain(req.Platform),
+		Token:     req.Token,
+		PublicKey: req.PublicKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &auth_pb.AddMyAuthFactorPushResponse{
+		DeviceId:         device.DeviceID,
+		VerificationCode: device.VerificationCode,
+		Details: object.AddToDetailsPb(
+			device.Sequence,
+			device.ChangeDate,
+			device.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) VerifyMyAuthFactorPush(ctx context.Context, req *auth_pb.VerifyMyAuthFactorPushRequest) (*auth_pb.VerifyMyAuthFactorPushResponse, error) {
+	ctxData := authz.GetCtxData(ctx)
+	objectDetails, err := s.command.VerifyHumanPushDevice(ctx, ctxData.UserID, ctxData.ResourceOwner, req.DeviceId, req.Signature)
+	if err != nil {
+		return nil, err
+	}
+	return &auth_pb.VerifyMyAuthFactorPushResponse{
+		Details: object.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
+
+func (s *Server) RemoveMyAuthFactorPush(ctx context.Context, req *auth_pb.RemoveMyAuthFactorPushRequest) (*auth_pb.RemoveMyAuthFactorPushResponse, error) {
+	ctxData := authz.GetCtxData(ctx)
+	objectDetails, err := s.command.RemoveHumanPushDevice(ctx, ctxData.UserID, req.DeviceId, ctxData.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return &auth_pb.RemoveMyAuthFactorPushResponse{
+		Details: object.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
+
+func (s *Server) AnswerMyPushChallenge(ctx context.Context, req *auth_pb.AnswerMyPushChallengeRequest) (*auth_pb.AnswerMyPushChallengeResponse, error) {
+	ctxData := authz.GetCtxData(ctx)
+	objectDetails, err := s.command.AnswerHumanPushChallenge(ctx, ctxData.UserID, ctxData.ResourceOwner, req.ChallengeId, req.DeviceId, req.Approve, req.Signature)
+	if err != nil {
+		return nil, err
+	}
+	return &auth_pb.AnswerMyPushChallengeResponse{
+		Details: object.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/grpc/user"
+	auth_pb "github.com/zitadel/zitadel/pkg/grpc/auth"
+)
+
+func (s *Server) GetMyNotificationPreferences(ctx context.Context, _ *auth_pb.GetMyNotificationPreferencesRequest) (*auth_pb.GetMyNotificationPreferencesResponse, error) {
+	ctxData := authz.GetCtxData(ctx)
+	preferences, err := s.query.NotificationPreferencesByUserID(ctx, ctxData.UserID, ctxData.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return &auth_pb.GetMyNotificationPreferencesResponse{
+		Preferences: user.NotificationPreferencesToPb(preferences),
+		Details: object.ToViewDetailsPb(
+			preferences.Sequence,
+			preferences.CreationDate,
+			preferences.ChangeDate,
+			preferences.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) SetMyNotificationPreferences(ctx context.Context, req *auth_pb.SetMyNotificationPreferencesRequest) (*auth_pb.SetMyNotificationPreferencesResponse, error) {
+	ctxData := authz.GetCtxData(ctx)
+	objectDetails, err := s.command.SetHumanNotificationPreferences(ctx, ctxData.UserID, ctxData.ResourceOwner, user.NotificationPreferencesToDomain(req.GetPreferences()))
+	if err != nil {
+		return nil, err
+	}
+	return &auth_pb.SetMyNotificationPreferencesResponse{
+		Details: object.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	grpc_utils "github.com/zitadel/zitadel/internal/api/grpc"
+)
+
+// GatewayClient marks the requests of the gateway, so the grpc server can trust the information of the original http request
+func GatewayClient() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(grpc_utils.SetGatewaySecret(ctx), method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+
+	"github.com/zitadel/logging"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/zitadel/zitadel/internal/api/http"
+)
+
+// gatewaySecret is generated on startup and only known by the gateway of this process
+var gatewaySecret = newGatewaySecret()
+
+func newGatewaySecret() string {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	logging.OnError(err).Fatal("unable to generate gateway secret")
+	return base64.RawURLEncoding.EncodeToString(secret)
+}
+
+// SetGatewaySecret marks the outgoing request as request of the gateway,
+// a value of the header passed by the client is overwritten
+func SetGatewaySecret(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Set(http.ZitadelGateway, gatewaySecret)
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// IsGatewayRequest returns true if the request was passed by the gateway of this process
+func IsGatewayRequest(ctx context.Context) bool {
+	return subtle.ConstantTimeCompare([]byte(GetHeader(ctx, http.ZitadelGateway)), []byte(gatewaySecret)) == 1
+}
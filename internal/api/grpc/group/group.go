@@ -0,0 +1,106 @@
+package group
+
+import (
+	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	group_pb "github.com/zitadel/zitadel/pkg/grpc/group"
+)
+
+func GroupsToPb(groups []*query.Group) []*group_pb.Group {
+	list := make([]*group_pb.Group, len(groups))
+	for i, group := range groups {
+		list[i] = GroupToPb(group)
+	}
+	return list
+}
+
+func GroupToPb(group *query.Group) *group_pb.Group {
+	return &group_pb.Group{
+		Id:          group.ID,
+		Details:     object_grpc.ToViewDetailsPb(group.Sequence, group.CreationDate, group.ChangeDate, group.ResourceOwner),
+		State:       GroupStateToPb(group.State),
+		Name:        group.Name,
+		Description: group.Description,
+	}
+}
+
+func GroupStateToPb(state domain.GroupState) group_pb.GroupState {
+	switch state {
+	case domain.GroupStateActive:
+		return group_pb.GroupState_GROUP_STATE_ACTIVE
+	default:
+		return group_pb.GroupState_GROUP_STATE_UNSPECIFIED
+	}
+}
+
+func GroupMembersToPb(resourceOwner string, members []*query.GroupMember) []*group_pb.GroupMember {
+	list := make([]*group_pb.GroupMember, len(members))
+	for i, member := range members {
+		list[i] = GroupMemberToPb(resourceOwner, member)
+	}
+	return list
+}
+
+func GroupMemberToPb(resourceOwner string, member *query.GroupMember) *group_pb.GroupMember {
+	return &group_pb.GroupMember{
+		UserId:             member.UserID,
+		Details:            object_grpc.ToViewDetailsPb(member.Sequence, member.CreationDate, member.CreationDate, resourceOwner),
+		PreferredLoginName: member.PreferredLoginName,
+		DisplayName:        member.DisplayName,
+		Type:               user_grpc.TypeToPb(member.UserType),
+	}
+}
+
+func GroupGrantsToPb(resourceOwner string, grants []*query.GroupGrant) []*group_pb.GroupGrant {
+	list := make([]*group_pb.GroupGrant, len(grants))
+	for i, grant := range grants {
+		list[i] = GroupGrantToPb(resourceOwner, grant)
+	}
+	return list
+}
+
+func GroupGrantToPb(resourceOwner string, grant *query.GroupGrant) *group_pb.GroupGrant {
+	return &group_pb.GroupGrant{
+		Id:             grant.ID,
+		Details:        object_grpc.ToViewDetailsPb(grant.Sequence, grant.CreationDate, grant.ChangeDate, resourceOwner),
+		ProjectId:      grant.ProjectID,
+		ProjectName:    grant.ProjectName,
+		ProjectGrantId: grant.ProjectGrantID,
+		RoleKeys:       grant.Roles,
+		State:          GroupGrantStateToPb(grant.State),
+	}
+}
+
+func GroupGrantStateToPb(state domain.GroupGrantState) group_pb.GroupGrantState {
+	switch state {
+	case domain.GroupGrantStateActive:
+		return group_pb.GroupGrantState_GROUP_GRANT_STATE_ACTIVE
+	case domain.GroupGrantStateInactive:
+		return group_pb.GroupGrantState_GROUP_GRANT_STATE_INACTIVE
+	default:
+		return group_pb.GroupGrantState_GROUP_GRANT_STATE_UNSPECIFIED
+	}
+}
+
+func GroupQueryToQuery(q *group_pb.GroupQuery) (query.SearchQuery, error) {
+	switch q := q.Query.(type) {
+	case *group_pb.GroupQuery_NameQuery:
+		return query.NewGroupNameSearchQuery(object_grpc.TextMethodToQuery(q.NameQuery.Method), q.NameQuery.Name)
+	case *group_pb.GroupQuery_MemberQuery:
+		return query.NewGroupMemberUserIDSearchQuery(q.MemberQuery.UserId)
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "GROUP-Vb8sk", "List.Query.Invalid")
+	}
+}
+
+func GroupGrantQueryToQuery(q *group_pb.GroupGrantQuery) (query.SearchQuery, error) {
+	switch q := q.Query.(type) {
+	case *group_pb.GroupGrantQuery_ProjectIdQuery:
+		return query.NewGroupGrantProjectIDSearchQuery(q.ProjectIdQuery.ProjectId)
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "GROUP-Ma2ft", "List.Query.Invalid")
+	}
+}
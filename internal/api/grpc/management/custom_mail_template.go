@@ -0,0 +1,65 @@
+package management
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	text_grpc "github.com/zitadel/zitadel/internal/api/grpc/text"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetCustomMailTemplate(ctx context.Context, req *mgmt_pb.GetCustomMailTemplateRequest) (*mgmt_pb.GetCustomMailTemplateResponse, error) {
+	template, err := s.query.CustomMailTemplateByOrg(ctx, authz.GetCtxData(ctx).OrgID, req.MessageType)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCustomMailTemplateResponse{
+		Template: text_grpc.CustomMailTemplateToPb(template, s.assetAPIPrefix(ctx)),
+	}, nil
+}
+
+func (s *Server) SetCustomMailTemplate(ctx context.Context, req *mgmt_pb.SetCustomMailTemplateRequest) (*mgmt_pb.SetCustomMailTemplateResponse, error) {
+	result, err := s.command.SetOrgCustomMailTemplate(ctx, authz.GetCtxData(ctx).OrgID, SetCustomMailTemplateToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomMailTemplateResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomMailTemplateToDefault(ctx context.Context, req *mgmt_pb.ResetCustomMailTemplateToDefaultRequest) (*mgmt_pb.ResetCustomMailTemplateToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgCustomMailTemplate(ctx, authz.GetCtxData(ctx).OrgID, req.MessageType)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomMailTemplateToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) PreviewMessage(ctx context.Context, req *mgmt_pb.PreviewMessageRequest) (*mgmt_pb.PreviewMessageResponse, error) {
+	lang := language.Make(req.Language)
+	if req.Language == "" {
+		lang = authz.GetInstance(ctx).DefaultLanguage()
+	}
+	subject, content, err := s.messagePreviewer.PreviewMessage(ctx, authz.GetCtxData(ctx).OrgID, req.MessageType, lang, req.Template)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.PreviewMessageResponse{
+		Subject: subject,
+		Html:    content,
+	}, nil
+}
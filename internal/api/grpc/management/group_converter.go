@@ -0,0 +1,118 @@
+package management
+
+import (
+	group_grpc "github.com/zitadel/zitadel/internal/api/grpc/group"
+	"github.com/zitadel/zitadel/internal/api/grpc/metadata"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func addGroupRequestToDomain(req *mgmt_pb.AddGroupRequest) *domain.Group {
+	return &domain.Group{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+}
+
+func updateGroupRequestToDomain(req *mgmt_pb.UpdateGroupRequest) *domain.Group {
+	return &domain.Group{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.Id,
+		},
+		Name:        req.Name,
+		Description: req.Description,
+	}
+}
+
+func addGroupGrantRequestToDomain(req *mgmt_pb.AddGroupGrantRequest) *domain.GroupGrant {
+	return &domain.GroupGrant{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.Id,
+		},
+		ProjectID:      req.ProjectId,
+		ProjectGrantID: req.ProjectGrantId,
+		RoleKeys:       req.RoleKeys,
+	}
+}
+
+func updateGroupGrantRequestToDomain(req *mgmt_pb.UpdateGroupGrantRequest) *domain.GroupGrant {
+	return &domain.GroupGrant{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.Id,
+		},
+		GrantID:  req.GrantId,
+		RoleKeys: req.RoleKeys,
+	}
+}
+
+func listGroupsRequestToQuery(orgID string, req *mgmt_pb.ListGroupsRequest) (_ *query.GroupSearchQueries, err error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries := make([]query.SearchQuery, len(req.Queries)+1)
+	queries[0], err = query.NewGroupResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	for i, groupQuery := range req.Queries {
+		queries[i+1], err = group_grpc.GroupQueryToQuery(groupQuery)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &query.GroupSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func listGroupMembersRequestToQuery(req *mgmt_pb.ListGroupMembersRequest) *query.GroupSubSearchQueries {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.GroupSubSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+	}
+}
+
+func listGroupMetadataRequestToQuery(req *mgmt_pb.ListGroupMetadataRequest) (*query.GroupSubSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := metadata.GroupMetadataQueriesToQuery(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	return &query.GroupSubSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func listGroupGrantsRequestToQuery(req *mgmt_pb.ListGroupGrantsRequest) (_ *query.GroupSubSearchQueries, err error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries := make([]query.SearchQuery, len(req.Queries))
+	for i, grantQuery := range req.Queries {
+		queries[i], err = group_grpc.GroupGrantQueryToQuery(grantQuery)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &query.GroupSubSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
@@ -91,6 +91,26 @@ func (s *Server) AddOrg(ctx context.Context, req *mgmt_pb.AddOrgRequest) (*mgmt_
 	}, err
 }
 
+func (s *Server) AddChildOrg(ctx context.Context, req *mgmt_pb.AddChildOrgRequest) (*mgmt_pb.AddChildOrgResponse, error) {
+	userIDs, err := s.getClaimedUserIDsOfOrgDomain(ctx, domain.NewIAMDomainName(req.Name, authz.GetInstance(ctx).RequestedDomain()), "")
+	if err != nil {
+		return nil, err
+	}
+	ctxData := authz.GetCtxData(ctx)
+	org, err := s.command.AddChildOrg(ctx, ctxData.OrgID, req.Name, ctxData.UserID, ctxData.ResourceOwner, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddChildOrgResponse{
+		Id: org.AggregateID,
+		Details: object.AddToDetailsPb(
+			org.Sequence,
+			org.ChangeDate,
+			org.ResourceOwner,
+		),
+	}, nil
+}
+
 func (s *Server) UpdateOrg(ctx context.Context, req *mgmt_pb.UpdateOrgRequest) (*mgmt_pb.UpdateOrgResponse, error) {
 	ctxData := authz.GetCtxData(ctx)
 	org, err := s.command.ChangeOrg(ctx, ctxData.OrgID, req.Name)
@@ -246,6 +266,37 @@ func (s *Server) SetPrimaryOrgDomain(ctx context.Context, req *mgmt_pb.SetPrimar
 	}, nil
 }
 
+func (s *Server) ListOrgLoginDomains(ctx context.Context, _ *mgmt_pb.ListOrgLoginDomainsRequest) (*mgmt_pb.ListOrgLoginDomainsResponse, error) {
+	domains, err := s.query.OrgLoginDomainsByOrgID(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListOrgLoginDomainsResponse{
+		Result:  org_grpc.LoginDomainsToPb(domains.Domains),
+		Details: object.ToListDetails(domains.Count, domains.Sequence, domains.Timestamp),
+	}, nil
+}
+
+func (s *Server) AddOrgLoginDomain(ctx context.Context, req *mgmt_pb.AddOrgLoginDomainRequest) (*mgmt_pb.AddOrgLoginDomainResponse, error) {
+	details, err := s.command.AddOrgLoginDomain(ctx, AddOrgLoginDomainRequestToDomain(ctx, req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddOrgLoginDomainResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveOrgLoginDomain(ctx context.Context, req *mgmt_pb.RemoveOrgLoginDomainRequest) (*mgmt_pb.RemoveOrgLoginDomainResponse, error) {
+	details, err := s.command.RemoveOrgLoginDomain(ctx, RemoveOrgLoginDomainRequestToDomain(ctx, req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveOrgLoginDomainResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) ListOrgMemberRoles(ctx context.Context, _ *mgmt_pb.ListOrgMemberRolesRequest) (*mgmt_pb.ListOrgMemberRolesResponse, error) {
 	instance, err := s.query.Instance(ctx, false)
 	if err != nil {
@@ -49,6 +49,24 @@ func RemoveOrgDomainRequestToDomain(ctx context.Context, req *mgmt_pb.RemoveOrgD
 	}
 }
 
+func AddOrgLoginDomainRequestToDomain(ctx context.Context, req *mgmt_pb.AddOrgLoginDomainRequest) *domain.OrgDomain {
+	return &domain.OrgDomain{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: authz.GetCtxData(ctx).OrgID,
+		},
+		Domain: req.Domain,
+	}
+}
+
+func RemoveOrgLoginDomainRequestToDomain(ctx context.Context, req *mgmt_pb.RemoveOrgLoginDomainRequest) *domain.OrgDomain {
+	return &domain.OrgDomain{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: authz.GetCtxData(ctx).OrgID,
+		},
+		Domain: req.Domain,
+	}
+}
+
 func ValidateOrgDomainRequestToDomain(ctx context.Context, req *mgmt_pb.ValidateOrgDomainRequest) *domain.OrgDomain {
 	return &domain.OrgDomain{
 		ObjectRoot: models.ObjectRoot{
@@ -0,0 +1,28 @@
+package management
+
+import (
+	"github.com/zitadel/zitadel/internal/command"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func addNotificationPolicyToCommand(req *mgmt_pb.AddCustomNotificationPolicyRequest) *command.NotificationPolicy {
+	return &command.NotificationPolicy{
+		PasswordChange: req.GetPasswordChange(),
+		NewDeviceLogin: req.GetNewDeviceLogin(),
+		MFAAdded:       req.GetMfaAdded(),
+		MFARemoved:     req.GetMfaRemoved(),
+		EmailChange:    req.GetEmailChange(),
+		AccountLocked:  req.GetAccountLocked(),
+	}
+}
+
+func updateNotificationPolicyToCommand(req *mgmt_pb.UpdateCustomNotificationPolicyRequest) *command.NotificationPolicy {
+	return &command.NotificationPolicy{
+		PasswordChange: req.GetPasswordChange(),
+		NewDeviceLogin: req.GetNewDeviceLogin(),
+		MFAAdded:       req.GetMfaAdded(),
+		MFARemoved:     req.GetMfaRemoved(),
+		EmailChange:    req.GetEmailChange(),
+		AccountLocked:  req.GetAccountLocked(),
+	}
+}
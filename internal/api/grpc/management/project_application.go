@@ -307,3 +307,26 @@ func (s *Server) RemoveAppKey(ctx context.Context, req *mgmt_pb.RemoveAppKeyRequ
 		Details: object_grpc.DomainToChangeDetailsPb(details),
 	}, nil
 }
+
+func (s *Server) AddProjectRegistrationToken(ctx context.Context, req *mgmt_pb.AddProjectRegistrationTokenRequest) (*mgmt_pb.AddProjectRegistrationTokenResponse, error) {
+	token := AddProjectRegistrationTokenRequestToCommand(req, authz.GetCtxData(ctx).OrgID)
+	details, err := s.command.AddProjectRegistrationToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddProjectRegistrationTokenResponse{
+		TokenId: token.TokenID,
+		Token:   token.Token,
+		Details: object_grpc.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveProjectRegistrationToken(ctx context.Context, req *mgmt_pb.RemoveProjectRegistrationTokenRequest) (*mgmt_pb.RemoveProjectRegistrationTokenResponse, error) {
+	details, err := s.command.RemoveProjectRegistrationToken(ctx, req.ProjectId, req.TokenId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveProjectRegistrationTokenResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -8,6 +8,7 @@ import (
 	authn_grpc "github.com/zitadel/zitadel/internal/api/grpc/authn"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	app_grpc "github.com/zitadel/zitadel/internal/api/grpc/project"
+	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/query"
@@ -57,6 +58,7 @@ func AddOIDCAppRequestToDomain(req *mgmt_pb.AddOIDCAppRequest) *domain.OIDCApp {
 		ClockSkew:                req.ClockSkew.AsDuration(),
 		AdditionalOrigins:        req.AdditionalOrigins,
 		SkipNativeAppSuccessPage: req.SkipNativeAppSuccessPage,
+		RequireConsent:           req.RequireConsent,
 	}
 }
 
@@ -65,9 +67,16 @@ func AddSAMLAppRequestToDomain(req *mgmt_pb.AddSAMLAppRequest) *domain.SAMLApp {
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: req.ProjectId,
 		},
-		AppName:     req.Name,
-		Metadata:    req.GetMetadataXml(),
-		MetadataURL: req.GetMetadataUrl(),
+		AppName:            req.Name,
+		Metadata:           req.GetMetadataXml(),
+		MetadataURL:        req.GetMetadataUrl(),
+		IDPInitiatedSSO:    req.IdpInitiatedSso,
+		DefaultRelayState:  req.DefaultRelayState,
+		SignatureAlgorithm: app_grpc.SAMLSignatureAlgorithmToDomain(req.SignatureAlgorithm),
+		SigningMode:        app_grpc.SAMLSigningModeToDomain(req.SigningMode),
+		EncryptAssertion:   req.EncryptAssertion,
+		NameIDFormat:       app_grpc.SAMLNameIDFormatToDomain(req.NameIdFormat),
+		AttributeMappings:  app_grpc.SAMLAttributeMappingsToDomain(req.AttributeMappings),
 	}
 }
 
@@ -108,6 +117,7 @@ func UpdateOIDCAppConfigRequestToDomain(app *mgmt_pb.UpdateOIDCAppConfigRequest)
 		ClockSkew:                app.ClockSkew.AsDuration(),
 		AdditionalOrigins:        app.AdditionalOrigins,
 		SkipNativeAppSuccessPage: app.SkipNativeAppSuccessPage,
+		RequireConsent:           app.RequireConsent,
 	}
 }
 
@@ -116,9 +126,16 @@ func UpdateSAMLAppConfigRequestToDomain(app *mgmt_pb.UpdateSAMLAppConfigRequest)
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: app.ProjectId,
 		},
-		AppID:       app.AppId,
-		Metadata:    app.GetMetadataXml(),
-		MetadataURL: app.GetMetadataUrl(),
+		AppID:              app.AppId,
+		Metadata:           app.GetMetadataXml(),
+		MetadataURL:        app.GetMetadataUrl(),
+		IDPInitiatedSSO:    app.IdpInitiatedSso,
+		DefaultRelayState:  app.DefaultRelayState,
+		SignatureAlgorithm: app_grpc.SAMLSignatureAlgorithmToDomain(app.SignatureAlgorithm),
+		SigningMode:        app_grpc.SAMLSigningModeToDomain(app.SigningMode),
+		EncryptAssertion:   app.EncryptAssertion,
+		NameIDFormat:       app_grpc.SAMLNameIDFormatToDomain(app.NameIdFormat),
+		AttributeMappings:  app_grpc.SAMLAttributeMappingsToDomain(app.AttributeMappings),
 	}
 }
 
@@ -132,6 +149,14 @@ func UpdateAPIAppConfigRequestToDomain(app *mgmt_pb.UpdateAPIAppConfigRequest) *
 	}
 }
 
+func AddProjectRegistrationTokenRequestToCommand(req *mgmt_pb.AddProjectRegistrationTokenRequest, resourceOwner string) *command.ProjectRegistrationToken {
+	expirationDate := time.Time{}
+	if req.ExpirationDate != nil {
+		expirationDate = req.ExpirationDate.AsTime()
+	}
+	return command.NewProjectRegistrationToken(req.ProjectId, resourceOwner, expirationDate)
+}
+
 func AddAPIClientKeyRequestToDomain(key *mgmt_pb.AddAppKeyRequest) *domain.ApplicationKey {
 	expirationDate := time.Time{}
 	if key.ExpirationDate != nil {
@@ -34,6 +34,7 @@ func ProjectUpdateToDomain(req *mgmt_pb.UpdateProjectRequest) *domain.Project {
 		ProjectRoleCheck:       req.ProjectRoleCheck,
 		HasProjectCheck:        req.HasProjectCheck,
 		PrivateLabelingSetting: privateLabelingSettingToDomain(req.PrivateLabelingSetting),
+		AllowImpersonation:     req.AllowImpersonation,
 	}
 }
 
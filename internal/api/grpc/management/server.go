@@ -12,6 +12,7 @@ import (
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/notification"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/pkg/grpc/management"
 )
@@ -32,6 +33,7 @@ type Server struct {
 	userCodeAlg       crypto.EncryptionAlgorithm
 	externalSecure    bool
 	auditLogRetention time.Duration
+	messagePreviewer  *notification.MessagePreviewer
 }
 
 func CreateServer(
@@ -41,6 +43,7 @@ func CreateServer(
 	userCodeAlg crypto.EncryptionAlgorithm,
 	externalSecure bool,
 	auditLogRetention time.Duration,
+	messagePreviewer *notification.MessagePreviewer,
 ) *Server {
 	return &Server{
 		command:           command,
@@ -51,6 +54,7 @@ func CreateServer(
 		userCodeAlg:       userCodeAlg,
 		externalSecure:    externalSecure,
 		auditLogRetention: auditLogRetention,
+		messagePreviewer:  messagePreviewer,
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	"github.com/zitadel/oidc/v2/pkg/oidc"
 	"golang.org/x/text/language"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/authn"
@@ -14,6 +15,7 @@ import (
 	idp_grpc "github.com/zitadel/zitadel/internal/api/grpc/idp"
 	"github.com/zitadel/zitadel/internal/api/grpc/metadata"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	settings_grpc "github.com/zitadel/zitadel/internal/api/grpc/settings"
 	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
 	"github.com/zitadel/zitadel/internal/api/http"
 	z_oidc "github.com/zitadel/zitadel/internal/api/oidc"
@@ -868,6 +870,54 @@ func (s *Server) RemovePersonalAccessToken(ctx context.Context, req *mgmt_pb.Rem
 	}, nil
 }
 
+func (s *Server) ListUserNotificationDeliveries(ctx context.Context, req *mgmt_pb.ListUserNotificationDeliveriesRequest) (*mgmt_pb.ListUserNotificationDeliveriesResponse, error) {
+	queries, err := ListUserNotificationDeliveriesRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.query.SearchNotificationDeliveries(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListUserNotificationDeliveriesResponse{
+		Result:  settings_grpc.NotificationDeliveriesToPb(result.Deliveries),
+		Details: obj_grpc.ToListDetails(result.Count, result.Sequence, result.Timestamp),
+	}, nil
+}
+
+func (s *Server) ResendUserNotification(ctx context.Context, req *mgmt_pb.ResendUserNotificationRequest) (*mgmt_pb.ResendUserNotificationResponse, error) {
+	objectDetails, err := s.command.ResendNotification(ctx, authz.GetCtxData(ctx).OrgID, req.UserId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResendUserNotificationResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
+
+func (s *Server) ImpersonateUser(ctx context.Context, req *mgmt_pb.ImpersonateUserRequest) (*mgmt_pb.ImpersonateUserResponse, error) {
+	token, err := s.command.StartImpersonation(ctx, ImpersonateUserRequestToCommand(req, authz.GetCtxData(ctx).OrgID))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ImpersonateUserResponse{
+		TokenId:        token.TokenID,
+		Token:          token.Token,
+		ExpirationDate: timestamppb.New(token.Expiration),
+		Details:        obj_grpc.DomainToAddDetailsPb(token.ObjectDetails),
+	}, nil
+}
+
+func (s *Server) EndUserImpersonation(ctx context.Context, req *mgmt_pb.EndUserImpersonationRequest) (*mgmt_pb.EndUserImpersonationResponse, error) {
+	objectDetails, err := s.command.EndImpersonation(ctx, req.UserId, authz.GetCtxData(ctx).OrgID, req.TokenId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.EndUserImpersonationResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
+
 func (s *Server) ListHumanLinkedIDPs(ctx context.Context, req *mgmt_pb.ListHumanLinkedIDPsRequest) (*mgmt_pb.ListHumanLinkedIDPsResponse, error) {
 	queries, err := ListHumanLinkedIDPsRequestToQuery(ctx, req)
 	if err != nil {
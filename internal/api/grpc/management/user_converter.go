@@ -281,6 +281,16 @@ func RemovePersonalAccessTokenRequestToCommand(req *mgmt_pb.RemovePersonalAccess
 	}
 }
 
+func ImpersonateUserRequestToCommand(req *mgmt_pb.ImpersonateUserRequest, resourceOwner string) *command.Impersonation {
+	return &command.Impersonation{
+		UserID:        req.UserId,
+		ResourceOwner: resourceOwner,
+		ProjectID:     req.ProjectId,
+		Reason:        req.Reason,
+		Lifetime:      req.Lifetime.AsDuration(),
+	}
+}
+
 func ListPersonalAccessTokensRequestToQuery(ctx context.Context, req *mgmt_pb.ListPersonalAccessTokensRequest) (*query.PersonalAccessTokenSearchQueries, error) {
 	resourceOwner, err := query.NewPersonalAccessTokenResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
 	if err != nil {
@@ -305,6 +315,30 @@ func ListPersonalAccessTokensRequestToQuery(ctx context.Context, req *mgmt_pb.Li
 
 }
 
+func ListUserNotificationDeliveriesRequestToQuery(ctx context.Context, req *mgmt_pb.ListUserNotificationDeliveriesRequest) (*query.NotificationDeliverySearchQueries, error) {
+	resourceOwner, err := query.NewNotificationDeliveryResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := query.NewNotificationDeliveryUserIDSearchQuery(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.NotificationDeliverySearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.NotificationOutboxColumnCreationDate,
+		},
+		Queries: []query.SearchQuery{
+			resourceOwner,
+			userID,
+		},
+	}, nil
+}
+
 func RemoveHumanLinkedIDPRequestToDomain(ctx context.Context, req *mgmt_pb.RemoveHumanLinkedIDPRequest) *domain.UserIDPLink {
 	return &domain.UserIDPLink{
 		ObjectRoot: models.ObjectRoot{
@@ -72,3 +72,40 @@ func MetadataQueryToQuery(query *meta_pb.MetadataQuery) (query.SearchQuery, erro
 func MetadataKeyQueryToQuery(q *meta_pb.MetadataKeyQuery) (query.SearchQuery, error) {
 	return query.NewUserMetadataKeySearchQuery(q.Key, object.TextMethodToQuery(q.Method))
 }
+
+func GroupMetadataListToPb(resourceOwner string, dataList []*query.GroupMetadata) []*meta_pb.Metadata {
+	mds := make([]*meta_pb.Metadata, len(dataList))
+	for i, data := range dataList {
+		mds[i] = GroupMetadataToPb(resourceOwner, data)
+	}
+	return mds
+}
+
+func GroupMetadataToPb(resourceOwner string, data *query.GroupMetadata) *meta_pb.Metadata {
+	return &meta_pb.Metadata{
+		Key:   data.Key,
+		Value: data.Value,
+		Details: object.ToViewDetailsPb(
+			data.Sequence,
+			data.CreationDate,
+			data.ChangeDate,
+			resourceOwner,
+		),
+	}
+}
+
+func GroupMetadataQueriesToQuery(queries []*meta_pb.MetadataQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, metadataQuery := range queries {
+		switch mq := metadataQuery.Query.(type) {
+		case *meta_pb.MetadataQuery_KeyQuery:
+			q[i], err = query.NewGroupMetadataKeySearchQuery(mq.KeyQuery.Key, object.TextMethodToQuery(mq.KeyQuery.Method))
+		default:
+			err = errors.ThrowInvalidArgument(nil, "METAD-Hk3xq", "List.Query.Invalid")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
@@ -27,6 +27,10 @@ func OrgQueryToModel(apiQuery *org_pb.OrgQuery) (query.SearchQuery, error) {
 		return query.NewOrgNameSearchQuery(object.TextMethodToQuery(q.NameQuery.Method), q.NameQuery.Name)
 	case *org_pb.OrgQuery_StateQuery:
 		return query.NewOrgStateSearchQuery(OrgStateToDomain(q.StateQuery.State))
+	case *org_pb.OrgQuery_ParentIdQuery:
+		return query.NewOrgParentIDSearchQuery(q.ParentIdQuery.ParentOrgId)
+	case *org_pb.OrgQuery_DescendantsQuery:
+		return query.NewOrgDescendantsSearchQuery(q.DescendantsQuery.OrgId)
 	default:
 		return nil, errors.ThrowInvalidArgument(nil, "ORG-vR9nC", "List.Query.Invalid")
 	}
@@ -51,6 +55,10 @@ func OrgQueryToQuery(search *org_pb.OrgQuery) (query.SearchQuery, error) {
 		return query.NewOrgNameSearchQuery(object.TextMethodToQuery(q.NameQuery.Method), q.NameQuery.Name)
 	case *org_pb.OrgQuery_StateQuery:
 		return query.NewOrgStateSearchQuery(OrgStateToDomain(q.StateQuery.State))
+	case *org_pb.OrgQuery_ParentIdQuery:
+		return query.NewOrgParentIDSearchQuery(q.ParentIdQuery.ParentOrgId)
+	case *org_pb.OrgQuery_DescendantsQuery:
+		return query.NewOrgDescendantsSearchQuery(q.DescendantsQuery.OrgId)
 	default:
 		return nil, errors.ThrowInvalidArgument(nil, "ADMIN-ADvsd", "List.Query.Invalid")
 	}
@@ -70,6 +78,7 @@ func OrgViewToPb(org *query.Org) *org_pb.Org {
 		State:         OrgStateToPb(org.State),
 		Name:          org.Name,
 		PrimaryDomain: org.Domain,
+		ParentOrgId:   org.ParentID,
 		Details: object.ToViewDetailsPb(
 			org.Sequence,
 			org.CreationDate,
@@ -92,6 +101,7 @@ func OrgToPb(org *query.Org) *org_pb.Org {
 		Id:            org.ID,
 		Name:          org.Name,
 		PrimaryDomain: org.Domain,
+		ParentOrgId:   org.ParentID,
 		Details:       object.ToViewDetailsPb(org.Sequence, org.CreationDate, org.ChangeDate, org.ResourceOwner),
 		State:         OrgStateToPb(org.State),
 	}
@@ -169,6 +179,27 @@ func DomainToPb(d *query.Domain) *org_pb.Domain {
 	}
 }
 
+func LoginDomainsToPb(domains []*query.OrgLoginDomain) []*org_pb.LoginDomain {
+	d := make([]*org_pb.LoginDomain, len(domains))
+	for i, domain := range domains {
+		d[i] = LoginDomainToPb(domain)
+	}
+	return d
+}
+
+func LoginDomainToPb(d *query.OrgLoginDomain) *org_pb.LoginDomain {
+	return &org_pb.LoginDomain{
+		OrgId:      d.OrgID,
+		DomainName: d.Domain,
+		Details: object.ToViewDetailsPb(
+			d.Sequence,
+			d.CreationDate,
+			d.ChangeDate,
+			d.OrgID,
+		),
+	}
+}
+
 func DomainValidationTypeToDomain(validationType org_pb.DomainValidationType) domain.OrgDomainValidationType {
 	switch validationType {
 	case org_pb.DomainValidationType_DOMAIN_VALIDATION_TYPE_HTTP:
@@ -24,6 +24,9 @@ func ModelLoginPolicyToPb(policy *query.LoginPolicy) *policy_pb.LoginPolicy {
 		AllowDomainDiscovery:       policy.AllowDomainDiscovery,
 		DisableLoginWithEmail:      policy.DisableLoginWithEmail,
 		DisableLoginWithPhone:      policy.DisableLoginWithPhone,
+		AllowMagicLink:             policy.AllowMagicLink,
+		AllowPhoneOTP:              policy.AllowPhoneOTP,
+		BotProtection:              ModelBotProtectionTypeToPb(policy.BotProtection),
 		DefaultRedirectUri:         policy.DefaultRedirectURI,
 		PasswordCheckLifetime:      durationpb.New(policy.PasswordCheckLifetime),
 		ExternalLoginCheckLifetime: durationpb.New(policy.ExternalLoginCheckLifetime),
@@ -63,3 +66,31 @@ func ModelPasswordlessTypeToPb(passwordlessType domain.PasswordlessType) policy_
 		return policy_pb.PasswordlessType_PASSWORDLESS_TYPE_NOT_ALLOWED
 	}
 }
+
+func BotProtectionTypeToDomain(botProtection policy_pb.BotProtectionType) domain.BotProtectionType {
+	switch botProtection {
+	case policy_pb.BotProtectionType_BOT_PROTECTION_TYPE_NONE:
+		return domain.BotProtectionTypeNone
+	case policy_pb.BotProtectionType_BOT_PROTECTION_TYPE_PROOF_OF_WORK:
+		return domain.BotProtectionTypeProofOfWork
+	case policy_pb.BotProtectionType_BOT_PROTECTION_TYPE_IMAGE_CAPTCHA:
+		return domain.BotProtectionTypeImageCaptcha
+	case policy_pb.BotProtectionType_BOT_PROTECTION_TYPE_EXTERNAL:
+		return domain.BotProtectionTypeExternal
+	default:
+		return -1
+	}
+}
+
+func ModelBotProtectionTypeToPb(botProtection domain.BotProtectionType) policy_pb.BotProtectionType {
+	switch botProtection {
+	case domain.BotProtectionTypeProofOfWork:
+		return policy_pb.BotProtectionType_BOT_PROTECTION_TYPE_PROOF_OF_WORK
+	case domain.BotProtectionTypeImageCaptcha:
+		return policy_pb.BotProtectionType_BOT_PROTECTION_TYPE_IMAGE_CAPTCHA
+	case domain.BotProtectionTypeExternal:
+		return policy_pb.BotProtectionType_BOT_PROTECTION_TYPE_EXTERNAL
+	default:
+		return policy_pb.BotProtectionType_BOT_PROTECTION_TYPE_NONE
+	}
+}
@@ -10,6 +10,11 @@ func ModelNotificationPolicyToPb(policy *query.NotificationPolicy) *policy_pb.No
 	return &policy_pb.NotificationPolicy{
 		IsDefault:      policy.IsDefault,
 		PasswordChange: policy.PasswordChange,
+		NewDeviceLogin: policy.NewDeviceLogin,
+		MfaAdded:       policy.MFAAdded,
+		MfaRemoved:     policy.MFARemoved,
+		EmailChange:    policy.EmailChange,
+		AccountLocked:  policy.AccountLocked,
 		Details: object.ToViewDetailsPb(
 			policy.Sequence,
 			policy.CreationDate,
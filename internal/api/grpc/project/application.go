@@ -61,6 +61,7 @@ func AppOIDCConfigToPb(app *query.OIDCApp) *app_pb.App_OidcConfig {
 			AdditionalOrigins:        app.AdditionalOrigins,
 			AllowedOrigins:           app.AllowedOrigins,
 			SkipNativeAppSuccessPage: app.SkipNativeAppSuccessPage,
+			RequireConsent:           app.RequireConsent,
 		},
 	}
 }
@@ -68,7 +69,14 @@ func AppOIDCConfigToPb(app *query.OIDCApp) *app_pb.App_OidcConfig {
 func AppSAMLConfigToPb(app *query.SAMLApp) app_pb.AppConfig {
 	return &app_pb.App_SamlConfig{
 		SamlConfig: &app_pb.SAMLConfig{
-			Metadata: &app_pb.SAMLConfig_MetadataXml{MetadataXml: app.Metadata},
+			Metadata:           &app_pb.SAMLConfig_MetadataXml{MetadataXml: app.Metadata},
+			IdpInitiatedSso:    app.IDPInitiatedSSO,
+			DefaultRelayState:  app.DefaultRelayState,
+			SignatureAlgorithm: SAMLSignatureAlgorithmToPb(app.SignatureAlgorithm),
+			SigningMode:        SAMLSigningModeToPb(app.SigningMode),
+			EncryptAssertion:   app.EncryptAssertion,
+			NameIdFormat:       SAMLNameIDFormatToPb(app.NameIDFormat),
+			AttributeMappings:  SAMLAttributeMappingsToPb(app.AttributeMappings),
 		},
 	}
 }
@@ -287,6 +295,167 @@ func APIAuthMethodTypeToDomain(authType app_pb.APIAuthMethodType) domain.APIAuth
 	}
 }
 
+func SAMLSignatureAlgorithmToPb(algorithm domain.SAMLSignatureAlgorithm) app_pb.SAMLSignatureAlgorithm {
+	switch algorithm {
+	case domain.SAMLSignatureAlgorithmRSASHA256:
+		return app_pb.SAMLSignatureAlgorithm_SAML_SIGNATURE_ALGORITHM_RSA_SHA256
+	case domain.SAMLSignatureAlgorithmRSASHA512:
+		return app_pb.SAMLSignatureAlgorithm_SAML_SIGNATURE_ALGORITHM_RSA_SHA512
+	default:
+		return app_pb.SAMLSignatureAlgorithm_SAML_SIGNATURE_ALGORITHM_UNSPECIFIED
+	}
+}
+
+func SAMLSignatureAlgorithmToDomain(algorithm app_pb.SAMLSignatureAlgorithm) domain.SAMLSignatureAlgorithm {
+	switch algorithm {
+	case app_pb.SAMLSignatureAlgorithm_SAML_SIGNATURE_ALGORITHM_RSA_SHA256:
+		return domain.SAMLSignatureAlgorithmRSASHA256
+	case app_pb.SAMLSignatureAlgorithm_SAML_SIGNATURE_ALGORITHM_RSA_SHA512:
+		return domain.SAMLSignatureAlgorithmRSASHA512
+	default:
+		return domain.SAMLSignatureAlgorithmUnspecified
+	}
+}
+
+func SAMLSigningModeToPb(mode domain.SAMLSigningMode) app_pb.SAMLSigningMode {
+	switch mode {
+	case domain.SAMLSigningModeResponse:
+		return app_pb.SAMLSigningMode_SAML_SIGNING_MODE_RESPONSE
+	case domain.SAMLSigningModeResponseAndAssertion:
+		return app_pb.SAMLSigningMode_SAML_SIGNING_MODE_RESPONSE_AND_ASSERTION
+	default:
+		return app_pb.SAMLSigningMode_SAML_SIGNING_MODE_ASSERTION
+	}
+}
+
+func SAMLSigningModeToDomain(mode app_pb.SAMLSigningMode) domain.SAMLSigningMode {
+	switch mode {
+	case app_pb.SAMLSigningMode_SAML_SIGNING_MODE_RESPONSE:
+		return domain.SAMLSigningModeResponse
+	case app_pb.SAMLSigningMode_SAML_SIGNING_MODE_RESPONSE_AND_ASSERTION:
+		return domain.SAMLSigningModeResponseAndAssertion
+	default:
+		return domain.SAMLSigningModeAssertion
+	}
+}
+
+func SAMLNameIDFormatToPb(format domain.SAMLNameIDFormat) app_pb.SAMLNameIDFormat {
+	switch format {
+	case domain.SAMLNameIDFormatEmail:
+		return app_pb.SAMLNameIDFormat_SAML_NAME_ID_FORMAT_EMAIL
+	case domain.SAMLNameIDFormatPersistent:
+		return app_pb.SAMLNameIDFormat_SAML_NAME_ID_FORMAT_PERSISTENT
+	case domain.SAMLNameIDFormatTransient:
+		return app_pb.SAMLNameIDFormat_SAML_NAME_ID_FORMAT_TRANSIENT
+	default:
+		return app_pb.SAMLNameIDFormat_SAML_NAME_ID_FORMAT_UNSPECIFIED
+	}
+}
+
+func SAMLNameIDFormatToDomain(format app_pb.SAMLNameIDFormat) domain.SAMLNameIDFormat {
+	switch format {
+	case app_pb.SAMLNameIDFormat_SAML_NAME_ID_FORMAT_EMAIL:
+		return domain.SAMLNameIDFormatEmail
+	case app_pb.SAMLNameIDFormat_SAML_NAME_ID_FORMAT_PERSISTENT:
+		return domain.SAMLNameIDFormatPersistent
+	case app_pb.SAMLNameIDFormat_SAML_NAME_ID_FORMAT_TRANSIENT:
+		return domain.SAMLNameIDFormatTransient
+	default:
+		return domain.SAMLNameIDFormatUnspecified
+	}
+}
+
+func SAMLAttributeMappingsToPb(mappings domain.SAMLAttributeMappings) []*app_pb.SAMLAttributeMapping {
+	converted := make([]*app_pb.SAMLAttributeMapping, len(mappings))
+	for i, mapping := range mappings {
+		converted[i] = &app_pb.SAMLAttributeMapping{
+			Name:         mapping.Name,
+			FriendlyName: mapping.FriendlyName,
+			Source:       samlAttributeSourceToPb(mapping.Source),
+			MetadataKey:  mapping.MetadataKey,
+		}
+	}
+	return converted
+}
+
+func SAMLAttributeMappingsToDomain(mappings []*app_pb.SAMLAttributeMapping) domain.SAMLAttributeMappings {
+	if len(mappings) == 0 {
+		return nil
+	}
+	converted := make(domain.SAMLAttributeMappings, len(mappings))
+	for i, mapping := range mappings {
+		converted[i] = &domain.SAMLAttributeMapping{
+			Name:         mapping.Name,
+			FriendlyName: mapping.FriendlyName,
+			Source:       samlAttributeSourceToDomain(mapping.Source),
+			MetadataKey:  mapping.MetadataKey,
+		}
+	}
+	return converted
+}
+
+func samlAttributeSourceToPb(source domain.SAMLAttributeSource) app_pb.SAMLAttributeSource {
+	switch source {
+	case domain.SAMLAttributeSourceUserID:
+		return app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_USER_ID
+	case domain.SAMLAttributeSourceUsername:
+		return app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_USERNAME
+	case domain.SAMLAttributeSourceEmail:
+		return app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_EMAIL
+	case domain.SAMLAttributeSourceFirstName:
+		return app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_FIRST_NAME
+	case domain.SAMLAttributeSourceLastName:
+		return app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_LAST_NAME
+	case domain.SAMLAttributeSourceDisplayName:
+		return app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_DISPLAY_NAME
+	case domain.SAMLAttributeSourceNickName:
+		return app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_NICK_NAME
+	case domain.SAMLAttributeSourcePhone:
+		return app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_PHONE
+	case domain.SAMLAttributeSourcePreferredLanguage:
+		return app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_PREFERRED_LANGUAGE
+	case domain.SAMLAttributeSourceMetadata:
+		return app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_METADATA
+	case domain.SAMLAttributeSourceRoles:
+		return app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_ROLES
+	case domain.SAMLAttributeSourceGroups:
+		return app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_GROUPS
+	default:
+		return app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_UNSPECIFIED
+	}
+}
+
+func samlAttributeSourceToDomain(source app_pb.SAMLAttributeSource) domain.SAMLAttributeSource {
+	switch source {
+	case app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_USER_ID:
+		return domain.SAMLAttributeSourceUserID
+	case app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_USERNAME:
+		return domain.SAMLAttributeSourceUsername
+	case app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_EMAIL:
+		return domain.SAMLAttributeSourceEmail
+	case app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_FIRST_NAME:
+		return domain.SAMLAttributeSourceFirstName
+	case app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_LAST_NAME:
+		return domain.SAMLAttributeSourceLastName
+	case app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_DISPLAY_NAME:
+		return domain.SAMLAttributeSourceDisplayName
+	case app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_NICK_NAME:
+		return domain.SAMLAttributeSourceNickName
+	case app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_PHONE:
+		return domain.SAMLAttributeSourcePhone
+	case app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_PREFERRED_LANGUAGE:
+		return domain.SAMLAttributeSourcePreferredLanguage
+	case app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_METADATA:
+		return domain.SAMLAttributeSourceMetadata
+	case app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_ROLES:
+		return domain.SAMLAttributeSourceRoles
+	case app_pb.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_GROUPS:
+		return domain.SAMLAttributeSourceGroups
+	default:
+		return domain.SAMLAttributeSourceUnspecified
+	}
+}
+
 func AppQueriesToModel(queries []*app_pb.AppQuery) (q []query.SearchQuery, err error) {
 	q = make([]query.SearchQuery, len(queries))
 	for i, query := range queries {
@@ -26,6 +26,7 @@ func ProjectViewToPb(project *query.Project) *proj_pb.Project {
 		HasProjectCheck:        project.HasProjectCheck,
 		ProjectRoleAssertion:   project.ProjectRoleAssertion,
 		ProjectRoleCheck:       project.ProjectRoleCheck,
+		AllowImpersonation:     project.AllowImpersonation,
 		Details: object.ToViewDetailsPb(
 			project.Sequence,
 			project.CreationDate,
@@ -15,6 +15,7 @@ import (
 
 	client_middleware "github.com/zitadel/zitadel/internal/api/grpc/client/middleware"
 	"github.com/zitadel/zitadel/internal/api/grpc/server/middleware"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
 	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 )
 
@@ -68,7 +69,7 @@ func CreateGatewayWithPrefix(ctx context.Context, g WithGatewayPrefix, port uint
 	runtimeMux := runtime.NewServeMux(serveMuxOptions...)
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithUnaryInterceptor(client_middleware.DefaultTracingClient()),
+		grpc.WithChainUnaryInterceptor(client_middleware.DefaultTracingClient(), client_middleware.GatewayClient()),
 	}
 	connection, err := dial(ctx, port, opts)
 	if err != nil {
@@ -86,7 +87,7 @@ func CreateGateway(ctx context.Context, port uint16, http1HostName string) (*Gat
 		port,
 		[]grpc.DialOption{
 			grpc.WithTransportCredentials(insecure.NewCredentials()),
-			grpc.WithUnaryInterceptor(client_middleware.DefaultTracingClient()),
+			grpc.WithChainUnaryInterceptor(client_middleware.DefaultTracingClient(), client_middleware.GatewayClient()),
 		})
 	if err != nil {
 		return nil, err
@@ -133,6 +134,7 @@ func dial(ctx context.Context, port uint16, opts []grpc.DialOption) (*grpc.Clien
 func addInterceptors(handler http.Handler, http1HostName string) http.Handler {
 	handler = http_mw.CallDurationHandler(handler)
 	handler = http1Host(handler, http1HostName)
+	handler = dpopTarget(handler)
 	handler = http_mw.CORSInterceptor(handler)
 	handler = http_mw.DefaultTelemetryHandler(handler)
 	return http_mw.DefaultMetricsHandler(handler)
@@ -149,3 +151,11 @@ func http1Host(next http.Handler, http1HostName string) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+func dpopTarget(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Set(http_util.ZitadelDPoPMethod, r.Method)
+		r.Header.Set(http_util.ZitadelDPoPPath, r.URL.Path)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -34,6 +34,9 @@ func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 	}
 
 	orgID := grpc_util.GetHeader(authCtx, http.ZitadelOrgID)
+	if dpopRequest := dpopRequestFromCtx(authCtx, info.FullMethod); dpopRequest != nil {
+		authCtx = authz.SetDPoPRequest(authCtx, dpopRequest)
+	}
 
 	ctxSetter, err := authz.CheckUserAuthorization(authCtx, req, authToken, orgID, verifier, authConfig, authOpt, info.FullMethod)
 	if err != nil {
@@ -42,3 +45,25 @@ func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 	span.End()
 	return handler(ctxSetter(ctx), req)
 }
+
+// dpopRequestFromCtx returns the DPoP proof of the request
+// native grpc calls are bound to POST and the full method,
+// calls through the gateway to the original http method and path.
+// The http method and path are only trusted if the gateway of this process passed them.
+func dpopRequestFromCtx(ctx context.Context, fullMethod string) *authz.DPoPRequest {
+	request := &authz.DPoPRequest{
+		Proof:  grpc_util.GetHeader(ctx, authz.DPoPHeader),
+		Method: "POST",
+		Host:   authz.GetInstance(ctx).RequestedHost(),
+		Path:   fullMethod,
+	}
+	if request.Proof == "" && grpc_util.IsGatewayRequest(ctx) {
+		request.Proof = grpc_util.GetGatewayHeader(ctx, authz.DPoPHeader)
+		request.Method = grpc_util.GetHeader(ctx, http.ZitadelDPoPMethod)
+		request.Path = grpc_util.GetHeader(ctx, http.ZitadelDPoPPath)
+	}
+	if request.Proof == "" {
+		return nil
+	}
+	return request
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	grpc_util "github.com/zitadel/zitadel/internal/api/grpc"
+	"github.com/zitadel/zitadel/internal/api/http"
 )
 
 var (
@@ -21,7 +24,7 @@ var (
 
 type verifierMock struct{}
 
-func (v *verifierMock) VerifyAccessToken(ctx context.Context, token, clientID, projectID string) (string, string, string, string, string, error) {
+func (v *verifierMock) VerifyAccessToken(ctx context.Context, token, clientID, projectID, dpopJKT string) (string, string, string, string, string, error) {
 	return "", "", "", "", "", nil
 }
 func (v *verifierMock) SearchMyMemberships(ctx context.Context) ([]*authz.Membership, error) {
@@ -34,6 +37,9 @@ func (v *verifierMock) ProjectIDAndOriginsByClientID(ctx context.Context, client
 func (v *verifierMock) ExistsOrg(ctx context.Context, orgID string) error {
 	return nil
 }
+func (v *verifierMock) AddDPoPProof(ctx context.Context, jti string, expiration time.Time) (bool, error) {
+	return true, nil
+}
 func (v *verifierMock) VerifierClientID(ctx context.Context, appName string) (string, string, error) {
 	return "", "", nil
 }
@@ -150,3 +156,55 @@ func Test_authorize(t *testing.T) {
 		})
 	}
 }
+
+func Test_dpopRequestFromCtx(t *testing.T) {
+	gatewayMD, _ := metadata.FromOutgoingContext(grpc_util.SetGatewaySecret(context.Background()))
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want *authz.DPoPRequest
+	}{
+		{
+			name: "no proof",
+			md:   metadata.Pairs(),
+			want: nil,
+		},
+		{
+			name: "native grpc, bound to method",
+			md: metadata.Pairs(
+				authz.DPoPHeader, "proof",
+				http.ZitadelDPoPMethod, "GET",
+				http.ZitadelDPoPPath, "/auth/v1/users/me",
+			),
+			want: &authz.DPoPRequest{Proof: "proof", Method: "POST", Path: "/zitadel.auth.v1.AuthService/GetMyUser"},
+		},
+		{
+			name: "gateway headers without gateway secret, ignored",
+			md: metadata.Pairs(
+				"grpcgateway-"+authz.DPoPHeader, "proof",
+				http.ZitadelDPoPMethod, "GET",
+				http.ZitadelDPoPPath, "/auth/v1/users/me",
+				http.ZitadelGateway, "secret",
+			),
+			want: nil,
+		},
+		{
+			name: "gateway, bound to http request",
+			md: metadata.Join(gatewayMD, metadata.Pairs(
+				"grpcgateway-"+authz.DPoPHeader, "proof",
+				http.ZitadelDPoPMethod, "GET",
+				http.ZitadelDPoPPath, "/auth/v1/users/me",
+			)),
+			want: &authz.DPoPRequest{Proof: "proof", Method: "GET", Path: "/auth/v1/users/me"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			got := dpopRequestFromCtx(ctx, "/zitadel.auth.v1.AuthService/GetMyUser")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dpopRequestFromCtx() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
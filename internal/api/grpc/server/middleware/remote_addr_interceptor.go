@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+)
+
+// RemoteAddrHandler makes the IP of the caller available by http_utils.RemoteIPFromCtx,
+// the x-forwarded-for header is passed by the gateway and proxies
+func RemoteAddrHandler() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var forwardedFor []string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			forwardedFor = md.Get(http_utils.ForwardedFor)
+		}
+		var addr string
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			addr = p.Addr.String()
+		}
+		return handler(http_utils.RemoteAddrToContext(ctx, forwardedFor, addr), req)
+	}
+}
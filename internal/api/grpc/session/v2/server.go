@@ -1,10 +1,13 @@
 package session
 
 import (
+	"context"
+
 	"google.golang.org/grpc"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/server"
+	"github.com/zitadel/zitadel/internal/auth/repository"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/pkg/grpc/session/v2alpha"
@@ -14,8 +17,11 @@ var _ session.SessionServiceServer = (*Server)(nil)
 
 type Server struct {
 	session.UnimplementedSessionServiceServer
-	command *command.Commands
-	query   *query.Queries
+	command         *command.Commands
+	query           *query.Queries
+	authRepo        repository.Repository
+	externalSecure  bool
+	authCallbackURL func(ctx context.Context, authRequestID string) string
 }
 
 type Config struct{}
@@ -23,10 +29,16 @@ type Config struct{}
 func CreateServer(
 	command *command.Commands,
 	query *query.Queries,
+	authRepo repository.Repository,
+	externalSecure bool,
+	authCallbackURL func(ctx context.Context, authRequestID string) string,
 ) *Server {
 	return &Server{
-		command: command,
-		query:   query,
+		command:         command,
+		query:           query,
+		authRepo:        authRepo,
+		externalSecure:  externalSecure,
+		authCallbackURL: authCallbackURL,
 	}
 }
 
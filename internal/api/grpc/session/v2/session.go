@@ -3,16 +3,316 @@ package session
 import (
 	"context"
 
+	"github.com/zitadel/oidc/v2/pkg/op"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/zitadel/zitadel/internal/api/authz"
-	"github.com/zitadel/zitadel/pkg/grpc/session/v2alpha"
-	"github.com/zitadel/zitadel/pkg/grpc/user/v2alpha"
+	object "github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	session "github.com/zitadel/zitadel/pkg/grpc/session/v2alpha"
 )
 
 func (s *Server) GetSession(ctx context.Context, req *session.GetSessionRequest) (*session.GetSessionResponse, error) {
+	res, err := s.query.SessionByID(ctx, true, req.GetSessionId(), req.GetSessionToken())
+	if err != nil {
+		return nil, err
+	}
 	return &session.GetSessionResponse{
-		Session: &session.Session{
-			Id:   req.Id,
-			User: &user.User{Id: authz.GetCtxData(ctx).UserID},
+		Session: sessionToPb(res),
+	}, nil
+}
+
+func (s *Server) ListSessions(ctx context.Context, req *session.ListSessionsRequest) (*session.ListSessionsResponse, error) {
+	queries, err := listSessionsRequestToQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.query.SearchSessions(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &session.ListSessionsResponse{
+		Details:  object.ToListDetails(sessions.SearchResponse),
+		Sessions: sessionsToPb(sessions.Sessions),
+	}, nil
+}
+
+func (s *Server) CreateSession(ctx context.Context, req *session.CreateSessionRequest) (*session.CreateSessionResponse, error) {
+	checks, metadata, err := s.createSessionRequestToCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	set, err := s.command.CreateSession(ctx, checks, metadata)
+	if err != nil {
+		return nil, err
+	}
+	challenges, err := challengesToPb(set)
+	if err != nil {
+		return nil, err
+	}
+	return &session.CreateSessionResponse{
+		Details:      object.DomainToDetailsPb(set.ObjectDetails),
+		SessionId:    set.ID,
+		SessionToken: set.NewToken,
+		Challenges:   challenges,
+	}, nil
+}
+
+func (s *Server) SetSession(ctx context.Context, req *session.SetSessionRequest) (*session.SetSessionResponse, error) {
+	checks, err := s.setSessionRequestToCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	set, err := s.command.UpdateSession(ctx, req.GetSessionId(), req.GetSessionToken(), checks, req.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+	challenges, err := challengesToPb(set)
+	if err != nil {
+		return nil, err
+	}
+	return &session.SetSessionResponse{
+		Details:      object.DomainToDetailsPb(set.ObjectDetails),
+		SessionToken: set.NewToken,
+		Challenges:   challenges,
+	}, nil
+}
+
+func (s *Server) DeleteSession(ctx context.Context, req *session.DeleteSessionRequest) (*session.DeleteSessionResponse, error) {
+	details, err := s.command.TerminateSession(ctx, req.GetSessionId(), req.GetSessionToken())
+	if err != nil {
+		return nil, err
+	}
+	return &session.DeleteSessionResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) LinkSessionToAuthRequest(ctx context.Context, req *session.LinkSessionToAuthRequestRequest) (*session.LinkSessionToAuthRequestResponse, error) {
+	err := s.authRepo.LinkSessionToAuthRequest(ctx, req.GetAuthRequestId(), req.GetSessionId(), req.GetSessionToken())
+	if err != nil {
+		return nil, err
+	}
+	issuerCtx := op.ContextWithIssuer(ctx, http.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), s.externalSecure))
+	return &session.LinkSessionToAuthRequestResponse{
+		CallbackUrl: s.authCallbackURL(issuerCtx, req.GetAuthRequestId()),
+	}, nil
+}
+
+func sessionsToPb(sessions []*query.Session) []*session.Session {
+	s := make([]*session.Session, len(sessions))
+	for i, sess := range sessions {
+		s[i] = sessionToPb(sess)
+	}
+	return s
+}
+
+func sessionToPb(s *query.Session) *session.Session {
+	return &session.Session{
+		Id:           s.ID,
+		CreationDate: timestamppb.New(s.CreationDate),
+		ChangeDate:   timestamppb.New(s.ChangeDate),
+		Sequence:     s.Sequence,
+		Factors:      factorsToPb(s),
+		Metadata:     s.Metadata,
+	}
+}
+
+func factorsToPb(s *query.Session) *session.Factors {
+	user := userFactorToPb(s.UserFactor)
+	if user == nil {
+		return nil
+	}
+	return &session.Factors{
+		User:     user,
+		Password: passwordFactorToPb(s.PasswordFactor),
+		WebAuthN: webAuthNFactorToPb(s.WebAuthNFactor),
+		Intent:   intentFactorToPb(s.IntentFactor),
+	}
+}
+
+func userFactorToPb(factor query.SessionUserFactor) *session.UserFactor {
+	if factor.UserID == "" || factor.UserCheckedAt.IsZero() {
+		return nil
+	}
+	return &session.UserFactor{
+		VerifiedAt:     timestamppb.New(factor.UserCheckedAt),
+		Id:             factor.UserID,
+		LoginName:      factor.LoginName,
+		DisplayName:    factor.DisplayName,
+		OrganisationId: factor.ResourceOwner,
+	}
+}
+
+func passwordFactorToPb(factor query.SessionPasswordFactor) *session.PasswordFactor {
+	if factor.PasswordCheckedAt.IsZero() {
+		return nil
+	}
+	return &session.PasswordFactor{
+		VerifiedAt: timestamppb.New(factor.PasswordCheckedAt),
+	}
+}
+
+func intentFactorToPb(factor query.SessionIntentFactor) *session.IntentFactor {
+	if factor.IntentCheckedAt.IsZero() {
+		return nil
+	}
+	return &session.IntentFactor{
+		VerifiedAt: timestamppb.New(factor.IntentCheckedAt),
+	}
+}
+
+func webAuthNFactorToPb(factor query.SessionWebAuthNFactor) *session.WebAuthNFactor {
+	if factor.WebAuthNCheckedAt.IsZero() {
+		return nil
+	}
+	return &session.WebAuthNFactor{
+		VerifiedAt:   timestamppb.New(factor.WebAuthNCheckedAt),
+		UserVerified: factor.UserVerified,
+	}
+}
+
+func listSessionsRequestToQuery(req *session.ListSessionsRequest) (*query.SessionsSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToQuery(req.GetQuery())
+	queries, err := sessionQueriesToQuery(req.GetQueries())
+	if err != nil {
+		return nil, err
+	}
+	return &query.SessionsSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func sessionQueriesToQuery(queries []*session.SearchQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, v := range queries {
+		q[i], err = sessionQueryToQuery(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func sessionQueryToQuery(sq *session.SearchQuery) (query.SearchQuery, error) {
+	switch q := sq.GetQuery().(type) {
+	case *session.SearchQuery_IdsQuery:
+		return query.NewSessionIDsSearchQuery(q.IdsQuery.GetIds())
+	case *session.SearchQuery_UserIdQuery:
+		return query.NewSessionUserIDSearchQuery(q.UserIdQuery.GetId())
+	case *session.SearchQuery_CreatorQuery:
+		return query.NewSessionCreatorSearchQuery(q.CreatorQuery.GetId())
+	default:
+		return nil, caos_errs.ThrowInvalidArgument(nil, "GRPC-Sfefs", "List.Query.Invalid")
+	}
+}
+
+func (s *Server) createSessionRequestToCommand(ctx context.Context, req *session.CreateSessionRequest) ([]command.SessionCommand, map[string][]byte, error) {
+	checks, err := s.checksToCommand(ctx, req.GetChecks())
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(checks, challengesToCommand(req.GetChallenges())...), req.GetMetadata(), nil
+}
+
+func (s *Server) setSessionRequestToCommand(ctx context.Context, req *session.SetSessionRequest) ([]command.SessionCommand, error) {
+	checks, err := s.checksToCommand(ctx, req.GetChecks())
+	if err != nil {
+		return nil, err
+	}
+	return append(checks, challengesToCommand(req.GetChallenges())...), nil
+}
+
+func (s *Server) checksToCommand(ctx context.Context, checks *session.Checks) ([]command.SessionCommand, error) {
+	if checks == nil {
+		return nil, nil
+	}
+	sessionChecks := make([]command.SessionCommand, 0, 4)
+	if checkUser := checks.GetUser(); checkUser != nil {
+		userID, err := s.checkUserToUserID(ctx, checkUser)
+		if err != nil {
+			return nil, err
+		}
+		sessionChecks = append(sessionChecks, command.CheckUser(userID))
+	}
+	if password := checks.GetPassword(); password != nil {
+		sessionChecks = append(sessionChecks, command.CheckPassword(password.GetPassword()))
+	}
+	if intent := checks.GetIntent(); intent != nil {
+		sessionChecks = append(sessionChecks, command.CheckIntent(intent.GetIntentId(), intent.GetToken()))
+	}
+	if webAuthN := checks.GetWebAuthN(); webAuthN != nil {
+		credentialAssertionData, err := webAuthN.GetCredentialAssertionData().MarshalJSON()
+		if err != nil {
+			return nil, caos_errs.ThrowInvalidArgument(err, "GRPC-shu8A", "Errors.Session.WebAuthN.InvalidAssertion")
+		}
+		sessionChecks = append(sessionChecks, command.CheckWebAuthN(credentialAssertionData))
+	}
+	return sessionChecks, nil
+}
+
+func (s *Server) checkUserToUserID(ctx context.Context, checkUser *session.CheckUser) (string, error) {
+	switch search := checkUser.GetSearch().(type) {
+	case *session.CheckUser_UserId:
+		return search.UserId, nil
+	case *session.CheckUser_LoginName:
+		loginName, err := query.NewUserLoginNamesSearchQuery(search.LoginName)
+		if err != nil {
+			return "", err
+		}
+		user, err := s.query.GetUser(ctx, true, false, loginName)
+		if err != nil {
+			return "", err
+		}
+		return user.ID, nil
+	default:
+		return "", caos_errs.ThrowInvalidArgument(nil, "GRPC-Sfw3b", "Errors.Session.User.Missing")
+	}
+}
+
+func challengesToCommand(challenges *session.RequestChallenges) []command.SessionCommand {
+	if challenges == nil || challenges.GetWebAuthN() == nil {
+		return nil
+	}
+	return []command.SessionCommand{
+		command.CreateWebAuthNChallenge(userVerificationRequirementToDomain(challenges.GetWebAuthN().GetUserVerificationRequirement())),
+	}
+}
+
+func userVerificationRequirementToDomain(req session.UserVerificationRequirement) domain.UserVerificationRequirement {
+	switch req {
+	case session.UserVerificationRequirement_USER_VERIFICATION_REQUIREMENT_REQUIRED:
+		return domain.UserVerificationRequirementRequired
+	case session.UserVerificationRequirement_USER_VERIFICATION_REQUIREMENT_PREFERRED:
+		return domain.UserVerificationRequirementPreferred
+	case session.UserVerificationRequirement_USER_VERIFICATION_REQUIREMENT_DISCOURAGED:
+		return domain.UserVerificationRequirementDiscouraged
+	default:
+		return domain.UserVerificationRequirementUnspecified
+	}
+}
+
+func challengesToPb(set *command.SessionChanged) (*session.Challenges, error) {
+	if set.WebAuthNChallenge == nil {
+		return nil, nil
+	}
+	options := new(structpb.Struct)
+	if err := options.UnmarshalJSON(set.WebAuthNChallenge.CredentialAssertionData); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "GRPC-ieQu0", "Errors.Internal")
+	}
+	return &session.Challenges{
+		WebAuthN: &session.Challenges_WebAuthN{
+			PublicKeyCredentialRequestOptions: options,
 		},
 	}, nil
 }
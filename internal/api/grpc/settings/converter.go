@@ -13,3 +13,12 @@ func NotificationProviderToPb(provider *query.DebugNotificationProvider) *settin
 	}
 	return mapped
 }
+
+func NotificationWebhookProviderToPb(provider *query.NotificationWebhookProvider) *settings_pb.NotificationWebhookProvider {
+	return &settings_pb.NotificationWebhookProvider{
+		Details: obj_pb.ToViewDetailsPb(provider.Sequence, provider.CreationDate, provider.ChangeDate, provider.ResourceOwner),
+		CallUrl: provider.CallURL,
+		Method:  provider.Method,
+		Headers: provider.Headers,
+	}
+}
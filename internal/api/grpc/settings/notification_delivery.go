@@ -0,0 +1,74 @@
+package settings
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	obj_pb "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	settings_pb "github.com/zitadel/zitadel/pkg/grpc/settings"
+)
+
+func NotificationDeliveriesToPb(deliveries []*query.NotificationDelivery) []*settings_pb.NotificationDelivery {
+	d := make([]*settings_pb.NotificationDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		d[i] = NotificationDeliveryToPb(delivery)
+	}
+	return d
+}
+
+func NotificationDeliveryToPb(delivery *query.NotificationDelivery) *settings_pb.NotificationDelivery {
+	var retryAt *timestamppb.Timestamp
+	if delivery.State == domain.NotificationDeliveryStateRetrying && !delivery.RetryAt.IsZero() {
+		retryAt = timestamppb.New(delivery.RetryAt)
+	}
+	return &settings_pb.NotificationDelivery{
+		Details:     obj_pb.ToViewDetailsPb(delivery.Sequence, delivery.CreationDate, delivery.ChangeDate, delivery.ResourceOwner),
+		Id:          delivery.ID,
+		UserId:      delivery.UserID,
+		Channel:     notificationChannelToPb(delivery.Channel),
+		Recipient:   delivery.Recipient,
+		MessageType: delivery.MessageType,
+		State:       NotificationDeliveryStateToPb(delivery.State),
+		Attempts:    uint32(delivery.Attempts),
+		LastError:   delivery.LastError,
+		RetryAt:     retryAt,
+	}
+}
+
+func notificationChannelToPb(channel domain.NotificationType) settings_pb.NotificationChannel {
+	switch channel {
+	case domain.NotificationTypeSms:
+		return settings_pb.NotificationChannel_NOTIFICATION_CHANNEL_SMS
+	case domain.NotificationTypePush:
+		return settings_pb.NotificationChannel_NOTIFICATION_CHANNEL_PUSH
+	default:
+		return settings_pb.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL
+	}
+}
+
+func NotificationDeliveryStateToPb(state domain.NotificationDeliveryState) settings_pb.NotificationDeliveryState {
+	switch state {
+	case domain.NotificationDeliveryStateDelivered:
+		return settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_DELIVERED
+	case domain.NotificationDeliveryStateRetrying:
+		return settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_RETRYING
+	case domain.NotificationDeliveryStateFailed:
+		return settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_FAILED
+	default:
+		return settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_UNSPECIFIED
+	}
+}
+
+func NotificationDeliveryStateToDomain(state settings_pb.NotificationDeliveryState) domain.NotificationDeliveryState {
+	switch state {
+	case settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_DELIVERED:
+		return domain.NotificationDeliveryStateDelivered
+	case settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_RETRYING:
+		return domain.NotificationDeliveryStateRetrying
+	case settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_FAILED:
+		return domain.NotificationDeliveryStateFailed
+	default:
+		return domain.NotificationDeliveryStateUnspecified
+	}
+}
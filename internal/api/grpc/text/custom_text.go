@@ -26,6 +26,21 @@ func ModelCustomMessageTextToPb(msg *query.MessageText) *text_pb.MessageCustomTe
 	}
 }
 
+func CustomMailTemplateToPb(template *query.CustomMailTemplate, assetPrefix string) *text_pb.CustomMailTemplate {
+	return &text_pb.CustomMailTemplate{
+		Details: object.ToViewDetailsPb(
+			template.Sequence,
+			template.CreationDate,
+			template.ChangeDate,
+			template.AggregateID,
+		),
+		MessageType:  template.MessageType,
+		TemplateUrl:  domain.AssetURL(assetPrefix, template.AggregateID, template.TemplateKey),
+		TemplateHash: template.TemplateHash,
+		MjmlUrl:      domain.AssetURL(assetPrefix, template.AggregateID, template.MJMLKey),
+	}
+}
+
 func CustomLoginTextToPb(text *domain.CustomLoginText) *text_pb.LoginCustomText {
 	return &text_pb.LoginCustomText{
 		Details: object.ToViewDetailsPb(
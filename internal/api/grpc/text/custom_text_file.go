@@ -0,0 +1,74 @@
+package text
+
+import (
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/query"
+	text_pb "github.com/zitadel/zitadel/pkg/grpc/text"
+)
+
+func TextFileFormatToDomain(format text_pb.TextFileFormat) domain.CustomTextFormat {
+	switch format {
+	case text_pb.TextFileFormat_TEXT_FILE_FORMAT_XLIFF:
+		return domain.CustomTextFormatXLIFF
+	case text_pb.TextFileFormat_TEXT_FILE_FORMAT_JSON:
+		return domain.CustomTextFormatJSON
+	default:
+		return domain.CustomTextFormatUnspecified
+	}
+}
+
+// ParseCustomTextLanguage parses the language of exported and imported texts,
+// languages which aren't part of the translation files are allowed
+func ParseCustomTextLanguage(lang string) (language.Tag, error) {
+	tag, err := language.Parse(lang)
+	if err != nil || tag == language.Und {
+		return language.Und, caos_errs.ThrowInvalidArgument(err, "TEXT-Fl2lg", "Errors.TranslationFile.InvalidLanguage")
+	}
+	return tag, nil
+}
+
+func CustomTextTranslationsToFile(translations *query.CustomTextTranslations, format text_pb.TextFileFormat) ([]byte, error) {
+	switch TextFileFormatToDomain(format) {
+	case domain.CustomTextFormatXLIFF:
+		return i18n.MarshalXLIFF(translations.File())
+	case domain.CustomTextFormatJSON:
+		return i18n.MarshalJSON(translations.File())
+	default:
+		return nil, caos_errs.ThrowInvalidArgument(nil, "TEXT-Fl3fm", "Errors.TranslationFile.FormatUnsupported")
+	}
+}
+
+// CustomTextFileToTranslationFile reads an imported file,
+// the language of the request is used if the file doesn't define it
+func CustomTextFileToTranslationFile(data []byte, format text_pb.TextFileFormat, lang string) (file *i18n.TranslationFile, err error) {
+	switch TextFileFormatToDomain(format) {
+	case domain.CustomTextFormatXLIFF:
+		file, err = i18n.UnmarshalXLIFF(data)
+	case domain.CustomTextFormatJSON:
+		file, err = i18n.UnmarshalJSON(data)
+	default:
+		return nil, caos_errs.ThrowInvalidArgument(nil, "TEXT-Fl4fm", "Errors.TranslationFile.FormatUnsupported")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if lang == "" {
+		if file.TargetLanguage == language.Und {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "TEXT-Fl5lg", "Errors.TranslationFile.LanguageMissing")
+		}
+		return file, nil
+	}
+	tag, err := ParseCustomTextLanguage(lang)
+	if err != nil {
+		return nil, err
+	}
+	if file.TargetLanguage != language.Und && file.TargetLanguage != tag {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "TEXT-Fl6lg", "Errors.TranslationFile.LanguageMismatch")
+	}
+	file.TargetLanguage = tag
+	return file, nil
+}
@@ -117,6 +117,26 @@ func ModelPhoneToPb(phone *query.Phone) *user_pb.Phone {
 	}
 }
 
+func NotificationPreferencesToPb(preferences *query.NotificationPreferences) *user_pb.NotificationPreferences {
+	return &user_pb.NotificationPreferences{
+		NewDeviceLogin: preferences.NewDeviceLogin,
+		MfaAdded:       preferences.MFAAdded,
+		MfaRemoved:     preferences.MFARemoved,
+		EmailChange:    preferences.EmailChange,
+		AccountLocked:  preferences.AccountLocked,
+	}
+}
+
+func NotificationPreferencesToDomain(preferences *user_pb.NotificationPreferences) *domain.NotificationPreferences {
+	return &domain.NotificationPreferences{
+		NewDeviceLogin: preferences.GetNewDeviceLogin(),
+		MFAAdded:       preferences.GetMfaAdded(),
+		MFARemoved:     preferences.GetMfaRemoved(),
+		EmailChange:    preferences.GetEmailChange(),
+		AccountLocked:  preferences.GetAccountLocked(),
+	}
+}
+
 func GenderToDomain(gender user_pb.Gender) domain.Gender {
 	switch gender {
 	case user_pb.Gender_GENDER_DIVERSE:
@@ -141,6 +161,17 @@ func AccessTokenTypeToDomain(accessTokenType user_pb.AccessTokenType) domain.OID
 	}
 }
 
+func PushPlatformToDomain(platform user_pb.PushPlatform) domain.PushPlatform {
+	switch platform {
+	case user_pb.PushPlatform_PUSH_PLATFORM_FCM:
+		return domain.PushPlatformFCM
+	case user_pb.PushPlatform_PUSH_PLATFORM_APNS:
+		return domain.PushPlatformAPNs
+	default:
+		return domain.PushPlatformUnspecified
+	}
+}
+
 func UserStateToPb(state domain.UserState) user_pb.UserState {
 	switch state {
 	case domain.UserStateActive:
@@ -0,0 +1,41 @@
+package user
+
+import (
+	"context"
+
+	object "github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/pkg/grpc/user/v2alpha"
+)
+
+func (s *Server) SetEmail(ctx context.Context, req *user.SetEmailRequest) (*user.SetEmailResponse, error) {
+	emailCodeGenerator, err := s.query.InitEncryptionGenerator(ctx, domain.SecretGeneratorTypeVerifyEmailCode, s.userCodeAlg)
+	if err != nil {
+		return nil, err
+	}
+	email, err := s.command.ChangeUserEmail(ctx, req.GetUserId(), req.GetEmail().GetEmail(), req.GetEmail().GetIsVerified(), emailCodeGenerator)
+	if err != nil {
+		return nil, err
+	}
+	return &user.SetEmailResponse{
+		Details: object.DomainToDetailsPb(&domain.ObjectDetails{
+			Sequence:      email.Sequence,
+			EventDate:     email.ChangeDate,
+			ResourceOwner: email.ResourceOwner,
+		}),
+	}, nil
+}
+
+func (s *Server) VerifyEmail(ctx context.Context, req *user.VerifyEmailRequest) (*user.VerifyEmailResponse, error) {
+	emailCodeGenerator, err := s.query.InitEncryptionGenerator(ctx, domain.SecretGeneratorTypeVerifyEmailCode, s.userCodeAlg)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.VerifyUserEmail(ctx, req.GetUserId(), req.GetVerificationCode(), emailCodeGenerator)
+	if err != nil {
+		return nil, err
+	}
+	return &user.VerifyEmailResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
@@ -0,0 +1,23 @@
+package user
+
+import (
+	"context"
+
+	object "github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/pkg/grpc/user/v2alpha"
+)
+
+func (s *Server) AddIDPLink(ctx context.Context, req *user.AddIDPLinkRequest) (*user.AddIDPLinkResponse, error) {
+	details, err := s.command.AddIDPLink(ctx, req.GetUserId(), &domain.UserIDPLink{
+		IDPConfigID:    req.GetIdpLink().GetIdpId(),
+		ExternalUserID: req.GetIdpLink().GetIdpExternalId(),
+		DisplayName:    req.GetIdpLink().GetDisplayName(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user.AddIDPLinkResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
@@ -0,0 +1,57 @@
+package user
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	object "github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/pkg/grpc/user/v2alpha"
+)
+
+func (s *Server) RegisterPasskey(ctx context.Context, req *user.RegisterPasskeyRequest) (*user.RegisterPasskeyResponse, error) {
+	token, err := s.command.RegisterUserPasskey(ctx, req.GetUserId(), passkeyAuthenticatorToDomain(req.GetAuthenticator()))
+	if err != nil {
+		return nil, err
+	}
+	options := new(structpb.Struct)
+	if err = options.UnmarshalJSON(token.CredentialCreationData); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "USERv2-Sfw3c", "Errors.Internal")
+	}
+	return &user.RegisterPasskeyResponse{
+		Details: object.DomainToDetailsPb(&domain.ObjectDetails{
+			Sequence:      token.Sequence,
+			EventDate:     token.ChangeDate,
+			ResourceOwner: token.ResourceOwner,
+		}),
+		PasskeyId:                          token.WebAuthNTokenID,
+		PublicKeyCredentialCreationOptions: options,
+	}, nil
+}
+
+func (s *Server) VerifyPasskeyRegistration(ctx context.Context, req *user.VerifyPasskeyRegistrationRequest) (*user.VerifyPasskeyRegistrationResponse, error) {
+	credential, err := req.GetPublicKeyCredential().MarshalJSON()
+	if err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "USERv2-Sfw3d", "Errors.User.WebAuthN.ValidateLoginFailed")
+	}
+	details, err := s.command.VerifyUserPasskey(ctx, req.GetUserId(), req.GetPasskeyName(), credential)
+	if err != nil {
+		return nil, err
+	}
+	return &user.VerifyPasskeyRegistrationResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func passkeyAuthenticatorToDomain(authenticator user.PasskeyAuthenticator) domain.AuthenticatorAttachment {
+	switch authenticator {
+	case user.PasskeyAuthenticator_PASSKEY_AUTHENTICATOR_PLATFORM:
+		return domain.AuthenticatorAttachmentPlattform
+	case user.PasskeyAuthenticator_PASSKEY_AUTHENTICATOR_CROSS_PLATFORM:
+		return domain.AuthenticatorAttachmentCrossPlattform
+	default:
+		return domain.AuthenticatorAttachmentUnspecified
+	}
+}
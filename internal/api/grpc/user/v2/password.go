@@ -0,0 +1,25 @@
+package user
+
+import (
+	"context"
+
+	object "github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/pkg/grpc/user/v2alpha"
+)
+
+func (s *Server) SetPassword(ctx context.Context, req *user.SetPasswordRequest) (*user.SetPasswordResponse, error) {
+	var details *domain.ObjectDetails
+	var err error
+	if req.GetCurrentPassword() != "" {
+		details, err = s.command.ChangeUserPassword(ctx, req.GetUserId(), req.GetCurrentPassword(), req.GetNewPassword().GetPassword())
+	} else {
+		details, err = s.command.SetUserPassword(ctx, req.GetUserId(), req.GetNewPassword().GetPassword(), req.GetNewPassword().GetChangeRequired())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user.SetPasswordResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
@@ -0,0 +1,234 @@
+package user
+
+import (
+	"context"
+
+	object "github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/pkg/grpc/user/v2alpha"
+)
+
+func (s *Server) GetUserByID(ctx context.Context, req *user.GetUserByIDRequest) (*user.GetUserByIDResponse, error) {
+	resp, err := s.query.GetUserByIDWithPermission(ctx, true, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	return &user.GetUserByIDResponse{
+		User: userToPb(resp),
+	}, nil
+}
+
+func (s *Server) ListUsers(ctx context.Context, req *user.ListUsersRequest) (*user.ListUsersResponse, error) {
+	queries, err := listUsersRequestToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchUsersWithPermission(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &user.ListUsersResponse{
+		Result:  usersToPb(res.Users),
+		Details: object.ToListDetails(res.SearchResponse),
+	}, nil
+}
+
+func usersToPb(users []*query.User) []*user.User {
+	u := make([]*user.User, len(users))
+	for i, usr := range users {
+		u[i] = userToPb(usr)
+	}
+	return u
+}
+
+func userToPb(usr *query.User) *user.User {
+	u := &user.User{
+		UserId: usr.ID,
+		Details: object.DomainToDetailsPb(&domain.ObjectDetails{
+			Sequence:      usr.Sequence,
+			EventDate:     usr.ChangeDate,
+			ResourceOwner: usr.ResourceOwner,
+		}),
+		State:              userStateToPb(usr.State),
+		Username:           usr.Username,
+		LoginNames:         usr.LoginNames,
+		PreferredLoginName: usr.PreferredLoginName,
+	}
+	if usr.Human != nil {
+		u.Type = &user.User_Human{
+			Human: humanToPb(usr.Human),
+		}
+	}
+	if usr.Machine != nil {
+		u.Type = &user.User_Machine{
+			Machine: &user.MachineUser{
+				Name:        usr.Machine.Name,
+				Description: usr.Machine.Description,
+			},
+		}
+	}
+	return u
+}
+
+func humanToPb(human *query.Human) *user.HumanUser {
+	return &user.HumanUser{
+		Profile: &user.HumanProfile{
+			GivenName:         human.FirstName,
+			FamilyName:        human.LastName,
+			NickName:          human.NickName,
+			DisplayName:       human.DisplayName,
+			PreferredLanguage: human.PreferredLanguage.String(),
+			Gender:            genderToPb(human.Gender),
+		},
+		Email: &user.HumanEmail{
+			Email:      string(human.Email),
+			IsVerified: human.IsEmailVerified,
+		},
+		Phone: &user.HumanPhone{
+			Phone:      string(human.Phone),
+			IsVerified: human.IsPhoneVerified,
+		},
+	}
+}
+
+func userStateToPb(state domain.UserState) user.UserState {
+	switch state {
+	case domain.UserStateActive:
+		return user.UserState_USER_STATE_ACTIVE
+	case domain.UserStateInactive:
+		return user.UserState_USER_STATE_INACTIVE
+	case domain.UserStateDeleted:
+		return user.UserState_USER_STATE_DELETED
+	case domain.UserStateInitial:
+		return user.UserState_USER_STATE_INITIAL
+	case domain.UserStateLocked:
+		return user.UserState_USER_STATE_LOCKED
+	default:
+		return user.UserState_USER_STATE_UNSPECIFIED
+	}
+}
+
+func genderToPb(gender domain.Gender) user.Gender {
+	switch gender {
+	case domain.GenderDiverse:
+		return user.Gender_GENDER_DIVERSE
+	case domain.GenderFemale:
+		return user.Gender_GENDER_FEMALE
+	case domain.GenderMale:
+		return user.Gender_GENDER_MALE
+	default:
+		return user.Gender_GENDER_UNSPECIFIED
+	}
+}
+
+func listUsersRequestToModel(req *user.ListUsersRequest) (*query.UserSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToQuery(req.GetQuery())
+	queries, err := userQueriesToQuery(req.GetQueries(), 0)
+	if err != nil {
+		return nil, err
+	}
+	return &query.UserSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func userQueriesToQuery(queries []*user.SearchQuery, level uint8) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, userQuery := range queries {
+		q[i], err = userQueryToQuery(userQuery, level)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+// maxQueryLevel limits the nesting of and, or and not queries
+const maxQueryLevel = 20
+
+func userQueryToQuery(sq *user.SearchQuery, level uint8) (query.SearchQuery, error) {
+	if level > maxQueryLevel {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "GRPC-zsQ97", "Errors.Query.TooManyNestingLevels")
+	}
+	switch q := sq.GetQuery().(type) {
+	case *user.SearchQuery_UserNameQuery:
+		return query.NewUserUsernameSearchQuery(q.UserNameQuery.GetUserName(), object.TextMethodToQuery(q.UserNameQuery.GetMethod()))
+	case *user.SearchQuery_FirstNameQuery:
+		return query.NewUserFirstNameSearchQuery(q.FirstNameQuery.GetFirstName(), object.TextMethodToQuery(q.FirstNameQuery.GetMethod()))
+	case *user.SearchQuery_LastNameQuery:
+		return query.NewUserLastNameSearchQuery(q.LastNameQuery.GetLastName(), object.TextMethodToQuery(q.LastNameQuery.GetMethod()))
+	case *user.SearchQuery_NickNameQuery:
+		return query.NewUserNickNameSearchQuery(q.NickNameQuery.GetNickName(), object.TextMethodToQuery(q.NickNameQuery.GetMethod()))
+	case *user.SearchQuery_DisplayNameQuery:
+		return query.NewUserDisplayNameSearchQuery(q.DisplayNameQuery.GetDisplayName(), object.TextMethodToQuery(q.DisplayNameQuery.GetMethod()))
+	case *user.SearchQuery_EmailQuery:
+		return query.NewUserEmailSearchQuery(q.EmailQuery.GetEmailAddress(), object.TextMethodToQuery(q.EmailQuery.GetMethod()))
+	case *user.SearchQuery_PhoneQuery:
+		return query.NewUserPhoneSearchQuery(q.PhoneQuery.GetNumber(), object.TextMethodToQuery(q.PhoneQuery.GetMethod()))
+	case *user.SearchQuery_StateQuery:
+		return query.NewUserStateSearchQuery(int32(userStateToDomain(q.StateQuery.GetState())))
+	case *user.SearchQuery_TypeQuery:
+		return query.NewUserTypeSearchQuery(int32(userTypeToDomain(q.TypeQuery.GetType())))
+	case *user.SearchQuery_LoginNameQuery:
+		return query.NewUserLoginNameExistsQuery(q.LoginNameQuery.GetLoginName(), object.TextMethodToQuery(q.LoginNameQuery.GetMethod()))
+	case *user.SearchQuery_OrganisationIdQuery:
+		return query.NewUserResourceOwnerSearchQuery(q.OrganisationIdQuery.GetOrganisationId(), query.TextEquals)
+	case *user.SearchQuery_InUserIdsQuery:
+		return query.NewUserInUserIDsSearchQuery(q.InUserIdsQuery.GetUserIds())
+	case *user.SearchQuery_OrQuery:
+		queries, err := userQueriesToQuery(q.OrQuery.GetQueries(), level+1)
+		if err != nil {
+			return nil, err
+		}
+		return query.Or(queries...), nil
+	case *user.SearchQuery_AndQuery:
+		queries, err := userQueriesToQuery(q.AndQuery.GetQueries(), level+1)
+		if err != nil {
+			return nil, err
+		}
+		return query.And(queries...), nil
+	case *user.SearchQuery_NotQuery:
+		notQuery, err := userQueryToQuery(q.NotQuery.GetQuery(), level+1)
+		if err != nil {
+			return nil, err
+		}
+		return query.Not(notQuery), nil
+	default:
+		return nil, caos_errs.ThrowInvalidArgument(nil, "GRPC-vR9nC", "List.Query.Invalid")
+	}
+}
+
+func userStateToDomain(state user.UserState) domain.UserState {
+	switch state {
+	case user.UserState_USER_STATE_ACTIVE:
+		return domain.UserStateActive
+	case user.UserState_USER_STATE_INACTIVE:
+		return domain.UserStateInactive
+	case user.UserState_USER_STATE_DELETED:
+		return domain.UserStateDeleted
+	case user.UserState_USER_STATE_LOCKED:
+		return domain.UserStateLocked
+	case user.UserState_USER_STATE_INITIAL:
+		return domain.UserStateInitial
+	default:
+		return domain.UserStateUnspecified
+	}
+}
+
+func userTypeToDomain(userType user.Type) domain.UserType {
+	switch userType {
+	case user.Type_TYPE_HUMAN:
+		return domain.UserTypeHuman
+	case user.Type_TYPE_MACHINE:
+		return domain.UserTypeMachine
+	default:
+		return domain.UserTypeUnspecified
+	}
+}
@@ -0,0 +1,81 @@
+package user
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	object "github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	object_pb "github.com/zitadel/zitadel/pkg/grpc/object/v2alpha"
+	"github.com/zitadel/zitadel/pkg/grpc/user/v2alpha"
+)
+
+func (s *Server) AddHumanUser(ctx context.Context, req *user.AddHumanUserRequest) (*user.AddHumanUserResponse, error) {
+	orgID, err := s.organisationToID(ctx, req.GetOrganisation())
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.AddUserHuman(ctx, orgID, req.GetUserId(), addUserRequestToAddHuman(req))
+	if err != nil {
+		return nil, err
+	}
+	return &user.AddHumanUserResponse{
+		UserId:  details.ID,
+		Details: object.DomainToDetailsPb(&details.ObjectDetails),
+	}, nil
+}
+
+func addUserRequestToAddHuman(req *user.AddHumanUserRequest) *command.AddHuman {
+	username := req.GetUsername()
+	if username == "" {
+		username = req.GetEmail().GetEmail()
+	}
+	return &command.AddHuman{
+		Username:          username,
+		FirstName:         req.GetProfile().GetGivenName(),
+		LastName:          req.GetProfile().GetFamilyName(),
+		NickName:          req.GetProfile().GetNickName(),
+		DisplayName:       req.GetProfile().GetDisplayName(),
+		PreferredLanguage: language.Make(req.GetProfile().GetPreferredLanguage()),
+		Gender:            genderToDomain(req.GetProfile().GetGender()),
+		Email: command.Email{
+			Address:  domain.EmailAddress(req.GetEmail().GetEmail()),
+			Verified: req.GetEmail().GetIsVerified(),
+		},
+		Password:               req.GetPassword().GetPassword(),
+		PasswordChangeRequired: req.GetPassword().GetChangeRequired(),
+	}
+}
+
+// organisationToID returns the id of the requested organisation,
+// if none is requested, the organisation of the authenticated user is used
+func (s *Server) organisationToID(ctx context.Context, org *object_pb.Organisation) (string, error) {
+	switch o := org.GetOrg().(type) {
+	case *object_pb.Organisation_OrgId:
+		return o.OrgId, nil
+	case *object_pb.Organisation_OrgDomain:
+		organisation, err := s.query.OrgByPrimaryDomain(ctx, o.OrgDomain)
+		if err != nil {
+			return "", err
+		}
+		return organisation.ID, nil
+	default:
+		return authz.GetCtxData(ctx).OrgID, nil
+	}
+}
+
+func genderToDomain(gender user.Gender) domain.Gender {
+	switch gender {
+	case user.Gender_GENDER_FEMALE:
+		return domain.GenderFemale
+	case user.Gender_GENDER_MALE:
+		return domain.GenderMale
+	case user.Gender_GENDER_DIVERSE:
+		return domain.GenderDiverse
+	default:
+		return domain.GenderUnspecified
+	}
+}
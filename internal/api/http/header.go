@@ -37,6 +37,13 @@ const (
 	PermissionsPolicy       = "permissions-policy"
 
 	ZitadelOrgID = "x-zitadel-orgid"
+	// ZitadelDPoPMethod and ZitadelDPoPPath pass the original http method and path of gateway requests
+	// to the grpc server for the verification of DPoP proofs
+	ZitadelDPoPMethod = "x-zitadel-dpop-method"
+	ZitadelDPoPPath   = "x-zitadel-dpop-path"
+	// ZitadelGateway authenticates the requests of the gateway to the grpc server,
+	// only then the information of the original http request passed by the gateway is trusted
+	ZitadelGateway = "x-zitadel-gateway"
 )
 
 type key int
@@ -112,6 +119,15 @@ func GetForwardedFor(headers http.Header) (string, bool) {
 	return "", false
 }
 
+// RemoteAddrToContext sets the x-forwarded-for header and the remote address of calls,
+// which aren't handled by CopyHeadersToContext (e.g. gRPC), so RemoteIPFromCtx returns the IP of the caller
+func RemoteAddrToContext(ctx context.Context, forwardedFor []string, addr string) context.Context {
+	if _, ok := HeadersFromCtx(ctx); !ok && len(forwardedFor) > 0 {
+		ctx = context.WithValue(ctx, httpHeaders, http.Header{ForwardedFor: forwardedFor})
+	}
+	return context.WithValue(ctx, remoteAddr, addr)
+}
+
 func RemoteAddrFromCtx(ctx context.Context) string {
 	ctxRemoteAddr, _ := ctx.Value(remoteAddr).(string)
 	return ctxRemoteAddr
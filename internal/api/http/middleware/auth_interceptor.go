@@ -62,6 +62,15 @@ func authorize(r *http.Request, verifier *authz.TokenVerifier, authConfig authz.
 		return nil, errors.New("auth header missing")
 	}
 
+	if proof := r.Header.Get(authz.DPoPHeader); proof != "" {
+		authCtx = authz.SetDPoPRequest(authCtx, &authz.DPoPRequest{
+			Proof:  proof,
+			Method: r.Method,
+			Host:   authz.GetInstance(authCtx).RequestedHost(),
+			Path:   r.URL.Path,
+		})
+	}
+
 	ctxSetter, err := authz.CheckUserAuthorization(authCtx, &httpReq{}, authToken, http_util.GetOrgID(r), verifier, authConfig, authOpt, r.RequestURI)
 	if err != nil {
 		return nil, err
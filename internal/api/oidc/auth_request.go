@@ -11,6 +11,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -24,7 +25,7 @@ func (o *OPStorage) CreateAuthRequest(ctx context.Context, req *oidc.AuthRequest
 	if !ok {
 		return nil, errors.ThrowPreconditionFailed(nil, "OIDC-sd436", "no user agent id")
 	}
-	req.Scopes, err = o.assertProjectRoleScopes(ctx, req.ClientID, req.Scopes)
+	req.Scopes, err = o.assertProjectRoleScopes(ctx, req.ClientID, domain.RemoveImpersonationActorScopes(req.Scopes))
 	if err != nil {
 		return nil, errors.ThrowPreconditionFailed(err, "OIDC-Gqrfg", "Errors.Internal")
 	}
@@ -47,6 +48,9 @@ func (o *OPStorage) AuthRequestByID(ctx context.Context, id string) (_ op.AuthRe
 	if err != nil {
 		return nil, err
 	}
+	if err = o.impersonateAuthRequest(ctx, resp); err != nil {
+		return nil, err
+	}
 	return AuthRequestFromBusiness(resp)
 }
 
@@ -58,9 +62,35 @@ func (o *OPStorage) AuthRequestByCode(ctx context.Context, code string) (_ op.Au
 	if err != nil {
 		return nil, err
 	}
+	if err = o.impersonateAuthRequest(ctx, resp); err != nil {
+		return nil, err
+	}
 	return AuthRequestFromBusiness(resp)
 }
 
+// impersonateAuthRequest replaces the authenticated user of a finished login by the user requested in the impersonation scope.
+// The authenticated user is kept as actor in the scopes, so the tokens of the impersonated user assert the actor claim.
+func (o *OPStorage) impersonateAuthRequest(ctx context.Context, authReq *domain.AuthRequest) error {
+	oidcReq, ok := authReq.Request.(*domain.AuthRequestOIDC)
+	if !ok || !authReq.Done() {
+		return nil
+	}
+	userID := domain.ImpersonatedUserFromScopes(oidcReq.Scopes)
+	if userID == "" {
+		return nil
+	}
+	projectID, err := o.query.ProjectIDFromOIDCClientID(ctx, authReq.ApplicationID, false)
+	if err != nil {
+		return err
+	}
+	if err = o.command.CheckLoginImpersonation(ctx, authReq.UserID, userID, projectID); err != nil {
+		return err
+	}
+	oidcReq.Scopes = append(domain.RemoveImpersonationActorScopes(oidcReq.Scopes), domain.ImpersonationActorScope+authReq.UserID)
+	authReq.UserID = userID
+	return nil
+}
+
 func (o *OPStorage) SaveAuthCode(ctx context.Context, id, code string) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -93,8 +123,19 @@ func (o *OPStorage) CreateAccessToken(ctx context.Context, req op.TokenRequest)
 	if err != nil {
 		return "", time.Time{}, err
 	}
+	if actorUserID := domain.ImpersonationActorFromScopes(req.GetScopes()); actorUserID != "" {
+		projectID, err := o.query.ProjectIDFromOIDCClientID(ctx, applicationID, false)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		resp, err := o.command.AddLoginImpersonationToken(setContextUserSystem(ctx), actorUserID, userOrgID, userAgentID, applicationID, req.GetSubject(), projectID, DPoPJKTFromCtx(ctx), req.GetAudience(), req.GetScopes(), accessTokenLifetime)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		return resp.TokenID, resp.Expiration, nil
+	}
 
-	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), accessTokenLifetime) //PLANNED: lifetime from client
+	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), DPoPJKTFromCtx(ctx), req.GetAudience(), req.GetScopes(), accessTokenLifetime) //PLANNED: lifetime from client
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -105,6 +146,9 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 	userAgentID, applicationID, userOrgID, authTime, authMethodsReferences := getInfoFromRequest(req)
+	if domain.ImpersonationActorFromScopes(req.GetScopes()) != "" {
+		return "", "", time.Time{}, errors.ThrowPreconditionFailed(nil, "OIDC-Im9rt", "Errors.User.Impersonation.RefreshTokenNotAllowed")
+	}
 	scopes, err := o.assertProjectRoleScopes(ctx, applicationID, req.GetScopes())
 	if err != nil {
 		return "", "", time.Time{}, errors.ThrowPreconditionFailed(err, "OIDC-Df2fq", "Errors.Internal")
@@ -119,7 +163,7 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 	}
 
 	resp, token, err := o.command.AddAccessAndRefreshToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(),
-		refreshToken, req.GetAudience(), scopes, authMethodsReferences, accessTokenLifetime,
+		refreshToken, DPoPJKTFromCtx(ctx), req.GetAudience(), scopes, authMethodsReferences, accessTokenLifetime,
 		refreshTokenIdleExpiration, refreshTokenExpiration, authTime) //PLANNED: lifetime from client
 	if err != nil {
 		if errors.IsErrorInvalidArgument(err) {
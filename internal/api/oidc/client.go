@@ -31,9 +31,12 @@ const (
 	ClaimProjectRolesFormat = "urn:zitadel:iam:org:project:%s:roles"
 	ScopeUserMetaData       = "urn:zitadel:iam:user:metadata"
 	ClaimUserMetaData       = ScopeUserMetaData
+	ScopeUserGroups         = "urn:zitadel:iam:user:groups"
+	ClaimUserGroups         = "groups"
 	ScopeResourceOwner      = "urn:zitadel:iam:user:resourceowner"
 	ClaimResourceOwner      = ScopeResourceOwner + ":"
 	ClaimActionLogFormat    = "urn:zitadel:iam:action:%s:log"
+	ClaimActor              = "act"
 
 	oidcCtx = "oidc"
 )
@@ -123,6 +126,9 @@ func (o *OPStorage) SetUserinfoFromToken(ctx context.Context, userInfo *oidc.Use
 	if err != nil {
 		return errors.ThrowPermissionDenied(nil, "OIDC-Dsfb2", "token is not valid or has expired")
 	}
+	if err = checkDPoPBinding(ctx, token.DPoPJKT); err != nil {
+		return err
+	}
 	if token.ApplicationID != "" {
 		app, err := o.query.AppByOIDCClientID(ctx, token.ApplicationID, false)
 		if err != nil {
@@ -132,7 +138,13 @@ func (o *OPStorage) SetUserinfoFromToken(ctx context.Context, userInfo *oidc.Use
 			return errors.ThrowPermissionDenied(nil, "OIDC-da1f3", "origin is not allowed")
 		}
 	}
-	return o.setUserinfo(ctx, userInfo, token.UserID, token.ApplicationID, token.Scopes, nil)
+	if err = o.setUserinfo(ctx, userInfo, token.UserID, token.ApplicationID, token.Scopes, nil); err != nil {
+		return err
+	}
+	if token.ActorUserID != "" {
+		userInfo.AppendClaims(ClaimActor, actorClaim(token.ActorUserID))
+	}
+	return nil
 }
 
 func (o *OPStorage) SetUserinfoFromScopes(ctx context.Context, userInfo *oidc.UserInfo, userID, applicationID string, scopes []string) (err error) {
@@ -179,6 +191,14 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 			introspection.Scope = token.Scopes
 			introspection.ClientID = token.ApplicationID
 			introspection.TokenType = oidc.BearerToken
+			if token.DPoPJKT != "" {
+				introspection.TokenType = TokenTypeDPoP
+				introspection.Claims = appendClaim(introspection.Claims, ClaimConfirmation, confirmationClaim(token.DPoPJKT))
+			}
+			if token.ActorUserID != "" {
+				// the token was issued to an administrator impersonating the user (RFC 8693)
+				introspection.Claims = appendClaim(introspection.Claims, ClaimActor, actorClaim(token.ActorUserID))
+			}
 			introspection.Expiration = oidc.FromTime(token.Expiration)
 			introspection.IssuedAt = oidc.FromTime(token.CreationDate)
 			introspection.NotBefore = oidc.FromTime(token.CreationDate)
@@ -231,6 +251,7 @@ func (o *OPStorage) ClientCredentials(ctx context.Context, clientID, clientSecre
 }
 
 func (o *OPStorage) checkOrgScopes(ctx context.Context, user *query.User, scopes []string) ([]string, error) {
+	scopes = domain.RemoveImpersonationActorScopes(scopes)
 	for i := len(scopes) - 1; i >= 0; i-- {
 		scope := scopes[i]
 		if strings.HasPrefix(scope, domain.OrgDomainPrimaryScope) {
@@ -281,6 +302,10 @@ func (o *OPStorage) setUserinfo(ctx context.Context, userInfo *oidc.UserInfo, us
 			if err := o.setUserInfoMetadata(ctx, userInfo, userID); err != nil {
 				return err
 			}
+		case ScopeUserGroups:
+			if err := o.setUserInfoGroups(ctx, userInfo, userID); err != nil {
+				return err
+			}
 		case ScopeResourceOwner:
 			if err := o.setUserInfoResourceOwner(ctx, userInfo, userID); err != nil {
 				return err
@@ -291,6 +316,9 @@ func (o *OPStorage) setUserinfo(ctx context.Context, userInfo *oidc.UserInfo, us
 			if strings.HasPrefix(scope, ScopeProjectRolePrefix) {
 				roles = append(roles, strings.TrimPrefix(scope, ScopeProjectRolePrefix))
 			}
+			if strings.HasPrefix(scope, domain.ImpersonationActorScope) {
+				userInfo.AppendClaims(ClaimActor, actorClaim(strings.TrimPrefix(scope, domain.ImpersonationActorScope)))
+			}
 			if strings.HasPrefix(scope, domain.OrgDomainPrimaryScope) {
 				userInfo.AppendClaims(domain.OrgDomainPrimaryClaim, strings.TrimPrefix(scope, domain.OrgDomainPrimaryScope))
 			}
@@ -363,6 +391,17 @@ func (o *OPStorage) setUserInfoMetadata(ctx context.Context, userInfo *oidc.User
 	return nil
 }
 
+func (o *OPStorage) setUserInfoGroups(ctx context.Context, userInfo *oidc.UserInfo, userID string) error {
+	groups, err := o.query.GroupNamesOfUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(groups) > 0 {
+		userInfo.AppendClaims(ClaimUserGroups, groups)
+	}
+	return nil
+}
+
 func (o *OPStorage) setUserInfoResourceOwner(ctx context.Context, userInfo *oidc.UserInfo, userID string) error {
 	resourceOwnerClaims, err := o.assertUserResourceOwner(ctx, userID)
 	if err != nil {
@@ -523,6 +562,14 @@ func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clie
 			if len(userMetaData) > 0 {
 				claims = appendClaim(claims, ClaimUserMetaData, userMetaData)
 			}
+		case ScopeUserGroups:
+			groups, err := o.query.GroupNamesOfUser(ctx, userID)
+			if err != nil {
+				return nil, err
+			}
+			if len(groups) > 0 {
+				claims = appendClaim(claims, ClaimUserGroups, groups)
+			}
 		case ScopeResourceOwner:
 			resourceOwnerClaims, err := o.assertUserResourceOwner(ctx, userID)
 			if err != nil {
@@ -540,6 +587,9 @@ func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clie
 		if strings.HasPrefix(scope, domain.OrgDomainPrimaryScope) {
 			claims = appendClaim(claims, domain.OrgDomainPrimaryClaim, strings.TrimPrefix(scope, domain.OrgDomainPrimaryScope))
 		}
+		if strings.HasPrefix(scope, domain.ImpersonationActorScope) {
+			claims = appendClaim(claims, ClaimActor, actorClaim(strings.TrimPrefix(scope, domain.ImpersonationActorScope)))
+		}
 		if strings.HasPrefix(scope, domain.OrgIDScope) {
 			claims = appendClaim(claims, domain.OrgIDClaim, strings.TrimPrefix(scope, domain.OrgIDScope))
 			resourceOwnerClaims, err := o.assertUserResourceOwner(ctx, userID)
@@ -573,7 +623,14 @@ func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clie
 		}
 	}
 
-	return o.privateClaimsFlows(ctx, userID, userGrants, claims)
+	claims, err = o.privateClaimsFlows(ctx, userID, userGrants, claims)
+	if err != nil {
+		return nil, err
+	}
+	if jkt := DPoPJKTFromCtx(ctx); jkt != "" {
+		claims = appendClaim(claims, ClaimConfirmation, confirmationClaim(jkt))
+	}
+	return claims, nil
 }
 
 func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, userGrants *query.UserGrants, claims map[string]interface{}) (map[string]interface{}, error) {
@@ -720,7 +777,8 @@ func (o *OPStorage) assertRoles(ctx context.Context, userID, applicationID strin
 	}
 	queries = append(queries, userIDQuery)
 	grants, err := o.query.UserGrants(ctx, &query.UserGrantsQueries{
-		Queries: queries,
+		Queries:         queries,
+		WithGroupGrants: true,
 	}, true, false)
 	if err != nil {
 		return nil, nil, err
@@ -827,6 +885,11 @@ func getGender(gender domain.Gender) oidc.Gender {
 	return ""
 }
 
+// actorClaim represents the administrator impersonating the subject of a token (RFC 8693)
+func actorClaim(actorUserID string) map[string]interface{} {
+	return map[string]interface{}{"sub": actorUserID}
+}
+
 func appendClaim(claims map[string]interface{}, claim string, value interface{}) map[string]interface{} {
 	if claims == nil {
 		claims = make(map[string]interface{})
@@ -112,9 +112,15 @@ func (c *Client) IsScopeAllowed(scope string) bool {
 	if strings.HasPrefix(scope, domain.SelectIDPScope) {
 		return true
 	}
+	if strings.HasPrefix(scope, domain.ImpersonationScope) {
+		return true
+	}
 	if scope == ScopeUserMetaData {
 		return true
 	}
+	if scope == ScopeUserGroups {
+		return true
+	}
 	if scope == ScopeResourceOwner {
 		return true
 	}
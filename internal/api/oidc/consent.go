@@ -0,0 +1,50 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const (
+	authCallbackPathSuffix = "/callback"
+	authCallbackIDParam    = "id"
+)
+
+type authRequestProvider interface {
+	AuthRequestByID(ctx context.Context, id, userAgentID string) (*domain.AuthRequest, error)
+}
+
+// consentDeniedInterceptor answers the callback of an auth request, on which the user denied the consent,
+// with an access_denied error to the client. All other requests are passed to the provider,
+// which would answer the callback of a denied auth request with interaction_required.
+func consentDeniedInterceptor(authRequests authRequestProvider) func(http.Handler) http.Handler {
+	encoder := oidc.NewEncoder()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.URL.Query().Get(authCallbackIDParam)
+			if id == "" || !strings.HasSuffix(r.URL.Path, authCallbackPathSuffix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			userAgentID, _ := middleware.UserAgentIDFromCtx(r.Context())
+			authReq, err := authRequests.AuthRequestByID(r.Context(), id, userAgentID)
+			if err != nil || !authReq.ConsentDenied {
+				next.ServeHTTP(w, r)
+				return
+			}
+			opAuthReq, err := AuthRequestFromBusiness(authReq)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			op.AuthRequestError(w, r, opAuthReq, oidc.ErrAccessDenied().WithDescription("The user denied the consent."), encoder)
+		})
+	}
+}
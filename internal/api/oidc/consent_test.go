@@ -0,0 +1,92 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+type mockAuthRequests struct {
+	authRequest *domain.AuthRequest
+}
+
+func (m *mockAuthRequests) AuthRequestByID(_ context.Context, id, _ string) (*domain.AuthRequest, error) {
+	if m.authRequest == nil || m.authRequest.ID != id {
+		return nil, errors.ThrowNotFound(nil, "OIDC-Mk1nf", "Errors.AuthRequest.NotFound")
+	}
+	return m.authRequest, nil
+}
+
+func Test_consentDeniedInterceptor(t *testing.T) {
+	authRequest := func(denied bool) *domain.AuthRequest {
+		return &domain.AuthRequest{
+			ID:            "request1",
+			CallbackURI:   "https://client.example.com/callback",
+			TransferState: "state1",
+			Request:       &domain.AuthRequestOIDC{ResponseType: domain.OIDCResponseTypeCode},
+			ConsentDenied: denied,
+		}
+	}
+	tests := []struct {
+		name        string
+		authRequest *domain.AuthRequest
+		target      string
+		wantNext    bool
+		wantErr     string
+	}{
+		{
+			name:        "other endpoint, next",
+			authRequest: authRequest(true),
+			target:      "/oauth/v2/token?id=request1",
+			wantNext:    true,
+		},
+		{
+			name:        "auth request not found, next",
+			authRequest: authRequest(true),
+			target:      "/oauth/v2/authorize/callback?id=request2",
+			wantNext:    true,
+		},
+		{
+			name:        "consent not denied, next",
+			authRequest: authRequest(false),
+			target:      "/oauth/v2/authorize/callback?id=request1",
+			wantNext:    true,
+		},
+		{
+			name:        "consent denied, access_denied error",
+			authRequest: authRequest(true),
+			target:      "/oauth/v2/authorize/callback?id=request1",
+			wantErr:     "access_denied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var nextCalled bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+			})
+			handler := consentDeniedInterceptor(&mockAuthRequests{authRequest: tt.authRequest})(next)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, tt.wantNext, nextCalled)
+			if tt.wantNext {
+				return
+			}
+			require.Equal(t, http.StatusFound, w.Code)
+			location, err := url.Parse(w.Header().Get("Location"))
+			require.NoError(t, err)
+			assert.Equal(t, "client.example.com", location.Host)
+			assert.Equal(t, tt.wantErr, location.Query().Get("error"))
+			assert.Equal(t, "state1", location.Query().Get("state"))
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	// TokenTypeDPoP is returned as token_type for sender-constrained access tokens (RFC 9449)
+	TokenTypeDPoP     = "DPoP"
+	ClaimConfirmation = "cnf"
+
+	errorInvalidDPoPProof = "invalid_dpop_proof"
+)
+
+type dpopKey struct{}
+
+type dpopError struct {
+	ErrorType   string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+// DPoPInterceptor verifies the DPoP proof of requests to the token and userinfo endpoint
+// and passes the thumbprint of the proof key (jkt) in the context,
+// so issued tokens can be bound to it and bound tokens can be checked against it.
+// Used proofs are remembered in the shared proof store to prevent their replay.
+func DPoPInterceptor(proofs authz.DPoPProofStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return dpopInterceptor(next, proofs)
+	}
+}
+
+func dpopInterceptor(next http.Handler, proofs authz.DPoPProofStore) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proof := r.Header.Get(authz.DPoPHeader)
+		if proof == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		var accessToken string
+		if auth := r.Header.Get(http_utils.Authorization); strings.HasPrefix(auth, authz.DPoPPrefix) {
+			accessToken = strings.TrimPrefix(auth, authz.DPoPPrefix)
+			// the bound token will be checked against the jkt of the context, the provider itself only understands bearer tokens
+			r.Header.Set(http_utils.Authorization, oidc.PrefixBearer+accessToken)
+		}
+		jkt, err := authz.VerifyDPoPProof(
+			r.Context(),
+			proofs,
+			&authz.DPoPRequest{
+				Proof:  proof,
+				Method: r.Method,
+				Host:   authz.GetInstance(r.Context()).RequestedHost(),
+				Path:   r.URL.Path,
+			},
+			accessToken,
+			authz.DefaultDPoPProofMaxAge,
+		)
+		if err != nil {
+			dpopRequestError(w, accessToken != "", err)
+			return
+		}
+		r = r.WithContext(context.WithValue(r.Context(), dpopKey{}, jkt))
+		if accessToken != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		tokenWriter := &dpopTokenResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(tokenWriter, r)
+		tokenWriter.flush()
+	})
+}
+
+// DPoPJKTFromCtx returns the thumbprint of the key of the verified DPoP proof, if any
+func DPoPJKTFromCtx(ctx context.Context) string {
+	jkt, _ := ctx.Value(dpopKey{}).(string)
+	return jkt
+}
+
+func checkDPoPBinding(ctx context.Context, boundJKT string) error {
+	if boundJKT == DPoPJKTFromCtx(ctx) {
+		return nil
+	}
+	return errors.ThrowPermissionDenied(nil, "OIDC-Dpo3f", "token is bound to another key")
+}
+
+func confirmationClaim(jkt string) map[string]interface{} {
+	return map[string]interface{}{"jkt": jkt}
+}
+
+func dpopRequestError(w http.ResponseWriter, resourceRequest bool, err error) {
+	status := http.StatusBadRequest
+	if resourceRequest {
+		status = http.StatusUnauthorized
+		w.Header().Set("WWW-Authenticate", TokenTypeDPoP+` error="`+errorInvalidDPoPProof+`"`)
+	}
+	httphelper.MarshalJSONWithStatus(w, &dpopError{ErrorType: errorInvalidDPoPProof, Description: err.Error()}, status)
+}
+
+// dpopTokenResponseWriter buffers the token response
+// to change the token_type of the issued (bound) access token from Bearer to DPoP
+type dpopTokenResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *dpopTokenResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *dpopTokenResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *dpopTokenResponseWriter) flush() {
+	body := w.body.Bytes()
+	if w.status == http.StatusOK {
+		body = setDPoPTokenType(body)
+	}
+	w.ResponseWriter.Header().Set(http_utils.ContentLength, strconv.Itoa(len(body)))
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(body)
+}
+
+func setDPoPTokenType(body []byte) []byte {
+	response := make(map[string]interface{})
+	if err := json.Unmarshal(body, &response); err != nil {
+		return body
+	}
+	if tokenType, _ := response["token_type"].(string); !strings.EqualFold(tokenType, oidc.BearerToken) {
+		return body
+	}
+	response["token_type"] = TokenTypeDPoP
+	changed, err := json.Marshal(response)
+	if err != nil {
+		return body
+	}
+	return changed
+}
@@ -0,0 +1,361 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	// RegistrationEndpoint is the client registration endpoint (RFC 7591),
+	// the client configuration endpoint (RFC 7592) of each registered client is below it
+	RegistrationEndpoint = "/oauth/v2/register"
+
+	registrationClientIDParam = "client_id"
+	// registrationProjectIDParam is the query parameter of the project
+	// a client is registered in with a personal access token instead of a registration token
+	registrationProjectIDParam = "project_id"
+
+	applicationTypeWeb    = "web"
+	applicationTypeNative = "native"
+
+	errorInvalidToken          = "invalid_token"
+	errorInsufficientScope     = "insufficient_scope"
+	errorInvalidClientMetadata = "invalid_client_metadata"
+)
+
+// clientMetadata are the supported client metadata of RFC 7591 and OpenID Connect Dynamic Client Registration
+type clientMetadata struct {
+	RedirectURIs            []string            `json:"redirect_uris,omitempty"`
+	PostLogoutRedirectURIs  []string            `json:"post_logout_redirect_uris,omitempty"`
+	TokenEndpointAuthMethod oidc.AuthMethod     `json:"token_endpoint_auth_method,omitempty"`
+	GrantTypes              []oidc.GrantType    `json:"grant_types,omitempty"`
+	ResponseTypes           []oidc.ResponseType `json:"response_types,omitempty"`
+	ApplicationType         string              `json:"application_type,omitempty"`
+	ClientName              string              `json:"client_name,omitempty"`
+}
+
+type clientInformation struct {
+	clientMetadata
+	ClientID                string `json:"client_id"`
+	ClientSecret            string `json:"client_secret,omitempty"`
+	ClientSecretExpiresAt   *int64 `json:"client_secret_expires_at,omitempty"`
+	RegistrationAccessToken string `json:"registration_access_token,omitempty"`
+	RegistrationClientURI   string `json:"registration_client_uri"`
+}
+
+type registrationError struct {
+	ErrorType   string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+type clientRegistration struct {
+	command        *command.Commands
+	query          *query.Queries
+	hashAlg        crypto.HashAlgorithm
+	verifier       *authz.TokenVerifier
+	externalSecure bool
+}
+
+// NewClientRegistrationHandler serves the registration of OIDC clients (RFC 7591)
+// in the project of the presented registration token
+// or in the passed project with a personal access token of a machine user allowed to write its applications
+// and the management of the registered clients with their registration access token (RFC 7592)
+func NewClientRegistrationHandler(command *command.Commands, query *query.Queries, hashAlg crypto.HashAlgorithm, verifier *authz.TokenVerifier, externalSecure bool, interceptors ...mux.MiddlewareFunc) http.Handler {
+	c := &clientRegistration{
+		command:        command,
+		query:          query,
+		hashAlg:        hashAlg,
+		verifier:       verifier,
+		externalSecure: externalSecure,
+	}
+	router := mux.NewRouter()
+	router.Use(interceptors...)
+	router.HandleFunc(RegistrationEndpoint, c.register).Methods(http.MethodPost)
+	clientRouter := router.PathPrefix(RegistrationEndpoint + "/{" + registrationClientIDParam + "}").Subrouter()
+	clientRouter.HandleFunc("", c.read).Methods(http.MethodGet)
+	clientRouter.HandleFunc("", c.update).Methods(http.MethodPut)
+	clientRouter.HandleFunc("", c.delete).Methods(http.MethodDelete)
+	return router
+}
+
+func (c *clientRegistration) register(w http.ResponseWriter, r *http.Request) {
+	ctx := setContextUserSystem(r.Context())
+	metadata := new(clientMetadata)
+	if err := json.NewDecoder(r.Body).Decode(metadata); err != nil {
+		registrationRequestError(w, errorInvalidClientMetadata, err)
+		return
+	}
+	app, err := metadata.toOIDCApp()
+	if err != nil {
+		registrationRequestError(w, errorInvalidClientMetadata, err)
+		return
+	}
+	generator, err := c.query.InitHashGenerator(ctx, domain.SecretGeneratorTypeAppSecret, c.hashAlg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var registrationAccessToken string
+	if projectID := r.URL.Query().Get(registrationProjectIDParam); projectID != "" {
+		ctx, err = c.machineUserContext(r)
+		if err != nil {
+			c.registrationError(w, err)
+			return
+		}
+		app, registrationAccessToken, err = c.command.RegisterOIDCClientInProject(ctx, projectID, app, generator)
+	} else {
+		app, registrationAccessToken, err = c.command.RegisterOIDCClient(ctx, bearerToken(r), app, generator)
+	}
+	if err != nil {
+		c.registrationError(w, err)
+		return
+	}
+	response := c.clientInformation(ctx, app)
+	response.ClientSecret = app.ClientSecretString
+	response.RegistrationAccessToken = registrationAccessToken
+	httphelper.MarshalJSONWithStatus(w, response, http.StatusCreated)
+}
+
+func (c *clientRegistration) read(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	projectID, appID, ok := c.registeredClient(w, r)
+	if !ok {
+		return
+	}
+	app, err := c.command.GetRegisteredOIDCClient(ctx, projectID, appID, bearerToken(r))
+	if err != nil {
+		c.registrationError(w, err)
+		return
+	}
+	httphelper.MarshalJSON(w, c.clientInformation(ctx, app))
+}
+
+func (c *clientRegistration) update(w http.ResponseWriter, r *http.Request) {
+	ctx := setContextUserSystem(r.Context())
+	projectID, appID, ok := c.registeredClient(w, r)
+	if !ok {
+		return
+	}
+	metadata := new(clientMetadata)
+	if err := json.NewDecoder(r.Body).Decode(metadata); err != nil {
+		registrationRequestError(w, errorInvalidClientMetadata, err)
+		return
+	}
+	app, err := metadata.toOIDCApp()
+	if err != nil {
+		registrationRequestError(w, errorInvalidClientMetadata, err)
+		return
+	}
+	app.AggregateID = projectID
+	app.AppID = appID
+	app, err = c.command.ChangeRegisteredOIDCClient(ctx, app, bearerToken(r))
+	if err != nil {
+		c.registrationError(w, err)
+		return
+	}
+	httphelper.MarshalJSON(w, c.clientInformation(ctx, app))
+}
+
+func (c *clientRegistration) delete(w http.ResponseWriter, r *http.Request) {
+	ctx := setContextUserSystem(r.Context())
+	projectID, appID, ok := c.registeredClient(w, r)
+	if !ok {
+		return
+	}
+	if _, err := c.command.RemoveRegisteredOIDCClient(ctx, projectID, appID, bearerToken(r)); err != nil {
+		c.registrationError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// registeredClient returns the project and app id of the client of the configuration endpoint,
+// unknown clients are answered the same as an invalid registration access token (RFC 7592 section 2)
+func (c *clientRegistration) registeredClient(w http.ResponseWriter, r *http.Request) (projectID, appID string, ok bool) {
+	app, err := c.query.AppByOIDCClientID(r.Context(), mux.Vars(r)[registrationClientIDParam], false)
+	if err != nil {
+		c.registrationError(w, errors.ThrowUnauthenticated(err, "OIDC-Rg1hs", "Errors.Project.App.RegistrationAccessTokenInvalid"))
+		return "", "", false
+	}
+	return app.ProjectID, app.ID, true
+}
+
+// machineUserContext verifies the personal access token of the request
+// and returns the context of its machine user, other users can't register clients
+func (c *clientRegistration) machineUserContext(r *http.Request) (context.Context, error) {
+	ctx := r.Context()
+	ctxData, err := authz.VerifyTokenAndCreateCtxData(ctx, r.Header.Get(http_utils.Authorization), "", c.verifier, RegistrationEndpoint)
+	if err != nil {
+		return nil, errors.ThrowUnauthenticated(err, "OIDC-Rg1pt", "Errors.Token.Invalid")
+	}
+	ctx = authz.SetCtxData(ctx, ctxData)
+	user, err := c.query.GetUserByID(ctx, false, ctxData.UserID, false)
+	if err != nil {
+		return nil, errors.ThrowUnauthenticated(err, "OIDC-Rg2pt", "Errors.Token.Invalid")
+	}
+	if user.Type != domain.UserTypeMachine {
+		return nil, errors.ThrowUnauthenticated(nil, "OIDC-Rg3pt", "Errors.Token.Invalid")
+	}
+	return ctx, nil
+}
+
+func (c *clientRegistration) clientInformation(ctx context.Context, app *domain.OIDCApp) *clientInformation {
+	expiresAt := int64(0)
+	information := &clientInformation{
+		clientMetadata: clientMetadata{
+			RedirectURIs:            app.RedirectUris,
+			PostLogoutRedirectURIs:  app.PostLogoutRedirectUris,
+			TokenEndpointAuthMethod: authMethodToOIDC(app.AuthMethodType),
+			GrantTypes:              grantTypesToOIDC(app.GrantTypes),
+			ResponseTypes:           responseTypesToOIDC(app.ResponseTypes),
+			ApplicationType:         applicationTypeWeb,
+			ClientName:              app.AppName,
+		},
+		ClientID:              app.ClientID,
+		RegistrationClientURI: http_utils.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), c.externalSecure) + RegistrationEndpoint + "/" + app.ClientID,
+	}
+	if app.ApplicationType == domain.OIDCApplicationTypeNative {
+		information.ApplicationType = applicationTypeNative
+	}
+	if app.AuthMethodType == domain.OIDCAuthMethodTypeBasic || app.AuthMethodType == domain.OIDCAuthMethodTypePost {
+		information.ClientSecretExpiresAt = &expiresAt
+	}
+	return information
+}
+
+func (c *clientRegistration) registrationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.IsUnauthenticated(err):
+		w.Header().Set("WWW-Authenticate", oidc.BearerToken+` error="`+errorInvalidToken+`"`)
+		httphelper.MarshalJSONWithStatus(w, &registrationError{ErrorType: errorInvalidToken}, http.StatusUnauthorized)
+	case errors.IsPermissionDenied(err):
+		httphelper.MarshalJSONWithStatus(w, &registrationError{ErrorType: errorInsufficientScope}, http.StatusForbidden)
+	case errors.IsPreconditionFailed(err):
+		registrationRequestError(w, errorInvalidClientMetadata, err)
+	case errors.IsErrorInvalidArgument(err):
+		registrationRequestError(w, errorInvalidClientMetadata, err)
+	default:
+		logging.WithError(err).Warn("client registration failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func registrationRequestError(w http.ResponseWriter, errorType string, err error) {
+	httphelper.MarshalJSONWithStatus(w, &registrationError{ErrorType: errorType, Description: err.Error()}, http.StatusBadRequest)
+}
+
+func bearerToken(r *http.Request) string {
+	auth := r.Header.Get(http_utils.Authorization)
+	if !strings.HasPrefix(auth, oidc.PrefixBearer) {
+		return ""
+	}
+	return strings.TrimPrefix(auth, oidc.PrefixBearer)
+}
+
+// toOIDCApp maps the client metadata to an OIDC application,
+// unset metadata are set to their defaults defined in RFC 7591 section 2
+func (m *clientMetadata) toOIDCApp() (*domain.OIDCApp, error) {
+	app := &domain.OIDCApp{
+		AppName:                m.ClientName,
+		OIDCVersion:            domain.OIDCVersionV1,
+		RedirectUris:           m.RedirectURIs,
+		PostLogoutRedirectUris: m.PostLogoutRedirectURIs,
+		AccessTokenType:        domain.OIDCTokenTypeBearer,
+	}
+	var err error
+	if app.AuthMethodType, err = authMethodFromOIDC(m.TokenEndpointAuthMethod); err != nil {
+		return nil, err
+	}
+	if app.GrantTypes, err = grantTypesFromOIDC(m.GrantTypes); err != nil {
+		return nil, err
+	}
+	if app.ResponseTypes, err = responseTypesFromOIDC(m.ResponseTypes); err != nil {
+		return nil, err
+	}
+	switch m.ApplicationType {
+	case "", applicationTypeWeb:
+		app.ApplicationType = domain.OIDCApplicationTypeWeb
+		// public clients running in the browser
+		if app.AuthMethodType == domain.OIDCAuthMethodTypeNone {
+			app.ApplicationType = domain.OIDCApplicationTypeUserAgent
+		}
+	case applicationTypeNative:
+		app.ApplicationType = domain.OIDCApplicationTypeNative
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "OIDC-Rg2hs", "unsupported application_type %s", m.ApplicationType)
+	}
+	if app.AppName == "" && len(app.RedirectUris) > 0 {
+		app.AppName = app.RedirectUris[0]
+	}
+	return app, nil
+}
+
+func authMethodFromOIDC(authMethod oidc.AuthMethod) (domain.OIDCAuthMethodType, error) {
+	switch authMethod {
+	case "", oidc.AuthMethodBasic:
+		return domain.OIDCAuthMethodTypeBasic, nil
+	case oidc.AuthMethodPost:
+		return domain.OIDCAuthMethodTypePost, nil
+	case oidc.AuthMethodNone:
+		return domain.OIDCAuthMethodTypeNone, nil
+	default:
+		return 0, errors.ThrowInvalidArgumentf(nil, "OIDC-Rg3hs", "unsupported token_endpoint_auth_method %s", authMethod)
+	}
+}
+
+func grantTypesFromOIDC(grantTypes []oidc.GrantType) ([]domain.OIDCGrantType, error) {
+	if len(grantTypes) == 0 {
+		return []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode}, nil
+	}
+	domainTypes := make([]domain.OIDCGrantType, len(grantTypes))
+	for i, grantType := range grantTypes {
+		switch grantType {
+		case oidc.GrantTypeCode:
+			domainTypes[i] = domain.OIDCGrantTypeAuthorizationCode
+		case oidc.GrantTypeImplicit:
+			domainTypes[i] = domain.OIDCGrantTypeImplicit
+		case oidc.GrantTypeRefreshToken:
+			domainTypes[i] = domain.OIDCGrantTypeRefreshToken
+		case oidc.GrantTypeDeviceCode:
+			domainTypes[i] = domain.OIDCGrantTypeDeviceCode
+		default:
+			return nil, errors.ThrowInvalidArgumentf(nil, "OIDC-Rg4hs", "unsupported grant_type %s", grantType)
+		}
+	}
+	return domainTypes, nil
+}
+
+func responseTypesFromOIDC(responseTypes []oidc.ResponseType) ([]domain.OIDCResponseType, error) {
+	if len(responseTypes) == 0 {
+		return []domain.OIDCResponseType{domain.OIDCResponseTypeCode}, nil
+	}
+	domainTypes := make([]domain.OIDCResponseType, len(responseTypes))
+	for i, responseType := range responseTypes {
+		switch responseType {
+		case oidc.ResponseTypeCode:
+			domainTypes[i] = domain.OIDCResponseTypeCode
+		case oidc.ResponseTypeIDToken:
+			domainTypes[i] = domain.OIDCResponseTypeIDTokenToken
+		case oidc.ResponseTypeIDTokenOnly:
+			domainTypes[i] = domain.OIDCResponseTypeIDToken
+		default:
+			return nil, errors.ThrowInvalidArgumentf(nil, "OIDC-Rg5hs", "unsupported response_type %s", responseType)
+		}
+	}
+	return domainTypes, nil
+}
@@ -0,0 +1,188 @@
+package saml
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	nameIDFormatEmail        = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	nameIDFormatPersistent   = "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent"
+	nameIDFormatTransient    = "urn:oasis:names:tc:SAML:2.0:nameid-format:transient"
+	attributeNameFormatURI   = "urn:oasis:names:tc:SAML:2.0:attrname-format:uri"
+	attributeNameFormatBasic = "urn:oasis:names:tc:SAML:2.0:attrname-format:basic"
+)
+
+// assertionAttributes returns the subject and the attributes of the assertion
+// as defined by the NameID format and the attribute mappings of the application
+func (p *Storage) assertionAttributes(ctx context.Context, app *query.App, userID string) (*saml.NameIDType, []*saml.AttributeType, error) {
+	user, err := p.query.GetUserByID(ctx, true, userID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	nameID := samlNameID(user, app.SAMLConfig.NameIDFormat)
+	if len(app.SAMLConfig.AttributeMappings) == 0 {
+		attributes := &provider.Attributes{}
+		setUserinfo(user, attributes, nil)
+		return nameID, attributes.GetSAML(), nil
+	}
+	mapper := &attributeMapper{
+		storage:   p,
+		user:      user,
+		projectID: app.ProjectID,
+	}
+	attributes := make([]*saml.AttributeType, 0, len(app.SAMLConfig.AttributeMappings))
+	for _, mapping := range app.SAMLConfig.AttributeMappings {
+		values, err := mapper.values(ctx, mapping)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(values) == 0 {
+			continue
+		}
+		attributes = append(attributes, &saml.AttributeType{
+			Name:           mapping.Name,
+			NameFormat:     attributeNameFormat(mapping.Name),
+			FriendlyName:   mapping.FriendlyName,
+			AttributeValue: values,
+		})
+	}
+	return nameID, attributes, nil
+}
+
+func samlNameID(user *query.User, format domain.SAMLNameIDFormat) *saml.NameIDType {
+	switch format {
+	case domain.SAMLNameIDFormatEmail:
+		if user.Human != nil && user.Human.Email != "" {
+			return &saml.NameIDType{Format: nameIDFormatEmail, Text: string(user.Human.Email)}
+		}
+	case domain.SAMLNameIDFormatPersistent:
+		return &saml.NameIDType{Format: nameIDFormatPersistent, Text: user.ID}
+	case domain.SAMLNameIDFormatTransient:
+		return &saml.NameIDType{Format: nameIDFormatTransient, Text: provider.NewID()}
+	}
+	return &saml.NameIDType{Format: nameIDFormatEmail, Text: user.PreferredLoginName}
+}
+
+// attributeNameFormat returns the uri format for names like urn:oid:0.9.2342.19200300.100.1.3
+func attributeNameFormat(name string) string {
+	if strings.HasPrefix(name, "urn:") || strings.Contains(name, "://") {
+		return attributeNameFormatURI
+	}
+	return attributeNameFormatBasic
+}
+
+// attributeMapper resolves the values of the mappings,
+// the metadata and grants of the user are only queried if mapped
+type attributeMapper struct {
+	storage   *Storage
+	user      *query.User
+	projectID string
+
+	metadata map[string]string
+	roles    []string
+}
+
+func (m *attributeMapper) values(ctx context.Context, mapping *domain.SAMLAttributeMapping) ([]string, error) {
+	switch mapping.Source {
+	case domain.SAMLAttributeSourceUserID:
+		return nonEmpty(m.user.ID), nil
+	case domain.SAMLAttributeSourceUsername:
+		return nonEmpty(m.user.PreferredLoginName), nil
+	case domain.SAMLAttributeSourceMetadata:
+		metadata, err := m.userMetadata(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return nonEmpty(metadata[mapping.MetadataKey]), nil
+	case domain.SAMLAttributeSourceRoles:
+		return m.userRoles(ctx)
+	case domain.SAMLAttributeSourceGroups:
+		return m.storage.query.GroupNamesOfUser(ctx, m.user.ID)
+	}
+	if m.user.Human == nil {
+		return nil, nil
+	}
+	switch mapping.Source {
+	case domain.SAMLAttributeSourceEmail:
+		return nonEmpty(string(m.user.Human.Email)), nil
+	case domain.SAMLAttributeSourceFirstName:
+		return nonEmpty(m.user.Human.FirstName), nil
+	case domain.SAMLAttributeSourceLastName:
+		return nonEmpty(m.user.Human.LastName), nil
+	case domain.SAMLAttributeSourceDisplayName:
+		return nonEmpty(m.user.Human.DisplayName), nil
+	case domain.SAMLAttributeSourceNickName:
+		return nonEmpty(m.user.Human.NickName), nil
+	case domain.SAMLAttributeSourcePhone:
+		return nonEmpty(string(m.user.Human.Phone)), nil
+	case domain.SAMLAttributeSourcePreferredLanguage:
+		if m.user.Human.PreferredLanguage.IsRoot() {
+			return nil, nil
+		}
+		return nonEmpty(m.user.Human.PreferredLanguage.String()), nil
+	}
+	return nil, nil
+}
+
+func (m *attributeMapper) userMetadata(ctx context.Context) (map[string]string, error) {
+	if m.metadata != nil {
+		return m.metadata, nil
+	}
+	metadata, err := m.storage.query.SearchUserMetadata(ctx, true, m.user.ID, &query.UserMetadataSearchQueries{}, false)
+	if err != nil {
+		return nil, err
+	}
+	m.metadata = make(map[string]string, len(metadata.Metadata))
+	for _, md := range metadata.Metadata {
+		m.metadata[md.Key] = string(md.Value)
+	}
+	return m.metadata, nil
+}
+
+// userRoles returns the granted roles of the user on the project of the application
+func (m *attributeMapper) userRoles(ctx context.Context) ([]string, error) {
+	if m.roles != nil {
+		return m.roles, nil
+	}
+	projectQuery, err := query.NewUserGrantProjectIDSearchQuery(m.projectID)
+	if err != nil {
+		return nil, err
+	}
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(m.user.ID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := m.storage.query.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries:         []query.SearchQuery{projectQuery, userIDQuery},
+		WithGroupGrants: true,
+	}, true, false)
+	if err != nil {
+		return nil, err
+	}
+	m.roles = make([]string, 0)
+	granted := make(map[string]struct{})
+	for _, grant := range grants.UserGrants {
+		for _, role := range grant.Roles {
+			if _, ok := granted[role]; ok {
+				continue
+			}
+			granted[role] = struct{}{}
+			m.roles = append(m.roles, role)
+		}
+	}
+	return m.roles, nil
+}
+
+func nonEmpty(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
@@ -0,0 +1,219 @@
+package saml
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+
+	"github.com/beevik/etree"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	xmlencNamespace   = "http://www.w3.org/2001/04/xmlenc#"
+	xmldsigNamespace  = "http://www.w3.org/2000/09/xmldsig#"
+	xmlencElementType = xmlencNamespace + "Element"
+
+	encryptionAES128CBC = xmlencNamespace + "aes128-cbc"
+	encryptionAES256CBC = xmlencNamespace + "aes256-cbc"
+	encryptionAES128GCM = "http://www.w3.org/2009/xmlenc11#aes128-gcm"
+	encryptionAES256GCM = "http://www.w3.org/2009/xmlenc11#aes256-gcm"
+
+	keyTransportRSAOAEP = xmlencNamespace + "rsa-oaep-mgf1p"
+	digestSHA1          = xmldsigNamespace + "sha1"
+)
+
+type encryptedAssertion struct {
+	XMLName       xml.Name      `xml:"urn:oasis:names:tc:SAML:2.0:assertion EncryptedAssertion"`
+	EncryptedData encryptedData `xml:"http://www.w3.org/2001/04/xmlenc# EncryptedData"`
+}
+
+type encryptedData struct {
+	Type             string           `xml:"Type,attr"`
+	EncryptionMethod encryptionMethod `xml:"http://www.w3.org/2001/04/xmlenc# EncryptionMethod"`
+	KeyInfo          encryptedKeyInfo `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+	CipherData       cipherData       `xml:"http://www.w3.org/2001/04/xmlenc# CipherData"`
+}
+
+type encryptionMethod struct {
+	Algorithm    string        `xml:"Algorithm,attr"`
+	DigestMethod *digestMethod `xml:"http://www.w3.org/2000/09/xmldsig# DigestMethod,omitempty"`
+}
+
+type digestMethod struct {
+	Algorithm string `xml:"Algorithm,attr"`
+}
+
+type encryptedKeyInfo struct {
+	EncryptedKey encryptedKey `xml:"http://www.w3.org/2001/04/xmlenc# EncryptedKey"`
+}
+
+type encryptedKey struct {
+	EncryptionMethod encryptionMethod `xml:"http://www.w3.org/2001/04/xmlenc# EncryptionMethod"`
+	KeyInfo          x509KeyInfo      `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+	CipherData       cipherData       `xml:"http://www.w3.org/2001/04/xmlenc# CipherData"`
+}
+
+type x509KeyInfo struct {
+	X509Data x509Data `xml:"http://www.w3.org/2000/09/xmldsig# X509Data"`
+}
+
+type x509Data struct {
+	X509Certificate string `xml:"http://www.w3.org/2000/09/xmldsig# X509Certificate"`
+}
+
+type cipherData struct {
+	CipherValue string `xml:"http://www.w3.org/2001/04/xmlenc# CipherValue"`
+}
+
+// assertionEncrypter encrypts assertions for a service provider
+// with the certificate and the preferred algorithm of its metadata
+type assertionEncrypter struct {
+	certificate string
+	publicKey   *rsa.PublicKey
+	algorithm   string
+}
+
+func newAssertionEncrypter(metadata *md.EntityDescriptorType) (*assertionEncrypter, error) {
+	if metadata == nil || metadata.SPSSODescriptor == nil {
+		return nil, errors.ThrowPreconditionFailed(nil, "SAML-Enc1s", "Errors.Project.App.SAMLEncryptionCertificateMissing")
+	}
+	for _, keyDescriptor := range metadata.SPSSODescriptor.KeyDescriptor {
+		if keyDescriptor.Use != "" && keyDescriptor.Use != md.KeyTypesEncryption {
+			continue
+		}
+		for _, data := range keyDescriptor.KeyInfo.X509Data {
+			if data.X509Certificate == "" {
+				continue
+			}
+			certificates, err := signature.ParseCertificates([]string{data.X509Certificate})
+			if err != nil {
+				return nil, errors.ThrowPreconditionFailed(err, "SAML-Enc2s", "Errors.Project.App.SAMLEncryptionCertificateMissing")
+			}
+			publicKey, ok := publicKeyOfCertificates(certificates)
+			if !ok {
+				continue
+			}
+			return &assertionEncrypter{
+				certificate: data.X509Certificate,
+				publicKey:   publicKey,
+				algorithm:   dataEncryptionAlgorithm(keyDescriptor),
+			}, nil
+		}
+	}
+	return nil, errors.ThrowPreconditionFailed(nil, "SAML-Enc3s", "Errors.Project.App.SAMLEncryptionCertificateMissing")
+}
+
+func publicKeyOfCertificates(certificates []*x509.Certificate) (*rsa.PublicKey, bool) {
+	if len(certificates) == 0 {
+		return nil, false
+	}
+	publicKey, ok := certificates[0].PublicKey.(*rsa.PublicKey)
+	return publicKey, ok
+}
+
+// dataEncryptionAlgorithm returns the first supported algorithm the service provider prefers
+// and defaults to AES256-CBC, which is supported by most service providers
+func dataEncryptionAlgorithm(keyDescriptor md.KeyDescriptorType) string {
+	for _, method := range keyDescriptor.EncryptionMethod {
+		switch method.Algorithm {
+		case encryptionAES128CBC,
+			encryptionAES256CBC,
+			encryptionAES128GCM,
+			encryptionAES256GCM:
+			return method.Algorithm
+		}
+	}
+	return encryptionAES256CBC
+}
+
+func (e *assertionEncrypter) Encrypt(assertion *etree.Element) (*encryptedAssertion, error) {
+	doc := etree.NewDocument()
+	doc.SetRoot(assertion)
+	plaintext, err := doc.WriteToBytes()
+	if err != nil {
+		return nil, err
+	}
+	key := make([]byte, keySize(e.algorithm))
+	if _, err = io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	ciphertext, err := encryptData(e.algorithm, key, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	encryptedKeyValue, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, e.publicKey, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedAssertion{
+		EncryptedData: encryptedData{
+			Type:             xmlencElementType,
+			EncryptionMethod: encryptionMethod{Algorithm: e.algorithm},
+			KeyInfo: encryptedKeyInfo{
+				EncryptedKey: encryptedKey{
+					EncryptionMethod: encryptionMethod{
+						Algorithm:    keyTransportRSAOAEP,
+						DigestMethod: &digestMethod{Algorithm: digestSHA1},
+					},
+					KeyInfo: x509KeyInfo{
+						X509Data: x509Data{X509Certificate: e.certificate},
+					},
+					CipherData: cipherData{CipherValue: base64.StdEncoding.EncodeToString(encryptedKeyValue)},
+				},
+			},
+			CipherData: cipherData{CipherValue: base64.StdEncoding.EncodeToString(ciphertext)},
+		},
+	}, nil
+}
+
+func keySize(algorithm string) int {
+	switch algorithm {
+	case encryptionAES128CBC, encryptionAES128GCM:
+		return 16
+	default:
+		return 32
+	}
+}
+
+// encryptData encrypts the plaintext as defined by XML Encryption,
+// the iv (or nonce) is prepended to the ciphertext
+func encryptData(algorithm string, key, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	switch algorithm {
+	case encryptionAES128GCM, encryptionAES256GCM:
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+			return nil, err
+		}
+		return gcm.Seal(nonce, nonce, plaintext, nil), nil
+	default:
+		padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+		padded := make([]byte, len(plaintext)+padding)
+		copy(padded, plaintext)
+		padded[len(padded)-1] = byte(padding)
+		ciphertext := make([]byte, aes.BlockSize+len(padded))
+		iv := ciphertext[:aes.BlockSize]
+		if _, err = io.ReadFull(rand.Reader, iv); err != nil {
+			return nil, err
+		}
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext[aes.BlockSize:], padded)
+		return ciphertext, nil
+	}
+}
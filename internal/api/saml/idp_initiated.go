@@ -0,0 +1,140 @@
+package saml
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/auth/repository"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	// IDPInitiatedEndpoint starts the login for a SAML application (IdP-initiated SSO),
+	// after the login the user is sent to the service provider with an unsolicited response
+	IDPInitiatedEndpoint = HandlerPrefix + "/idp-initiated"
+
+	idpInitiatedAppIDParam = "app_id"
+	relayStateParam        = "RelayState"
+)
+
+type idpInitiated struct {
+	repo  repository.Repository
+	query *query.Queries
+}
+
+// NewIDPInitiatedHandler serves the IdP-initiated SSO of the SAML applications,
+// which have it enabled in their configuration
+func NewIDPInitiatedHandler(query *query.Queries, repo repository.Repository, interceptors ...mux.MiddlewareFunc) http.Handler {
+	i := &idpInitiated{
+		repo:  repo,
+		query: query,
+	}
+	router := mux.NewRouter()
+	router.Use(interceptors...)
+	router.HandleFunc(IDPInitiatedEndpoint+"/{"+idpInitiatedAppIDParam+"}", i.login).Methods(http.MethodGet)
+	return router
+}
+
+func (i *idpInitiated) login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		http.Error(w, "no user agent id", http.StatusBadRequest)
+		return
+	}
+	app, err := i.query.AppByID(ctx, mux.Vars(r)[idpInitiatedAppIDParam], false)
+	if err != nil {
+		idpInitiatedError(w, err)
+		return
+	}
+	acsURL, err := idpInitiatedAssertionConsumerService(app)
+	if err != nil {
+		idpInitiatedError(w, err)
+		return
+	}
+	relayState := r.URL.Query().Get(relayStateParam)
+	if relayState == "" {
+		relayState = app.SAMLConfig.DefaultRelayState
+	}
+	authRequest, err := i.repo.CreateAuthRequest(ctx, &domain.AuthRequest{
+		CreationDate:  time.Now(),
+		AgentID:       userAgentID,
+		ApplicationID: app.ID,
+		CallbackURI:   acsURL,
+		TransferState: relayState,
+		InstanceID:    authz.GetInstance(ctx).InstanceID(),
+		Request: &domain.AuthRequestSAML{
+			// the response is not related to any request of the service provider
+			ID:          "",
+			BindingType: provider.PostBinding,
+			Issuer:      app.SAMLConfig.EntityID,
+		},
+	})
+	if err != nil {
+		idpInitiatedError(w, err)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("%s%s?%s=%s", login.HandlerPrefix, login.EndpointLogin, login.QueryAuthRequestID, authRequest.ID), http.StatusFound)
+}
+
+// idpInitiatedAssertionConsumerService returns the AssertionConsumerService of the service provider
+// the unsolicited response is posted to, preferably the default one
+func idpInitiatedAssertionConsumerService(app *query.App) (string, error) {
+	if app.SAMLConfig == nil {
+		return "", errors.ThrowInvalidArgument(nil, "SAML-Idp2s", "Errors.Project.App.IsNotSAML")
+	}
+	if app.State != domain.AppStateActive || !app.SAMLConfig.IDPInitiatedSSO {
+		return "", errors.ThrowPreconditionFailed(nil, "SAML-Idp3s", "Errors.Project.App.IDPInitiatedSSODisabled")
+	}
+	metadata, err := xml.ParseMetadataXmlIntoStruct(app.SAMLConfig.Metadata)
+	if err != nil {
+		return "", errors.ThrowPreconditionFailed(err, "SAML-Idp4s", "Errors.Project.App.SAMLMetadataFormat")
+	}
+	if acsURL := postAssertionConsumerService(metadata); acsURL != "" {
+		return acsURL, nil
+	}
+	return "", errors.ThrowPreconditionFailed(nil, "SAML-Idp5s", "Errors.Project.App.SAMLMetadataFormat")
+}
+
+func postAssertionConsumerService(metadata *md.EntityDescriptorType) string {
+	if metadata.SPSSODescriptor == nil {
+		return ""
+	}
+	var location string
+	for _, service := range metadata.SPSSODescriptor.AssertionConsumerService {
+		if service.Binding != provider.PostBinding {
+			continue
+		}
+		if service.IsDefault == "true" {
+			return service.Location
+		}
+		if location == "" {
+			location = service.Location
+		}
+	}
+	return location
+}
+
+func idpInitiatedError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.IsNotFound(err):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.IsErrorInvalidArgument(err), errors.IsPreconditionFailed(err):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		logging.WithError(err).Warn("idp initiated sso failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,374 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"html/template"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/serviceprovider"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	"github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	nameIDFormatEntity = "urn:oasis:names:tc:SAML:2.0:nameid-format:entity"
+)
+
+// propagationTemplate logs out the service providers in hidden iframes
+// and posts the original LogoutRequest back to the SingleLogoutService afterwards,
+// which then answers the requesting service provider as all sessions are ended
+var propagationTemplate = template.Must(template.New("propagation").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<script>
+var pending = {{ len .Requests }};
+function loaded() {
+	pending--;
+	if (pending === 0) {
+		document.getElementById('samlpost').submit();
+	}
+}
+setTimeout(function () { document.getElementById('samlpost').submit(); }, 5000);
+</script>
+</head>
+<body>
+{{ range $i, $request := .Requests }}
+<iframe name="slo{{ $i }}" style="display:none" onload="loaded()"{{ if $request.RedirectURL }} src="{{ $request.RedirectURL }}"{{ end }}></iframe>
+{{ if not $request.RedirectURL }}
+<form action="{{ $request.Location }}" method="post" target="slo{{ $i }}" class="slo">
+<input type="hidden" name="SAMLRequest" value="{{ $request.SAMLRequest }}"/>
+</form>
+{{ end }}
+{{ end }}
+<form action="{{ .SingleLogoutURL }}" method="post" id="samlpost">
+<input type="hidden" name="SAMLRequest" value="{{ .SAMLRequest }}"/>
+<input type="hidden" name="SAMLEncoding" value="{{ .SAMLEncoding }}"/>
+<input type="hidden" name="RelayState" value="{{ .RelayState }}"/>
+<noscript>
+<input type="submit" value="Continue"/>
+</noscript>
+</form>
+<script>
+var forms = document.getElementsByClassName('slo');
+for (var i = 0; i < forms.length; i++) {
+	forms[i].submit();
+}
+</script>
+</body>
+</html>`))
+
+type propagationData struct {
+	Requests        []*propagationRequest
+	SingleLogoutURL string
+	SAMLRequest     string
+	SAMLEncoding    string
+	RelayState      string
+}
+
+type propagationRequest struct {
+	Location    string
+	RedirectURL string
+	SAMLRequest string
+}
+
+// singleLogout intercepts the LogoutRequests of the service providers,
+// the LogoutResponse itself is created by the SingleLogoutService of the provider
+type singleLogout struct {
+	storage            *Storage
+	endpoint           provider.Endpoint
+	metadataEndpoint   provider.Endpoint
+	signatureAlgorithm string
+}
+
+func newSingleLogout(storage *Storage, conf *provider.Config) *singleLogout {
+	logout := &singleLogout{
+		storage:          storage,
+		endpoint:         provider.NewEndpoint(provider.DefaultSingleLogOutEndpoint),
+		metadataEndpoint: provider.NewEndpoint(provider.DefaultMetadataEndpoint),
+	}
+	if conf == nil {
+		return logout
+	}
+	if conf.Metadata != nil {
+		logout.metadataEndpoint = *conf.Metadata
+	}
+	if conf.IDPConfig != nil {
+		logout.signatureAlgorithm = conf.IDPConfig.SignatureAlgorithm
+		if conf.IDPConfig.Endpoints != nil && conf.IDPConfig.Endpoints.SingleLogOut != nil {
+			logout.endpoint = *conf.IDPConfig.Endpoints.SingleLogOut
+		}
+	}
+	return logout
+}
+
+// Handler ends the sessions of the user agent on a LogoutRequest
+// and propagates the logout to all other service providers the user agent is logged into
+func (l *singleLogout) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != l.endpoint.Relative() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		data, err := l.terminateSessions(r)
+		if errors.IsPermissionDenied(err) {
+			logging.WithError(err).Info("saml logout request rejected")
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			logging.WithError(err).Warn("unable to terminate sessions on saml logout")
+		}
+		if data == nil || len(data.Requests) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err = propagationTemplate.Execute(w, data); err != nil {
+			logging.WithError(err).Error("unable to render saml logout propagation")
+		}
+	})
+}
+
+func (l *singleLogout) terminateSessions(r *http.Request) (*propagationData, error) {
+	ctx := r.Context()
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	data := &propagationData{
+		SingleLogoutURL: l.endpoint.Absolute(provider.IssuerFromContext(ctx)),
+		SAMLRequest:     r.Form.Get("SAMLRequest"),
+		SAMLEncoding:    r.Form.Get("SAMLEncoding"),
+		RelayState:      r.Form.Get("RelayState"),
+	}
+	if data.SAMLRequest == "" {
+		return nil, nil
+	}
+	logoutRequest, err := xml.DecodeLogoutRequest(data.SAMLEncoding, data.SAMLRequest)
+	if err != nil || logoutRequest.Issuer == nil {
+		// the SingleLogoutService answers invalid requests
+		return nil, nil
+	}
+	if err = l.verifyLogoutRequest(ctx, r.Form, logoutRequest); err != nil {
+		return nil, err
+	}
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return nil, errors.ThrowPreconditionFailed(nil, "SAML-Slo2k", "no user agent id")
+	}
+	sessions, err := l.storage.command.HumanSAMLSessions(ctx, userAgentID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs, err := l.storage.repo.UserSessionUserIDsByAgentID(ctx, userAgentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		userIDs = appendUserID(userIDs, session.UserID)
+		if session.EntityID == logoutRequest.Issuer.Text {
+			ctx = authz.SetCtxData(ctx, authz.CtxData{UserID: session.UserID})
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	if err = l.storage.command.HumansSignOut(ctx, userAgentID, userIDs); err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		if session.EntityID == logoutRequest.Issuer.Text {
+			continue
+		}
+		request, err := l.logoutRequest(ctx, session)
+		if err != nil {
+			logging.WithFields("entityID", session.EntityID).WithError(err).Warn("unable to create saml logout request")
+			continue
+		}
+		if request != nil {
+			data.Requests = append(data.Requests, request)
+		}
+	}
+	return data, nil
+}
+
+// verifyLogoutRequest ensures the LogoutRequest was issued and signed by a registered service provider,
+// so a forged request (e.g. an unsigned cross-site post) can't end the sessions of the user agent
+func (l *singleLogout) verifyLogoutRequest(ctx context.Context, form url.Values, logoutRequest *samlp.LogoutRequestType) error {
+	sp, err := l.storage.GetEntityByID(ctx, logoutRequest.Issuer.Text)
+	if err != nil {
+		return errors.ThrowPermissionDenied(err, "SAML-Slo3u", "logout request of unknown service provider")
+	}
+	return verifyLogoutRequestSignature(sp, form, logoutRequest)
+}
+
+// verifyLogoutRequestSignature checks the signature of the query (HTTP-Redirect binding)
+// or the enveloped signature of the request (HTTP-POST binding) against the certificates of the service provider metadata
+func verifyLogoutRequestSignature(sp *serviceprovider.ServiceProvider, form url.Values, logoutRequest *samlp.LogoutRequestType) error {
+	if sig := form.Get("Signature"); sig != "" {
+		if err := sp.ValidateRedirectSignature(form.Get("SAMLRequest"), form.Get("RelayState"), form.Get("SigAlg"), sig); err != nil {
+			return errors.ThrowPermissionDenied(err, "SAML-Slo3r", "invalid signature of logout request")
+		}
+		return nil
+	}
+	if logoutRequest.Signature == nil {
+		return errors.ThrowPermissionDenied(nil, "SAML-Slo3n", "logout request is not signed")
+	}
+	message, err := inflateSAMLMessage(form.Get("SAMLRequest"))
+	if err != nil {
+		return errors.ThrowPermissionDenied(err, "SAML-Slo3d", "unable to decode logout request")
+	}
+	if err = sp.ValidatePostSignature(string(message)); err != nil {
+		return errors.ThrowPermissionDenied(err, "SAML-Slo3p", "invalid signature of logout request")
+	}
+	return nil
+}
+
+// inflateSAMLMessage decodes the base64 encoded message, which is deflated for the HTTP-Redirect binding
+func inflateSAMLMessage(message string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(message)
+	if err != nil {
+		return nil, err
+	}
+	inflated, err := io.ReadAll(flate.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		return data, nil
+	}
+	return inflated, nil
+}
+
+// deflateSAMLMessage deflates and base64 encodes the message for the HTTP-Redirect binding
+func deflateSAMLMessage(message []byte) (string, error) {
+	var buf bytes.Buffer
+	writer, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err = writer.Write(message); err != nil {
+		return "", err
+	}
+	if err = writer.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// logoutRequest creates the signed LogoutRequest for the SingleLogoutService of the service provider,
+// preferably for the HTTP-Redirect binding
+func (l *singleLogout) logoutRequest(ctx context.Context, session *command.HumanSAMLSession) (*propagationRequest, error) {
+	sp, err := l.storage.GetEntityByID(ctx, session.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	service := singleLogoutService(sp.Metadata)
+	if service == nil {
+		return nil, nil
+	}
+	request := &samlp.LogoutRequestType{
+		Id:           provider.NewID(),
+		Version:      "2.0",
+		IssueInstant: time.Now().UTC().Format(timeFormat),
+		Destination:  service.Location,
+		Issuer: &saml.NameIDType{
+			Format: nameIDFormatEntity,
+			Text:   l.metadataEndpoint.Absolute(provider.IssuerFromContext(ctx)),
+		},
+		NameID: &saml.NameIDType{
+			Text: session.NameID,
+		},
+	}
+	certAndKey, err := l.storage.GetResponseSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if service.Binding == provider.RedirectBinding {
+		redirectURL, err := l.redirectLogoutRequest(request, service.Location, certAndKey.Certificate, certAndKey.Key)
+		if err != nil {
+			return nil, err
+		}
+		return &propagationRequest{Location: service.Location, RedirectURL: redirectURL}, nil
+	}
+	signer, err := signature.GetSigner(certAndKey.Certificate, certAndKey.Key, l.signatureAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	request.Signature, err = signature.Create(signer, request)
+	if err != nil {
+		return nil, err
+	}
+	message, err := xml.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	return &propagationRequest{
+		Location:    service.Location,
+		SAMLRequest: base64.StdEncoding.EncodeToString([]byte(message)),
+	}, nil
+}
+
+func (l *singleLogout) redirectLogoutRequest(request *samlp.LogoutRequestType, location string, cert []byte, key *rsa.PrivateKey) (string, error) {
+	message, err := xml.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	deflated, err := deflateSAMLMessage([]byte(message))
+	if err != nil {
+		return "", err
+	}
+	query := "SAMLRequest=" + url.QueryEscape(deflated) + "&SigAlg=" + url.QueryEscape(l.signatureAlgorithm)
+	tlsCert, err := signature.ParseTlsKeyPair(cert, key)
+	if err != nil {
+		return "", err
+	}
+	signingContext, err := signature.GetSigningContext(tlsCert, l.signatureAlgorithm)
+	if err != nil {
+		return "", err
+	}
+	sig, err := signature.CreateRedirect(signingContext, query)
+	if err != nil {
+		return "", err
+	}
+	return location + "?" + query + "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(sig)), nil
+}
+
+func singleLogoutService(metadata *md.EntityDescriptorType) *md.EndpointType {
+	if metadata == nil || metadata.SPSSODescriptor == nil {
+		return nil
+	}
+	var service *md.EndpointType
+	for i, endpoint := range metadata.SPSSODescriptor.SingleLogoutService {
+		switch endpoint.Binding {
+		case provider.RedirectBinding:
+			return &metadata.SPSSODescriptor.SingleLogoutService[i]
+		case provider.PostBinding:
+			if service == nil {
+				service = &metadata.SPSSODescriptor.SingleLogoutService[i]
+			}
+		}
+	}
+	return service
+}
+
+func appendUserID(userIDs []string, userID string) []string {
+	for _, id := range userIDs {
+		if id == userID {
+			return userIDs
+		}
+	}
+	return append(userIDs, userID)
+}
@@ -0,0 +1,159 @@
+package saml
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/saml/pkg/provider/serviceprovider"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	"github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const spMetadata = `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://sp.example.com/metadata">
+<md:SPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+<md:KeyDescriptor use="signing"><ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#"><ds:X509Data><ds:X509Certificate>%s</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>
+<md:SingleLogoutService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://sp.example.com/slo"/>
+</md:SPSSODescriptor>
+</md:EntityDescriptor>`
+
+func Test_verifyLogoutRequestSignature(t *testing.T) {
+	spCert, spKey := newTestCertAndKey(t)
+	otherCert, otherKey := newTestCertAndKey(t)
+	sp, err := serviceprovider.NewServiceProvider("app", &serviceprovider.Config{
+		Metadata: []byte(fmt.Sprintf(spMetadata, base64.StdEncoding.EncodeToString(spCert))),
+	}, "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		form    func() (url.Values, *samlp.LogoutRequestType)
+		wantErr bool
+	}{
+		{
+			name: "redirect signed by service provider",
+			form: func() (url.Values, *samlp.LogoutRequestType) {
+				return redirectLogoutForm(t, spCert, spKey)
+			},
+		},
+		{
+			name: "redirect signed by other key",
+			form: func() (url.Values, *samlp.LogoutRequestType) {
+				return redirectLogoutForm(t, otherCert, otherKey)
+			},
+			wantErr: true,
+		},
+		{
+			name: "redirect with changed relay state",
+			form: func() (url.Values, *samlp.LogoutRequestType) {
+				form, request := redirectLogoutForm(t, spCert, spKey)
+				form.Set("RelayState", "changed")
+				return form, request
+			},
+			wantErr: true,
+		},
+		{
+			name: "post signed by service provider",
+			form: func() (url.Values, *samlp.LogoutRequestType) {
+				return postLogoutForm(t, spCert, spKey)
+			},
+		},
+		{
+			name: "post signed by other key",
+			form: func() (url.Values, *samlp.LogoutRequestType) {
+				return postLogoutForm(t, otherCert, otherKey)
+			},
+			wantErr: true,
+		},
+		{
+			name: "post unsigned",
+			form: func() (url.Values, *samlp.LogoutRequestType) {
+				request := testLogoutRequest()
+				message, err := xml.Marshal(request)
+				require.NoError(t, err)
+				deflated, err := deflateSAMLMessage([]byte(message))
+				require.NoError(t, err)
+				return url.Values{"SAMLRequest": {deflated}}, request
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form, request := tt.form()
+			err := verifyLogoutRequestSignature(sp, form, request)
+			if tt.wantErr {
+				require.True(t, errors.IsPermissionDenied(err), "expected permission denied, got %v", err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func testLogoutRequest() *samlp.LogoutRequestType {
+	return &samlp.LogoutRequestType{
+		Id:           "_logout",
+		Version:      "2.0",
+		IssueInstant: time.Now().UTC().Format(time.RFC3339),
+		Destination:  "https://idp.example.com/saml/v2/SLO",
+		Issuer: &saml.NameIDType{
+			Format: nameIDFormatEntity,
+			Text:   "https://sp.example.com/metadata",
+		},
+		NameID: &saml.NameIDType{
+			Text: "user",
+		},
+	}
+}
+
+func redirectLogoutForm(t *testing.T, cert []byte, key *rsa.PrivateKey) (url.Values, *samlp.LogoutRequestType) {
+	request := testLogoutRequest()
+	l := &singleLogout{signatureAlgorithm: dsig.RSASHA256SignatureMethod}
+	redirectURL, err := l.redirectLogoutRequest(request, "https://idp.example.com/saml/v2/SLO", cert, key)
+	require.NoError(t, err)
+	parsed, err := url.Parse(redirectURL)
+	require.NoError(t, err)
+	return parsed.Query(), request
+}
+
+func postLogoutForm(t *testing.T, cert []byte, key *rsa.PrivateKey) (url.Values, *samlp.LogoutRequestType) {
+	request := testLogoutRequest()
+	signer, err := signature.GetSigner(cert, key, dsig.RSASHA256SignatureMethod)
+	require.NoError(t, err)
+	request.Signature, err = signature.Create(signer, request)
+	require.NoError(t, err)
+	message, err := xml.Marshal(request)
+	require.NoError(t, err)
+	deflated, err := deflateSAMLMessage([]byte(message))
+	require.NoError(t, err)
+	return url.Values{"SAMLRequest": {deflated}}, request
+}
+
+func newTestCertAndKey(t *testing.T) ([]byte, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sp.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return cert, key
+}
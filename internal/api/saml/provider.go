@@ -21,6 +21,8 @@ import (
 
 const (
 	HandlerPrefix = "/saml/v2"
+
+	timeFormat = "2006-01-02T15:04:05.999Z"
 )
 
 type Config struct {
@@ -65,8 +67,10 @@ func NewProvider(
 			userAgentCookie,
 			accessHandler,
 			http_utils.CopyHeadersToContext,
+			newSingleLogout(provStorage, conf.ProviderConfig).Handler,
+			newCustomResponse(provStorage, conf.ProviderConfig).Handler,
 		),
-		provider.WithCustomTimeFormat("2006-01-02T15:04:05.999Z"),
+		provider.WithCustomTimeFormat(timeFormat),
 	}
 	if !externalSecure {
 		options = append(options, provider.WithAllowInsecure())
@@ -0,0 +1,386 @@
+package saml
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"html/template"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/models"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	samlxml "github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/metrics"
+)
+
+const (
+	assertionValidity = 5 * time.Minute
+)
+
+var responsePostTemplate = template.Must(template.New("post").Parse(`<!DOCTYPE html>
+<html lang="en">
+<body onload="document.getElementById('samlpost').submit()">
+<form action="{{ .AssertionConsumerServiceURL }}" method="post" id="samlpost">
+<input type="hidden" name="RelayState" value="{{ .RelayState }}"/>
+<input type="hidden" name="SAMLResponse" value="{{ .SAMLResponse }}"/>
+<noscript>
+<input type="submit" value="Continue"/>
+</noscript>
+</form>
+</body>
+</html>`))
+
+// samlResponse is the Response of the SAML protocol without the assertion,
+// which is added (signed and / or encrypted) as element
+type samlResponse struct {
+	XMLName      xml.Name         `xml:"urn:oasis:names:tc:SAML:2.0:protocol Response"`
+	Id           string           `xml:"ID,attr"`
+	InResponseTo string           `xml:"InResponseTo,attr,omitempty"`
+	Version      string           `xml:"Version,attr"`
+	IssueInstant string           `xml:"IssueInstant,attr"`
+	Destination  string           `xml:"Destination,attr,omitempty"`
+	Issuer       *saml.NameIDType `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Status       samlp.StatusType `xml:"Status"`
+}
+
+// customResponse intercepts the callback of the login
+// and creates the response for applications with custom signing, encryption or attribute settings,
+// the responses of all other applications are created by the provider
+type customResponse struct {
+	storage            *Storage
+	endpoint           provider.Endpoint
+	metadataEndpoint   provider.Endpoint
+	signatureAlgorithm string
+}
+
+func newCustomResponse(storage *Storage, conf *provider.Config) *customResponse {
+	response := &customResponse{
+		storage:            storage,
+		endpoint:           provider.NewEndpoint(provider.DefaultCallbackEndpoint),
+		metadataEndpoint:   provider.NewEndpoint(provider.DefaultMetadataEndpoint),
+		signatureAlgorithm: dsig.RSASHA256SignatureMethod,
+	}
+	if conf == nil {
+		return response
+	}
+	if conf.Metadata != nil {
+		response.metadataEndpoint = *conf.Metadata
+	}
+	if conf.IDPConfig != nil {
+		if conf.IDPConfig.SignatureAlgorithm != "" {
+			response.signatureAlgorithm = conf.IDPConfig.SignatureAlgorithm
+		}
+		if conf.IDPConfig.Endpoints != nil && conf.IDPConfig.Endpoints.Callback != nil {
+			response.endpoint = *conf.IDPConfig.Endpoints.Callback
+		}
+	}
+	return response
+}
+
+func (c *customResponse) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != c.endpoint.Relative() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		app := c.customizedApp(r)
+		if app == nil {
+			recorder := &metrics.StatusRecorder{ResponseWriter: w, Status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+			if recorder.Status < http.StatusBadRequest {
+				c.addProviderSAMLSession(r)
+			}
+			return
+		}
+		if err := c.sendResponse(w, r, app); err != nil {
+			logging.WithError(err).Warn("unable to send saml response")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// addProviderSAMLSession records the session of the response sent by the provider,
+// the provider answers failures with an error status or, if the auth request can't be read, with a denied response
+func (c *customResponse) addProviderSAMLSession(r *http.Request) {
+	ctx := r.Context()
+	authRequest, err := c.storage.authRequestByID(ctx, r.Form.Get("id"))
+	if err != nil {
+		return
+	}
+	c.storage.addSAMLSession(ctx, authRequest, authRequest.GetNameID())
+}
+
+// customizedApp returns the application of the auth request if it has custom settings,
+// errors are returned by the provider
+func (c *customResponse) customizedApp(r *http.Request) *query.App {
+	ctx := r.Context()
+	if err := r.ParseForm(); err != nil {
+		return nil
+	}
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return nil
+	}
+	authRequest, err := c.storage.repo.AuthRequestByIDCheckLoggedIn(ctx, r.Form.Get("id"), userAgentID)
+	if err != nil || !authRequest.Done() {
+		return nil
+	}
+	app, err := c.storage.query.AppByID(ctx, authRequest.ApplicationID, false)
+	if err != nil || app.SAMLConfig == nil {
+		return nil
+	}
+	if app.SAMLConfig.SignatureAlgorithm == domain.SAMLSignatureAlgorithmUnspecified &&
+		app.SAMLConfig.SigningMode == domain.SAMLSigningModeAssertion &&
+		!app.SAMLConfig.EncryptAssertion &&
+		app.SAMLConfig.NameIDFormat == domain.SAMLNameIDFormatUnspecified &&
+		len(app.SAMLConfig.AttributeMappings) == 0 {
+		return nil
+	}
+	return app
+}
+
+func (c *customResponse) sendResponse(w http.ResponseWriter, r *http.Request, app *query.App) error {
+	ctx := r.Context()
+	authRequest, err := c.storage.authRequestByID(ctx, r.Form.Get("id"))
+	if err != nil {
+		return err
+	}
+	nameID, attributes, err := c.storage.assertionAttributes(ctx, app, authRequest.GetUserID())
+	if err != nil {
+		return err
+	}
+	c.storage.addSAMLSession(ctx, authRequest, nameID.Text)
+	signer, err := c.signer(ctx, app.SAMLConfig.SignatureAlgorithm)
+	if err != nil {
+		return err
+	}
+	response, err := c.createResponse(authRequest, app.SAMLConfig, nameID, attributes, provider.IssuerFromContext(ctx), signer)
+	if err != nil {
+		return err
+	}
+	if authRequest.GetBindingType() == provider.RedirectBinding {
+		return redirectResponse(w, r, authRequest, response, signer, app.SAMLConfig.SigningMode.SignResponse())
+	}
+	if app.SAMLConfig.SigningMode.SignResponse() {
+		if err = signer.sign(response); err != nil {
+			return err
+		}
+	}
+	message, err := marshalDocument(response)
+	if err != nil {
+		return err
+	}
+	return responsePostTemplate.Execute(w, &provider.AuthResponseForm{
+		RelayState:                  authRequest.GetRelayState(),
+		SAMLResponse:                base64.StdEncoding.EncodeToString(message),
+		AssertionConsumerServiceURL: authRequest.GetAccessConsumerServiceURL(),
+	})
+}
+
+func (c *customResponse) createResponse(authRequest models.AuthRequestInt, config *query.SAMLApp, nameID *saml.NameIDType, attributes []*saml.AttributeType, issuer string, signer *responseSigner) (*etree.Element, error) {
+	now := time.Now().UTC()
+	issueInstant := now.Format(timeFormat)
+	untilInstant := now.Add(assertionValidity).Format(timeFormat)
+	entityID := c.metadataEndpoint.Absolute(issuer)
+
+	assertion, err := unmarshalElement(createAssertion(authRequest, nameID, attributes, entityID, config.EntityID, issueInstant, untilInstant))
+	if err != nil {
+		return nil, err
+	}
+	if config.SigningMode.SignAssertion() {
+		if err = signer.sign(assertion); err != nil {
+			return nil, err
+		}
+	}
+	if config.EncryptAssertion {
+		if assertion, err = encryptAssertion(config.Metadata, assertion); err != nil {
+			return nil, err
+		}
+	}
+	response, err := unmarshalElement(&samlResponse{
+		Id:           provider.NewID(),
+		InResponseTo: authRequest.GetAuthRequestID(),
+		Version:      "2.0",
+		IssueInstant: issueInstant,
+		Destination:  authRequest.GetAccessConsumerServiceURL(),
+		Issuer: &saml.NameIDType{
+			Format: nameIDFormatEntity,
+			Text:   entityID,
+		},
+		Status: samlp.StatusType{
+			StatusCode: samlp.StatusCodeType{Value: provider.StatusCodeSuccess},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.AddChild(assertion)
+	return response, nil
+}
+
+func encryptAssertion(metadata []byte, assertion *etree.Element) (*etree.Element, error) {
+	entity, err := samlxml.ParseMetadataXmlIntoStruct(metadata)
+	if err != nil {
+		return nil, errors.ThrowPreconditionFailed(err, "SAML-Rsp1s", "Errors.Project.App.SAMLMetadataFormat")
+	}
+	encrypter, err := newAssertionEncrypter(entity)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := encrypter.Encrypt(assertion)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalElement(encrypted)
+}
+
+func createAssertion(authRequest models.AuthRequestInt, nameID *saml.NameIDType, attributes []*saml.AttributeType, issuer, audience, issueInstant, untilInstant string) *saml.AssertionType {
+	id := provider.NewID()
+	return &saml.AssertionType{
+		Version:      "2.0",
+		Id:           id,
+		IssueInstant: issueInstant,
+		Issuer: saml.NameIDType{
+			Format: nameIDFormatEntity,
+			Text:   issuer,
+		},
+		Subject: &saml.SubjectType{
+			NameID: nameID,
+			SubjectConfirmation: []saml.SubjectConfirmationType{
+				{
+					Method: "urn:oasis:names:tc:SAML:2.0:cm:bearer",
+					SubjectConfirmationData: &saml.SubjectConfirmationDataType{
+						InResponseTo: authRequest.GetAuthRequestID(),
+						Recipient:    authRequest.GetAccessConsumerServiceURL(),
+						NotBefore:    issueInstant,
+						NotOnOrAfter: untilInstant,
+					},
+				},
+			},
+		},
+		Conditions: &saml.ConditionsType{
+			NotBefore:    issueInstant,
+			NotOnOrAfter: untilInstant,
+			AudienceRestriction: []saml.AudienceRestrictionType{
+				{Audience: []string{audience}},
+			},
+		},
+		AuthnStatement: []saml.AuthnStatementType{
+			{
+				AuthnInstant: issueInstant,
+				SessionIndex: id,
+				AuthnContext: saml.AuthnContextType{
+					AuthnContextClassRef: "urn:oasis:names:tc:SAML:2.0:ac:classes:PasswordProtectedTransport",
+				},
+			},
+		},
+		AttributeStatement: []saml.AttributeStatementType{
+			{Attribute: attributes},
+		},
+	}
+}
+
+// redirectResponse sends the response with the HTTP-Redirect binding,
+// where the response is signed as part of the query
+func redirectResponse(w http.ResponseWriter, r *http.Request, authRequest models.AuthRequestInt, response *etree.Element, signer *responseSigner, signResponse bool) error {
+	message, err := marshalDocument(response)
+	if err != nil {
+		return err
+	}
+	deflated, err := deflateSAMLMessage(message)
+	if err != nil {
+		return err
+	}
+	query := "SAMLResponse=" + url.QueryEscape(deflated)
+	if relayState := authRequest.GetRelayState(); relayState != "" {
+		query += "&RelayState=" + url.QueryEscape(relayState)
+	}
+	if signResponse {
+		query += "&SigAlg=" + url.QueryEscape(signer.signingContext.GetSignatureMethodIdentifier())
+		sig, err := signature.CreateRedirect(signer.signingContext, query)
+		if err != nil {
+			return err
+		}
+		query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(sig))
+	}
+	http.Redirect(w, r, authRequest.GetAccessConsumerServiceURL()+"?"+query, http.StatusFound)
+	return nil
+}
+
+type responseSigner struct {
+	signingContext *dsig.SigningContext
+}
+
+func (c *customResponse) signer(ctx context.Context, algorithm domain.SAMLSignatureAlgorithm) (*responseSigner, error) {
+	certAndKey, err := c.storage.GetResponseSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tlsCert, err := signature.ParseTlsKeyPair(certAndKey.Certificate, certAndKey.Key)
+	if err != nil {
+		return nil, err
+	}
+	signingContext, err := signature.GetSigningContext(tlsCert, c.signatureAlgorithmURI(algorithm))
+	if err != nil {
+		return nil, err
+	}
+	return &responseSigner{signingContext: signingContext}, nil
+}
+
+func (c *customResponse) signatureAlgorithmURI(algorithm domain.SAMLSignatureAlgorithm) string {
+	switch algorithm {
+	case domain.SAMLSignatureAlgorithmRSASHA256:
+		return dsig.RSASHA256SignatureMethod
+	case domain.SAMLSignatureAlgorithmRSASHA512:
+		return dsig.RSASHA512SignatureMethod
+	default:
+		return c.signatureAlgorithm
+	}
+}
+
+// sign adds the enveloped signature to the element (assertion or response),
+// which has to follow the issuer
+func (s *responseSigner) sign(el *etree.Element) error {
+	sig, err := s.signingContext.ConstructSignature(el, true)
+	if err != nil {
+		return err
+	}
+	index := 0
+	if issuer := el.SelectElement("Issuer"); issuer != nil {
+		index = issuer.Index() + 1
+	}
+	el.InsertChildAt(index, sig)
+	return nil
+}
+
+func unmarshalElement(v interface{}) (*etree.Element, error) {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	doc := etree.NewDocument()
+	if err = doc.ReadFromBytes(data); err != nil {
+		return nil, err
+	}
+	return doc.Root(), nil
+}
+
+func marshalDocument(el *etree.Element) ([]byte, error) {
+	doc := etree.NewDocument()
+	doc.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
+	doc.SetRoot(el)
+	return doc.WriteToBytes()
+}
@@ -0,0 +1,405 @@
+package saml
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const spEncryptionMetadata = `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://sp.example.com/metadata">
+<md:SPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+<md:KeyDescriptor use="encryption"><ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#"><ds:X509Data><ds:X509Certificate>%s</ds:X509Certificate></ds:X509Data></ds:KeyInfo>%s</md:KeyDescriptor>
+<md:AssertionConsumerService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://sp.example.com/acs" index="0"/>
+</md:SPSSODescriptor>
+</md:EntityDescriptor>`
+
+func Test_encryptAssertion(t *testing.T) {
+	cert, key := newTestCertAndKey(t)
+	tests := []struct {
+		name              string
+		encryptionMethods string
+		wantAlgorithm     string
+		wantKeySize       int
+	}{
+		{
+			name:              "aes128-cbc",
+			encryptionMethods: `<md:EncryptionMethod Algorithm="http://www.w3.org/2001/04/xmlenc#aes128-cbc"/>`,
+			wantAlgorithm:     encryptionAES128CBC,
+			wantKeySize:       16,
+		},
+		{
+			name:              "aes256-cbc",
+			encryptionMethods: `<md:EncryptionMethod Algorithm="http://www.w3.org/2001/04/xmlenc#aes256-cbc"/>`,
+			wantAlgorithm:     encryptionAES256CBC,
+			wantKeySize:       32,
+		},
+		{
+			name:              "aes128-gcm",
+			encryptionMethods: `<md:EncryptionMethod Algorithm="http://www.w3.org/2009/xmlenc11#aes128-gcm"/>`,
+			wantAlgorithm:     encryptionAES128GCM,
+			wantKeySize:       16,
+		},
+		{
+			name:              "aes256-gcm",
+			encryptionMethods: `<md:EncryptionMethod Algorithm="http://www.w3.org/2009/xmlenc11#aes256-gcm"/>`,
+			wantAlgorithm:     encryptionAES256GCM,
+			wantKeySize:       32,
+		},
+		{
+			name:              "first supported algorithm",
+			encryptionMethods: `<md:EncryptionMethod Algorithm="http://www.w3.org/2001/04/xmlenc#tripledes-cbc"/><md:EncryptionMethod Algorithm="http://www.w3.org/2009/xmlenc11#aes128-gcm"/>`,
+			wantAlgorithm:     encryptionAES128GCM,
+			wantKeySize:       16,
+		},
+		{
+			name:          "no algorithm, default aes256-cbc",
+			wantAlgorithm: encryptionAES256CBC,
+			wantKeySize:   32,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := fmt.Sprintf(spEncryptionMetadata, base64.StdEncoding.EncodeToString(cert), tt.encryptionMethods)
+			assertion := testAssertion(t)
+			want, err := writeElement(assertion.Copy())
+			require.NoError(t, err)
+
+			encrypted, err := encryptAssertion([]byte(metadata), assertion)
+			require.NoError(t, err)
+			encrypted = roundTrip(t, encrypted)
+
+			assert.Equal(t, "EncryptedAssertion", encrypted.Tag)
+			assert.Nil(t, encrypted.SelectElement("Assertion"), "assertion must not be readable")
+			got := decryptAssertion(t, key, encrypted, tt.wantAlgorithm, tt.wantKeySize)
+			assert.Equal(t, string(want), string(got))
+		})
+	}
+}
+
+func Test_encryptAssertion_certificateMissing(t *testing.T) {
+	cert, _ := newTestCertAndKey(t)
+	metadata := fmt.Sprintf(spMetadata, base64.StdEncoding.EncodeToString(cert))
+	metadata = strings.Replace(metadata, `use="signing"`, `use="encryption"`, 1)
+	_, err := encryptAssertion([]byte(metadata), testAssertion(t))
+	require.NoError(t, err, "a certificate for encryption must be used")
+
+	_, err = encryptAssertion([]byte(fmt.Sprintf(spMetadata, base64.StdEncoding.EncodeToString(cert))), testAssertion(t))
+	require.Error(t, err, "a certificate for signing must not be used")
+}
+
+func Test_customResponse_createResponse(t *testing.T) {
+	idpCert, idpKey := newTestCertAndKey(t)
+	spCert, spKey := newTestCertAndKey(t)
+	type args struct {
+		signingMode        domain.SAMLSigningMode
+		signatureAlgorithm domain.SAMLSignatureAlgorithm
+		encryptAssertion   bool
+	}
+	tests := []struct {
+		name                 string
+		args                 args
+		wantAlgorithm        string
+		wantSignedResponse   bool
+		wantSignedAssertion  bool
+		wantEncryptAssertion bool
+	}{
+		{
+			name: "sign assertion",
+			args: args{
+				signingMode: domain.SAMLSigningModeAssertion,
+			},
+			wantAlgorithm:       dsig.RSASHA256SignatureMethod,
+			wantSignedAssertion: true,
+		},
+		{
+			name: "sign response",
+			args: args{
+				signingMode: domain.SAMLSigningModeResponse,
+			},
+			wantAlgorithm:      dsig.RSASHA256SignatureMethod,
+			wantSignedResponse: true,
+		},
+		{
+			name: "sign response and assertion, sha512",
+			args: args{
+				signingMode:        domain.SAMLSigningModeResponseAndAssertion,
+				signatureAlgorithm: domain.SAMLSignatureAlgorithmRSASHA512,
+			},
+			wantAlgorithm:       dsig.RSASHA512SignatureMethod,
+			wantSignedResponse:  true,
+			wantSignedAssertion: true,
+		},
+		{
+			name: "sign and encrypt assertion",
+			args: args{
+				signingMode:      domain.SAMLSigningModeAssertion,
+				encryptAssertion: true,
+			},
+			wantAlgorithm:        dsig.RSASHA256SignatureMethod,
+			wantSignedAssertion:  true,
+			wantEncryptAssertion: true,
+		},
+		{
+			name: "sign response with encrypted assertion",
+			args: args{
+				signingMode:      domain.SAMLSigningModeResponse,
+				encryptAssertion: true,
+			},
+			wantAlgorithm:        dsig.RSASHA256SignatureMethod,
+			wantSignedResponse:   true,
+			wantEncryptAssertion: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &customResponse{
+				metadataEndpoint:   provider.NewEndpoint(provider.DefaultMetadataEndpoint),
+				signatureAlgorithm: dsig.RSASHA256SignatureMethod,
+			}
+			signer := testResponseSigner(t, c, idpCert, idpKey, tt.args.signatureAlgorithm)
+			config := &query.SAMLApp{
+				EntityID:         "https://sp.example.com/metadata",
+				Metadata:         []byte(fmt.Sprintf(spEncryptionMetadata, base64.StdEncoding.EncodeToString(spCert), "")),
+				SigningMode:      tt.args.signingMode,
+				EncryptAssertion: tt.args.encryptAssertion,
+			}
+			response, err := c.createResponse(testSAMLAuthRequest(), config, &saml.NameIDType{Text: "user"}, nil, "https://idp.example.com", signer)
+			require.NoError(t, err)
+			if tt.args.signingMode.SignResponse() {
+				require.NoError(t, signer.sign(response))
+			}
+			response = roundTrip(t, response)
+
+			validator := testValidationContext(t, idpCert)
+			assertSignature(t, validator, response, tt.wantSignedResponse, tt.wantAlgorithm)
+
+			assertion := response.SelectElement("Assertion")
+			if tt.wantEncryptAssertion {
+				require.Nil(t, assertion)
+				encrypted := response.SelectElement("EncryptedAssertion")
+				require.NotNil(t, encrypted)
+				plaintext := decryptAssertion(t, spKey, encrypted, encryptionAES256CBC, 32)
+				doc := etree.NewDocument()
+				require.NoError(t, doc.ReadFromBytes(plaintext))
+				assertion = doc.Root()
+			}
+			require.NotNil(t, assertion)
+			assertSignature(t, validator, assertion, tt.wantSignedAssertion, tt.wantAlgorithm)
+			assert.Equal(t, "user", assertion.FindElement("./Subject/NameID").Text())
+		})
+	}
+}
+
+func Test_responseSigner_sign_tampered(t *testing.T) {
+	cert, key := newTestCertAndKey(t)
+	c := &customResponse{
+		metadataEndpoint:   provider.NewEndpoint(provider.DefaultMetadataEndpoint),
+		signatureAlgorithm: dsig.RSASHA256SignatureMethod,
+	}
+	signer := testResponseSigner(t, c, cert, key, domain.SAMLSignatureAlgorithmUnspecified)
+	assertion := testAssertion(t)
+	require.NoError(t, signer.sign(assertion))
+	assertion = roundTrip(t, assertion)
+	assertion.FindElement("./Subject/NameID").SetText("admin")
+
+	_, err := testValidationContext(t, cert).Validate(assertion)
+	require.Error(t, err)
+}
+
+func Test_redirectResponse(t *testing.T) {
+	cert, key := newTestCertAndKey(t)
+	c := &customResponse{
+		metadataEndpoint:   provider.NewEndpoint(provider.DefaultMetadataEndpoint),
+		signatureAlgorithm: dsig.RSASHA256SignatureMethod,
+	}
+	signer := testResponseSigner(t, c, cert, key, domain.SAMLSignatureAlgorithmUnspecified)
+	tests := []struct {
+		name         string
+		signResponse bool
+	}{
+		{
+			name:         "signed",
+			signResponse: true,
+		},
+		{
+			name:         "unsigned",
+			signResponse: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authRequest := testSAMLAuthRequest()
+			response, err := c.createResponse(authRequest, &query.SAMLApp{EntityID: "https://sp.example.com/metadata"}, &saml.NameIDType{Text: "user"}, nil, "https://idp.example.com", signer)
+			require.NoError(t, err)
+			responseID := response.SelectAttrValue("ID", "")
+
+			w := httptest.NewRecorder()
+			err = redirectResponse(w, httptest.NewRequest(http.MethodGet, "/saml/v2/SSO", nil), authRequest, response, signer, tt.signResponse)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusFound, w.Code)
+			location, err := url.Parse(w.Header().Get("Location"))
+			require.NoError(t, err)
+			assert.Equal(t, "https://sp.example.com/acs", location.Scheme+"://"+location.Host+location.Path)
+
+			values := location.Query()
+			assert.Equal(t, "state", values.Get("RelayState"))
+			message, err := base64.StdEncoding.DecodeString(values.Get("SAMLResponse"))
+			require.NoError(t, err)
+			assert.False(t, strings.HasPrefix(string(message), "<"), "response must be deflated")
+			inflated, err := inflateSAMLMessage(values.Get("SAMLResponse"))
+			require.NoError(t, err)
+			doc := etree.NewDocument()
+			require.NoError(t, doc.ReadFromBytes(inflated))
+			assert.Equal(t, "Response", doc.Root().Tag)
+			assert.Equal(t, responseID, doc.Root().SelectAttrValue("ID", ""))
+
+			if !tt.signResponse {
+				assert.Empty(t, values.Get("SigAlg"))
+				assert.Empty(t, values.Get("Signature"))
+				return
+			}
+			assert.Equal(t, dsig.RSASHA256SignatureMethod, values.Get("SigAlg"))
+			signed, _, found := strings.Cut(location.RawQuery, "&Signature=")
+			require.True(t, found)
+			sig, err := base64.StdEncoding.DecodeString(values.Get("Signature"))
+			require.NoError(t, err)
+			hash := sha256.Sum256([]byte(signed))
+			require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], sig))
+		})
+	}
+}
+
+func testSAMLAuthRequest() *AuthRequest {
+	return &AuthRequest{
+		AuthRequest: &domain.AuthRequest{
+			ID:            "authRequest1",
+			CallbackURI:   "https://sp.example.com/acs",
+			TransferState: "state",
+			Request: &domain.AuthRequestSAML{
+				ID:          "_request1",
+				BindingType: provider.RedirectBinding,
+				Issuer:      "https://sp.example.com/metadata",
+			},
+		},
+	}
+}
+
+func testAssertion(t *testing.T) *etree.Element {
+	now := time.Now().UTC()
+	assertion, err := unmarshalElement(createAssertion(
+		testSAMLAuthRequest(),
+		&saml.NameIDType{Text: "user"},
+		[]*saml.AttributeType{{Name: "Email", AttributeValue: []string{"user@example.com"}}},
+		"https://idp.example.com/saml/v2/metadata",
+		"https://sp.example.com/metadata",
+		now.Format(timeFormat),
+		now.Add(assertionValidity).Format(timeFormat),
+	))
+	require.NoError(t, err)
+	return assertion
+}
+
+func testResponseSigner(t *testing.T, c *customResponse, cert []byte, key *rsa.PrivateKey, algorithm domain.SAMLSignatureAlgorithm) *responseSigner {
+	tlsCert, err := signature.ParseTlsKeyPair(cert, key)
+	require.NoError(t, err)
+	signingContext, err := signature.GetSigningContext(tlsCert, c.signatureAlgorithmURI(algorithm))
+	require.NoError(t, err)
+	return &responseSigner{signingContext: signingContext}
+}
+
+func testValidationContext(t *testing.T, cert []byte) *dsig.ValidationContext {
+	certificate, err := x509.ParseCertificate(cert)
+	require.NoError(t, err)
+	return dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: []*x509.Certificate{certificate}})
+}
+
+func assertSignature(t *testing.T, validator *dsig.ValidationContext, el *etree.Element, signed bool, algorithm string) {
+	sig := el.SelectElement("Signature")
+	if !signed {
+		assert.Nil(t, sig, "%s must not be signed", el.Tag)
+		return
+	}
+	require.NotNil(t, sig, "%s must be signed", el.Tag)
+	assert.Equal(t, algorithm, sig.FindElement("./SignedInfo/SignatureMethod").SelectAttrValue("Algorithm", ""))
+	_, err := validator.Validate(el)
+	require.NoError(t, err, "signature of %s must be valid", el.Tag)
+}
+
+// roundTrip serializes and parses the element as it is sent to the service provider
+func roundTrip(t *testing.T, el *etree.Element) *etree.Element {
+	data, err := marshalDocument(el)
+	require.NoError(t, err)
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromBytes(data))
+	return doc.Root()
+}
+
+func writeElement(el *etree.Element) ([]byte, error) {
+	doc := etree.NewDocument()
+	doc.SetRoot(el)
+	return doc.WriteToBytes()
+}
+
+// decryptAssertion decrypts the assertion as a service provider would,
+// the key is transported with RSA-OAEP and the data encrypted with the expected algorithm
+func decryptAssertion(t *testing.T, key *rsa.PrivateKey, encrypted *etree.Element, algorithm string, keySize int) []byte {
+	data := encrypted.SelectElement("EncryptedData")
+	require.NotNil(t, data)
+	assert.Equal(t, xmlencElementType, data.SelectAttrValue("Type", ""))
+	assert.Equal(t, algorithm, data.FindElement("./EncryptionMethod").SelectAttrValue("Algorithm", ""))
+
+	encryptedKey := data.FindElement("./KeyInfo/EncryptedKey")
+	require.NotNil(t, encryptedKey)
+	assert.Equal(t, keyTransportRSAOAEP, encryptedKey.FindElement("./EncryptionMethod").SelectAttrValue("Algorithm", ""))
+	assert.Equal(t, digestSHA1, encryptedKey.FindElement("./EncryptionMethod/DigestMethod").SelectAttrValue("Algorithm", ""))
+	keyValue, err := base64.StdEncoding.DecodeString(encryptedKey.FindElement("./CipherData/CipherValue").Text())
+	require.NoError(t, err)
+	dataKey, err := rsa.DecryptOAEP(sha1.New(), nil, key, keyValue, nil)
+	require.NoError(t, err)
+	require.Len(t, dataKey, keySize)
+
+	ciphertext, err := base64.StdEncoding.DecodeString(data.FindElement("./CipherData/CipherValue").Text())
+	require.NoError(t, err)
+	block, err := aes.NewCipher(dataKey)
+	require.NoError(t, err)
+	switch algorithm {
+	case encryptionAES128GCM, encryptionAES256GCM:
+		gcm, err := cipher.NewGCM(block)
+		require.NoError(t, err)
+		nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+		plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+		require.NoError(t, err)
+		return plaintext
+	default:
+		require.Zero(t, len(ciphertext)%aes.BlockSize)
+		iv, padded := ciphertext[:aes.BlockSize], ciphertext[aes.BlockSize:]
+		plaintext := make([]byte, len(padded))
+		cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, padded)
+		padding := int(plaintext[len(plaintext)-1])
+		require.True(t, padding > 0 && padding <= aes.BlockSize)
+		return plaintext[:len(plaintext)-padding]
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/zitadel/logging"
 	"github.com/zitadel/saml/pkg/provider"
 	"github.com/zitadel/saml/pkg/provider/key"
 	"github.com/zitadel/saml/pkg/provider/models"
@@ -107,6 +108,10 @@ func (p *Storage) CreateAuthRequest(ctx context.Context, req *samlp.AuthnRequest
 func (p *Storage) AuthRequestByID(ctx context.Context, id string) (_ models.AuthRequestInt, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
+	return p.authRequestByID(ctx, id)
+}
+
+func (p *Storage) authRequestByID(ctx context.Context, id string) (*AuthRequest, error) {
 	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
 	if !ok {
 		return nil, errors.ThrowPreconditionFailed(nil, "SAML-D3g21", "no user agent id")
@@ -115,7 +120,20 @@ func (p *Storage) AuthRequestByID(ctx context.Context, id string) (_ models.Auth
 	if err != nil {
 		return nil, err
 	}
-	return AuthRequestFromBusiness(resp)
+	if _, ok := resp.Request.(*domain.AuthRequestSAML); !ok {
+		return nil, errors.ThrowInvalidArgument(nil, "SAML-Hbz7B", "auth request is not of type saml")
+	}
+	return &AuthRequest{resp}, nil
+}
+
+// addSAMLSession records the session of the service provider, which has to be logged out on single logout,
+// it must only be called if the response with the nameID is sent
+func (p *Storage) addSAMLSession(ctx context.Context, authRequest *AuthRequest, nameID string) {
+	if !authRequest.Done() {
+		return
+	}
+	err := p.command.AddHumanSAMLSession(ctx, authRequest.UserID, authRequest.UserOrgID, authRequest.AgentID, authRequest.ApplicationID, authRequest.GetIssuer(), nameID)
+	logging.WithFields("authRequestID", authRequest.ID).OnError(err).Warn("unable to add saml session")
 }
 
 func (p *Storage) SetUserinfoWithUserID(ctx context.Context, userinfo models.AttributeSetter, userID string, attributes []int) (err error) {
@@ -0,0 +1,401 @@
+package login
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"html/template"
+	"math/bits"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	botProtectionChallengeField = "bot-challenge"
+	botProtectionSolutionField  = "bot-solution"
+
+	maxProofOfWorkDifficulty = 32
+)
+
+type BotProtectionConfig struct {
+	// ChallengeLifetime is the time a rendered challenge can be solved and submitted
+	ChallengeLifetime time.Duration
+	// ProofOfWorkDifficulty is the number of leading zero bits the hash computed by the browser must have
+	ProofOfWorkDifficulty uint8
+	// CaptchaLength is the number of digits shown on the image captcha
+	CaptchaLength int
+	// External configures a widget based provider (e.g. hCaptcha or Cloudflare Turnstile)
+	External ExternalBotProtectionConfig
+}
+
+type ExternalBotProtectionConfig struct {
+	// ScriptURL of the widget, its host is added to the content security policy
+	ScriptURL string
+	// WidgetClass is the css class of the element the widget will be rendered into (e.g. h-captcha or cf-turnstile)
+	WidgetClass string
+	// ResponseField is the name of the form field the widget writes its response to (e.g. h-captcha-response)
+	ResponseField string
+	// VerifyURL is called with the secret and the response of the widget
+	VerifyURL string
+	SiteKey   string
+	Secret    string
+	Timeout   time.Duration
+}
+
+func (c *ExternalBotProtectionConfig) isConfigured() bool {
+	return c.ScriptURL != "" && c.VerifyURL != "" && c.ResponseField != "" && c.SiteKey != "" && c.Secret != ""
+}
+
+// cspHost returns the origin of the widget script, so it can be allowed in the content security policy
+func (c *ExternalBotProtectionConfig) cspHost() string {
+	if c.ScriptURL == "" {
+		return ""
+	}
+	scriptURL, err := url.Parse(c.ScriptURL)
+	if err != nil || scriptURL.Host == "" {
+		return ""
+	}
+	return scriptURL.Scheme + "://" + scriptURL.Host
+}
+
+type botProtectionData struct {
+	Type        domain.BotProtectionType
+	Challenge   string
+	Difficulty  uint8
+	Image       template.URL
+	ScriptURL   string
+	WidgetClass string
+	SiteKey     string
+}
+
+func (d *botProtectionData) IsProofOfWork() bool {
+	return d.Type == domain.BotProtectionTypeProofOfWork
+}
+
+func (d *botProtectionData) IsImageCaptcha() bool {
+	return d.Type == domain.BotProtectionTypeImageCaptcha
+}
+
+func (d *botProtectionData) IsExternal() bool {
+	return d.Type == domain.BotProtectionTypeExternal
+}
+
+// botProtection issues signed challenges, which are bound to the user agent and expire after the configured lifetime.
+// Each challenge can only be verified once, the nonces of verified challenges are kept until they expire.
+type botProtection struct {
+	config BotProtectionConfig
+	key    []byte
+	client *http.Client
+	now    func() time.Time
+	used   *usedChallenges
+}
+
+// usedChallenges remembers the nonces of verified challenges until the challenges expire,
+// so a solved challenge can't be submitted again. The nonces are kept in memory of the running process.
+type usedChallenges struct {
+	mu          sync.Mutex
+	nonces      map[string]time.Time
+	lastCleanup time.Time
+}
+
+func newUsedChallenges() *usedChallenges {
+	return &usedChallenges{
+		nonces: make(map[string]time.Time),
+	}
+}
+
+// use marks the nonce as used until the expiration and returns false if it was already used
+func (u *usedChallenges) use(nonce string, expiration, now time.Time) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.cleanup(now)
+	if exp, ok := u.nonces[nonce]; ok && now.Before(exp) {
+		return false
+	}
+	u.nonces[nonce] = expiration
+	return true
+}
+
+// cleanup removes expired nonces at most once a minute
+func (u *usedChallenges) cleanup(now time.Time) {
+	if now.Sub(u.lastCleanup) < time.Minute {
+		return
+	}
+	u.lastCleanup = now
+	for nonce, expiration := range u.nonces {
+		if !now.Before(expiration) {
+			delete(u.nonces, nonce)
+		}
+	}
+}
+
+func newBotProtection(config BotProtectionConfig, key []byte) *botProtection {
+	if config.ChallengeLifetime == 0 {
+		config.ChallengeLifetime = 10 * time.Minute
+	}
+	if config.ProofOfWorkDifficulty == 0 || config.ProofOfWorkDifficulty > maxProofOfWorkDifficulty {
+		config.ProofOfWorkDifficulty = 16
+	}
+	if config.CaptchaLength <= 0 {
+		config.CaptchaLength = 6
+	}
+	if config.External.Timeout == 0 {
+		config.External.Timeout = 10 * time.Second
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("bot-protection"))
+	return &botProtection{
+		config: config,
+		key:    mac.Sum(nil),
+		client: &http.Client{Timeout: config.External.Timeout},
+		now:    time.Now,
+		used:   newUsedChallenges(),
+	}
+}
+
+// challenge creates the data needed to render the challenge of the type into the form.
+// It returns nil if no challenge is required.
+func (b *botProtection) challenge(r *http.Request, botProtectionType domain.BotProtectionType) (*botProtectionData, error) {
+	switch botProtectionType {
+	case domain.BotProtectionTypeProofOfWork:
+		token, err := b.newChallengeToken(r, botProtectionType)
+		if err != nil {
+			return nil, err
+		}
+		return &botProtectionData{
+			Type:       botProtectionType,
+			Challenge:  token,
+			Difficulty: b.config.ProofOfWorkDifficulty,
+		}, nil
+	case domain.BotProtectionTypeImageCaptcha:
+		token, err := b.newChallengeToken(r, botProtectionType)
+		if err != nil {
+			return nil, err
+		}
+		image, err := captchaImage(b.captchaAnswer(token))
+		if err != nil {
+			return nil, err
+		}
+		return &botProtectionData{
+			Type:      botProtectionType,
+			Challenge: token,
+			Image:     template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(image)),
+		}, nil
+	case domain.BotProtectionTypeExternal:
+		if !b.config.External.isConfigured() {
+			return nil, caos_errs.ThrowPreconditionFailed(nil, "LOGIN-Zu3ka", "Errors.BotProtection.NotConfigured")
+		}
+		return &botProtectionData{
+			Type:        botProtectionType,
+			ScriptURL:   b.config.External.ScriptURL,
+			WidgetClass: b.config.External.WidgetClass,
+			SiteKey:     b.config.External.SiteKey,
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// verify checks the solution of the challenge submitted with the form of the request
+func (b *botProtection) verify(r *http.Request, botProtectionType domain.BotProtectionType) error {
+	switch botProtectionType {
+	case domain.BotProtectionTypeProofOfWork:
+		token := r.FormValue(botProtectionChallengeField)
+		difficulty, err := b.verifyChallengeToken(r, token, botProtectionType)
+		if err != nil {
+			return err
+		}
+		solution := r.FormValue(botProtectionSolutionField)
+		if _, err := strconv.ParseUint(solution, 10, 64); err != nil {
+			return caos_errs.ThrowInvalidArgument(err, "LOGIN-Nb5gs", "Errors.BotProtection.Failed")
+		}
+		hash := sha256.Sum256([]byte(token + ":" + solution))
+		if leadingZeroBits(hash[:]) < int(difficulty) {
+			return caos_errs.ThrowInvalidArgument(nil, "LOGIN-Ye8wu", "Errors.BotProtection.Failed")
+		}
+		return nil
+	case domain.BotProtectionTypeImageCaptcha:
+		token := r.FormValue(botProtectionChallengeField)
+		if _, err := b.verifyChallengeToken(r, token, botProtectionType); err != nil {
+			return err
+		}
+		solution := strings.TrimSpace(r.FormValue(botProtectionSolutionField))
+		if subtle.ConstantTimeCompare([]byte(solution), []byte(b.captchaAnswer(token))) != 1 {
+			return caos_errs.ThrowInvalidArgument(nil, "LOGIN-Xo2pd", "Errors.BotProtection.Failed")
+		}
+		return nil
+	case domain.BotProtectionTypeExternal:
+		return b.verifyExternal(r)
+	default:
+		return nil
+	}
+}
+
+type externalVerificationResponse struct {
+	Success bool `json:"success"`
+}
+
+// verifyExternal calls the verification endpoint of the provider,
+// hCaptcha and Turnstile share the same request and response format
+func (b *botProtection) verifyExternal(r *http.Request) error {
+	if !b.config.External.isConfigured() {
+		return caos_errs.ThrowPreconditionFailed(nil, "LOGIN-Gm0ve", "Errors.BotProtection.NotConfigured")
+	}
+	response := r.FormValue(b.config.External.ResponseField)
+	if response == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "LOGIN-Hw3sq", "Errors.BotProtection.Failed")
+	}
+	values := url.Values{
+		"secret":   {b.config.External.Secret},
+		"response": {response},
+		"sitekey":  {b.config.External.SiteKey},
+	}
+	if ip := http_utils.RemoteIPStringFromRequest(r); ip != "" {
+		values.Set("remoteip", ip)
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), b.config.External.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.config.External.VerifyURL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "LOGIN-Tq1mc", "Errors.BotProtection.Unavailable")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return caos_errs.ThrowUnavailable(err, "LOGIN-Pk4rf", "Errors.BotProtection.Unavailable")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return caos_errs.ThrowUnavailable(nil, "LOGIN-Bv6le", "Errors.BotProtection.Unavailable")
+	}
+	verification := new(externalVerificationResponse)
+	if err = json.NewDecoder(resp.Body).Decode(verification); err != nil {
+		return caos_errs.ThrowUnavailable(err, "LOGIN-Ja9xe", "Errors.BotProtection.Unavailable")
+	}
+	if !verification.Success {
+		return caos_errs.ThrowInvalidArgument(nil, "LOGIN-Cs7nw", "Errors.BotProtection.Failed")
+	}
+	return nil
+}
+
+// newChallengeToken returns a signed token containing the type, the expiration,
+// the user agent and the difficulty of the challenge
+func (b *botProtection) newChallengeToken(r *http.Request, botProtectionType domain.BotProtectionType) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", caos_errs.ThrowInternal(err, "LOGIN-Rf3ui", "Errors.Internal")
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	payload := strings.Join([]string{
+		strconv.Itoa(int(botProtectionType)),
+		strconv.FormatInt(b.now().Add(b.config.ChallengeLifetime).Unix(), 10),
+		userAgentID,
+		strconv.Itoa(int(b.config.ProofOfWorkDifficulty)),
+		base64.RawURLEncoding.EncodeToString(nonce),
+	}, "|")
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + base64.RawURLEncoding.EncodeToString(b.sign([]byte(payload))), nil
+}
+
+// verifyChallengeToken checks signature, type, expiration and user agent of the token,
+// marks it as used and returns the difficulty the challenge was issued with.
+// A token can only be verified once, even if the submitted solution is wrong.
+func (b *botProtection) verifyChallengeToken(r *http.Request, token string, botProtectionType domain.BotProtectionType) (uint8, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, caos_errs.ThrowInvalidArgument(nil, "LOGIN-Ew4ka", "Errors.BotProtection.Failed")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return 0, caos_errs.ThrowInvalidArgument(err, "LOGIN-Sd0vo", "Errors.BotProtection.Failed")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, b.sign(payload)) {
+		return 0, caos_errs.ThrowInvalidArgument(err, "LOGIN-Lo5qi", "Errors.BotProtection.Failed")
+	}
+	parts := strings.Split(string(payload), "|")
+	if len(parts) != 5 || parts[0] != strconv.Itoa(int(botProtectionType)) {
+		return 0, caos_errs.ThrowInvalidArgument(nil, "LOGIN-Vb1ar", "Errors.BotProtection.Failed")
+	}
+	expiration, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || b.now().After(time.Unix(expiration, 0)) {
+		return 0, caos_errs.ThrowInvalidArgument(err, "LOGIN-Mi2dz", "Errors.BotProtection.Expired")
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	if parts[2] != userAgentID {
+		return 0, caos_errs.ThrowInvalidArgument(nil, "LOGIN-Qe6ty", "Errors.BotProtection.Failed")
+	}
+	difficulty, err := strconv.ParseUint(parts[3], 10, 8)
+	if err != nil {
+		return 0, caos_errs.ThrowInvalidArgument(err, "LOGIN-Fz8hn", "Errors.BotProtection.Failed")
+	}
+	if !b.used.use(parts[4], time.Unix(expiration, 0), b.now()) {
+		return 0, caos_errs.ThrowInvalidArgument(nil, "LOGIN-Ru5sd", "Errors.BotProtection.AlreadyUsed")
+	}
+	return uint8(difficulty), nil
+}
+
+// captchaAnswer derives the digits shown on the image from the token,
+// so the answer does not have to be stored or sent to the browser
+func (b *botProtection) captchaAnswer(token string) string {
+	sum := b.sign([]byte("captcha|" + token))
+	answer := make([]byte, b.config.CaptchaLength)
+	for i := range answer {
+		answer[i] = '0' + sum[i%len(sum)]%10
+	}
+	return string(answer)
+}
+
+func (b *botProtection) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, b.key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func leadingZeroBits(hash []byte) int {
+	count := 0
+	for _, b := range hash {
+		if b != 0 {
+			return count + bits.LeadingZeros8(b)
+		}
+		count += 8
+	}
+	return count
+}
+
+// botProtectionChallenge returns the challenge required by the login policy of the organisation (default policy if empty).
+// Errors are only logged, the form is rendered without challenge and will be rejected on submit.
+func (l *Login) botProtectionChallenge(r *http.Request, orgID string) *botProtectionData {
+	policy, err := l.getLoginPolicy(r, orgID)
+	if err != nil {
+		logging.WithError(err).Warn("unable to get login policy for bot protection")
+		return nil
+	}
+	data, err := l.botProtection.challenge(r, policy.BotProtection)
+	logging.OnError(err).Warn("unable to create bot protection challenge")
+	return data
+}
+
+// checkBotProtection verifies the submitted challenge if the login policy of the organisation requires one
+func (l *Login) checkBotProtection(r *http.Request, orgID string) error {
+	policy, err := l.getLoginPolicy(r, orgID)
+	if err != nil {
+		return err
+	}
+	return l.botProtection.verify(r, policy.BotProtection)
+}
@@ -0,0 +1,146 @@
+package login
+
+import (
+	"bytes"
+	"crypto/rand"
+	"image"
+	"image/color"
+	"image/png"
+	"math/big"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	captchaGlyphWidth  = 5
+	captchaGlyphHeight = 7
+	captchaScale       = 4
+	captchaPadding     = 8
+	captchaSpacing     = 6
+	captchaNoiseLines  = 6
+	captchaNoiseDots   = 120
+)
+
+// captchaGlyphs are 5x7 bitmaps of the digits 0-9, each row uses the lower 5 bits
+var captchaGlyphs = [10][captchaGlyphHeight]uint8{
+	{0x0e, 0x11, 0x13, 0x15, 0x19, 0x11, 0x0e},
+	{0x04, 0x0c, 0x04, 0x04, 0x04, 0x04, 0x0e},
+	{0x0e, 0x11, 0x01, 0x02, 0x04, 0x08, 0x1f},
+	{0x1f, 0x02, 0x04, 0x02, 0x01, 0x11, 0x0e},
+	{0x02, 0x06, 0x0a, 0x12, 0x1f, 0x02, 0x02},
+	{0x1f, 0x10, 0x1e, 0x01, 0x01, 0x11, 0x0e},
+	{0x06, 0x08, 0x10, 0x1e, 0x11, 0x11, 0x0e},
+	{0x1f, 0x01, 0x02, 0x04, 0x08, 0x08, 0x08},
+	{0x0e, 0x11, 0x11, 0x0e, 0x11, 0x11, 0x0e},
+	{0x0e, 0x11, 0x11, 0x0f, 0x01, 0x02, 0x0c},
+}
+
+// captchaImage renders the digits into a png with randomly shifted glyphs and noise
+func captchaImage(digits string) ([]byte, error) {
+	glyphWidth := captchaGlyphWidth * captchaScale
+	glyphHeight := captchaGlyphHeight * captchaScale
+	width := 2*captchaPadding + len(digits)*(glyphWidth+captchaSpacing) - captchaSpacing
+	height := 2*captchaPadding + glyphHeight
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	background := color.RGBA{R: 0xf5, G: 0xf5, B: 0xf5, A: 0xff}
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, background)
+		}
+	}
+
+	for i, digit := range digits {
+		if digit < '0' || digit > '9' {
+			return nil, caos_errs.ThrowInternal(nil, "LOGIN-Ug4vb", "Errors.Internal")
+		}
+		offsetX := captchaPadding + i*(glyphWidth+captchaSpacing) + randomInt(5) - 2
+		offsetY := captchaPadding + randomInt(captchaPadding) - captchaPadding/2
+		ink := randomColor(0x00, 0x60)
+		drawGlyph(img, captchaGlyphs[digit-'0'], offsetX, offsetY, ink)
+	}
+
+	for i := 0; i < captchaNoiseLines; i++ {
+		drawLine(img, randomInt(width), randomInt(height), randomInt(width), randomInt(height), randomColor(0x40, 0xa0))
+	}
+	for i := 0; i < captchaNoiseDots; i++ {
+		img.Set(randomInt(width), randomInt(height), randomColor(0x00, 0xc0))
+	}
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "LOGIN-Hs5ko", "Errors.Internal")
+	}
+	return buf.Bytes(), nil
+}
+
+func drawGlyph(img *image.RGBA, glyph [captchaGlyphHeight]uint8, offsetX, offsetY int, ink color.Color) {
+	for row, bits := range glyph {
+		// shear the rows slightly, so the glyphs are not identical for every image
+		shift := randomInt(2)
+		for col := 0; col < captchaGlyphWidth; col++ {
+			if bits&(1<<(captchaGlyphWidth-1-col)) == 0 {
+				continue
+			}
+			for dx := 0; dx < captchaScale; dx++ {
+				for dy := 0; dy < captchaScale; dy++ {
+					img.Set(offsetX+col*captchaScale+dx+shift, offsetY+row*captchaScale+dy, ink)
+				}
+			}
+		}
+	}
+}
+
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		img.Set(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func randomColor(min, max uint8) color.Color {
+	return color.RGBA{
+		R: min + uint8(randomInt(int(max-min))),
+		G: min + uint8(randomInt(int(max-min))),
+		B: min + uint8(randomInt(int(max-min))),
+		A: 0xff,
+	}
+}
+
+func randomInt(max int) int {
+	if max <= 0 {
+		return 0
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		return 0
+	}
+	return int(n.Int64())
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
@@ -0,0 +1,43 @@
+package login
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func Test_captchaImage(t *testing.T) {
+	tests := []struct {
+		name    string
+		digits  string
+		wantErr bool
+	}{
+		{
+			name:   "digits, ok",
+			digits: "0123456789",
+		},
+		{
+			name:    "non digit, error",
+			digits:  "12a4",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := captchaImage(tt.digits)
+			if tt.wantErr {
+				assert.True(t, caos_errs.IsInternal(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			img, err := png.Decode(bytes.NewReader(got))
+			require.NoError(t, err)
+			assert.Greater(t, img.Bounds().Dx(), img.Bounds().Dy())
+		})
+	}
+}
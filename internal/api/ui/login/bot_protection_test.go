@@ -0,0 +1,268 @@
+package login
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func newTestBotProtection(config BotProtectionConfig, now time.Time) *botProtection {
+	b := newBotProtection(config, []byte("key"))
+	b.now = func() time.Time { return now }
+	return b
+}
+
+func botProtectionRequest(values url.Values) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func solveProofOfWork(token string, difficulty uint8) string {
+	for i := 0; ; i++ {
+		solution := strconv.Itoa(i)
+		hash := sha256.Sum256([]byte(token + ":" + solution))
+		if leadingZeroBits(hash[:]) >= int(difficulty) {
+			return solution
+		}
+	}
+}
+
+func Test_botProtection_verifyChallengeToken(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	config := BotProtectionConfig{ChallengeLifetime: time.Minute, ProofOfWorkDifficulty: 8}
+	tests := []struct {
+		name    string
+		token   func(b *botProtection) string
+		typ     domain.BotProtectionType
+		wantErr string
+	}{
+		{
+			name: "valid, ok",
+			token: func(b *botProtection) string {
+				token, _ := b.newChallengeToken(botProtectionRequest(nil), domain.BotProtectionTypeProofOfWork)
+				return token
+			},
+			typ: domain.BotProtectionTypeProofOfWork,
+		},
+		{
+			name: "tampered payload, error",
+			token: func(b *botProtection) string {
+				token, _ := b.newChallengeToken(botProtectionRequest(nil), domain.BotProtectionTypeProofOfWork)
+				encodedPayload, signature, _ := strings.Cut(token, ".")
+				payload, _ := base64.RawURLEncoding.DecodeString(encodedPayload)
+				payload = []byte(strings.Replace(string(payload), "|8|", "|0|", 1))
+				return base64.RawURLEncoding.EncodeToString(payload) + "." + signature
+			},
+			typ:     domain.BotProtectionTypeProofOfWork,
+			wantErr: "Errors.BotProtection.Failed",
+		},
+		{
+			name: "signed with other key, error",
+			token: func(b *botProtection) string {
+				other := newBotProtection(config, []byte("other"))
+				other.now = b.now
+				token, _ := other.newChallengeToken(botProtectionRequest(nil), domain.BotProtectionTypeProofOfWork)
+				return token
+			},
+			typ:     domain.BotProtectionTypeProofOfWork,
+			wantErr: "Errors.BotProtection.Failed",
+		},
+		{
+			name: "other type, error",
+			token: func(b *botProtection) string {
+				token, _ := b.newChallengeToken(botProtectionRequest(nil), domain.BotProtectionTypeImageCaptcha)
+				return token
+			},
+			typ:     domain.BotProtectionTypeProofOfWork,
+			wantErr: "Errors.BotProtection.Failed",
+		},
+		{
+			name: "expired, error",
+			token: func(b *botProtection) string {
+				token, _ := b.newChallengeToken(botProtectionRequest(nil), domain.BotProtectionTypeProofOfWork)
+				b.now = func() time.Time { return now.Add(2 * time.Minute) }
+				return token
+			},
+			typ:     domain.BotProtectionTypeProofOfWork,
+			wantErr: "Errors.BotProtection.Expired",
+		},
+		{
+			name: "already used, error",
+			token: func(b *botProtection) string {
+				token, _ := b.newChallengeToken(botProtectionRequest(nil), domain.BotProtectionTypeProofOfWork)
+				_, err := b.verifyChallengeToken(botProtectionRequest(nil), token, domain.BotProtectionTypeProofOfWork)
+				require.NoError(t, err)
+				return token
+			},
+			typ:     domain.BotProtectionTypeProofOfWork,
+			wantErr: "Errors.BotProtection.AlreadyUsed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBotProtection(config, now)
+			token := tt.token(b)
+			difficulty, err := b.verifyChallengeToken(botProtectionRequest(nil), token, tt.typ)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.True(t, caos_errs.IsErrorInvalidArgument(err))
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, config.ProofOfWorkDifficulty, difficulty)
+		})
+	}
+}
+
+func Test_botProtection_verify_proofOfWork(t *testing.T) {
+	b := newTestBotProtection(BotProtectionConfig{ProofOfWorkDifficulty: 8}, time.Now())
+	data, err := b.challenge(botProtectionRequest(nil), domain.BotProtectionTypeProofOfWork)
+	require.NoError(t, err)
+	require.Equal(t, uint8(8), data.Difficulty)
+
+	var wrong string
+	for i := 0; ; i++ {
+		hash := sha256.Sum256([]byte(data.Challenge + ":" + strconv.Itoa(i)))
+		if leadingZeroBits(hash[:]) < int(data.Difficulty) {
+			wrong = strconv.Itoa(i)
+			break
+		}
+	}
+
+	err = b.verify(botProtectionRequest(url.Values{botProtectionChallengeField: {data.Challenge}, botProtectionSolutionField: {wrong}}), domain.BotProtectionTypeProofOfWork)
+	assert.ErrorContains(t, err, "Errors.BotProtection.Failed")
+
+	data, err = b.challenge(botProtectionRequest(nil), domain.BotProtectionTypeProofOfWork)
+	require.NoError(t, err)
+	solution := solveProofOfWork(data.Challenge, data.Difficulty)
+	form := url.Values{botProtectionChallengeField: {data.Challenge}, botProtectionSolutionField: {solution}}
+	assert.NoError(t, b.verify(botProtectionRequest(form), domain.BotProtectionTypeProofOfWork))
+	assert.ErrorContains(t, b.verify(botProtectionRequest(form), domain.BotProtectionTypeProofOfWork), "Errors.BotProtection.AlreadyUsed")
+}
+
+func Test_botProtection_verify_imageCaptcha(t *testing.T) {
+	b := newTestBotProtection(BotProtectionConfig{CaptchaLength: 6}, time.Now())
+	data, err := b.challenge(botProtectionRequest(nil), domain.BotProtectionTypeImageCaptcha)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data.Image), "data:image/png;base64,"))
+
+	answer := b.captchaAnswer(data.Challenge)
+	require.Len(t, answer, 6)
+	wrong := []byte(answer)
+	wrong[0] = '0' + (wrong[0]-'0'+1)%10
+	err = b.verify(botProtectionRequest(url.Values{botProtectionChallengeField: {data.Challenge}, botProtectionSolutionField: {string(wrong)}}), domain.BotProtectionTypeImageCaptcha)
+	assert.ErrorContains(t, err, "Errors.BotProtection.Failed")
+	// the answer can't be guessed with the same challenge after a wrong solution
+	err = b.verify(botProtectionRequest(url.Values{botProtectionChallengeField: {data.Challenge}, botProtectionSolutionField: {answer}}), domain.BotProtectionTypeImageCaptcha)
+	assert.ErrorContains(t, err, "Errors.BotProtection.AlreadyUsed")
+
+	data, err = b.challenge(botProtectionRequest(nil), domain.BotProtectionTypeImageCaptcha)
+	require.NoError(t, err)
+	form := url.Values{botProtectionChallengeField: {data.Challenge}, botProtectionSolutionField: {" " + b.captchaAnswer(data.Challenge) + " "}}
+	assert.NoError(t, b.verify(botProtectionRequest(form), domain.BotProtectionTypeImageCaptcha))
+	assert.ErrorContains(t, b.verify(botProtectionRequest(form), domain.BotProtectionTypeImageCaptcha), "Errors.BotProtection.AlreadyUsed")
+}
+
+func Test_botProtection_verify_external(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.FormValue("secret") != "secret" || r.FormValue("sitekey") != "sitekey" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.FormValue("response") {
+		case "valid":
+			w.Write([]byte(`{"success":true}`))
+		case "unavailable":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Write([]byte(`{"success":false}`))
+		}
+	}))
+	defer server.Close()
+
+	config := BotProtectionConfig{
+		External: ExternalBotProtectionConfig{
+			ScriptURL:     "https://captcha.example.com/api.js",
+			ResponseField: "captcha-response",
+			VerifyURL:     server.URL,
+			SiteKey:       "sitekey",
+			Secret:        "secret",
+		},
+	}
+	tests := []struct {
+		name     string
+		config   BotProtectionConfig
+		response string
+		wantErr  func(error) bool
+	}{
+		{
+			name:     "not configured, precondition error",
+			config:   BotProtectionConfig{},
+			response: "valid",
+			wantErr:  caos_errs.IsPreconditionFailed,
+		},
+		{
+			name:     "missing response, invalid argument error",
+			config:   config,
+			response: "",
+			wantErr:  caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name:     "provider unavailable, unavailable error",
+			config:   config,
+			response: "unavailable",
+			wantErr:  caos_errs.IsUnavailable,
+		},
+		{
+			name:     "rejected, invalid argument error",
+			config:   config,
+			response: "invalid",
+			wantErr:  caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name:     "valid, ok",
+			config:   config,
+			response: "valid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBotProtection(tt.config, time.Now())
+			err := b.verify(botProtectionRequest(url.Values{"captcha-response": {tt.response}}), domain.BotProtectionTypeExternal)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_usedChallenges_use(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	used := newUsedChallenges()
+
+	assert.True(t, used.use("nonce", now.Add(time.Minute), now))
+	assert.False(t, used.use("nonce", now.Add(time.Minute), now.Add(30*time.Second)))
+	assert.True(t, used.use("other", now.Add(time.Minute), now.Add(30*time.Second)))
+
+	// expired nonces are removed on cleanup
+	assert.True(t, used.use("new", now.Add(3*time.Minute), now.Add(2*time.Minute)))
+	assert.NotContains(t, used.nonces, "nonce")
+	assert.NotContains(t, used.nonces, "other")
+	assert.Contains(t, used.nonces, "new")
+}
@@ -0,0 +1,24 @@
+package login
+
+import (
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// finishCodeChallengeCheck runs the post authentication actions after a code sent to the user (magic link, phone otp)
+// was checked and sets the metadata returned by them. It returns the error of the check or the actions.
+func (l *Login) finishCodeChallengeCheck(r *http.Request, authReq *domain.AuthRequest, method authMethod, checkErr error) error {
+	metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, method, checkErr)
+	if checkErr != nil {
+		return checkErr
+	}
+	if actionErr != nil {
+		return actionErr
+	}
+	if len(metadata) > 0 {
+		_, err := l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
+		return err
+	}
+	return nil
+}
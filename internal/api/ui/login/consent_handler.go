@@ -0,0 +1,94 @@
+package login
+
+import (
+	"net/http"
+
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	tmplConsent = "consent"
+)
+
+type consentData struct {
+	Deny bool `schema:"deny"`
+}
+
+type consentScope struct {
+	Scope string
+	Label string
+}
+
+type consentTemplateData struct {
+	userData
+	AppName string
+	Scopes  []consentScope
+}
+
+var consentScopeLabels = map[string]string{
+	oidc.ScopeOpenID:        "Consent.Scopes.OpenID",
+	oidc.ScopeProfile:       "Consent.Scopes.Profile",
+	oidc.ScopeEmail:         "Consent.Scopes.Email",
+	oidc.ScopePhone:         "Consent.Scopes.Phone",
+	oidc.ScopeAddress:       "Consent.Scopes.Address",
+	oidc.ScopeOfflineAccess: "Consent.Scopes.OfflineAccess",
+}
+
+func (l *Login) renderConsent(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, step *domain.ConsentStep, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	translator := l.getTranslator(r.Context(), authReq)
+	data := &consentTemplateData{
+		userData: l.getUserData(r, authReq, "Consent.Title", "Consent.Description", errID, errMessage),
+		AppName:  authReq.ApplicationID,
+		Scopes:   make([]consentScope, 0, len(step.Scopes)),
+	}
+	if app, err := l.query.AppByOIDCClientID(r.Context(), authReq.ApplicationID, false); err == nil {
+		data.AppName = app.Name
+	}
+	for _, scope := range step.Scopes {
+		label := scope
+		if key, ok := consentScopeLabels[scope]; ok {
+			label = translator.LocalizeWithoutArgs(key)
+		}
+		data.Scopes = append(data.Scopes, consentScope{Scope: scope, Label: label})
+	}
+	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplConsent], data, nil)
+}
+
+// handleConsent stores the consent of the user and continues the login.
+// If the user denies, the user is redirected back to the client, which will receive an access_denied error.
+func (l *Login) handleConsent(w http.ResponseWriter, r *http.Request) {
+	data := new(consentData)
+	authReq, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq == nil {
+		l.renderError(w, r, nil, caos_errs.ThrowInvalidArgument(nil, "LOGIN-Vq2pe", "Errors.AuthRequest.NotFound"))
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	if data.Deny {
+		err = l.authRepo.DenyConsent(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, authReq.ID, userAgentID, domain.BrowserInfoFromRequest(r))
+		if err != nil {
+			l.renderError(w, r, authReq, err)
+			return
+		}
+		l.redirectToCallback(w, r, authReq)
+		return
+	}
+	err = l.authRepo.GrantConsent(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, authReq.ID, userAgentID, domain.BrowserInfoFromRequest(r))
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
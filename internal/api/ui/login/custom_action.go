@@ -135,6 +135,9 @@ const (
 	authMethodOTP          authMethod = "OTP"
 	authMethodU2F          authMethod = "U2F"
 	authMethodPasswordless authMethod = "passwordless"
+	authMethodMagicLink    authMethod = "magicLink"
+	authMethodPhoneOTP     authMethod = "phoneOTP"
+	authMethodPush         authMethod = "push"
 )
 
 func (l *Login) runPostInternalAuthenticationActions(
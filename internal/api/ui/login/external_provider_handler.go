@@ -137,35 +137,7 @@ func (l *Login) handleIDP(w http.ResponseWriter, r *http.Request, authReq *domai
 		l.renderLogin(w, r, authReq, err)
 		return
 	}
-	var provider idp.Provider
-
-	switch identityProvider.Type {
-	case domain.IDPTypeOAuth:
-		provider, err = l.oauthProvider(r.Context(), identityProvider)
-	case domain.IDPTypeOIDC:
-		provider, err = l.oidcProvider(r.Context(), identityProvider)
-	case domain.IDPTypeJWT:
-		provider, err = l.jwtProvider(identityProvider)
-	case domain.IDPTypeAzureAD:
-		provider, err = l.azureProvider(r.Context(), identityProvider)
-	case domain.IDPTypeGitHub:
-		provider, err = l.githubProvider(r.Context(), identityProvider)
-	case domain.IDPTypeGitHubEnterprise:
-		provider, err = l.githubEnterpriseProvider(r.Context(), identityProvider)
-	case domain.IDPTypeGitLab:
-		provider, err = l.gitlabProvider(r.Context(), identityProvider)
-	case domain.IDPTypeGitLabSelfHosted:
-		provider, err = l.gitlabSelfHostedProvider(r.Context(), identityProvider)
-	case domain.IDPTypeGoogle:
-		provider, err = l.googleProvider(r.Context(), identityProvider)
-	case domain.IDPTypeLDAP:
-		provider, err = l.ldapProvider(r.Context(), identityProvider)
-	case domain.IDPTypeUnspecified:
-		fallthrough
-	default:
-		l.renderLogin(w, r, authReq, errors.ThrowInvalidArgument(nil, "LOGIN-AShek", "Errors.ExternalIDP.IDPTypeNotImplemented"))
-		return
-	}
+	provider, err := l.idpProvider(r.Context(), identityProvider)
 	if err != nil {
 		l.renderLogin(w, r, authReq, err)
 		return
@@ -188,6 +160,10 @@ func (l *Login) handleExternalLoginCallback(w http.ResponseWriter, r *http.Reque
 		l.renderLogin(w, r, nil, err)
 		return
 	}
+	if strings.HasPrefix(data.State, intentStatePrefix) {
+		l.handleIDPIntentCallback(w, r, strings.TrimPrefix(data.State, intentStatePrefix), data.Code)
+		return
+	}
 	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
 	authReq, err := l.authRepo.AuthRequestByID(r.Context(), data.State, userAgentID)
 	if err != nil {
@@ -199,80 +175,108 @@ func (l *Login) handleExternalLoginCallback(w http.ResponseWriter, r *http.Reque
 		l.externalAuthFailed(w, r, authReq, nil, nil, err)
 		return
 	}
-	var provider idp.Provider
-	var session idp.Session
+	session, err := l.idpSession(r.Context(), identityProvider, data.Code)
+	if err != nil {
+		l.externalAuthFailed(w, r, authReq, nil, nil, err)
+		return
+	}
+
+	user, err := session.FetchUser(r.Context())
+	if err != nil {
+		l.externalAuthFailed(w, r, authReq, tokens(session), user, err)
+		return
+	}
+	l.handleExternalUserAuthenticated(w, r, authReq, identityProvider, session, user, l.renderNextStep)
+}
+
+// idpProvider creates the provider for the authentication on the passed identity provider
+func (l *Login) idpProvider(ctx context.Context, identityProvider *query.IDPTemplate) (idp.Provider, error) {
 	switch identityProvider.Type {
 	case domain.IDPTypeOAuth:
-		provider, err = l.oauthProvider(r.Context(), identityProvider)
+		return l.oauthProvider(ctx, identityProvider)
+	case domain.IDPTypeOIDC:
+		return l.oidcProvider(ctx, identityProvider)
+	case domain.IDPTypeJWT:
+		return l.jwtProvider(identityProvider)
+	case domain.IDPTypeAzureAD:
+		return l.azureProvider(ctx, identityProvider)
+	case domain.IDPTypeGitHub:
+		return l.githubProvider(ctx, identityProvider)
+	case domain.IDPTypeGitHubEnterprise:
+		return l.githubEnterpriseProvider(ctx, identityProvider)
+	case domain.IDPTypeGitLab:
+		return l.gitlabProvider(ctx, identityProvider)
+	case domain.IDPTypeGitLabSelfHosted:
+		return l.gitlabSelfHostedProvider(ctx, identityProvider)
+	case domain.IDPTypeGoogle:
+		return l.googleProvider(ctx, identityProvider)
+	case domain.IDPTypeLDAP:
+		return l.ldapProvider(ctx, identityProvider)
+	case domain.IDPTypeUnspecified:
+		fallthrough
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "LOGIN-AShek", "Errors.ExternalIDP.IDPTypeNotImplemented")
+	}
+}
+
+// idpSession creates the session of the identity provider for the code returned on the callback
+func (l *Login) idpSession(ctx context.Context, identityProvider *query.IDPTemplate, code string) (idp.Session, error) {
+	switch identityProvider.Type {
+	case domain.IDPTypeOAuth:
+		provider, err := l.oauthProvider(ctx, identityProvider)
 		if err != nil {
-			l.externalAuthFailed(w, r, authReq, nil, nil, err)
-			return
+			return nil, err
 		}
-		session = &oauth.Session{Provider: provider.(*oauth.Provider), Code: data.Code}
+		return &oauth.Session{Provider: provider, Code: code}, nil
 	case domain.IDPTypeOIDC:
-		provider, err = l.oidcProvider(r.Context(), identityProvider)
+		provider, err := l.oidcProvider(ctx, identityProvider)
 		if err != nil {
-			l.externalAuthFailed(w, r, authReq, nil, nil, err)
-			return
+			return nil, err
 		}
-		session = &openid.Session{Provider: provider.(*openid.Provider), Code: data.Code}
+		return &openid.Session{Provider: provider, Code: code}, nil
 	case domain.IDPTypeAzureAD:
-		provider, err = l.azureProvider(r.Context(), identityProvider)
+		provider, err := l.azureProvider(ctx, identityProvider)
 		if err != nil {
-			l.externalAuthFailed(w, r, authReq, nil, nil, err)
-			return
+			return nil, err
 		}
-		session = &oauth.Session{Provider: provider.(*azuread.Provider).Provider, Code: data.Code}
+		return &oauth.Session{Provider: provider.Provider, Code: code}, nil
 	case domain.IDPTypeGitHub:
-		provider, err = l.githubProvider(r.Context(), identityProvider)
+		provider, err := l.githubProvider(ctx, identityProvider)
 		if err != nil {
-			l.externalAuthFailed(w, r, authReq, nil, nil, err)
-			return
+			return nil, err
 		}
-		session = &oauth.Session{Provider: provider.(*github.Provider).Provider, Code: data.Code}
+		return &oauth.Session{Provider: provider.Provider, Code: code}, nil
 	case domain.IDPTypeGitHubEnterprise:
-		provider, err = l.githubEnterpriseProvider(r.Context(), identityProvider)
+		provider, err := l.githubEnterpriseProvider(ctx, identityProvider)
 		if err != nil {
-			l.externalAuthFailed(w, r, authReq, nil, nil, err)
-			return
+			return nil, err
 		}
-		session = &oauth.Session{Provider: provider.(*github.Provider).Provider, Code: data.Code}
+		return &oauth.Session{Provider: provider.Provider, Code: code}, nil
 	case domain.IDPTypeGitLab:
-		provider, err = l.gitlabProvider(r.Context(), identityProvider)
+		provider, err := l.gitlabProvider(ctx, identityProvider)
 		if err != nil {
-			l.externalAuthFailed(w, r, authReq, nil, nil, err)
-			return
+			return nil, err
 		}
-		session = &openid.Session{Provider: provider.(*gitlab.Provider).Provider, Code: data.Code}
+		return &openid.Session{Provider: provider.Provider, Code: code}, nil
 	case domain.IDPTypeGitLabSelfHosted:
-		provider, err = l.gitlabSelfHostedProvider(r.Context(), identityProvider)
+		provider, err := l.gitlabSelfHostedProvider(ctx, identityProvider)
 		if err != nil {
-			l.externalAuthFailed(w, r, authReq, nil, nil, err)
-			return
+			return nil, err
 		}
-		session = &openid.Session{Provider: provider.(*gitlab.Provider).Provider, Code: data.Code}
+		return &openid.Session{Provider: provider.Provider, Code: code}, nil
 	case domain.IDPTypeGoogle:
-		provider, err = l.googleProvider(r.Context(), identityProvider)
+		provider, err := l.googleProvider(ctx, identityProvider)
 		if err != nil {
-			l.externalAuthFailed(w, r, authReq, nil, nil, err)
-			return
+			return nil, err
 		}
-		session = &openid.Session{Provider: provider.(*google.Provider).Provider, Code: data.Code}
+		return &openid.Session{Provider: provider.Provider, Code: code}, nil
 	case domain.IDPTypeJWT,
 		domain.IDPTypeLDAP,
 		domain.IDPTypeUnspecified:
 		fallthrough
 	default:
-		l.renderLogin(w, r, authReq, errors.ThrowInvalidArgument(nil, "LOGIN-SFefg", "Errors.ExternalIDP.IDPTypeNotImplemented"))
-		return
-	}
-
-	user, err := session.FetchUser(r.Context())
-	if err != nil {
-		l.externalAuthFailed(w, r, authReq, tokens(session), user, err)
-		return
+		return nil, errors.ThrowInvalidArgument(nil, "LOGIN-SFefg", "Errors.ExternalIDP.IDPTypeNotImplemented")
 	}
-	l.handleExternalUserAuthenticated(w, r, authReq, identityProvider, session, user, l.renderNextStep)
 }
 
 // handleExternalUserAuthenticated maps the IDP user, checks for a corresponding externalID
@@ -0,0 +1,167 @@
+package login
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	// intentStatePrefix marks the state of an idp callback as an intent started through the API
+	// (instead of an auth request of the login UI)
+	intentStatePrefix = "intent_"
+
+	queryIntentID    = "id"
+	queryIntentToken = "token"
+	queryIntentUser  = "user"
+	queryIntentError = "error"
+)
+
+type idpIntentData struct {
+	ID string `schema:"id"`
+}
+
+// handleIDPIntentStart is called by the redirect of the user after creating an intent through the API,
+// it will redirect to the auth page of the IDP
+func (l *Login) handleIDPIntentStart(w http.ResponseWriter, r *http.Request) {
+	data := new(idpIntentData)
+	err := l.getParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	intent, err := l.startedIntent(r.Context(), data.ID)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	identityProvider, err := l.getIDPByID(r, intent.IDPID)
+	if err != nil {
+		l.failIDPIntent(w, r, intent, err)
+		return
+	}
+	if identityProvider.Type == domain.IDPTypeLDAP || identityProvider.Type == domain.IDPTypeJWT {
+		l.failIDPIntent(w, r, intent, errors.ThrowInvalidArgument(nil, "LOGIN-Sfgw2", "Errors.ExternalIDP.IDPTypeNotImplemented"))
+		return
+	}
+	provider, err := l.idpProvider(r.Context(), identityProvider)
+	if err != nil {
+		l.failIDPIntent(w, r, intent, err)
+		return
+	}
+	session, err := provider.BeginAuth(r.Context(), intentStatePrefix+intent.AggregateID)
+	if err != nil {
+		l.failIDPIntent(w, r, intent, err)
+		return
+	}
+	http.Redirect(w, r, session.GetAuthURL(), http.StatusFound)
+}
+
+// handleIDPIntentCallback handles the callback from the IDP of an intent,
+// stores the information of the IDP user and redirects to the success url of the intent
+func (l *Login) handleIDPIntentCallback(w http.ResponseWriter, r *http.Request, intentID, code string) {
+	intent, err := l.startedIntent(r.Context(), intentID)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	identityProvider, err := l.getIDPByID(r, intent.IDPID)
+	if err != nil {
+		l.failIDPIntent(w, r, intent, err)
+		return
+	}
+	session, err := l.idpSession(r.Context(), identityProvider, code)
+	if err != nil {
+		l.failIDPIntent(w, r, intent, err)
+		return
+	}
+	idpUser, err := session.FetchUser(r.Context())
+	if err != nil {
+		l.failIDPIntent(w, r, intent, err)
+		return
+	}
+	userID, err := l.linkedUserID(r.Context(), identityProvider.ID, idpUser.GetID())
+	if err != nil {
+		l.failIDPIntent(w, r, intent, err)
+		return
+	}
+	token, err := l.command.SucceedIDPIntent(r.Context(), intent, idpUser, userID)
+	if err != nil {
+		l.failIDPIntent(w, r, intent, err)
+		return
+	}
+	redirectURL := *intent.SuccessURL
+	params := redirectURL.Query()
+	params.Set(queryIntentID, intent.AggregateID)
+	params.Set(queryIntentToken, token)
+	if userID != "" {
+		params.Set(queryIntentUser, userID)
+	}
+	redirectURL.RawQuery = params.Encode()
+	http.Redirect(w, r, redirectURL.String(), http.StatusFound)
+}
+
+func (l *Login) startedIntent(ctx context.Context, intentID string) (*command.IDPIntentWriteModel, error) {
+	intent, err := l.command.GetIntentWriteModel(ctx, intentID, "")
+	if err != nil {
+		return nil, err
+	}
+	if intent.State != domain.IDPIntentStateStarted {
+		return nil, errors.ThrowPreconditionFailed(nil, "LOGIN-Sfgw3", "Errors.Intent.NotStarted")
+	}
+	return intent, nil
+}
+
+// failIDPIntent marks the intent as failed and redirects to the failure url of the intent
+func (l *Login) failIDPIntent(w http.ResponseWriter, r *http.Request, intent *command.IDPIntentWriteModel, err error) {
+	_, reason := l.getErrorMessage(r, err)
+	if failErr := l.command.FailIDPIntent(r.Context(), intent, reason); failErr != nil {
+		logging.WithFields("intentID", intent.AggregateID).WithError(failErr).Error("unable to fail idp intent")
+	}
+	redirectURL := *intent.FailureURL
+	params := redirectURL.Query()
+	params.Set(queryIntentID, intent.AggregateID)
+	params.Set(queryIntentError, reason)
+	redirectURL.RawQuery = params.Encode()
+	http.Redirect(w, r, redirectURL.String(), http.StatusFound)
+}
+
+// linkedUserID returns the id of the user linked to the IDP user
+// or an empty string if no (single) user is linked
+func (l *Login) linkedUserID(ctx context.Context, idpID, externalUserID string) (string, error) {
+	idpIDQuery, err := query.NewIDPUserLinkIDPIDSearchQuery(idpID)
+	if err != nil {
+		return "", err
+	}
+	externalIDQuery, err := query.NewIDPUserLinksExternalIDSearchQuery(externalUserID)
+	if err != nil {
+		return "", err
+	}
+	links, err := l.query.IDPUserLinks(ctx,
+		&query.IDPUserLinksSearchQuery{
+			Queries: []query.SearchQuery{
+				idpIDQuery,
+				externalIDQuery,
+			},
+		}, false,
+	)
+	if err != nil {
+		return "", err
+	}
+	if len(links.Links) != 1 {
+		return "", nil
+	}
+	return links.Links[0].UserID, nil
+}
+
+// IDPIntentStartURL returns the url of the login, which starts the authentication of the intent on the IDP
+func IDPIntentStartURL(origin, intentID string) string {
+	return origin + HandlerPrefix + EndpointIDPIntentStart + "?" + url.Values{queryIntentID: []string{intentID}}.Encode()
+}
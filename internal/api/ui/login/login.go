@@ -40,6 +40,7 @@ type Login struct {
 	samlAuthCallbackURL func(context.Context, string) string
 	idpConfigAlg        crypto.EncryptionAlgorithm
 	userCodeAlg         crypto.EncryptionAlgorithm
+	botProtection       *botProtection
 }
 
 type Config struct {
@@ -47,6 +48,7 @@ type Config struct {
 	CSRFCookieName     string
 	Cache              middleware.CacheConfig
 	AssetCache         middleware.CacheConfig
+	BotProtection      BotProtectionConfig
 }
 
 const (
@@ -85,6 +87,7 @@ func CreateLogin(config Config,
 		authRepo:            authRepo,
 		idpConfigAlg:        idpConfigAlg,
 		userCodeAlg:         userCodeAlg,
+		botProtection:       newBotProtection(config.BotProtection, csrfCookieKey),
 	}
 	statikFS, err := fs.NewWithNamespace("login")
 	if err != nil {
@@ -93,7 +96,7 @@ func CreateLogin(config Config,
 
 	csrfInterceptor := createCSRFInterceptor(config.CSRFCookieName, csrfCookieKey, externalSecure, login.csrfErrorHandler())
 	cacheInterceptor := createCacheInterceptor(config.Cache.MaxAge, config.Cache.SharedMaxAge, assetCache)
-	security := middleware.SecurityHeaders(csp(), login.cspErrorHandler)
+	security := middleware.SecurityHeaders(csp(config.BotProtection.External), login.cspErrorHandler)
 
 	login.router = CreateRouter(login, statikFS, middleware.TelemetryHandler(IgnoreInstanceEndpoints...), oidcInstanceHandler, samlInstanceHandler, csrfInterceptor, cacheInterceptor, security, userAgentCookie, issuerInterceptor, accessHandler)
 	login.renderer = CreateRenderer(HandlerPrefix, statikFS, staticStorage, config.LanguageCookieName)
@@ -101,11 +104,19 @@ func CreateLogin(config Config,
 	return login, nil
 }
 
-func csp() *middleware.CSP {
+func csp(externalBotProtection ExternalBotProtectionConfig) *middleware.CSP {
 	csp := middleware.DefaultSCP
 	csp.ObjectSrc = middleware.CSPSourceOptsSelf()
 	csp.StyleSrc = csp.StyleSrc.AddNonce()
 	csp.ScriptSrc = csp.ScriptSrc.AddNonce()
+	// image captchas are rendered as data url
+	csp.ImgSrc = csp.ImgSrc.AddScheme("data")
+	if host := externalBotProtection.cspHost(); host != "" {
+		csp.ScriptSrc = csp.ScriptSrc.AddHost(host)
+		csp.StyleSrc = csp.StyleSrc.AddHost(host)
+		csp.FrameSrc = middleware.CSPSourceOpts().AddHost(host)
+		csp.ConnectSrc = csp.ConnectSrc.AddHost(host)
+	}
 	return &csp
 }
 
@@ -87,6 +87,9 @@ func (l *Login) handleLoginNameCheck(w http.ResponseWriter, r *http.Request) {
 		l.renderLogin(w, r, authReq, err)
 		return
 	}
+	if l.requestPhoneOTPLogin(w, r, authReq, loginName) {
+		return
+	}
 	l.renderNextStep(w, r, authReq)
 }
 
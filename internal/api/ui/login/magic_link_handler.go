@@ -0,0 +1,74 @@
+package login
+
+import (
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	tmplMagicLinkSent = "magiclinksent"
+)
+
+type magicLinkData struct {
+	UserID string `schema:"userID"`
+	LinkID string `schema:"linkID"`
+	Code   string `schema:"code"`
+}
+
+// handleMagicLinkRequest requests a magic link for the user of the auth request
+// and informs the user to check the email inbox
+func (l *Login) handleMagicLinkRequest(w http.ResponseWriter, r *http.Request) {
+	authReq, err := l.getAuthRequest(r)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq == nil {
+		l.renderError(w, r, nil, caos_errs.ThrowInvalidArgument(nil, "LOGIN-Jm2sa", "Errors.AuthRequest.NotFound"))
+		return
+	}
+	err = l.authRepo.RequestMagicLink(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, authReq.ID, authReq.AgentID, domain.BrowserInfoFromRequest(r))
+	if err != nil {
+		l.renderPassword(w, r, authReq, err)
+		return
+	}
+	l.renderMagicLinkSent(w, r, authReq, nil)
+}
+
+// handleMagicLink consumes the magic link sent by email,
+// the link is only valid in the browser (user agent) it was requested from
+func (l *Login) handleMagicLink(w http.ResponseWriter, r *http.Request) {
+	data := new(magicLinkData)
+	authReq, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq == nil {
+		l.renderError(w, r, nil, caos_errs.ThrowInvalidArgument(nil, "LOGIN-9fKsd", "Errors.AuthRequest.NotFound"))
+		return
+	}
+	if authReq.UserID != data.UserID {
+		l.renderError(w, r, authReq, caos_errs.ThrowPreconditionFailed(nil, "LOGIN-Ws1pq", "Errors.User.NotMatchingUserID"))
+		return
+	}
+	err = l.authRepo.VerifyMagicLink(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, authReq.ID, authReq.AgentID, data.LinkID, data.Code, domain.BrowserInfoFromRequest(r))
+
+	err = l.finishCodeChallengeCheck(r, authReq, authMethodMagicLink, err)
+	if err != nil {
+		l.renderMagicLinkSent(w, r, authReq, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
+
+func (l *Login) renderMagicLinkSent(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := l.getUserData(r, authReq, "MagicLinkSent.Title", "MagicLinkSent.Description", errID, errMessage)
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplMagicLinkSent], data, nil)
+}
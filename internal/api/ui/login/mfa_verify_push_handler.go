@@ -0,0 +1,91 @@
+package login
+
+import (
+	"net/http"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	tmplMFAPush = "mfapush"
+)
+
+type mfaPushData struct {
+	userData
+	ChallengeID  string
+	MFAProviders []domain.MFAType
+}
+
+type mfaPushFormData struct {
+	ChallengeID string `schema:"challengeID"`
+	Resend      bool   `schema:"resend"`
+}
+
+// requestMFAPush sends a push notification to the devices of the user
+// and renders the page, which waits for the user to approve the sign-in
+func (l *Login) requestMFAPush(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, providers []domain.MFAType) {
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	challengeID, err := l.authRepo.RequestMFAPush(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, authReq.ID, userAgentID, domain.BrowserInfoFromRequest(r))
+	l.renderMFAPush(w, r, authReq, providers, challengeID, err)
+}
+
+// handleMFAPush is polled by the waiting page and continues the login as soon as the user approved the sign-in
+func (l *Login) handleMFAPush(w http.ResponseWriter, r *http.Request) {
+	data := new(mfaPushFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	step, ok := authReq.PossibleSteps[0].(*domain.MFAVerificationStep)
+	if !ok {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	providers := removeSelectedProviderFromList(step.MFAProviders, domain.MFATypePush)
+	if data.Resend || data.ChallengeID == "" {
+		l.requestMFAPush(w, r, authReq, providers)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	state, err := l.authRepo.CheckMFAPush(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, authReq.ID, userAgentID, data.ChallengeID)
+	if err != nil {
+		l.renderMFAPush(w, r, authReq, providers, "", err)
+		return
+	}
+	switch state {
+	case domain.PushChallengeStateRequested:
+		l.renderMFAPush(w, r, authReq, providers, data.ChallengeID, nil)
+		return
+	case domain.PushChallengeStateDenied:
+		err = caos_errs.ThrowPreconditionFailed(nil, "LOGIN-Pu6dn", "Errors.User.MFA.Push.Denied")
+	}
+
+	metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, authMethodPush, err)
+	if err == nil && actionErr == nil && len(metadata) > 0 {
+		_, err = l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
+	} else if actionErr != nil && err == nil {
+		err = actionErr
+	}
+
+	if err != nil {
+		l.renderMFAPush(w, r, authReq, providers, "", err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
+
+func (l *Login) renderMFAPush(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, providers []domain.MFAType, challengeID string, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := &mfaPushData{
+		userData:     l.getUserData(r, authReq, "VerifyMFAPush.Title", "VerifyMFAPush.Description", errID, errMessage),
+		ChallengeID:  challengeID,
+		MFAProviders: providers,
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplMFAPush], data, nil)
+}
@@ -3,21 +3,53 @@ package login
 import (
 	"net/http"
 
+	"github.com/zitadel/logging"
+
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
 )
 
 const (
+	tmplPasswordReset     = "passwordreset"
 	tmplPasswordResetDone = "passwordresetdone"
 )
 
+type passwordResetData struct {
+	userData
+	BotProtection *botProtectionData
+}
+
 func (l *Login) handlePasswordReset(w http.ResponseWriter, r *http.Request) {
 	authReq, err := l.getAuthRequest(r)
 	if err != nil {
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	if authReq.LoginPolicy != nil && authReq.LoginPolicy.BotProtection != domain.BotProtectionTypeNone {
+		l.renderPasswordReset(w, r, authReq, nil)
+		return
+	}
+	l.resetPassword(w, r, authReq)
+}
+
+// handlePasswordResetCheck verifies the bot protection challenge before the reset is requested
+func (l *Login) handlePasswordResetCheck(w http.ResponseWriter, r *http.Request) {
+	authReq, err := l.getAuthRequest(r)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq.LoginPolicy != nil {
+		if err = l.botProtection.verify(r, authReq.LoginPolicy.BotProtection); err != nil {
+			l.renderPasswordReset(w, r, authReq, err)
+			return
+		}
+	}
+	l.resetPassword(w, r, authReq)
+}
+
+func (l *Login) resetPassword(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest) {
 	loginName, err := query.NewUserLoginNamesSearchQuery(authReq.LoginName)
 	if err != nil {
 		l.renderInitPassword(w, r, authReq, authReq.UserID, "", err)
@@ -40,6 +72,19 @@ func (l *Login) handlePasswordReset(w http.ResponseWriter, r *http.Request) {
 	l.renderPasswordResetDone(w, r, authReq, err)
 }
 
+func (l *Login) renderPasswordReset(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := &passwordResetData{
+		userData: l.getUserData(r, authReq, "PasswordReset.Title", "PasswordReset.Description", errID, errMessage),
+	}
+	data.BotProtection, err = l.botProtection.challenge(r, authReq.LoginPolicy.BotProtection)
+	logging.OnError(err).Warn("unable to create bot protection challenge")
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplPasswordReset], data, nil)
+}
+
 func (l *Login) renderPasswordResetDone(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
 	var errID, errMessage string
 	if err != nil {
@@ -0,0 +1,86 @@
+package login
+
+import (
+	"net/http"
+	"strings"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	tmplPhoneOTP = "phoneotp"
+)
+
+type phoneOTPData struct {
+	Phone  string `schema:"phone"`
+	Code   string `schema:"code"`
+	Resend bool   `schema:"resend"`
+}
+
+type phoneOTPTemplateData struct {
+	userData
+	Phone string
+}
+
+// requestPhoneOTPLogin sends a sign-in code by SMS if the login name was entered as phone number
+// and the login policy allows it. If no code could be requested, the login continues with the next step (e.g. password).
+func (l *Login) requestPhoneOTPLogin(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, loginName string) bool {
+	if authReq.LoginPolicy == nil || !authReq.LoginPolicy.AllowPhoneOTP || strings.Contains(loginName, "@") {
+		return false
+	}
+	phone, err := domain.PhoneNumber(loginName).Normalize()
+	if err != nil {
+		return false
+	}
+	authReq, err = l.authRepo.AuthRequestByID(r.Context(), authReq.ID, authReq.AgentID)
+	if err != nil || authReq.UserID == "" {
+		return false
+	}
+	err = l.authRepo.RequestPhoneOTP(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, authReq.ID, authReq.AgentID, string(phone), domain.BrowserInfoFromRequest(r))
+	if err != nil {
+		return false
+	}
+	l.renderPhoneOTP(w, r, authReq, string(phone), nil)
+	return true
+}
+
+func (l *Login) handlePhoneOTP(w http.ResponseWriter, r *http.Request) {
+	data := new(phoneOTPData)
+	authReq, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq == nil {
+		l.renderError(w, r, nil, caos_errs.ThrowInvalidArgument(nil, "LOGIN-Nf4mw", "Errors.AuthRequest.NotFound"))
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	if data.Resend {
+		err = l.authRepo.RequestPhoneOTP(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, authReq.ID, userAgentID, data.Phone, domain.BrowserInfoFromRequest(r))
+		l.renderPhoneOTP(w, r, authReq, data.Phone, err)
+		return
+	}
+	err = l.authRepo.VerifyPhoneOTP(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, authReq.ID, userAgentID, data.Code, domain.BrowserInfoFromRequest(r))
+
+	err = l.finishCodeChallengeCheck(r, authReq, authMethodPhoneOTP, err)
+	if err != nil {
+		l.renderPhoneOTP(w, r, authReq, data.Phone, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
+
+func (l *Login) renderPhoneOTP(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, phone string, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := &phoneOTPTemplateData{
+		userData: l.getUserData(r, authReq, "PhoneOTP.Title", "PhoneOTP.Description", errID, errMessage),
+		Phone:    phone,
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplPhoneOTP], data, nil)
+}
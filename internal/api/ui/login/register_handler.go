@@ -37,6 +37,7 @@ type registerData struct {
 	ShowUsername       bool
 	ShowUsernameSuffix bool
 	OrgRegister        bool
+	BotProtection      *botProtectionData
 }
 
 func (l *Login) handleRegister(w http.ResponseWriter, r *http.Request) {
@@ -67,6 +68,10 @@ func (l *Login) handleRegisterCheck(w http.ResponseWriter, r *http.Request) {
 	if authRequest != nil && authRequest.RequestedOrgID != "" && authRequest.RequestedOrgID != resourceOwner {
 		resourceOwner = authRequest.RequestedOrgID
 	}
+	if err = l.checkBotProtection(r, resourceOwner); err != nil {
+		l.renderRegister(w, r, authRequest, data, err)
+		return
+	}
 	initCodeGenerator, err := l.query.InitEncryptionGenerator(r.Context(), domain.SecretGeneratorTypeInitCode, l.userCodeAlg)
 	if err != nil {
 		l.renderRegister(w, r, authRequest, data, err)
@@ -195,6 +200,7 @@ func (l *Login) renderRegister(w http.ResponseWriter, r *http.Request, authReque
 		return
 	}
 	data.ShowUsernameSuffix = !labelPolicy.HideLoginNameSuffix
+	data.BotProtection = l.botProtectionChallenge(r, resourceOwner)
 
 	funcs := map[string]interface{}{
 		"selectedLanguage": func(l string) bool {
@@ -35,6 +35,7 @@ type registerOrgData struct {
 	HasSymbol                 string
 	UserLoginMustBeDomain     bool
 	IamDomain                 string
+	BotProtection             *botProtectionData
 }
 
 func (l *Login) handleRegisterOrg(w http.ResponseWriter, r *http.Request) {
@@ -60,6 +61,11 @@ func (l *Login) handleRegisterOrgCheck(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err = l.checkBotProtection(r, ""); err != nil {
+		l.renderRegisterOrg(w, r, authRequest, data, err)
+		return
+	}
+
 	ctx := setContext(r.Context(), "")
 	userIDs, err := l.getClaimedUserIDsOfOrgDomain(ctx, data.RegisterOrgName)
 	if err != nil {
@@ -112,6 +118,7 @@ func (l *Login) renderRegisterOrg(w http.ResponseWriter, r *http.Request, authRe
 		data.UserLoginMustBeDomain = orgPolicy.UserLoginMustBeDomain
 		data.IamDomain = authz.GetInstance(r.Context()).RequestedDomain()
 	}
+	data.BotProtection = l.botProtectionChallenge(r, "")
 
 	if authRequest == nil {
 		l.customTexts(r.Context(), translator, "")
@@ -59,6 +59,7 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplMFAInitVerify:                "mfa_init_otp.html",
 		tmplMFAU2FInit:                   "mfa_init_u2f.html",
 		tmplU2FVerification:              "mfa_verification_u2f.html",
+		tmplMFAPush:                      "mfa_verify_push.html",
 		tmplMFAInitDone:                  "mfa_init_done.html",
 		tmplMailVerification:             "mail_verification.html",
 		tmplMailVerified:                 "mail_verified.html",
@@ -66,7 +67,11 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplInitPasswordDone:             "init_password_done.html",
 		tmplInitUser:                     "init_user.html",
 		tmplInitUserDone:                 "init_user_done.html",
+		tmplPasswordReset:                "password_reset.html",
 		tmplPasswordResetDone:            "password_reset_done.html",
+		tmplMagicLinkSent:                "magic_link_sent.html",
+		tmplPhoneOTP:                     "phone_otp.html",
+		tmplConsent:                      "consent.html",
 		tmplChangePassword:               "change_password.html",
 		tmplChangePasswordDone:           "change_password_done.html",
 		tmplRegisterOption:               "register_option.html",
@@ -155,9 +160,21 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"passwordResetUrl": func(id string) string {
 			return path.Join(r.pathPrefix, fmt.Sprintf("%s?%s=%s", EndpointPasswordReset, QueryAuthRequestID, id))
 		},
+		"passwordResetCheckUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointPasswordReset)
+		},
 		"passwordUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointPassword)
 		},
+		"magicLinkUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointMagicLink)
+		},
+		"phoneOTPUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointPhoneOTP)
+		},
+		"consentUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointConsent)
+		},
 		"mfaVerifyUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointMFAVerify)
 		},
@@ -176,6 +193,9 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"mfaInitU2FLoginUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointU2FVerification)
 		},
+		"mfaPushUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointMFAPush)
+		},
 		"mailVerificationUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointMailVerification)
 		},
@@ -297,6 +317,8 @@ func (l *Login) chooseNextStep(w http.ResponseWriter, r *http.Request, authReq *
 			return
 		}
 		l.redirectToCallback(w, r, authReq)
+	case *domain.ConsentStep:
+		l.renderConsent(w, r, authReq, step, err)
 	case *domain.LoginSucceededStep:
 		l.redirectToLoginSuccess(w, r, authReq.ID)
 	case *domain.ChangePasswordStep:
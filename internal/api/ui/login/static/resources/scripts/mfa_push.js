@@ -0,0 +1,12 @@
+const mfaPushPollInterval = 3000;
+
+document.addEventListener('DOMContentLoaded', function () {
+    setTimeout(checkMFAPush, mfaPushPollInterval);
+});
+
+function checkMFAPush() {
+    let form = document.getElementById('mfa-push-form');
+    if (form) {
+        form.submit();
+    }
+}
@@ -19,17 +19,26 @@ type AuthRequestRepository interface {
 	SetExternalUserLogin(ctx context.Context, authReqID, userAgentID string, user *domain.ExternalUser) error
 	SelectUser(ctx context.Context, id, userID, userAgentID string) error
 	SelectExternalIDP(ctx context.Context, authReqID, idpConfigID, userAgentID string) error
+	LinkSessionToAuthRequest(ctx context.Context, authReqID, sessionID, sessionToken string) error
 	VerifyPassword(ctx context.Context, id, userID, resourceOwner, password, userAgentID string, info *domain.BrowserInfo) error
 
 	VerifyMFAOTP(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) error
 	BeginMFAU2FLogin(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (*domain.WebAuthNLogin, error)
 	VerifyMFAU2F(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string, credentialData []byte, info *domain.BrowserInfo) error
+	RequestMFAPush(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string, info *domain.BrowserInfo) (string, error)
+	CheckMFAPush(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID, challengeID string) (domain.PushChallengeState, error)
 	BeginPasswordlessSetup(ctx context.Context, userID, resourceOwner string, preferredPlatformType domain.AuthenticatorAttachment) (login *domain.WebAuthNToken, err error)
 	VerifyPasswordlessSetup(ctx context.Context, userID, resourceOwner, userAgentID, tokenName string, credentialData []byte) (err error)
 	BeginPasswordlessInitCodeSetup(ctx context.Context, userID, resourceOwner, codeID, verificationCode string, preferredPlatformType domain.AuthenticatorAttachment) (login *domain.WebAuthNToken, err error)
 	VerifyPasswordlessInitCodeSetup(ctx context.Context, userID, resourceOwner, userAgentID, tokenName, codeID, verificationCode string, credentialData []byte) (err error)
 	BeginPasswordlessLogin(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (*domain.WebAuthNLogin, error)
 	VerifyPasswordless(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string, credentialData []byte, info *domain.BrowserInfo) error
+	RequestMagicLink(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string, info *domain.BrowserInfo) error
+	VerifyMagicLink(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID, linkID, code string, info *domain.BrowserInfo) error
+	RequestPhoneOTP(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID, phone string, info *domain.BrowserInfo) error
+	VerifyPhoneOTP(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID, code string, info *domain.BrowserInfo) error
+	GrantConsent(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string, info *domain.BrowserInfo) error
+	DenyConsent(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string, info *domain.BrowserInfo) error
 
 	LinkExternalUsers(ctx context.Context, authReqID, userAgentID string, info *domain.BrowserInfo) error
 	AutoRegisterExternalUser(ctx context.Context, user *domain.Human, externalIDP *domain.UserIDPLink, orgMemberRoles []string, authReqID, userAgentID, resourceOwner string, metadatas []*domain.Metadata, info *domain.BrowserInfo) error
@@ -49,6 +49,8 @@ type AuthRequestRepo struct {
 	UserGrantProvider         userGrantProvider
 	ProjectProvider           projectProvider
 	ApplicationProvider       applicationProvider
+	UserConsentProvider       userConsentProvider
+	SessionProvider           sessionProvider
 
 	IdGenerator id.Generator
 }
@@ -91,11 +93,13 @@ type userEventProvider interface {
 
 type userCommandProvider interface {
 	BulkAddedUserIDPLinks(ctx context.Context, userID, resourceOwner string, externalIDPs []*domain.UserIDPLink) error
+	UnlockExpiredLockout(ctx context.Context, userID, resourceOwner string) (bool, error)
 }
 
 type orgViewProvider interface {
 	OrgByID(context.Context, bool, string) (*query.Org, error)
 	OrgByPrimaryDomain(context.Context, string) (*query.Org, error)
+	OrgByLoginDomain(context.Context, string) (*query.Org, error)
 }
 
 type userGrantProvider interface {
@@ -112,6 +116,14 @@ type applicationProvider interface {
 	AppByOIDCClientID(context.Context, string, bool) (*query.App, error)
 }
 
+type sessionProvider interface {
+	SessionByID(ctx context.Context, shouldTriggerBulk bool, id, sessionToken string) (*query.Session, error)
+}
+
+type userConsentProvider interface {
+	UserConsentByClientID(ctx context.Context, shouldTriggerBulk bool, userID, clientID string) (*query.UserConsent, error)
+}
+
 func (repo *AuthRequestRepo) Health(ctx context.Context) error {
 	return repo.AuthRequests.Health(ctx)
 }
@@ -235,6 +247,30 @@ func (repo *AuthRequestRepo) SelectExternalIDP(ctx context.Context, authReqID, i
 	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
 }
 
+// LinkSessionToAuthRequest links a session (created through the session API) to the auth request,
+// the checked factors of the session will be used to determine the next steps of the auth request
+func (repo *AuthRequestRepo) LinkSessionToAuthRequest(ctx context.Context, authReqID, sessionID, sessionToken string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.AuthRequests.GetAuthRequestByID(ctx, authReqID)
+	if err != nil {
+		return err
+	}
+	session, err := repo.SessionProvider.SessionByID(ctx, false, sessionID, sessionToken)
+	if err != nil {
+		return err
+	}
+	if session.UserFactor.UserID == "" {
+		return errors.ThrowPreconditionFailed(nil, "EVENT-Sfwg2", "Errors.Session.User.Missing")
+	}
+	if request.UserID != "" && request.UserID != session.UserFactor.UserID {
+		return errors.ThrowPreconditionFailed(nil, "EVENT-Sfwg3", "Errors.User.NotMatchingUserID")
+	}
+	request.SetUserInfo(session.UserFactor.UserID, "", session.UserFactor.LoginName, session.UserFactor.DisplayName, "", session.UserFactor.ResourceOwner)
+	request.SessionID = session.ID
+	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
+}
+
 func (repo *AuthRequestRepo) CheckExternalUserLogin(ctx context.Context, authReqID, userAgentID string, externalUser *domain.ExternalUser, info *domain.BrowserInfo) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -287,7 +323,7 @@ func (repo *AuthRequestRepo) SelectUser(ctx context.Context, id, userID, userAge
 	if err != nil {
 		return err
 	}
-	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, userID, false)
+	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, repo.UserCommandProvider, userID, false)
 	if err != nil {
 		return err
 	}
@@ -342,7 +378,9 @@ func lockoutPolicyToDomain(policy *query.LockoutPolicy) *domain.LockoutPolicy {
 		},
 		Default:             policy.IsDefault,
 		MaxPasswordAttempts: policy.MaxPasswordAttempts,
+		MaxOTPAttempts:      policy.MaxOTPAttempts,
 		ShowLockOutFailures: policy.ShowFailures,
+		LockoutDuration:     policy.LockoutDuration,
 	}
 }
 
@@ -353,7 +391,11 @@ func (repo *AuthRequestRepo) VerifyMFAOTP(ctx context.Context, authRequestID, us
 	if err != nil {
 		return err
 	}
-	return repo.Command.HumanCheckMFAOTP(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckMFAOTP(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info), lockoutPolicyToDomain(policy))
 }
 
 func (repo *AuthRequestRepo) BeginMFAU2FLogin(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (login *domain.WebAuthNLogin, err error) {
@@ -374,7 +416,33 @@ func (repo *AuthRequestRepo) VerifyMFAU2F(ctx context.Context, userID, resourceO
 	if err != nil {
 		return err
 	}
-	return repo.Command.HumanFinishU2FLogin(ctx, userID, resourceOwner, credentialData, request)
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanFinishU2FLogin(ctx, userID, resourceOwner, credentialData, request, lockoutPolicyToDomain(policy))
+}
+
+// RequestMFAPush sends a push notification to the devices of the user to approve the sign-in
+// and returns the id of the challenge, which is used to poll for the answer of the user
+func (repo *AuthRequestRepo) RequestMFAPush(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string, info *domain.BrowserInfo) (_ string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return "", err
+	}
+	return repo.Command.RequestHumanPushChallenge(ctx, userID, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) CheckMFAPush(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID, challengeID string) (_ domain.PushChallengeState, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return domain.PushChallengeStateUnspecified, err
+	}
+	return repo.Command.HumanPushChallengeState(ctx, userID, resourceOwner, challengeID, request)
 }
 
 func (repo *AuthRequestRepo) BeginPasswordlessSetup(ctx context.Context, userID, resourceOwner string, authenticatorPlatform domain.AuthenticatorAttachment) (login *domain.WebAuthNToken, err error) {
@@ -428,7 +496,104 @@ func (repo *AuthRequestRepo) VerifyPasswordless(ctx context.Context, userID, res
 	if err != nil {
 		return err
 	}
-	return repo.Command.HumanFinishPasswordlessLogin(ctx, userID, resourceOwner, credentialData, request)
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanFinishPasswordlessLogin(ctx, userID, resourceOwner, credentialData, request, lockoutPolicyToDomain(policy))
+}
+
+func (repo *AuthRequestRepo) RequestMagicLink(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	_, err = repo.Command.RequestHumanMagicLink(ctx, userID, resourceOwner, request.WithCurrentInfo(info))
+	return err
+}
+
+func (repo *AuthRequestRepo) VerifyMagicLink(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID, linkID, code string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckMagicLink(ctx, userID, resourceOwner, linkID, code, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) RequestPhoneOTP(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID, phone string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	_, err = repo.Command.RequestHumanPhoneOTP(ctx, userID, resourceOwner, domain.PhoneNumber(phone), request.WithCurrentInfo(info))
+	return err
+}
+
+func (repo *AuthRequestRepo) VerifyPhoneOTP(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID, code string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckPhoneOTP(ctx, userID, resourceOwner, code, request.WithCurrentInfo(info), lockoutPolicyToDomain(policy))
+}
+
+// GrantConsent stores the consent of the user to all scopes requested by the client of the auth request
+func (repo *AuthRequestRepo) GrantConsent(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	oidcRequest, ok := request.Request.(*domain.AuthRequestOIDC)
+	if !ok {
+		return errors.ThrowPreconditionFailed(nil, "EVENT-Ln3wq", "Errors.AuthRequest.RequestTypeNotSupported")
+	}
+	app, err := repo.ApplicationProvider.AppByOIDCClientID(ctx, request.ApplicationID, false)
+	if err != nil {
+		return err
+	}
+	consent := &domain.UserConsent{
+		ClientID:  request.ApplicationID,
+		AppID:     app.ID,
+		ProjectID: app.ProjectID,
+		Scopes:    oidcRequest.Scopes,
+	}
+	_, err = repo.Command.GrantHumanConsent(ctx, userID, resourceOwner, consent, request.WithCurrentInfo(info))
+	return err
+}
+
+// DenyConsent records that the user denied the scopes requested by the client of the auth request
+// and marks the auth request as denied, so the client receives an access_denied error on the callback
+func (repo *AuthRequestRepo) DenyConsent(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	oidcRequest, ok := request.Request.(*domain.AuthRequestOIDC)
+	if !ok {
+		return errors.ThrowPreconditionFailed(nil, "EVENT-Ln4wq", "Errors.AuthRequest.RequestTypeNotSupported")
+	}
+	_, err = repo.Command.DenyHumanConsent(ctx, userID, resourceOwner, request.ApplicationID, oidcRequest.Scopes, request.WithCurrentInfo(info))
+	if err != nil {
+		return err
+	}
+	request.ConsentDenied = true
+	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
 }
 
 func (repo *AuthRequestRepo) LinkExternalUsers(ctx context.Context, authReqID, userAgentID string, info *domain.BrowserInfo) (err error) {
@@ -530,7 +695,7 @@ func (repo *AuthRequestRepo) getAuthRequestEnsureUser(ctx context.Context, authR
 	if request.UserID != userID {
 		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-GBH32", "Errors.User.NotMatchingUserID")
 	}
-	_, err = activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, request.UserID, false)
+	_, err = activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, repo.UserCommandProvider, request.UserID, false)
 	if err != nil {
 		return request, err
 	}
@@ -560,7 +725,7 @@ func (repo *AuthRequestRepo) getLoginPolicyAndIDPProviders(ctx context.Context,
 	if !policy.AllowExternalIDPs {
 		return policy, nil, nil
 	}
-	idpProviders, err := getLoginPolicyIDPProviders(ctx, repo.IDPProviderViewProvider, authz.GetInstance(ctx).InstanceID(), orgID, policy.IsDefault)
+	idpProviders, err := getLoginPolicyIDPProviders(ctx, repo.IDPProviderViewProvider, authz.GetInstance(ctx).InstanceID(), policy.OrgID, policy.IsDefault)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -662,6 +827,16 @@ func (repo *AuthRequestRepo) checkLoginName(ctx context.Context, request *domain
 	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
+	// a user locked by the lockout policy is unlocked again after the lockout duration
+	if user != nil && user.State == int32(domain.UserStateLocked) {
+		unlocked, err := repo.UserCommandProvider.UnlockExpiredLockout(ctx, user.ID, user.ResourceOwner)
+		if err != nil {
+			return err
+		}
+		if unlocked {
+			user.State = int32(domain.UserStateActive)
+		}
+	}
 	// if there's an active (human) user, let's use it
 	if user != nil && !user.HumanView.IsZero() && domain.UserState(user.State).NotDisabled() {
 		request.SetUserInfo(user.ID, loginName, user.PreferredLoginName, "", "", user.ResourceOwner)
@@ -840,6 +1015,9 @@ func queryLoginPolicyToDomain(policy *query.LoginPolicy) *domain.LoginPolicy {
 		MultiFactorCheckLifetime:   policy.MultiFactorCheckLifetime,
 		DisableLoginWithEmail:      policy.DisableLoginWithEmail,
 		DisableLoginWithPhone:      policy.DisableLoginWithPhone,
+		AllowMagicLink:             policy.AllowMagicLink,
+		AllowPhoneOTP:              policy.AllowPhoneOTP,
+		BotProtection:              policy.BotProtection,
 	}
 }
 
@@ -879,7 +1057,7 @@ func (repo *AuthRequestRepo) checkExternalUserLogin(ctx context.Context, request
 	if len(links.Links) != 1 {
 		return errors.ThrowNotFound(nil, "AUTH-Sf8sd", "Errors.ExternalIDP.NotFound")
 	}
-	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, links.Links[0].UserID, false)
+	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, repo.UserCommandProvider, links.Links[0].UserID, false)
 	if err != nil {
 		return err
 	}
@@ -925,7 +1103,7 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		}
 		return steps, nil
 	}
-	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, request.UserID, request.LoginPolicy.IgnoreUnknownUsernames)
+	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, repo.UserCommandProvider, request.UserID, request.LoginPolicy.IgnoreUnknownUsernames)
 	if err != nil {
 		return nil, err
 	}
@@ -937,7 +1115,15 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		return nil, err
 	}
 
-	isInternalLogin := request.SelectedIDPConfigID == "" && userSession.SelectedIDPConfigID == ""
+	var sessionIntentChecked bool
+	if request.SessionID != "" {
+		sessionIntentChecked, err = repo.applySessionChecks(ctx, request, userSession)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	isInternalLogin := request.SelectedIDPConfigID == "" && userSession.SelectedIDPConfigID == "" && !sessionIntentChecked
 	idps, err := checkExternalIDPsOfUser(ctx, repo.IDPUserLinksProvider, user.ID)
 	if err != nil {
 		return nil, err
@@ -1002,6 +1188,14 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		return append(steps, &domain.GrantRequiredStep{}), nil
 	}
 
+	consentStep, err := repo.consentRequired(ctx, request, repo.ApplicationProvider, repo.UserConsentProvider)
+	if err != nil {
+		return nil, err
+	}
+	if consentStep != nil {
+		return append(steps, consentStep), nil
+	}
+
 	ok, err = repo.hasSucceededPage(ctx, request, repo.ApplicationProvider)
 	if err != nil {
 		return nil, err
@@ -1012,6 +1206,38 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 	return append(steps, &domain.RedirectToCallbackStep{}), nil
 }
 
+// applySessionChecks applies the checked factors of the linked session to the user session
+// and returns if the user was authenticated on an identity provider (intent)
+func (repo *AuthRequestRepo) applySessionChecks(ctx context.Context, request *domain.AuthRequest, userSession *user_model.UserSessionView) (intentChecked bool, err error) {
+	session, err := repo.SessionProvider.SessionByID(ctx, false, request.SessionID, "")
+	if err != nil {
+		return false, err
+	}
+	if session.UserFactor.UserID != request.UserID {
+		return false, errors.ThrowPreconditionFailed(nil, "EVENT-Sfwg4", "Errors.User.NotMatchingUserID")
+	}
+	userSession.PasswordVerification = latestVerification(userSession.PasswordVerification, session.PasswordFactor.PasswordCheckedAt)
+	userSession.ExternalLoginVerification = latestVerification(userSession.ExternalLoginVerification, session.IntentFactor.IntentCheckedAt)
+	if session.WebAuthNFactor.UserVerified {
+		userSession.PasswordlessVerification = latestVerification(userSession.PasswordlessVerification, session.WebAuthNFactor.WebAuthNCheckedAt)
+		if session.WebAuthNFactor.WebAuthNCheckedAt.After(userSession.MultiFactorVerification) {
+			userSession.MultiFactorVerification = session.WebAuthNFactor.WebAuthNCheckedAt
+			userSession.MultiFactorVerificationType = domain.MFATypeU2FUserVerification
+		}
+	} else if session.WebAuthNFactor.WebAuthNCheckedAt.After(userSession.SecondFactorVerification) {
+		userSession.SecondFactorVerification = session.WebAuthNFactor.WebAuthNCheckedAt
+		userSession.SecondFactorVerificationType = domain.MFATypeU2F
+	}
+	return !session.IntentFactor.IntentCheckedAt.IsZero(), nil
+}
+
+func latestVerification(current, checked time.Time) time.Time {
+	if checked.After(current) {
+		return checked
+	}
+	return current
+}
+
 func checkExternalIDPsOfUser(ctx context.Context, idpUserLinksProvider idpUserLinksProvider, userID string) (*query.IDPUserLinks, error) {
 	userIDQuery, err := query.NewIDPUserLinksUserIDSearchQuery(userID)
 	if err != nil {
@@ -1064,6 +1290,11 @@ func (repo *AuthRequestRepo) firstFactorChecked(request *domain.AuthRequest, use
 	}
 
 	if user.PasswordInitRequired {
+		// users without password can still sign in with a magic link or a code sent by SMS
+		if (request.LoginPolicy.AllowMagicLink || request.LoginPolicy.AllowPhoneOTP) && checkVerificationTimeMaxAge(userSession.PasswordVerification, request.LoginPolicy.PasswordCheckLifetime, request) {
+			request.AuthTime = userSession.PasswordVerification
+			return nil
+		}
 		return &domain.InitPasswordStep{}
 	}
 
@@ -1226,6 +1457,35 @@ func (repo *AuthRequestRepo) hasSucceededPage(ctx context.Context, request *doma
 	return app.OIDCConfig.AppType == domain.OIDCApplicationTypeNative && !app.OIDCConfig.SkipNativeAppSuccessPage, nil
 }
 
+// consentRequired returns the consent step with the scopes the user has not yet consented to,
+// if the app requires the consent of the user
+func (repo *AuthRequestRepo) consentRequired(ctx context.Context, request *domain.AuthRequest, appProvider applicationProvider, consentProvider userConsentProvider) (*domain.ConsentStep, error) {
+	oidcRequest, ok := request.Request.(*domain.AuthRequestOIDC)
+	if !ok {
+		return nil, nil
+	}
+	app, err := appProvider.AppByOIDCClientID(ctx, request.ApplicationID, false)
+	if err != nil {
+		return nil, err
+	}
+	if app.OIDCConfig == nil || !app.OIDCConfig.RequireConsent {
+		return nil, nil
+	}
+	var granted []string
+	consent, err := consentProvider.UserConsentByClientID(ctx, true, request.UserID, request.ApplicationID)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if consent != nil {
+		granted = consent.Scopes
+	}
+	missing := domain.MissingConsentScopes(granted, oidcRequest.Scopes)
+	if len(missing) == 0 {
+		return nil, nil
+	}
+	return &domain.ConsentStep{Scopes: missing}, nil
+}
+
 func (repo *AuthRequestRepo) getDomainPolicy(ctx context.Context, orgID string) (*query.DomainPolicy, error) {
 	return repo.Query.DomainPolicyByOrg(ctx, false, orgID, false)
 }
@@ -1243,7 +1503,7 @@ func setOrgID(ctx context.Context, orgViewProvider orgViewProvider, request *dom
 
 	primaryDomain := request.GetScopeOrgPrimaryDomain()
 	if primaryDomain == "" {
-		return nil
+		return setOrgIDByLoginDomain(ctx, orgViewProvider, request)
 	}
 
 	org, err := orgViewProvider.OrgByPrimaryDomain(ctx, primaryDomain)
@@ -1254,6 +1514,23 @@ func setOrgID(ctx context.Context, orgViewProvider orgViewProvider, request *dom
 	return nil
 }
 
+// setOrgIDByLoginDomain requests the organization, if the login is called on one of its login domains
+func setOrgIDByLoginDomain(ctx context.Context, orgViewProvider orgViewProvider, request *domain.AuthRequest) error {
+	loginDomain := authz.GetInstance(ctx).RequestedDomain()
+	if loginDomain == "" {
+		return nil
+	}
+	org, err := orgViewProvider.OrgByLoginDomain(ctx, loginDomain)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	request.SetOrgInformation(org.ID, org.Name, org.Domain, false)
+	return nil
+}
+
 func getLoginPolicyIDPProviders(ctx context.Context, provider idpProviderViewProvider, iamID, orgID string, defaultPolicy bool) ([]*domain.IDPProvider, error) {
 	resourceOwner := iamID
 	if !defaultPolicy {
@@ -1329,7 +1606,13 @@ func userSessionByIDs(ctx context.Context, provider userSessionViewProvider, eve
 			user_repo.HumanPasswordlessTokenCheckSucceededType,
 			user_repo.HumanPasswordlessTokenCheckFailedType,
 			user_repo.HumanU2FTokenCheckSucceededType,
-			user_repo.HumanU2FTokenCheckFailedType:
+			user_repo.HumanU2FTokenCheckFailedType,
+			user_repo.HumanMagicLinkCheckSucceededType,
+			user_repo.HumanMagicLinkCheckFailedType,
+			user_repo.HumanPhoneOTPCheckSucceededType,
+			user_repo.HumanPhoneOTPCheckFailedType,
+			user_repo.HumanPushCheckSucceededType,
+			user_repo.HumanPushCheckFailedType:
 			eventData, err := user_view_model.UserSessionFromEvent(event)
 			if err != nil {
 				logging.WithFields("traceID", tracing.TraceIDFromCtx(ctx)).WithError(err).Debug("error getting event data")
@@ -1347,8 +1630,7 @@ func userSessionByIDs(ctx context.Context, provider userSessionViewProvider, eve
 	return user_view_model.UserSessionToModel(&sessionCopy), nil
 }
 
-func activeUserByID(ctx context.Context, userViewProvider userViewProvider, userEventProvider userEventProvider, queries orgViewProvider, lockoutPolicyProvider lockoutPolicyViewProvider, userID string, ignoreUnknownUsernames bool) (user *user_model.UserView, err error) {
-	// PLANNED: Check LockoutPolicy
+func activeUserByID(ctx context.Context, userViewProvider userViewProvider, userEventProvider userEventProvider, queries orgViewProvider, lockoutPolicyProvider lockoutPolicyViewProvider, userCommandProvider userCommandProvider, userID string, ignoreUnknownUsernames bool) (user *user_model.UserView, err error) {
 	user, err = userByID(ctx, userViewProvider, userEventProvider, userID)
 	if err != nil {
 		if ignoreUnknownUsernames && errors.IsNotFound(err) {
@@ -1363,7 +1645,17 @@ func activeUserByID(ctx context.Context, userViewProvider userViewProvider, user
 	if user.HumanView == nil {
 		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-Lm69x", "Errors.User.NotHuman")
 	}
-	if user.State == user_model.UserStateLocked || user.State == user_model.UserStateSuspend {
+	if user.State == user_model.UserStateLocked {
+		unlocked, err := userCommandProvider.UnlockExpiredLockout(ctx, user.ID, user.ResourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if !unlocked {
+			return nil, errors.ThrowPreconditionFailed(nil, "EVENT-FJ262", "Errors.User.Locked")
+		}
+		user.State = user_model.UserStateActive
+	}
+	if user.State == user_model.UserStateSuspend {
 		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-FJ262", "Errors.User.Locked")
 	}
 	if !(user.State == user_model.UserStateActive || user.State == user_model.UserStateInitial) {
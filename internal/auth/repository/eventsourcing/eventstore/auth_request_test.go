@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/auth/repository/eventsourcing/view"
 	"github.com/zitadel/zitadel/internal/auth_request/repository/cache"
 	"github.com/zitadel/zitadel/internal/crypto"
@@ -179,6 +180,12 @@ func (m *mockViewOrg) OrgByPrimaryDomain(context.Context, string) (*query.Org, e
 	}, nil
 }
 
+func (m *mockViewOrg) OrgByLoginDomain(context.Context, string) (*query.Org, error) {
+	return &query.Org{
+		State: m.State,
+	}, nil
+}
+
 type mockViewErrOrg struct{}
 
 func (m *mockViewErrOrg) OrgByID(context.Context, bool, string) (*query.Org, error) {
@@ -189,6 +196,35 @@ func (m *mockViewErrOrg) OrgByPrimaryDomain(context.Context, string) (*query.Org
 	return nil, errors.ThrowInternal(nil, "id", "internal error")
 }
 
+func (m *mockViewErrOrg) OrgByLoginDomain(context.Context, string) (*query.Org, error) {
+	return nil, errors.ThrowInternal(nil, "id", "internal error")
+}
+
+type mockViewLoginDomainOrg struct {
+	orgs map[string]*query.Org
+}
+
+func (m *mockViewLoginDomainOrg) OrgByID(_ context.Context, _ bool, id string) (*query.Org, error) {
+	for _, org := range m.orgs {
+		if org.ID == id {
+			return org, nil
+		}
+	}
+	return nil, errors.ThrowNotFound(nil, "id", "not found")
+}
+
+func (m *mockViewLoginDomainOrg) OrgByPrimaryDomain(context.Context, string) (*query.Org, error) {
+	return nil, errors.ThrowNotFound(nil, "id", "not found")
+}
+
+func (m *mockViewLoginDomainOrg) OrgByLoginDomain(_ context.Context, loginDomain string) (*query.Org, error) {
+	org, ok := m.orgs[loginDomain]
+	if !ok {
+		return nil, errors.ThrowNotFound(nil, "id", "not found")
+	}
+	return org, nil
+}
+
 type mockUserGrants struct {
 	roleCheck  bool
 	userGrants int
@@ -235,6 +271,17 @@ func (m *mockApp) AppByOIDCClientID(ctx context.Context, id string, _ bool) (*qu
 	return nil, errors.ThrowNotFound(nil, "ERROR", "error")
 }
 
+type mockUserConsent struct {
+	consent *query.UserConsent
+}
+
+func (m *mockUserConsent) UserConsentByClientID(ctx context.Context, _ bool, userID, clientID string) (*query.UserConsent, error) {
+	if m.consent != nil {
+		return m.consent, nil
+	}
+	return nil, errors.ThrowNotFound(nil, "ERROR", "error")
+}
+
 type mockIDPUserLinks struct {
 	idps []*query.IDPUserLink
 }
@@ -243,6 +290,26 @@ func (m *mockIDPUserLinks) IDPUserLinks(ctx context.Context, queries *query.IDPU
 	return &query.IDPUserLinks{Links: m.idps}, nil
 }
 
+type mockSession struct {
+	session *query.Session
+}
+
+func (m *mockSession) SessionByID(context.Context, bool, string, string) (*query.Session, error) {
+	return m.session, nil
+}
+
+type mockUserCommands struct {
+	unlocked bool
+}
+
+func (m *mockUserCommands) BulkAddedUserIDPLinks(context.Context, string, string, []*domain.UserIDPLink) error {
+	return nil
+}
+
+func (m *mockUserCommands) UnlockExpiredLockout(context.Context, string, string) (bool, error) {
+	return m.unlocked, nil
+}
+
 func TestAuthRequestRepo_nextSteps(t *testing.T) {
 	type fields struct {
 		AuthRequests            *cache.AuthRequestCache
@@ -254,9 +321,12 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 		userGrantProvider       userGrantProvider
 		projectProvider         projectProvider
 		applicationProvider     applicationProvider
+		userConsentProvider     userConsentProvider
 		loginPolicyProvider     loginPolicyViewProvider
 		lockoutPolicyProvider   lockoutPolicyViewProvider
 		idpUserLinksProvider    idpUserLinksProvider
+		userCommandProvider     userCommandProvider
+		sessionProvider         sessionProvider
 	}
 	type args struct {
 		request       *domain.AuthRequest
@@ -457,11 +527,34 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 						ShowFailures: true,
 					},
 				},
+				userCommandProvider: &mockUserCommands{},
 			},
 			args{&domain.AuthRequest{UserID: "UserID", LoginPolicy: &domain.LoginPolicy{}}, false},
 			nil,
 			errors.IsPreconditionFailed,
 		},
+		{
+			"user lockout expired, unlocked, org error, internal error",
+			fields{
+				userViewProvider: &mockViewUser{},
+				userEventProvider: &mockEventUser{
+					&es_models.Event{
+						AggregateType: user_repo.AggregateType,
+						Type:          es_models.EventType(user_repo.UserLockedType),
+					},
+				},
+				orgViewProvider: &mockViewErrOrg{},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				userCommandProvider: &mockUserCommands{unlocked: true},
+			},
+			args{&domain.AuthRequest{UserID: "UserID", LoginPolicy: &domain.LoginPolicy{}}, false},
+			nil,
+			errors.IsInternal,
+		},
 		{
 			"org error, internal error",
 			fields{
@@ -851,6 +944,116 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 			[]domain.NextStep{&domain.RedirectToCallbackStep{}},
 			nil,
 		},
+		{
+			"linked session with password and webauthn checked, callback",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider:   &mockEventUser{},
+				orgViewProvider:     &mockViewOrg{State: domain.OrgStateActive},
+				userGrantProvider:   &mockUserGrants{},
+				projectProvider:     &mockProject{},
+				applicationProvider: &mockApp{app: &query.App{OIDCConfig: &query.OIDCApp{AppType: domain.OIDCApplicationTypeWeb}}},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+				sessionProvider: &mockSession{
+					session: &query.Session{
+						ID:             "SessionID",
+						UserFactor:     query.SessionUserFactor{UserID: "UserID"},
+						PasswordFactor: query.SessionPasswordFactor{PasswordCheckedAt: testNow.Add(-5 * time.Minute)},
+						WebAuthNFactor: query.SessionWebAuthNFactor{WebAuthNCheckedAt: testNow.Add(-5 * time.Minute)},
+					},
+				},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID:    "UserID",
+					SessionID: "SessionID",
+					Request:   &domain.AuthRequestOIDC{},
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactorCheckLifetime: 18 * time.Hour,
+						PasswordCheckLifetime:     10 * 24 * time.Hour,
+					},
+				}, false},
+			[]domain.NextStep{&domain.RedirectToCallbackStep{}},
+			nil,
+		},
+		{
+			"linked session with intent checked, mfa check step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{},
+				userViewProvider: &mockViewUser{
+					PasswordSet: true,
+					OTPState:    int32(user_model.MFAStateReady),
+					MFAMaxSetUp: int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+				sessionProvider: &mockSession{
+					session: &query.Session{
+						ID:           "SessionID",
+						UserFactor:   query.SessionUserFactor{UserID: "UserID"},
+						IntentFactor: query.SessionIntentFactor{IntentCheckedAt: testNow.Add(-5 * time.Minute)},
+					},
+				},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID:    "UserID",
+					SessionID: "SessionID",
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:              []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+						SecondFactorCheckLifetime:  18 * time.Hour,
+						ExternalLoginCheckLifetime: 10 * 24 * time.Hour,
+					},
+				}, false},
+			[]domain.NextStep{&domain.MFAVerificationStep{
+				MFAProviders: []domain.MFAType{domain.MFATypeOTP},
+			}},
+			nil,
+		},
+		{
+			"linked session of other user, precondition failed error",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{},
+				userViewProvider:        &mockViewUser{},
+				userEventProvider:       &mockEventUser{},
+				orgViewProvider:         &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				sessionProvider: &mockSession{
+					session: &query.Session{
+						ID:         "SessionID",
+						UserFactor: query.SessionUserFactor{UserID: "OtherUserID"},
+					},
+				},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID:      "UserID",
+					SessionID:   "SessionID",
+					LoginPolicy: &domain.LoginPolicy{},
+				}, false},
+			nil,
+			errors.IsPreconditionFailed,
+		},
 		{
 			"password verified, passwordless set up, mfa not verified, mfa check step",
 			fields{
@@ -1164,6 +1367,82 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 			[]domain.NextStep{&domain.LoginSucceededStep{}, &domain.RedirectToCallbackStep{}},
 			nil,
 		},
+		{
+			"consent required and scope not consented, consent step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     testNow.Add(-5 * time.Minute),
+					SecondFactorVerification: testNow.Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider:   &mockEventUser{},
+				orgViewProvider:     &mockViewOrg{State: domain.OrgStateActive},
+				userGrantProvider:   &mockUserGrants{},
+				projectProvider:     &mockProject{},
+				applicationProvider: &mockApp{app: &query.App{OIDCConfig: &query.OIDCApp{AppType: domain.OIDCApplicationTypeWeb, RequireConsent: true}}},
+				userConsentProvider: &mockUserConsent{consent: &query.UserConsent{Scopes: []string{"openid"}}},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+			},
+			args{&domain.AuthRequest{
+				UserID:        "UserID",
+				ApplicationID: "clientID",
+				Request:       &domain.AuthRequestOIDC{Scopes: []string{"openid", "email"}},
+				LoginPolicy: &domain.LoginPolicy{
+					SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+					PasswordCheckLifetime:     10 * 24 * time.Hour,
+					SecondFactorCheckLifetime: 18 * time.Hour,
+				},
+			}, false},
+			[]domain.NextStep{&domain.ConsentStep{Scopes: []string{"email"}}},
+			nil,
+		},
+		{
+			"consent required and all scopes consented, redirect to callback step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     testNow.Add(-5 * time.Minute),
+					SecondFactorVerification: testNow.Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider:   &mockEventUser{},
+				orgViewProvider:     &mockViewOrg{State: domain.OrgStateActive},
+				userGrantProvider:   &mockUserGrants{},
+				projectProvider:     &mockProject{},
+				applicationProvider: &mockApp{app: &query.App{OIDCConfig: &query.OIDCApp{AppType: domain.OIDCApplicationTypeWeb, RequireConsent: true}}},
+				userConsentProvider: &mockUserConsent{consent: &query.UserConsent{Scopes: []string{"openid", "email", "profile"}}},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+			},
+			args{&domain.AuthRequest{
+				UserID:        "UserID",
+				ApplicationID: "clientID",
+				Request:       &domain.AuthRequestOIDC{Scopes: []string{"openid", "email"}},
+				LoginPolicy: &domain.LoginPolicy{
+					SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+					PasswordCheckLifetime:     10 * 24 * time.Hour,
+					SecondFactorCheckLifetime: 18 * time.Hour,
+				},
+			}, false},
+			[]domain.NextStep{&domain.RedirectToCallbackStep{}},
+			nil,
+		},
 		{
 			"prompt none, checkLoggedIn true, authenticated and required user grants missing, grant required step",
 			fields{
@@ -1407,9 +1686,12 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 				UserGrantProvider:         tt.fields.userGrantProvider,
 				ProjectProvider:           tt.fields.projectProvider,
 				ApplicationProvider:       tt.fields.applicationProvider,
+				UserConsentProvider:       tt.fields.userConsentProvider,
 				LoginPolicyViewProvider:   tt.fields.loginPolicyProvider,
 				LockoutPolicyViewProvider: tt.fields.lockoutPolicyProvider,
 				IDPUserLinksProvider:      tt.fields.idpUserLinksProvider,
+				UserCommandProvider:       tt.fields.userCommandProvider,
+				SessionProvider:           tt.fields.sessionProvider,
 			}
 			got, err := repo.nextSteps(context.Background(), tt.args.request, tt.args.checkLoggedIn)
 			if (err != nil && tt.wantErr == nil) || (tt.wantErr != nil && !tt.wantErr(err)) {
@@ -1953,3 +2235,88 @@ func Test_userByID(t *testing.T) {
 		})
 	}
 }
+
+func Test_setOrgID(t *testing.T) {
+	loginDomainOrgs := &mockViewLoginDomainOrg{
+		orgs: map[string]*query.Org{
+			"login.customer.com": {ID: "org1", Name: "customer", Domain: "customer.com"},
+			"login.other.com":    {ID: "org2", Name: "other", Domain: "other.com"},
+		},
+	}
+	type args struct {
+		ctx     context.Context
+		request *domain.AuthRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *domain.AuthRequest
+		wantErr func(error) bool
+	}{
+		{
+			"no scope, instance domain, no org",
+			args{
+				ctx:     authz.WithRequestedDomain(context.Background(), "zitadel.cloud"),
+				request: &domain.AuthRequest{Request: &domain.AuthRequestOIDC{}},
+			},
+			&domain.AuthRequest{Request: &domain.AuthRequestOIDC{}},
+			nil,
+		},
+		{
+			"no scope, login domain, org of login domain",
+			args{
+				ctx:     authz.WithRequestedDomain(context.Background(), "login.customer.com"),
+				request: &domain.AuthRequest{Request: &domain.AuthRequestOIDC{}},
+			},
+			&domain.AuthRequest{
+				Request:                &domain.AuthRequestOIDC{},
+				RequestedOrgID:         "org1",
+				RequestedOrgName:       "customer",
+				RequestedPrimaryDomain: "customer.com",
+			},
+			nil,
+		},
+		{
+			"org scope, login domain, org of scope",
+			args{
+				ctx: authz.WithRequestedDomain(context.Background(), "login.customer.com"),
+				request: &domain.AuthRequest{Request: &domain.AuthRequestOIDC{
+					Scopes: []string{domain.OrgIDScope + "org2"},
+				}},
+			},
+			&domain.AuthRequest{
+				Request: &domain.AuthRequestOIDC{
+					Scopes: []string{domain.OrgIDScope + "org2"},
+				},
+				RequestedOrgID:         "org2",
+				RequestedOrgName:       "other",
+				RequestedPrimaryDomain: "other.com",
+			},
+			nil,
+		},
+		{
+			"login domain, error",
+			args{
+				ctx:     authz.WithRequestedDomain(context.Background(), "login.customer.com"),
+				request: &domain.AuthRequest{Request: &domain.AuthRequestOIDC{}},
+			},
+			nil,
+			errors.IsInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var provider orgViewProvider = loginDomainOrgs
+			if tt.wantErr != nil {
+				provider = &mockViewErrOrg{}
+			}
+			err := setOrgID(tt.args.ctx, provider, tt.args.request)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, tt.args.request)
+		})
+	}
+}
@@ -141,6 +141,9 @@ func (u *User) ProcessUser(event *es_models.Event) (err error) {
 		user_repo.HumanU2FTokenAddedType,
 		user_repo.HumanU2FTokenVerifiedType,
 		user_repo.HumanU2FTokenRemovedType,
+		user_repo.HumanPushDeviceAddedType,
+		user_repo.HumanPushDeviceVerifiedType,
+		user_repo.HumanPushDeviceRemovedType,
 		user_repo.HumanPasswordlessTokenAddedType,
 		user_repo.HumanPasswordlessTokenVerifiedType,
 		user_repo.HumanPasswordlessTokenRemovedType,
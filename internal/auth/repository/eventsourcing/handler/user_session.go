@@ -98,6 +98,12 @@ func (u *UserSession) Reduce(event *models.Event) (err error) {
 		user.HumanU2FTokenCheckFailedType,
 		user.HumanPasswordlessTokenCheckSucceededType,
 		user.HumanPasswordlessTokenCheckFailedType,
+		user.HumanMagicLinkCheckSucceededType,
+		user.HumanMagicLinkCheckFailedType,
+		user.HumanPhoneOTPCheckSucceededType,
+		user.HumanPhoneOTPCheckFailedType,
+		user.HumanPushCheckSucceededType,
+		user.HumanPushCheckFailedType,
 		user.HumanSignedOutType:
 		eventData, err := view_model.UserSessionFromEvent(event)
 		if err != nil {
@@ -133,7 +139,8 @@ func (u *UserSession) Reduce(event *models.Event) (err error) {
 		user.UserIDPLinkRemovedType,
 		user.UserIDPLinkCascadeRemovedType,
 		user.HumanPasswordlessTokenRemovedType,
-		user.HumanU2FTokenRemovedType:
+		user.HumanU2FTokenRemovedType,
+		user.HumanPushDeviceRemovedType:
 		sessions, err := u.view.UserSessionsByUserID(event.AggregateID, event.InstanceID)
 		if err != nil {
 			return err
@@ -84,7 +84,7 @@ func (repo *TokenVerifierRepo) tokenByID(ctx context.Context, tokenID, userID st
 	return model.TokenViewToModel(token), nil
 }
 
-func (repo *TokenVerifierRepo) VerifyAccessToken(ctx context.Context, tokenString, verifierClientID, projectID string) (userID string, agentID string, clientID, prefLang, resourceOwner string, err error) {
+func (repo *TokenVerifierRepo) VerifyAccessToken(ctx context.Context, tokenString, verifierClientID, projectID, dpopJKT string) (userID string, agentID string, clientID, prefLang, resourceOwner string, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -101,6 +101,10 @@ func (repo *TokenVerifierRepo) VerifyAccessToken(ctx context.Context, tokenStrin
 	if !token.Expiration.After(time.Now().UTC()) {
 		return "", "", "", "", "", caos_errs.ThrowUnauthenticated(err, "APP-k9KS0", "invalid token")
 	}
+	// sender-constrained tokens must be used with a proof of the bound key, unbound tokens must not be used as DPoP token
+	if token.DPoPJKT != dpopJKT {
+		return "", "", "", "", "", caos_errs.ThrowUnauthenticated(nil, "APP-Dpo3k", "invalid token binding")
+	}
 	if token.IsPAT {
 		return token.UserID, "", "", "", token.ResourceOwner, nil
 	}
@@ -120,6 +124,13 @@ func (repo *TokenVerifierRepo) ProjectIDAndOriginsByClientID(ctx context.Context
 	return app.ProjectID, app.OIDCConfig.AllowedOrigins, nil
 }
 
+func (repo *TokenVerifierRepo) AddDPoPProof(ctx context.Context, jti string, expiration time.Time) (_ bool, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return repo.View.AddDPoPProof(authz.GetInstance(ctx).InstanceID(), jti, expiration)
+}
+
 func (repo *TokenVerifierRepo) VerifierClientID(ctx context.Context, appName string) (clientID, projectID string, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -38,8 +38,21 @@ func (repo *UserMembershipRepo) searchUserMemberships(ctx context.Context) (_ []
 	if err != nil {
 		return nil, err
 	}
+	orQueries := []query.SearchQuery{orgIDsQuery, grantedIDQuery}
+	// members of an ancestor org are members of its child orgs as well
+	ancestorIDs, err := repo.Queries.OrgAncestorIDs(ctx, ctxData.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ancestorIDs) > 0 {
+		ancestorsQuery, err := query.NewMembershipOrgIDsSearchQuery(ancestorIDs...)
+		if err != nil {
+			return nil, err
+		}
+		orQueries = append(orQueries, ancestorsQuery)
+	}
 	memberships, err := repo.Queries.Memberships(ctx, &query.MembershipSearchQuery{
-		Queries: []query.SearchQuery{userIDQuery, query.Or(orgIDsQuery, grantedIDQuery)},
+		Queries: []query.SearchQuery{userIDQuery, query.Or(orQueries...)},
 	}, false)
 	if err != nil {
 		return nil, err
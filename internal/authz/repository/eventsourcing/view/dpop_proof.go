@@ -0,0 +1,27 @@
+package view
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	dpopProofTable = "auth.dpop_proofs"
+)
+
+// AddDPoPProof stores the jti of a DPoP proof until it expires and returns false if it is already stored.
+// Expired proofs of the instance are removed on the way.
+func (v *View) AddDPoPProof(instanceID, jti string, expiration time.Time) (bool, error) {
+	result := v.Db.Exec("WITH expired AS ("+
+		"DELETE FROM "+dpopProofTable+" WHERE instance_id = ? AND jti <> ? AND expiration < now()"+
+		") INSERT INTO "+dpopProofTable+" (instance_id, jti, expiration) VALUES (?, ?, ?)"+
+		" ON CONFLICT (instance_id, jti) DO UPDATE SET expiration = EXCLUDED.expiration"+
+		" WHERE "+dpopProofTable+".expiration < now()",
+		instanceID, jti, instanceID, jti, expiration,
+	)
+	if result.Error != nil {
+		return false, errors.ThrowInternal(result.Error, "VIEW-Dp0Ps", "unable to store dpop proof")
+	}
+	return result.RowsAffected == 1, nil
+}
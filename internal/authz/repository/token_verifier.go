@@ -2,10 +2,12 @@ package repository
 
 import (
 	"context"
+	"time"
 )
 
 type TokenVerifierRepository interface {
-	VerifyAccessToken(ctx context.Context, tokenString, verifierClientID, projectID string) (userID string, agentID string, clientID, prefLang, resourceOwner string, err error)
+	VerifyAccessToken(ctx context.Context, tokenString, verifierClientID, projectID, dpopJKT string) (userID string, agentID string, clientID, prefLang, resourceOwner string, err error)
 	ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (projectID string, origins []string, err error)
 	VerifierClientID(ctx context.Context, appName string) (clientID, projectID string, err error)
+	AddDPoPProof(ctx context.Context, jti string, expiration time.Time) (bool, error)
 }
@@ -15,11 +15,15 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/idpintent"
 	instance_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/keypair"
+	"github.com/zitadel/zitadel/internal/repository/notification"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	proj_repo "github.com/zitadel/zitadel/internal/repository/project"
 	"github.com/zitadel/zitadel/internal/repository/quota"
+	"github.com/zitadel/zitadel/internal/repository/session"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
 	usr_grant_repo "github.com/zitadel/zitadel/internal/repository/usergrant"
 	"github.com/zitadel/zitadel/internal/static"
@@ -47,6 +51,16 @@ type Commands struct {
 	domainVerificationAlg       crypto.EncryptionAlgorithm
 	domainVerificationGenerator crypto.Generator
 	domainVerificationValidator func(domain, token, verifier string, checkType api_http.CheckType) error
+	magicLinkGenerator          crypto.Generator
+	magicLinkLimits             codeChallengeLimits
+	phoneOTPGenerator           crypto.Generator
+	phoneOTPLimits              codeChallengeLimits
+	pushChallengeExpiry         time.Duration
+	pushDeviceCodeGenerator     crypto.Generator
+	loginThrottle               *loginThrottle
+	sessionTokenCreator         func(sessionID string) (id string, token string, err error)
+	sessionTokenVerifier        func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error)
+	checkPermission             domain.PermissionCheck
 
 	multifactors         domain.MultifactorConfigs
 	webauthnConfig       *webauthn_helper.Config
@@ -76,6 +90,7 @@ func StartCommands(es *eventstore.Eventstore,
 	oidcEncryption,
 	samlEncryption crypto.EncryptionAlgorithm,
 	httpClient *http.Client,
+	permissionCheck domain.PermissionCheck,
 ) (repo *Commands, err error) {
 	if externalDomain == "" {
 		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Df21s", "no external domain specified")
@@ -102,6 +117,7 @@ func StartCommands(es *eventstore.Eventstore,
 		certificateAlgorithm:  samlEncryption,
 		webauthnConfig:        webAuthN,
 		httpClient:            httpClient,
+		checkPermission:       permissionCheck,
 	}
 
 	instance_repo.RegisterEventMappers(repo.eventstore)
@@ -111,7 +127,11 @@ func StartCommands(es *eventstore.Eventstore,
 	proj_repo.RegisterEventMappers(repo.eventstore)
 	keypair.RegisterEventMappers(repo.eventstore)
 	action.RegisterEventMappers(repo.eventstore)
+	group.RegisterEventMappers(repo.eventstore)
 	quota.RegisterEventMappers(repo.eventstore)
+	session.RegisterEventMappers(repo.eventstore)
+	idpintent.RegisterEventMappers(repo.eventstore)
+	notification.RegisterEventMappers(repo.eventstore)
 
 	repo.userPasswordAlg = crypto.NewBCrypt(defaults.SecretGenerators.PasswordSaltCost)
 	repo.machineKeySize = int(defaults.SecretGenerators.MachineKeySize)
@@ -126,6 +146,23 @@ func StartCommands(es *eventstore.Eventstore,
 
 	repo.domainVerificationGenerator = crypto.NewEncryptionGenerator(defaults.DomainVerification.VerificationGenerator, repo.domainVerificationAlg)
 	repo.domainVerificationValidator = api_http.ValidateDomain
+	repo.magicLinkGenerator = crypto.NewEncryptionGenerator(defaults.MagicLink.CodeGenerator, repo.userEncryption)
+	repo.magicLinkLimits = codeChallengeLimits{
+		MaxAttempts:     defaults.MagicLink.MaxAttempts,
+		MaxRequests:     defaults.MagicLink.MaxRequests,
+		RequestInterval: defaults.MagicLink.RequestInterval,
+	}
+	repo.phoneOTPGenerator = crypto.NewEncryptionGenerator(defaults.PhoneOTP.CodeGenerator, repo.userEncryption)
+	repo.phoneOTPLimits = codeChallengeLimits{
+		MaxAttempts:     defaults.PhoneOTP.MaxAttempts,
+		MaxRequests:     defaults.PhoneOTP.MaxRequests,
+		RequestInterval: defaults.PhoneOTP.RequestInterval,
+	}
+	repo.pushChallengeExpiry = defaults.Multifactors.Push.ChallengeExpiry
+	repo.pushDeviceCodeGenerator = crypto.NewEncryptionGenerator(defaults.Multifactors.Push.VerificationCodeGenerator, repo.userEncryption)
+	repo.loginThrottle = newLoginThrottle(defaults.LoginThrottling)
+	repo.sessionTokenCreator = sessionTokenCreator(repo.idGenerator, oidcEncryption)
+	repo.sessionTokenVerifier = authz.SessionTokenVerifier(oidcEncryption)
 	return repo, nil
 }
 
@@ -32,3 +32,39 @@ func (wm *CustomTextWriteModel) Reduce() error {
 	}
 	return wm.WriteModel.Reduce()
 }
+
+// CustomTextsWriteModel reduces all custom texts of an aggregate in a language
+type CustomTextsWriteModel struct {
+	eventstore.WriteModel
+
+	Language language.Tag
+	// Texts are the custom texts by the id of domain.CustomTextID
+	Texts map[string]string
+}
+
+func (wm *CustomTextsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.CustomTextSetEvent:
+			if wm.Language != e.Language {
+				continue
+			}
+			wm.Texts[domain.CustomTextID(e.Template, e.Key)] = e.Text
+		case *policy.CustomTextRemovedEvent:
+			if wm.Language != e.Language {
+				continue
+			}
+			delete(wm.Texts, domain.CustomTextID(e.Template, e.Key))
+		case *policy.CustomTextTemplateRemovedEvent:
+			if wm.Language != e.Language {
+				continue
+			}
+			for id := range wm.Texts {
+				if template, _ := domain.SplitCustomTextID(id); template == e.Template {
+					delete(wm.Texts, id)
+				}
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
@@ -0,0 +1,417 @@
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/group"
+)
+
+func (c *Commands) AddGroup(ctx context.Context, addGroup *domain.Group, resourceOwner string) (_ string, _ *domain.ObjectDetails, err error) {
+	if !addGroup.IsValid() || resourceOwner == "" {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Gr9ak", "Errors.Group.Invalid")
+	}
+	groupID, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	groupModel := NewGroupWriteModel(groupID, resourceOwner)
+	groupAgg := GroupAggregateFromWriteModel(&groupModel.WriteModel)
+
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewAddedEvent(
+		ctx,
+		groupAgg,
+		addGroup.Name,
+		addGroup.Description,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(groupModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return groupModel.AggregateID, writeModelToObjectDetails(&groupModel.WriteModel), nil
+}
+
+func (c *Commands) ChangeGroup(ctx context.Context, groupChange *domain.Group, resourceOwner string) (*domain.ObjectDetails, error) {
+	if !groupChange.IsValid() || groupChange.AggregateID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hs8al", "Errors.Group.Invalid")
+	}
+	existingGroup, err := c.getGroupWriteModelByID(ctx, groupChange.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingGroup.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Lp2nx", "Errors.Group.NotFound")
+	}
+
+	groupAgg := GroupAggregateFromWriteModel(&existingGroup.WriteModel)
+	changedEvent, err := existingGroup.NewChangedEvent(
+		ctx,
+		groupAgg,
+		groupChange.Name,
+		groupChange.Description)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGroup, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingGroup.WriteModel), nil
+}
+
+// RemoveGroup removes the group, its members and grants are removed with it
+func (c *Commands) RemoveGroup(ctx context.Context, groupID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if groupID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Mz8qp", "Errors.IDMissing")
+	}
+	existingGroup, err := c.getGroupWriteModelByID(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingGroup.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ue0sk", "Errors.Group.NotFound")
+	}
+	groupAgg := GroupAggregateFromWriteModel(&existingGroup.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewRemovedEvent(ctx, groupAgg, existingGroup.Name))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGroup, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingGroup.WriteModel), nil
+}
+
+func (c *Commands) AddGroupMember(ctx context.Context, groupID, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if groupID == "" || userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Wq7bn", "Errors.Group.Member.Invalid")
+	}
+	members, err := c.getGroupMembersWriteModelByID(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !members.GroupExists {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Yx0ar", "Errors.Group.NotFound")
+	}
+	if members.IsMember(userID) {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Rb3ma", "Errors.Group.Member.AlreadyExists")
+	}
+	err = c.checkUserExists(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	groupAgg := GroupAggregateFromWriteModel(&members.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewMemberAddedEvent(ctx, groupAgg, userID))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(members, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&members.WriteModel), nil
+}
+
+func (c *Commands) RemoveGroupMember(ctx context.Context, groupID, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if groupID == "" || userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pn2vc", "Errors.Group.Member.Invalid")
+	}
+	members, err := c.getGroupMembersWriteModelByID(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !members.GroupExists {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Dj3ka", "Errors.Group.NotFound")
+	}
+	if !members.IsMember(userID) {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Zl8fw", "Errors.Group.Member.NotFound")
+	}
+	groupAgg := GroupAggregateFromWriteModel(&members.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewMemberRemovedEvent(ctx, groupAgg, userID))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(members, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&members.WriteModel), nil
+}
+
+func (c *Commands) SetGroupMetadata(ctx context.Context, groupID, resourceOwner string, metadata *domain.Metadata) (_ *domain.Metadata, err error) {
+	if !metadata.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "META-Bz7ql", "Errors.Metadata.Invalid")
+	}
+	err = c.checkGroupExists(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	setMetadata := NewGroupMetadataWriteModel(groupID, resourceOwner, metadata.Key)
+	groupAgg := GroupAggregateFromWriteModel(&setMetadata.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewMetadataSetEvent(
+		ctx,
+		groupAgg,
+		metadata.Key,
+		metadata.Value,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(setMetadata, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToGroupMetadata(setMetadata), nil
+}
+
+func (c *Commands) RemoveGroupMetadata(ctx context.Context, groupID, resourceOwner, metadataKey string) (_ *domain.ObjectDetails, err error) {
+	if metadataKey == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "META-Cv8sn", "Errors.Metadata.Invalid")
+	}
+	err = c.checkGroupExists(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	removeMetadata := NewGroupMetadataWriteModel(groupID, resourceOwner, metadataKey)
+	err = c.eventstore.FilterToQueryReducer(ctx, removeMetadata)
+	if err != nil {
+		return nil, err
+	}
+	if !removeMetadata.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "META-Xk2lq", "Errors.Metadata.NotFound")
+	}
+	groupAgg := GroupAggregateFromWriteModel(&removeMetadata.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewMetadataRemovedEvent(ctx, groupAgg, metadataKey))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(removeMetadata, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&removeMetadata.WriteModel), nil
+}
+
+func (c *Commands) AddGroupGrant(ctx context.Context, grant *domain.GroupGrant, resourceOwner string) (_ string, _ *domain.ObjectDetails, err error) {
+	if !grant.IsValid() {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fk3m1", "Errors.Group.Grant.Invalid")
+	}
+	err = c.checkGroupExists(ctx, grant.AggregateID, resourceOwner)
+	if err != nil {
+		return "", nil, err
+	}
+	err = c.checkGroupGrantPreCondition(ctx, grant, resourceOwner)
+	if err != nil {
+		return "", nil, err
+	}
+	grant.GrantID, err = c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	grantModel := NewGroupGrantWriteModel(grant.AggregateID, grant.GrantID, resourceOwner)
+	groupAgg := GroupAggregateFromWriteModel(&grantModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewGrantAddedEvent(
+		ctx,
+		groupAgg,
+		grant.GrantID,
+		grant.ProjectID,
+		grant.ProjectGrantID,
+		grant.RoleKeys,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(grantModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return grant.GrantID, writeModelToObjectDetails(&grantModel.WriteModel), nil
+}
+
+func (c *Commands) ChangeGroupGrant(ctx context.Context, grant *domain.GroupGrant, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	if grant.AggregateID == "" || grant.GrantID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Tq0ce", "Errors.Group.Grant.Invalid")
+	}
+	existingGrant, err := c.getGroupGrantWriteModelByID(ctx, grant.AggregateID, grant.GrantID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingGrant.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ob4ri", "Errors.Group.Grant.NotFound")
+	}
+	if reflect.DeepEqual(existingGrant.RoleKeys, grant.RoleKeys) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Vn7dk", "Errors.Group.Grant.NotChanged")
+	}
+	grant.ProjectID = existingGrant.ProjectID
+	grant.ProjectGrantID = existingGrant.ProjectGrantID
+	err = c.checkGroupGrantPreCondition(ctx, grant, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	groupAgg := GroupAggregateFromWriteModel(&existingGrant.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewGrantChangedEvent(ctx, groupAgg, grant.GrantID, grant.RoleKeys))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGrant, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingGrant.WriteModel), nil
+}
+
+func (c *Commands) RemoveGroupGrant(ctx context.Context, groupID, grantID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	if groupID == "" || grantID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ha9wm", "Errors.IDMissing")
+	}
+	existingGrant, err := c.getGroupGrantWriteModelByID(ctx, groupID, grantID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingGrant.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ei3ox", "Errors.Group.Grant.NotFound")
+	}
+	groupAgg := GroupAggregateFromWriteModel(&existingGrant.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewGrantRemovedEvent(
+		ctx,
+		groupAgg,
+		grantID,
+		existingGrant.ProjectID,
+		existingGrant.ProjectGrantID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGrant, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingGrant.WriteModel), nil
+}
+
+func (c *Commands) DeactivateGroupGrant(ctx context.Context, groupID, grantID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	if groupID == "" || grantID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Gd1ia", "Errors.IDMissing")
+	}
+	existingGrant, err := c.getGroupGrantWriteModelByID(ctx, groupID, grantID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingGrant.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Gd1nf", "Errors.Group.Grant.NotFound")
+	}
+	if existingGrant.State != domain.GroupGrantStateActive {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Gd1na", "Errors.Group.Grant.NotActive")
+	}
+	groupAgg := GroupAggregateFromWriteModel(&existingGrant.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewGrantDeactivatedEvent(ctx, groupAgg, grantID))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGrant, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingGrant.WriteModel), nil
+}
+
+func (c *Commands) ReactivateGroupGrant(ctx context.Context, groupID, grantID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	if groupID == "" || grantID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Gr1ia", "Errors.IDMissing")
+	}
+	existingGrant, err := c.getGroupGrantWriteModelByID(ctx, groupID, grantID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingGrant.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Gr1nf", "Errors.Group.Grant.NotFound")
+	}
+	if existingGrant.State != domain.GroupGrantStateInactive {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Gr1ni", "Errors.Group.Grant.NotInactive")
+	}
+	groupAgg := GroupAggregateFromWriteModel(&existingGrant.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewGrantReactivatedEvent(ctx, groupAgg, grantID))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGrant, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingGrant.WriteModel), nil
+}
+
+func (c *Commands) checkGroupExists(ctx context.Context, groupID, resourceOwner string) error {
+	existingGroup, err := c.getGroupWriteModelByID(ctx, groupID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !existingGroup.State.Exists() {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ks9ew", "Errors.Group.NotFound")
+	}
+	return nil
+}
+
+func (c *Commands) checkGroupGrantPreCondition(ctx context.Context, grant *domain.GroupGrant, resourceOwner string) error {
+	preConditions := NewGroupGrantPreConditionReadModel(grant.ProjectID, grant.ProjectGrantID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, preConditions)
+	if err != nil {
+		return err
+	}
+	if grant.ProjectGrantID == "" && !preConditions.ProjectExists {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sj2ka", "Errors.Project.NotFound")
+	}
+	if grant.ProjectGrantID != "" && !preConditions.ProjectGrantExists {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ah5ol", "Errors.Project.Grant.NotFound")
+	}
+	if grant.HasInvalidRoles(preConditions.ExistingRoleKeys) {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Wo8xv", "Errors.Project.Role.NotFound")
+	}
+	return nil
+}
+
+func (c *Commands) getGroupWriteModelByID(ctx context.Context, groupID, resourceOwner string) (*GroupWriteModel, error) {
+	groupWriteModel := NewGroupWriteModel(groupID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, groupWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	return groupWriteModel, nil
+}
+
+func (c *Commands) getGroupMembersWriteModelByID(ctx context.Context, groupID, resourceOwner string) (*GroupMembersWriteModel, error) {
+	membersWriteModel := NewGroupMembersWriteModel(groupID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, membersWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	return membersWriteModel, nil
+}
+
+func (c *Commands) getGroupGrantWriteModelByID(ctx context.Context, groupID, grantID, resourceOwner string) (*GroupGrantWriteModel, error) {
+	grantWriteModel := NewGroupGrantWriteModel(groupID, grantID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, grantWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	return grantWriteModel, nil
+}
+
+func writeModelToGroupMetadata(wm *GroupMetadataWriteModel) *domain.Metadata {
+	return &domain.Metadata{
+		ObjectRoot: writeModelToObjectRoot(wm.WriteModel),
+		Key:        wm.Key,
+		Value:      wm.Value,
+		State:      wm.State,
+	}
+}
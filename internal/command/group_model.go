@@ -0,0 +1,349 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type GroupWriteModel struct {
+	eventstore.WriteModel
+
+	Name        string
+	Description string
+	State       domain.GroupState
+}
+
+func NewGroupWriteModel(groupID string, resourceOwner string) *GroupWriteModel {
+	return &GroupWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   groupID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *GroupWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *group.AddedEvent:
+			wm.Name = e.Name
+			wm.Description = e.Description
+			wm.State = domain.GroupStateActive
+		case *group.ChangedEvent:
+			if e.Name != nil {
+				wm.Name = *e.Name
+			}
+			if e.Description != nil {
+				wm.Description = *e.Description
+			}
+		case *group.RemovedEvent:
+			wm.State = domain.GroupStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *GroupWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(group.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(group.AddedEventType,
+			group.ChangedEventType,
+			group.RemovedEventType).
+		Builder()
+}
+
+func (wm *GroupWriteModel) NewChangedEvent(
+	ctx context.Context,
+	agg *eventstore.Aggregate,
+	name string,
+	description string,
+) (*group.ChangedEvent, error) {
+	changes := make([]group.GroupChanges, 0)
+	if wm.Name != name {
+		changes = append(changes, group.ChangeName(name, wm.Name))
+	}
+	if wm.Description != description {
+		changes = append(changes, group.ChangeDescription(description))
+	}
+	return group.NewChangedEvent(ctx, agg, changes)
+}
+
+func GroupAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return eventstore.AggregateFromWriteModel(wm, group.AggregateType, group.AggregateVersion)
+}
+
+// GroupMembersWriteModel holds the user ids of the members of the group
+type GroupMembersWriteModel struct {
+	eventstore.WriteModel
+
+	GroupExists bool
+	Members     map[string]struct{}
+}
+
+func NewGroupMembersWriteModel(groupID string, resourceOwner string) *GroupMembersWriteModel {
+	return &GroupMembersWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   groupID,
+			ResourceOwner: resourceOwner,
+		},
+		Members: make(map[string]struct{}),
+	}
+}
+
+func (wm *GroupMembersWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *group.AddedEvent:
+			wm.GroupExists = true
+		case *group.MemberAddedEvent:
+			wm.Members[e.UserID] = struct{}{}
+		case *group.MemberRemovedEvent:
+			delete(wm.Members, e.UserID)
+		case *group.RemovedEvent:
+			wm.GroupExists = false
+			wm.Members = make(map[string]struct{})
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *GroupMembersWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(group.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(group.AddedEventType,
+			group.MemberAddedEventType,
+			group.MemberRemovedEventType,
+			group.RemovedEventType).
+		Builder()
+}
+
+func (wm *GroupMembersWriteModel) IsMember(userID string) bool {
+	_, ok := wm.Members[userID]
+	return ok
+}
+
+type GroupMetadataWriteModel struct {
+	MetadataWriteModel
+}
+
+func NewGroupMetadataWriteModel(groupID, resourceOwner, key string) *GroupMetadataWriteModel {
+	return &GroupMetadataWriteModel{
+		MetadataWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   groupID,
+				ResourceOwner: resourceOwner,
+			},
+			Key: key,
+		},
+	}
+}
+
+func (wm *GroupMetadataWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *group.MetadataSetEvent:
+			wm.MetadataWriteModel.AppendEvents(&e.SetEvent)
+		case *group.MetadataRemovedEvent:
+			wm.MetadataWriteModel.AppendEvents(&e.RemovedEvent)
+		case *group.MetadataRemovedAllEvent:
+			wm.MetadataWriteModel.AppendEvents(&e.RemovedAllEvent)
+		}
+	}
+}
+
+func (wm *GroupMetadataWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateIDs(wm.MetadataWriteModel.AggregateID).
+		AggregateTypes(group.AggregateType).
+		EventTypes(
+			group.MetadataSetType,
+			group.MetadataRemovedType,
+			group.MetadataRemovedAllType).
+		Builder()
+}
+
+type GroupGrantWriteModel struct {
+	eventstore.WriteModel
+
+	GrantID        string
+	ProjectID      string
+	ProjectGrantID string
+	RoleKeys       []string
+	State          domain.GroupGrantState
+}
+
+func NewGroupGrantWriteModel(groupID, grantID, resourceOwner string) *GroupGrantWriteModel {
+	return &GroupGrantWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   groupID,
+			ResourceOwner: resourceOwner,
+		},
+		GrantID: grantID,
+	}
+}
+
+func (wm *GroupGrantWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *group.GrantAddedEvent:
+			if e.GrantID != wm.GrantID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *group.GrantChangedEvent:
+			if e.GrantID != wm.GrantID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *group.GrantRemovedEvent:
+			if e.GrantID != wm.GrantID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *group.GrantDeactivatedEvent:
+			if e.GrantID != wm.GrantID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *group.GrantReactivatedEvent:
+			if e.GrantID != wm.GrantID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *group.RemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *GroupGrantWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *group.GrantAddedEvent:
+			wm.ProjectID = e.ProjectID
+			wm.ProjectGrantID = e.ProjectGrantID
+			wm.RoleKeys = e.RoleKeys
+			wm.State = domain.GroupGrantStateActive
+		case *group.GrantChangedEvent:
+			wm.RoleKeys = e.RoleKeys
+		case *group.GrantDeactivatedEvent:
+			wm.State = domain.GroupGrantStateInactive
+		case *group.GrantReactivatedEvent:
+			wm.State = domain.GroupGrantStateActive
+		case *group.GrantRemovedEvent, *group.RemovedEvent:
+			wm.State = domain.GroupGrantStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *GroupGrantWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(group.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			group.GrantAddedEventType,
+			group.GrantChangedEventType,
+			group.GrantRemovedEventType,
+			group.GrantDeactivatedEventType,
+			group.GrantReactivatedEventType,
+			group.RemovedEventType).
+		Builder()
+}
+
+// GroupGrantPreConditionReadModel checks if the project (grant)
+// and the roles of a group grant exist for the organisation of the group
+type GroupGrantPreConditionReadModel struct {
+	eventstore.WriteModel
+
+	ProjectID          string
+	ProjectGrantID     string
+	ResourceOwner      string
+	ProjectExists      bool
+	ProjectGrantExists bool
+	ExistingRoleKeys   []string
+}
+
+func NewGroupGrantPreConditionReadModel(projectID, projectGrantID, resourceOwner string) *GroupGrantPreConditionReadModel {
+	return &GroupGrantPreConditionReadModel{
+		ProjectID:      projectID,
+		ProjectGrantID: projectGrantID,
+		ResourceOwner:  resourceOwner,
+	}
+}
+
+func (wm *GroupGrantPreConditionReadModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.ProjectAddedEvent:
+			if wm.ProjectGrantID == "" && wm.ResourceOwner == e.Aggregate().ResourceOwner {
+				wm.ProjectExists = true
+			}
+		case *project.ProjectRemovedEvent:
+			wm.ProjectExists = false
+			wm.ProjectGrantExists = false
+		case *project.GrantAddedEvent:
+			if wm.ProjectGrantID == e.GrantID && wm.ResourceOwner == e.GrantedOrgID {
+				wm.ProjectGrantExists = true
+				wm.ExistingRoleKeys = e.RoleKeys
+			}
+		case *project.GrantChangedEvent:
+			if wm.ProjectGrantID == e.GrantID {
+				wm.ExistingRoleKeys = e.RoleKeys
+			}
+		case *project.GrantRemovedEvent:
+			if wm.ProjectGrantID == e.GrantID {
+				wm.ProjectGrantExists = false
+				wm.ExistingRoleKeys = []string{}
+			}
+		case *project.RoleAddedEvent:
+			if wm.ProjectGrantID != "" {
+				continue
+			}
+			wm.ExistingRoleKeys = append(wm.ExistingRoleKeys, e.Key)
+		case *project.RoleRemovedEvent:
+			if wm.ProjectGrantID != "" {
+				continue
+			}
+			for i, key := range wm.ExistingRoleKeys {
+				if key == e.Key {
+					copy(wm.ExistingRoleKeys[i:], wm.ExistingRoleKeys[i+1:])
+					wm.ExistingRoleKeys[len(wm.ExistingRoleKeys)-1] = ""
+					wm.ExistingRoleKeys = wm.ExistingRoleKeys[:len(wm.ExistingRoleKeys)-1]
+					break
+				}
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *GroupGrantPreConditionReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.ProjectID).
+		EventTypes(
+			project.ProjectAddedType,
+			project.ProjectRemovedType,
+			project.GrantAddedType,
+			project.GrantChangedType,
+			project.GrantRemovedType,
+			project.RoleAddedType,
+			project.RoleRemovedType).
+		Builder()
+}
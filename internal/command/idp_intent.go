@@ -0,0 +1,120 @@
+package command
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/repository/idpintent"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// CreateIntent starts an intent to authenticate a user on the identity provider,
+// the user will be redirected to the successURL or failureURL at the end of the flow
+func (c *Commands) CreateIntent(ctx context.Context, idpID, successURL, failureURL, resourceOwner string) (_ *IDPIntentWriteModel, _ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if idpID == "" {
+		return nil, nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-x8j2bk", "Errors.Intent.IDPMissing")
+	}
+	if err := validateIntentURL(successURL); err != nil {
+		return nil, nil, caos_errs.ThrowInvalidArgument(err, "COMMAND-x8j3bk", "Errors.Intent.SuccessURLMissing")
+	}
+	if err := validateIntentURL(failureURL); err != nil {
+		return nil, nil, caos_errs.ThrowInvalidArgument(err, "COMMAND-x8j4bk", "Errors.Intent.FailureURLMissing")
+	}
+	exists, err := ExistsIDP(ctx, c.eventstore.Filter, idpID, resourceOwner)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !exists {
+		return nil, nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-39n221fs", "Errors.IDPConfig.NotExisting")
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	writeModel := NewIDPIntentWriteModel(id, resourceOwner)
+	pushedEvents, err := c.eventstore.Push(ctx, idpintent.NewStartedEvent(ctx, writeModel.aggregate, successURL, failureURL, idpID))
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, nil, err
+	}
+	return writeModel, writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// SucceedIDPIntent stores the information of the idp user and returns the token,
+// which is needed to check the intent in a session
+func (c *Commands) SucceedIDPIntent(ctx context.Context, writeModel *IDPIntentWriteModel, idpUser idp.User, userID string) (string, error) {
+	token, err := c.idpIntentToken(writeModel.AggregateID)
+	if err != nil {
+		return "", err
+	}
+	idpInfo, err := json.Marshal(idpUser)
+	if err != nil {
+		return "", caos_errs.ThrowInternal(err, "COMMAND-Bsv2f", "Errors.Intent.IDPUserInvalid")
+	}
+	_, err = c.eventstore.Push(ctx, idpintent.NewSucceededEvent(ctx, writeModel.aggregate, idpInfo, idpUser.GetID(), idpUser.GetPreferredUsername(), userID))
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (c *Commands) FailIDPIntent(ctx context.Context, writeModel *IDPIntentWriteModel, reason string) error {
+	_, err := c.eventstore.Push(ctx, idpintent.NewFailedEvent(ctx, writeModel.aggregate, reason))
+	return err
+}
+
+func (c *Commands) GetIntentWriteModel(ctx context.Context, id, resourceOwner string) (*IDPIntentWriteModel, error) {
+	writeModel := NewIDPIntentWriteModel(id, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Hk2rd", "Errors.Intent.NotFound")
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) idpIntentToken(intentID string) (string, error) {
+	token, err := c.idpConfigEncryption.Encrypt([]byte(intentID))
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+func (c *Commands) verifyIDPIntentToken(intentID, token string) error {
+	decodedToken, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return caos_errs.ThrowPermissionDenied(err, "COMMAND-Df3gq", "Errors.Intent.InvalidToken")
+	}
+	var decryptedID string
+	for _, keyID := range c.idpConfigEncryption.DecryptionKeyIDs() {
+		decryptedID, err = c.idpConfigEncryption.DecryptString(decodedToken, keyID)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil || decryptedID != intentID {
+		return caos_errs.ThrowPermissionDenied(err, "COMMAND-Df3gr", "Errors.Intent.InvalidToken")
+	}
+	return nil
+}
+
+func validateIntentURL(rawURL string) error {
+	if rawURL == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Dfg3a", "Errors.Intent.URLMissing")
+	}
+	_, err := url.ParseRequestURI(rawURL)
+	return err
+}
@@ -0,0 +1,86 @@
+package command
+
+import (
+	"net/url"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idpintent"
+)
+
+type IDPIntentWriteModel struct {
+	eventstore.WriteModel
+
+	SuccessURL  *url.URL
+	FailureURL  *url.URL
+	IDPID       string
+	IDPUser     []byte
+	IDPUserID   string
+	IDPUserName string
+	UserID      string
+
+	State domain.IDPIntentState
+
+	aggregate *eventstore.Aggregate
+}
+
+func NewIDPIntentWriteModel(id, resourceOwner string) *IDPIntentWriteModel {
+	return &IDPIntentWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+		aggregate: &idpintent.NewAggregate(id, resourceOwner).Aggregate,
+	}
+}
+
+func (wm *IDPIntentWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idpintent.StartedEvent:
+			wm.reduceStartedEvent(e)
+		case *idpintent.SucceededEvent:
+			wm.reduceSucceededEvent(e)
+		case *idpintent.FailedEvent:
+			wm.reduceFailedEvent(e)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *IDPIntentWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(idpintent.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			idpintent.StartedEventType,
+			idpintent.SucceededEventType,
+			idpintent.FailedEventType,
+		).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+func (wm *IDPIntentWriteModel) reduceStartedEvent(e *idpintent.StartedEvent) {
+	wm.SuccessURL, _ = url.Parse(e.SuccessURL)
+	wm.FailureURL, _ = url.Parse(e.FailureURL)
+	wm.IDPID = e.IDPID
+	wm.State = domain.IDPIntentStateStarted
+}
+
+func (wm *IDPIntentWriteModel) reduceSucceededEvent(e *idpintent.SucceededEvent) {
+	wm.UserID = e.UserID
+	wm.IDPUser = e.IDPUser
+	wm.IDPUserID = e.IDPUserID
+	wm.IDPUserName = e.IDPUserName
+	wm.State = domain.IDPIntentStateSucceeded
+}
+
+func (wm *IDPIntentWriteModel) reduceFailedEvent(e *idpintent.FailedEvent) {
+	wm.State = domain.IDPIntentStateFailed
+}
@@ -0,0 +1,198 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/repository/idpintent"
+)
+
+func TestCommands_CreateIntent(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		idpID         string
+		successURL    string
+		failureURL    string
+		resourceOwner string
+	}
+	type res struct {
+		err error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"missing idpID",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:        context.Background(),
+				successURL: "https://success.url",
+				failureURL: "https://failure.url",
+			},
+			res{
+				err: caos_errs.ThrowInvalidArgument(nil, "COMMAND-x8j2bk", "Errors.Intent.IDPMissing"),
+			},
+		},
+		{
+			"invalid success url",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:        context.Background(),
+				idpID:      "idp",
+				successURL: "success",
+				failureURL: "https://failure.url",
+			},
+			res{
+				err: caos_errs.ThrowInvalidArgument(nil, "COMMAND-x8j3bk", "Errors.Intent.SuccessURLMissing"),
+			},
+		},
+		{
+			"invalid failure url",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:        context.Background(),
+				idpID:      "idp",
+				successURL: "https://success.url",
+			},
+			res{
+				err: caos_errs.ThrowInvalidArgument(nil, "COMMAND-x8j4bk", "Errors.Intent.FailureURLMissing"),
+			},
+		},
+		{
+			"idp not existing",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance"),
+				idpID:         "idp",
+				successURL:    "https://success.url",
+				failureURL:    "https://failure.url",
+				resourceOwner: "org1",
+			},
+			res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-39n221fs", "Errors.IDPConfig.NotExisting"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			_, _, err := c.CreateIntent(tt.args.ctx, tt.args.idpID, tt.args.successURL, tt.args.failureURL, tt.args.resourceOwner)
+			require.ErrorIs(t, err, tt.res.err)
+		})
+	}
+}
+
+func TestCommands_GetIntentWriteModel(t *testing.T) {
+	tests := []struct {
+		name       string
+		eventstore *eventstore.Eventstore
+		wantState  domain.IDPIntentState
+		wantErr    error
+	}{
+		{
+			"not found",
+			eventstoreExpect(t,
+				expectFilter(),
+			),
+			domain.IDPIntentStateUnspecified,
+			caos_errs.ThrowNotFound(nil, "COMMAND-Hk2rd", "Errors.Intent.NotFound"),
+		},
+		{
+			"started",
+			eventstoreExpect(t,
+				expectFilter(
+					eventFromEventPusher(
+						idpintent.NewStartedEvent(context.Background(),
+							&idpintent.NewAggregate("intent", "org1").Aggregate,
+							"https://success.url",
+							"https://failure.url",
+							"idp",
+						),
+					),
+				),
+			),
+			domain.IDPIntentStateStarted,
+			nil,
+		},
+		{
+			"succeeded",
+			eventstoreExpect(t,
+				expectFilter(
+					eventFromEventPusher(
+						idpintent.NewStartedEvent(context.Background(),
+							&idpintent.NewAggregate("intent", "org1").Aggregate,
+							"https://success.url",
+							"https://failure.url",
+							"idp",
+						),
+					),
+					eventFromEventPusher(
+						idpintent.NewSucceededEvent(context.Background(),
+							&idpintent.NewAggregate("intent", "org1").Aggregate,
+							[]byte(`{"id":"idpUser"}`),
+							"idpUser",
+							"username",
+							"user",
+						),
+					),
+				),
+			),
+			domain.IDPIntentStateSucceeded,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore,
+			}
+			got, err := c.GetIntentWriteModel(context.Background(), "intent", "")
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.wantState, got.State)
+			}
+		})
+	}
+}
+
+func TestCommands_verifyIDPIntentToken(t *testing.T) {
+	c := &Commands{
+		idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+	}
+	token, err := c.idpIntentToken("intent")
+	require.NoError(t, err)
+	assert.NoError(t, c.verifyIDPIntentToken("intent", token))
+	assert.Error(t, c.verifyIDPIntentToken("other", token))
+	assert.Error(t, c.verifyIDPIntentToken("intent", "invalid"))
+}
@@ -75,7 +75,10 @@ type InstanceSetup struct {
 		AllowDomainDiscovery       bool
 		DisableLoginWithEmail      bool
 		DisableLoginWithPhone      bool
+		AllowMagicLink             bool
+		AllowPhoneOTP              bool
 		PasswordlessType           domain.PasswordlessType
+		BotProtection              domain.BotProtectionType
 		DefaultRedirectURI         string
 		PasswordCheckLifetime      time.Duration
 		ExternalLoginCheckLifetime time.Duration
@@ -83,10 +86,8 @@ type InstanceSetup struct {
 		SecondFactorCheckLifetime  time.Duration
 		MultiFactorCheckLifetime   time.Duration
 	}
-	NotificationPolicy struct {
-		PasswordChange bool
-	}
-	PrivacyPolicy struct {
+	NotificationPolicy NotificationPolicy
+	PrivacyPolicy      struct {
 		TOSLink      string
 		PrivacyLink  string
 		HelpLink     string
@@ -107,7 +108,9 @@ type InstanceSetup struct {
 	}
 	LockoutPolicy struct {
 		MaxAttempts              uint64
+		MaxOTPAttempts           uint64
 		ShouldShowLockoutFailure bool
+		LockoutDuration          time.Duration
 	}
 	EmailTemplate     []byte
 	MessageTexts      []*domain.CustomMessageText
@@ -231,7 +234,10 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 			setup.LoginPolicy.AllowDomainDiscovery,
 			setup.LoginPolicy.DisableLoginWithEmail,
 			setup.LoginPolicy.DisableLoginWithPhone,
+			setup.LoginPolicy.AllowMagicLink,
+			setup.LoginPolicy.AllowPhoneOTP,
 			setup.LoginPolicy.PasswordlessType,
+			setup.LoginPolicy.BotProtection,
 			setup.LoginPolicy.DefaultRedirectURI,
 			setup.LoginPolicy.PasswordCheckLifetime,
 			setup.LoginPolicy.ExternalLoginCheckLifetime,
@@ -244,8 +250,8 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 		prepareAddMultiFactorToDefaultLoginPolicy(instanceAgg, domain.MultiFactorTypeU2FWithPIN),
 
 		prepareAddDefaultPrivacyPolicy(instanceAgg, setup.PrivacyPolicy.TOSLink, setup.PrivacyPolicy.PrivacyLink, setup.PrivacyPolicy.HelpLink, setup.PrivacyPolicy.SupportEmail),
-		prepareAddDefaultNotificationPolicy(instanceAgg, setup.NotificationPolicy.PasswordChange),
-		prepareAddDefaultLockoutPolicy(instanceAgg, setup.LockoutPolicy.MaxAttempts, setup.LockoutPolicy.ShouldShowLockoutFailure),
+		prepareAddDefaultNotificationPolicy(instanceAgg, &setup.NotificationPolicy),
+		prepareAddDefaultLockoutPolicy(instanceAgg, setup.LockoutPolicy.MaxAttempts, setup.LockoutPolicy.MaxOTPAttempts, setup.LockoutPolicy.ShouldShowLockoutFailure, setup.LockoutPolicy.LockoutDuration),
 
 		prepareAddDefaultLabelPolicy(
 			instanceAgg,
@@ -400,12 +406,11 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 		validations = append(validations,
 			c.prepareAddSMTPConfig(
 				instanceAgg,
-				setup.SMTPConfiguration.From,
-				setup.SMTPConfiguration.FromName,
-				setup.SMTPConfiguration.SMTP.Host,
-				setup.SMTPConfiguration.SMTP.User,
-				[]byte(setup.SMTPConfiguration.SMTP.Password),
-				setup.SMTPConfiguration.Tls,
+				instanceID,
+				"",
+				"",
+				0,
+				setup.SMTPConfiguration,
 			),
 		)
 	}
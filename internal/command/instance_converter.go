@@ -34,6 +34,9 @@ func writeModelToLoginPolicy(wm *LoginPolicyWriteModel) *domain.LoginPolicy {
 		IgnoreUnknownUsernames:     wm.IgnoreUnknownUsernames,
 		AllowDomainDiscovery:       wm.AllowDomainDiscovery,
 		ForceMFA:                   wm.ForceMFA,
+		AllowMagicLink:             wm.AllowMagicLink,
+		AllowPhoneOTP:              wm.AllowPhoneOTP,
+		BotProtection:              wm.BotProtection,
 		PasswordlessType:           wm.PasswordlessType,
 		DefaultRedirectURI:         wm.DefaultRedirectURI,
 		PasswordCheckLifetime:      wm.PasswordCheckLifetime,
@@ -117,7 +120,9 @@ func writeModelToLockoutPolicy(wm *LockoutPolicyWriteModel) *domain.LockoutPolic
 	return &domain.LockoutPolicy{
 		ObjectRoot:          writeModelToObjectRoot(wm.WriteModel),
 		MaxPasswordAttempts: wm.MaxPasswordAttempts,
+		MaxOTPAttempts:      wm.MaxOTPAttempts,
 		ShowLockOutFailures: wm.ShowLockOutFailures,
+		LockoutDuration:     wm.LockoutDuration,
 	}
 }
 
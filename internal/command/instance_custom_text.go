@@ -59,3 +59,51 @@ func (c *Commands) defaultCustomTextWriteModelByID(ctx context.Context, key stri
 	}
 	return writeModel, nil
 }
+
+// ImportInstanceCustomTexts sets the texts (e.g. of a translation file) as custom texts of the instance in the language,
+// texts which are already set on the instance are skipped
+func (c *Commands) ImportInstanceCustomTexts(ctx context.Context, lang language.Tag, texts []*domain.CustomText) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if err := validateImportedCustomTexts(lang, texts); err != nil {
+		return nil, err
+	}
+	existingTexts := NewInstanceCustomTextsWriteModel(ctx, lang)
+	err = c.eventstore.FilterToQueryReducer(ctx, existingTexts)
+	if err != nil {
+		return nil, err
+	}
+	instanceAgg := InstanceAggregateFromWriteModel(&existingTexts.WriteModel)
+	events := make([]eventstore.Command, 0, len(texts))
+	for _, text := range texts {
+		if existingTexts.Texts[domain.CustomTextID(text.Template, text.Key)] == text.Text {
+			continue
+		}
+		events = append(events, instance.NewCustomTextSetEvent(ctx, instanceAgg, text.Template, text.Key, text.Text, lang))
+	}
+	if len(events) == 0 {
+		return writeModelToObjectDetails(&existingTexts.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingTexts, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingTexts.WriteModel), nil
+}
+
+func validateImportedCustomTexts(lang language.Tag, texts []*domain.CustomText) error {
+	if lang == language.Und {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ct8im", "Errors.CustomText.Invalid")
+	}
+	for _, text := range texts {
+		if text.Template == "" || text.Language != lang || !text.IsValid() {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ct9im", "Errors.CustomText.Invalid")
+		}
+	}
+	return nil
+}
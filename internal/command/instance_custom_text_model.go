@@ -50,3 +50,50 @@ func (wm *InstanceCustomTextWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.CustomTextSetEventType).
 		Builder()
 }
+
+type InstanceCustomTextsWriteModel struct {
+	CustomTextsWriteModel
+}
+
+func NewInstanceCustomTextsWriteModel(ctx context.Context, language language.Tag) *InstanceCustomTextsWriteModel {
+	return &InstanceCustomTextsWriteModel{
+		CustomTextsWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   authz.GetInstance(ctx).InstanceID(),
+				ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+			},
+			Language: language,
+			Texts:    make(map[string]string),
+		},
+	}
+}
+
+func (wm *InstanceCustomTextsWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.CustomTextSetEvent:
+			wm.CustomTextsWriteModel.AppendEvents(&e.CustomTextSetEvent)
+		case *instance.CustomTextRemovedEvent:
+			wm.CustomTextsWriteModel.AppendEvents(&e.CustomTextRemovedEvent)
+		case *instance.CustomTextTemplateRemovedEvent:
+			wm.CustomTextsWriteModel.AppendEvents(&e.CustomTextTemplateRemovedEvent)
+		}
+	}
+}
+
+func (wm *InstanceCustomTextsWriteModel) Reduce() error {
+	return wm.CustomTextsWriteModel.Reduce()
+}
+
+func (wm *InstanceCustomTextsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateIDs(wm.CustomTextsWriteModel.AggregateID).
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.CustomTextSetEventType,
+			instance.CustomTextRemovedEventType,
+			instance.CustomTextTemplateRemovedEventType).
+		Builder()
+}
@@ -0,0 +1,145 @@
+package command
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+const notificationWebhookSigningKeyMinLength = 32
+
+// AddNotificationWebhookProvider adds the webhook notification provider of the instance,
+// all user notifications are sent to the webhook instead of the smtp and sms providers
+func (c *Commands) AddNotificationWebhookProvider(ctx context.Context, config *webhook.Config) (*domain.ObjectDetails, error) {
+	if config.Method == "" {
+		config.Method = http.MethodPost
+	}
+	if err := validateNotificationWebhook(config); err != nil {
+		return nil, err
+	}
+	if len(config.SigningKey) < notificationWebhookSigningKeyMinLength {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Wh4k1", "Errors.IAM.NotificationWebhookProvider.InvalidSigningKey")
+	}
+	writeModel := NewInstanceNotificationWebhookWriteModel(ctx)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State.Exists() {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Wh4e1", "Errors.IAM.NotificationWebhookProvider.AlreadyExists")
+	}
+	signingKey, err := crypto.Encrypt([]byte(config.SigningKey), c.smsEncryption)
+	if err != nil {
+		return nil, err
+	}
+	instanceAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewNotificationWebhookProviderAddedEvent(
+		ctx,
+		instanceAgg,
+		config.CallURL,
+		config.Method,
+		config.Headers,
+		signingKey))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// ChangeNotificationWebhookProvider changes the webhook notification provider of the instance,
+// the signing key is only changed if one is provided
+func (c *Commands) ChangeNotificationWebhookProvider(ctx context.Context, config *webhook.Config) (*domain.ObjectDetails, error) {
+	if config.Method == "" {
+		config.Method = http.MethodPost
+	}
+	if err := validateNotificationWebhook(config); err != nil {
+		return nil, err
+	}
+	if config.SigningKey != "" && len(config.SigningKey) < notificationWebhookSigningKeyMinLength {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Wh4k2", "Errors.IAM.NotificationWebhookProvider.InvalidSigningKey")
+	}
+	writeModel := NewInstanceNotificationWebhookWriteModel(ctx)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Wh4n1", "Errors.IAM.NotificationWebhookProvider.NotFound")
+	}
+	var signingKey *crypto.CryptoValue
+	if config.SigningKey != "" {
+		signingKey, err = crypto.Encrypt([]byte(config.SigningKey), c.smsEncryption)
+		if err != nil {
+			return nil, err
+		}
+	}
+	instanceAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	changedEvent, hasChanged, err := writeModel.NewChangedEvent(
+		ctx,
+		instanceAgg,
+		config.CallURL,
+		config.Method,
+		config.Headers,
+		signingKey)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Wh4c1", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RemoveNotificationWebhookProvider removes the webhook notification provider of the instance,
+// user notifications are sent through the smtp and sms providers again
+func (c *Commands) RemoveNotificationWebhookProvider(ctx context.Context) (*domain.ObjectDetails, error) {
+	writeModel := NewInstanceNotificationWebhookWriteModel(ctx)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Wh4n2", "Errors.IAM.NotificationWebhookProvider.NotFound")
+	}
+	instanceAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewNotificationWebhookProviderRemovedEvent(ctx, instanceAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func validateNotificationWebhook(config *webhook.Config) error {
+	callURL, err := url.Parse(config.CallURL)
+	if err != nil || (callURL.Scheme != "http" && callURL.Scheme != "https") || callURL.Host == "" {
+		return caos_errs.ThrowInvalidArgument(err, "COMMAND-Wh4u1", "Errors.IAM.NotificationWebhookProvider.InvalidCallURL")
+	}
+	switch config.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Wh4m1", "Errors.IAM.NotificationWebhookProvider.InvalidMethod")
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceNotificationWebhookWriteModel struct {
+	eventstore.WriteModel
+
+	CallURL    string
+	Method     string
+	Headers    map[string]string
+	SigningKey *crypto.CryptoValue
+	State      domain.NotificationProviderState
+}
+
+func NewInstanceNotificationWebhookWriteModel(ctx context.Context) *InstanceNotificationWebhookWriteModel {
+	return &InstanceNotificationWebhookWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   authz.GetInstance(ctx).InstanceID(),
+			ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		},
+	}
+}
+
+func (wm *InstanceNotificationWebhookWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.NotificationWebhookProviderAddedEvent:
+			wm.CallURL = e.CallURL
+			wm.Method = e.Method
+			wm.Headers = e.Headers
+			wm.SigningKey = e.SigningKey
+			wm.State = domain.NotificationProviderStateActive
+		case *instance.NotificationWebhookProviderChangedEvent:
+			if e.CallURL != nil {
+				wm.CallURL = *e.CallURL
+			}
+			if e.Method != nil {
+				wm.Method = *e.Method
+			}
+			if e.Headers != nil {
+				wm.Headers = e.Headers
+			}
+			if e.SigningKey != nil {
+				wm.SigningKey = e.SigningKey
+			}
+		case *instance.NotificationWebhookProviderRemovedEvent:
+			wm.CallURL = ""
+			wm.Method = ""
+			wm.Headers = nil
+			wm.SigningKey = nil
+			wm.State = domain.NotificationProviderStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InstanceNotificationWebhookWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.NotificationWebhookProviderAddedEventType,
+			instance.NotificationWebhookProviderChangedEventType,
+			instance.NotificationWebhookProviderRemovedEventType).
+		Builder()
+}
+
+func (wm *InstanceNotificationWebhookWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	callURL,
+	method string,
+	headers map[string]string,
+	signingKey *crypto.CryptoValue,
+) (*instance.NotificationWebhookProviderChangedEvent, bool, error) {
+	changes := make([]instance.NotificationWebhookProviderChanges, 0)
+
+	if wm.CallURL != callURL {
+		changes = append(changes, instance.ChangeNotificationWebhookProviderCallURL(callURL))
+	}
+	if wm.Method != method {
+		changes = append(changes, instance.ChangeNotificationWebhookProviderMethod(method))
+	}
+	if !headersEqual(wm.Headers, headers) {
+		changes = append(changes, instance.ChangeNotificationWebhookProviderHeaders(headers))
+	}
+	if signingKey != nil {
+		changes = append(changes, instance.ChangeNotificationWebhookProviderSigningKey(signingKey))
+	}
+
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewNotificationWebhookProviderChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
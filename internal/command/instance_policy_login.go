@@ -221,6 +221,9 @@ func prepareChangeDefaultLoginPolicy(a *instance.Aggregate, policy *ChangeLoginP
 		if ok := domain.ValidateDefaultRedirectURI(policy.DefaultRedirectURI); !ok {
 			return nil, caos_errs.ThrowInvalidArgument(nil, "IAM-SFdqd", "Errors.IAM.LoginPolicy.RedirectURIInvalid")
 		}
+		if !policy.BotProtection.Valid() {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "IAM-Wc7sn", "Errors.IAM.LoginPolicy.BotProtectionInvalid")
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			wm := NewInstanceLoginPolicyWriteModel(ctx)
 			if err := queryAndReduce(ctx, filter, wm); err != nil {
@@ -239,7 +242,10 @@ func prepareChangeDefaultLoginPolicy(a *instance.Aggregate, policy *ChangeLoginP
 				policy.AllowDomainDiscovery,
 				policy.DisableLoginWithEmail,
 				policy.DisableLoginWithPhone,
+				policy.AllowMagicLink,
+				policy.AllowPhoneOTP,
 				policy.PasswordlessType,
+				policy.BotProtection,
 				policy.DefaultRedirectURI,
 				policy.PasswordCheckLifetime,
 				policy.ExternalLoginCheckLifetime,
@@ -265,7 +271,10 @@ func prepareAddDefaultLoginPolicy(
 	allowDomainDiscovery bool,
 	disableLoginWithEmail bool,
 	disableLoginWithPhone bool,
+	allowMagicLink,
+	allowPhoneOTP bool,
 	passwordlessType domain.PasswordlessType,
+	botProtection domain.BotProtectionType,
 	defaultRedirectURI string,
 	passwordCheckLifetime time.Duration,
 	externalLoginCheckLifetime time.Duration,
@@ -298,7 +307,10 @@ func prepareAddDefaultLoginPolicy(
 					allowDomainDiscovery,
 					disableLoginWithEmail,
 					disableLoginWithPhone,
+					allowMagicLink,
+					allowPhoneOTP,
 					passwordlessType,
+					botProtection,
 					defaultRedirectURI,
 					passwordCheckLifetime,
 					externalLoginCheckLifetime,
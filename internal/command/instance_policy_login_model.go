@@ -70,7 +70,10 @@ func (wm *InstanceLoginPolicyWriteModel) NewChangedEvent(
 	allowDomainDiscovery,
 	disableLoginWithEmail,
 	disableLoginWithPhone bool,
+	allowMagicLink,
+	allowPhoneOTP bool,
 	passwordlessType domain.PasswordlessType,
+	botProtection domain.BotProtectionType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
 	externalLoginCheckLifetime,
@@ -128,6 +131,15 @@ func (wm *InstanceLoginPolicyWriteModel) NewChangedEvent(
 	if wm.DisableLoginWithPhone != disableLoginWithPhone {
 		changes = append(changes, policy.ChangeDisableLoginWithPhone(disableLoginWithPhone))
 	}
+	if wm.AllowMagicLink != allowMagicLink {
+		changes = append(changes, policy.ChangeAllowMagicLink(allowMagicLink))
+	}
+	if wm.AllowPhoneOTP != allowPhoneOTP {
+		changes = append(changes, policy.ChangeAllowPhoneOTP(allowPhoneOTP))
+	}
+	if wm.BotProtection != botProtection {
+		changes = append(changes, policy.ChangeBotProtection(botProtection))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
@@ -73,7 +73,10 @@ func TestCommandSide_ChangeDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"https://example.com/redirect",
 								time.Hour*1,
 								time.Hour*2,
@@ -129,7 +132,10 @@ func TestCommandSide_ChangeDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"https://example.com/redirect",
 								time.Hour*1,
 								time.Hour*2,
@@ -281,7 +287,10 @@ func TestCommandSide_AddIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -322,7 +331,10 @@ func TestCommandSide_AddIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -383,7 +395,10 @@ func TestCommandSide_AddIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -526,7 +541,10 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -567,7 +585,10 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -621,7 +642,10 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -680,7 +704,10 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -747,7 +774,10 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -19,9 +19,9 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		resourceOwner  string
-		passwordChange bool
+		ctx           context.Context
+		resourceOwner string
+		policy        *NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -43,15 +43,22 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				policy: &NotificationPolicy{
+					PasswordChange: true,
+				},
 			},
 			res: res{
 				err: caos_errs.IsErrorAlreadyExists,
@@ -69,6 +76,11 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 								instance.NewNotificationPolicyAddedEvent(context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
 									true,
+									false,
+									false,
+									false,
+									false,
+									false,
 								),
 							),
 						},
@@ -76,9 +88,11 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				policy: &NotificationPolicy{
+					PasswordChange: true,
+				},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -98,6 +112,11 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 								instance.NewNotificationPolicyAddedEvent(context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
 									true,
+									false,
+									false,
+									false,
+									false,
+									false,
 								),
 							),
 						},
@@ -105,9 +124,11 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				policy: &NotificationPolicy{
+					PasswordChange: true,
+				},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -121,7 +142,7 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange)
+			got, err := r.AddDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.policy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -140,9 +161,9 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		resourceOwner  string
-		passwordChange bool
+		ctx           context.Context
+		resourceOwner string
+		policy        *NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -163,9 +184,11 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				policy: &NotificationPolicy{
+					PasswordChange: true,
+				},
 			},
 			res: res{
 				err: caos_errs.IsNotFound,
@@ -181,15 +204,22 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				policy: &NotificationPolicy{
+					PasswordChange: true,
+				},
 			},
 			res: res{
 				err: caos_errs.IsPreconditionFailed,
@@ -205,6 +235,11 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
@@ -219,9 +254,11 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				policy: &NotificationPolicy{
+					PasswordChange: true,
+				},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -235,7 +272,7 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.ChangeDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange)
+			got, err := r.ChangeDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.policy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -12,12 +12,20 @@ import (
 type InstanceSMTPConfigWriteModel struct {
 	eventstore.WriteModel
 
+	ID            string
+	OrgID         string
+	Description   string
+	Priority      uint32
 	SenderAddress string
 	SenderName    string
 	TLS           bool
 	Host          string
 	User          string
 	Password      *crypto.CryptoValue
+	TLSMode       domain.SMTPTLSMode
+	AuthType      domain.SMTPAuthType
+	XOAuth2       *instance.SMTPXOAuth2
+	CACertificate *crypto.CryptoValue
 	State         domain.SMTPConfigState
 
 	domain                                 string
@@ -25,12 +33,13 @@ type InstanceSMTPConfigWriteModel struct {
 	smtpSenderAddressMatchesInstanceDomain bool
 }
 
-func NewInstanceSMTPConfigWriteModel(instanceID, domain string) *InstanceSMTPConfigWriteModel {
+func NewInstanceSMTPConfigWriteModel(instanceID, id, domain string) *InstanceSMTPConfigWriteModel {
 	return &InstanceSMTPConfigWriteModel{
 		WriteModel: eventstore.WriteModel{
 			AggregateID:   instanceID,
 			ResourceOwner: instanceID,
 		},
+		ID:     id,
 		domain: domain,
 	}
 }
@@ -48,6 +57,31 @@ func (wm *InstanceSMTPConfigWriteModel) AppendEvents(events ...eventstore.Event)
 				continue
 			}
 			wm.WriteModel.AppendEvents(e)
+		case *instance.SMTPConfigAddedEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *instance.SMTPConfigChangedEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *instance.SMTPConfigPasswordChangedEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *instance.SMTPConfigRemovedEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *instance.SMTPConfigCACertificateChangedEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
 		default:
 			wm.WriteModel.AppendEvents(e)
 		}
@@ -59,14 +93,36 @@ func (wm *InstanceSMTPConfigWriteModel) Reduce() error {
 	for _, event := range wm.Events {
 		switch e := event.(type) {
 		case *instance.SMTPConfigAddedEvent:
+			wm.OrgID = e.OrgID
+			wm.Description = e.Description
+			wm.Priority = e.Priority
 			wm.TLS = e.TLS
 			wm.SenderAddress = e.SenderAddress
 			wm.SenderName = e.SenderName
 			wm.Host = e.Host
 			wm.User = e.User
 			wm.Password = e.Password
+			wm.TLSMode = e.TLSMode
+			wm.AuthType = e.AuthType
+			wm.XOAuth2 = e.XOAuth2
+			wm.CACertificate = e.CACertificate
 			wm.State = domain.SMTPConfigStateActive
 		case *instance.SMTPConfigChangedEvent:
+			if e.Description != nil {
+				wm.Description = *e.Description
+			}
+			if e.Priority != nil {
+				wm.Priority = *e.Priority
+			}
+			if e.TLSMode != nil {
+				wm.TLSMode = *e.TLSMode
+			}
+			if e.AuthType != nil {
+				wm.AuthType = *e.AuthType
+			}
+			if e.XOAuth2 != nil {
+				wm.XOAuth2 = e.XOAuth2
+			}
 			if e.TLS != nil {
 				wm.TLS = *e.TLS
 			}
@@ -82,14 +138,25 @@ func (wm *InstanceSMTPConfigWriteModel) Reduce() error {
 			if e.User != nil {
 				wm.User = *e.User
 			}
+		case *instance.SMTPConfigPasswordChangedEvent:
+			wm.Password = e.Password
+		case *instance.SMTPConfigCACertificateChangedEvent:
+			wm.CACertificate = e.CACertificate
 		case *instance.SMTPConfigRemovedEvent:
 			wm.State = domain.SMTPConfigStateRemoved
+			wm.OrgID = ""
+			wm.Description = ""
+			wm.Priority = 0
 			wm.TLS = false
 			wm.SenderName = ""
 			wm.SenderAddress = ""
 			wm.Host = ""
 			wm.User = ""
 			wm.Password = nil
+			wm.TLSMode = domain.SMTPTLSModeUnspecified
+			wm.AuthType = domain.SMTPAuthTypeUnspecified
+			wm.XOAuth2 = nil
+			wm.CACertificate = nil
 		case *instance.DomainAddedEvent:
 			wm.domainState = domain.InstanceDomainStateActive
 		case *instance.DomainRemovedEvent:
@@ -115,6 +182,8 @@ func (wm *InstanceSMTPConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.SMTPConfigAddedEventType,
 			instance.SMTPConfigChangedEventType,
 			instance.SMTPConfigPasswordChangedEventType,
+			instance.SMTPConfigRemovedEventType,
+			instance.SMTPConfigCACertificateChangedEventType,
 			instance.InstanceDomainAddedEventType,
 			instance.InstanceDomainRemovedEventType,
 			instance.DomainPolicyAddedEventType,
@@ -122,13 +191,33 @@ func (wm *InstanceSMTPConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 		Builder()
 }
 
-func (wm *InstanceSMTPConfigWriteModel) NewChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, tls bool, fromAddress, fromName, smtpHost, smtpUser string) (*instance.SMTPConfigChangedEvent, bool, error) {
+// NewChangedEvent compares the passed values with the current state,
+// xoauth2 is only compared if set, a client secret which is set is always considered a change
+func (wm *InstanceSMTPConfigWriteModel) NewChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, description string, priority uint32, tls bool, tlsMode domain.SMTPTLSMode, authType domain.SMTPAuthType, fromAddress, fromName, smtpHost, smtpUser string, xoauth2 *instance.SMTPXOAuth2) (*instance.SMTPConfigChangedEvent, bool, error) {
 	changes := make([]instance.SMTPConfigChanges, 0)
 	var err error
 
+	if wm.Description != description {
+		changes = append(changes, instance.ChangeSMTPConfigDescription(description))
+	}
+	if wm.Priority != priority {
+		changes = append(changes, instance.ChangeSMTPConfigPriority(priority))
+	}
 	if wm.TLS != tls {
 		changes = append(changes, instance.ChangeSMTPConfigTLS(tls))
 	}
+	if wm.TLSMode != tlsMode {
+		changes = append(changes, instance.ChangeSMTPConfigTLSMode(tlsMode))
+	}
+	if wm.AuthType != authType {
+		changes = append(changes, instance.ChangeSMTPConfigAuthType(authType))
+	}
+	if xoauth2 != nil && wm.xoauth2Changed(xoauth2) {
+		if xoauth2.ClientSecret == nil && wm.XOAuth2 != nil {
+			xoauth2.ClientSecret = wm.XOAuth2.ClientSecret
+		}
+		changes = append(changes, instance.ChangeSMTPConfigXOAuth2(xoauth2))
+	}
 	if wm.SenderAddress != fromAddress {
 		changes = append(changes, instance.ChangeSMTPConfigFromAddress(fromAddress))
 	}
@@ -145,9 +234,24 @@ func (wm *InstanceSMTPConfigWriteModel) NewChangedEvent(ctx context.Context, agg
 	if len(changes) == 0 {
 		return nil, false, nil
 	}
-	changeEvent, err := instance.NewSMTPConfigChangeEvent(ctx, aggregate, changes)
+	changeEvent, err := instance.NewSMTPConfigChangeEvent(ctx, aggregate, wm.ID, changes)
 	if err != nil {
 		return nil, false, err
 	}
 	return changeEvent, true, nil
 }
+
+func (wm *InstanceSMTPConfigWriteModel) xoauth2Changed(xoauth2 *instance.SMTPXOAuth2) bool {
+	if wm.XOAuth2 == nil || xoauth2.ClientSecret != nil {
+		return true
+	}
+	if wm.XOAuth2.TokenEndpoint != xoauth2.TokenEndpoint || wm.XOAuth2.ClientID != xoauth2.ClientID || len(wm.XOAuth2.Scopes) != len(xoauth2.Scopes) {
+		return true
+	}
+	for i, scope := range wm.XOAuth2.Scopes {
+		if xoauth2.Scopes[i] != scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package command
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+// loginThrottle slows down failed authentication checks per IP and per instance.
+// After the threshold of failures inside the window is reached, each further failure
+// doubles the delay until the next check is allowed, up to the max delay.
+// The counters are kept in memory of the running process.
+type loginThrottle struct {
+	config sd.LoginThrottling
+	now    func() time.Time
+
+	mu          sync.Mutex
+	entries     map[string]*throttleEntry
+	lastCleanup time.Time
+}
+
+type throttleEntry struct {
+	failures     uint32
+	lastFailure  time.Time
+	blockedUntil time.Time
+}
+
+func newLoginThrottle(config sd.LoginThrottling) *loginThrottle {
+	return &loginThrottle{
+		config:  config,
+		now:     time.Now,
+		entries: make(map[string]*throttleEntry),
+	}
+}
+
+func (t *loginThrottle) enabled() bool {
+	return t != nil && (t.config.IPFailureThreshold > 0 || t.config.InstanceFailureThreshold > 0)
+}
+
+// check returns an error if checks from the IP or on the instance are currently throttled
+func (t *loginThrottle) check(instanceID string, ip net.IP) error {
+	if !t.enabled() {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	for _, key := range t.keys(instanceID, ip) {
+		if entry, ok := t.entries[key]; ok && now.Before(entry.blockedUntil) {
+			return caos_errs.ThrowResourceExhausted(nil, "COMMAND-Tr7ok", "Errors.User.Lockout.Throttled")
+		}
+	}
+	return nil
+}
+
+// failed counts the failed check and computes the delay of the next allowed check
+func (t *loginThrottle) failed(instanceID string, ip net.IP) {
+	if !t.enabled() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.cleanup(now)
+	if ip != nil && t.config.IPFailureThreshold > 0 {
+		t.fail(ipThrottleKey(instanceID, ip), t.config.IPFailureThreshold, now)
+	}
+	if t.config.InstanceFailureThreshold > 0 {
+		t.fail(instanceThrottleKey(instanceID), t.config.InstanceFailureThreshold, now)
+	}
+}
+
+// succeeded resets the failures of the IP, the failures of the instance expire with the window
+func (t *loginThrottle) succeeded(instanceID string, ip net.IP) {
+	if !t.enabled() || ip == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, ipThrottleKey(instanceID, ip))
+}
+
+func (t *loginThrottle) fail(key string, threshold uint32, now time.Time) {
+	entry, ok := t.entries[key]
+	if !ok || now.Sub(entry.lastFailure) > t.config.Window {
+		entry = new(throttleEntry)
+		t.entries[key] = entry
+	}
+	entry.failures++
+	entry.lastFailure = now
+	if entry.failures < threshold {
+		return
+	}
+	entry.blockedUntil = now.Add(t.delay(entry.failures - threshold))
+}
+
+func (t *loginThrottle) delay(exceeded uint32) time.Duration {
+	maxDelay := t.config.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = time.Hour
+	}
+	delay := t.config.BaseDelay
+	for i := uint32(0); i < exceeded && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// cleanup removes the entries which are neither blocked nor inside the window anymore,
+// it runs at most once per window
+func (t *loginThrottle) cleanup(now time.Time) {
+	if now.Sub(t.lastCleanup) < t.config.Window {
+		return
+	}
+	t.lastCleanup = now
+	for key, entry := range t.entries {
+		if now.Sub(entry.lastFailure) > t.config.Window && now.After(entry.blockedUntil) {
+			delete(t.entries, key)
+		}
+	}
+}
+
+func (t *loginThrottle) keys(instanceID string, ip net.IP) []string {
+	keys := []string{instanceThrottleKey(instanceID)}
+	if ip != nil {
+		keys = append(keys, ipThrottleKey(instanceID, ip))
+	}
+	return keys
+}
+
+func ipThrottleKey(instanceID string, ip net.IP) string {
+	return "ip:" + instanceID + ":" + ip.String()
+}
+
+func instanceThrottleKey(instanceID string) string {
+	return "instance:" + instanceID
+}
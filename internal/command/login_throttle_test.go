@@ -0,0 +1,77 @@
+package command
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func Test_loginThrottle(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	throttle := newLoginThrottle(sd.LoginThrottling{
+		IPFailureThreshold: 2,
+		Window:             time.Minute,
+		BaseDelay:          time.Second,
+		MaxDelay:           3 * time.Second,
+	})
+	throttle.now = func() time.Time { return now }
+	ip := net.ParseIP("192.168.0.1")
+	otherIP := net.ParseIP("192.168.0.2")
+
+	throttle.failed("instance", ip)
+	assert.NoError(t, throttle.check("instance", ip), "below threshold")
+
+	throttle.failed("instance", ip)
+	assert.True(t, caos_errs.IsResourceExhausted(throttle.check("instance", ip)), "threshold reached")
+	assert.NoError(t, throttle.check("instance", otherIP), "other ip")
+	assert.NoError(t, throttle.check("other", ip), "other instance")
+
+	now = now.Add(time.Second)
+	assert.NoError(t, throttle.check("instance", ip), "base delay over")
+
+	throttle.failed("instance", ip)
+	now = now.Add(time.Second)
+	assert.True(t, caos_errs.IsResourceExhausted(throttle.check("instance", ip)), "delay doubled")
+	now = now.Add(time.Second)
+	assert.NoError(t, throttle.check("instance", ip), "doubled delay over")
+
+	throttle.failed("instance", ip)
+	throttle.failed("instance", ip)
+	now = now.Add(3 * time.Second)
+	assert.NoError(t, throttle.check("instance", ip), "max delay")
+
+	throttle.succeeded("instance", ip)
+	throttle.failed("instance", ip)
+	assert.NoError(t, throttle.check("instance", ip), "reset by success")
+
+	now = now.Add(2 * time.Minute)
+	throttle.failed("instance", ip)
+	assert.NoError(t, throttle.check("instance", ip), "failures outside window forgotten")
+}
+
+func Test_loginThrottle_instance(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	throttle := newLoginThrottle(sd.LoginThrottling{
+		InstanceFailureThreshold: 2,
+		Window:                   time.Minute,
+		BaseDelay:                time.Second,
+		MaxDelay:                 time.Minute,
+	})
+	throttle.now = func() time.Time { return now }
+
+	throttle.failed("instance", net.ParseIP("192.168.0.1"))
+	throttle.failed("instance", net.ParseIP("192.168.0.2"))
+	assert.True(t, caos_errs.IsResourceExhausted(throttle.check("instance", net.ParseIP("192.168.0.3"))))
+	assert.NoError(t, throttle.check("other", net.ParseIP("192.168.0.3")))
+}
+
+func Test_loginThrottle_disabled(t *testing.T) {
+	var throttle *loginThrottle
+	throttle.failed("instance", nil)
+	assert.NoError(t, throttle.check("instance", nil))
+}
@@ -10,14 +10,20 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
 	"github.com/zitadel/zitadel/internal/eventstore/repository/mock"
 	action_repo "github.com/zitadel/zitadel/internal/repository/action"
+	group_repo "github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/idpintent"
 	iam_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	key_repo "github.com/zitadel/zitadel/internal/repository/keypair"
+	"github.com/zitadel/zitadel/internal/repository/notification"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	proj_repo "github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/session"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/repository/usergrant"
 )
@@ -37,6 +43,10 @@ func eventstoreExpect(t *testing.T, expects ...expect) *eventstore.Eventstore {
 	usergrant.RegisterEventMappers(es)
 	key_repo.RegisterEventMappers(es)
 	action_repo.RegisterEventMappers(es)
+	group_repo.RegisterEventMappers(es)
+	session.RegisterEventMappers(es)
+	idpintent.RegisterEventMappers(es)
+	notification.RegisterEventMappers(es)
 	return es
 }
 
@@ -125,6 +135,12 @@ func expectFilter(events ...*repository.Event) expect {
 	}
 }
 
+func expectFilterError(err error) expect {
+	return func(m *mock.MockRepository) {
+		m.ExpectFilterEventsError(err)
+	}
+}
+
 func expectFilterOrgDomainNotFound() expect {
 	return func(m *mock.MockRepository) {
 		m.ExpectFilterNoEventsNoError()
@@ -199,6 +215,18 @@ func uniqueConstraintsFromEventConstraintWithInstanceID(instanceID string, const
 		Action:       repository.UniqueConstraintAction(constraint.Action)}
 }
 
+func newMockPermissionCheckAllowed() domain.PermissionCheck {
+	return func(ctx context.Context, permission, orgID, resourceID string) (err error) {
+		return nil
+	}
+}
+
+func newMockPermissionCheckNotAllowed() domain.PermissionCheck {
+	return func(ctx context.Context, permission, orgID, resourceID string) (err error) {
+		return caos_errs.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied")
+	}
+}
+
 func GetMockSecretGenerator(t *testing.T) crypto.Generator {
 	ctrl := gomock.NewController(t)
 	alg := crypto.CreateMockEncryptionAlg(ctrl)
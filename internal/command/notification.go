@@ -0,0 +1,123 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+)
+
+// Notification is a message sent to a user which is recorded in the outbox
+type Notification struct {
+	UserID        string
+	ResourceOwner string
+	// TriggerEventType and TriggerSequence identify the event the message was created for
+	TriggerEventType eventstore.EventType
+	TriggerSequence  uint64
+	Channel          domain.NotificationType
+	Recipient        string
+	MessageType      string
+}
+
+// NotificationDelivered records the successful delivery of a notification.
+// If no id is passed, the notification is added to the outbox and its new id is returned.
+func (c *Commands) NotificationDelivered(ctx context.Context, id string, n *Notification) (string, error) {
+	writeModel, cmds, err := c.prepareNotificationDeliveryAttempt(ctx, id, n)
+	if err != nil {
+		return "", err
+	}
+	cmds = append(cmds, notification.NewDeliveredEvent(ctx, notificationAggregate(ctx, writeModel), writeModel.Attempts+1))
+	if _, err = c.eventstore.Push(ctx, cmds...); err != nil {
+		return "", err
+	}
+	return writeModel.AggregateID, nil
+}
+
+// NotificationDeliveryFailed records a failed delivery of a notification,
+// the delivery is retried at retryAt or given up if retryAt is nil.
+// If no id is passed, the notification is added to the outbox and its new id is returned.
+func (c *Commands) NotificationDeliveryFailed(ctx context.Context, id string, n *Notification, reason string, retryAt *time.Time) (string, error) {
+	writeModel, cmds, err := c.prepareNotificationDeliveryAttempt(ctx, id, n)
+	if err != nil {
+		return "", err
+	}
+	cmds = append(cmds, notification.NewDeliveryFailedEvent(ctx, notificationAggregate(ctx, writeModel), writeModel.Attempts+1, reason, retryAt))
+	if _, err = c.eventstore.Push(ctx, cmds...); err != nil {
+		return "", err
+	}
+	return writeModel.AggregateID, nil
+}
+
+// ResendNotification schedules the notification to be sent again immediately,
+// the resourceOwner and userID are only checked if they are set
+func (c *Commands) ResendNotification(ctx context.Context, resourceOwner, userID, id string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Nt3fc", "Errors.IDMissing")
+	}
+	writeModel, err := c.getNotificationWriteModel(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() || (userID != "" && writeModel.UserID != userID) {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Nt3fd", "Errors.Notification.NotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, notification.NewResendRequestedEvent(ctx, notificationAggregate(ctx, writeModel)))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) prepareNotificationDeliveryAttempt(ctx context.Context, id string, n *Notification) (*NotificationWriteModel, []eventstore.Command, error) {
+	if id != "" {
+		writeModel, err := c.getNotificationWriteModel(ctx, id, "")
+		if err != nil {
+			return nil, nil, err
+		}
+		if !writeModel.State.Exists() {
+			return nil, nil, errors.ThrowNotFound(nil, "COMMAND-Nt3fa", "Errors.Notification.NotFound")
+		}
+		return writeModel, nil, nil
+	}
+	if n == nil || n.UserID == "" || n.ResourceOwner == "" || !n.Channel.Valid() {
+		return nil, nil, errors.ThrowInvalidArgument(nil, "COMMAND-Nt3fb", "Errors.Notification.Invalid")
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	writeModel := NewNotificationWriteModel(id, n.ResourceOwner)
+	return writeModel, []eventstore.Command{
+		notification.NewAddedEvent(
+			ctx,
+			notificationAggregate(ctx, writeModel),
+			n.UserID,
+			n.TriggerEventType,
+			n.TriggerSequence,
+			n.Channel,
+			n.Recipient,
+			n.MessageType,
+		),
+	}, nil
+}
+
+func (c *Commands) getNotificationWriteModel(ctx context.Context, id, resourceOwner string) (*NotificationWriteModel, error) {
+	writeModel := NewNotificationWriteModel(id, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func notificationAggregate(ctx context.Context, writeModel *NotificationWriteModel) *eventstore.Aggregate {
+	return &notification.NewAggregate(writeModel.AggregateID, writeModel.ResourceOwner, authz.GetInstance(ctx).InstanceID()).Aggregate
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+)
+
+type NotificationWriteModel struct {
+	eventstore.WriteModel
+
+	UserID   string
+	State    domain.NotificationDeliveryState
+	Attempts uint16
+}
+
+func NewNotificationWriteModel(id, resourceOwner string) *NotificationWriteModel {
+	return &NotificationWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *NotificationWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *notification.AddedEvent:
+			wm.UserID = e.UserID
+			wm.State = domain.NotificationDeliveryStateRetrying
+		case *notification.DeliveredEvent:
+			wm.Attempts = e.Attempt
+			wm.State = domain.NotificationDeliveryStateDelivered
+		case *notification.DeliveryFailedEvent:
+			wm.Attempts = e.Attempt
+			wm.State = domain.NotificationDeliveryStateFailed
+			if e.RetryAt != nil {
+				wm.State = domain.NotificationDeliveryStateRetrying
+			}
+		case *notification.ResendRequestedEvent:
+			wm.State = domain.NotificationDeliveryStateRetrying
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *NotificationWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(notification.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			notification.AddedType,
+			notification.DeliveredType,
+			notification.DeliveryFailedType,
+			notification.ResendRequestedType,
+		).
+		Builder()
+}
@@ -0,0 +1,463 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommands_NotificationDelivered(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx          context.Context
+		id           string
+		notification *Notification
+	}
+	type res struct {
+		want string
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing user, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				notification: &Notification{
+					ResourceOwner: "org1",
+					Channel:       domain.NotificationTypeEmail,
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "add delivered notification, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								notification.NewAddedEvent(context.Background(),
+									&notification.NewAggregate("notification1", "org1", "instance1").Aggregate,
+									"user1",
+									user.HumanEmailCodeAddedType,
+									5,
+									domain.NotificationTypeEmail,
+									"email@test.ch",
+									domain.VerifyEmailMessageType,
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								notification.NewDeliveredEvent(context.Background(),
+									&notification.NewAggregate("notification1", "org1", "instance1").Aggregate,
+									1,
+								),
+							),
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "notification1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				notification: &Notification{
+					UserID:           "user1",
+					ResourceOwner:    "org1",
+					TriggerEventType: user.HumanEmailCodeAddedType,
+					TriggerSequence:  5,
+					Channel:          domain.NotificationTypeEmail,
+					Recipient:        "email@test.ch",
+					MessageType:      domain.VerifyEmailMessageType,
+				},
+			},
+			res: res{
+				want: "notification1",
+			},
+		},
+		{
+			name: "existing notification not found, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "notification1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "retried notification delivered, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							notification.NewAddedEvent(context.Background(),
+								&notification.NewAggregate("notification1", "org1", "instance1").Aggregate,
+								"user1",
+								user.HumanEmailCodeAddedType,
+								5,
+								domain.NotificationTypeEmail,
+								"email@test.ch",
+								domain.VerifyEmailMessageType,
+							),
+						),
+						eventFromEventPusher(
+							notification.NewDeliveryFailedEvent(context.Background(),
+								&notification.NewAggregate("notification1", "org1", "instance1").Aggregate,
+								1,
+								"connection refused",
+								&time.Time{},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								notification.NewDeliveredEvent(context.Background(),
+									&notification.NewAggregate("notification1", "org1", "instance1").Aggregate,
+									2,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "notification1",
+			},
+			res: res{
+				want: "notification1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := c.NotificationDelivered(tt.args.ctx, tt.args.id, tt.args.notification)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_NotificationDeliveryFailed(t *testing.T) {
+	retryAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx          context.Context
+		id           string
+		notification *Notification
+		reason       string
+		retryAt      *time.Time
+	}
+	type res struct {
+		want string
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "add failed notification with retry, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								notification.NewAddedEvent(context.Background(),
+									&notification.NewAggregate("notification1", "org1", "instance1").Aggregate,
+									"user1",
+									user.HumanPhoneCodeAddedType,
+									5,
+									domain.NotificationTypeSms,
+									"+41791234567",
+									domain.VerifyPhoneMessageType,
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								notification.NewDeliveryFailedEvent(context.Background(),
+									&notification.NewAggregate("notification1", "org1", "instance1").Aggregate,
+									1,
+									"connection refused",
+									&retryAt,
+								),
+							),
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "notification1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				notification: &Notification{
+					UserID:           "user1",
+					ResourceOwner:    "org1",
+					TriggerEventType: user.HumanPhoneCodeAddedType,
+					TriggerSequence:  5,
+					Channel:          domain.NotificationTypeSms,
+					Recipient:        "+41791234567",
+					MessageType:      domain.VerifyPhoneMessageType,
+				},
+				reason:  "connection refused",
+				retryAt: &retryAt,
+			},
+			res: res{
+				want: "notification1",
+			},
+		},
+		{
+			name: "last attempt failed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							notification.NewAddedEvent(context.Background(),
+								&notification.NewAggregate("notification1", "org1", "instance1").Aggregate,
+								"user1",
+								user.HumanPhoneCodeAddedType,
+								5,
+								domain.NotificationTypeSms,
+								"+41791234567",
+								domain.VerifyPhoneMessageType,
+							),
+						),
+						eventFromEventPusher(
+							notification.NewDeliveryFailedEvent(context.Background(),
+								&notification.NewAggregate("notification1", "org1", "instance1").Aggregate,
+								1,
+								"connection refused",
+								&retryAt,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								notification.NewDeliveryFailedEvent(context.Background(),
+									&notification.NewAggregate("notification1", "org1", "instance1").Aggregate,
+									2,
+									"connection refused",
+									nil,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				id:     "notification1",
+				reason: "connection refused",
+			},
+			res: res{
+				want: "notification1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := c.NotificationDeliveryFailed(tt.args.ctx, tt.args.id, tt.args.notification, tt.args.reason, tt.args.retryAt)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_ResendNotification(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		userID        string
+		id            string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing id, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "notification not found, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				id:            "notification1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "notification of other user, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							notification.NewAddedEvent(context.Background(),
+								&notification.NewAggregate("notification1", "org1", "instance1").Aggregate,
+								"user1",
+								user.HumanEmailCodeAddedType,
+								5,
+								domain.NotificationTypeEmail,
+								"email@test.ch",
+								domain.VerifyEmailMessageType,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				userID:        "user2",
+				id:            "notification1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "resend delivered notification, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							notification.NewAddedEvent(context.Background(),
+								&notification.NewAggregate("notification1", "org1", "instance1").Aggregate,
+								"user1",
+								user.HumanEmailCodeAddedType,
+								5,
+								domain.NotificationTypeEmail,
+								"email@test.ch",
+								domain.VerifyEmailMessageType,
+							),
+						),
+						eventFromEventPusher(
+							notification.NewDeliveredEvent(context.Background(),
+								&notification.NewAggregate("notification1", "org1", "instance1").Aggregate,
+								1,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								notification.NewResendRequestedEvent(context.Background(),
+									&notification.NewAggregate("notification1", "org1", "instance1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				userID:        "user1",
+				id:            "notification1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.ResendNotification(tt.args.ctx, tt.args.resourceOwner, tt.args.userID, tt.args.id)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
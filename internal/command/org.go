@@ -20,6 +20,7 @@ type OrgSetup struct {
 	Human        *AddHuman
 	Machine      *AddMachine
 	Roles        []string
+	ParentOrgID  string
 }
 
 func (c *Commands) SetUpOrgWithIDs(ctx context.Context, o *OrgSetup, orgID, userID string, userIDs ...string) (string, *domain.ObjectDetails, error) {
@@ -47,6 +48,9 @@ func (c *Commands) setUpOrgWithIDs(ctx context.Context, o *OrgSetup, orgID, user
 	validations := []preparation.Validation{
 		AddOrgCommand(ctx, orgAgg, o.Name, userIDs...),
 	}
+	if o.ParentOrgID != "" {
+		validations = append(validations, prepareSetOrgParent(orgAgg, o.ParentOrgID))
+	}
 
 	var pat *PersonalAccessToken
 	var machineKey *MachineKey
@@ -166,7 +170,7 @@ func (c *Commands) AddOrgWithID(ctx context.Context, name, userID, resourceOwner
 		return nil, errors.ThrowNotFound(nil, "ORG-lapo2m", "Errors.Org.AlreadyExisting")
 	}
 
-	return c.addOrgWithIDAndMember(ctx, name, userID, resourceOwner, orgID, claimedUserIDs)
+	return c.addOrgWithIDAndMember(ctx, name, userID, resourceOwner, orgID, "", claimedUserIDs)
 }
 
 func (c *Commands) AddOrg(ctx context.Context, name, userID, resourceOwner string, claimedUserIDs []string) (*domain.Org, error) {
@@ -179,14 +183,20 @@ func (c *Commands) AddOrg(ctx context.Context, name, userID, resourceOwner strin
 		return nil, errors.ThrowInternal(err, "COMMA-OwciI", "Errors.Internal")
 	}
 
-	return c.addOrgWithIDAndMember(ctx, name, userID, resourceOwner, orgID, claimedUserIDs)
+	return c.addOrgWithIDAndMember(ctx, name, userID, resourceOwner, orgID, "", claimedUserIDs)
 }
 
-func (c *Commands) addOrgWithIDAndMember(ctx context.Context, name, userID, resourceOwner, orgID string, claimedUserIDs []string) (*domain.Org, error) {
+func (c *Commands) addOrgWithIDAndMember(ctx context.Context, name, userID, resourceOwner, orgID, parentID string, claimedUserIDs []string) (*domain.Org, error) {
 	orgAgg, addedOrg, events, err := c.addOrgWithID(ctx, &domain.Org{Name: name}, orgID, claimedUserIDs)
 	if err != nil {
 		return nil, err
 	}
+	if parentID != "" {
+		if err = checkOrgParent(ctx, c.eventstore.Filter, orgID, parentID); err != nil {
+			return nil, err
+		}
+		events = append(events, org.NewOrgParentSetEvent(ctx, orgAgg, parentID))
+	}
 	err = c.checkUserExists(ctx, userID, resourceOwner)
 	if err != nil {
 		return nil, err
@@ -355,7 +365,15 @@ func (c *Commands) prepareRemoveOrg(a *org.Aggregate) preparation.Validation {
 			if err != nil {
 				return nil, err
 			}
-			return []eventstore.Command{org.NewOrgRemovedEvent(ctx, &a.Aggregate, writeModel.Name, usernames, domainPolicy.UserLoginMustBeDomain, domains, links, entityIds)}, nil
+			loginDomains, err := OrgLoginDomains(ctx, filter, a.ID)
+			if err != nil {
+				return nil, err
+			}
+			cmds := make([]eventstore.Command, 0, len(loginDomains)+1)
+			for _, loginDomain := range loginDomains {
+				cmds = append(cmds, org.NewDomainLoginRemovedEvent(ctx, &a.Aggregate, loginDomain))
+			}
+			return append(cmds, org.NewOrgRemovedEvent(ctx, &a.Aggregate, writeModel.Name, usernames, domainPolicy.UserLoginMustBeDomain, domains, links, entityIds)), nil
 		}, nil
 	}
 }
@@ -484,6 +502,38 @@ func OrgDomains(ctx context.Context, filter preparation.FilterToQueryReducer, or
 	return names, nil
 }
 
+// OrgLoginDomains returns the domains of the organization, which are used for the login
+func OrgLoginDomains(ctx context.Context, filter preparation.FilterToQueryReducer, orgID string) ([]string, error) {
+	events, err := filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(orgID).
+		OrderAsc().
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.OrgDomainLoginAddedEventType,
+			org.OrgDomainLoginRemovedEventType,
+		).Builder())
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0)
+	for _, event := range events {
+		switch eventTyped := event.(type) {
+		case *org.DomainLoginAddedEvent:
+			names = append(names, eventTyped.Domain)
+		case *org.DomainLoginRemovedEvent:
+			for i := range names {
+				if names[i] == eventTyped.Domain {
+					names[i] = names[len(names)-1]
+					names = names[:len(names)-1]
+					break
+				}
+			}
+		}
+	}
+	return names, nil
+}
+
 type userIDName struct {
 	name string
 	id   string
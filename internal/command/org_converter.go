@@ -10,6 +10,7 @@ func orgWriteModelToOrg(wm *OrgWriteModel) *domain.Org {
 		Name:          wm.Name,
 		State:         wm.State,
 		PrimaryDomain: wm.PrimaryDomain,
+		ParentID:      wm.ParentID,
 	}
 }
 
@@ -0,0 +1,132 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/static"
+)
+
+const (
+	customMailTemplateContentTypeHTML = "text/html"
+	customMailTemplateContentTypeMJML = "text/mjml"
+)
+
+// SetOrgCustomMailTemplate stores the html layout (and optional mjml source) of a message type
+// and replaces a previously uploaded version
+func (c *Commands) SetOrgCustomMailTemplate(ctx context.Context, resourceOwner string, mailTemplate *domain.CustomMailTemplate) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Mt9rs", "Errors.ResourceOwnerMissing")
+	}
+	if !mailTemplate.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Mt9iv", "Errors.Org.CustomMailTemplate.Invalid")
+	}
+	if _, err := template.New("").Parse(string(mailTemplate.Template)); err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "ORG-Mt9pa", "Errors.Org.CustomMailTemplate.Invalid")
+	}
+	existing, err := c.orgCustomMailTemplateWriteModelByID(ctx, resourceOwner, mailTemplate.MessageType)
+	if err != nil {
+		return nil, err
+	}
+	templateAsset, err := c.uploadCustomMailTemplate(ctx, resourceOwner, mailTemplate.MessageType, "html", customMailTemplateContentTypeHTML, mailTemplate.Template)
+	if err != nil {
+		return nil, err
+	}
+	var mjmlKey string
+	if len(mailTemplate.MJML) > 0 {
+		mjmlAsset, err := c.uploadCustomMailTemplate(ctx, resourceOwner, mailTemplate.MessageType, "mjml", customMailTemplateContentTypeMJML, mailTemplate.MJML)
+		if err != nil {
+			c.removeCustomMailTemplateAssets(ctx, resourceOwner, templateAsset.Name)
+			return nil, err
+		}
+		mjmlKey = mjmlAsset.Name
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewCustomMailTemplateSetEvent(ctx, orgAgg, mailTemplate.MessageType, templateAsset.Name, templateAsset.Hash, mjmlKey))
+	if err != nil {
+		c.removeCustomMailTemplateAssets(ctx, resourceOwner, templateAsset.Name, mjmlKey)
+		return nil, err
+	}
+	// the previous version is still part of the event history (by its hash) but no longer needed in the storage
+	c.removeCustomMailTemplateAssets(ctx, resourceOwner, existing.TemplateKey, existing.MJMLKey)
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+// RemoveOrgCustomMailTemplate removes the layout of the message type,
+// so the emails are rendered with the mail template policy again
+func (c *Commands) RemoveOrgCustomMailTemplate(ctx context.Context, resourceOwner, messageType string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Mt9Rr", "Errors.ResourceOwnerMissing")
+	}
+	if !domain.IsEmailMessageType(messageType) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Mt9Ri", "Errors.Org.CustomMailTemplate.Invalid")
+	}
+	existing, err := c.orgCustomMailTemplateWriteModelByID(ctx, resourceOwner, messageType)
+	if err != nil {
+		return nil, err
+	}
+	if existing.State != domain.PolicyStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Mt9Rn", "Errors.Org.CustomMailTemplate.NotFound")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewCustomMailTemplateRemovedEvent(ctx, orgAgg, messageType))
+	if err != nil {
+		return nil, err
+	}
+	c.removeCustomMailTemplateAssets(ctx, resourceOwner, existing.TemplateKey, existing.MJMLKey)
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) uploadCustomMailTemplate(ctx context.Context, resourceOwner, messageType, extension, contentType string, content []byte) (*static.Asset, error) {
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	asset, err := c.uploadAsset(ctx, &AssetUpload{
+		ResourceOwner: resourceOwner,
+		ObjectName:    domain.CustomMailTemplateObjectName(messageType, id, extension),
+		ContentType:   contentType,
+		ObjectType:    static.ObjectTypeMailTemplate,
+		File:          bytes.NewReader(content),
+		Size:          int64(len(content)),
+	})
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "ORG-Mt9up", "Errors.Assets.Object.PutFailed")
+	}
+	return asset, nil
+}
+
+// removeCustomMailTemplateAssets removes the objects of a template version,
+// failures are only logged as the stored objects are not referenced anymore
+func (c *Commands) removeCustomMailTemplateAssets(ctx context.Context, resourceOwner string, keys ...string) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		err := c.removeAsset(ctx, resourceOwner, key)
+		logging.WithFields("resourceOwner", resourceOwner, "key", key).OnError(err).Warn("unable to remove custom mail template asset")
+	}
+}
+
+func (c *Commands) orgCustomMailTemplateWriteModelByID(ctx context.Context, orgID, messageType string) (*OrgCustomMailTemplateWriteModel, error) {
+	writeModel := NewOrgCustomMailTemplateWriteModel(orgID, messageType)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,78 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgCustomMailTemplateWriteModel struct {
+	eventstore.WriteModel
+
+	MessageType  string
+	TemplateKey  string
+	TemplateHash string
+	MJMLKey      string
+	State        domain.PolicyState
+}
+
+func NewOrgCustomMailTemplateWriteModel(orgID, messageType string) *OrgCustomMailTemplateWriteModel {
+	return &OrgCustomMailTemplateWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   orgID,
+			ResourceOwner: orgID,
+		},
+		MessageType: messageType,
+	}
+}
+
+func (wm *OrgCustomMailTemplateWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.CustomMailTemplateSetEvent:
+			if e.MessageType != wm.MessageType {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *org.CustomMailTemplateRemovedEvent:
+			if e.MessageType != wm.MessageType {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *org.OrgRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgCustomMailTemplateWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *org.CustomMailTemplateSetEvent:
+			wm.TemplateKey = e.TemplateKey
+			wm.TemplateHash = e.TemplateHash
+			wm.MJMLKey = e.MJMLKey
+			wm.State = domain.PolicyStateActive
+		case *org.CustomMailTemplateRemovedEvent, *org.OrgRemovedEvent:
+			wm.TemplateKey = ""
+			wm.TemplateHash = ""
+			wm.MJMLKey = ""
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgCustomMailTemplateWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.CustomMailTemplateSetEventType,
+			org.CustomMailTemplateRemovedEventType,
+			org.OrgRemovedEventType,
+		).
+		Builder()
+}
@@ -0,0 +1,364 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/static/mock"
+)
+
+func TestCommandSide_SetOrgCustomMailTemplate(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		storage     static.Storage
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		template      *domain.CustomMailTemplate
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "resourceowner missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				template: &domain.CustomMailTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Template:    []byte("<html>{{.Text}}</html>"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no email message type, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				template: &domain.CustomMailTemplate{
+					MessageType: domain.PhoneOTPMessageType,
+					Template:    []byte("<html>{{.Text}}</html>"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "template not parsable, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				template: &domain.CustomMailTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Template:    []byte("<html>{{.Text</html>"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "upload failed, internal error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+				storage:     mock.NewStorage(t).ExpectPutObjectError(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				template: &domain.CustomMailTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Template:    []byte("<html>{{.Text}}</html>"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsInternal,
+			},
+		},
+		{
+			name: "template set, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewCustomMailTemplateSetEvent(context.Background(),
+									&org.NewAggregate("org1").Aggregate,
+									domain.InitCodeMessageType,
+									"policy/mail/template/InitCode-id1.html",
+									"<html>{{.Text}}</html>",
+									"policy/mail/template/InitCode-id2.mjml",
+								),
+							),
+						},
+					),
+				),
+				storage:     mock.NewStorage(t).ExpectPutObject().ExpectPutObject(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1", "id2"),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				template: &domain.CustomMailTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Template:    []byte("<html>{{.Text}}</html>"),
+					MJML:        []byte("<mjml></mjml>"),
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "template replaced, previous version removed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewCustomMailTemplateSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								"policy/mail/template/InitCode-id0.html",
+								"<html></html>",
+								"",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewCustomMailTemplateSetEvent(context.Background(),
+									&org.NewAggregate("org1").Aggregate,
+									domain.InitCodeMessageType,
+									"policy/mail/template/InitCode-id1.html",
+									"<html>{{.Text}}</html>",
+									"",
+								),
+							),
+						},
+					),
+				),
+				storage:     mock.NewStorage(t).ExpectPutObject().ExpectRemoveObjectNoError(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				template: &domain.CustomMailTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Template:    []byte("<html>{{.Text}}</html>"),
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore,
+				static:      tt.fields.storage,
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := r.SetOrgCustomMailTemplate(tt.args.ctx, tt.args.resourceOwner, tt.args.template)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveOrgCustomMailTemplate(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		storage    static.Storage
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		messageType   string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "resourceowner missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				messageType: domain.InitCodeMessageType,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "template not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				messageType:   domain.InitCodeMessageType,
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "template of other message type, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewCustomMailTemplateSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.PasswordResetMessageType,
+								"policy/mail/template/PasswordReset-id0.html",
+								"<html></html>",
+								"",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				messageType:   domain.InitCodeMessageType,
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "template removed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewCustomMailTemplateSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								"policy/mail/template/InitCode-id0.html",
+								"<html></html>",
+								"",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewCustomMailTemplateRemovedEvent(context.Background(),
+									&org.NewAggregate("org1").Aggregate,
+									domain.InitCodeMessageType,
+								),
+							),
+						},
+					),
+				),
+				storage: mock.NewStorage(t).ExpectRemoveObjectNoError(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				messageType:   domain.InitCodeMessageType,
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+				static:     tt.fields.storage,
+			}
+			got, err := r.RemoveOrgCustomMailTemplate(tt.args.ctx, tt.args.resourceOwner, tt.args.messageType)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
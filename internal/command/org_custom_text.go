@@ -0,0 +1,52 @@
+package command
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// ImportOrgCustomTexts sets the texts (e.g. of a translation file) as custom texts of the organization in the language,
+// texts which are already set on the organization are skipped
+func (c *Commands) ImportOrgCustomTexts(ctx context.Context, resourceOwner string, lang language.Tag, texts []*domain.CustomText) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Ct2im", "Errors.ResourceOwnerMissing")
+	}
+	if err := validateImportedCustomTexts(lang, texts); err != nil {
+		return nil, err
+	}
+	existingTexts := NewOrgCustomTextsWriteModel(resourceOwner, lang)
+	err = c.eventstore.FilterToQueryReducer(ctx, existingTexts)
+	if err != nil {
+		return nil, err
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existingTexts.WriteModel)
+	events := make([]eventstore.Command, 0, len(texts))
+	for _, text := range texts {
+		if existingTexts.Texts[domain.CustomTextID(text.Template, text.Key)] == text.Text {
+			continue
+		}
+		events = append(events, org.NewCustomTextSetEvent(ctx, orgAgg, text.Template, text.Key, text.Text, lang))
+	}
+	if len(events) == 0 {
+		return writeModelToObjectDetails(&existingTexts.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingTexts, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingTexts.WriteModel), nil
+}
@@ -0,0 +1,55 @@
+package command
+
+import (
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgCustomTextsWriteModel struct {
+	CustomTextsWriteModel
+}
+
+func NewOrgCustomTextsWriteModel(orgID string, language language.Tag) *OrgCustomTextsWriteModel {
+	return &OrgCustomTextsWriteModel{
+		CustomTextsWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			Language: language,
+			Texts:    make(map[string]string),
+		},
+	}
+}
+
+func (wm *OrgCustomTextsWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.CustomTextSetEvent:
+			wm.CustomTextsWriteModel.AppendEvents(&e.CustomTextSetEvent)
+		case *org.CustomTextRemovedEvent:
+			wm.CustomTextsWriteModel.AppendEvents(&e.CustomTextRemovedEvent)
+		case *org.CustomTextTemplateRemovedEvent:
+			wm.CustomTextsWriteModel.AppendEvents(&e.CustomTextTemplateRemovedEvent)
+		}
+	}
+}
+
+func (wm *OrgCustomTextsWriteModel) Reduce() error {
+	return wm.CustomTextsWriteModel.Reduce()
+}
+
+func (wm *OrgCustomTextsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.CustomTextsWriteModel.AggregateID).
+		EventTypes(
+			org.CustomTextSetEventType,
+			org.CustomTextRemovedEventType,
+			org.CustomTextTemplateRemovedEventType).
+		Builder()
+}
@@ -0,0 +1,249 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestCommandSide_ImportOrgCustomTexts(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		lang          language.Tag
+		texts         []*domain.CustomText
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no resource owner, error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:  context.Background(),
+				lang: language.German,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no language, error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "text of other language, error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				lang:          language.German,
+				texts: []*domain.CustomText{
+					{
+						Template: domain.LoginCustomText,
+						Key:      domain.LoginKeyLoginTitle,
+						Language: language.English,
+						Text:     "Login",
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "text without template, error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				lang:          language.German,
+				texts: []*domain.CustomText{
+					{
+						Key:      domain.LoginKeyLoginTitle,
+						Language: language.German,
+						Text:     "Anmeldung",
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "texts unchanged, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewCustomTextSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.LoginCustomText,
+								domain.LoginKeyLoginTitle,
+								"Anmeldung",
+								language.German,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				lang:          language.German,
+				texts: []*domain.CustomText{
+					{
+						Template: domain.LoginCustomText,
+						Key:      domain.LoginKeyLoginTitle,
+						Language: language.German,
+						Text:     "Anmeldung",
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "import texts, only changed texts set",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewCustomTextSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.LoginCustomText,
+								domain.LoginKeyLoginTitle,
+								"Anmeldung",
+								language.German,
+							),
+						),
+						eventFromEventPusher(
+							org.NewCustomTextSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								domain.MessageSubject,
+								"Subject",
+								language.English,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewCustomTextSetEvent(context.Background(),
+									&org.NewAggregate("org1").Aggregate,
+									domain.InitCodeMessageType,
+									domain.MessageSubject,
+									"Benutzer initialisieren",
+									language.German,
+								),
+							),
+							eventFromEventPusher(
+								org.NewCustomTextSetEvent(context.Background(),
+									&org.NewAggregate("org1").Aggregate,
+									domain.LoginCustomText,
+									domain.LoginKeyLoginDescription,
+									"Melde dich mit deinem Konto an",
+									language.German,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				lang:          language.German,
+				texts: []*domain.CustomText{
+					{
+						Template: domain.LoginCustomText,
+						Key:      domain.LoginKeyLoginTitle,
+						Language: language.German,
+						Text:     "Anmeldung",
+					},
+					{
+						Template: domain.InitCodeMessageType,
+						Key:      domain.MessageSubject,
+						Language: language.German,
+						Text:     "Benutzer initialisieren",
+					},
+					{
+						Template: domain.LoginCustomText,
+						Key:      domain.LoginKeyLoginDescription,
+						Language: language.German,
+						Text:     "Melde dich mit deinem Konto an",
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ImportOrgCustomTexts(tt.args.ctx, tt.args.resourceOwner, tt.args.lang, tt.args.texts)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -259,7 +259,74 @@ func (c *Commands) RemoveOrgDomain(ctx context.Context, orgDomain *domain.OrgDom
 		return nil, errors.ThrowPreconditionFailed(nil, "ORG-Sjdi3", "Errors.Org.PrimaryDomainNotDeletable")
 	}
 	orgAgg := OrgAggregateFromWriteModel(&domainWriteModel.WriteModel)
-	pushedEvents, err := c.eventstore.Push(ctx, org.NewDomainRemovedEvent(ctx, orgAgg, orgDomain.Domain, domainWriteModel.Verified))
+	events := make([]eventstore.Command, 0, 2)
+	if domainWriteModel.Login {
+		events = append(events, org.NewDomainLoginRemovedEvent(ctx, orgAgg, orgDomain.Domain))
+	}
+	events = append(events, org.NewDomainRemovedEvent(ctx, orgAgg, orgDomain.Domain, domainWriteModel.Verified))
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(domainWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&domainWriteModel.WriteModel), nil
+}
+
+// AddOrgLoginDomain routes the login UI and the OIDC issuer of the verified domain to the organization
+func (c *Commands) AddOrgLoginDomain(ctx context.Context, orgDomain *domain.OrgDomain) (*domain.ObjectDetails, error) {
+	if orgDomain == nil || !orgDomain.IsValid() || orgDomain.AggregateID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "ORG-Lg4ia", "Errors.Org.InvalidDomain")
+	}
+	domainWriteModel, err := c.getOrgDomainWriteModel(ctx, orgDomain.AggregateID, orgDomain.Domain)
+	if err != nil {
+		return nil, err
+	}
+	if domainWriteModel.State != domain.OrgDomainStateActive {
+		return nil, errors.ThrowNotFound(nil, "ORG-Lg4nf", "Errors.Org.DomainNotOnOrg")
+	}
+	if !domainWriteModel.Verified {
+		return nil, errors.ThrowPreconditionFailed(nil, "ORG-Lg4nv", "Errors.Org.DomainNotVerified")
+	}
+	if domainWriteModel.Login {
+		return nil, errors.ThrowAlreadyExists(nil, "ORG-Lg4ae", "Errors.Org.Domain.LoginAlreadyExists")
+	}
+	// domains are verified without any challenge if the domain policy doesn't validate org domains,
+	// so only the instance can route such a domain, which would otherwise allow to claim any host name
+	if !domainWriteModel.Validated {
+		if err = c.checkPermission(ctx, domain.PermissionInstanceWrite, authz.GetInstance(ctx).InstanceID(), ""); err != nil {
+			return nil, errors.ThrowPermissionDenied(err, "ORG-Lg4nw", "Errors.Org.Domain.LoginNotValidated")
+		}
+	}
+	orgAgg := OrgAggregateFromWriteModel(&domainWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewDomainLoginAddedEvent(ctx, orgAgg, orgDomain.Domain))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(domainWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&domainWriteModel.WriteModel), nil
+}
+
+// RemoveOrgLoginDomain stops routing the login UI and the OIDC issuer of the domain to the organization,
+// the domain itself stays on the organization
+func (c *Commands) RemoveOrgLoginDomain(ctx context.Context, orgDomain *domain.OrgDomain) (*domain.ObjectDetails, error) {
+	if orgDomain == nil || !orgDomain.IsValid() || orgDomain.AggregateID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "ORG-Lg5ia", "Errors.Org.InvalidDomain")
+	}
+	domainWriteModel, err := c.getOrgDomainWriteModel(ctx, orgDomain.AggregateID, orgDomain.Domain)
+	if err != nil {
+		return nil, err
+	}
+	if domainWriteModel.State != domain.OrgDomainStateActive || !domainWriteModel.Login {
+		return nil, errors.ThrowNotFound(nil, "ORG-Lg5nf", "Errors.Org.Domain.LoginNotFound")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&domainWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewDomainLoginRemovedEvent(ctx, orgAgg, orgDomain.Domain))
 	if err != nil {
 		return nil, err
 	}
@@ -321,6 +388,9 @@ func (c *Commands) changeDefaultDomain(ctx context.Context, orgID, newName strin
 				if isPrimary {
 					events = append(events, org.NewDomainPrimarySetEvent(ctx, orgAgg, newDefaultDomain))
 				}
+				if orgDomain.Login {
+					events = append(events, org.NewDomainLoginRemovedEvent(ctx, orgAgg, orgDomain.Domain))
+				}
 				events = append(events, org.NewDomainRemovedEvent(ctx, orgAgg, orgDomain.Domain, orgDomain.Verified))
 				return events, nil
 			}
@@ -346,6 +416,9 @@ func (c *Commands) removeCustomDomains(ctx context.Context, orgID string) ([]eve
 				hasDefault = true
 				continue
 			}
+			if orgDomain.Login {
+				events = append(events, org.NewDomainLoginRemovedEvent(ctx, orgAgg, orgDomain.Domain))
+			}
 			events = append(events, org.NewDomainRemovedEvent(ctx, orgAgg, orgDomain.Domain, orgDomain.Verified))
 		}
 	}
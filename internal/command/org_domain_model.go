@@ -15,6 +15,9 @@ type OrgDomainWriteModel struct {
 	ValidationCode *crypto.CryptoValue
 	Primary        bool
 	Verified       bool
+	// Validated is only set if the domain was verified by a DNS or HTTP challenge
+	Validated bool
+	Login     bool
 
 	State domain.OrgDomainState
 }
@@ -59,6 +62,16 @@ func (wm *OrgDomainWriteModel) AppendEvents(events ...eventstore.Event) {
 				continue
 			}
 			wm.WriteModel.AppendEvents(e)
+		case *org.DomainLoginAddedEvent:
+			if e.Domain != wm.Domain {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *org.DomainLoginRemovedEvent:
+			if e.Domain != wm.Domain {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
 		}
 	}
 }
@@ -74,14 +87,21 @@ func (wm *OrgDomainWriteModel) Reduce() error {
 			wm.ValidationCode = e.ValidationCode
 		case *org.DomainVerifiedEvent:
 			wm.Verified = true
+			wm.Validated = wm.ValidationType != domain.OrgDomainValidationTypeUnspecified
 		case *org.DomainPrimarySetEvent:
 			wm.Primary = e.Domain == wm.Domain
 		case *org.DomainRemovedEvent:
 			wm.State = domain.OrgDomainStateRemoved
 			wm.Verified = false
+			wm.Validated = false
 			wm.Primary = false
+			wm.Login = false
 			wm.ValidationType = domain.OrgDomainValidationTypeUnspecified
 			wm.ValidationCode = nil
+		case *org.DomainLoginAddedEvent:
+			wm.Login = true
+		case *org.DomainLoginRemovedEvent:
+			wm.Login = false
 		}
 	}
 	return nil
@@ -99,7 +119,9 @@ func (wm *OrgDomainWriteModel) Query() *eventstore.SearchQueryBuilder {
 			org.OrgDomainVerificationAddedEventType,
 			org.OrgDomainVerifiedEventType,
 			org.OrgDomainPrimarySetEventType,
-			org.OrgDomainRemovedEventType).
+			org.OrgDomainRemovedEventType,
+			org.OrgDomainLoginAddedEventType,
+			org.OrgDomainLoginRemovedEventType).
 		Builder()
 }
 
@@ -114,6 +136,7 @@ type OrgDomainsWriteModel struct {
 type Domain struct {
 	Domain   string
 	Verified bool
+	Login    bool
 	State    domain.OrgDomainState
 }
 
@@ -149,6 +172,21 @@ func (wm *OrgDomainsWriteModel) Reduce() error {
 			for _, d := range wm.Domains {
 				if d.Domain == e.Domain {
 					d.State = domain.OrgDomainStateRemoved
+					d.Login = false
+					continue
+				}
+			}
+		case *org.DomainLoginAddedEvent:
+			for _, d := range wm.Domains {
+				if d.Domain == e.Domain {
+					d.Login = true
+					continue
+				}
+			}
+		case *org.DomainLoginRemovedEvent:
+			for _, d := range wm.Domains {
+				if d.Domain == e.Domain {
+					d.Login = false
 					continue
 				}
 			}
@@ -171,7 +209,9 @@ func (wm *OrgDomainsWriteModel) Query() *eventstore.SearchQueryBuilder {
 			org.OrgDomainVerificationAddedEventType,
 			org.OrgDomainVerifiedEventType,
 			org.OrgDomainPrimarySetEventType,
-			org.OrgDomainRemovedEventType).
+			org.OrgDomainRemovedEventType,
+			org.OrgDomainLoginAddedEventType,
+			org.OrgDomainLoginRemovedEventType).
 		Builder()
 }
 
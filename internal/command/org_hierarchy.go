@@ -0,0 +1,102 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// SetOrgParent moves the org below the parent org,
+// an empty parentID moves the org to the top level of the instance
+func (c *Commands) SetOrgParent(ctx context.Context, orgID, parentID string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "ORG-Rk2vd", "Errors.Org.Invalid")
+	}
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareSetOrgParent(org.NewAggregate(orgID), parentID))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+// AddChildOrg creates a new org below the parent org with the user as owner
+func (c *Commands) AddChildOrg(ctx context.Context, parentID, name, userID, resourceOwner string, claimedUserIDs []string) (*domain.Org, error) {
+	if parentID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "ORG-Ty8bn", "Errors.Org.Hierarchy.ParentNotFound")
+	}
+	orgID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Pz4nx", "Errors.Internal")
+	}
+	return c.addOrgWithIDAndMember(ctx, name, userID, resourceOwner, orgID, parentID, claimedUserIDs)
+}
+
+func prepareSetOrgParent(a *org.Aggregate, parentID string) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if a.ID == parentID {
+			return nil, errors.ThrowInvalidArgument(nil, "ORG-Xp2ka", "Errors.Org.Hierarchy.Cycle")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			existing, err := orgWriteModel(ctx, filter, a.ID)
+			if err != nil {
+				return nil, err
+			}
+			if !isOrgStateExists(existing.State) {
+				return nil, errors.ThrowNotFound(nil, "ORG-Lm4ds", "Errors.Org.NotFound")
+			}
+			if existing.ParentID == parentID {
+				return nil, errors.ThrowPreconditionFailed(nil, "ORG-Qe9tv", "Errors.Org.NotChanged")
+			}
+			if err = checkOrgParent(ctx, filter, a.ID, parentID); err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{
+				org.NewOrgParentSetEvent(ctx, &a.Aggregate, parentID),
+			}, nil
+		}, nil
+	}
+}
+
+// checkOrgParent walks up the ancestors of the parent:
+// the org must not be an ancestor of the parent and the depth of the hierarchy is limited
+func checkOrgParent(ctx context.Context, filter preparation.FilterToQueryReducer, orgID, parentID string) error {
+	for depth, id := 1, parentID; id != ""; depth++ {
+		if id == orgID {
+			return errors.ThrowPreconditionFailed(nil, "ORG-Bv5nm", "Errors.Org.Hierarchy.Cycle")
+		}
+		if depth > domain.OrgHierarchyMaxDepth {
+			return errors.ThrowPreconditionFailed(nil, "ORG-Ju7cs", "Errors.Org.Hierarchy.TooDeep")
+		}
+		ancestor, err := orgWriteModel(ctx, filter, id)
+		if err != nil {
+			return err
+		}
+		if !isOrgStateExists(ancestor.State) {
+			if id == parentID {
+				return errors.ThrowPreconditionFailed(nil, "ORG-Hn3vf", "Errors.Org.Hierarchy.ParentNotFound")
+			}
+			// the hierarchy ends at removed orgs
+			return nil
+		}
+		id = ancestor.ParentID
+	}
+	return nil
+}
+
+func orgWriteModel(ctx context.Context, filter preparation.FilterToQueryReducer, orgID string) (*OrgWriteModel, error) {
+	wm := NewOrgWriteModel(orgID)
+	events, err := filter(ctx, wm.Query())
+	if err != nil {
+		return nil, err
+	}
+	wm.AppendEvents(events...)
+	return wm, wm.Reduce()
+}
@@ -12,6 +12,7 @@ type OrgWriteModel struct {
 	Name          string
 	State         domain.OrgState
 	PrimaryDomain string
+	ParentID      string
 }
 
 func NewOrgWriteModel(orgID string) *OrgWriteModel {
@@ -39,6 +40,8 @@ func (wm *OrgWriteModel) Reduce() error {
 			wm.Name = e.Name
 		case *org.DomainPrimarySetEvent:
 			wm.PrimaryDomain = e.Domain
+		case *org.OrgParentSetEvent:
+			wm.ParentID = e.ParentID
 		}
 	}
 	return wm.WriteModel.Reduce()
@@ -56,7 +59,8 @@ func (wm *OrgWriteModel) Query() *eventstore.SearchQueryBuilder {
 			org.OrgDeactivatedEventType,
 			org.OrgReactivatedEventType,
 			org.OrgRemovedEventType,
-			org.OrgDomainPrimarySetEventType).
+			org.OrgDomainPrimarySetEventType,
+			org.OrgParentSetEventType).
 		Builder()
 }
 
@@ -62,7 +62,9 @@ func TestCommandSide_AddPasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
 								true,
+								0,
 							),
 						),
 					),
@@ -92,7 +94,9 @@ func TestCommandSide_AddPasswordLockoutPolicy(t *testing.T) {
 								org.NewLockoutPolicyAddedEvent(context.Background(),
 									&org.NewAggregate("org1").Aggregate,
 									10,
+									0,
 									true,
+									0,
 								),
 							),
 						},
@@ -205,7 +209,9 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
 								true,
+								0,
 							),
 						),
 					),
@@ -233,7 +239,9 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
 								true,
+								0,
 							),
 						),
 					),
@@ -343,7 +351,9 @@ func TestCommandSide_RemovePasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
 								true,
+								0,
 							),
 						),
 					),
@@ -35,6 +35,9 @@ type AddLoginPolicy struct {
 	MultiFactorCheckLifetime   time.Duration
 	DisableLoginWithEmail      bool
 	DisableLoginWithPhone      bool
+	AllowMagicLink             bool
+	AllowPhoneOTP              bool
+	BotProtection              domain.BotProtectionType
 }
 
 type AddLoginPolicyIDP struct {
@@ -59,6 +62,9 @@ type ChangeLoginPolicy struct {
 	MultiFactorCheckLifetime   time.Duration
 	DisableLoginWithEmail      bool
 	DisableLoginWithPhone      bool
+	AllowMagicLink             bool
+	AllowPhoneOTP              bool
+	BotProtection              domain.BotProtectionType
 }
 
 func (c *Commands) AddLoginPolicy(ctx context.Context, resourceOwner string, policy *AddLoginPolicy) (*domain.ObjectDetails, error) {
@@ -83,13 +89,21 @@ func (c *Commands) orgLoginPolicyWriteModelByID(ctx context.Context, orgID strin
 	return policyWriteModel, nil
 }
 
+// getOrgLoginPolicy returns the policy of the org or the nearest ancestor defining one,
+// if none is found the default policy is returned
 func (c *Commands) getOrgLoginPolicy(ctx context.Context, orgID string) (*domain.LoginPolicy, error) {
-	policy, err := c.orgLoginPolicyWriteModelByID(ctx, orgID)
-	if err != nil {
-		return nil, err
-	}
-	if policy.State == domain.PolicyStateActive {
-		return writeModelToLoginPolicy(&policy.LoginPolicyWriteModel), nil
+	for depth := 0; depth <= domain.OrgHierarchyMaxDepth; depth++ {
+		policy, err := c.orgLoginPolicyWriteModelByID(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if policy.State == domain.PolicyStateActive {
+			return writeModelToLoginPolicy(&policy.LoginPolicyWriteModel), nil
+		}
+		if policy.orgRemoved || policy.ParentOrgID == "" {
+			break
+		}
+		orgID = policy.ParentOrgID
 	}
 	return c.getDefaultLoginPolicy(ctx)
 }
@@ -399,6 +413,9 @@ func prepareAddLoginPolicy(a *org.Aggregate, policy *AddLoginPolicy) preparation
 		if ok := domain.ValidateDefaultRedirectURI(policy.DefaultRedirectURI); !ok {
 			return nil, caos_errs.ThrowInvalidArgument(nil, "Org-WSfdq", "Errors.Org.LoginPolicy.RedirectURIInvalid")
 		}
+		if !policy.BotProtection.Valid() {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Jb4qv", "Errors.Org.LoginPolicy.BotProtectionInvalid")
+		}
 		for _, factor := range policy.SecondFactors {
 			if !factor.Valid() {
 				return nil, caos_errs.ThrowInvalidArgument(nil, "Org-SFeea", "Errors.Org.LoginPolicy.MFA.Unspecified")
@@ -430,7 +447,10 @@ func prepareAddLoginPolicy(a *org.Aggregate, policy *AddLoginPolicy) preparation
 				policy.AllowDomainDiscovery,
 				policy.DisableLoginWithEmail,
 				policy.DisableLoginWithPhone,
+				policy.AllowMagicLink,
+				policy.AllowPhoneOTP,
 				policy.PasswordlessType,
+				policy.BotProtection,
 				policy.DefaultRedirectURI,
 				policy.PasswordCheckLifetime,
 				policy.ExternalLoginCheckLifetime,
@@ -457,6 +477,9 @@ func prepareChangeLoginPolicy(a *org.Aggregate, policy *ChangeLoginPolicy) prepa
 		if ok := domain.ValidateDefaultRedirectURI(policy.DefaultRedirectURI); !ok {
 			return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Sfd21", "Errors.Org.LoginPolicy.RedirectURIInvalid")
 		}
+		if !policy.BotProtection.Valid() {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Qm8ed", "Errors.Org.LoginPolicy.BotProtectionInvalid")
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			wm := NewOrgLoginPolicyWriteModel(a.ID)
 			if err := queryAndReduce(ctx, filter, wm); err != nil {
@@ -475,7 +498,10 @@ func prepareChangeLoginPolicy(a *org.Aggregate, policy *ChangeLoginPolicy) prepa
 				policy.AllowDomainDiscovery,
 				policy.DisableLoginWithEmail,
 				policy.DisableLoginWithPhone,
+				policy.AllowMagicLink,
+				policy.AllowPhoneOTP,
 				policy.PasswordlessType,
+				policy.BotProtection,
 				policy.DefaultRedirectURI,
 				policy.PasswordCheckLifetime,
 				policy.ExternalLoginCheckLifetime,
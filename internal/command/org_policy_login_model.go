@@ -13,11 +13,15 @@ import (
 
 type OrgLoginPolicyWriteModel struct {
 	LoginPolicyWriteModel
+
+	// ParentOrgID is used to inherit the policy of the ancestors
+	ParentOrgID string
+	orgRemoved  bool
 }
 
 func NewOrgLoginPolicyWriteModel(orgID string) *OrgLoginPolicyWriteModel {
 	return &OrgLoginPolicyWriteModel{
-		LoginPolicyWriteModel{
+		LoginPolicyWriteModel: LoginPolicyWriteModel{
 			WriteModel: eventstore.WriteModel{
 				AggregateID:   orgID,
 				ResourceOwner: orgID,
@@ -35,6 +39,10 @@ func (wm *OrgLoginPolicyWriteModel) AppendEvents(events ...eventstore.Event) {
 			wm.LoginPolicyWriteModel.AppendEvents(&e.LoginPolicyChangedEvent)
 		case *org.LoginPolicyRemovedEvent:
 			wm.LoginPolicyWriteModel.AppendEvents(&e.LoginPolicyRemovedEvent)
+		case *org.OrgParentSetEvent:
+			wm.ParentOrgID = e.ParentID
+		case *org.OrgRemovedEvent:
+			wm.orgRemoved = true
 		}
 	}
 }
@@ -56,7 +64,9 @@ func (wm *OrgLoginPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
 		EventTypes(
 			org.LoginPolicyAddedEventType,
 			org.LoginPolicyChangedEventType,
-			org.LoginPolicyRemovedEventType).
+			org.LoginPolicyRemovedEventType,
+			org.OrgParentSetEventType,
+			org.OrgRemovedEventType).
 		Builder()
 }
 
@@ -72,7 +82,10 @@ func (wm *OrgLoginPolicyWriteModel) NewChangedEvent(
 	allowDomainDiscovery,
 	disableLoginWithEmail,
 	disableLoginWithPhone bool,
+	allowMagicLink,
+	allowPhoneOTP bool,
 	passwordlessType domain.PasswordlessType,
+	botProtection domain.BotProtectionType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
 	externalLoginCheckLifetime,
@@ -130,6 +143,15 @@ func (wm *OrgLoginPolicyWriteModel) NewChangedEvent(
 	if wm.DisableLoginWithPhone != disableLoginWithPhone {
 		changes = append(changes, policy.ChangeDisableLoginWithPhone(disableLoginWithPhone))
 	}
+	if wm.AllowMagicLink != allowMagicLink {
+		changes = append(changes, policy.ChangeAllowMagicLink(allowMagicLink))
+	}
+	if wm.AllowPhoneOTP != allowPhoneOTP {
+		changes = append(changes, policy.ChangeAllowPhoneOTP(allowPhoneOTP))
+	}
+	if wm.BotProtection != botProtection {
+		changes = append(changes, policy.ChangeBotProtection(botProtection))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
@@ -63,7 +63,10 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 								true,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"https://example.com/redirect",
 								time.Hour*1,
 								time.Hour*2,
@@ -118,7 +121,10 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 									true,
 									true,
 									true,
+									false,
+									false,
 									domain.PasswordlessTypeAllowed,
+									domain.BotProtectionTypeNone,
 									"https://example.com/redirect",
 									time.Hour*1,
 									time.Hour*2,
@@ -193,6 +199,32 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 				err: caos_errs.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "add policy with invalid bot protection, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &AddLoginPolicy{
+					AllowRegister:              true,
+					AllowUsernamePassword:      true,
+					PasswordlessType:           domain.PasswordlessTypeAllowed,
+					BotProtection:              domain.BotProtectionType(42),
+					PasswordCheckLifetime:      time.Hour * 1,
+					ExternalLoginCheckLifetime: time.Hour * 2,
+					MFAInitSkipLifetime:        time.Hour * 3,
+					SecondFactorCheckLifetime:  time.Hour * 4,
+					MultiFactorCheckLifetime:   time.Hour * 5,
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "add policy factors,ok",
 			fields: fields{
@@ -213,7 +245,10 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 									true,
 									true,
 									true,
+									false,
+									false,
 									domain.PasswordlessTypeAllowed,
+									domain.BotProtectionTypeNone,
 									"https://example.com/redirect",
 									time.Hour*1,
 									time.Hour*2,
@@ -341,7 +376,10 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 									true,
 									true,
 									true,
+									false,
+									false,
 									domain.PasswordlessTypeAllowed,
+									domain.BotProtectionTypeNone,
 									"https://example.com/redirect",
 									time.Hour*1,
 									time.Hour*2,
@@ -480,7 +518,10 @@ func TestCommandSide_ChangeLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"https://example.com/redirect",
 								time.Hour*1,
 								time.Hour*2,
@@ -536,7 +577,10 @@ func TestCommandSide_ChangeLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"https://example.com/redirect",
 								time.Hour*1,
 								time.Hour*2,
@@ -686,7 +730,10 @@ func TestCommandSide_RemoveLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -829,7 +876,10 @@ func TestCommandSide_AddIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -873,7 +923,10 @@ func TestCommandSide_AddIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -937,7 +990,10 @@ func TestCommandSide_AddIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -1104,7 +1160,10 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -1148,7 +1207,10 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -1204,7 +1266,10 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -1267,7 +1332,10 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -1338,7 +1406,10 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
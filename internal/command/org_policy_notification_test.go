@@ -19,9 +19,9 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		orgID          string
-		passwordChange bool
+		ctx    context.Context
+		orgID  string
+		policy *NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -41,9 +41,11 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "",
-				passwordChange: true,
+				ctx:   context.Background(),
+				orgID: "",
+				policy: &NotificationPolicy{
+					PasswordChange: true,
+				},
 			},
 			res: res{
 				err: caos_errs.IsErrorInvalidArgument,
@@ -59,15 +61,22 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &NotificationPolicy{
+					PasswordChange: true,
+				},
 			},
 			res: res{
 				err: caos_errs.IsErrorAlreadyExists,
@@ -85,6 +94,11 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 								org.NewNotificationPolicyAddedEvent(context.Background(),
 									&org.NewAggregate("org1").Aggregate,
 									true,
+									false,
+									false,
+									false,
+									false,
+									false,
 								),
 							),
 						},
@@ -92,9 +106,11 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &NotificationPolicy{
+					PasswordChange: true,
+				},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -114,6 +130,11 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 								org.NewNotificationPolicyAddedEvent(context.Background(),
 									&org.NewAggregate("org1").Aggregate,
 									false,
+									false,
+									false,
+									false,
+									false,
+									false,
 								),
 							),
 						},
@@ -121,9 +142,11 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: false,
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &NotificationPolicy{
+					PasswordChange: false,
+				},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -137,7 +160,7 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange)
+			got, err := r.AddNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.policy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -156,9 +179,9 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		orgID          string
-		passwordChange bool
+		ctx    context.Context
+		orgID  string
+		policy *NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -178,8 +201,10 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				passwordChange: true,
+				ctx: context.Background(),
+				policy: &NotificationPolicy{
+					PasswordChange: true,
+				},
 			},
 			res: res{
 				err: caos_errs.IsErrorInvalidArgument,
@@ -194,9 +219,11 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &NotificationPolicy{
+					PasswordChange: true,
+				},
 			},
 			res: res{
 				err: caos_errs.IsNotFound,
@@ -212,15 +239,22 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &NotificationPolicy{
+					PasswordChange: true,
+				},
 			},
 			res: res{
 				err: caos_errs.IsPreconditionFailed,
@@ -236,6 +270,11 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
@@ -249,9 +288,68 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: false,
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &NotificationPolicy{
+					PasswordChange: false,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "change security notifications, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewNotificationPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								func() *org.NotificationPolicyChangedEvent {
+									event, _ := org.NewNotificationPolicyChangedEvent(context.Background(),
+										&org.NewAggregate("org1").Aggregate,
+										[]policy.NotificationPolicyChanges{
+											policy.ChangeNewDeviceLogin(true),
+											policy.ChangeMFAAdded(true),
+											policy.ChangeMFARemoved(true),
+											policy.ChangeEmailChange(true),
+											policy.ChangeAccountLocked(true),
+										},
+									)
+									return event
+								}(),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &NotificationPolicy{
+					PasswordChange: true,
+					NewDeviceLogin: true,
+					MFAAdded:       true,
+					MFARemoved:     true,
+					EmailChange:    true,
+					AccountLocked:  true,
+				},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -265,7 +363,7 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.ChangeNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange)
+			got, err := r.ChangeNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.policy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -337,6 +435,11 @@ func TestCommandSide_RemoveNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
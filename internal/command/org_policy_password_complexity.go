@@ -8,13 +8,21 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/org"
 )
 
+// getOrgPasswordComplexityPolicy returns the policy of the org or the nearest ancestor defining one,
+// if none is found the default policy is returned
 func (c *Commands) getOrgPasswordComplexityPolicy(ctx context.Context, orgID string) (*domain.PasswordComplexityPolicy, error) {
-	policy, err := c.orgPasswordComplexityPolicyWriteModelByID(ctx, orgID)
-	if err != nil {
-		return nil, err
-	}
-	if policy.State == domain.PolicyStateActive {
-		return orgWriteModelToPasswordComplexityPolicy(policy), nil
+	for depth := 0; depth <= domain.OrgHierarchyMaxDepth; depth++ {
+		policy, err := c.orgPasswordComplexityPolicyWriteModelByID(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if policy.State == domain.PolicyStateActive {
+			return orgWriteModelToPasswordComplexityPolicy(policy), nil
+		}
+		if policy.orgRemoved || policy.ParentOrgID == "" {
+			break
+		}
+		orgID = policy.ParentOrgID
 	}
 	return c.getDefaultPasswordComplexityPolicy(ctx)
 }
@@ -11,11 +11,15 @@ import (
 
 type OrgPasswordComplexityPolicyWriteModel struct {
 	PasswordComplexityPolicyWriteModel
+
+	// ParentOrgID is used to inherit the policy of the ancestors
+	ParentOrgID string
+	orgRemoved  bool
 }
 
 func NewOrgPasswordComplexityPolicyWriteModel(orgID string) *OrgPasswordComplexityPolicyWriteModel {
 	return &OrgPasswordComplexityPolicyWriteModel{
-		PasswordComplexityPolicyWriteModel{
+		PasswordComplexityPolicyWriteModel: PasswordComplexityPolicyWriteModel{
 			WriteModel: eventstore.WriteModel{
 				AggregateID:   orgID,
 				ResourceOwner: orgID,
@@ -33,6 +37,10 @@ func (wm *OrgPasswordComplexityPolicyWriteModel) AppendEvents(events ...eventsto
 			wm.PasswordComplexityPolicyWriteModel.AppendEvents(&e.PasswordComplexityPolicyChangedEvent)
 		case *org.PasswordComplexityPolicyRemovedEvent:
 			wm.PasswordComplexityPolicyWriteModel.AppendEvents(&e.PasswordComplexityPolicyRemovedEvent)
+		case *org.OrgParentSetEvent:
+			wm.ParentOrgID = e.ParentID
+		case *org.OrgRemovedEvent:
+			wm.orgRemoved = true
 		}
 	}
 }
@@ -49,7 +57,9 @@ func (wm *OrgPasswordComplexityPolicyWriteModel) Query() *eventstore.SearchQuery
 		AggregateIDs(wm.PasswordComplexityPolicyWriteModel.AggregateID).
 		EventTypes(org.PasswordComplexityPolicyAddedEventType,
 			org.PasswordComplexityPolicyChangedEventType,
-			org.PasswordComplexityPolicyRemovedEventType).
+			org.PasswordComplexityPolicyRemovedEventType,
+			org.OrgParentSetEventType,
+			org.OrgRemovedEventType).
 		Builder()
 }
 
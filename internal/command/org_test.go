@@ -1139,6 +1139,7 @@ func TestCommandSide_RemoveOrg(t *testing.T) {
 					expectFilter(),
 					expectFilter(),
 					expectFilter(),
+					expectFilter(),
 					expectPushFailed(
 						errors.ThrowInternal(nil, "id", "message"),
 						[]*repository.Event{
@@ -1187,6 +1188,7 @@ func TestCommandSide_RemoveOrg(t *testing.T) {
 					expectFilter(),
 					expectFilter(),
 					expectFilter(),
+					expectFilter(),
 					expectPush(
 						[]*repository.Event{
 							eventFromEventPusher(
@@ -1272,14 +1274,28 @@ func TestCommandSide_RemoveOrg(t *testing.T) {
 					),
 					expectFilter(
 						eventFromEventPusher(
-							project.NewSAMLConfigAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate, "app1", "entity1", []byte{}, ""),
+							project.NewSAMLConfigAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate, "app1", "entity1", []byte{}, "", false, "", domain.SAMLSignatureAlgorithmUnspecified, domain.SAMLSigningModeAssertion, false, domain.SAMLNameIDFormatUnspecified, nil),
+						),
+						eventFromEventPusher(
+							project.NewSAMLConfigAddedEvent(context.Background(), &project.NewAggregate("project2", "org1").Aggregate, "app2", "entity2", []byte{}, "", false, "", domain.SAMLSignatureAlgorithmUnspecified, domain.SAMLSigningModeAssertion, false, domain.SAMLNameIDFormatUnspecified, nil),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainLoginAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "domain1"),
+						),
+						eventFromEventPusher(
+							org.NewDomainLoginAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "domain2"),
 						),
 						eventFromEventPusher(
-							project.NewSAMLConfigAddedEvent(context.Background(), &project.NewAggregate("project2", "org1").Aggregate, "app2", "entity2", []byte{}, ""),
+							org.NewDomainLoginRemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "domain2"),
 						),
 					),
 					expectPush(
 						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewDomainLoginRemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "domain1"),
+							),
 							eventFromEventPusher(
 								org.NewOrgRemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org",
 									[]string{"user1", "user2"},
@@ -1290,6 +1306,7 @@ func TestCommandSide_RemoveOrg(t *testing.T) {
 								),
 							),
 						},
+						uniqueConstraintsFromEventConstraint(org.NewRemoveOrgLoginDomainUniqueConstraint("domain1")),
 						uniqueConstraintsFromEventConstraint(org.NewRemoveOrgNameUniqueConstraint("org")),
 						uniqueConstraintsFromEventConstraint(user.NewRemoveUsernameUniqueConstraint("user1", "org1", true)),
 						uniqueConstraintsFromEventConstraint(user.NewRemoveUsernameUniqueConstraint("user2", "org1", true)),
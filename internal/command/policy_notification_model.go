@@ -3,27 +3,76 @@ package command
 import (
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
-	"github.com/zitadel/zitadel/internal/repository/policy"
+	policy_repo "github.com/zitadel/zitadel/internal/repository/policy"
 )
 
 type NotificationPolicyWriteModel struct {
 	eventstore.WriteModel
 
 	PasswordChange bool
+	NewDeviceLogin bool
+	MFAAdded       bool
+	MFARemoved     bool
+	EmailChange    bool
+	AccountLocked  bool
 	State          domain.PolicyState
 }
 
+// changes returns the changes needed to align the policy with the given notification settings
+func (wm *NotificationPolicyWriteModel) changes(policy *NotificationPolicy) []policy_repo.NotificationPolicyChanges {
+	changes := make([]policy_repo.NotificationPolicyChanges, 0)
+	if wm.PasswordChange != policy.PasswordChange {
+		changes = append(changes, policy_repo.ChangePasswordChange(policy.PasswordChange))
+	}
+	if wm.NewDeviceLogin != policy.NewDeviceLogin {
+		changes = append(changes, policy_repo.ChangeNewDeviceLogin(policy.NewDeviceLogin))
+	}
+	if wm.MFAAdded != policy.MFAAdded {
+		changes = append(changes, policy_repo.ChangeMFAAdded(policy.MFAAdded))
+	}
+	if wm.MFARemoved != policy.MFARemoved {
+		changes = append(changes, policy_repo.ChangeMFARemoved(policy.MFARemoved))
+	}
+	if wm.EmailChange != policy.EmailChange {
+		changes = append(changes, policy_repo.ChangeEmailChange(policy.EmailChange))
+	}
+	if wm.AccountLocked != policy.AccountLocked {
+		changes = append(changes, policy_repo.ChangeAccountLocked(policy.AccountLocked))
+	}
+	return changes
+}
+
 func (wm *NotificationPolicyWriteModel) Reduce() error {
 	for _, event := range wm.Events {
 		switch e := event.(type) {
-		case *policy.NotificationPolicyAddedEvent:
+		case *policy_repo.NotificationPolicyAddedEvent:
 			wm.PasswordChange = e.PasswordChange
+			wm.NewDeviceLogin = e.NewDeviceLogin
+			wm.MFAAdded = e.MFAAdded
+			wm.MFARemoved = e.MFARemoved
+			wm.EmailChange = e.EmailChange
+			wm.AccountLocked = e.AccountLocked
 			wm.State = domain.PolicyStateActive
-		case *policy.NotificationPolicyChangedEvent:
+		case *policy_repo.NotificationPolicyChangedEvent:
 			if e.PasswordChange != nil {
 				wm.PasswordChange = *e.PasswordChange
 			}
-		case *policy.NotificationPolicyRemovedEvent:
+			if e.NewDeviceLogin != nil {
+				wm.NewDeviceLogin = *e.NewDeviceLogin
+			}
+			if e.MFAAdded != nil {
+				wm.MFAAdded = *e.MFAAdded
+			}
+			if e.MFARemoved != nil {
+				wm.MFARemoved = *e.MFARemoved
+			}
+			if e.EmailChange != nil {
+				wm.EmailChange = *e.EmailChange
+			}
+			if e.AccountLocked != nil {
+				wm.AccountLocked = *e.AccountLocked
+			}
+		case *policy_repo.NotificationPolicyRemovedEvent:
 			wm.State = domain.PolicyStateRemoved
 		}
 	}
@@ -190,7 +190,8 @@ func (c *Commands) ChangeProject(ctx context.Context, projectChange *domain.Proj
 		projectChange.ProjectRoleAssertion,
 		projectChange.ProjectRoleCheck,
 		projectChange.HasProjectCheck,
-		projectChange.PrivateLabelingSetting)
+		projectChange.PrivateLabelingSetting,
+		projectChange.AllowImpersonation)
 	if err != nil {
 		return nil, err
 	}
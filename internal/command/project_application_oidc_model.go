@@ -19,6 +19,7 @@ type OIDCApplicationWriteModel struct {
 	ClientID                 string
 	ClientSecret             *crypto.CryptoValue
 	ClientSecretString       string
+	RegistrationAccessToken  *crypto.CryptoValue
 	RedirectUris             []string
 	ResponseTypes            []domain.OIDCResponseType
 	GrantTypes               []domain.OIDCGrantType
@@ -36,6 +37,7 @@ type OIDCApplicationWriteModel struct {
 	State                    domain.AppState
 	AdditionalOrigins        []string
 	SkipNativeAppSuccessPage bool
+	RequireConsent           bool
 	oidc                     bool
 }
 
@@ -100,6 +102,11 @@ func (wm *OIDCApplicationWriteModel) AppendEvents(events ...eventstore.Event) {
 				continue
 			}
 			wm.WriteModel.AppendEvents(e)
+		case *project.OIDCConfigRegistrationTokenSetEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
 		case *project.ProjectRemovedEvent:
 			wm.WriteModel.AppendEvents(e)
 		}
@@ -132,6 +139,8 @@ func (wm *OIDCApplicationWriteModel) Reduce() error {
 			wm.appendChangeOIDCEvent(e)
 		case *project.OIDCConfigSecretChangedEvent:
 			wm.ClientSecret = e.ClientSecret
+		case *project.OIDCConfigRegistrationTokenSetEvent:
+			wm.RegistrationAccessToken = e.RegistrationAccessToken
 		case *project.ProjectRemovedEvent:
 			wm.State = domain.AppStateRemoved
 		}
@@ -158,6 +167,7 @@ func (wm *OIDCApplicationWriteModel) appendAddOIDCEvent(e *project.OIDCConfigAdd
 	wm.ClockSkew = e.ClockSkew
 	wm.AdditionalOrigins = e.AdditionalOrigins
 	wm.SkipNativeAppSuccessPage = e.SkipNativeAppSuccessPage
+	wm.RequireConsent = e.RequireConsent
 }
 
 func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfigChangedEvent) {
@@ -206,6 +216,9 @@ func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfig
 	if e.SkipNativeAppSuccessPage != nil {
 		wm.SkipNativeAppSuccessPage = *e.SkipNativeAppSuccessPage
 	}
+	if e.RequireConsent != nil {
+		wm.RequireConsent = *e.RequireConsent
+	}
 }
 
 func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -223,6 +236,7 @@ func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
 			project.OIDCConfigAddedType,
 			project.OIDCConfigChangedType,
 			project.OIDCConfigSecretChangedType,
+			project.OIDCConfigRegistrationTokenSetType,
 			project.ProjectRemovedType).
 		Builder()
 }
@@ -245,7 +259,8 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	idTokenUserinfoAssertion bool,
 	clockSkew time.Duration,
 	additionalOrigins []string,
-	skipNativeAppSuccessPage bool,
+	skipNativeAppSuccessPage,
+	requireConsent bool,
 ) (*project.OIDCConfigChangedEvent, bool, error) {
 	changes := make([]project.OIDCConfigChanges, 0)
 	var err error
@@ -295,6 +310,9 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	if wm.SkipNativeAppSuccessPage != skipNativeAppSuccessPage {
 		changes = append(changes, project.ChangeSkipNativeAppSuccessPage(skipNativeAppSuccessPage))
 	}
+	if wm.RequireConsent != requireConsent {
+		changes = append(changes, project.ChangeRequireConsent(requireConsent))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
@@ -170,6 +170,7 @@ func TestAddOIDCApp(t *testing.T) {
 						0,
 						nil,
 						false,
+						false,
 					),
 				},
 			},
@@ -328,6 +329,7 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 									time.Second*1,
 									[]string{"https://sub.test.ch"},
 									true,
+									false,
 								),
 							),
 						},
@@ -565,6 +567,7 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								false,
 							),
 						),
 					),
@@ -639,6 +642,7 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								false,
 							),
 						),
 					),
@@ -840,6 +844,7 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								false,
+								false,
 							),
 						),
 					),
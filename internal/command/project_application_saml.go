@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
 
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
@@ -62,6 +63,9 @@ func (c *Commands) addSAMLApplication(ctx context.Context, projectAgg *eventstor
 	if err != nil {
 		return nil, caos_errs.ThrowInvalidArgument(err, "SAML-bquso", "Errors.Project.App.SAMLMetadataFormat")
 	}
+	if samlApp.EncryptAssertion && !hasEncryptionCertificate(entity) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "SAML-Ek2n1", "Errors.Project.App.SAMLEncryptionCertificateMissing")
+	}
 
 	samlApp.AppID, err = c.idGenerator.Next()
 	if err != nil {
@@ -76,6 +80,13 @@ func (c *Commands) addSAMLApplication(ctx context.Context, projectAgg *eventstor
 			string(entity.EntityID),
 			samlApp.Metadata,
 			samlApp.MetadataURL,
+			samlApp.IDPInitiatedSSO,
+			samlApp.DefaultRelayState,
+			samlApp.SignatureAlgorithm,
+			samlApp.SigningMode,
+			samlApp.EncryptAssertion,
+			samlApp.NameIDFormat,
+			samlApp.AttributeMappings,
 		),
 	}, nil
 }
@@ -109,6 +120,9 @@ func (c *Commands) ChangeSAMLApplication(ctx context.Context, samlApp *domain.SA
 	if err != nil {
 		return nil, caos_errs.ThrowInvalidArgument(err, "SAML-3fk2b", "Errors.Project.App.SAMLMetadataFormat")
 	}
+	if samlApp.EncryptAssertion && !hasEncryptionCertificate(entity) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "SAML-Ek2n2", "Errors.Project.App.SAMLEncryptionCertificateMissing")
+	}
 
 	changedEvent, hasChanged, err := existingSAML.NewChangedEvent(
 		ctx,
@@ -116,7 +130,15 @@ func (c *Commands) ChangeSAMLApplication(ctx context.Context, samlApp *domain.SA
 		samlApp.AppID,
 		string(entity.EntityID),
 		samlApp.Metadata,
-		samlApp.MetadataURL)
+		samlApp.MetadataURL,
+		samlApp.IDPInitiatedSSO,
+		samlApp.DefaultRelayState,
+		samlApp.SignatureAlgorithm,
+		samlApp.SigningMode,
+		samlApp.EncryptAssertion,
+		samlApp.NameIDFormat,
+		samlApp.AttributeMappings,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -144,3 +166,22 @@ func (c *Commands) getSAMLAppWriteModel(ctx context.Context, projectID, appID, r
 	}
 	return appWriteModel, nil
 }
+
+// hasEncryptionCertificate checks if the service provider provides a certificate
+// the assertions can be encrypted with
+func hasEncryptionCertificate(entity *md.EntityDescriptorType) bool {
+	if entity.SPSSODescriptor == nil {
+		return false
+	}
+	for _, keyDescriptor := range entity.SPSSODescriptor.KeyDescriptor {
+		if keyDescriptor.Use != "" && keyDescriptor.Use != md.KeyTypesEncryption {
+			continue
+		}
+		for _, data := range keyDescriptor.KeyInfo.X509Data {
+			if data.X509Certificate != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -12,11 +12,18 @@ import (
 type SAMLApplicationWriteModel struct {
 	eventstore.WriteModel
 
-	AppID       string
-	AppName     string
-	EntityID    string
-	Metadata    []byte
-	MetadataURL string
+	AppID              string
+	AppName            string
+	EntityID           string
+	Metadata           []byte
+	MetadataURL        string
+	IDPInitiatedSSO    bool
+	DefaultRelayState  string
+	SignatureAlgorithm domain.SAMLSignatureAlgorithm
+	SigningMode        domain.SAMLSigningMode
+	EncryptAssertion   bool
+	NameIDFormat       domain.SAMLNameIDFormat
+	AttributeMappings  domain.SAMLAttributeMappings
 
 	State domain.AppState
 	saml  bool
@@ -121,6 +128,13 @@ func (wm *SAMLApplicationWriteModel) appendAddSAMLEvent(e *project.SAMLConfigAdd
 	wm.Metadata = e.Metadata
 	wm.MetadataURL = e.MetadataURL
 	wm.EntityID = e.EntityID
+	wm.IDPInitiatedSSO = e.IDPInitiatedSSO
+	wm.DefaultRelayState = e.DefaultRelayState
+	wm.SignatureAlgorithm = e.SignatureAlgorithm
+	wm.SigningMode = e.SigningMode
+	wm.EncryptAssertion = e.EncryptAssertion
+	wm.NameIDFormat = e.NameIDFormat
+	wm.AttributeMappings = e.AttributeMappings
 }
 
 func (wm *SAMLApplicationWriteModel) appendChangeSAMLEvent(e *project.SAMLConfigChangedEvent) {
@@ -134,6 +148,27 @@ func (wm *SAMLApplicationWriteModel) appendChangeSAMLEvent(e *project.SAMLConfig
 	if e.EntityID != "" {
 		wm.EntityID = e.EntityID
 	}
+	if e.IDPInitiatedSSO != nil {
+		wm.IDPInitiatedSSO = *e.IDPInitiatedSSO
+	}
+	if e.DefaultRelayState != nil {
+		wm.DefaultRelayState = *e.DefaultRelayState
+	}
+	if e.SignatureAlgorithm != nil {
+		wm.SignatureAlgorithm = *e.SignatureAlgorithm
+	}
+	if e.SigningMode != nil {
+		wm.SigningMode = *e.SigningMode
+	}
+	if e.EncryptAssertion != nil {
+		wm.EncryptAssertion = *e.EncryptAssertion
+	}
+	if e.NameIDFormat != nil {
+		wm.NameIDFormat = *e.NameIDFormat
+	}
+	if e.AttributeMappings != nil {
+		wm.AttributeMappings = *e.AttributeMappings
+	}
 }
 
 func (wm *SAMLApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -161,6 +196,13 @@ func (wm *SAMLApplicationWriteModel) NewChangedEvent(
 	entityID string,
 	metadata []byte,
 	metadataURL string,
+	idpInitiatedSSO bool,
+	defaultRelayState string,
+	signatureAlgorithm domain.SAMLSignatureAlgorithm,
+	signingMode domain.SAMLSigningMode,
+	encryptAssertion bool,
+	nameIDFormat domain.SAMLNameIDFormat,
+	attributeMappings domain.SAMLAttributeMappings,
 ) (*project.SAMLConfigChangedEvent, bool, error) {
 	changes := make([]project.SAMLConfigChanges, 0)
 	var err error
@@ -173,6 +215,27 @@ func (wm *SAMLApplicationWriteModel) NewChangedEvent(
 	if wm.EntityID != entityID {
 		changes = append(changes, project.ChangeEntityID(entityID))
 	}
+	if wm.IDPInitiatedSSO != idpInitiatedSSO {
+		changes = append(changes, project.ChangeIDPInitiatedSSO(idpInitiatedSSO))
+	}
+	if wm.DefaultRelayState != defaultRelayState {
+		changes = append(changes, project.ChangeDefaultRelayState(defaultRelayState))
+	}
+	if wm.SignatureAlgorithm != signatureAlgorithm {
+		changes = append(changes, project.ChangeSignatureAlgorithm(signatureAlgorithm))
+	}
+	if wm.SigningMode != signingMode {
+		changes = append(changes, project.ChangeSigningMode(signingMode))
+	}
+	if wm.EncryptAssertion != encryptAssertion {
+		changes = append(changes, project.ChangeEncryptAssertion(encryptAssertion))
+	}
+	if wm.NameIDFormat != nameIDFormat {
+		changes = append(changes, project.ChangeNameIDFormat(nameIDFormat))
+	}
+	if !wm.AttributeMappings.Equal(attributeMappings) {
+		changes = append(changes, project.ChangeAttributeMappings(attributeMappings))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
@@ -47,6 +47,28 @@ var testMetadataChangedEntityID = []byte(`<?xml version="1.0"?>
     </md:SPSSODescriptor>
 </md:EntityDescriptor>
 `)
+var testMetadataEncryption = []byte(`<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata"
+                     xmlns:ds="http://www.w3.org/2000/09/xmldsig#"
+                     validUntil="2022-08-26T14:08:16Z"
+                     cacheDuration="PT604800S"
+                     entityID="https://test.com/saml/metadata">
+    <md:SPSSODescriptor AuthnRequestsSigned="false" WantAssertionsSigned="false" protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+        <md:KeyDescriptor use="encryption">
+            <ds:KeyInfo>
+                <ds:X509Data>
+                    <ds:X509Certificate>MIIBcertificate</ds:X509Certificate>
+                </ds:X509Data>
+            </ds:KeyInfo>
+        </md:KeyDescriptor>
+        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>
+        <md:AssertionConsumerService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+                                     Location="https://test.com/saml/acs"
+                                     index="1" />
+        
+    </md:SPSSODescriptor>
+</md:EntityDescriptor>
+`)
 
 func TestCommandSide_AddSAMLApplication(t *testing.T) {
 	type fields struct {
@@ -200,6 +222,13 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 									"https://test.com/saml/metadata",
 									testMetadata,
 									"",
+									false,
+									"",
+									domain.SAMLSignatureAlgorithmUnspecified,
+									domain.SAMLSigningModeAssertion,
+									false,
+									domain.SAMLNameIDFormatUnspecified,
+									nil,
 								),
 							),
 						},
@@ -266,6 +295,13 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 									"https://test.com/saml/metadata",
 									testMetadata,
 									"http://localhost:8080/saml/metadata",
+									false,
+									"",
+									domain.SAMLSignatureAlgorithmUnspecified,
+									domain.SAMLSigningModeAssertion,
+									false,
+									domain.SAMLNameIDFormatUnspecified,
+									nil,
 								),
 							),
 						},
@@ -490,6 +526,13 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"http://localhost:8080/saml/metadata",
+								false,
+								"",
+								domain.SAMLSignatureAlgorithmUnspecified,
+								domain.SAMLSigningModeAssertion,
+								false,
+								domain.SAMLNameIDFormatUnspecified,
+								nil,
 							),
 						),
 					),
@@ -535,6 +578,13 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"",
+								false,
+								"",
+								domain.SAMLSignatureAlgorithmUnspecified,
+								domain.SAMLSigningModeAssertion,
+								false,
+								domain.SAMLNameIDFormatUnspecified,
+								nil,
 							),
 						),
 					),
@@ -580,6 +630,13 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"http://localhost:8080/saml/metadata",
+								false,
+								"",
+								domain.SAMLSignatureAlgorithmUnspecified,
+								domain.SAMLSigningModeAssertion,
+								false,
+								domain.SAMLNameIDFormatUnspecified,
+								nil,
 							),
 						),
 					),
@@ -652,6 +709,13 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"",
+								false,
+								"",
+								domain.SAMLSignatureAlgorithmUnspecified,
+								domain.SAMLSigningModeAssertion,
+								false,
+								domain.SAMLNameIDFormatUnspecified,
+								nil,
 							),
 						),
 					),
@@ -704,6 +768,341 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "change saml app, ok, idp initiated sso",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewSAMLConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"https://test.com/saml/metadata",
+								testMetadata,
+								"",
+								false,
+								"",
+								domain.SAMLSignatureAlgorithmUnspecified,
+								domain.SAMLSigningModeAssertion,
+								false,
+								domain.SAMLNameIDFormatUnspecified,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newSAMLAppChangedEventIDPInitiatedSSO(context.Background(),
+									"app1",
+									"project1",
+									"org1",
+									"https://test.com/saml/metadata",
+									true,
+									"https://test.com/home",
+								),
+							),
+						},
+					),
+				),
+				httpClient: nil,
+			},
+			args: args{
+				ctx: context.Background(),
+				samlApp: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:             "app1",
+					AppName:           "app",
+					EntityID:          "https://test.com/saml/metadata",
+					Metadata:          testMetadata,
+					MetadataURL:       "",
+					IDPInitiatedSSO:   true,
+					DefaultRelayState: "https://test.com/home",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:             "app1",
+					AppName:           "app",
+					EntityID:          "https://test.com/saml/metadata",
+					Metadata:          testMetadata,
+					MetadataURL:       "",
+					IDPInitiatedSSO:   true,
+					DefaultRelayState: "https://test.com/home",
+					State:             domain.AppStateActive,
+				},
+			},
+		},
+		{
+			name: "change saml app, encryption certificate missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewSAMLConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"https://test.com/saml/metadata",
+								testMetadata,
+								"",
+								false,
+								"",
+								domain.SAMLSignatureAlgorithmUnspecified,
+								domain.SAMLSigningModeAssertion,
+								false,
+								domain.SAMLNameIDFormatUnspecified,
+								nil,
+							),
+						),
+					),
+				),
+				httpClient: nil,
+			},
+			args: args{
+				ctx: context.Background(),
+				samlApp: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:            "app1",
+					AppName:          "app",
+					EntityID:         "https://test.com/saml/metadata",
+					Metadata:         testMetadata,
+					MetadataURL:      "",
+					EncryptAssertion: true,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "change saml app, ok, signing and encryption",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewSAMLConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"https://test.com/saml/metadata",
+								testMetadataEncryption,
+								"",
+								false,
+								"",
+								domain.SAMLSignatureAlgorithmUnspecified,
+								domain.SAMLSigningModeAssertion,
+								false,
+								domain.SAMLNameIDFormatUnspecified,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newSAMLAppChangedEventSigning(context.Background(),
+									"app1",
+									"project1",
+									"org1",
+									"https://test.com/saml/metadata",
+									domain.SAMLSignatureAlgorithmRSASHA512,
+									domain.SAMLSigningModeResponseAndAssertion,
+									true,
+								),
+							),
+						},
+					),
+				),
+				httpClient: nil,
+			},
+			args: args{
+				ctx: context.Background(),
+				samlApp: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:              "app1",
+					AppName:            "app",
+					EntityID:           "https://test.com/saml/metadata",
+					Metadata:           testMetadataEncryption,
+					MetadataURL:        "",
+					SignatureAlgorithm: domain.SAMLSignatureAlgorithmRSASHA512,
+					SigningMode:        domain.SAMLSigningModeResponseAndAssertion,
+					EncryptAssertion:   true,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:              "app1",
+					AppName:            "app",
+					EntityID:           "https://test.com/saml/metadata",
+					Metadata:           testMetadataEncryption,
+					MetadataURL:        "",
+					SignatureAlgorithm: domain.SAMLSignatureAlgorithmRSASHA512,
+					SigningMode:        domain.SAMLSigningModeResponseAndAssertion,
+					EncryptAssertion:   true,
+					State:              domain.AppStateActive,
+				},
+			},
+		},
+		{
+			name: "change saml app, invalid attribute mapping, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				samlApp: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:    "app1",
+					AppName:  "app",
+					Metadata: testMetadata,
+					AttributeMappings: domain.SAMLAttributeMappings{
+						{Name: "department", Source: domain.SAMLAttributeSourceMetadata},
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "change saml app, ok, name id format and attribute mappings",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewSAMLConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"https://test.com/saml/metadata",
+								testMetadata,
+								"",
+								false,
+								"",
+								domain.SAMLSignatureAlgorithmUnspecified,
+								domain.SAMLSigningModeAssertion,
+								false,
+								domain.SAMLNameIDFormatUnspecified,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newSAMLAppChangedEventAttributes(context.Background(),
+									"app1",
+									"project1",
+									"org1",
+									"https://test.com/saml/metadata",
+									domain.SAMLNameIDFormatPersistent,
+									domain.SAMLAttributeMappings{
+										{Name: "urn:oid:0.9.2342.19200300.100.1.3", FriendlyName: "mail", Source: domain.SAMLAttributeSourceEmail},
+										{Name: "department", Source: domain.SAMLAttributeSourceMetadata, MetadataKey: "department"},
+									},
+								),
+							),
+						},
+					),
+				),
+				httpClient: nil,
+			},
+			args: args{
+				ctx: context.Background(),
+				samlApp: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:        "app1",
+					AppName:      "app",
+					EntityID:     "https://test.com/saml/metadata",
+					Metadata:     testMetadata,
+					MetadataURL:  "",
+					NameIDFormat: domain.SAMLNameIDFormatPersistent,
+					AttributeMappings: domain.SAMLAttributeMappings{
+						{Name: "urn:oid:0.9.2342.19200300.100.1.3", FriendlyName: "mail", Source: domain.SAMLAttributeSourceEmail},
+						{Name: "department", Source: domain.SAMLAttributeSourceMetadata, MetadataKey: "department"},
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:        "app1",
+					AppName:      "app",
+					EntityID:     "https://test.com/saml/metadata",
+					Metadata:     testMetadata,
+					MetadataURL:  "",
+					NameIDFormat: domain.SAMLNameIDFormatPersistent,
+					AttributeMappings: domain.SAMLAttributeMappings{
+						{Name: "urn:oid:0.9.2342.19200300.100.1.3", FriendlyName: "mail", Source: domain.SAMLAttributeSourceEmail},
+						{Name: "department", Source: domain.SAMLAttributeSourceMetadata, MetadataKey: "department"},
+					},
+					State: domain.AppStateActive,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -774,3 +1173,46 @@ func newTestClient(httpStatus int, metadata []byte) *http.Client {
 		Transport: fn,
 	}
 }
+
+func newSAMLAppChangedEventIDPInitiatedSSO(ctx context.Context, appID, projectID, resourceOwner, entityID string, idpInitiatedSSO bool, defaultRelayState string) *project.SAMLConfigChangedEvent {
+	changes := []project.SAMLConfigChanges{
+		project.ChangeIDPInitiatedSSO(idpInitiatedSSO),
+		project.ChangeDefaultRelayState(defaultRelayState),
+	}
+	event, _ := project.NewSAMLConfigChangedEvent(ctx,
+		&project.NewAggregate(projectID, resourceOwner).Aggregate,
+		appID,
+		entityID,
+		changes,
+	)
+	return event
+}
+
+func newSAMLAppChangedEventSigning(ctx context.Context, appID, projectID, resourceOwner, entityID string, signatureAlgorithm domain.SAMLSignatureAlgorithm, signingMode domain.SAMLSigningMode, encryptAssertion bool) *project.SAMLConfigChangedEvent {
+	changes := []project.SAMLConfigChanges{
+		project.ChangeSignatureAlgorithm(signatureAlgorithm),
+		project.ChangeSigningMode(signingMode),
+		project.ChangeEncryptAssertion(encryptAssertion),
+	}
+	event, _ := project.NewSAMLConfigChangedEvent(ctx,
+		&project.NewAggregate(projectID, resourceOwner).Aggregate,
+		appID,
+		entityID,
+		changes,
+	)
+	return event
+}
+
+func newSAMLAppChangedEventAttributes(ctx context.Context, appID, projectID, resourceOwner, entityID string, nameIDFormat domain.SAMLNameIDFormat, attributeMappings domain.SAMLAttributeMappings) *project.SAMLConfigChangedEvent {
+	changes := []project.SAMLConfigChanges{
+		project.ChangeNameIDFormat(nameIDFormat),
+		project.ChangeAttributeMappings(attributeMappings),
+	}
+	event, _ := project.NewSAMLConfigChangedEvent(ctx,
+		&project.NewAggregate(projectID, resourceOwner).Aggregate,
+		appID,
+		entityID,
+		changes,
+	)
+	return event
+}
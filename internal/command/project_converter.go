@@ -12,6 +12,7 @@ func projectWriteModelToProject(writeModel *ProjectWriteModel) *domain.Project {
 		ProjectRoleCheck:       writeModel.ProjectRoleCheck,
 		HasProjectCheck:        writeModel.HasProjectCheck,
 		PrivateLabelingSetting: writeModel.PrivateLabelingSetting,
+		AllowImpersonation:     writeModel.AllowImpersonation,
 	}
 }
 
@@ -47,18 +48,26 @@ func oidcWriteModelToOIDCConfig(writeModel *OIDCApplicationWriteModel) *domain.O
 		ClockSkew:                writeModel.ClockSkew,
 		AdditionalOrigins:        writeModel.AdditionalOrigins,
 		SkipNativeAppSuccessPage: writeModel.SkipNativeAppSuccessPage,
+		RequireConsent:           writeModel.RequireConsent,
 	}
 }
 
 func samlWriteModelToSAMLConfig(writeModel *SAMLApplicationWriteModel) *domain.SAMLApp {
 	return &domain.SAMLApp{
-		ObjectRoot:  writeModelToObjectRoot(writeModel.WriteModel),
-		AppID:       writeModel.AppID,
-		AppName:     writeModel.AppName,
-		State:       writeModel.State,
-		Metadata:    writeModel.Metadata,
-		MetadataURL: writeModel.MetadataURL,
-		EntityID:    writeModel.EntityID,
+		ObjectRoot:         writeModelToObjectRoot(writeModel.WriteModel),
+		AppID:              writeModel.AppID,
+		AppName:            writeModel.AppName,
+		State:              writeModel.State,
+		Metadata:           writeModel.Metadata,
+		MetadataURL:        writeModel.MetadataURL,
+		EntityID:           writeModel.EntityID,
+		IDPInitiatedSSO:    writeModel.IDPInitiatedSSO,
+		DefaultRelayState:  writeModel.DefaultRelayState,
+		SignatureAlgorithm: writeModel.SignatureAlgorithm,
+		SigningMode:        writeModel.SigningMode,
+		EncryptAssertion:   writeModel.EncryptAssertion,
+		NameIDFormat:       writeModel.NameIDFormat,
+		AttributeMappings:  writeModel.AttributeMappings,
 	}
 }
 
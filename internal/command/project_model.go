@@ -16,6 +16,7 @@ type ProjectWriteModel struct {
 	ProjectRoleCheck       bool
 	HasProjectCheck        bool
 	PrivateLabelingSetting domain.PrivateLabelingSetting
+	AllowImpersonation     bool
 	State                  domain.ProjectState
 }
 
@@ -54,6 +55,9 @@ func (wm *ProjectWriteModel) Reduce() error {
 			if e.PrivateLabelingSetting != nil {
 				wm.PrivateLabelingSetting = *e.PrivateLabelingSetting
 			}
+			if e.AllowImpersonation != nil {
+				wm.AllowImpersonation = *e.AllowImpersonation
+			}
 		case *project.ProjectDeactivatedEvent:
 			if wm.State == domain.ProjectStateRemoved {
 				continue
@@ -93,6 +97,7 @@ func (wm *ProjectWriteModel) NewChangedEvent(
 	projectRoleCheck,
 	hasProjectCheck bool,
 	privateLabelingSetting domain.PrivateLabelingSetting,
+	allowImpersonation bool,
 ) (*project.ProjectChangeEvent, bool, error) {
 	changes := make([]project.ProjectChanges, 0)
 	var err error
@@ -114,6 +119,9 @@ func (wm *ProjectWriteModel) NewChangedEvent(
 	if wm.PrivateLabelingSetting != privateLabelingSetting {
 		changes = append(changes, project.ChangePrivateLabelingSetting(privateLabelingSetting))
 	}
+	if wm.AllowImpersonation != allowImpersonation {
+		changes = append(changes, project.ChangeAllowImpersonation(allowImpersonation))
+	}
 	if len(changes) == 0 {
 		return nil, false, nil
 	}
@@ -0,0 +1,323 @@
+package command
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// ProjectRegistrationToken is an initial access token (RFC 7591)
+// which allows the registration of OIDC clients in the project
+type ProjectRegistrationToken struct {
+	models.ObjectRoot
+
+	TokenID        string
+	ExpirationDate time.Time
+
+	Token string
+}
+
+func NewProjectRegistrationToken(projectID, resourceOwner string, expirationDate time.Time) *ProjectRegistrationToken {
+	return &ProjectRegistrationToken{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		ExpirationDate: expirationDate,
+	}
+}
+
+func (c *Commands) AddProjectRegistrationToken(ctx context.Context, token *ProjectRegistrationToken) (_ *domain.ObjectDetails, err error) {
+	if token.AggregateID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Rt8sd", "Errors.Project.ProjectIDMissing")
+	}
+	token.ExpirationDate, err = domain.ValidateExpirationDate(token.ExpirationDate)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.checkProjectExists(ctx, token.AggregateID, token.ResourceOwner); err != nil {
+		return nil, err
+	}
+	token.TokenID, err = c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	writeModel, err := c.projectRegistrationTokenWriteModelByID(ctx, token.AggregateID, token.TokenID, token.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	token.Token, err = createToken(c.keyAlgorithm, token.TokenID, token.AggregateID)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project.NewRegistrationTokenAddedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+		token.TokenID,
+		token.ExpirationDate,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveProjectRegistrationToken(ctx context.Context, projectID, tokenID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if projectID == "" || tokenID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Rt9sd", "Errors.IDMissing")
+	}
+	writeModel, err := c.projectRegistrationTokenWriteModelByID(ctx, projectID, tokenID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.Exists() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Rt0sd", "Errors.Project.RegistrationToken.NotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project.NewRegistrationTokenRemovedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+		tokenID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RegisterOIDCClient creates an OIDC application (RFC 7591) in the project the registration token was issued for.
+// It returns the created application and the registration access token for the client configuration endpoint (RFC 7592).
+func (c *Commands) RegisterOIDCClient(ctx context.Context, registrationToken string, oidcApp *domain.OIDCApp, appSecretGenerator crypto.Generator) (_ *domain.OIDCApp, registrationAccessToken string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	tokenWriteModel, err := c.verifyProjectRegistrationToken(ctx, registrationToken)
+	if err != nil {
+		return nil, "", err
+	}
+	if oidcApp == nil {
+		return nil, "", errors.ThrowInvalidArgument(nil, "COMMAND-Rg3fs", "Errors.Project.App.Invalid")
+	}
+	oidcApp.AggregateID = tokenWriteModel.AggregateID
+	if err = checkRegisteredOIDCClient(oidcApp); err != nil {
+		return nil, "", err
+	}
+	project, err := c.getProjectByID(ctx, tokenWriteModel.AggregateID, tokenWriteModel.ResourceOwner)
+	if err != nil {
+		return nil, "", errors.ThrowPreconditionFailed(err, "COMMAND-Rg4fs", "Errors.Project.NotFound")
+	}
+	return c.registerOIDCClient(ctx, oidcApp, project, appSecretGenerator)
+}
+
+// RegisterOIDCClientInProject creates an OIDC application (RFC 7591) in the project,
+// if the user of the context (e.g. a machine user authenticated by a personal access token) is allowed to write its applications.
+// It returns the created application and the registration access token for the client configuration endpoint (RFC 7592).
+func (c *Commands) RegisterOIDCClientInProject(ctx context.Context, projectID string, oidcApp *domain.OIDCApp, appSecretGenerator crypto.Generator) (_ *domain.OIDCApp, registrationAccessToken string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if projectID == "" {
+		return nil, "", errors.ThrowInvalidArgument(nil, "COMMAND-Rg1ps", "Errors.Project.ProjectIDMissing")
+	}
+	if oidcApp == nil {
+		return nil, "", errors.ThrowInvalidArgument(nil, "COMMAND-Rg2ps", "Errors.Project.App.Invalid")
+	}
+	oidcApp.AggregateID = projectID
+	if err = checkRegisteredOIDCClient(oidcApp); err != nil {
+		return nil, "", err
+	}
+	project, err := c.getProjectByID(ctx, projectID, "")
+	if err != nil {
+		return nil, "", errors.ThrowPreconditionFailed(err, "COMMAND-Rg3ps", "Errors.Project.NotFound")
+	}
+	if err = c.checkPermission(ctx, domain.PermissionProjectAppWrite, project.ResourceOwner, projectID); err != nil {
+		return nil, "", err
+	}
+	return c.registerOIDCClient(ctx, oidcApp, project, appSecretGenerator)
+}
+
+func (c *Commands) registerOIDCClient(ctx context.Context, oidcApp *domain.OIDCApp, project *domain.Project, appSecretGenerator crypto.Generator) (_ *domain.OIDCApp, registrationAccessToken string, err error) {
+	appID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, "", err
+	}
+	cryptoToken, registrationAccessToken, err := crypto.NewCode(appSecretGenerator)
+	if err != nil {
+		return nil, "", err
+	}
+	app, err := c.addOIDCApplicationWithID(ctx, oidcApp, project.ResourceOwner, project, appID, appSecretGenerator, cryptoToken)
+	if err != nil {
+		return nil, "", err
+	}
+	return app, registrationAccessToken, nil
+}
+
+// GetRegisteredOIDCClient returns the OIDC application if the registration access token is valid
+func (c *Commands) GetRegisteredOIDCClient(ctx context.Context, projectID, appID, registrationAccessToken string) (*domain.OIDCApp, error) {
+	existingOIDC, err := c.verifyOIDCClientRegistrationAccessToken(ctx, projectID, appID, registrationAccessToken)
+	if err != nil {
+		return nil, err
+	}
+	result := oidcWriteModelToOIDCConfig(existingOIDC)
+	result.FillCompliance()
+	return result, nil
+}
+
+// ChangeRegisteredOIDCClient replaces the client metadata (RFC 7592) of the OIDC application,
+// configuration which cannot be registered by the client itself remains unchanged
+func (c *Commands) ChangeRegisteredOIDCClient(ctx context.Context, oidcApp *domain.OIDCApp, registrationAccessToken string) (*domain.OIDCApp, error) {
+	existingOIDC, err := c.verifyOIDCClientRegistrationAccessToken(ctx, oidcApp.AggregateID, oidcApp.AppID, registrationAccessToken)
+	if err != nil {
+		return nil, err
+	}
+	oidcApp.OIDCVersion = existingOIDC.OIDCVersion
+	if err = checkRegisteredOIDCClient(oidcApp); err != nil {
+		return nil, err
+	}
+	projectAgg := ProjectAggregateFromWriteModel(&existingOIDC.WriteModel)
+	events := make([]eventstore.Command, 0, 2)
+	if oidcApp.AppName != existingOIDC.AppName {
+		events = append(events, project.NewApplicationChangedEvent(ctx, projectAgg, oidcApp.AppID, existingOIDC.AppName, oidcApp.AppName))
+	}
+	changedEvent, hasChanged, err := existingOIDC.NewChangedEvent(
+		ctx,
+		projectAgg,
+		oidcApp.AppID,
+		oidcApp.RedirectUris,
+		oidcApp.PostLogoutRedirectUris,
+		oidcApp.ResponseTypes,
+		oidcApp.GrantTypes,
+		oidcApp.ApplicationType,
+		oidcApp.AuthMethodType,
+		existingOIDC.OIDCVersion,
+		existingOIDC.AccessTokenType,
+		existingOIDC.DevMode,
+		existingOIDC.AccessTokenRoleAssertion,
+		existingOIDC.IDTokenRoleAssertion,
+		existingOIDC.IDTokenUserinfoAssertion,
+		existingOIDC.ClockSkew,
+		existingOIDC.AdditionalOrigins,
+		existingOIDC.SkipNativeAppSuccessPage,
+		existingOIDC.RequireConsent,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if hasChanged {
+		events = append(events, changedEvent)
+	}
+	if len(events) > 0 {
+		pushedEvents, err := c.eventstore.Push(ctx, events...)
+		if err != nil {
+			return nil, err
+		}
+		err = AppendAndReduce(existingOIDC, pushedEvents...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := oidcWriteModelToOIDCConfig(existingOIDC)
+	result.FillCompliance()
+	return result, nil
+}
+
+// RemoveRegisteredOIDCClient removes the OIDC application (RFC 7592) if the registration access token is valid
+func (c *Commands) RemoveRegisteredOIDCClient(ctx context.Context, projectID, appID, registrationAccessToken string) (*domain.ObjectDetails, error) {
+	existingOIDC, err := c.verifyOIDCClientRegistrationAccessToken(ctx, projectID, appID, registrationAccessToken)
+	if err != nil {
+		return nil, err
+	}
+	return c.RemoveApplication(ctx, projectID, appID, existingOIDC.ResourceOwner)
+}
+
+func (c *Commands) verifyProjectRegistrationToken(ctx context.Context, registrationToken string) (*ProjectRegistrationTokenWriteModel, error) {
+	tokenID, projectID, err := parseProjectRegistrationToken(c.keyAlgorithm, registrationToken)
+	if err != nil {
+		return nil, err
+	}
+	writeModel, err := c.projectRegistrationTokenWriteModelByID(ctx, projectID, tokenID, "")
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.Exists() || writeModel.ExpirationDate.Before(time.Now()) {
+		return nil, errors.ThrowUnauthenticated(nil, "COMMAND-Rg5fs", "Errors.Project.RegistrationToken.Invalid")
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) verifyOIDCClientRegistrationAccessToken(ctx context.Context, projectID, appID, registrationAccessToken string) (_ *OIDCApplicationWriteModel, err error) {
+	if projectID == "" || appID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Rg6fs", "Errors.IDMissing")
+	}
+	existingOIDC, err := c.getOIDCAppWriteModel(ctx, projectID, appID, "")
+	if err != nil {
+		return nil, err
+	}
+	if !existingOIDC.State.Exists() || !existingOIDC.IsOIDC() || existingOIDC.RegistrationAccessToken == nil {
+		return nil, errors.ThrowUnauthenticated(nil, "COMMAND-Rg7fs", "Errors.Project.App.RegistrationAccessTokenInvalid")
+	}
+	ctx, spanHashComparison := tracing.NewNamedSpan(ctx, "crypto.CompareHash")
+	err = crypto.CompareHash(existingOIDC.RegistrationAccessToken, []byte(registrationAccessToken), c.userPasswordAlg)
+	spanHashComparison.EndWithError(err)
+	if err != nil {
+		return nil, errors.ThrowUnauthenticated(err, "COMMAND-Rg8fs", "Errors.Project.App.RegistrationAccessTokenInvalid")
+	}
+	return existingOIDC, nil
+}
+
+func (c *Commands) projectRegistrationTokenWriteModelByID(ctx context.Context, projectID, tokenID, resourceOwner string) (*ProjectRegistrationTokenWriteModel, error) {
+	writeModel := NewProjectRegistrationTokenWriteModel(projectID, tokenID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+// checkRegisteredOIDCClient ensures the client metadata is valid and,
+// other than through the management API, only compliant configurations can be registered
+func checkRegisteredOIDCClient(oidcApp *domain.OIDCApp) error {
+	if oidcApp.AppName == "" || !oidcApp.IsValid() {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Rg9fs", "Errors.Project.App.OIDCConfigInvalid")
+	}
+	oidcApp.FillCompliance()
+	if oidcApp.Compliance.NoneCompliant {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Rg0fs", "Errors.Project.App.NotCompliant")
+	}
+	return nil
+}
+
+func parseProjectRegistrationToken(algorithm crypto.EncryptionAlgorithm, token string) (tokenID, projectID string, err error) {
+	tokenData, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", "", errors.ThrowUnauthenticated(err, "COMMAND-Rg1ft", "Errors.Project.RegistrationToken.Invalid")
+	}
+	tokenIDProjectID, err := algorithm.DecryptString(tokenData, algorithm.EncryptionKeyID())
+	if err != nil {
+		logging.WithError(err).Debug("unable to decrypt registration token")
+		return "", "", errors.ThrowUnauthenticated(nil, "COMMAND-Rg2ft", "Errors.Project.RegistrationToken.Invalid")
+	}
+	tokenID, projectID, ok := strings.Cut(tokenIDProjectID, ":")
+	if !ok || tokenID == "" || projectID == "" {
+		return "", "", errors.ThrowUnauthenticated(nil, "COMMAND-Rg3ft", "Errors.Project.RegistrationToken.Invalid")
+	}
+	return tokenID, projectID, nil
+}
@@ -0,0 +1,79 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type ProjectRegistrationTokenWriteModel struct {
+	eventstore.WriteModel
+
+	TokenID        string
+	ExpirationDate time.Time
+
+	State domain.ProjectRegistrationTokenState
+}
+
+func NewProjectRegistrationTokenWriteModel(projectID, tokenID, resourceOwner string) *ProjectRegistrationTokenWriteModel {
+	return &ProjectRegistrationTokenWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		TokenID: tokenID,
+	}
+}
+
+func (wm *ProjectRegistrationTokenWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *project.RegistrationTokenAddedEvent:
+			if wm.TokenID != e.TokenID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.RegistrationTokenRemovedEvent:
+			if wm.TokenID != e.TokenID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ProjectRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *ProjectRegistrationTokenWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.RegistrationTokenAddedEvent:
+			wm.ExpirationDate = e.ExpirationDate
+			wm.State = domain.ProjectRegistrationTokenStateActive
+		case *project.RegistrationTokenRemovedEvent:
+			wm.State = domain.ProjectRegistrationTokenStateRemoved
+		case *project.ProjectRemovedEvent:
+			wm.State = domain.ProjectRegistrationTokenStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *ProjectRegistrationTokenWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.RegistrationTokenAddedType,
+			project.RegistrationTokenRemovedType,
+			project.ProjectRemovedType).
+		Builder()
+}
+
+func (wm *ProjectRegistrationTokenWriteModel) Exists() bool {
+	return wm.State == domain.ProjectRegistrationTokenStateActive
+}
@@ -0,0 +1,823 @@
+package command
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestCommands_AddProjectRegistrationToken(t *testing.T) {
+	type fields struct {
+		eventstore   *eventstore.Eventstore
+		idGenerator  id.Generator
+		keyAlgorithm crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx   context.Context
+		token *ProjectRegistrationToken
+	}
+	type res struct {
+		want  *domain.ObjectDetails
+		token string
+		err   func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "project id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:   context.Background(),
+				token: NewProjectRegistrationToken("", "org1", time.Time{}),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project not existing, precondition failed error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				token: NewProjectRegistrationToken("project1", "org1", time.Time{}),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "token added, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewRegistrationTokenAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"token1",
+									time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+								),
+							),
+						},
+					),
+				),
+				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "token1"),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:   context.Background(),
+				token: NewProjectRegistrationToken("project1", "org1", time.Time{}),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+				token: base64.RawURLEncoding.EncodeToString([]byte("token1:project1")),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.fields.eventstore,
+				idGenerator:  tt.fields.idGenerator,
+				keyAlgorithm: tt.fields.keyAlgorithm,
+			}
+			got, err := c.AddProjectRegistrationToken(tt.args.ctx, tt.args.token)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+				assert.Equal(t, tt.res.token, tt.args.token.Token)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveProjectRegistrationToken(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		projectID     string
+		tokenID       string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "token not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				tokenID:       "token1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "token removed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewRegistrationTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewRegistrationTokenRemovedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"token1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				tokenID:       "token1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.RemoveProjectRegistrationToken(tt.args.ctx, tt.args.projectID, tt.args.tokenID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_RegisterOIDCClient(t *testing.T) {
+	type fields struct {
+		eventstore   *eventstore.Eventstore
+		idGenerator  id.Generator
+		keyAlgorithm crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx               context.Context
+		registrationToken string
+		oidcApp           *domain.OIDCApp
+	}
+	type res struct {
+		want                    *domain.OIDCApp
+		registrationAccessToken string
+		err                     func(error) bool
+	}
+	registeredApp := func() *domain.OIDCApp {
+		return &domain.OIDCApp{
+			AppName:         "app",
+			OIDCVersion:     domain.OIDCVersionV1,
+			AuthMethodType:  domain.OIDCAuthMethodTypeBasic,
+			RedirectUris:    []string{"https://test.ch"},
+			ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+			GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+			ApplicationType: domain.OIDCApplicationTypeWeb,
+		}
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "malformed token, unauthenticated error",
+			fields: fields{
+				eventstore:   eventstoreExpect(t),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:               context.Background(),
+				registrationToken: base64.RawURLEncoding.EncodeToString([]byte("token1")),
+				oidcApp:           registeredApp(),
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "token removed, unauthenticated error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewRegistrationTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+							),
+						),
+						eventFromEventPusher(
+							project.NewRegistrationTokenRemovedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+							),
+						),
+					),
+				),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:               context.Background(),
+				registrationToken: base64.RawURLEncoding.EncodeToString([]byte("token1:project1")),
+				oidcApp:           registeredApp(),
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "token expired, unauthenticated error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewRegistrationTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								time.Now().Add(-time.Hour),
+							),
+						),
+					),
+				),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:               context.Background(),
+				registrationToken: base64.RawURLEncoding.EncodeToString([]byte("token1:project1")),
+				oidcApp:           registeredApp(),
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "not compliant, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewRegistrationTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+							),
+						),
+					),
+				),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:               context.Background(),
+				registrationToken: base64.RawURLEncoding.EncodeToString([]byte("token1:project1")),
+				oidcApp: func() *domain.OIDCApp {
+					app := registeredApp()
+					app.RedirectUris = nil
+					return app
+				}(),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "client registered, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewRegistrationTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewApplicationAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"app1",
+									"app",
+								),
+							),
+							eventFromEventPusher(
+								project.NewOIDCConfigAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									domain.OIDCVersionV1,
+									"app1",
+									"client1@project",
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("a"),
+									},
+									[]string{"https://test.ch"},
+									[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+									[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+									domain.OIDCApplicationTypeWeb,
+									domain.OIDCAuthMethodTypeBasic,
+									nil,
+									false,
+									domain.OIDCTokenTypeBearer,
+									false,
+									false,
+									false,
+									0,
+									nil,
+									false,
+									false,
+								),
+							),
+							eventFromEventPusher(
+								project.NewOIDCConfigRegistrationTokenSetEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"app1",
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("a"),
+									},
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(project.NewAddApplicationUniqueConstraint("app", "project1")),
+					),
+				),
+				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "app1", "client1"),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:               context.Background(),
+				registrationToken: base64.RawURLEncoding.EncodeToString([]byte("token1:project1")),
+				oidcApp:           registeredApp(),
+			},
+			res: res{
+				want: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:              "app1",
+					AppName:            "app",
+					ClientID:           "client1@project",
+					ClientSecretString: "a",
+					AuthMethodType:     domain.OIDCAuthMethodTypeBasic,
+					OIDCVersion:        domain.OIDCVersionV1,
+					RedirectUris:       []string{"https://test.ch"},
+					ResponseTypes:      []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:         []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType:    domain.OIDCApplicationTypeWeb,
+					AccessTokenType:    domain.OIDCTokenTypeBearer,
+					State:              domain.AppStateActive,
+					Compliance:         &domain.Compliance{},
+				},
+				registrationAccessToken: "a",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.fields.eventstore,
+				idGenerator:  tt.fields.idGenerator,
+				keyAlgorithm: tt.fields.keyAlgorithm,
+			}
+			got, registrationAccessToken, err := c.RegisterOIDCClient(tt.args.ctx, tt.args.registrationToken, tt.args.oidcApp, GetMockSecretGenerator(t))
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+				assert.Equal(t, tt.res.registrationAccessToken, registrationAccessToken)
+			}
+		})
+	}
+}
+
+func TestCommands_RegisterOIDCClientInProject(t *testing.T) {
+	type fields struct {
+		eventstore      *eventstore.Eventstore
+		idGenerator     id.Generator
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		ctx       context.Context
+		projectID string
+		oidcApp   *domain.OIDCApp
+	}
+	type res struct {
+		want                    *domain.OIDCApp
+		registrationAccessToken string
+		err                     func(error) bool
+	}
+	registeredApp := func() *domain.OIDCApp {
+		return &domain.OIDCApp{
+			AppName:         "app",
+			OIDCVersion:     domain.OIDCVersionV1,
+			AuthMethodType:  domain.OIDCAuthMethodTypeBasic,
+			RedirectUris:    []string{"https://test.ch"},
+			ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+			GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+			ApplicationType: domain.OIDCApplicationTypeWeb,
+		}
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no project id, invalid argument error",
+			fields: fields{
+				eventstore:      eventstoreExpect(t),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:     context.Background(),
+				oidcApp: registeredApp(),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project not found, precondition failed error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:       context.Background(),
+				projectID: "project1",
+				oidcApp:   registeredApp(),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "no permission, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx:       context.Background(),
+				projectID: "project1",
+				oidcApp:   registeredApp(),
+			},
+			res: res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			name: "client registered, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewApplicationAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"app1",
+									"app",
+								),
+							),
+							eventFromEventPusher(
+								project.NewOIDCConfigAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									domain.OIDCVersionV1,
+									"app1",
+									"client1@project",
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("a"),
+									},
+									[]string{"https://test.ch"},
+									[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+									[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+									domain.OIDCApplicationTypeWeb,
+									domain.OIDCAuthMethodTypeBasic,
+									nil,
+									false,
+									domain.OIDCTokenTypeBearer,
+									false,
+									false,
+									false,
+									0,
+									nil,
+									false,
+									false,
+								),
+							),
+							eventFromEventPusher(
+								project.NewOIDCConfigRegistrationTokenSetEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"app1",
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("a"),
+									},
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(project.NewAddApplicationUniqueConstraint("app", "project1")),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "app1", "client1"),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:       context.Background(),
+				projectID: "project1",
+				oidcApp:   registeredApp(),
+			},
+			res: res{
+				want: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:              "app1",
+					AppName:            "app",
+					ClientID:           "client1@project",
+					ClientSecretString: "a",
+					AuthMethodType:     domain.OIDCAuthMethodTypeBasic,
+					OIDCVersion:        domain.OIDCVersionV1,
+					RedirectUris:       []string{"https://test.ch"},
+					ResponseTypes:      []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:         []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType:    domain.OIDCApplicationTypeWeb,
+					AccessTokenType:    domain.OIDCTokenTypeBearer,
+					State:              domain.AppStateActive,
+					Compliance:         &domain.Compliance{},
+				},
+				registrationAccessToken: "a",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore,
+				idGenerator:     tt.fields.idGenerator,
+				checkPermission: tt.fields.checkPermission,
+			}
+			got, registrationAccessToken, err := c.RegisterOIDCClientInProject(tt.args.ctx, tt.args.projectID, tt.args.oidcApp, GetMockSecretGenerator(t))
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+				assert.Equal(t, tt.res.registrationAccessToken, registrationAccessToken)
+			}
+		})
+	}
+}
+
+func TestCommands_GetRegisteredOIDCClient(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx                     context.Context
+		registrationAccessToken string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	registeredClientEvents := func() []*repository.Event {
+		return []*repository.Event{
+			eventFromEventPusher(
+				project.NewApplicationAddedEvent(context.Background(),
+					&project.NewAggregate("project1", "org1").Aggregate,
+					"app1",
+					"app",
+				),
+			),
+			eventFromEventPusher(
+				project.NewOIDCConfigAddedEvent(context.Background(),
+					&project.NewAggregate("project1", "org1").Aggregate,
+					domain.OIDCVersionV1,
+					"app1",
+					"client1@project",
+					nil,
+					[]string{"https://test.ch"},
+					[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					domain.OIDCApplicationTypeWeb,
+					domain.OIDCAuthMethodTypeNone,
+					nil,
+					false,
+					domain.OIDCTokenTypeBearer,
+					false,
+					false,
+					false,
+					0,
+					nil,
+					false,
+					false,
+				),
+			),
+			eventFromEventPusher(
+				project.NewOIDCConfigRegistrationTokenSetEvent(context.Background(),
+					&project.NewAggregate("project1", "org1").Aggregate,
+					"app1",
+					&crypto.CryptoValue{
+						CryptoType: crypto.TypeHash,
+						Algorithm:  "hash",
+						Crypted:    []byte("token"),
+					},
+				),
+			),
+		}
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "not registered, unauthenticated error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(registeredClientEvents()[:2]...),
+				),
+			},
+			args: args{
+				ctx:                     context.Background(),
+				registrationAccessToken: "token",
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "wrong token, unauthenticated error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(registeredClientEvents()...),
+				),
+			},
+			args: args{
+				ctx:                     context.Background(),
+				registrationAccessToken: "wrong",
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "valid token, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(registeredClientEvents()...),
+				),
+			},
+			args: args{
+				ctx:                     context.Background(),
+				registrationAccessToken: "token",
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore,
+				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+			}
+			got, err := c.GetRegisteredOIDCClient(tt.args.ctx, "project1", "app1", tt.args.registrationAccessToken)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, "client1@project", got.ClientID)
+			}
+		})
+	}
+}
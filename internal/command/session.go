@@ -0,0 +1,435 @@
+package command
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type SessionCommand func(ctx context.Context, cmd *SessionCommands) error
+
+type SessionCommands struct {
+	cmds              []SessionCommand
+	sessionWriteModel *SessionWriteModel
+	c                 *Commands
+
+	// userResourceOwner is the organisation of the checked user
+	userResourceOwner string
+	// userCommands are events on the checked user (e.g. sign count changes of webauthn tokens)
+	userCommands []eventstore.Command
+	// webAuthNChallenge is set if a challenge was requested
+	webAuthNChallenge *domain.WebAuthNLogin
+	now               func() time.Time
+}
+
+func (c *Commands) NewSessionCommands(cmds []SessionCommand, session *SessionWriteModel) *SessionCommands {
+	return &SessionCommands{
+		cmds:              cmds,
+		sessionWriteModel: session,
+		c:                 c,
+		now:               time.Now,
+	}
+}
+
+// SessionChanged contains the result of a session creation or update
+type SessionChanged struct {
+	*domain.ObjectDetails
+	ID       string
+	NewToken string
+	// WebAuthNChallenge is only set if a challenge was requested
+	WebAuthNChallenge *domain.WebAuthNLogin
+}
+
+// CheckUser defines a user check to be executed for a session update
+func CheckUser(id string) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) error {
+		if id == "" {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sfw3f", "Errors.User.UserIDMissing")
+		}
+		if cmd.sessionWriteModel.UserID != "" && cmd.sessionWriteModel.UserID != id {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sfw3g", "Errors.Session.UserChangeNotAllowed")
+		}
+		userWriteModel, err := cmd.c.sessionUser(ctx, id)
+		if err != nil {
+			return err
+		}
+		cmd.userResourceOwner = userWriteModel.ResourceOwner
+		cmd.sessionWriteModel.UserChecked(ctx, id, cmd.now())
+		return nil
+	}
+}
+
+// CheckPassword defines a password check to be executed for a session update
+func CheckPassword(password string) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) (err error) {
+		if password == "" {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sfw3h", "Errors.User.Password.Empty")
+		}
+		if err = cmd.c.checkLoginThrottle(ctx, nil); err != nil {
+			return err
+		}
+		userID, resourceOwner, err := cmd.checkedUser(ctx)
+		if err != nil {
+			return err
+		}
+		loginPolicy, err := cmd.c.getOrgLoginPolicy(ctx, resourceOwner)
+		if err != nil {
+			return caos_errs.ThrowPreconditionFailed(err, "COMMAND-Sfw3i", "Errors.Org.LoginPolicy.NotFound")
+		}
+		if !loginPolicy.AllowUsernamePassword {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw3j", "Errors.Org.LoginPolicy.UsernamePasswordNotAllowed")
+		}
+		existingPassword, err := cmd.c.passwordWriteModel(ctx, userID, resourceOwner)
+		if err != nil {
+			return err
+		}
+		if existingPassword.Secret == nil {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw3k", "Errors.User.Password.NotSet")
+		}
+
+		ctx, spanPasswordComparison := tracing.NewNamedSpan(ctx, "crypto.CompareHash")
+		err = crypto.CompareHash(existingPassword.Secret, []byte(password), cmd.c.userPasswordAlg)
+		spanPasswordComparison.EndWithError(err)
+		if err != nil {
+			cmd.c.loginCheckFailed(ctx, nil)
+			lockoutPolicy, policyErr := cmd.c.getOrgLockoutPolicy(ctx, resourceOwner)
+			logging.OnError(policyErr).Warn("unable to get lockout policy for failed password check")
+			cmd.c.humanPasswordCheckFailed(ctx, existingPassword, lockoutPolicy, nil)
+			return caos_errs.ThrowInvalidArgument(err, "COMMAND-Sfw3l", "Errors.User.Password.Invalid")
+		}
+		cmd.c.loginCheckSucceeded(ctx, nil)
+		userAgg := UserAggregateFromWriteModel(&existingPassword.WriteModel)
+		cmd.userCommands = append(cmd.userCommands, usr_repo.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, nil))
+		cmd.sessionWriteModel.PasswordChecked(ctx, cmd.now())
+		return nil
+	}
+}
+
+// CheckIntent defines a check of a succeeded idp intent to be executed for a session update
+func CheckIntent(intentID, token string) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) error {
+		if intentID == "" || token == "" {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sfw3m", "Errors.Intent.InvalidToken")
+		}
+		if err := cmd.c.verifyIDPIntentToken(intentID, token); err != nil {
+			return err
+		}
+		userID, _, err := cmd.checkedUser(ctx)
+		if err != nil {
+			return err
+		}
+		intent, err := cmd.c.GetIntentWriteModel(ctx, intentID, "")
+		if err != nil {
+			return err
+		}
+		if intent.State != domain.IDPIntentStateSucceeded {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw3n", "Errors.Intent.NotSucceeded")
+		}
+		if intent.UserID != userID {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw3o", "Errors.Intent.OtherUser")
+		}
+		cmd.sessionWriteModel.IntentChecked(ctx, cmd.now())
+		return nil
+	}
+}
+
+// CreateWebAuthNChallenge requests a webauthn challenge for the checked user,
+// which can be checked by a later update using CheckWebAuthN.
+// If user verification is required only passwordless tokens are allowed.
+func CreateWebAuthNChallenge(userVerification domain.UserVerificationRequirement) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) error {
+		userID, resourceOwner, err := cmd.checkedUser(ctx)
+		if err != nil {
+			return err
+		}
+		human, err := cmd.c.getHuman(ctx, userID, resourceOwner)
+		if err != nil {
+			return err
+		}
+		passwordlessTokens, u2fTokens, err := cmd.webAuthNTokens(ctx, userID, resourceOwner, userVerification)
+		if err != nil {
+			return err
+		}
+		tokens := append(passwordlessTokens, u2fTokens...)
+		webAuthNLogin, err := cmd.c.webauthnConfig.BeginLogin(ctx, human, userVerification, tokens...)
+		if err != nil {
+			return err
+		}
+		cmd.webAuthNChallenge = webAuthNLogin
+		cmd.sessionWriteModel.WebAuthNChallenged(ctx, webAuthNLogin.Challenge, webAuthNLogin.AllowedCredentialIDs, webAuthNLogin.UserVerification)
+		return nil
+	}
+}
+
+// CheckWebAuthN defines a check of the assertion of a previously requested webauthn challenge
+func CheckWebAuthN(credentialAssertionData []byte) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) (err error) {
+		if err = cmd.c.checkLoginThrottle(ctx, nil); err != nil {
+			return err
+		}
+		challenge := cmd.sessionWriteModel.WebAuthNChallenge
+		if challenge == nil {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw3p", "Errors.Session.WebAuthN.NoChallenge")
+		}
+		userID, resourceOwner, err := cmd.checkedUser(ctx)
+		if err != nil {
+			return err
+		}
+		human, err := cmd.c.getHuman(ctx, userID, resourceOwner)
+		if err != nil {
+			return err
+		}
+		passwordlessTokens, u2fTokens, err := cmd.webAuthNTokens(ctx, userID, resourceOwner, challenge.UserVerification)
+		if err != nil {
+			return err
+		}
+		tokens := append(passwordlessTokens, u2fTokens...)
+		keyID, signCount, err := cmd.c.webauthnConfig.FinishLogin(ctx, human, challenge.WebAuthNLogin(human, credentialAssertionData), credentialAssertionData, tokens...)
+		userAgg := &usr_repo.NewAggregate(userID, resourceOwner).Aggregate
+		if err != nil && keyID == nil {
+			cmd.c.loginCheckFailed(ctx, nil)
+			// only passwordless tokens are allowed if user verification is required
+			cmd.c.sessionWebAuthNCheckFailed(ctx, userAgg, challenge.UserVerification == domain.UserVerificationRequirementRequired)
+			return err
+		}
+		_, token := domain.GetTokenByKeyID(tokens, keyID)
+		if token == nil {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw3q", "Errors.User.WebAuthN.NotFound")
+		}
+		cmd.c.loginCheckSucceeded(ctx, nil)
+		if index, _ := domain.GetTokenByKeyID(passwordlessTokens, keyID); index >= 0 {
+			cmd.userCommands = append(cmd.userCommands,
+				usr_repo.NewHumanPasswordlessCheckSucceededEvent(ctx, userAgg, nil),
+				usr_repo.NewHumanPasswordlessSignCountChangedEvent(ctx, userAgg, token.WebAuthNTokenID, signCount),
+			)
+		} else {
+			cmd.userCommands = append(cmd.userCommands,
+				usr_repo.NewHumanU2FCheckSucceededEvent(ctx, userAgg, nil),
+				usr_repo.NewHumanU2FSignCountChangedEvent(ctx, userAgg, token.WebAuthNTokenID, signCount),
+			)
+		}
+		cmd.sessionWriteModel.WebAuthNChecked(ctx, cmd.now(), challenge.UserVerification == domain.UserVerificationRequirementRequired)
+		return nil
+	}
+}
+
+// Exec will execute the commands specified and returns an error on the first occurrence
+func (s *SessionCommands) Exec(ctx context.Context) error {
+	for _, cmd := range s.cmds {
+		if err := cmd(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkedUser returns the id and organisation of the user checked in the session
+func (s *SessionCommands) checkedUser(ctx context.Context) (userID, resourceOwner string, err error) {
+	userID = s.sessionWriteModel.UserID
+	if userID == "" {
+		return "", "", caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw3r", "Errors.User.UserIDMissing")
+	}
+	if s.userResourceOwner != "" {
+		return userID, s.userResourceOwner, nil
+	}
+	userWriteModel, err := s.c.sessionUser(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+	s.userResourceOwner = userWriteModel.ResourceOwner
+	return userID, s.userResourceOwner, nil
+}
+
+// sessionUser returns the user to be checked in a session, if it's in a valid state.
+// A user locked by the lockout policy is unlocked again after the lockout duration.
+func (c *Commands) sessionUser(ctx context.Context, userID string) (*UserWriteModel, error) {
+	userWriteModel := NewUserWriteModel(userID, "")
+	if err := queryAndReduce(ctx, c.eventstore.Filter, userWriteModel); err != nil {
+		return nil, err
+	}
+	if userWriteModel.UserState == domain.UserStateLocked {
+		unlocked, err := c.UnlockExpiredLockout(ctx, userID, userWriteModel.ResourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if unlocked {
+			userWriteModel.UserState = domain.UserStateActive
+		}
+	}
+	if err := checkSessionUserState(userWriteModel.UserState); err != nil {
+		return nil, err
+	}
+	return userWriteModel, nil
+}
+
+// sessionWebAuthNCheckFailed pushes the failed u2f or passwordless check
+// and locks the user if the maximum attempts of the lockout policy are reached.
+// Errors are only logged, as the check already failed.
+func (c *Commands) sessionWebAuthNCheckFailed(ctx context.Context, userAgg *eventstore.Aggregate, passwordless bool) {
+	var failedEvent eventstore.Command = usr_repo.NewHumanU2FCheckFailedEvent(ctx, userAgg, nil)
+	if passwordless {
+		failedEvent = usr_repo.NewHumanPasswordlessCheckFailedEvent(ctx, userAgg, nil)
+	}
+	events := []eventstore.Command{failedEvent}
+	lockoutPolicy, err := c.getOrgLockoutPolicy(ctx, userAgg.ResourceOwner)
+	logging.WithFields("userID", userAgg.ID).OnError(err).Warn("unable to get lockout policy for failed webauthn check")
+	lockEvent, err := c.otpCheckFailedLockEvent(ctx, userAgg, lockoutPolicy, nil)
+	logging.WithFields("userID", userAgg.ID).OnError(err).Warn("could not check webauthn lockout")
+	if lockEvent != nil {
+		events = append(events, lockEvent)
+	}
+	_, err = c.eventstore.Push(ctx, events...)
+	logging.WithFields("userID", userAgg.ID).OnError(err).Warn("could not push failed webauthn check event")
+}
+
+// webAuthNTokens returns the passwordless tokens of the user
+// and, if no user verification is required, its u2f tokens as well
+func (s *SessionCommands) webAuthNTokens(ctx context.Context, userID, resourceOwner string, userVerification domain.UserVerificationRequirement) (passwordlessTokens, u2fTokens []*domain.WebAuthNToken, err error) {
+	passwordlessTokens, err = s.c.getHumanPasswordlessTokens(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, nil, err
+	}
+	if userVerification == domain.UserVerificationRequirementRequired {
+		return passwordlessTokens, nil, nil
+	}
+	u2fTokens, err = s.c.getHumanU2FTokens(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, nil, err
+	}
+	return passwordlessTokens, u2fTokens, nil
+}
+
+func (s *SessionCommands) commands(ctx context.Context) ([]eventstore.Command, string, error) {
+	tokenID, token, err := s.c.sessionTokenCreator(s.sessionWriteModel.AggregateID)
+	if err != nil {
+		return nil, "", err
+	}
+	s.sessionWriteModel.SetToken(ctx, tokenID)
+	// the session events are pushed last so the details of the last event belong to the session
+	return append(s.userCommands, s.sessionWriteModel.commands...), token, nil
+}
+
+func checkSessionUserState(state domain.UserState) error {
+	switch state {
+	case domain.UserStateActive, domain.UserStateInitial:
+		return nil
+	case domain.UserStateLocked:
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw3s", "Errors.User.Locked")
+	case domain.UserStateInactive:
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw3t", "Errors.User.NotActive")
+	default:
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Sfw3u", "Errors.User.NotFound")
+	}
+}
+
+func (c *Commands) CreateSession(ctx context.Context, cmds []SessionCommand, metadata map[string][]byte) (set *SessionChanged, err error) {
+	sessionID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	sessionWriteModel := NewSessionWriteModel(sessionID, authz.GetInstance(ctx).InstanceID())
+	if err = c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel); err != nil {
+		return nil, err
+	}
+	if sessionWriteModel.State != domain.SessionStateUnspecified {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Sfw3v", "Errors.Session.AlreadyExists")
+	}
+	cmd := c.NewSessionCommands(cmds, sessionWriteModel)
+	cmd.sessionWriteModel.Start(ctx)
+	return c.updateSession(ctx, cmd, metadata)
+}
+
+func (c *Commands) UpdateSession(ctx context.Context, sessionID, sessionToken string, cmds []SessionCommand, metadata map[string][]byte) (set *SessionChanged, err error) {
+	sessionWriteModel := NewSessionWriteModel(sessionID, authz.GetInstance(ctx).InstanceID())
+	if err = c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel); err != nil {
+		return nil, err
+	}
+	if sessionWriteModel.State == domain.SessionStateUnspecified {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Sfw3w", "Errors.Session.NotExisting")
+	}
+	if err = c.sessionTokenVerifier(ctx, sessionToken, sessionWriteModel.AggregateID, sessionWriteModel.TokenID); err != nil {
+		return nil, err
+	}
+	cmd := c.NewSessionCommands(cmds, sessionWriteModel)
+	return c.updateSession(ctx, cmd, metadata)
+}
+
+// TerminateSession terminates the session. The session token is only verified if provided,
+// the api requires the permission to delete sessions otherwise.
+func (c *Commands) TerminateSession(ctx context.Context, sessionID, sessionToken string) (*domain.ObjectDetails, error) {
+	sessionWriteModel := NewSessionWriteModel(sessionID, authz.GetInstance(ctx).InstanceID())
+	if err := c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel); err != nil {
+		return nil, err
+	}
+	if sessionToken != "" {
+		if err := c.sessionTokenVerifier(ctx, sessionToken, sessionWriteModel.AggregateID, sessionWriteModel.TokenID); err != nil {
+			return nil, err
+		}
+	}
+	if sessionWriteModel.State != domain.SessionStateActive {
+		return writeModelToObjectDetails(&sessionWriteModel.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, session.NewTerminateEvent(ctx, sessionWriteModel.aggregate))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(sessionWriteModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&sessionWriteModel.WriteModel), nil
+}
+
+func (c *Commands) updateSession(ctx context.Context, checks *SessionCommands, metadata map[string][]byte) (set *SessionChanged, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if checks.sessionWriteModel.State == domain.SessionStateTerminated {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw3x", "Errors.Session.Terminated")
+	}
+	if err = checks.Exec(ctx); err != nil {
+		return nil, err
+	}
+	checks.sessionWriteModel.ChangeMetadata(ctx, metadata)
+	cmds, token, err := checks.commands(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return &SessionChanged{
+		ObjectDetails:     pushedEventsToObjectDetails(pushedEvents),
+		ID:                checks.sessionWriteModel.AggregateID,
+		NewToken:          token,
+		WebAuthNChallenge: checks.webAuthNChallenge,
+	}, nil
+}
+
+// sessionTokenCreator returns a function, which creates a new token id and the encrypted session token
+func sessionTokenCreator(idGenerator id.Generator, sessionAlg crypto.EncryptionAlgorithm) func(sessionID string) (id string, token string, err error) {
+	return func(sessionID string) (id string, token string, err error) {
+		id, err = idGenerator.Next()
+		if err != nil {
+			return "", "", err
+		}
+		encrypted, err := sessionAlg.Encrypt([]byte(fmt.Sprintf(authz.SessionTokenFormat, sessionID, id)))
+		if err != nil {
+			return "", "", err
+		}
+		return id, base64.RawURLEncoding.EncodeToString(encrypted), nil
+	}
+}
@@ -0,0 +1,1003 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/webauthn"
+)
+
+func TestCommands_CreateSession(t *testing.T) {
+	type fields struct {
+		eventstore          *eventstore.Eventstore
+		idGenerator         id.Generator
+		sessionTokenCreator func(sessionID string) (string, string, error)
+	}
+	type args struct {
+		ctx      context.Context
+		checks   []SessionCommand
+		metadata map[string][]byte
+	}
+	type res struct {
+		want *SessionChanged
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id generator fails",
+			fields{
+				idGenerator: func() id.Generator {
+					m := id_mock.NewMockGenerator(gomock.NewController(t))
+					m.EXPECT().Next().Return("", caos_errs.ThrowInternal(nil, "id", "generator failed"))
+					return m
+				}(),
+			},
+			args{
+				ctx: context.Background(),
+			},
+			res{
+				err: caos_errs.ThrowInternal(nil, "id", "generator failed"),
+			},
+		},
+		{
+			"eventstore failed",
+			fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "sessionID"),
+				eventstore: eventstoreExpect(t,
+					expectFilterError(caos_errs.ThrowInternal(nil, "id", "filter failed")),
+				),
+			},
+			args{
+				ctx: context.Background(),
+			},
+			res{
+				err: caos_errs.ThrowInternal(nil, "id", "filter failed"),
+			},
+		},
+		{
+			"empty session",
+			fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "sessionID"),
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewAddedEvent(authz.WithInstanceID(context.Background(), "instance1"),
+									&session.NewAggregate("sessionID", "instance1").Aggregate,
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewTokenSetEvent(authz.WithInstanceID(context.Background(), "instance1"),
+									&session.NewAggregate("sessionID", "instance1").Aggregate,
+									"tokenID",
+								),
+							),
+						},
+					),
+				),
+				sessionTokenCreator: func(sessionID string) (string, string, error) {
+					return "tokenID", "token", nil
+				},
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+			},
+			res{
+				want: &SessionChanged{
+					ObjectDetails: &domain.ObjectDetails{ResourceOwner: "instance1"},
+					ID:            "sessionID",
+					NewToken:      "token",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:          tt.fields.eventstore,
+				idGenerator:         tt.fields.idGenerator,
+				sessionTokenCreator: tt.fields.sessionTokenCreator,
+			}
+			got, err := c.CreateSession(tt.args.ctx, tt.args.checks, tt.args.metadata)
+			require.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
+
+func TestCommands_UpdateSession(t *testing.T) {
+	type fields struct {
+		eventstore           *eventstore.Eventstore
+		sessionTokenVerifier func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error)
+	}
+	type args struct {
+		ctx          context.Context
+		sessionID    string
+		sessionToken string
+		checks       []SessionCommand
+		metadata     map[string][]byte
+	}
+	type res struct {
+		want *SessionChanged
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"eventstore failed",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilterError(caos_errs.ThrowInternal(nil, "id", "filter failed")),
+				),
+			},
+			args{
+				ctx: context.Background(),
+			},
+			res{
+				err: caos_errs.ThrowInternal(nil, "id", "filter failed"),
+			},
+		},
+		{
+			"not existing",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID: "sessionID",
+			},
+			res{
+				err: caos_errs.ThrowNotFound(nil, "COMMAND-Sfw3w", "Errors.Session.NotExisting"),
+			},
+		},
+		{
+			"invalid session token",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(),
+								&session.NewAggregate("sessionID", "instance1").Aggregate)),
+						eventFromEventPusher(
+							session.NewTokenSetEvent(context.Background(),
+								&session.NewAggregate("sessionID", "instance1").Aggregate,
+								"tokenID")),
+					),
+				),
+				sessionTokenVerifier: func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error) {
+					return caos_errs.ThrowPermissionDenied(nil, "", "Errors.Session.Token.Invalid")
+				},
+			},
+			args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "sessionID",
+				sessionToken: "invalid",
+			},
+			res{
+				err: caos_errs.ThrowPermissionDenied(nil, "", "Errors.Session.Token.Invalid"),
+			},
+		},
+		{
+			"terminated session",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(),
+								&session.NewAggregate("sessionID", "instance1").Aggregate)),
+						eventFromEventPusher(
+							session.NewTokenSetEvent(context.Background(),
+								&session.NewAggregate("sessionID", "instance1").Aggregate,
+								"tokenID")),
+						eventFromEventPusher(
+							session.NewTerminateEvent(context.Background(),
+								&session.NewAggregate("sessionID", "instance1").Aggregate)),
+					),
+				),
+				sessionTokenVerifier: func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error) {
+					return nil
+				},
+			},
+			args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "sessionID",
+				sessionToken: "token",
+			},
+			res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw3x", "Errors.Session.Terminated"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:           tt.fields.eventstore,
+				sessionTokenVerifier: tt.fields.sessionTokenVerifier,
+			}
+			got, err := c.UpdateSession(tt.args.ctx, tt.args.sessionID, tt.args.sessionToken, tt.args.checks, tt.args.metadata)
+			require.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
+
+func TestCommands_updateSession(t *testing.T) {
+	testNow := time.Now()
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		checks   *SessionCommands
+		metadata map[string][]byte
+	}
+	type res struct {
+		want *SessionChanged
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"terminated",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				checks: &SessionCommands{
+					sessionWriteModel: &SessionWriteModel{State: domain.SessionStateTerminated},
+				},
+			},
+			res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw3x", "Errors.Session.Terminated"),
+			},
+		},
+		{
+			"check failed",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				checks: &SessionCommands{
+					sessionWriteModel: NewSessionWriteModel("sessionID", "instance1"),
+					cmds: []SessionCommand{
+						func(ctx context.Context, cmd *SessionCommands) error {
+							return caos_errs.ThrowInternal(nil, "id", "check failed")
+						},
+					},
+				},
+			},
+			res{
+				err: caos_errs.ThrowInternal(nil, "id", "check failed"),
+			},
+		},
+		{
+			"user not existing",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				checks: &SessionCommands{
+					sessionWriteModel: NewSessionWriteModel("sessionID", "instance1"),
+					cmds: []SessionCommand{
+						CheckUser("userID"),
+					},
+					now: func() time.Time {
+						return testNow
+					},
+				},
+			},
+			res{
+				err: caos_errs.ThrowNotFound(nil, "COMMAND-Sfw3u", "Errors.User.NotFound"),
+			},
+		},
+		{
+			"user checked and metadata set",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("userID", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"",
+								"firstname lastname",
+								language.English,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewUserCheckedEvent(authz.WithInstanceID(context.Background(), "instance1"),
+									&session.NewAggregate("sessionID", "instance1").Aggregate,
+									"userID", testNow,
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewMetadataSetEvent(authz.WithInstanceID(context.Background(), "instance1"),
+									&session.NewAggregate("sessionID", "instance1").Aggregate,
+									map[string][]byte{"key": []byte("value")},
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewTokenSetEvent(authz.WithInstanceID(context.Background(), "instance1"),
+									&session.NewAggregate("sessionID", "instance1").Aggregate,
+									"tokenID",
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				checks: &SessionCommands{
+					sessionWriteModel: NewSessionWriteModel("sessionID", "instance1"),
+					cmds: []SessionCommand{
+						CheckUser("userID"),
+					},
+					now: func() time.Time {
+						return testNow
+					},
+				},
+				metadata: map[string][]byte{
+					"key": []byte("value"),
+				},
+			},
+			res{
+				want: &SessionChanged{
+					ObjectDetails: &domain.ObjectDetails{ResourceOwner: "instance1"},
+					ID:            "sessionID",
+					NewToken:      "token",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+				sessionTokenCreator: func(sessionID string) (string, string, error) {
+					return "tokenID", "token", nil
+				},
+			}
+			tt.args.checks.c = c
+			got, err := c.updateSession(tt.args.ctx, tt.args.checks, tt.args.metadata)
+			require.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	testNow := time.Now()
+	loginPolicy := func() *repository.Event {
+		return eventFromEventPusher(
+			org.NewLoginPolicyAddedEvent(context.Background(),
+				&org.NewAggregate("org1").Aggregate,
+				true,
+				false,
+				false,
+				false,
+				false,
+				false,
+				false,
+				false,
+				false,
+				false,
+				false,
+				domain.PasswordlessTypeNotAllowed,
+				domain.BotProtectionTypeNone,
+				"",
+				time.Hour*1,
+				time.Hour*2,
+				time.Hour*3,
+				time.Hour*4,
+				time.Hour*5,
+			),
+		)
+	}
+	passwordEvents := func() []*repository.Event {
+		return []*repository.Event{
+			eventFromEventPusher(
+				user.NewHumanAddedEvent(context.Background(),
+					&user.NewAggregate("userID", "org1").Aggregate,
+					"username",
+					"firstname",
+					"lastname",
+					"nickname",
+					"displayname",
+					language.German,
+					domain.GenderUnspecified,
+					"email@test.ch",
+					true,
+				),
+			),
+			eventFromEventPusher(
+				user.NewHumanPasswordChangedEvent(context.Background(),
+					&user.NewAggregate("userID", "org1").Aggregate,
+					&crypto.CryptoValue{
+						CryptoType: crypto.TypeHash,
+						Algorithm:  "hash",
+						KeyID:      "",
+						Crypted:    []byte("password"),
+					},
+					false,
+					"",
+				),
+			),
+		}
+	}
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		password string
+	}
+	type res struct {
+		want *SessionChanged
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"password invalid, check failed and user locked",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(loginPolicy()),
+					expectFilter(passwordEvents()...),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewLockoutPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("instance1").Aggregate,
+								1,
+								0,
+								false,
+								0,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								user.NewHumanPasswordCheckFailedEvent(authz.WithInstanceID(context.Background(), "instance1"),
+									&user.NewAggregate("userID", "org1").Aggregate,
+									nil,
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								user.NewUserLockedByPolicyEvent(authz.WithInstanceID(context.Background(), "instance1"),
+									&user.NewAggregate("userID", "org1").Aggregate,
+									domain.UserLockReasonPasswordAttempts,
+									1,
+									nil,
+									nil,
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				password: "wrong",
+			},
+			res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"password checked",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(loginPolicy()),
+					expectFilter(passwordEvents()...),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								user.NewHumanPasswordCheckSucceededEvent(authz.WithInstanceID(context.Background(), "instance1"),
+									&user.NewAggregate("userID", "org1").Aggregate,
+									nil,
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewPasswordCheckedEvent(authz.WithInstanceID(context.Background(), "instance1"),
+									&session.NewAggregate("sessionID", "instance1").Aggregate,
+									testNow,
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewTokenSetEvent(authz.WithInstanceID(context.Background(), "instance1"),
+									&session.NewAggregate("sessionID", "instance1").Aggregate,
+									"tokenID",
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				password: "password",
+			},
+			res{
+				want: &SessionChanged{
+					ObjectDetails: &domain.ObjectDetails{ResourceOwner: "instance1"},
+					ID:            "sessionID",
+					NewToken:      "token",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore,
+				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				sessionTokenCreator: func(sessionID string) (string, string, error) {
+					return "tokenID", "token", nil
+				},
+			}
+			sessionWriteModel := NewSessionWriteModel("sessionID", "instance1")
+			sessionWriteModel.UserID = "userID"
+			checks := &SessionCommands{
+				cmds:              []SessionCommand{CheckPassword(tt.args.password)},
+				sessionWriteModel: sessionWriteModel,
+				c:                 c,
+				userResourceOwner: "org1",
+				now: func() time.Time {
+					return testNow
+				},
+			}
+			got, err := c.updateSession(authz.WithInstanceID(context.Background(), "instance1"), checks, nil)
+			if tt.res.err == nil {
+				require.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
+
+func TestCheckWebAuthN(t *testing.T) {
+	testNow := time.Now()
+	humanAdded := func() *repository.Event {
+		return eventFromEventPusher(
+			user.NewHumanAddedEvent(context.Background(),
+				&user.NewAggregate("userID", "org1").Aggregate,
+				"username",
+				"firstname",
+				"lastname",
+				"nickname",
+				"displayname",
+				language.German,
+				domain.GenderUnspecified,
+				"email@test.ch",
+				true,
+			),
+		)
+	}
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		userVerification        domain.UserVerificationRequirement
+		credentialAssertionData []byte
+	}
+	type res struct {
+		want *SessionChanged
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"assertion invalid, passwordless check failed and user locked",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(humanAdded()),
+					expectFilter(),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewLockoutPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("instance1").Aggregate,
+								0,
+								1,
+								false,
+								0,
+							),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								user.NewHumanPasswordlessCheckFailedEvent(authz.WithInstanceID(context.Background(), "instance1"),
+									&user.NewAggregate("userID", "org1").Aggregate,
+									nil,
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								user.NewUserLockedByPolicyEvent(authz.WithInstanceID(context.Background(), "instance1"),
+									&user.NewAggregate("userID", "org1").Aggregate,
+									domain.UserLockReasonOTPAttempts,
+									1,
+									nil,
+									nil,
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				userVerification:        domain.UserVerificationRequirementRequired,
+				credentialAssertionData: []byte("invalid"),
+			},
+			res{
+				err: caos_errs.IsInternal,
+			},
+		},
+		{
+			"assertion invalid, u2f check failed",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(humanAdded()),
+					expectFilter(),
+					expectFilter(),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewLockoutPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("instance1").Aggregate,
+								0,
+								0,
+								false,
+								0,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								user.NewHumanU2FCheckFailedEvent(authz.WithInstanceID(context.Background(), "instance1"),
+									&user.NewAggregate("userID", "org1").Aggregate,
+									nil,
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				userVerification:        domain.UserVerificationRequirementDiscouraged,
+				credentialAssertionData: []byte("invalid"),
+			},
+			res{
+				err: caos_errs.IsInternal,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:     tt.fields.eventstore,
+				webauthnConfig: &webauthn.Config{},
+			}
+			sessionWriteModel := NewSessionWriteModel("sessionID", "instance1")
+			sessionWriteModel.UserID = "userID"
+			sessionWriteModel.WebAuthNChallenge = &WebAuthNChallengeModel{
+				Challenge:        "challenge",
+				UserVerification: tt.args.userVerification,
+			}
+			checks := &SessionCommands{
+				cmds:              []SessionCommand{CheckWebAuthN(tt.args.credentialAssertionData)},
+				sessionWriteModel: sessionWriteModel,
+				c:                 c,
+				userResourceOwner: "org1",
+				now: func() time.Time {
+					return testNow
+				},
+			}
+			got, err := c.updateSession(authz.WithInstanceID(context.Background(), "instance1"), checks, nil)
+			if tt.res.err == nil {
+				require.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
+
+func TestSessionCommands_checkedUser(t *testing.T) {
+	humanAdded := func() *repository.Event {
+		return eventFromEventPusher(
+			user.NewHumanAddedEvent(context.Background(),
+				&user.NewAggregate("userID", "org1").Aggregate,
+				"username",
+				"firstname",
+				"lastname",
+				"nickname",
+				"displayname",
+				language.German,
+				domain.GenderUnspecified,
+				"email@test.ch",
+				true,
+			),
+		)
+	}
+	lockedUntil := func(until time.Time) *repository.Event {
+		return eventFromEventPusher(
+			user.NewUserLockedByPolicyEvent(context.Background(),
+				&user.NewAggregate("userID", "org1").Aggregate,
+				domain.UserLockReasonPasswordAttempts,
+				3,
+				&until,
+				nil,
+			),
+		)
+	}
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type res struct {
+		userID        string
+		resourceOwner string
+		err           func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			"user locked, precondition error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						humanAdded(),
+						lockedUntil(time.Now().Add(time.Hour)),
+					),
+					expectFilter(
+						lockedUntil(time.Now().Add(time.Hour)),
+					),
+				),
+			},
+			res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			"lockout expired, user unlocked",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						humanAdded(),
+						lockedUntil(time.Now().Add(-time.Hour)),
+					),
+					expectFilter(
+						lockedUntil(time.Now().Add(-time.Hour)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewUserUnlockedEvent(context.Background(),
+									&user.NewAggregate("userID", "org1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+			},
+			res{
+				userID:        "userID",
+				resourceOwner: "org1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionWriteModel := NewSessionWriteModel("sessionID", "instance1")
+			sessionWriteModel.UserID = "userID"
+			checks := &SessionCommands{
+				sessionWriteModel: sessionWriteModel,
+				c: &Commands{
+					eventstore: tt.fields.eventstore,
+				},
+			}
+			userID, resourceOwner, err := checks.checkedUser(context.Background())
+			if tt.res.err == nil {
+				require.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			assert.Equal(t, tt.res.userID, userID)
+			assert.Equal(t, tt.res.resourceOwner, resourceOwner)
+		})
+	}
+}
+
+func TestCommands_TerminateSession(t *testing.T) {
+	type fields struct {
+		eventstore           *eventstore.Eventstore
+		sessionTokenVerifier func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error)
+	}
+	type args struct {
+		ctx          context.Context
+		sessionID    string
+		sessionToken string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"eventstore failed",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilterError(caos_errs.ThrowInternal(nil, "id", "filter failed")),
+				),
+			},
+			args{
+				ctx: context.Background(),
+			},
+			res{
+				err: caos_errs.ThrowInternal(nil, "id", "filter failed"),
+			},
+		},
+		{
+			"invalid session token",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(),
+								&session.NewAggregate("sessionID", "instance1").Aggregate)),
+						eventFromEventPusher(
+							session.NewTokenSetEvent(context.Background(),
+								&session.NewAggregate("sessionID", "instance1").Aggregate,
+								"tokenID")),
+					),
+				),
+				sessionTokenVerifier: func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error) {
+					return caos_errs.ThrowPermissionDenied(nil, "", "Errors.Session.Token.Invalid")
+				},
+			},
+			args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "sessionID",
+				sessionToken: "invalid",
+			},
+			res{
+				err: caos_errs.ThrowPermissionDenied(nil, "", "Errors.Session.Token.Invalid"),
+			},
+		},
+		{
+			"already terminated",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(),
+								&session.NewAggregate("sessionID", "instance1").Aggregate)),
+						eventFromEventPusher(
+							session.NewTerminateEvent(context.Background(),
+								&session.NewAggregate("sessionID", "instance1").Aggregate)),
+					),
+				),
+			},
+			args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID: "sessionID",
+			},
+			res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+		{
+			"terminate without token",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(),
+								&session.NewAggregate("sessionID", "instance1").Aggregate)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewTerminateEvent(authz.WithInstanceID(context.Background(), "instance1"),
+									&session.NewAggregate("sessionID", "instance1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID: "sessionID",
+			},
+			res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:           tt.fields.eventstore,
+				sessionTokenVerifier: tt.fields.sessionTokenVerifier,
+			}
+			got, err := c.TerminateSession(tt.args.ctx, tt.args.sessionID, tt.args.sessionToken)
+			require.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
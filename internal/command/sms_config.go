@@ -2,10 +2,12 @@ package command
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
@@ -110,6 +112,122 @@ func (c *Commands) ChangeSMSConfigTwilioToken(ctx context.Context, instanceID, i
 	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
 }
 
+func (c *Commands) AddSMSConfigHTTP(ctx context.Context, instanceID string, config *httpsms.Config) (string, *domain.ObjectDetails, error) {
+	if config.Method == "" {
+		config.Method = http.MethodPost
+	}
+	if err := config.Validate(); err != nil {
+		return "", nil, err
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var password, apiKey *crypto.CryptoValue
+	if config.Password != "" {
+		password, err = crypto.Encrypt([]byte(config.Password), c.smsEncryption)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	if config.APIKey != "" {
+		apiKey, err = crypto.Encrypt([]byte(config.APIKey), c.smsEncryption)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewSMSConfigHTTPAddedEvent(
+		ctx,
+		iamAgg,
+		id,
+		config.Endpoint,
+		config.Method,
+		config.Headers,
+		config.BodyTemplate,
+		config.Username,
+		password,
+		config.APIKeyHeader,
+		apiKey))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+// ChangeSMSConfigHTTP changes the config of the http sms provider,
+// the password and the api key are only changed if provided
+func (c *Commands) ChangeSMSConfigHTTP(ctx context.Context, instanceID, id string, config *httpsms.Config) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "SMS-Sfw7a", "Errors.IDMissing")
+	}
+	if config.Method == "" {
+		config.Method = http.MethodPost
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.HTTP == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Sfw7b", "Errors.SMSConfig.NotFound")
+	}
+	var password, apiKey *crypto.CryptoValue
+	if config.Password != "" {
+		password, err = crypto.Encrypt([]byte(config.Password), c.smsEncryption)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.APIKey != "" {
+		apiKey, err = crypto.Encrypt([]byte(config.APIKey), c.smsEncryption)
+		if err != nil {
+			return nil, err
+		}
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+
+	changedEvent, hasChanged, err := smsConfigWriteModel.NewHTTPChangedEvent(
+		ctx,
+		iamAgg,
+		id,
+		config.Endpoint,
+		config.Method,
+		config.Headers,
+		config.BodyTemplate,
+		config.Username,
+		password,
+		config.APIKeyHeader,
+		apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw7c", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
 func (c *Commands) ActivateSMSConfig(ctx context.Context, instanceID, id string) (*domain.ObjectDetails, error) {
 	if id == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "SMS-dn93n", "Errors.IDMissing")
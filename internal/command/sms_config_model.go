@@ -14,6 +14,7 @@ type IAMSMSConfigWriteModel struct {
 
 	ID     string
 	Twilio *TwilioConfig
+	HTTP   *HTTPConfig
 	State  domain.SMSConfigState
 }
 
@@ -23,6 +24,17 @@ type TwilioConfig struct {
 	SenderNumber string
 }
 
+type HTTPConfig struct {
+	Endpoint     string
+	Method       string
+	Headers      map[string]string
+	BodyTemplate string
+	Username     string
+	Password     *crypto.CryptoValue
+	APIKeyHeader string
+	APIKey       *crypto.CryptoValue
+}
+
 func NewIAMSMSConfigWriteModel(instanceID, id string) *IAMSMSConfigWriteModel {
 	return &IAMSMSConfigWriteModel{
 		WriteModel: eventstore.WriteModel{
@@ -61,6 +73,49 @@ func (wm *IAMSMSConfigWriteModel) Reduce() error {
 				continue
 			}
 			wm.Twilio.Token = e.Token
+		case *instance.SMSConfigHTTPAddedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.HTTP = &HTTPConfig{
+				Endpoint:     e.Endpoint,
+				Method:       e.Method,
+				Headers:      e.Headers,
+				BodyTemplate: e.BodyTemplate,
+				Username:     e.Username,
+				Password:     e.Password,
+				APIKeyHeader: e.APIKeyHeader,
+				APIKey:       e.APIKey,
+			}
+			wm.State = domain.SMSConfigStateInactive
+		case *instance.SMSConfigHTTPChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			if e.Endpoint != nil {
+				wm.HTTP.Endpoint = *e.Endpoint
+			}
+			if e.Method != nil {
+				wm.HTTP.Method = *e.Method
+			}
+			if e.Headers != nil {
+				wm.HTTP.Headers = e.Headers
+			}
+			if e.BodyTemplate != nil {
+				wm.HTTP.BodyTemplate = *e.BodyTemplate
+			}
+			if e.Username != nil {
+				wm.HTTP.Username = *e.Username
+			}
+			if e.Password != nil {
+				wm.HTTP.Password = e.Password
+			}
+			if e.APIKeyHeader != nil {
+				wm.HTTP.APIKeyHeader = *e.APIKeyHeader
+			}
+			if e.APIKey != nil {
+				wm.HTTP.APIKey = e.APIKey
+			}
 		case *instance.SMSConfigActivatedEvent:
 			if wm.ID != e.ID {
 				continue
@@ -76,6 +131,7 @@ func (wm *IAMSMSConfigWriteModel) Reduce() error {
 				continue
 			}
 			wm.Twilio = nil
+			wm.HTTP = nil
 			wm.State = domain.SMSConfigStateRemoved
 		}
 	}
@@ -91,6 +147,8 @@ func (wm *IAMSMSConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.SMSConfigTwilioAddedEventType,
 			instance.SMSConfigTwilioChangedEventType,
 			instance.SMSConfigTwilioTokenChangedEventType,
+			instance.SMSConfigHTTPAddedEventType,
+			instance.SMSConfigHTTPChangedEventType,
 			instance.SMSConfigActivatedEventType,
 			instance.SMSConfigDeactivatedEventType,
 			instance.SMSConfigRemovedEventType).
@@ -117,3 +175,66 @@ func (wm *IAMSMSConfigWriteModel) NewChangedEvent(ctx context.Context, aggregate
 	}
 	return changeEvent, true, nil
 }
+
+func (wm *IAMSMSConfigWriteModel) NewHTTPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	endpoint,
+	method string,
+	headers map[string]string,
+	bodyTemplate,
+	username string,
+	password *crypto.CryptoValue,
+	apiKeyHeader string,
+	apiKey *crypto.CryptoValue,
+) (*instance.SMSConfigHTTPChangedEvent, bool, error) {
+	changes := make([]instance.SMSConfigHTTPChanges, 0)
+	var err error
+
+	if wm.HTTP.Endpoint != endpoint {
+		changes = append(changes, instance.ChangeSMSConfigHTTPEndpoint(endpoint))
+	}
+	if wm.HTTP.Method != method {
+		changes = append(changes, instance.ChangeSMSConfigHTTPMethod(method))
+	}
+	if !headersEqual(wm.HTTP.Headers, headers) {
+		changes = append(changes, instance.ChangeSMSConfigHTTPHeaders(headers))
+	}
+	if wm.HTTP.BodyTemplate != bodyTemplate {
+		changes = append(changes, instance.ChangeSMSConfigHTTPBodyTemplate(bodyTemplate))
+	}
+	if wm.HTTP.Username != username {
+		changes = append(changes, instance.ChangeSMSConfigHTTPUsername(username))
+	}
+	if password != nil {
+		changes = append(changes, instance.ChangeSMSConfigHTTPPassword(password))
+	}
+	if wm.HTTP.APIKeyHeader != apiKeyHeader {
+		changes = append(changes, instance.ChangeSMSConfigHTTPAPIKeyHeader(apiKeyHeader))
+	}
+	if apiKey != nil {
+		changes = append(changes, instance.ChangeSMSConfigHTTPAPIKey(apiKey))
+	}
+
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewSMSConfigHTTPChangedEvent(ctx, aggregate, id, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
+
+func headersEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
@@ -2,7 +2,9 @@ package command
 
 import (
 	"context"
+	"crypto/x509"
 	"net"
+	"net/url"
 	"strings"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
@@ -15,27 +17,42 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
 
-func (c *Commands) AddSMTPConfig(ctx context.Context, config *smtp.Config) (*domain.ObjectDetails, error) {
+// SMTPConfig is an smtp provider of the instance,
+// configs with an OrgID are only used to send the notifications of the organization
+type SMTPConfig struct {
+	ID          string
+	OrgID       string
+	Description string
+	// Priority defines the order in which the configs are used, the lowest is tried first
+	Priority uint32
+	smtp.Config
+}
+
+func (c *Commands) AddSMTPConfig(ctx context.Context, config *SMTPConfig) (string, *domain.ObjectDetails, error) {
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareAddSMTPConfig(instanceAgg, config.From, config.FromName, config.SMTP.Host, config.SMTP.User, []byte(config.SMTP.Password), config.Tls)
+	validation := c.prepareAddSMTPConfig(instanceAgg, id, config.OrgID, config.Description, config.Priority, &config.Config)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 	events, err := c.eventstore.Push(ctx, cmds...)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
-	return &domain.ObjectDetails{
+	return id, &domain.ObjectDetails{
 		Sequence:      events[len(events)-1].Sequence(),
 		EventDate:     events[len(events)-1].CreationDate(),
 		ResourceOwner: events[len(events)-1].Aggregate().InstanceID,
 	}, nil
 }
 
-func (c *Commands) ChangeSMTPConfig(ctx context.Context, config *smtp.Config) (*domain.ObjectDetails, error) {
+func (c *Commands) ChangeSMTPConfig(ctx context.Context, config *SMTPConfig) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareChangeSMTPConfig(instanceAgg, config.From, config.FromName, config.SMTP.Host, config.SMTP.User, config.Tls)
+	validation := c.prepareChangeSMTPConfig(instanceAgg, smtpConfigIDOrDefault(ctx, config.ID), config.Description, config.Priority, &config.Config)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
 		return nil, err
@@ -51,9 +68,9 @@ func (c *Commands) ChangeSMTPConfig(ctx context.Context, config *smtp.Config) (*
 	}, nil
 }
 
-func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, password string) (*domain.ObjectDetails, error) {
+func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, id, password string) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	smtpConfigWriteModel, err := getSMTPConfigWriteModel(ctx, c.eventstore.Filter, "")
+	smtpConfigWriteModel, err := getSMTPConfigWriteModel(ctx, c.eventstore.Filter, smtpConfigIDOrDefault(ctx, id), "")
 	if err != nil {
 		return nil, err
 	}
@@ -70,6 +87,7 @@ func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, password string
 	events, err := c.eventstore.Push(ctx, instance.NewSMTPConfigPasswordChangedEvent(
 		ctx,
 		&instanceAgg.Aggregate,
+		smtpConfigWriteModel.ID,
 		smtpPassword))
 	if err != nil {
 		return nil, err
@@ -81,9 +99,44 @@ func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, password string
 	}, nil
 }
 
-func (c *Commands) RemoveSMTPConfig(ctx context.Context) (*domain.ObjectDetails, error) {
+func (c *Commands) ChangeSMTPConfigCACertificate(ctx context.Context, id, caCertificate string) (*domain.ObjectDetails, error) {
+	caCertificate = strings.TrimSpace(caCertificate)
+	if err := validateSMTPCACertificate(caCertificate); err != nil {
+		return nil, err
+	}
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	smtpConfigWriteModel, err := getSMTPConfigWriteModel(ctx, c.eventstore.Filter, smtpConfigIDOrDefault(ctx, id), "")
+	if err != nil {
+		return nil, err
+	}
+	if smtpConfigWriteModel.State != domain.SMTPConfigStateActive {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Sfw3a", "Errors.SMTPConfig.NotFound")
+	}
+	if caCertificate == "" && smtpConfigWriteModel.CACertificate == nil {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Sfw3b", "Errors.NoChangesFound")
+	}
+	smtpCACertificate, err := c.smtpCACertificate(caCertificate)
+	if err != nil {
+		return nil, err
+	}
+	events, err := c.eventstore.Push(ctx, instance.NewSMTPConfigCACertificateChangedEvent(
+		ctx,
+		&instanceAgg.Aggregate,
+		smtpConfigWriteModel.ID,
+		smtpCACertificate))
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ObjectDetails{
+		Sequence:      events[len(events)-1].Sequence(),
+		EventDate:     events[len(events)-1].CreationDate(),
+		ResourceOwner: events[len(events)-1].Aggregate().InstanceID,
+	}, nil
+}
+
+func (c *Commands) RemoveSMTPConfig(ctx context.Context, id string) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareRemoveSMTPConfig(instanceAgg)
+	validation := c.prepareRemoveSMTPConfig(instanceAgg, smtpConfigIDOrDefault(ctx, id))
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
 		return nil, err
@@ -99,65 +152,152 @@ func (c *Commands) RemoveSMTPConfig(ctx context.Context) (*domain.ObjectDetails,
 	}, nil
 }
 
-func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, from, name, hostAndPort, user string, password []byte, tls bool) preparation.Validation {
+// TestSMTPConfig sends a test message to the receiver using the stored config
+// and returns the error of the smtp dialogue if the message could not be sent
+func (c *Commands) TestSMTPConfig(ctx context.Context, id, receiver string) error {
+	if receiver = strings.TrimSpace(receiver); receiver == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Sfw2a", "Errors.Invalid.Argument")
+	}
+	smtpConfigWriteModel, err := getSMTPConfigWriteModel(ctx, c.eventstore.Filter, smtpConfigIDOrDefault(ctx, id), "")
+	if err != nil {
+		return err
+	}
+	if smtpConfigWriteModel.State != domain.SMTPConfigStateActive {
+		return errors.ThrowNotFound(nil, "COMMAND-Sfw2b", "Errors.SMTPConfig.NotFound")
+	}
+	password, err := crypto.DecryptString(smtpConfigWriteModel.Password, c.smtpEncryption)
+	if err != nil {
+		return err
+	}
+	config := &smtp.Config{
+		Tls:      smtpConfigWriteModel.TLS,
+		TLSMode:  smtpConfigWriteModel.TLSMode,
+		From:     smtpConfigWriteModel.SenderAddress,
+		FromName: smtpConfigWriteModel.SenderName,
+		SMTP: smtp.SMTP{
+			Host:     smtpConfigWriteModel.Host,
+			User:     smtpConfigWriteModel.User,
+			Password: password,
+			AuthType: smtpConfigWriteModel.AuthType,
+		},
+	}
+	if xoauth2 := smtpConfigWriteModel.XOAuth2; xoauth2 != nil {
+		config.SMTP.XOAuth2 = &smtp.XOAuth2{
+			TokenEndpoint: xoauth2.TokenEndpoint,
+			ClientID:      xoauth2.ClientID,
+			Scopes:        xoauth2.Scopes,
+		}
+		if xoauth2.ClientSecret != nil {
+			config.SMTP.XOAuth2.ClientSecret, err = crypto.DecryptString(xoauth2.ClientSecret, c.smtpEncryption)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if smtpConfigWriteModel.CACertificate != nil {
+		config.CACertificate, err = crypto.DecryptString(smtpConfigWriteModel.CACertificate, c.smtpEncryption)
+		if err != nil {
+			return err
+		}
+	}
+	return smtp.TestConfiguration(config, receiver)
+}
+
+// smtpConfigIDOrDefault returns the instance id for an empty id,
+// which is the id of the config created before multiple configs were supported
+func smtpConfigIDOrDefault(ctx context.Context, id string) string {
+	if id != "" {
+		return id
+	}
+	return authz.GetInstance(ctx).InstanceID()
+}
+
+func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, id, orgID, description string, priority uint32, config *smtp.Config) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
-		if from = strings.TrimSpace(from); from == "" {
+		from := strings.TrimSpace(config.From)
+		if from == "" {
 			return nil, errors.ThrowInvalidArgument(nil, "INST-mruNY", "Errors.Invalid.Argument")
 		}
-		hostAndPort = strings.TrimSpace(hostAndPort)
+		hostAndPort := strings.TrimSpace(config.SMTP.Host)
 		if _, _, err := net.SplitHostPort(hostAndPort); err != nil {
 			return nil, errors.ThrowInvalidArgument(nil, "INST-9JdRe", "Errors.Invalid.Argument")
 		}
+		if err := validateSMTPSecurity(config); err != nil {
+			return nil, err
+		}
+		if config.SMTP.AuthType == domain.SMTPAuthTypeXOAuth2 && config.SMTP.XOAuth2 == nil {
+			return nil, errors.ThrowInvalidArgument(nil, "INST-Sfw5f", "Errors.SMTPConfig.InvalidXOAuth2")
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			fromSplitted := strings.Split(from, "@")
 			senderDomain := fromSplitted[len(fromSplitted)-1]
-			writeModel, err := getSMTPConfigWriteModel(ctx, filter, senderDomain)
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, senderDomain)
 			if err != nil {
 				return nil, err
 			}
 			if writeModel.State == domain.SMTPConfigStateActive {
 				return nil, errors.ThrowAlreadyExists(nil, "INST-W3VS2", "Errors.SMTPConfig.AlreadyExists")
 			}
-			err = checkSenderAddress(writeModel)
+			if orgID != "" {
+				if exists, err := ExistsOrg(ctx, filter, orgID); err != nil || !exists {
+					return nil, errors.ThrowPreconditionFailed(err, "INST-Sfw3d", "Errors.Org.NotFound")
+				}
+			} else if err = checkSenderAddress(writeModel); err != nil {
+				return nil, err
+			}
+			smtpPassword, err := crypto.Encrypt([]byte(config.SMTP.Password), c.smtpEncryption)
 			if err != nil {
 				return nil, err
 			}
-			var smtpPassword *crypto.CryptoValue
-			if password != nil {
-				smtpPassword, err = crypto.Encrypt(password, c.smtpEncryption)
-				if err != nil {
-					return nil, err
-				}
+			xoauth2, err := c.smtpXOAuth2(config.SMTP.XOAuth2)
+			if err != nil {
+				return nil, err
+			}
+			caCertificate, err := c.smtpCACertificate(config.CACertificate)
+			if err != nil {
+				return nil, err
 			}
 			return []eventstore.Command{
 				instance.NewSMTPConfigAddedEvent(
 					ctx,
 					&a.Aggregate,
-					tls,
+					id,
+					orgID,
+					description,
+					priority,
+					config.Tls,
 					from,
-					name,
+					config.FromName,
 					hostAndPort,
-					user,
+					config.SMTP.User,
 					smtpPassword,
+					config.TLSMode,
+					config.SMTP.AuthType,
+					xoauth2,
+					caCertificate,
 				),
 			}, nil
 		}, nil
 	}
 }
 
-func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, from, name, hostAndPort, user string, tls bool) preparation.Validation {
+func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, id, description string, priority uint32, config *smtp.Config) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
-		if from = strings.TrimSpace(from); from == "" {
+		from := strings.TrimSpace(config.From)
+		if from == "" {
 			return nil, errors.ThrowInvalidArgument(nil, "INST-ASv2d", "Errors.Invalid.Argument")
 		}
-		hostAndPort = strings.TrimSpace(hostAndPort)
+		hostAndPort := strings.TrimSpace(config.SMTP.Host)
 		if _, _, err := net.SplitHostPort(hostAndPort); err != nil {
 			return nil, errors.ThrowInvalidArgument(nil, "INST-Kv875", "Errors.Invalid.Argument")
 		}
+		if err := validateSMTPSecurity(config); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			fromSplitted := strings.Split(from, "@")
 			senderDomain := fromSplitted[len(fromSplitted)-1]
-			writeModel, err := getSMTPConfigWriteModel(ctx, filter, senderDomain)
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, senderDomain)
 			if err != nil {
 				return nil, err
 			}
@@ -168,14 +308,26 @@ func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, from, name, ho
 			if err != nil {
 				return nil, err
 			}
+			xoauth2, err := c.smtpXOAuth2(config.SMTP.XOAuth2)
+			if err != nil {
+				return nil, err
+			}
+			if config.SMTP.AuthType == domain.SMTPAuthTypeXOAuth2 && xoauth2 == nil && writeModel.XOAuth2 == nil {
+				return nil, errors.ThrowInvalidArgument(nil, "INST-Sfw5c", "Errors.SMTPConfig.InvalidXOAuth2")
+			}
 			changedEvent, hasChanged, err := writeModel.NewChangedEvent(
 				ctx,
 				&a.Aggregate,
-				tls,
+				description,
+				priority,
+				config.Tls,
+				config.TLSMode,
+				config.SMTP.AuthType,
 				from,
-				name,
+				config.FromName,
 				hostAndPort,
-				user,
+				config.SMTP.User,
+				xoauth2,
 			)
 			if err != nil {
 				return nil, err
@@ -190,10 +342,10 @@ func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, from, name, ho
 	}
 }
 
-func (c *Commands) prepareRemoveSMTPConfig(a *instance.Aggregate) preparation.Validation {
+func (c *Commands) prepareRemoveSMTPConfig(a *instance.Aggregate, id string) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
-			writeModel, err := getSMTPConfigWriteModel(ctx, filter, "")
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, "")
 			if err != nil {
 				return nil, err
 			}
@@ -201,14 +353,66 @@ func (c *Commands) prepareRemoveSMTPConfig(a *instance.Aggregate) preparation.Va
 				return nil, errors.ThrowNotFound(nil, "INST-Sfefg", "Errors.SMTPConfig.NotFound")
 			}
 			return []eventstore.Command{
-				instance.NewSMTPConfigRemovedEvent(ctx, &a.Aggregate),
+				instance.NewSMTPConfigRemovedEvent(ctx, &a.Aggregate, id),
 			}, nil
 		}, nil
 	}
 }
 
+// validateSMTPSecurity checks the tls mode, the authentication and the ca certificate of the config
+func validateSMTPSecurity(config *smtp.Config) error {
+	if !config.TLSMode.Valid() {
+		return errors.ThrowInvalidArgument(nil, "INST-Sfw5a", "Errors.SMTPConfig.InvalidTLSMode")
+	}
+	if !config.SMTP.AuthType.Valid() {
+		return errors.ThrowInvalidArgument(nil, "INST-Sfw5b", "Errors.SMTPConfig.InvalidAuthType")
+	}
+	if xoauth2 := config.SMTP.XOAuth2; xoauth2 != nil {
+		endpoint, err := url.Parse(xoauth2.TokenEndpoint)
+		if err != nil || (endpoint.Scheme != "https" && endpoint.Scheme != "http") || endpoint.Host == "" || xoauth2.ClientID == "" {
+			return errors.ThrowInvalidArgument(err, "INST-Sfw5d", "Errors.SMTPConfig.InvalidXOAuth2")
+		}
+	}
+	return validateSMTPCACertificate(config.CACertificate)
+}
+
+func validateSMTPCACertificate(caCertificate string) error {
+	if caCertificate != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(caCertificate)) {
+		return errors.ThrowInvalidArgument(nil, "INST-Sfw5e", "Errors.SMTPConfig.InvalidCACertificate")
+	}
+	return nil
+}
+
+// smtpXOAuth2 maps the config and encrypts the client secret if it is set
+func (c *Commands) smtpXOAuth2(config *smtp.XOAuth2) (_ *instance.SMTPXOAuth2, err error) {
+	if config == nil {
+		return nil, nil
+	}
+	xoauth2 := &instance.SMTPXOAuth2{
+		TokenEndpoint: config.TokenEndpoint,
+		ClientID:      config.ClientID,
+		Scopes:        config.Scopes,
+	}
+	if config.ClientSecret != "" {
+		xoauth2.ClientSecret, err = crypto.Encrypt([]byte(config.ClientSecret), c.smtpEncryption)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return xoauth2, nil
+}
+
+func (c *Commands) smtpCACertificate(caCertificate string) (*crypto.CryptoValue, error) {
+	if caCertificate == "" {
+		return nil, nil
+	}
+	return crypto.Encrypt([]byte(caCertificate), c.smtpEncryption)
+}
+
+// checkSenderAddress checks the sender address against the instance domains if required by the domain policy,
+// configs of an organization are allowed to use the organization's own sender domain
 func checkSenderAddress(writeModel *InstanceSMTPConfigWriteModel) error {
-	if !writeModel.smtpSenderAddressMatchesInstanceDomain {
+	if !writeModel.smtpSenderAddressMatchesInstanceDomain || writeModel.OrgID != "" {
 		return nil
 	}
 	if !writeModel.domainState.Exists() {
@@ -217,8 +421,8 @@ func checkSenderAddress(writeModel *InstanceSMTPConfigWriteModel) error {
 	return nil
 }
 
-func getSMTPConfigWriteModel(ctx context.Context, filter preparation.FilterToQueryReducer, domain string) (_ *InstanceSMTPConfigWriteModel, err error) {
-	writeModel := NewInstanceSMTPConfigWriteModel(authz.GetInstance(ctx).InstanceID(), domain)
+func getSMTPConfigWriteModel(ctx context.Context, filter preparation.FilterToQueryReducer, id, domain string) (_ *InstanceSMTPConfigWriteModel, err error) {
+	writeModel := NewInstanceSMTPConfigWriteModel(authz.GetInstance(ctx).InstanceID(), id, domain)
 	events, err := filter(ctx, writeModel.Query())
 	if err != nil {
 		return nil, err
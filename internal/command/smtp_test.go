@@ -13,8 +13,10 @@ import (
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
 )
 
 func TestCommandSide_AddSMTPConfig(t *testing.T) {
@@ -23,8 +25,9 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		alg        crypto.EncryptionAlgorithm
 	}
 	type args struct {
-		ctx  context.Context
-		smtp *smtp.Config
+		ctx   context.Context
+		orgID string
+		smtp  *smtp.Config
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -92,12 +95,20 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 						eventFromEventPusher(
 							instance.NewSMTPConfigAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"",
+								"",
+								0,
 								true,
 								"from@domain.ch",
 								"name",
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
 							),
 						),
 					),
@@ -147,6 +158,10 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 								instance.NewSMTPConfigAddedEvent(
 									context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
+									"configid",
+									"",
+									"",
+									0,
 									true,
 									"from@domain.ch",
 									"name",
@@ -158,6 +173,10 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 										KeyID:      "id",
 										Crypted:    []byte("password"),
 									},
+									domain.SMTPTLSModeUnspecified,
+									domain.SMTPAuthTypeUnspecified,
+									nil,
+									nil,
 								),
 							),
 						},
@@ -184,6 +203,282 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "add org smtp config, org not existing",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "INSTANCE"),
+				orgID: "org1",
+				smtp: &smtp.Config{
+					From: "from@org.ch",
+					SMTP: smtp.SMTP{
+						Host: "host:587",
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "add org smtp config, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewDomainPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								true, true, true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"org",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewSMTPConfigAddedEvent(
+									context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"configid",
+									"org1",
+									"",
+									0,
+									false,
+									"from@org.ch",
+									"",
+									"host:587",
+									"",
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte(""),
+									},
+									domain.SMTPTLSModeUnspecified,
+									domain.SMTPAuthTypeUnspecified,
+									nil,
+									nil,
+								),
+							),
+						},
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "INSTANCE"),
+				orgID: "org1",
+				smtp: &smtp.Config{
+					From: "from@org.ch",
+					SMTP: smtp.SMTP{
+						Host: "host:587",
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "smtp config, invalid tls mode",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				smtp: &smtp.Config{
+					TLSMode:  domain.SMTPTLSMode(99),
+					From:     "from@domain.ch",
+					FromName: "name",
+					SMTP: smtp.SMTP{
+						Host: "host:587",
+						User: "user",
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "smtp config, xoauth2 token endpoint invalid",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				smtp: &smtp.Config{
+					From:     "from@domain.ch",
+					FromName: "name",
+					SMTP: smtp.SMTP{
+						Host:     "host:587",
+						User:     "user",
+						AuthType: domain.SMTPAuthTypeXOAuth2,
+						XOAuth2: &smtp.XOAuth2{
+							TokenEndpoint: "token",
+							ClientID:      "clientID",
+							ClientSecret:  "clientSecret",
+						},
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "smtp config, xoauth2 config missing",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				smtp: &smtp.Config{
+					From:     "from@domain.ch",
+					FromName: "name",
+					SMTP: smtp.SMTP{
+						Host:     "host:587",
+						User:     "user",
+						AuthType: domain.SMTPAuthTypeXOAuth2,
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "smtp config, invalid ca certificate",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				smtp: &smtp.Config{
+					From:          "from@domain.ch",
+					FromName:      "name",
+					CACertificate: "certificate",
+					SMTP: smtp.SMTP{
+						Host: "host:587",
+						User: "user",
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "add smtp config with xoauth2, starttls and ca certificate, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewDomainAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"domain.ch",
+								false,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewDomainPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								true, true, false,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewSMTPConfigAddedEvent(
+									context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"configid",
+									"",
+									"",
+									0,
+									false,
+									"from@domain.ch",
+									"name",
+									"host:587",
+									"user",
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte(""),
+									},
+									domain.SMTPTLSModeStartTLSRequired,
+									domain.SMTPAuthTypeXOAuth2,
+									&instance.SMTPXOAuth2{
+										TokenEndpoint: "https://login.domain.ch/oauth2/token",
+										ClientID:      "clientID",
+										ClientSecret: &crypto.CryptoValue{
+											CryptoType: crypto.TypeEncryption,
+											Algorithm:  "enc",
+											KeyID:      "id",
+											Crypted:    []byte("clientSecret"),
+										},
+										Scopes: []string{"https://outlook.office365.com/.default"},
+									},
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte(testSMTPCACertificate),
+									},
+								),
+							),
+						},
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				smtp: &smtp.Config{
+					TLSMode:       domain.SMTPTLSModeStartTLSRequired,
+					CACertificate: testSMTPCACertificate,
+					From:          "from@domain.ch",
+					FromName:      "name",
+					SMTP: smtp.SMTP{
+						Host:     "host:587",
+						User:     "user",
+						AuthType: domain.SMTPAuthTypeXOAuth2,
+						XOAuth2: &smtp.XOAuth2{
+							TokenEndpoint: "https://login.domain.ch/oauth2/token",
+							ClientID:      "clientID",
+							ClientSecret:  "clientSecret",
+							Scopes:        []string{"https://outlook.office365.com/.default"},
+						},
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
 		{
 			name: "smtp config, port is missing",
 			fields: fields{
@@ -255,6 +550,10 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 								instance.NewSMTPConfigAddedEvent(
 									context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
+									"configid",
+									"",
+									"",
+									0,
 									true,
 									"from@domain.ch",
 									"name",
@@ -266,6 +565,10 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 										KeyID:      "id",
 										Crypted:    []byte("password"),
 									},
+									domain.SMTPTLSModeUnspecified,
+									domain.SMTPAuthTypeUnspecified,
+									nil,
+									nil,
 								),
 							),
 						},
@@ -297,9 +600,10 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
 				eventstore:     tt.fields.eventstore,
+				idGenerator:    id_mock.NewIDGeneratorExpectIDs(t, "configid"),
 				smtpEncryption: tt.fields.alg,
 			}
-			got, err := r.AddSMTPConfig(tt.args.ctx, tt.args.smtp)
+			_, got, err := r.AddSMTPConfig(tt.args.ctx, &SMTPConfig{OrgID: tt.args.orgID, Config: *tt.args.smtp})
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -316,10 +620,14 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 	type fields struct {
 		eventstore *eventstore.Eventstore
+		alg        crypto.EncryptionAlgorithm
 	}
 	type args struct {
-		ctx  context.Context
-		smtp *smtp.Config
+		ctx         context.Context
+		id          string
+		description string
+		priority    uint32
+		smtp        *smtp.Config
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -393,12 +701,20 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
+								"",
+								"",
+								0,
 								true,
 								"from@domain.ch",
 								"name",
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
 							),
 						),
 					),
@@ -443,12 +759,20 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
+								"",
+								"",
+								0,
 								true,
 								"from@domain.ch",
 								"name",
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
 							),
 						),
 					),
@@ -493,12 +817,20 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
+								"",
+								"",
+								0,
 								true,
 								"from@domain.ch",
 								"name",
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
 							),
 						),
 					),
@@ -538,9 +870,46 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "smtp config, port is missing",
+			name: "smtp config change to xoauth2 without xoauth2 config, invalid argument error",
 			fields: fields{
-				eventstore: eventstoreExpect(t),
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewDomainAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"domain.ch",
+								false,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewDomainPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								true, true, true,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
+								"",
+								"",
+								0,
+								true,
+								"from@domain.ch",
+								"name",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
+							),
+						),
+					),
+				),
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
@@ -549,9 +918,9 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 					From:     "from@domain.ch",
 					FromName: "name",
 					SMTP: smtp.SMTP{
-						Host:     "host",
+						Host:     "host:587",
 						User:     "user",
-						Password: "password",
+						AuthType: domain.SMTPAuthTypeXOAuth2,
 					},
 				},
 			},
@@ -560,13 +929,219 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "smtp config, host is empty",
+			name: "smtp config change to xoauth2 and starttls, ok",
 			fields: fields{
-				eventstore: eventstoreExpect(t),
-			},
-			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
-				smtp: &smtp.Config{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewDomainAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"domain.ch",
+								false,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewDomainPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								true, true, true,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
+								"",
+								"",
+								0,
+								true,
+								"from@domain.ch",
+								"name",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								func() *instance.SMTPConfigChangedEvent {
+									event, _ := instance.NewSMTPConfigChangeEvent(context.Background(),
+										&instance.NewAggregate("INSTANCE").Aggregate,
+										"INSTANCE",
+										[]instance.SMTPConfigChanges{
+											instance.ChangeSMTPConfigTLSMode(domain.SMTPTLSModeStartTLSRequired),
+											instance.ChangeSMTPConfigAuthType(domain.SMTPAuthTypeXOAuth2),
+											instance.ChangeSMTPConfigXOAuth2(&instance.SMTPXOAuth2{
+												TokenEndpoint: "https://login.domain.ch/oauth2/token",
+												ClientID:      "clientID",
+												ClientSecret: &crypto.CryptoValue{
+													CryptoType: crypto.TypeEncryption,
+													Algorithm:  "enc",
+													KeyID:      "id",
+													Crypted:    []byte("clientSecret"),
+												},
+											}),
+										},
+									)
+									return event
+								}(),
+							),
+						},
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				smtp: &smtp.Config{
+					Tls:      true,
+					TLSMode:  domain.SMTPTLSModeStartTLSRequired,
+					From:     "from@domain.ch",
+					FromName: "name",
+					SMTP: smtp.SMTP{
+						Host:     "host:587",
+						User:     "user",
+						AuthType: domain.SMTPAuthTypeXOAuth2,
+						XOAuth2: &smtp.XOAuth2{
+							TokenEndpoint: "https://login.domain.ch/oauth2/token",
+							ClientID:      "clientID",
+							ClientSecret:  "clientSecret",
+						},
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "smtp config change by id, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"",
+								"",
+								0,
+								true,
+								"from@domain.ch",
+								"name",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid2",
+								"",
+								"",
+								0,
+								false,
+								"from2@domain.ch",
+								"name2",
+								"host2:587",
+								"user2",
+								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								func() *instance.SMTPConfigChangedEvent {
+									event, _ := instance.NewSMTPConfigChangeEvent(context.Background(),
+										&instance.NewAggregate("INSTANCE").Aggregate,
+										"configid",
+										[]instance.SMTPConfigChanges{
+											instance.ChangeSMTPConfigDescription("fallback"),
+											instance.ChangeSMTPConfigPriority(1),
+										},
+									)
+									return event
+								}(),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:          "configid",
+				description: "fallback",
+				priority:    1,
+				smtp: &smtp.Config{
+					Tls:      true,
+					From:     "from@domain.ch",
+					FromName: "name",
+					SMTP: smtp.SMTP{
+						Host: "host:587",
+						User: "user",
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "smtp config, port is missing",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				smtp: &smtp.Config{
+					Tls:      true,
+					From:     "from@domain.ch",
+					FromName: "name",
+					SMTP: smtp.SMTP{
+						Host:     "host",
+						User:     "user",
+						Password: "password",
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "smtp config, host is empty",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
 					FromName: "name",
@@ -604,12 +1179,20 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
+								"",
+								"",
+								0,
 								true,
 								"from@domain.ch",
 								"name",
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
 							),
 						),
 					),
@@ -652,9 +1235,10 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
-				eventstore: tt.fields.eventstore,
+				eventstore:     tt.fields.eventstore,
+				smtpEncryption: tt.fields.alg,
 			}
-			got, err := r.ChangeSMTPConfig(tt.args.ctx, tt.args.smtp)
+			got, err := r.ChangeSMTPConfig(tt.args.ctx, &SMTPConfig{ID: tt.args.id, Description: tt.args.description, Priority: tt.args.priority, Config: *tt.args.smtp})
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -675,6 +1259,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 	}
 	type args struct {
 		ctx      context.Context
+		id       string
 		password string
 	}
 	type res struct {
@@ -713,12 +1298,20 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
+								"",
+								"",
+								0,
 								true,
 								"from",
 								"name",
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
 							),
 						),
 					),
@@ -729,6 +1322,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 								instance.NewSMTPConfigPasswordChangedEvent(
 									context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
+									"INSTANCE",
 									&crypto.CryptoValue{
 										CryptoType: crypto.TypeEncryption,
 										Algorithm:  "enc",
@@ -759,7 +1353,222 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 				eventstore:     tt.fields.eventstore,
 				smtpEncryption: tt.fields.alg,
 			}
-			got, err := r.ChangeSMTPConfigPassword(tt.args.ctx, tt.args.password)
+			got, err := r.ChangeSMTPConfigPassword(tt.args.ctx, tt.args.id, tt.args.password)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeSMTPConfigCACertificate(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		alg        crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx           context.Context
+		id            string
+		caCertificate string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid ca certificate, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "INSTANCE"),
+				caCertificate: "certificate",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "smtp config, error not found",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "INSTANCE"),
+				caCertificate: testSMTPCACertificate,
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "remove missing ca certificate, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
+								"",
+								"",
+								0,
+								true,
+								"from",
+								"name",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "change smtp config ca certificate, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
+								"",
+								"",
+								0,
+								true,
+								"from",
+								"name",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewSMTPConfigCACertificateChangedEvent(
+									context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"INSTANCE",
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte(testSMTPCACertificate),
+									},
+								),
+							),
+						},
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "INSTANCE"),
+				caCertificate: testSMTPCACertificate,
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "remove smtp config ca certificate, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
+								"",
+								"",
+								0,
+								true,
+								"from",
+								"name",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								&crypto.CryptoValue{},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewSMTPConfigCACertificateChangedEvent(
+									context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"INSTANCE",
+									nil,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:     tt.fields.eventstore,
+				smtpEncryption: tt.fields.alg,
+			}
+			got, err := r.ChangeSMTPConfigCACertificate(tt.args.ctx, tt.args.id, tt.args.caCertificate)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -780,6 +1589,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 	}
 	type args struct {
 		ctx context.Context
+		id  string
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -816,12 +1626,20 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
+								"",
+								"",
+								0,
 								true,
 								"from",
 								"name",
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
 							),
 						),
 					),
@@ -832,6 +1650,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 								instance.NewSMTPConfigRemovedEvent(
 									context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
+									"INSTANCE",
 								),
 							),
 						},
@@ -847,6 +1666,94 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "remove smtp config by id, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"",
+								"",
+								0,
+								true,
+								"from",
+								"name",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewSMTPConfigRemovedEvent(
+									context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"configid",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "configid",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "remove smtp config of other id, not found",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"",
+								"",
+								0,
+								true,
+								"from",
+								"name",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "configid2",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -854,7 +1761,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 				eventstore:     tt.fields.eventstore,
 				smtpEncryption: tt.fields.alg,
 			}
-			got, err := r.RemoveSMTPConfig(tt.args.ctx)
+			got, err := r.RemoveSMTPConfig(tt.args.ctx, tt.args.id)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -868,6 +1775,99 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 	}
 }
 
+func TestCommandSide_TestSMTPConfig(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		id       string
+		receiver string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "receiver missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "configid",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "smtp config removed, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"",
+								"",
+								0,
+								true,
+								"from",
+								"name",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								nil,
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewSMTPConfigRemovedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:      authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:       "configid",
+				receiver: "test@domain.ch",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := r.TestSMTPConfig(tt.args.ctx, tt.args.id, tt.args.receiver)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
 func newSMTPConfigChangedEvent(ctx context.Context, tls bool, fromAddress, fromName, host, user string) *instance.SMTPConfigChangedEvent {
 	changes := []instance.SMTPConfigChanges{
 		instance.ChangeSMTPConfigTLS(tls),
@@ -878,7 +1878,20 @@ func newSMTPConfigChangedEvent(ctx context.Context, tls bool, fromAddress, fromN
 	}
 	event, _ := instance.NewSMTPConfigChangeEvent(ctx,
 		&instance.NewAggregate("INSTANCE").Aggregate,
+		"INSTANCE",
 		changes,
 	)
 	return event
 }
+
+const testSMTPCACertificate = `-----BEGIN CERTIFICATE-----
+MIIBiDCCAS+gAwIBAgIUZ/HBnGgBdfMHx/Bh9rqSLjIUYRowCgYIKoZIzj0EAwIw
+GTEXMBUGA1UEAwwOc210cC5kb21haW4uY2gwIBcNMjYxMDE5MDQ1NTUzWhgPMjEy
+NjA5MjUwNDU1NTNaMBkxFzAVBgNVBAMMDnNtdHAuZG9tYWluLmNoMFkwEwYHKoZI
+zj0CAQYIKoZIzj0DAQcDQgAEX9UzxU43vFqmLfI8Zj4oEs3HHhQy3NZCako+qmSq
+ZMAVQRUDNQ2Uwdpk4+LxOkY6wNevOxCFm3lLpEZxxZ27FKNTMFEwHQYDVR0OBBYE
+FA/I8ksv04SHDNbqb/P+WPPPbPtSMB8GA1UdIwQYMBaAFA/I8ksv04SHDNbqb/P+
+WPPPbPtSMA8GA1UdEwEB/wQFMAMBAf8wCgYIKoZIzj0EAwIDRwAwRAIgb7YN3eWq
+qhYUUUn9KbnKekXf++5leWi6ew9AOPjuc2ACIGusvx6LQjRHw6yWcBbcJyLO/D6f
+ayCH7YyM/NIG1vBn
+-----END CERTIFICATE-----`
@@ -0,0 +1,90 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// humanCodeChallenge reduces a single-use code sent to the user to sign in (e.g. magic link or phone otp),
+// which is bound to the auth request and user agent it was requested from.
+// It's embedded in the write models of the specific challenges, requesting a new code supersedes any previous one.
+// Failed checks and requested codes are counted per auth request over all codes,
+// so requesting a new code doesn't reset them.
+type humanCodeChallenge struct {
+	CryptoCode    *crypto.CryptoValue
+	RequestDate   time.Time
+	Expiration    time.Duration
+	AuthRequestID string
+	UserAgentID   string
+
+	attempts map[string]uint8
+	requests map[string]uint8
+}
+
+// codeChallengeLimits restrict the checks and requests of codes sent to the user
+type codeChallengeLimits struct {
+	// MaxAttempts is the number of failed checks per auth request after which no code can be checked anymore
+	MaxAttempts uint8
+	// MaxRequests is the number of codes which can be requested per auth request, 0 is unlimited
+	MaxRequests uint8
+	// RequestInterval is the minimal duration between two requested codes of a user, 0 is unlimited
+	RequestInterval time.Duration
+}
+
+func (c *humanCodeChallenge) reduceRequested(code *crypto.CryptoValue, requestDate time.Time, expiry time.Duration, info *user.AuthRequestInfo) {
+	c.CryptoCode = code
+	c.RequestDate = requestDate
+	c.Expiration = expiry
+	c.AuthRequestID = ""
+	c.UserAgentID = ""
+	if info != nil {
+		c.AuthRequestID = info.ID
+		c.UserAgentID = info.UserAgentID
+	}
+	if c.requests == nil {
+		c.requests = make(map[string]uint8)
+	}
+	c.requests[c.AuthRequestID]++
+}
+
+func (c *humanCodeChallenge) reduceCheckFailed(info *user.AuthRequestInfo) {
+	var authRequestID string
+	if info != nil {
+		authRequestID = info.ID
+	}
+	if c.attempts == nil {
+		c.attempts = make(map[string]uint8)
+	}
+	c.attempts[authRequestID]++
+}
+
+// attemptsExceeded returns true if no code must be checked in the auth request anymore because of too many failed checks
+func (c *humanCodeChallenge) attemptsExceeded(authRequestID string, limits codeChallengeLimits) bool {
+	return c.attempts[authRequestID] >= limits.MaxAttempts
+}
+
+// checkRequestAllowed returns an error if a new code must not be requested for the auth request yet
+func (c *humanCodeChallenge) checkRequestAllowed(authRequestID string, limits codeChallengeLimits) error {
+	if limits.MaxRequests > 0 && c.requests[authRequestID] >= limits.MaxRequests {
+		return caos_errs.ThrowResourceExhausted(nil, "COMMAND-Cc2rl", "Errors.User.Code.TooManyRequests")
+	}
+	if limits.RequestInterval > 0 && !c.RequestDate.IsZero() && time.Since(c.RequestDate) < limits.RequestInterval {
+		return caos_errs.ThrowResourceExhausted(nil, "COMMAND-Cc3ri", "Errors.User.Code.RequestedTooOften")
+	}
+	return nil
+}
+
+// verify checks the code and that it's used in the auth request and user agent it was requested from
+func (c *humanCodeChallenge) verify(code string, authRequest *domain.AuthRequest, generator crypto.Generator) error {
+	if err := crypto.VerifyCode(c.RequestDate, c.Expiration, c.CryptoCode, code, generator); err != nil {
+		return err
+	}
+	if c.AuthRequestID != authRequest.ID || c.UserAgentID != authRequest.AgentID {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Cc1au", "Errors.User.Code.Invalid")
+	}
+	return nil
+}
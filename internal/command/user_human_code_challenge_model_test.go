@@ -0,0 +1,88 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func Test_humanCodeChallenge_verify(t *testing.T) {
+	code := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("a"),
+	}
+	type args struct {
+		requestDate time.Time
+		code        string
+		authRequest *domain.AuthRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  func(error) bool
+	}{
+		{
+			name: "wrong code, invalid argument error",
+			args: args{
+				requestDate: time.Now(),
+				code:        "b",
+				authRequest: &domain.AuthRequest{ID: "request1", AgentID: "agent1"},
+			},
+			err: caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "expired code, precondition error",
+			args: args{
+				requestDate: time.Now().Add(-2 * time.Hour),
+				code:        "a",
+				authRequest: &domain.AuthRequest{ID: "request1", AgentID: "agent1"},
+			},
+			err: caos_errs.IsPreconditionFailed,
+		},
+		{
+			name: "other auth request, invalid argument error",
+			args: args{
+				requestDate: time.Now(),
+				code:        "a",
+				authRequest: &domain.AuthRequest{ID: "request2", AgentID: "agent1"},
+			},
+			err: caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "other user agent, invalid argument error",
+			args: args{
+				requestDate: time.Now(),
+				code:        "a",
+				authRequest: &domain.AuthRequest{ID: "request1", AgentID: "agent2"},
+			},
+			err: caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "correct code, ok",
+			args: args{
+				requestDate: time.Now(),
+				code:        "a",
+				authRequest: &domain.AuthRequest{ID: "request1", AgentID: "agent1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := new(humanCodeChallenge)
+			c.reduceRequested(code, tt.args.requestDate, time.Hour, &user.AuthRequestInfo{ID: "request1", UserAgentID: "agent1"})
+			err := c.verify(tt.args.code, tt.args.authRequest, GetMockSecretGenerator(t))
+			if tt.err == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.err != nil && !tt.err(err) {
+				t.Errorf("got wrong err: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// GrantHumanConsent stores the scopes the user approved for the client.
+// Scopes of an existing consent are kept, so the user will only be asked for newly requested scopes.
+func (c *Commands) GrantHumanConsent(ctx context.Context, userID, resourceOwner string, consent *domain.UserConsent, authRequest *domain.AuthRequest) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-c7Lqe", "Errors.User.UserIDMissing")
+	}
+	if consent == nil || consent.ClientID == "" || len(consent.Scopes) == 0 {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vb2ne", "Errors.User.Consent.Invalid")
+	}
+	human, err := c.getHumanWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateExists(human.UserState) {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Oe7mz", "Errors.User.NotFound")
+	}
+
+	existingConsent, err := c.humanConsentWriteModel(ctx, userID, resourceOwner, consent.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	missing := domain.MissingConsentScopes(existingConsent.Scopes, consent.Scopes)
+	if existingConsent.State == domain.ConsentStateActive && len(missing) == 0 {
+		return writeModelToObjectDetails(&existingConsent.WriteModel), nil
+	}
+	scopes := make([]string, 0, len(existingConsent.Scopes)+len(missing))
+	scopes = append(scopes, existingConsent.Scopes...)
+	scopes = append(scopes, missing...)
+
+	var info *user.AuthRequestInfo
+	if authRequest != nil {
+		info = authRequestDomainToAuthRequestInfo(authRequest)
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanConsentGrantedEvent(
+		ctx,
+		UserAggregateFromWriteModel(&human.WriteModel),
+		consent.ClientID,
+		consent.AppID,
+		consent.ProjectID,
+		scopes,
+		info,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingConsent, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingConsent.WriteModel), nil
+}
+
+// DenyHumanConsent records that the user denied the scopes requested by the client in the auth request.
+// An existing consent of the user is kept.
+func (c *Commands) DenyHumanConsent(ctx context.Context, userID, resourceOwner, clientID string, scopes []string, authRequest *domain.AuthRequest) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Dn1ui", "Errors.User.UserIDMissing")
+	}
+	if clientID == "" || authRequest == nil {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Dn2ci", "Errors.User.Consent.Invalid")
+	}
+	human, err := c.getHumanWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateExists(human.UserState) {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Dn3nf", "Errors.User.NotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanConsentDeniedEvent(
+		ctx,
+		UserAggregateFromWriteModel(&human.WriteModel),
+		clientID,
+		scopes,
+		authRequestDomainToAuthRequestInfo(authRequest),
+	))
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+// RevokeHumanConsent removes the consent of the user for the client together with the provided refresh tokens,
+// which were issued to the client.
+func (c *Commands) RevokeHumanConsent(ctx context.Context, userID, resourceOwner, clientID string, refreshTokenIDs []string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Uo3bd", "Errors.User.UserIDMissing")
+	}
+	if clientID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-r8Kfw", "Errors.IDMissing")
+	}
+	existingConsent, err := c.humanConsentWriteModel(ctx, userID, resourceOwner, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if existingConsent.State != domain.ConsentStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Jd0xa", "Errors.User.Consent.NotFound")
+	}
+
+	events := make([]eventstore.Command, 0, len(refreshTokenIDs)+1)
+	events = append(events, user.NewHumanConsentRevokedEvent(ctx, UserAggregateFromWriteModel(&existingConsent.WriteModel), clientID))
+	for _, tokenID := range refreshTokenIDs {
+		event, _, err := c.removeRefreshToken(ctx, userID, existingConsent.ResourceOwner, tokenID)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingConsent, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingConsent.WriteModel), nil
+}
+
+func (c *Commands) humanConsentWriteModel(ctx context.Context, userID, resourceOwner, clientID string) (writeModel *HumanConsentWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanConsentWriteModel(userID, resourceOwner, clientID)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
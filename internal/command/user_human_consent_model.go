@@ -0,0 +1,76 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// HumanConsentWriteModel reduces the scopes the user consented to for a specific client
+type HumanConsentWriteModel struct {
+	eventstore.WriteModel
+
+	ClientID  string
+	AppID     string
+	ProjectID string
+	Scopes    []string
+	State     domain.ConsentState
+}
+
+func NewHumanConsentWriteModel(userID, resourceOwner, clientID string) *HumanConsentWriteModel {
+	return &HumanConsentWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		ClientID: clientID,
+	}
+}
+
+func (wm *HumanConsentWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *user.HumanConsentGrantedEvent:
+			if wm.ClientID != e.ClientID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.HumanConsentRevokedEvent:
+			if wm.ClientID != e.ClientID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		default:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *HumanConsentWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanConsentGrantedEvent:
+			wm.AppID = e.AppID
+			wm.ProjectID = e.ProjectID
+			wm.Scopes = e.Scopes
+			wm.State = domain.ConsentStateActive
+		case *user.HumanConsentRevokedEvent,
+			*user.UserRemovedEvent:
+			wm.Scopes = nil
+			wm.State = domain.ConsentStateRevoked
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanConsentWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanConsentGrantedType,
+			user.HumanConsentRevokedType,
+			user.UserRemovedType).
+		Builder()
+}
@@ -0,0 +1,549 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_GrantHumanConsent(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		userID        string
+		resourceOwner string
+		consent       *domain.UserConsent
+		authRequest   *domain.AuthRequest
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	humanAddedEvent := func() *repository.Event {
+		return eventFromEventPusher(
+			user.NewHumanAddedEvent(context.Background(),
+				&user.NewAggregate("user1", "org1").Aggregate,
+				"username",
+				"firstname",
+				"lastname",
+				"nickname",
+				"displayname",
+				language.German,
+				domain.GenderUnspecified,
+				"email@test.ch",
+				true,
+			),
+		)
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				consent: &domain.UserConsent{
+					ClientID: "client1",
+					Scopes:   []string{oidc.ScopeOpenID},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "scopes missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				consent: &domain.UserConsent{
+					ClientID: "client1",
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not found, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				consent: &domain.UserConsent{
+					ClientID: "client1",
+					Scopes:   []string{oidc.ScopeOpenID},
+				},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "scopes already granted, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						humanAddedEvent(),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanConsentGrantedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"client1",
+								"app1",
+								"project1",
+								[]string{oidc.ScopeOpenID, oidc.ScopeEmail},
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				consent: &domain.UserConsent{
+					ClientID:  "client1",
+					AppID:     "app1",
+					ProjectID: "project1",
+					Scopes:    []string{oidc.ScopeOpenID},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "additional scopes, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						humanAddedEvent(),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanConsentGrantedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"client1",
+								"app1",
+								"project1",
+								[]string{oidc.ScopeOpenID},
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanConsentGrantedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"client2",
+								"app2",
+								"project1",
+								[]string{oidc.ScopeOpenID, oidc.ScopePhone},
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanConsentGrantedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"client1",
+									"app1",
+									"project1",
+									[]string{oidc.ScopeOpenID, oidc.ScopeEmail},
+									&user.AuthRequestInfo{
+										ID:          "request1",
+										UserAgentID: "agent1",
+									},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				consent: &domain.UserConsent{
+					ClientID:  "client1",
+					AppID:     "app1",
+					ProjectID: "project1",
+					Scopes:    []string{oidc.ScopeOpenID, oidc.ScopeEmail},
+				},
+				authRequest: &domain.AuthRequest{
+					ID:      "request1",
+					AgentID: "agent1",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.GrantHumanConsent(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.consent, tt.args.authRequest)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_DenyHumanConsent(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		userID        string
+		resourceOwner string
+		clientID      string
+		scopes        []string
+		authRequest   *domain.AuthRequest
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "auth request missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				clientID:      "client1",
+				scopes:        []string{oidc.ScopeOpenID},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not found, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				clientID:      "client1",
+				scopes:        []string{oidc.ScopeOpenID},
+				authRequest: &domain.AuthRequest{
+					ID:      "request1",
+					AgentID: "agent1",
+				},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "deny consent, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanConsentDeniedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"client1",
+									[]string{oidc.ScopeOpenID},
+									&user.AuthRequestInfo{
+										ID:          "request1",
+										UserAgentID: "agent1",
+									},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				clientID:      "client1",
+				scopes:        []string{oidc.ScopeOpenID},
+				authRequest: &domain.AuthRequest{
+					ID:      "request1",
+					AgentID: "agent1",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.DenyHumanConsent(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.clientID, tt.args.scopes, tt.args.authRequest)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RevokeHumanConsent(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx             context.Context
+		userID          string
+		resourceOwner   string
+		clientID        string
+		refreshTokenIDs []string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	consentGrantedEvent := func() *repository.Event {
+		return eventFromEventPusher(
+			user.NewHumanConsentGrantedEvent(context.Background(),
+				&user.NewAggregate("user1", "org1").Aggregate,
+				"client1",
+				"app1",
+				"project1",
+				[]string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess},
+				nil,
+			),
+		)
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "client id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "consent not found, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				clientID:      "client1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "consent already revoked, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						consentGrantedEvent(),
+						eventFromEventPusher(
+							user.NewHumanConsentRevokedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"client1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				clientID:      "client1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "revoke consent with refresh tokens, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						consentGrantedEvent(),
+					),
+					expectFilter(
+						eventFromEventPusher(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"token1",
+							"client1",
+							"agent1",
+							"de",
+							"",
+							[]string{"client1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							10*time.Hour,
+						)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanConsentRevokedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"client1",
+								),
+							),
+							eventFromEventPusher(
+								user.NewHumanRefreshTokenRemovedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"token1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:             context.Background(),
+				userID:          "user1",
+				resourceOwner:   "org1",
+				clientID:        "client1",
+				refreshTokenIDs: []string{"token1"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RevokeHumanConsent(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.clientID, tt.args.refreshTokenIDs)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
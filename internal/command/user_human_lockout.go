@@ -0,0 +1,92 @@
+package command
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// UnlockExpiredLockout unlocks the user if it was locked by the lockout policy and the lockout duration is over.
+// It returns true if the user was unlocked.
+func (c *Commands) UnlockExpiredLockout(ctx context.Context, userID, resourceOwner string) (_ bool, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return false, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hu8nd", "Errors.User.UserIDMissing")
+	}
+	lockout, err := c.humanLockoutWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return false, err
+	}
+	if !lockout.lockExpired(time.Now()) {
+		return false, nil
+	}
+	_, err = c.eventstore.Push(ctx, user.NewUserUnlockedEvent(ctx, UserAggregateFromWriteModel(&lockout.WriteModel)))
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// otpCheckFailedLockEvent returns the event locking the user,
+// if the failed check reaches the max otp attempts of the lockout policy
+func (c *Commands) otpCheckFailedLockEvent(ctx context.Context, userAgg *eventstore.Aggregate, lockoutPolicy *domain.LockoutPolicy, info *user.AuthRequestInfo) (eventstore.Command, error) {
+	if lockoutPolicy == nil || lockoutPolicy.MaxOTPAttempts == 0 {
+		return nil, nil
+	}
+	lockout, err := c.humanLockoutWriteModel(ctx, userAgg.ID, userAgg.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if lockout.Locked || lockout.OTPCheckFailedCount+1 < lockoutPolicy.MaxOTPAttempts {
+		return nil, nil
+	}
+	return user.NewUserLockedByPolicyEvent(ctx, userAgg, domain.UserLockReasonOTPAttempts, lockout.OTPCheckFailedCount+1, lockoutPolicy.LockedUntil(time.Now()), info), nil
+}
+
+func (c *Commands) humanLockoutWriteModel(ctx context.Context, userID, resourceOwner string) (writeModel *HumanLockoutWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanLockoutWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+// checkLoginThrottle returns an error if checks from the IP of the auth request (or the caller) or on the instance are delayed
+func (c *Commands) checkLoginThrottle(ctx context.Context, authRequest *domain.AuthRequest) error {
+	return c.loginThrottle.check(authz.GetInstance(ctx).InstanceID(), loginRemoteIP(ctx, authRequest))
+}
+
+func (c *Commands) loginCheckFailed(ctx context.Context, authRequest *domain.AuthRequest) {
+	c.loginThrottle.failed(authz.GetInstance(ctx).InstanceID(), loginRemoteIP(ctx, authRequest))
+}
+
+func (c *Commands) loginCheckSucceeded(ctx context.Context, authRequest *domain.AuthRequest) {
+	c.loginThrottle.succeeded(authz.GetInstance(ctx).InstanceID(), loginRemoteIP(ctx, authRequest))
+}
+
+// loginRemoteIP returns the IP of the browser of the auth request
+// or the IP of the caller if there's no auth request (e.g. checks of the session API)
+func loginRemoteIP(ctx context.Context, authRequest *domain.AuthRequest) net.IP {
+	if authRequest != nil && authRequest.BrowserInfo != nil {
+		return authRequest.BrowserInfo.RemoteIP
+	}
+	remoteIP := http_utils.RemoteIPFromCtx(ctx)
+	if host, _, err := net.SplitHostPort(remoteIP); err == nil {
+		remoteIP = host
+	}
+	return net.ParseIP(remoteIP)
+}
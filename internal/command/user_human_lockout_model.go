@@ -0,0 +1,81 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// HumanLockoutWriteModel counts the failed checks of second factors and passwordless
+// since the last successful check or unlock of the user and reduces the lock state of the user
+type HumanLockoutWriteModel struct {
+	eventstore.WriteModel
+
+	OTPCheckFailedCount uint64
+	Locked              bool
+	LockReason          domain.UserLockReason
+	LockedUntil         *time.Time
+}
+
+func NewHumanLockoutWriteModel(userID, resourceOwner string) *HumanLockoutWriteModel {
+	return &HumanLockoutWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanLockoutWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanOTPCheckFailedEvent,
+			*user.HumanPhoneOTPCheckFailedEvent,
+			*user.HumanU2FCheckFailedEvent,
+			*user.HumanPasswordlessCheckFailedEvent:
+			wm.OTPCheckFailedCount++
+		case *user.HumanOTPCheckSucceededEvent,
+			*user.HumanPhoneOTPCheckSucceededEvent,
+			*user.HumanU2FCheckSucceededEvent,
+			*user.HumanPasswordlessCheckSucceededEvent:
+			wm.OTPCheckFailedCount = 0
+		case *user.UserLockedEvent:
+			wm.Locked = true
+			wm.LockReason = e.Reason
+			wm.LockedUntil = e.LockedUntil
+		case *user.UserUnlockedEvent:
+			wm.OTPCheckFailedCount = 0
+			wm.Locked = false
+			wm.LockReason = domain.UserLockReasonUnspecified
+			wm.LockedUntil = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanLockoutWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.HumanMFAOTPCheckFailedType,
+			user.HumanMFAOTPCheckSucceededType,
+			user.HumanPhoneOTPCheckFailedType,
+			user.HumanPhoneOTPCheckSucceededType,
+			user.HumanU2FTokenCheckFailedType,
+			user.HumanU2FTokenCheckSucceededType,
+			user.HumanPasswordlessTokenCheckFailedType,
+			user.HumanPasswordlessTokenCheckSucceededType,
+			user.UserLockedType,
+			user.UserUnlockedType).
+		Builder()
+}
+
+// lockExpired is true if the user was locked by the lockout policy and the lockout duration is over
+func (wm *HumanLockoutWriteModel) lockExpired(now time.Time) bool {
+	return wm.Locked && wm.LockedUntil != nil && !now.Before(*wm.LockedUntil)
+}
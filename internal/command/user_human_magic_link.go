@@ -0,0 +1,152 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// RequestHumanMagicLink creates a new single-use sign-in link for the user, which is bound to the auth request
+// and user agent it was requested from. The link is sent to the verified email of the user by the notification handler.
+// The number and frequency of requested links are limited.
+func (c *Commands) RequestHumanMagicLink(ctx context.Context, userID, resourceOwner string, authRequest *domain.AuthRequest) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Wq3nf", "Errors.User.UserIDMissing")
+	}
+	if authRequest == nil || authRequest.ID == "" || authRequest.AgentID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-b7Kds", "Errors.User.MagicLink.AuthRequestMissing")
+	}
+	if err = c.checkMagicLinkAllowed(ctx, resourceOwner); err != nil {
+		return nil, err
+	}
+
+	human, err := c.getHumanWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if human.UserState == domain.UserStateUnspecified || human.UserState == domain.UserStateDeleted {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-p2Nfe", "Errors.User.NotFound")
+	}
+	if human.UserState != domain.UserStateActive {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-7Gs0d", "Errors.User.MagicLink.UserNotActive")
+	}
+	if !human.IsEmailVerified {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ld92a", "Errors.User.MagicLink.EmailNotVerified")
+	}
+	existingLink, err := c.magicLinkWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if err = existingLink.checkRequestAllowed(authRequest.ID, c.magicLinkLimits); err != nil {
+		return nil, err
+	}
+
+	linkID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	cryptoCode, _, err := crypto.NewCode(c.magicLinkGenerator)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanMagicLinkRequestedEvent(
+		ctx,
+		UserAggregateFromWriteModel(&human.WriteModel),
+		linkID,
+		cryptoCode,
+		c.magicLinkGenerator.Expiry(),
+		authRequestDomainToAuthRequestInfo(authRequest),
+	))
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func (c *Commands) HumanMagicLinkSent(ctx context.Context, userID, resourceOwner, linkID string) error {
+	if userID == "" || linkID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Jd8sm", "Errors.IDMissing")
+	}
+	existingLink, err := c.magicLinkWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if existingLink.LinkID != linkID || existingLink.State != domain.MagicLinkStateRequested {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Hs0vq", "Errors.User.MagicLink.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx,
+		user.NewHumanMagicLinkSentEvent(ctx, UserAggregateFromWriteModel(&existingLink.WriteModel), linkID),
+	)
+	return err
+}
+
+// HumanCheckMagicLink verifies the code of the magic link. The link is only valid for the auth request and user agent
+// it was requested from and gets invalid after it was used or too many failed attempts in the auth request.
+func (c *Commands) HumanCheckMagicLink(ctx context.Context, userID, resourceOwner, linkID, code string, authRequest *domain.AuthRequest) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || linkID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-R7bsx", "Errors.IDMissing")
+	}
+	if authRequest == nil {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-z0Gde", "Errors.User.MagicLink.AuthRequestMissing")
+	}
+	if err = c.checkMagicLinkAllowed(ctx, resourceOwner); err != nil {
+		return err
+	}
+
+	existingLink, err := c.magicLinkWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if existingLink.State == domain.MagicLinkStateUnspecified || existingLink.LinkID != linkID {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Ue2lc", "Errors.User.MagicLink.NotFound")
+	}
+	if existingLink.State != domain.MagicLinkStateActive || existingLink.attemptsExceeded(authRequest.ID, c.magicLinkLimits) {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Pw8ne", "Errors.User.MagicLink.Invalid")
+	}
+
+	userAgg := UserAggregateFromWriteModel(&existingLink.WriteModel)
+	info := authRequestDomainToAuthRequestInfo(authRequest)
+	err = existingLink.verify(code, authRequest, c.magicLinkGenerator)
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewHumanMagicLinkCheckSucceededEvent(ctx, userAgg, linkID, info))
+		return err
+	}
+	_, pushErr := c.eventstore.Push(ctx, user.NewHumanMagicLinkCheckFailedEvent(ctx, userAgg, linkID, info))
+	logging.WithFields("userID", userAgg.ID).OnError(pushErr).Error("NewHumanMagicLinkCheckFailedEvent push failed")
+	return caos_errs.ThrowInvalidArgument(err, "COMMAND-4Kfsl", "Errors.User.MagicLink.Invalid")
+}
+
+func (c *Commands) checkMagicLinkAllowed(ctx context.Context, resourceOwner string) error {
+	loginPolicy, err := c.getOrgLoginPolicy(ctx, resourceOwner)
+	if err != nil {
+		return caos_errs.ThrowPreconditionFailed(err, "COMMAND-Fz9xe", "Errors.Org.LoginPolicy.NotFound")
+	}
+	if !loginPolicy.AllowMagicLink {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-N3kdw", "Errors.User.MagicLink.NotAllowed")
+	}
+	return nil
+}
+
+func (c *Commands) magicLinkWriteModel(ctx context.Context, userID, resourceOwner string) (writeModel *HumanMagicLinkWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanMagicLinkWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// HumanMagicLinkWriteModel reduces the latest magic link of a user,
+// requesting a new link supersedes any previous one
+type HumanMagicLinkWriteModel struct {
+	eventstore.WriteModel
+
+	humanCodeChallenge
+
+	LinkID string
+	State  domain.MagicLinkState
+}
+
+func NewHumanMagicLinkWriteModel(userID, resourceOwner string) *HumanMagicLinkWriteModel {
+	return &HumanMagicLinkWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanMagicLinkWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanMagicLinkRequestedEvent:
+			wm.reduceRequestedEvent(e)
+		case *user.HumanMagicLinkSentEvent:
+			if wm.LinkID == e.LinkID && wm.State == domain.MagicLinkStateRequested {
+				wm.State = domain.MagicLinkStateActive
+			}
+		case *user.HumanMagicLinkCheckFailedEvent:
+			wm.reduceCheckFailed(e.AuthRequestInfo)
+		case *user.HumanMagicLinkCheckSucceededEvent:
+			if wm.LinkID == e.LinkID {
+				wm.State = domain.MagicLinkStateRemoved
+			}
+		case *user.UserLockedEvent,
+			*user.UserDeactivatedEvent,
+			*user.UserRemovedEvent:
+			wm.State = domain.MagicLinkStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanMagicLinkWriteModel) reduceRequestedEvent(e *user.HumanMagicLinkRequestedEvent) {
+	wm.LinkID = e.LinkID
+	wm.reduceRequested(e.Code, e.CreationDate(), e.Expiry, e.AuthRequestInfo)
+	wm.State = domain.MagicLinkStateRequested
+}
+
+func (wm *HumanMagicLinkWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanMagicLinkRequestedType,
+			user.HumanMagicLinkSentType,
+			user.HumanMagicLinkCheckFailedType,
+			user.HumanMagicLinkCheckSucceededType,
+			user.UserLockedType,
+			user.UserDeactivatedType,
+			user.UserRemovedType).
+		Builder()
+}
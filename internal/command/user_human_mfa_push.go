@@ -0,0 +1,327 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// AddHumanPushDevice registers an installation of the mobile app of the user,
+// which gets a push notification to approve the sign-ins of the user.
+// The token of the device is stored encrypted with the user encryption key.
+// The device isn't active until it signed the returned verification code with the private key of its public key (see VerifyHumanPushDevice).
+func (c *Commands) AddHumanPushDevice(ctx context.Context, userID, resourceOwner string, device *domain.PushDevice) (_ *domain.PushDevice, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pu8da", "Errors.User.UserIDMissing")
+	}
+	if device == nil || !device.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pu8db", "Errors.User.MFA.Push.DeviceInvalid")
+	}
+	human, err := c.getHumanWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if human.UserState == domain.UserStateUnspecified || human.UserState == domain.UserStateDeleted {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Pu8dc", "Errors.User.NotFound")
+	}
+	deviceID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	token, err := crypto.Encrypt([]byte(device.Token), c.userEncryption)
+	if err != nil {
+		return nil, err
+	}
+	code, plainCode, err := crypto.NewCode(c.pushDeviceCodeGenerator)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanPushDeviceAddedEvent(
+		ctx,
+		UserAggregateFromWriteModel(&human.WriteModel),
+		deviceID,
+		device.Name,
+		device.Platform,
+		token,
+		device.PublicKey,
+		code,
+		c.pushDeviceCodeGenerator.Expiry(),
+	))
+	if err != nil {
+		return nil, err
+	}
+	details := pushedEventsToObjectDetails(pushedEvents)
+	return &domain.PushDevice{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   userID,
+			ResourceOwner: details.ResourceOwner,
+			Sequence:      details.Sequence,
+			ChangeDate:    details.EventDate,
+		},
+		DeviceID:         deviceID,
+		Name:             device.Name,
+		Platform:         device.Platform,
+		PublicKey:        device.PublicKey,
+		VerificationCode: plainCode,
+		State:            domain.PushDeviceStateNotReady,
+	}, nil
+}
+
+// VerifyHumanPushDevice activates a device, if the signature of the verification code matches its public key
+func (c *Commands) VerifyHumanPushDevice(ctx context.Context, userID, resourceOwner, deviceID string, signature []byte) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || deviceID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pu8va", "Errors.IDMissing")
+	}
+	existingDevices, err := c.pushDevicesWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	device := existingDevices.Device(deviceID)
+	if device == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Pu8vb", "Errors.User.MFA.Push.DeviceNotFound")
+	}
+	if device.State != domain.PushDeviceStateNotReady {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Pu8vc", "Errors.User.MFA.Push.DeviceAlreadyVerified")
+	}
+	if crypto.IsCodeExpired(device.CodeCreationDate, device.CodeExpiry) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Pu8vd", "Errors.User.MFA.Push.VerificationExpired")
+	}
+	code, err := crypto.DecryptString(device.Code, c.userEncryption)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.VerifyPushSignature(device.PublicKey, domain.PushDeviceVerificationPayload(deviceID, code), signature) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pu8ve", "Errors.User.MFA.Push.SignatureInvalid")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanPushDeviceVerifiedEvent(
+		ctx,
+		UserAggregateFromWriteModel(&existingDevices.WriteModel),
+		deviceID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingDevices, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingDevices.WriteModel), nil
+}
+
+func (c *Commands) RemoveHumanPushDevice(ctx context.Context, userID, deviceID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || deviceID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pu8ra", "Errors.IDMissing")
+	}
+	existingDevices, err := c.pushDevicesWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingDevices.Device(deviceID) == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Pu8rb", "Errors.User.MFA.Push.DeviceNotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanPushDeviceRemovedEvent(
+		ctx,
+		UserAggregateFromWriteModel(&existingDevices.WriteModel),
+		deviceID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingDevices, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingDevices.WriteModel), nil
+}
+
+// RequestHumanPushChallenge asks the user to approve the sign-in of the auth request on the registered devices.
+// The notification is sent by the notification handler, the returned id of the challenge is used to check its state.
+func (c *Commands) RequestHumanPushChallenge(ctx context.Context, userID, resourceOwner string, authRequest *domain.AuthRequest) (_ string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pu8ca", "Errors.User.UserIDMissing")
+	}
+	if authRequest == nil || authRequest.ID == "" || authRequest.AgentID == "" {
+		return "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pu8cb", "Errors.User.MFA.Push.AuthRequestMissing")
+	}
+	human, err := c.getHumanWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return "", err
+	}
+	if human.UserState == domain.UserStateUnspecified || human.UserState == domain.UserStateDeleted {
+		return "", caos_errs.ThrowNotFound(nil, "COMMAND-Pu8cc", "Errors.User.NotFound")
+	}
+	if human.UserState != domain.UserStateActive {
+		return "", caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Pu8cd", "Errors.User.NotActive")
+	}
+	existingDevices, err := c.pushDevicesWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return "", err
+	}
+	devices := existingDevices.ActiveDevices()
+	if len(devices) == 0 {
+		return "", caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Pu8ce", "Errors.User.MFA.Push.NoDevice")
+	}
+	targets := make([]*user.PushTarget, len(devices))
+	for i, device := range devices {
+		targets[i] = &user.PushTarget{
+			DeviceID: device.DeviceID,
+			Platform: device.Platform,
+			Token:    device.Token,
+		}
+	}
+	challengeID, err := c.idGenerator.Next()
+	if err != nil {
+		return "", err
+	}
+	_, err = c.eventstore.Push(ctx, user.NewHumanPushChallengeRequestedEvent(
+		ctx,
+		UserAggregateFromWriteModel(&existingDevices.WriteModel),
+		challengeID,
+		c.pushChallengeExpiry,
+		targets,
+		authRequestDomainToAuthRequestInfo(authRequest),
+	))
+	if err != nil {
+		return "", err
+	}
+	return challengeID, nil
+}
+
+func (c *Commands) HumanPushChallengeSent(ctx context.Context, userID, resourceOwner, challengeID string) error {
+	if userID == "" || challengeID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pu8sa", "Errors.IDMissing")
+	}
+	existingChallenge, err := c.pushChallengeWriteModel(ctx, userID, resourceOwner, challengeID)
+	if err != nil {
+		return err
+	}
+	if existingChallenge.State == domain.PushChallengeStateUnspecified {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Pu8sb", "Errors.User.MFA.Push.ChallengeNotFound")
+	}
+	_, err = c.eventstore.Push(ctx,
+		user.NewHumanPushChallengeSentEvent(ctx, UserAggregateFromWriteModel(&existingChallenge.WriteModel), challengeID),
+	)
+	return err
+}
+
+// AnswerHumanPushChallenge approves or denies the sign-in of a challenge, it's called from the app of the user.
+// The answer has to be signed by one of the active devices the challenge was sent to.
+// An approval verifies the second factor of the user session the challenge was requested from.
+func (c *Commands) AnswerHumanPushChallenge(ctx context.Context, userID, resourceOwner, challengeID, deviceID string, approve bool, signature []byte) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || challengeID == "" || deviceID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pu8aa", "Errors.IDMissing")
+	}
+	existingChallenge, err := c.pushChallengeWriteModel(ctx, userID, resourceOwner, challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if existingChallenge.State == domain.PushChallengeStateUnspecified {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Pu8ab", "Errors.User.MFA.Push.ChallengeNotFound")
+	}
+	if existingChallenge.State != domain.PushChallengeStateRequested {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Pu8ac", "Errors.User.MFA.Push.ChallengeAnswered")
+	}
+	if existingChallenge.Expired() {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Pu8ad", "Errors.User.MFA.Push.ChallengeExpired")
+	}
+	if !existingChallenge.IsTarget(deviceID) {
+		return nil, caos_errs.ThrowPermissionDenied(nil, "COMMAND-Pu8ae", "Errors.User.MFA.Push.DeviceNotTarget")
+	}
+	existingDevices, err := c.pushDevicesWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	device := existingDevices.Device(deviceID)
+	if device == nil || device.State != domain.PushDeviceStateActive {
+		return nil, caos_errs.ThrowPermissionDenied(nil, "COMMAND-Pu8af", "Errors.User.MFA.Push.DeviceNotTarget")
+	}
+	if !domain.VerifyPushSignature(device.PublicKey, domain.PushChallengeAnswerPayload(challengeID, deviceID, approve), signature) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pu8ag", "Errors.User.MFA.Push.SignatureInvalid")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingChallenge.WriteModel)
+	var event eventstore.Command = user.NewHumanPushCheckFailedEvent(ctx, userAgg, challengeID, deviceID, existingChallenge.AuthRequestInfo)
+	if approve {
+		event = user.NewHumanPushCheckSucceededEvent(ctx, userAgg, challengeID, deviceID, existingChallenge.AuthRequestInfo)
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingChallenge, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingChallenge.WriteModel), nil
+}
+
+// HumanPushChallengeState returns whether the user answered the challenge requested for the auth request
+func (c *Commands) HumanPushChallengeState(ctx context.Context, userID, resourceOwner, challengeID string, authRequest *domain.AuthRequest) (_ domain.PushChallengeState, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || challengeID == "" {
+		return domain.PushChallengeStateUnspecified, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pu8qa", "Errors.IDMissing")
+	}
+	if authRequest == nil {
+		return domain.PushChallengeStateUnspecified, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pu8qb", "Errors.User.MFA.Push.AuthRequestMissing")
+	}
+	existingChallenge, err := c.pushChallengeWriteModel(ctx, userID, resourceOwner, challengeID)
+	if err != nil {
+		return domain.PushChallengeStateUnspecified, err
+	}
+	if existingChallenge.State == domain.PushChallengeStateUnspecified ||
+		existingChallenge.AuthRequestInfo == nil ||
+		existingChallenge.AuthRequestInfo.ID != authRequest.ID ||
+		existingChallenge.AuthRequestInfo.UserAgentID != authRequest.AgentID {
+		return domain.PushChallengeStateUnspecified, caos_errs.ThrowNotFound(nil, "COMMAND-Pu8qc", "Errors.User.MFA.Push.ChallengeNotFound")
+	}
+	if existingChallenge.State == domain.PushChallengeStateRequested && existingChallenge.Expired() {
+		return domain.PushChallengeStateUnspecified, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Pu8qd", "Errors.User.MFA.Push.ChallengeExpired")
+	}
+	return existingChallenge.State, nil
+}
+
+func (c *Commands) pushDevicesWriteModel(ctx context.Context, userID, resourceOwner string) (writeModel *HumanPushDevicesWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanPushDevicesWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) pushChallengeWriteModel(ctx context.Context, userID, resourceOwner, challengeID string) (writeModel *HumanPushChallengeWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanPushChallengeWriteModel(userID, resourceOwner, challengeID)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
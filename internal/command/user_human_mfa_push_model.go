@@ -0,0 +1,207 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanPushDevice struct {
+	DeviceID         string
+	Name             string
+	Platform         domain.PushPlatform
+	Token            *crypto.CryptoValue
+	PublicKey        []byte
+	Code             *crypto.CryptoValue
+	CodeCreationDate time.Time
+	CodeExpiry       time.Duration
+	State            domain.PushDeviceState
+}
+
+// HumanPushDevicesWriteModel reduces the devices the user registered to approve sign-ins
+type HumanPushDevicesWriteModel struct {
+	eventstore.WriteModel
+
+	Devices []*HumanPushDevice
+}
+
+func NewHumanPushDevicesWriteModel(userID, resourceOwner string) *HumanPushDevicesWriteModel {
+	return &HumanPushDevicesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanPushDevicesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanPushDeviceAddedEvent:
+			wm.Devices = append(wm.Devices, &HumanPushDevice{
+				DeviceID:         e.DeviceID,
+				Name:             e.Name,
+				Platform:         e.Platform,
+				Token:            e.Token,
+				PublicKey:        e.PublicKey,
+				Code:             e.Code,
+				CodeCreationDate: e.CreationDate(),
+				CodeExpiry:       e.Expiry,
+				State:            domain.PushDeviceStateNotReady,
+			})
+		case *user.HumanPushDeviceVerifiedEvent:
+			if device := wm.Device(e.DeviceID); device != nil {
+				device.Code = nil
+				device.State = domain.PushDeviceStateActive
+			}
+		case *user.HumanPushDeviceRemovedEvent:
+			wm.removeDevice(e.DeviceID)
+		case *user.UserRemovedEvent:
+			wm.Devices = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanPushDevicesWriteModel) removeDevice(deviceID string) {
+	for i, device := range wm.Devices {
+		if device.DeviceID == deviceID {
+			wm.Devices = append(wm.Devices[:i], wm.Devices[i+1:]...)
+			return
+		}
+	}
+}
+
+func (wm *HumanPushDevicesWriteModel) Device(deviceID string) *HumanPushDevice {
+	for _, device := range wm.Devices {
+		if device.DeviceID == deviceID {
+			return device
+		}
+	}
+	return nil
+}
+
+// ActiveDevices returns the verified devices, which are able to answer challenges
+func (wm *HumanPushDevicesWriteModel) ActiveDevices() []*HumanPushDevice {
+	devices := make([]*HumanPushDevice, 0, len(wm.Devices))
+	for _, device := range wm.Devices {
+		if device.State == domain.PushDeviceStateActive {
+			devices = append(devices, device)
+		}
+	}
+	return devices
+}
+
+func (wm *HumanPushDevicesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanPushDeviceAddedType,
+			user.HumanPushDeviceVerifiedType,
+			user.HumanPushDeviceRemovedType,
+			user.UserRemovedType).
+		Builder()
+}
+
+// HumanPushChallengeWriteModel reduces a single request to approve a sign-in
+type HumanPushChallengeWriteModel struct {
+	eventstore.WriteModel
+
+	ChallengeID     string
+	RequestDate     time.Time
+	Expiry          time.Duration
+	AuthRequestInfo *user.AuthRequestInfo
+	// DeviceIDs are the devices the challenge was sent to, only they can answer it
+	DeviceIDs []string
+	State     domain.PushChallengeState
+}
+
+func NewHumanPushChallengeWriteModel(userID, resourceOwner, challengeID string) *HumanPushChallengeWriteModel {
+	return &HumanPushChallengeWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		ChallengeID: challengeID,
+	}
+}
+
+func (wm *HumanPushChallengeWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *user.HumanPushChallengeRequestedEvent:
+			if e.ChallengeID != wm.ChallengeID {
+				continue
+			}
+		case *user.HumanPushCheckSucceededEvent:
+			if e.ChallengeID != wm.ChallengeID {
+				continue
+			}
+		case *user.HumanPushCheckFailedEvent:
+			if e.ChallengeID != wm.ChallengeID {
+				continue
+			}
+		}
+		wm.WriteModel.AppendEvents(event)
+	}
+}
+
+func (wm *HumanPushChallengeWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanPushChallengeRequestedEvent:
+			wm.RequestDate = e.CreationDate()
+			wm.Expiry = e.Expiry
+			wm.AuthRequestInfo = e.AuthRequestInfo
+			wm.DeviceIDs = make([]string, len(e.Targets))
+			for i, target := range e.Targets {
+				wm.DeviceIDs[i] = target.DeviceID
+			}
+			wm.State = domain.PushChallengeStateRequested
+		case *user.HumanPushCheckSucceededEvent:
+			wm.State = domain.PushChallengeStateApproved
+		case *user.HumanPushCheckFailedEvent:
+			wm.State = domain.PushChallengeStateDenied
+		case *user.UserLockedEvent,
+			*user.UserDeactivatedEvent,
+			*user.UserRemovedEvent:
+			if wm.State == domain.PushChallengeStateRequested {
+				wm.State = domain.PushChallengeStateDenied
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanPushChallengeWriteModel) IsTarget(deviceID string) bool {
+	for _, id := range wm.DeviceIDs {
+		if id == deviceID {
+			return true
+		}
+	}
+	return false
+}
+
+func (wm *HumanPushChallengeWriteModel) Expired() bool {
+	return wm.RequestDate.Add(wm.Expiry).Before(time.Now())
+}
+
+func (wm *HumanPushChallengeWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanPushChallengeRequestedType,
+			user.HumanPushCheckSucceededType,
+			user.HumanPushCheckFailedType,
+			user.UserLockedType,
+			user.UserDeactivatedType,
+			user.UserRemovedType).
+		Builder()
+}
@@ -0,0 +1,1215 @@
+package command
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func pushHumanAddedEvent() *repository.Event {
+	return eventFromEventPusher(
+		user.NewHumanAddedEvent(context.Background(),
+			&user.NewAggregate("user1", "org1").Aggregate,
+			"username",
+			"firstname",
+			"lastname",
+			"nickname",
+			"displayname",
+			language.German,
+			domain.GenderUnspecified,
+			"email@test.ch",
+			true,
+		),
+	)
+}
+
+func pushDeviceToken() *crypto.CryptoValue {
+	return &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("token"),
+	}
+}
+
+var pushDeviceKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+func pushDevicePublicKey() []byte {
+	publicKey, _ := x509.MarshalPKIXPublicKey(&pushDeviceKey.PublicKey)
+	return publicKey
+}
+
+func pushDeviceSign(t *testing.T, payload []byte) []byte {
+	hash := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, pushDeviceKey, hash[:])
+	require.NoError(t, err)
+	return signature
+}
+
+func pushDeviceAddedEvent(deviceID string) *user.HumanPushDeviceAddedEvent {
+	return user.NewHumanPushDeviceAddedEvent(context.Background(),
+		&user.NewAggregate("user1", "org1").Aggregate,
+		deviceID,
+		"phone",
+		domain.PushPlatformFCM,
+		pushDeviceToken(),
+		pushDevicePublicKey(),
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("a"),
+		},
+		time.Hour,
+	)
+}
+
+func pushDeviceVerifiedEvent(deviceID string) *user.HumanPushDeviceVerifiedEvent {
+	return user.NewHumanPushDeviceVerifiedEvent(context.Background(),
+		&user.NewAggregate("user1", "org1").Aggregate,
+		deviceID,
+	)
+}
+
+func pushChallengeRequestedEvent(challengeID string) *user.HumanPushChallengeRequestedEvent {
+	return user.NewHumanPushChallengeRequestedEvent(context.Background(),
+		&user.NewAggregate("user1", "org1").Aggregate,
+		challengeID,
+		time.Minute*2,
+		[]*user.PushTarget{
+			{
+				DeviceID: "device1",
+				Platform: domain.PushPlatformFCM,
+				Token:    pushDeviceToken(),
+			},
+		},
+		&user.AuthRequestInfo{
+			ID:          "request1",
+			UserAgentID: "agent1",
+		},
+	)
+}
+
+func TestCommandSide_AddHumanPushDevice(t *testing.T) {
+	type fields struct {
+		eventstore              *eventstore.Eventstore
+		idGenerator             id.Generator
+		userEncryption          crypto.EncryptionAlgorithm
+		pushDeviceCodeGenerator crypto.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		userID        string
+		resourceOwner string
+		device        *domain.PushDevice
+	}
+	type res struct {
+		want *domain.PushDevice
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				device: &domain.PushDevice{
+					Name:      "phone",
+					Platform:  domain.PushPlatformFCM,
+					Token:     "token",
+					PublicKey: pushDevicePublicKey(),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "device invalid, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				device: &domain.PushDevice{
+					Name:      "phone",
+					Platform:  domain.PushPlatformUnspecified,
+					Token:     "token",
+					PublicKey: pushDevicePublicKey(),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "public key invalid, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				device: &domain.PushDevice{
+					Name:      "phone",
+					Platform:  domain.PushPlatformFCM,
+					Token:     "token",
+					PublicKey: []byte("key"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				device: &domain.PushDevice{
+					Name:      "phone",
+					Platform:  domain.PushPlatformFCM,
+					Token:     "token",
+					PublicKey: pushDevicePublicKey(),
+				},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "add device, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						pushHumanAddedEvent(),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								pushDeviceAddedEvent("device1"),
+							),
+						},
+					),
+				),
+				idGenerator:             id_mock.NewIDGeneratorExpectIDs(t, "device1"),
+				userEncryption:          crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				pushDeviceCodeGenerator: GetMockSecretGenerator(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				device: &domain.PushDevice{
+					Name:      "phone",
+					Platform:  domain.PushPlatformFCM,
+					Token:     "token",
+					PublicKey: pushDevicePublicKey(),
+				},
+			},
+			res: res{
+				want: &domain.PushDevice{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "user1",
+						ResourceOwner: "org1",
+					},
+					DeviceID:         "device1",
+					Name:             "phone",
+					Platform:         domain.PushPlatformFCM,
+					PublicKey:        pushDevicePublicKey(),
+					VerificationCode: "a",
+					State:            domain.PushDeviceStateNotReady,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:              tt.fields.eventstore,
+				idGenerator:             tt.fields.idGenerator,
+				userEncryption:          tt.fields.userEncryption,
+				pushDeviceCodeGenerator: tt.fields.pushDeviceCodeGenerator,
+			}
+			got, err := r.AddHumanPushDevice(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.device)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_VerifyHumanPushDevice(t *testing.T) {
+	type fields struct {
+		eventstore     *eventstore.Eventstore
+		userEncryption crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx           context.Context
+		userID        string
+		resourceOwner string
+		deviceID      string
+		signature     []byte
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "deviceid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				signature:     pushDeviceSign(t, domain.PushDeviceVerificationPayload("device1", "a")),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "device not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				deviceID:      "device1",
+				signature:     pushDeviceSign(t, domain.PushDeviceVerificationPayload("device1", "a")),
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "device already verified, precondition failed error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(pushDeviceAddedEvent("device1")),
+						eventFromEventPusherWithCreationDateNow(pushDeviceVerifiedEvent("device1")),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				deviceID:      "device1",
+				signature:     pushDeviceSign(t, domain.PushDeviceVerificationPayload("device1", "a")),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "code expired, precondition failed error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(pushDeviceAddedEvent("device1")),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				deviceID:      "device1",
+				signature:     pushDeviceSign(t, domain.PushDeviceVerificationPayload("device1", "a")),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "signature of other code, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(pushDeviceAddedEvent("device1")),
+					),
+				),
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				deviceID:      "device1",
+				signature:     pushDeviceSign(t, domain.PushDeviceVerificationPayload("device1", "b")),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "verify device, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(pushDeviceAddedEvent("device1")),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(pushDeviceVerifiedEvent("device1")),
+						},
+					),
+				),
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				deviceID:      "device1",
+				signature:     pushDeviceSign(t, domain.PushDeviceVerificationPayload("device1", "a")),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:     tt.fields.eventstore,
+				userEncryption: tt.fields.userEncryption,
+			}
+			got, err := r.VerifyHumanPushDevice(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.deviceID, tt.args.signature)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveHumanPushDevice(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		userID        string
+		deviceID      string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "deviceid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "device not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							pushDeviceAddedEvent("device1"),
+						),
+						eventFromEventPusher(
+							user.NewHumanPushDeviceRemovedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"device1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				deviceID:      "device1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "remove device, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							pushDeviceAddedEvent("device1"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanPushDeviceRemovedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"device1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				deviceID:      "device1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RemoveHumanPushDevice(tt.args.ctx, tt.args.userID, tt.args.deviceID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RequestHumanPushChallenge(t *testing.T) {
+	type fields struct {
+		eventstore          *eventstore.Eventstore
+		idGenerator         id.Generator
+		pushChallengeExpiry time.Duration
+	}
+	type args struct {
+		ctx           context.Context
+		userID        string
+		resourceOwner string
+		authRequest   *domain.AuthRequest
+	}
+	type res struct {
+		want string
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "auth request missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user locked, precondition failed error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						pushHumanAddedEvent(),
+						eventFromEventPusher(
+							user.NewUserLockedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				authRequest: &domain.AuthRequest{
+					ID:      "request1",
+					AgentID: "agent1",
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "no device registered, precondition failed error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						pushHumanAddedEvent(),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				authRequest: &domain.AuthRequest{
+					ID:      "request1",
+					AgentID: "agent1",
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "device not verified, precondition failed error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						pushHumanAddedEvent(),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							pushDeviceAddedEvent("device1"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				authRequest: &domain.AuthRequest{
+					ID:      "request1",
+					AgentID: "agent1",
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "request challenge, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						pushHumanAddedEvent(),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							pushDeviceAddedEvent("device1"),
+						),
+						eventFromEventPusher(
+							pushDeviceVerifiedEvent("device1"),
+						),
+						eventFromEventPusher(
+							pushDeviceAddedEvent("device2"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								pushChallengeRequestedEvent("challenge1"),
+							),
+						},
+					),
+				),
+				idGenerator:         id_mock.NewIDGeneratorExpectIDs(t, "challenge1"),
+				pushChallengeExpiry: time.Minute * 2,
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				authRequest: &domain.AuthRequest{
+					ID:      "request1",
+					AgentID: "agent1",
+				},
+			},
+			res: res{
+				want: "challenge1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:          tt.fields.eventstore,
+				idGenerator:         tt.fields.idGenerator,
+				pushChallengeExpiry: tt.fields.pushChallengeExpiry,
+			}
+			got, err := r.RequestHumanPushChallenge(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.authRequest)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_AnswerHumanPushChallenge(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		userID        string
+		resourceOwner string
+		challengeID   string
+		deviceID      string
+		approve       bool
+		signature     []byte
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "deviceid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				challengeID:   "challenge1",
+				approve:       true,
+				signature:     pushDeviceSign(t, domain.PushChallengeAnswerPayload("challenge1", "device1", true)),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "challenge not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(
+							pushChallengeRequestedEvent("challenge2"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				challengeID:   "challenge1",
+				deviceID:      "device1",
+				approve:       true,
+				signature:     pushDeviceSign(t, domain.PushChallengeAnswerPayload("challenge1", "device1", true)),
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "challenge expired, precondition failed error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							pushChallengeRequestedEvent("challenge1"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				challengeID:   "challenge1",
+				deviceID:      "device1",
+				approve:       true,
+				signature:     pushDeviceSign(t, domain.PushChallengeAnswerPayload("challenge1", "device1", true)),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "challenge already answered, precondition failed error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(
+							pushChallengeRequestedEvent("challenge1"),
+						),
+						eventFromEventPusherWithCreationDateNow(
+							user.NewHumanPushCheckFailedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"challenge1",
+								"device1",
+								&user.AuthRequestInfo{
+									ID:          "request1",
+									UserAgentID: "agent1",
+								},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				challengeID:   "challenge1",
+				deviceID:      "device1",
+				approve:       true,
+				signature:     pushDeviceSign(t, domain.PushChallengeAnswerPayload("challenge1", "device1", true)),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "user locked after request, precondition failed error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(
+							pushChallengeRequestedEvent("challenge1"),
+						),
+						eventFromEventPusherWithCreationDateNow(
+							user.NewUserLockedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				challengeID:   "challenge1",
+				deviceID:      "device1",
+				approve:       true,
+				signature:     pushDeviceSign(t, domain.PushChallengeAnswerPayload("challenge1", "device1", true)),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "device not target of challenge, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(
+							pushChallengeRequestedEvent("challenge1"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				challengeID:   "challenge1",
+				deviceID:      "device2",
+				approve:       true,
+				signature:     pushDeviceSign(t, domain.PushChallengeAnswerPayload("challenge1", "device2", true)),
+			},
+			res: res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			name: "device removed after request, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(
+							pushChallengeRequestedEvent("challenge1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(pushDeviceAddedEvent("device1")),
+						eventFromEventPusher(pushDeviceVerifiedEvent("device1")),
+						eventFromEventPusher(
+							user.NewHumanPushDeviceRemovedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"device1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				challengeID:   "challenge1",
+				deviceID:      "device1",
+				approve:       true,
+				signature:     pushDeviceSign(t, domain.PushChallengeAnswerPayload("challenge1", "device1", true)),
+			},
+			res: res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			name: "signature of other answer, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(
+							pushChallengeRequestedEvent("challenge1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(pushDeviceAddedEvent("device1")),
+						eventFromEventPusher(pushDeviceVerifiedEvent("device1")),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				challengeID:   "challenge1",
+				deviceID:      "device1",
+				approve:       true,
+				signature:     pushDeviceSign(t, domain.PushChallengeAnswerPayload("challenge1", "device1", false)),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "approve challenge, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(
+							pushChallengeRequestedEvent("challenge1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(pushDeviceAddedEvent("device1")),
+						eventFromEventPusher(pushDeviceVerifiedEvent("device1")),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanPushCheckSucceededEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"challenge1",
+									"device1",
+									&user.AuthRequestInfo{
+										ID:          "request1",
+										UserAgentID: "agent1",
+									},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				challengeID:   "challenge1",
+				deviceID:      "device1",
+				approve:       true,
+				signature:     pushDeviceSign(t, domain.PushChallengeAnswerPayload("challenge1", "device1", true)),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "deny challenge, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(
+							pushChallengeRequestedEvent("challenge1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(pushDeviceAddedEvent("device1")),
+						eventFromEventPusher(pushDeviceVerifiedEvent("device1")),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanPushCheckFailedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"challenge1",
+									"device1",
+									&user.AuthRequestInfo{
+										ID:          "request1",
+										UserAgentID: "agent1",
+									},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				challengeID:   "challenge1",
+				deviceID:      "device1",
+				approve:       false,
+				signature:     pushDeviceSign(t, domain.PushChallengeAnswerPayload("challenge1", "device1", false)),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.AnswerHumanPushChallenge(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.challengeID, tt.args.deviceID, tt.args.approve, tt.args.signature)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_HumanPushChallengeState(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		userID        string
+		resourceOwner string
+		challengeID   string
+		authRequest   *domain.AuthRequest
+	}
+	type res struct {
+		want domain.PushChallengeState
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "other auth request, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(
+							pushChallengeRequestedEvent("challenge1"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				challengeID:   "challenge1",
+				authRequest: &domain.AuthRequest{
+					ID:      "request2",
+					AgentID: "agent1",
+				},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "not answered, requested",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(
+							pushChallengeRequestedEvent("challenge1"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				challengeID:   "challenge1",
+				authRequest: &domain.AuthRequest{
+					ID:      "request1",
+					AgentID: "agent1",
+				},
+			},
+			res: res{
+				want: domain.PushChallengeStateRequested,
+			},
+		},
+		{
+			name: "approved, approved",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							pushChallengeRequestedEvent("challenge1"),
+						),
+						eventFromEventPusher(
+							user.NewHumanPushCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"challenge1",
+								"device1",
+								&user.AuthRequestInfo{
+									ID:          "request1",
+									UserAgentID: "agent1",
+								},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				challengeID:   "challenge1",
+				authRequest: &domain.AuthRequest{
+					ID:      "request1",
+					AgentID: "agent1",
+				},
+			},
+			res: res{
+				want: domain.PushChallengeStateApproved,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.HumanPushChallengeState(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.challengeID, tt.args.authRequest)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
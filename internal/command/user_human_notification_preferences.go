@@ -0,0 +1,60 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// SetHumanNotificationPreferences sets which security notifications the user wants to receive
+func (c *Commands) SetHumanNotificationPreferences(ctx context.Context, userID, resourceOwner string, preferences *domain.NotificationPreferences) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Np3fs", "Errors.User.UserIDMissing")
+	}
+	if preferences == nil {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Np9wq", "Errors.User.NotificationPreferences.Invalid")
+	}
+	existing, err := c.notificationPreferencesWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existing.UserState == domain.UserStateUnspecified || existing.UserState == domain.UserStateDeleted {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Np2la", "Errors.User.NotFound")
+	}
+	if existing.NotificationPreferences == *preferences {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Np8ct", "Errors.User.NotificationPreferences.NotChanged")
+	}
+	userAgg := UserAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanNotificationPreferencesSetEvent(
+		ctx,
+		userAgg,
+		preferences.NewDeviceLogin,
+		preferences.MFAAdded,
+		preferences.MFARemoved,
+		preferences.EmailChange,
+		preferences.AccountLocked,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) notificationPreferencesWriteModel(ctx context.Context, userID, resourceOwner string) (writeModel *HumanNotificationPreferencesWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanNotificationPreferencesWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
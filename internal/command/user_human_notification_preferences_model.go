@@ -0,0 +1,57 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanNotificationPreferencesWriteModel struct {
+	eventstore.WriteModel
+
+	domain.NotificationPreferences
+	UserState domain.UserState
+}
+
+func NewHumanNotificationPreferencesWriteModel(userID, resourceOwner string) *HumanNotificationPreferencesWriteModel {
+	return &HumanNotificationPreferencesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		NotificationPreferences: *domain.DefaultNotificationPreferences(),
+	}
+}
+
+func (wm *HumanNotificationPreferencesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent, *user.HumanRegisteredEvent:
+			wm.UserState = domain.UserStateActive
+		case *user.HumanNotificationPreferencesSetEvent:
+			wm.NewDeviceLogin = e.NewDeviceLogin
+			wm.MFAAdded = e.MFAAdded
+			wm.MFARemoved = e.MFARemoved
+			wm.EmailChange = e.EmailChange
+			wm.AccountLocked = e.AccountLocked
+		case *user.UserRemovedEvent:
+			wm.UserState = domain.UserStateDeleted
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanNotificationPreferencesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.UserV1AddedType,
+			user.UserV1RegisteredType,
+			user.HumanAddedType,
+			user.HumanRegisteredType,
+			user.HumanNotificationPreferencesSetType,
+			user.UserRemovedType).
+		Builder()
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -120,10 +121,15 @@ func (c *Commands) HumanCheckMFAOTPSetup(ctx context.Context, userID, code, user
 	return writeModelToObjectDetails(&existingOTP.WriteModel), nil
 }
 
-func (c *Commands) HumanCheckMFAOTP(ctx context.Context, userID, code, resourceowner string, authRequest *domain.AuthRequest) error {
+// HumanCheckMFAOTP verifies the code of the authenticator app,
+// failed checks count towards the max otp attempts of the lockout policy
+func (c *Commands) HumanCheckMFAOTP(ctx context.Context, userID, code, resourceowner string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
 	if userID == "" {
 		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-8N9ds", "Errors.User.UserIDMissing")
 	}
+	if err := c.checkLoginThrottle(ctx, authRequest); err != nil {
+		return err
+	}
 	existingOTP, err := c.otpWriteModelByID(ctx, userID, resourceowner)
 	if err != nil {
 		return err
@@ -132,12 +138,23 @@ func (c *Commands) HumanCheckMFAOTP(ctx context.Context, userID, code, resourceo
 		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-3Mif9s", "Errors.User.MFA.OTP.NotReady")
 	}
 	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	info := authRequestDomainToAuthRequestInfo(authRequest)
 	err = domain.VerifyMFAOTP(code, existingOTP.Secret, c.multifactors.OTP.CryptoMFA)
 	if err == nil {
-		_, err = c.eventstore.Push(ctx, user.NewHumanOTPCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		c.loginCheckSucceeded(ctx, authRequest)
+		_, err = c.eventstore.Push(ctx, user.NewHumanOTPCheckSucceededEvent(ctx, userAgg, info))
 		return err
 	}
-	_, pushErr := c.eventstore.Push(ctx, user.NewHumanOTPCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	c.loginCheckFailed(ctx, authRequest)
+	events := []eventstore.Command{
+		user.NewHumanOTPCheckFailedEvent(ctx, userAgg, info),
+	}
+	lockEvent, lockErr := c.otpCheckFailedLockEvent(ctx, userAgg, lockoutPolicy, info)
+	logging.WithFields("userID", userAgg.ID).OnError(lockErr).Error("unable to check otp lockout")
+	if lockEvent != nil {
+		events = append(events, lockEvent)
+	}
+	_, pushErr := c.eventstore.Push(ctx, events...)
 	logging.Log("COMMAND-9fj7s").OnError(pushErr).Error("error create password check failed event")
 	return err
 }
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/logging"
 
@@ -223,6 +224,9 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 	if password == "" {
 		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-3n8fs", "Errors.User.Password.Empty")
 	}
+	if err = c.checkLoginThrottle(ctx, authRequest); err != nil {
+		return err
+	}
 
 	loginPolicy, err := c.getOrgLoginPolicy(ctx, orgID)
 	if err != nil {
@@ -248,21 +252,31 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 	ctx, spanPasswordComparison := tracing.NewNamedSpan(ctx, "crypto.CompareHash")
 	err = crypto.CompareHash(existingPassword.Secret, []byte(password), c.userPasswordAlg)
 	spanPasswordComparison.EndWithError(err)
+	info := authRequestDomainToAuthRequestInfo(authRequest)
 	if err == nil {
-		_, err = c.eventstore.Push(ctx, user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		c.loginCheckSucceeded(ctx, authRequest)
+		_, err = c.eventstore.Push(ctx, user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, info))
 		return err
 	}
+	c.loginCheckFailed(ctx, authRequest)
+	c.humanPasswordCheckFailed(ctx, existingPassword, lockoutPolicy, info)
+	return caos_errs.ThrowInvalidArgument(nil, "COMMAND-452ad", "Errors.User.Password.Invalid")
+}
+
+// humanPasswordCheckFailed pushes the failed password check
+// and locks the user if the maximum attempts of the lockout policy are reached.
+// Errors are only logged, as the check already failed.
+func (c *Commands) humanPasswordCheckFailed(ctx context.Context, existingPassword *HumanPasswordWriteModel, lockoutPolicy *domain.LockoutPolicy, info *user.AuthRequestInfo) {
+	userAgg := UserAggregateFromWriteModel(&existingPassword.WriteModel)
 	events := make([]eventstore.Command, 0)
-	events = append(events, user.NewHumanPasswordCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	events = append(events, user.NewHumanPasswordCheckFailedEvent(ctx, userAgg, info))
 	if lockoutPolicy != nil && lockoutPolicy.MaxPasswordAttempts > 0 {
 		if existingPassword.PasswordCheckFailedCount+1 >= lockoutPolicy.MaxPasswordAttempts {
-			events = append(events, user.NewUserLockedEvent(ctx, userAgg))
+			events = append(events, user.NewUserLockedByPolicyEvent(ctx, userAgg, domain.UserLockReasonPasswordAttempts, existingPassword.PasswordCheckFailedCount+1, lockoutPolicy.LockedUntil(time.Now()), info))
 		}
-
 	}
-	_, err = c.eventstore.Push(ctx, events...)
+	_, err := c.eventstore.Push(ctx, events...)
 	logging.Log("COMMAND-9fj7s").OnError(err).Error("error create password check failed event")
-	return caos_errs.ThrowInvalidArgument(nil, "COMMAND-452ad", "Errors.User.Password.Invalid")
 }
 
 func (c *Commands) passwordWriteModel(ctx context.Context, userID, resourceOwner string) (writeModel *HumanPasswordWriteModel, err error) {
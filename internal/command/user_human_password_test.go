@@ -1160,7 +1160,10 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -1200,7 +1203,10 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -1241,7 +1247,10 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -1298,7 +1307,10 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -1389,7 +1401,10 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -1443,8 +1458,15 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 								),
 							),
 							eventFromEventPusher(
-								user.NewUserLockedEvent(context.Background(),
+								user.NewUserLockedByPolicyEvent(context.Background(),
 									&user.NewAggregate("user1", "org1").Aggregate,
+									domain.UserLockReasonPasswordAttempts,
+									1,
+									nil,
+									&user.AuthRequestInfo{
+										ID:          "request1",
+										UserAgentID: "agent1",
+									},
 								),
 							),
 						},
@@ -1487,7 +1509,10 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
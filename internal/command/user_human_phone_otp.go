@@ -0,0 +1,169 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// RequestHumanPhoneOTP creates a new sign-in code for the user, which is bound to the auth request
+// and user agent it was requested from. The code is sent to the verified phone of the user by the notification handler.
+// The provided phone must match the verified phone of the user. The number and frequency of requested codes are limited.
+func (c *Commands) RequestHumanPhoneOTP(ctx context.Context, userID, resourceOwner string, phone domain.PhoneNumber, authRequest *domain.AuthRequest) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Tb4ns", "Errors.User.UserIDMissing")
+	}
+	if authRequest == nil || authRequest.ID == "" || authRequest.AgentID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-cS8wq", "Errors.User.PhoneOTP.AuthRequestMissing")
+	}
+	phone, err = phone.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	if err = c.checkPhoneOTPAllowed(ctx, resourceOwner); err != nil {
+		return nil, err
+	}
+
+	human, err := c.getHumanWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if human.UserState == domain.UserStateUnspecified || human.UserState == domain.UserStateDeleted {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ve1ko", "Errors.User.NotFound")
+	}
+	if human.UserState != domain.UserStateActive {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-k3Lxn", "Errors.User.PhoneOTP.UserNotActive")
+	}
+	if !human.IsPhoneVerified || human.Phone != phone {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Wn6fa", "Errors.User.PhoneOTP.PhoneNotVerified")
+	}
+	existingOTP, err := c.phoneOTPWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if err = existingOTP.checkRequestAllowed(authRequest.ID, c.phoneOTPLimits); err != nil {
+		return nil, err
+	}
+
+	cryptoCode, _, err := crypto.NewCode(c.phoneOTPGenerator)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanPhoneOTPRequestedEvent(
+		ctx,
+		UserAggregateFromWriteModel(&human.WriteModel),
+		cryptoCode,
+		c.phoneOTPGenerator.Expiry(),
+		authRequestDomainToAuthRequestInfo(authRequest),
+	))
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func (c *Commands) HumanPhoneOTPSent(ctx context.Context, userID, resourceOwner string) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pq0vd", "Errors.User.UserIDMissing")
+	}
+	existingOTP, err := c.phoneOTPWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if existingOTP.State != domain.PhoneOTPStateRequested {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Hd7mz", "Errors.User.PhoneOTP.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx,
+		user.NewHumanPhoneOTPSentEvent(ctx, UserAggregateFromWriteModel(&existingOTP.WriteModel)),
+	)
+	return err
+}
+
+// HumanCheckPhoneOTP verifies the sign-in code sent by SMS. The code is only valid for the auth request and user agent
+// it was requested from and gets invalid after it was used or too many failed attempts in the auth request.
+// Failed checks count towards the max otp attempts of the lockout policy.
+func (c *Commands) HumanCheckPhoneOTP(ctx context.Context, userID, resourceOwner, code string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Zm3ka", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Xr5bw", "Errors.User.Code.Empty")
+	}
+	if authRequest == nil {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-g1Fvo", "Errors.User.PhoneOTP.AuthRequestMissing")
+	}
+	if err = c.checkPhoneOTPAllowed(ctx, resourceOwner); err != nil {
+		return err
+	}
+	if err = c.checkLoginThrottle(ctx, authRequest); err != nil {
+		return err
+	}
+
+	existingOTP, err := c.phoneOTPWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if existingOTP.State == domain.PhoneOTPStateUnspecified {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-u8Ncs", "Errors.User.PhoneOTP.NotFound")
+	}
+	if existingOTP.State != domain.PhoneOTPStateActive || existingOTP.attemptsExceeded(authRequest.ID, c.phoneOTPLimits) {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ob2hd", "Errors.User.PhoneOTP.Invalid")
+	}
+
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	info := authRequestDomainToAuthRequestInfo(authRequest)
+	err = existingOTP.verify(code, authRequest, c.phoneOTPGenerator)
+	if err == nil {
+		c.loginCheckSucceeded(ctx, authRequest)
+		_, err = c.eventstore.Push(ctx, user.NewHumanPhoneOTPCheckSucceededEvent(ctx, userAgg, info))
+		return err
+	}
+	c.loginCheckFailed(ctx, authRequest)
+	events := []eventstore.Command{
+		user.NewHumanPhoneOTPCheckFailedEvent(ctx, userAgg, info),
+	}
+	lockEvent, lockErr := c.otpCheckFailedLockEvent(ctx, userAgg, lockoutPolicy, info)
+	logging.WithFields("userID", userAgg.ID).OnError(lockErr).Error("unable to check otp lockout")
+	if lockEvent != nil {
+		events = append(events, lockEvent)
+	}
+	_, pushErr := c.eventstore.Push(ctx, events...)
+	logging.WithFields("userID", userAgg.ID).OnError(pushErr).Error("NewHumanPhoneOTPCheckFailedEvent push failed")
+	return caos_errs.ThrowInvalidArgument(err, "COMMAND-Lw9cv", "Errors.User.PhoneOTP.Invalid")
+}
+
+func (c *Commands) checkPhoneOTPAllowed(ctx context.Context, resourceOwner string) error {
+	loginPolicy, err := c.getOrgLoginPolicy(ctx, resourceOwner)
+	if err != nil {
+		return caos_errs.ThrowPreconditionFailed(err, "COMMAND-Ec4rp", "Errors.Org.LoginPolicy.NotFound")
+	}
+	if !loginPolicy.AllowPhoneOTP {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Js6gu", "Errors.User.PhoneOTP.NotAllowed")
+	}
+	return nil
+}
+
+func (c *Commands) phoneOTPWriteModel(ctx context.Context, userID, resourceOwner string) (writeModel *HumanPhoneOTPWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanPhoneOTPWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// HumanPhoneOTPWriteModel reduces the latest sign-in code sent by SMS,
+// requesting a new code supersedes any previous one
+type HumanPhoneOTPWriteModel struct {
+	eventstore.WriteModel
+
+	humanCodeChallenge
+
+	State domain.PhoneOTPState
+}
+
+func NewHumanPhoneOTPWriteModel(userID, resourceOwner string) *HumanPhoneOTPWriteModel {
+	return &HumanPhoneOTPWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanPhoneOTPWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanPhoneOTPRequestedEvent:
+			wm.reduceRequestedEvent(e)
+		case *user.HumanPhoneOTPSentEvent:
+			if wm.State == domain.PhoneOTPStateRequested {
+				wm.State = domain.PhoneOTPStateActive
+			}
+		case *user.HumanPhoneOTPCheckFailedEvent:
+			wm.reduceCheckFailed(e.AuthRequestInfo)
+		case *user.HumanPhoneOTPCheckSucceededEvent:
+			wm.State = domain.PhoneOTPStateRemoved
+		case *user.HumanPhoneChangedEvent,
+			*user.HumanPhoneRemovedEvent,
+			*user.UserLockedEvent,
+			*user.UserDeactivatedEvent,
+			*user.UserRemovedEvent:
+			wm.State = domain.PhoneOTPStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanPhoneOTPWriteModel) reduceRequestedEvent(e *user.HumanPhoneOTPRequestedEvent) {
+	wm.reduceRequested(e.Code, e.CreationDate(), e.Expiry, e.AuthRequestInfo)
+	wm.State = domain.PhoneOTPStateRequested
+}
+
+func (wm *HumanPhoneOTPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanPhoneOTPRequestedType,
+			user.HumanPhoneOTPSentType,
+			user.HumanPhoneOTPCheckFailedType,
+			user.HumanPhoneOTPCheckSucceededType,
+			user.HumanPhoneChangedType,
+			user.HumanPhoneRemovedType,
+			user.UserLockedType,
+			user.UserDeactivatedType,
+			user.UserRemovedType).
+		Builder()
+}
@@ -16,7 +16,8 @@ func (c *Commands) AddAccessAndRefreshToken(
 	agentID,
 	clientID,
 	userID,
-	refreshToken string,
+	refreshToken,
+	dpopJKT string,
 	audience,
 	scopes,
 	authMethodsReferences []string,
@@ -26,9 +27,9 @@ func (c *Commands) AddAccessAndRefreshToken(
 	authTime time.Time,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
 	if refreshToken == "" {
-		return c.AddNewRefreshTokenAndAccessToken(ctx, userID, orgID, agentID, clientID, audience, scopes, authMethodsReferences, refreshExpiration, accessLifetime, refreshIdleExpiration, authTime)
+		return c.AddNewRefreshTokenAndAccessToken(ctx, userID, orgID, agentID, clientID, dpopJKT, audience, scopes, authMethodsReferences, refreshExpiration, accessLifetime, refreshIdleExpiration, authTime)
 	}
-	return c.RenewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, audience, scopes, refreshIdleExpiration, accessLifetime)
+	return c.RenewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, dpopJKT, audience, scopes, refreshIdleExpiration, accessLifetime)
 }
 
 func (c *Commands) AddNewRefreshTokenAndAccessToken(
@@ -36,7 +37,8 @@ func (c *Commands) AddNewRefreshTokenAndAccessToken(
 	userID,
 	orgID,
 	agentID,
-	clientID string,
+	clientID,
+	dpopJKT string,
 	audience,
 	scopes,
 	authMethodsReferences []string,
@@ -53,7 +55,7 @@ func (c *Commands) AddNewRefreshTokenAndAccessToken(
 	if err != nil {
 		return nil, "", err
 	}
-	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime)
+	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, dpopJKT, audience, scopes, accessLifetime)
 	if err != nil {
 		return nil, "", err
 	}
@@ -74,18 +76,19 @@ func (c *Commands) RenewRefreshTokenAndAccessToken(
 	orgID,
 	refreshToken,
 	agentID,
-	clientID string,
+	clientID,
+	dpopJKT string,
 	audience,
 	scopes []string,
 	idleExpiration,
 	accessLifetime time.Duration,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
-	refreshTokenEvent, refreshTokenID, newRefreshToken, err := c.renewRefreshToken(ctx, userID, orgID, refreshToken, idleExpiration)
+	refreshTokenEvent, refreshTokenID, newRefreshToken, err := c.renewRefreshToken(ctx, userID, orgID, refreshToken, dpopJKT, idleExpiration)
 	if err != nil {
 		return nil, "", err
 	}
 	userWriteModel := NewUserWriteModel(userID, orgID)
-	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime)
+	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, dpopJKT, audience, scopes, accessLifetime)
 	if err != nil {
 		return nil, "", err
 	}
@@ -136,11 +139,11 @@ func (c *Commands) addRefreshToken(ctx context.Context, accessToken *domain.Toke
 	refreshTokenWriteModel := NewHumanRefreshTokenWriteModel(accessToken.AggregateID, accessToken.ResourceOwner, accessToken.RefreshTokenID)
 	userAgg := UserAggregateFromWriteModel(&refreshTokenWriteModel.WriteModel)
 	return user.NewHumanRefreshTokenAddedEvent(ctx, userAgg, accessToken.RefreshTokenID, accessToken.ApplicationID, accessToken.UserAgentID,
-			accessToken.PreferredLanguage, accessToken.Audience, accessToken.Scopes, authMethodsReferences, authTime, idleExpiration, expiration),
+			accessToken.PreferredLanguage, accessToken.DPoPJKT, accessToken.Audience, accessToken.Scopes, authMethodsReferences, authTime, idleExpiration, expiration),
 		refreshToken, nil
 }
 
-func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refreshToken string, idleExpiration time.Duration) (event *user.HumanRefreshTokenRenewedEvent, refreshTokenID, newRefreshToken string, err error) {
+func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refreshToken, dpopJKT string, idleExpiration time.Duration) (event *user.HumanRefreshTokenRenewedEvent, refreshTokenID, newRefreshToken string, err error) {
 	if refreshToken == "" {
 		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-DHrr3", "Errors.IDMissing")
 	}
@@ -165,6 +168,10 @@ func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refresh
 		refreshTokenWriteModel.Expiration.Before(time.Now()) {
 		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vr43e", "Errors.User.RefreshToken.Invalid")
 	}
+	// a refresh token bound to a DPoP key can only be used with a proof of the same key
+	if refreshTokenWriteModel.DPoPJKT != "" && refreshTokenWriteModel.DPoPJKT != dpopJKT {
+		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Dpo2q", "Errors.User.RefreshToken.Invalid")
+	}
 
 	newToken, err := c.idGenerator.Next()
 	if err != nil {
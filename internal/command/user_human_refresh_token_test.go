@@ -130,6 +130,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 							"applicationID",
 							"userAgentID",
 							"de",
+							"",
 							[]string{"clientID1"},
 							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 							[]string{"password"},
@@ -168,6 +169,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 							"applicationID",
 							"userAgentID",
 							"de",
+							"",
 							[]string{"clientID1"},
 							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 							[]string{"password"},
@@ -232,6 +234,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 		//					"applicationID",
 		//					"userAgentID",
 		//					"de",
+		//					"",
 		//					[]string{"clientID1"},
 		//					[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 		//					[]string{"password"},
@@ -251,6 +254,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 		//						"agentID",
 		//						"de",
 		//						[]string{"clientID1"},
+		//						"",
 		//						[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 		//						time.Now().Add(5*time.Minute),
 		//					)),
@@ -292,7 +296,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 				idGenerator:  tt.fields.idGenerator,
 				keyAlgorithm: tt.fields.keyAlgorithm,
 			}
-			got, gotRefresh, err := c.AddAccessAndRefreshToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.refreshToken,
+			got, gotRefresh, err := c.AddAccessAndRefreshToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.refreshToken, "",
 				tt.args.audience, tt.args.scopes, tt.args.authMethodsReferences, tt.args.lifetime, tt.args.refreshIdleExpiration, tt.args.refreshExpiration, tt.args.authTime)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
@@ -367,6 +371,7 @@ func TestCommands_RevokeRefreshToken(t *testing.T) {
 							"clientID",
 							"agentID",
 							"de",
+							"",
 							[]string{"clientID1"},
 							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 							[]string{"password"},
@@ -408,6 +413,7 @@ func TestCommands_RevokeRefreshToken(t *testing.T) {
 							"clientID",
 							"agentID",
 							"de",
+							"",
 							[]string{"clientID1"},
 							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 							[]string{"password"},
@@ -505,6 +511,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							"clientID",
 							"agentID",
 							"de",
+							"",
 							[]string{"clientID1"},
 							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 							[]string{"password"},
@@ -538,6 +545,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							"clientID",
 							"agentID",
 							"de",
+							"",
 							[]string{"clientID"},
 							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 							[]string{"password"},
@@ -554,6 +562,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							"clientID2",
 							"agentID",
 							"de",
+							"",
 							[]string{"clientID2"},
 							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 							[]string{"password"},
@@ -600,6 +609,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							"clientID",
 							"agentID",
 							"de",
+							"",
 							[]string{"clientID1"},
 							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 							[]string{"password"},
@@ -616,6 +626,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							"clientID2",
 							"agentID",
 							"de",
+							"",
 							[]string{"clientID2"},
 							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 							[]string{"password"},
@@ -748,6 +759,7 @@ func TestCommands_addRefreshToken(t *testing.T) {
 					"clientID",
 					"agentID",
 					"de",
+					"",
 					[]string{"clientID1"},
 					[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 					[]string{"password"},
@@ -791,6 +803,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 		userID         string
 		orgID          string
 		refreshToken   string
+		dpopJKT        string
 		idleExpiration time.Duration
 	}
 	type res struct {
@@ -859,6 +872,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							"applicationID",
 							"userAgentID",
 							"de",
+							"",
 							[]string{"clientID1"},
 							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 							[]string{"password"},
@@ -897,6 +911,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							"applicationID",
 							"userAgentID",
 							"de",
+							"",
 							[]string{"clientID1"},
 							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 							[]string{"password"},
@@ -930,6 +945,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							"applicationID",
 							"userAgentID",
 							"de",
+							"",
 							[]string{"clientID1"},
 							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 							[]string{"password"},
@@ -970,6 +986,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							"applicationID",
 							"userAgentID",
 							"de",
+							"",
 							[]string{"clientID1"},
 							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 							[]string{"password"},
@@ -1011,6 +1028,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							"applicationID",
 							"userAgentID",
 							"de",
+							"",
 							[]string{"clientID1"},
 							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 							[]string{"password"},
@@ -1042,6 +1060,87 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 				newRefreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:refreshToken1")),
 			},
 		},
+		{
+			name: "token bound to other dpop key, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							"jkt",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				dpopJKT:        "otherJKT",
+				idleExpiration: 1 * time.Hour,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "token bound to dpop key renewed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							"jkt",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "refreshToken1"),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				dpopJKT:        "jkt",
+				idleExpiration: 1 * time.Hour,
+			},
+			res: res{
+				event: user.NewHumanRefreshTokenRenewedEvent(
+					context.Background(),
+					&user.NewAggregate("userID", "orgID").Aggregate,
+					"tokenID",
+					"refreshToken1",
+					1*time.Hour,
+				),
+				refreshTokenID:  "tokenID",
+				newRefreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:refreshToken1")),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1050,7 +1149,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 				idGenerator:  tt.fields.idGenerator,
 				keyAlgorithm: tt.fields.keyAlgorithm,
 			}
-			gotEvent, gotRefreshTokenID, gotNewRefreshToken, err := c.renewRefreshToken(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.refreshToken, tt.args.idleExpiration)
+			gotEvent, gotRefreshTokenID, gotNewRefreshToken, err := c.renewRefreshToken(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.refreshToken, tt.args.dpopJKT, tt.args.idleExpiration)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// AddHumanSAMLSession records that a SAML response for the user was sent to the service provider,
+// so that the service provider can be logged out on single logout of the user agent
+func (c *Commands) AddHumanSAMLSession(ctx context.Context, userID, resourceOwner, userAgentID, applicationID, entityID, nameID string) error {
+	if userID == "" || userAgentID == "" || applicationID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Sw3ml", "Errors.IDMissing")
+	}
+	existingUser, err := c.getHumanWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return errors.ThrowNotFound(nil, "COMMAND-Sm3l2", "Errors.User.NotFound")
+	}
+	sessions, err := c.humanSAMLSessionsWriteModel(ctx, userAgentID)
+	if err != nil {
+		return err
+	}
+	if sessions.session(userID, applicationID) != nil {
+		return nil
+	}
+	_, err = c.eventstore.Push(ctx, user.NewHumanSAMLSessionAddedEvent(
+		ctx,
+		UserAggregateFromWriteModel(&existingUser.WriteModel),
+		userAgentID,
+		applicationID,
+		entityID,
+		nameID,
+	))
+	return err
+}
+
+// HumanSAMLSessions returns the SAML sessions of all users of the user agent,
+// which were not yet ended by a sign out
+func (c *Commands) HumanSAMLSessions(ctx context.Context, userAgentID string) ([]*HumanSAMLSession, error) {
+	if userAgentID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Sl2m4", "Errors.IDMissing")
+	}
+	sessions, err := c.humanSAMLSessionsWriteModel(ctx, userAgentID)
+	if err != nil {
+		return nil, err
+	}
+	return sessions.Sessions, nil
+}
+
+func (c *Commands) humanSAMLSessionsWriteModel(ctx context.Context, userAgentID string) (*HumanSAMLSessionsWriteModel, error) {
+	writeModel := NewHumanSAMLSessionsWriteModel(userAgentID)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
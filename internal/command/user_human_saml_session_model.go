@@ -0,0 +1,97 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanSAMLSession struct {
+	UserID        string
+	ResourceOwner string
+	ApplicationID string
+	EntityID      string
+	NameID        string
+}
+
+// HumanSAMLSessionsWriteModel collects the SAML service providers
+// a user agent is logged into (and not signed out of) across all users of the instance
+type HumanSAMLSessionsWriteModel struct {
+	eventstore.WriteModel
+
+	UserAgentID string
+	Sessions    []*HumanSAMLSession
+}
+
+func NewHumanSAMLSessionsWriteModel(userAgentID string) *HumanSAMLSessionsWriteModel {
+	return &HumanSAMLSessionsWriteModel{
+		UserAgentID: userAgentID,
+	}
+}
+
+func (wm *HumanSAMLSessionsWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *user.HumanSAMLSessionAddedEvent:
+			if e.UserAgentID != wm.UserAgentID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.HumanSignedOutEvent:
+			if e.UserAgentID != wm.UserAgentID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *HumanSAMLSessionsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanSAMLSessionAddedEvent:
+			if wm.session(e.Aggregate().ID, e.ApplicationID) != nil {
+				continue
+			}
+			wm.Sessions = append(wm.Sessions, &HumanSAMLSession{
+				UserID:        e.Aggregate().ID,
+				ResourceOwner: e.Aggregate().ResourceOwner,
+				ApplicationID: e.ApplicationID,
+				EntityID:      e.EntityID,
+				NameID:        e.NameID,
+			})
+		case *user.HumanSignedOutEvent:
+			wm.removeSessionsOfUser(e.Aggregate().ID)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanSAMLSessionsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		EventTypes(
+			user.HumanSAMLSessionAddedType,
+			user.HumanSignedOutType).
+		EventData(map[string]interface{}{"userAgentID": wm.UserAgentID}).
+		Builder()
+}
+
+func (wm *HumanSAMLSessionsWriteModel) session(userID, applicationID string) *HumanSAMLSession {
+	for _, session := range wm.Sessions {
+		if session.UserID == userID && session.ApplicationID == applicationID {
+			return session
+		}
+	}
+	return nil
+}
+
+func (wm *HumanSAMLSessionsWriteModel) removeSessionsOfUser(userID string) {
+	sessions := make([]*HumanSAMLSession, 0, len(wm.Sessions))
+	for _, session := range wm.Sessions {
+		if session.UserID != userID {
+			sessions = append(sessions, session)
+		}
+	}
+	wm.Sessions = sessions
+}
@@ -0,0 +1,286 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_AddHumanSAMLSession(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		userID        string
+		resourceOwner string
+		userAgentID   string
+		applicationID string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "user agent missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				applicationID: "app1",
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				userAgentID:   "agent1",
+				applicationID: "app1",
+			},
+			res: res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			name: "session already existing, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newAddHumanEvent("", false, ""),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanSAMLSessionAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"agent1",
+								"app1",
+								"https://sp.test.com/metadata",
+								"username",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				userAgentID:   "agent1",
+				applicationID: "app1",
+			},
+			res: res{},
+		},
+		{
+			name: "session signed out before, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newAddHumanEvent("", false, ""),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanSAMLSessionAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"agent1",
+								"app1",
+								"https://sp.test.com/metadata",
+								"username",
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanSignedOutEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"agent1",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanSAMLSessionAddedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"agent1",
+									"app1",
+									"https://sp.test.com/metadata",
+									"username",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				userAgentID:   "agent1",
+				applicationID: "app1",
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := r.AddHumanSAMLSession(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.userAgentID, tt.args.applicationID, "https://sp.test.com/metadata", "username")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestCommandSide_HumanSAMLSessions(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx         context.Context
+		userAgentID string
+	}
+	type res struct {
+		want []*HumanSAMLSession
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "user agent missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "sessions of signed out users removed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanSAMLSessionAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"agent1",
+								"app1",
+								"https://sp1.test.com/metadata",
+								"username1",
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanSAMLSessionAddedEvent(context.Background(),
+								&user.NewAggregate("user2", "org1").Aggregate,
+								"agent1",
+								"app1",
+								"https://sp1.test.com/metadata",
+								"username2",
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanSAMLSessionAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"agent1",
+								"app2",
+								"https://sp2.test.com/metadata",
+								"username1",
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanSignedOutEvent(context.Background(),
+								&user.NewAggregate("user2", "org1").Aggregate,
+								"agent1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				userAgentID: "agent1",
+			},
+			res: res{
+				want: []*HumanSAMLSession{
+					{
+						UserID:        "user1",
+						ResourceOwner: "org1",
+						ApplicationID: "app1",
+						EntityID:      "https://sp1.test.com/metadata",
+						NameID:        "username1",
+					},
+					{
+						UserID:        "user1",
+						ResourceOwner: "org1",
+						ApplicationID: "app2",
+						EntityID:      "https://sp2.test.com/metadata",
+						NameID:        "username1",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.HumanSAMLSessions(tt.args.ctx, tt.args.userAgentID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
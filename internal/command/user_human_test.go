@@ -2030,7 +2030,10 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -2099,7 +2102,10 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -2168,7 +2174,10 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -2254,7 +2263,10 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -2398,7 +2410,10 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -2510,7 +2525,10 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -2622,7 +2640,10 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -2728,7 +2749,10 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -2856,7 +2880,10 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -2979,7 +3006,10 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
+								domain.BotProtectionTypeNone,
 								"",
 								time.Hour*1,
 								time.Hour*2,
@@ -357,7 +357,10 @@ func (c *Commands) beginWebAuthNLogin(ctx context.Context, userID, resourceOwner
 	return userAgg, webAuthNLogin, nil
 }
 
-func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, authRequest *domain.AuthRequest) error {
+func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
+	if err := c.checkLoginThrottle(ctx, authRequest); err != nil {
+		return err
+	}
 	webAuthNLogin, err := c.getHumanU2FLogin(ctx, userID, authRequest.ID, resourceOwner)
 	if err != nil {
 		return err
@@ -373,17 +376,22 @@ func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwne
 			logging.WithFields("userID", userID, "resourceOwner", resourceOwner).WithError(err).Warn("missing userAggregate for pushing failed u2f check event")
 			return err
 		}
-		_, pushErr := c.eventstore.Push(ctx,
-			usr_repo.NewHumanU2FCheckFailedEvent(
-				ctx,
-				userAgg,
-				authRequestDomainToAuthRequestInfo(authRequest),
-			),
-		)
+		c.loginCheckFailed(ctx, authRequest)
+		info := authRequestDomainToAuthRequestInfo(authRequest)
+		events := []eventstore.Command{
+			usr_repo.NewHumanU2FCheckFailedEvent(ctx, userAgg, info),
+		}
+		lockEvent, lockErr := c.otpCheckFailedLockEvent(ctx, userAgg, lockoutPolicy, info)
+		logging.WithFields("userID", userID, "resourceOwner", resourceOwner).OnError(lockErr).Warn("could not check u2f lockout")
+		if lockEvent != nil {
+			events = append(events, lockEvent)
+		}
+		_, pushErr := c.eventstore.Push(ctx, events...)
 		logging.WithFields("userID", userID, "resourceOwner", resourceOwner).OnError(pushErr).Warn("could not push failed u2f check event")
 		return err
 	}
 
+	c.loginCheckSucceeded(ctx, authRequest)
 	_, err = c.eventstore.Push(ctx,
 		usr_repo.NewHumanU2FCheckSucceededEvent(
 			ctx,
@@ -401,7 +409,10 @@ func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwne
 	return err
 }
 
-func (c *Commands) HumanFinishPasswordlessLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, authRequest *domain.AuthRequest) error {
+func (c *Commands) HumanFinishPasswordlessLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
+	if err := c.checkLoginThrottle(ctx, authRequest); err != nil {
+		return err
+	}
 	webAuthNLogin, err := c.getHumanPasswordlessLogin(ctx, userID, authRequest.ID, resourceOwner)
 	if err != nil {
 		return err
@@ -418,17 +429,22 @@ func (c *Commands) HumanFinishPasswordlessLogin(ctx context.Context, userID, res
 			logging.WithFields("userID", userID, "resourceOwner", resourceOwner).WithError(err).Warn("missing userAggregate for pushing failed passwordless check event")
 			return err
 		}
-		_, pushErr := c.eventstore.Push(ctx,
-			usr_repo.NewHumanPasswordlessCheckFailedEvent(
-				ctx,
-				userAgg,
-				authRequestDomainToAuthRequestInfo(authRequest),
-			),
-		)
+		c.loginCheckFailed(ctx, authRequest)
+		info := authRequestDomainToAuthRequestInfo(authRequest)
+		events := []eventstore.Command{
+			usr_repo.NewHumanPasswordlessCheckFailedEvent(ctx, userAgg, info),
+		}
+		lockEvent, lockErr := c.otpCheckFailedLockEvent(ctx, userAgg, lockoutPolicy, info)
+		logging.WithFields("userID", userID, "resourceOwner", resourceOwner).OnError(lockErr).Warn("could not check passwordless lockout")
+		if lockEvent != nil {
+			events = append(events, lockEvent)
+		}
+		_, pushErr := c.eventstore.Push(ctx, events...)
 		logging.WithFields("userID", userID, "resourceOwner", resourceOwner).OnError(pushErr).Warn("could not push failed passwordless check event")
 		return err
 	}
 
+	c.loginCheckSucceeded(ctx, authRequest)
 	_, err = c.eventstore.Push(ctx,
 		usr_repo.NewHumanPasswordlessCheckSucceededEvent(
 			ctx,
@@ -0,0 +1,192 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type Impersonation struct {
+	UserID        string
+	ResourceOwner string
+	// ProjectID is the project the token is issued for, it has to allow impersonation
+	ProjectID string
+	Reason    string
+	// Lifetime of the token, the default lifetime is used if not set
+	Lifetime time.Duration
+}
+
+type ImpersonationToken struct {
+	*domain.ObjectDetails
+
+	TokenID    string
+	Token      string
+	Expiration time.Time
+}
+
+func (i *Impersonation) validate(actorUserID string) error {
+	if i.UserID == "" || i.ProjectID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Nk3fa", "Errors.IDMissing")
+	}
+	if i.UserID == actorUserID {
+		return errors.ThrowPreconditionFailed(nil, "COMMAND-Hs7vq", "Errors.User.Impersonation.Self")
+	}
+	if i.Lifetime == 0 {
+		i.Lifetime = domain.ImpersonationDefaultLifetime
+	}
+	if i.Lifetime < 0 || i.Lifetime > domain.ImpersonationMaxLifetime {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Pm2xd", "Errors.User.Impersonation.LifetimeInvalid")
+	}
+	return nil
+}
+
+// StartImpersonation issues an access token for the user to the administrator of the context.
+// The token is restricted to a project which opted in to impersonation and carries the administrator as actor.
+func (c *Commands) StartImpersonation(ctx context.Context, impersonation *Impersonation) (_ *ImpersonationToken, err error) {
+	actor := authz.GetCtxData(ctx)
+	if err = impersonation.validate(actor.UserID); err != nil {
+		return nil, err
+	}
+	if err = c.checkImpersonation(ctx, impersonation); err != nil {
+		return nil, err
+	}
+
+	userWriteModel := NewUserWriteModel(impersonation.UserID, impersonation.ResourceOwner)
+	tokenEvent, token, err := c.addUserToken(ctx, userWriteModel, "", "", "", "", []string{impersonation.ProjectID}, domain.ImpersonationScopes, impersonation.Lifetime)
+	if err != nil {
+		return nil, err
+	}
+	tokenEvent.ActorUserID = actor.UserID
+	accessToken, err := createToken(c.keyAlgorithm, token.TokenID, userWriteModel.AggregateID)
+	if err != nil {
+		return nil, err
+	}
+
+	userAgg := UserAggregateFromWriteModel(&userWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx,
+		user.NewUserImpersonationStartedEvent(ctx, userAgg, token.TokenID, actor.UserID, actor.ResourceOwner, impersonation.ProjectID, impersonation.Reason, token.Expiration),
+		tokenEvent,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ImpersonationToken{
+		ObjectDetails: pushedEventsToObjectDetails(pushedEvents),
+		TokenID:       token.TokenID,
+		Token:         accessToken,
+		Expiration:    token.Expiration,
+	}, nil
+}
+
+// CheckLoginImpersonation verifies the impersonation of the user requested by the actor in a login.
+// The actor needs the permission to impersonate the user, the project of the client has to allow impersonation
+// and the user must not hold any manager role.
+func (c *Commands) CheckLoginImpersonation(ctx context.Context, actorUserID, userID, projectID string) error {
+	impersonation := &Impersonation{
+		UserID:    userID,
+		ProjectID: projectID,
+	}
+	if err := impersonation.validate(actorUserID); err != nil {
+		return err
+	}
+	existing, err := c.userWriteModelByID(ctx, userID, "")
+	if err != nil {
+		return err
+	}
+	if existing.UserState != domain.UserStateActive {
+		return errors.ThrowNotFound(nil, "COMMAND-Im5nf", "Errors.User.NotFound")
+	}
+	actorCtx := authz.SetCtxData(ctx, authz.CtxData{UserID: actorUserID})
+	if err = c.checkPermission(actorCtx, domain.PermissionUserImpersonate, existing.ResourceOwner, userID); err != nil {
+		return errors.ThrowPermissionDenied(err, "COMMAND-Im6pd", "Errors.PermissionDenied")
+	}
+	return c.checkImpersonation(ctx, impersonation)
+}
+
+// AddLoginImpersonationToken issues an access token of a login impersonation verified by CheckLoginImpersonation.
+// The lifetime of the token is limited to the default lifetime of impersonations.
+func (c *Commands) AddLoginImpersonationToken(ctx context.Context, actorUserID, actorResourceOwner, agentID, clientID, userID, projectID, dpopJKT string, audience, scopes []string, lifetime time.Duration) (*domain.Token, error) {
+	if actorUserID == "" || userID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Im7ld", "Errors.IDMissing")
+	}
+	if lifetime <= 0 || lifetime > domain.ImpersonationDefaultLifetime {
+		lifetime = domain.ImpersonationDefaultLifetime
+	}
+	userWriteModel := NewUserWriteModel(userID, "")
+	tokenEvent, token, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, "", dpopJKT, audience, scopes, lifetime)
+	if err != nil {
+		return nil, err
+	}
+	tokenEvent.ActorUserID = actorUserID
+	token.ActorUserID = actorUserID
+
+	userAgg := UserAggregateFromWriteModel(&userWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx,
+		user.NewUserImpersonationStartedEvent(ctx, userAgg, token.TokenID, actorUserID, actorResourceOwner, projectID, "login", token.Expiration),
+		tokenEvent,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// checkImpersonation verifies the project opted in to impersonation and the user can be impersonated
+func (c *Commands) checkImpersonation(ctx context.Context, impersonation *Impersonation) error {
+	project, err := c.getProjectWriteModelByID(ctx, impersonation.ProjectID, "")
+	if err != nil {
+		return err
+	}
+	if project.State != domain.ProjectStateActive {
+		return errors.ThrowNotFound(nil, "COMMAND-Ta9ws", "Errors.Project.NotFound")
+	}
+	if !project.AllowImpersonation {
+		return errors.ThrowPreconditionFailed(nil, "COMMAND-Lo2bn", "Errors.User.Impersonation.NotAllowed")
+	}
+	return c.checkImpersonationTarget(ctx, impersonation.UserID)
+}
+
+// checkImpersonationTarget prevents the escalation of privileges through impersonation:
+// users holding any manager role (instance, organization, project or project grant membership) cannot be impersonated
+func (c *Commands) checkImpersonationTarget(ctx context.Context, userID string) error {
+	memberships := NewUserMembershipsWriteModel(userID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, memberships); err != nil {
+		return err
+	}
+	if len(memberships.Memberships) > 0 {
+		return errors.ThrowPermissionDenied(nil, "COMMAND-Im4ng", "Errors.User.Impersonation.ManagerNotAllowed")
+	}
+	return nil
+}
+
+// EndImpersonation revokes the token of the impersonation before it expires
+func (c *Commands) EndImpersonation(ctx context.Context, userID, resourceOwner, tokenID string) (*domain.ObjectDetails, error) {
+	if userID == "" || tokenID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Vd5rk", "Errors.IDMissing")
+	}
+	existing := NewUserImpersonationWriteModel(userID, resourceOwner, tokenID)
+	err := c.eventstore.FilterToQueryReducer(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.Active {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Jf8qe", "Errors.User.Impersonation.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx,
+		user.NewUserImpersonationEndedEvent(ctx, userAgg, tokenID),
+		user.NewUserTokenRemovedEvent(ctx, userAgg, tokenID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
@@ -0,0 +1,203 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type UserImpersonationWriteModel struct {
+	eventstore.WriteModel
+
+	TokenID     string
+	ActorUserID string
+	ProjectID   string
+	Expiration  time.Time
+	Active      bool
+}
+
+func NewUserImpersonationWriteModel(userID, resourceOwner, tokenID string) *UserImpersonationWriteModel {
+	return &UserImpersonationWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		TokenID: tokenID,
+	}
+}
+
+func (wm *UserImpersonationWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *user.UserImpersonationStartedEvent:
+			if wm.TokenID != e.TokenID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.UserImpersonationEndedEvent:
+			if wm.TokenID != e.TokenID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.UserTokenRemovedEvent:
+			if wm.TokenID != e.TokenID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.UserLockedEvent,
+			*user.UserDeactivatedEvent,
+			*user.UserRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *UserImpersonationWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.UserImpersonationStartedEvent:
+			wm.ActorUserID = e.ActorUserID
+			wm.ProjectID = e.ProjectID
+			wm.Expiration = e.Expiration
+			wm.Active = e.Expiration.After(time.Now())
+		case *user.UserImpersonationEndedEvent,
+			*user.UserTokenRemovedEvent,
+			*user.UserLockedEvent,
+			*user.UserDeactivatedEvent,
+			*user.UserRemovedEvent:
+			wm.Active = false
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserImpersonationWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.UserImpersonationStartedType,
+			user.UserImpersonationEndedType,
+			user.UserTokenRemovedType,
+			user.UserLockedType,
+			user.UserDeactivatedType,
+			user.UserRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+// UserMembershipsWriteModel collects the memberships of a user on the instance, organizations, projects and project grants.
+// A membership grants manager roles, which must not be impersonated.
+type UserMembershipsWriteModel struct {
+	eventstore.WriteModel
+
+	UserID string
+	// Memberships maps the aggregate (and grant) of the membership to its roles
+	Memberships map[string][]string
+}
+
+func NewUserMembershipsWriteModel(userID string) *UserMembershipsWriteModel {
+	return &UserMembershipsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID: userID,
+		},
+		UserID:      userID,
+		Memberships: make(map[string][]string),
+	}
+}
+
+func (wm *UserMembershipsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.MemberAddedEvent:
+			wm.addMembership(e.Aggregate().ID, e.UserID, e.Roles)
+		case *instance.MemberChangedEvent:
+			wm.addMembership(e.Aggregate().ID, e.UserID, e.Roles)
+		case *instance.MemberRemovedEvent:
+			wm.removeMembership(e.Aggregate().ID, e.UserID)
+		case *instance.MemberCascadeRemovedEvent:
+			wm.removeMembership(e.Aggregate().ID, e.UserID)
+		case *org.MemberAddedEvent:
+			wm.addMembership(e.Aggregate().ID, e.UserID, e.Roles)
+		case *org.MemberChangedEvent:
+			wm.addMembership(e.Aggregate().ID, e.UserID, e.Roles)
+		case *org.MemberRemovedEvent:
+			wm.removeMembership(e.Aggregate().ID, e.UserID)
+		case *org.MemberCascadeRemovedEvent:
+			wm.removeMembership(e.Aggregate().ID, e.UserID)
+		case *project.MemberAddedEvent:
+			wm.addMembership(e.Aggregate().ID, e.UserID, e.Roles)
+		case *project.MemberChangedEvent:
+			wm.addMembership(e.Aggregate().ID, e.UserID, e.Roles)
+		case *project.MemberRemovedEvent:
+			wm.removeMembership(e.Aggregate().ID, e.UserID)
+		case *project.MemberCascadeRemovedEvent:
+			wm.removeMembership(e.Aggregate().ID, e.UserID)
+		case *project.GrantMemberAddedEvent:
+			wm.addMembership(e.Aggregate().ID+":"+e.GrantID, e.UserID, e.Roles)
+		case *project.GrantMemberChangedEvent:
+			wm.addMembership(e.Aggregate().ID+":"+e.GrantID, e.UserID, e.Roles)
+		case *project.GrantMemberRemovedEvent:
+			wm.removeMembership(e.Aggregate().ID+":"+e.GrantID, e.UserID)
+		case *project.GrantMemberCascadeRemovedEvent:
+			wm.removeMembership(e.Aggregate().ID+":"+e.GrantID, e.UserID)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserMembershipsWriteModel) addMembership(id, userID string, roles []string) {
+	if userID != wm.UserID {
+		return
+	}
+	wm.Memberships[id] = roles
+}
+
+func (wm *UserMembershipsWriteModel) removeMembership(id, userID string) {
+	if userID != wm.UserID {
+		return
+	}
+	delete(wm.Memberships, id)
+}
+
+func (wm *UserMembershipsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.MemberAddedEventType,
+			instance.MemberChangedEventType,
+			instance.MemberRemovedEventType,
+			instance.MemberCascadeRemovedEventType).
+		EventData(map[string]interface{}{"userId": wm.UserID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.MemberAddedEventType,
+			org.MemberChangedEventType,
+			org.MemberRemovedEventType,
+			org.MemberCascadeRemovedEventType).
+		EventData(map[string]interface{}{"userId": wm.UserID}).
+		Or().
+		AggregateTypes(project.AggregateType).
+		EventTypes(
+			project.MemberAddedType,
+			project.MemberChangedType,
+			project.MemberRemovedType,
+			project.MemberCascadeRemovedType,
+			project.GrantMemberAddedType,
+			project.GrantMemberChangedType,
+			project.GrantMemberRemovedType,
+			project.GrantMemberCascadeRemovedType).
+		EventData(map[string]interface{}{"userId": wm.UserID}).
+		Builder()
+}
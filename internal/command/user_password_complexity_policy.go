@@ -5,6 +5,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 )
 
@@ -20,18 +21,29 @@ func passwordComplexityPolicyWriteModel(ctx context.Context, filter preparation.
 	return nil, errors.ThrowInternal(nil, "USER-uQ96e", "Errors.Internal")
 }
 
+// customPasswordComplexityPolicy returns the policy of the org of the context
+// or of the nearest ancestor defining one
 func customPasswordComplexityPolicy(ctx context.Context, filter preparation.FilterToQueryReducer) (*PasswordComplexityPolicyWriteModel, error) {
-	policy := NewOrgPasswordComplexityPolicyWriteModel(authz.GetCtxData(ctx).OrgID)
-	events, err := filter(ctx, policy.Query())
-	if err != nil {
-		return nil, err
+	orgID := authz.GetCtxData(ctx).OrgID
+	for depth := 0; depth <= domain.OrgHierarchyMaxDepth; depth++ {
+		policy := NewOrgPasswordComplexityPolicyWriteModel(orgID)
+		events, err := filter(ctx, policy.Query())
+		if err != nil {
+			return nil, err
+		}
+		if len(events) == 0 {
+			return nil, nil
+		}
+		policy.AppendEvents(events...)
+		if err = policy.Reduce(); err != nil {
+			return nil, err
+		}
+		if policy.State.Exists() || policy.orgRemoved || policy.ParentOrgID == "" {
+			return &policy.PasswordComplexityPolicyWriteModel, nil
+		}
+		orgID = policy.ParentOrgID
 	}
-	if len(events) == 0 {
-		return nil, nil
-	}
-	policy.AppendEvents(events...)
-	err = policy.Reduce()
-	return &policy.PasswordComplexityPolicyWriteModel, err
+	return nil, nil
 }
 
 func defaultPasswordComplexityPolicy(ctx context.Context, filter preparation.FilterToQueryReducer) (*PasswordComplexityPolicyWriteModel, error) {
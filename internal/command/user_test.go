@@ -1448,7 +1448,7 @@ func TestCommandSide_AddUserToken(t *testing.T) {
 				eventstore:  tt.fields.eventstore,
 				idGenerator: tt.fields.idGenerator,
 			}
-			got, err := r.AddUserToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.audience, tt.args.scopes, tt.args.lifetime)
+			got, err := r.AddUserToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, "", tt.args.audience, tt.args.scopes, tt.args.lifetime)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -1511,6 +1511,7 @@ func TestCommands_RevokeAccessToken(t *testing.T) {
 								"agentID",
 								"de",
 								"refreshTokenID",
+								"",
 								[]string{"clientID"},
 								[]string{"openid"},
 								time.Now(),
@@ -1543,6 +1544,7 @@ func TestCommands_RevokeAccessToken(t *testing.T) {
 								"agentID",
 								"de",
 								"refreshTokenID",
+								"",
 								[]string{"clientID"},
 								[]string{"openid"},
 								time.Now().Add(5*time.Hour),
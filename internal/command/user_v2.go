@@ -0,0 +1,154 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// AddUserHuman adds a human user to the organisation,
+// the user of the context needs the permission to write users on the organisation
+func (c *Commands) AddUserHuman(ctx context.Context, resourceOwner, userID string, human *AddHuman) (*domain.HumanDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sfw2b", "Errors.ResourceOwnerMissing")
+	}
+	if err := c.checkPermission(ctx, domain.PermissionUserWrite, resourceOwner, userID); err != nil {
+		return nil, err
+	}
+	if userID != "" {
+		return c.AddHumanWithID(ctx, resourceOwner, userID, human)
+	}
+	return c.AddHuman(ctx, resourceOwner, human)
+}
+
+// ChangeUserEmail sets a new email address of the user,
+// the address can directly be set as verified, otherwise a verification code will be sent.
+// Setting it as verified requires the permission to write users, even for its own user,
+// without it the flag is ignored and a verification code will be sent.
+func (c *Commands) ChangeUserEmail(ctx context.Context, userID, email string, isVerified bool, emailCodeGenerator crypto.Generator) (*domain.Email, error) {
+	resourceOwner, err := c.checkPermissionUpdateUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if isVerified && c.checkPermission(ctx, domain.PermissionUserWrite, resourceOwner, userID) != nil {
+		isVerified = false
+	}
+	return c.ChangeHumanEmail(ctx,
+		&domain.Email{
+			ObjectRoot: models.ObjectRoot{
+				AggregateID:   userID,
+				ResourceOwner: resourceOwner,
+			},
+			EmailAddress:    domain.EmailAddress(email),
+			IsEmailVerified: isVerified,
+		},
+		emailCodeGenerator,
+	)
+}
+
+// VerifyUserEmail verifies the email address of the user with the code sent to the address
+func (c *Commands) VerifyUserEmail(ctx context.Context, userID, code string, emailCodeGenerator crypto.Generator) (*domain.ObjectDetails, error) {
+	resourceOwner, err := c.checkPermissionUpdateUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return c.VerifyHumanEmail(ctx, userID, code, resourceOwner, emailCodeGenerator)
+}
+
+// SetUserPassword sets the password of the user,
+// the user of the context always needs the permission to write users, even for its own user
+func (c *Commands) SetUserPassword(ctx context.Context, userID, password string, changeRequired bool) (*domain.ObjectDetails, error) {
+	resourceOwner, err := c.userResourceOwner(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.checkPermission(ctx, domain.PermissionUserWrite, resourceOwner, userID); err != nil {
+		return nil, err
+	}
+	return c.SetPassword(ctx, resourceOwner, userID, password, changeRequired)
+}
+
+// ChangeUserPassword changes the password of the user after verifying the current password
+func (c *Commands) ChangeUserPassword(ctx context.Context, userID, currentPassword, newPassword string) (*domain.ObjectDetails, error) {
+	resourceOwner, err := c.checkPermissionUpdateUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return c.ChangePassword(ctx, resourceOwner, userID, currentPassword, newPassword, "")
+}
+
+// RegisterUserPasskey starts the registration of a new passkey (passwordless authenticator) of the user
+func (c *Commands) RegisterUserPasskey(ctx context.Context, userID string, authenticator domain.AuthenticatorAttachment) (*domain.WebAuthNToken, error) {
+	resourceOwner, err := c.checkPermissionUpdateUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return c.HumanAddPasswordlessSetup(ctx, userID, resourceOwner, false, authenticator)
+}
+
+// VerifyUserPasskey finishes the registration of the passkey with the credential created by the authenticator
+func (c *Commands) VerifyUserPasskey(ctx context.Context, userID, passkeyName string, credentialData []byte) (*domain.ObjectDetails, error) {
+	resourceOwner, err := c.checkPermissionUpdateUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return c.HumanHumanPasswordlessSetup(ctx, userID, resourceOwner, passkeyName, "", credentialData)
+}
+
+// AddIDPLink links the user of an identity provider to the user
+func (c *Commands) AddIDPLink(ctx context.Context, userID string, link *domain.UserIDPLink) (*domain.ObjectDetails, error) {
+	resourceOwner, err := c.checkPermissionUpdateUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	linkWriteModel := NewUserIDPLinkWriteModel(userID, link.IDPConfigID, link.ExternalUserID, resourceOwner)
+	userAgg := UserAggregateFromWriteModel(&linkWriteModel.WriteModel)
+	event, err := c.addUserIDPLink(ctx, userAgg, link)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(linkWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&linkWriteModel.WriteModel), nil
+}
+
+// checkPermissionUpdateUser returns the organisation of the user
+// if the user of the context is allowed to update the user,
+// users are always allowed to update themselves
+func (c *Commands) checkPermissionUpdateUser(ctx context.Context, userID string) (string, error) {
+	resourceOwner, err := c.userResourceOwner(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if userID == authz.GetCtxData(ctx).UserID {
+		return resourceOwner, nil
+	}
+	if err = c.checkPermission(ctx, domain.PermissionUserWrite, resourceOwner, userID); err != nil {
+		return "", err
+	}
+	return resourceOwner, nil
+}
+
+func (c *Commands) userResourceOwner(ctx context.Context, userID string) (string, error) {
+	if userID == "" {
+		return "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sfw2c", "Errors.User.UserIDMissing")
+	}
+	writeModel, err := c.userWriteModelByID(ctx, userID, "")
+	if err != nil {
+		return "", err
+	}
+	if !isUserStateExists(writeModel.UserState) {
+		return "", caos_errs.ThrowNotFound(nil, "COMMAND-Sfw2d", "Errors.User.NotFound")
+	}
+	return writeModel.ResourceOwner, nil
+}
@@ -4,6 +4,8 @@ import (
 	"crypto/tls"
 	"errors"
 	"os"
+
+	"github.com/zitadel/zitadel/internal/acme"
 )
 
 var (
@@ -25,6 +27,9 @@ type TLS struct {
 	Key []byte
 	//Certificate for the TLS connection (CertPath will this overwrite, if specified)
 	Cert []byte
+	//ACME issues the certificates of the instance and login domains automatically,
+	//the certificate above is optional and only served if no ACME certificate is available
+	ACME acme.Config
 }
 
 func (t *TLS) Config() (_ *tls.Config, err error) {
@@ -44,6 +49,9 @@ func (t *TLS) Config() (_ *tls.Config, err error) {
 		}
 	}
 	if t.Key == nil || t.Cert == nil {
+		if t.ACME.Enabled {
+			return new(tls.Config), nil
+		}
 		return nil, ErrMissingConfig
 	}
 	tlsCert, err := tls.X509KeyPair(t.Cert, t.Key)
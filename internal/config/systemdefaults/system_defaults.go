@@ -4,12 +4,16 @@ import (
 	"time"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/notification/channels/push"
 )
 
 type SystemDefaults struct {
 	SecretGenerators   SecretGenerators
 	Multifactors       MultifactorConfig
 	DomainVerification DomainVerification
+	MagicLink          MagicLink
+	PhoneOTP           PhoneOTP
+	LoginThrottling    LoginThrottling
 	Notifications      Notifications
 	KeyConfig          KeyConfig
 }
@@ -21,19 +25,55 @@ type SecretGenerators struct {
 }
 
 type MultifactorConfig struct {
-	OTP OTPConfig
+	OTP  OTPConfig
+	Push PushConfig
 }
 
 type OTPConfig struct {
 	Issuer string
 }
 
+type PushConfig struct {
+	// ChallengeExpiry is the time the user has to approve the sign-in in the app
+	ChallengeExpiry time.Duration
+	// VerificationCodeGenerator creates the code a new device has to sign to be activated
+	VerificationCodeGenerator crypto.GeneratorConfig
+}
+
 type DomainVerification struct {
 	VerificationGenerator crypto.GeneratorConfig
 }
 
+type MagicLink struct {
+	CodeGenerator   crypto.GeneratorConfig
+	MaxAttempts     uint8
+	MaxRequests     uint8
+	RequestInterval time.Duration
+}
+
+type PhoneOTP struct {
+	CodeGenerator   crypto.GeneratorConfig
+	MaxAttempts     uint8
+	MaxRequests     uint8
+	RequestInterval time.Duration
+}
+
+// LoginThrottling delays authentication checks after repeated failures per IP and per instance
+type LoginThrottling struct {
+	// IPFailureThreshold is the number of failed checks from one IP before checks are delayed, 0 disables it
+	IPFailureThreshold uint32
+	// InstanceFailureThreshold is the number of failed checks on one instance before checks are delayed, 0 disables it
+	InstanceFailureThreshold uint32
+	// Window after which the failures are forgotten
+	Window time.Duration
+	// BaseDelay is doubled with every failure above the threshold
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
 type Notifications struct {
 	FileSystemPath string
+	Push           push.Config
 }
 
 type KeyConfig struct {
@@ -2,6 +2,7 @@ package database
 
 import (
 	"database/sql/driver"
+	"encoding/json"
 
 	"github.com/jackc/pgtype"
 )
@@ -70,3 +71,28 @@ func (s EnumArray[F]) Value() (driver.Value, error) {
 
 	return array.Value()
 }
+
+type Map[V any] map[string]V
+
+// Scan implements the `database/sql.Scanner` interface.
+func (m *Map[V]) Scan(src any) error {
+	var bytes []byte
+	switch value := src.(type) {
+	case []byte:
+		bytes = value
+	case string:
+		bytes = []byte(value)
+	}
+	if len(bytes) == 0 {
+		return nil
+	}
+	return json.Unmarshal(bytes, &m)
+}
+
+// Value implements the `database/sql/driver.Valuer` interface.
+func (m Map[V]) Value() (driver.Value, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(m)
+}
@@ -46,6 +46,7 @@ type OIDCApp struct {
 	ClockSkew                time.Duration
 	AdditionalOrigins        []string
 	SkipNativeAppSuccessPage bool
+	RequireConsent           bool
 
 	State AppState
 }
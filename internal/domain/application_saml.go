@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
@@ -12,10 +15,164 @@ type SAMLApp struct {
 	EntityID    string
 	Metadata    []byte
 	MetadataURL string
+	// IDPInitiatedSSO allows to start the login from ZITADEL
+	// and send an unsolicited response to the service provider
+	IDPInitiatedSSO   bool
+	DefaultRelayState string
+	// SignatureAlgorithm overwrites the algorithm of the instance configuration if specified
+	SignatureAlgorithm SAMLSignatureAlgorithm
+	SigningMode        SAMLSigningMode
+	// EncryptAssertion encrypts the assertion with the encryption certificate of the service provider metadata
+	EncryptAssertion bool
+	// NameIDFormat defines the format and the value of the subject in the assertion
+	NameIDFormat SAMLNameIDFormat
+	// AttributeMappings replace the default attributes of the assertion if set
+	AttributeMappings SAMLAttributeMappings
 
 	State AppState
 }
 
+type SAMLSignatureAlgorithm int32
+
+const (
+	SAMLSignatureAlgorithmUnspecified SAMLSignatureAlgorithm = iota
+	SAMLSignatureAlgorithmRSASHA256
+	SAMLSignatureAlgorithmRSASHA512
+
+	samlSignatureAlgorithmCount
+)
+
+func (a SAMLSignatureAlgorithm) Valid() bool {
+	return a >= SAMLSignatureAlgorithmUnspecified && a < samlSignatureAlgorithmCount
+}
+
+// SAMLSigningMode defines which elements of the SAML response are signed
+type SAMLSigningMode int32
+
+const (
+	SAMLSigningModeAssertion SAMLSigningMode = iota
+	SAMLSigningModeResponse
+	SAMLSigningModeResponseAndAssertion
+
+	samlSigningModeCount
+)
+
+func (m SAMLSigningMode) Valid() bool {
+	return m >= SAMLSigningModeAssertion && m < samlSigningModeCount
+}
+
+func (m SAMLSigningMode) SignAssertion() bool {
+	return m == SAMLSigningModeAssertion || m == SAMLSigningModeResponseAndAssertion
+}
+
+func (m SAMLSigningMode) SignResponse() bool {
+	return m == SAMLSigningModeResponse || m == SAMLSigningModeResponseAndAssertion
+}
+
+// SAMLNameIDFormat defines the format and the value of the NameID of the subject,
+// unspecified sends the username with the format emailAddress
+type SAMLNameIDFormat int32
+
+const (
+	SAMLNameIDFormatUnspecified SAMLNameIDFormat = iota
+	SAMLNameIDFormatEmail
+	SAMLNameIDFormatPersistent
+	SAMLNameIDFormatTransient
+
+	samlNameIDFormatCount
+)
+
+func (f SAMLNameIDFormat) Valid() bool {
+	return f >= SAMLNameIDFormatUnspecified && f < samlNameIDFormatCount
+}
+
+// SAMLAttributeSource defines the value of the user which is sent as attribute
+type SAMLAttributeSource int32
+
+const (
+	SAMLAttributeSourceUnspecified SAMLAttributeSource = iota
+	SAMLAttributeSourceUserID
+	SAMLAttributeSourceUsername
+	SAMLAttributeSourceEmail
+	SAMLAttributeSourceFirstName
+	SAMLAttributeSourceLastName
+	SAMLAttributeSourceDisplayName
+	SAMLAttributeSourceNickName
+	SAMLAttributeSourcePhone
+	SAMLAttributeSourcePreferredLanguage
+	SAMLAttributeSourceMetadata
+	SAMLAttributeSourceRoles
+	SAMLAttributeSourceGroups
+
+	samlAttributeSourceCount
+)
+
+func (s SAMLAttributeSource) Valid() bool {
+	return s > SAMLAttributeSourceUnspecified && s < samlAttributeSourceCount
+}
+
+type SAMLAttributeMapping struct {
+	// Name of the attribute in the assertion, e.g. urn:oid:0.9.2342.19200300.100.1.3
+	Name         string              `json:"name"`
+	FriendlyName string              `json:"friendlyName,omitempty"`
+	Source       SAMLAttributeSource `json:"source"`
+	// MetadataKey of the user metadata, only used with SAMLAttributeSourceMetadata
+	MetadataKey string `json:"metadataKey,omitempty"`
+}
+
+func (m *SAMLAttributeMapping) IsValid() bool {
+	if m == nil || m.Name == "" || !m.Source.Valid() {
+		return false
+	}
+	return m.Source != SAMLAttributeSourceMetadata || m.MetadataKey != ""
+}
+
+type SAMLAttributeMappings []*SAMLAttributeMapping
+
+// IsValid checks all mappings, the names of the attributes must be unique
+func (m SAMLAttributeMappings) IsValid() bool {
+	names := make(map[string]struct{}, len(m))
+	for _, mapping := range m {
+		if !mapping.IsValid() {
+			return false
+		}
+		if _, ok := names[mapping.Name]; ok {
+			return false
+		}
+		names[mapping.Name] = struct{}{}
+	}
+	return true
+}
+
+func (m SAMLAttributeMappings) Equal(mappings SAMLAttributeMappings) bool {
+	if len(m) != len(mappings) {
+		return false
+	}
+	for i, mapping := range m {
+		if *mapping != *mappings[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (m SAMLAttributeMappings) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return json.Marshal(&m)
+}
+
+func (m *SAMLAttributeMappings) Scan(src interface{}) error {
+	if b, ok := src.([]byte); ok {
+		return json.Unmarshal(b, m)
+	}
+	if s, ok := src.(string); ok {
+		return json.Unmarshal([]byte(s), m)
+	}
+	return nil
+}
+
 func (a *SAMLApp) GetApplicationName() string {
 	return a.AppName
 }
@@ -36,5 +193,8 @@ func (a *SAMLApp) IsValid() bool {
 	if a.MetadataURL == "" && a.Metadata == nil {
 		return false
 	}
-	return true
+	return a.SignatureAlgorithm.Valid() &&
+		a.SigningMode.Valid() &&
+		a.NameIDFormat.Valid() &&
+		a.AttributeMappings.IsValid()
 }
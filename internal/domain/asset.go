@@ -11,6 +11,7 @@ const (
 	labelPolicyLogoPrefix = LabelPolicyPrefix + "/logo"
 	labelPolicyIconPrefix = LabelPolicyPrefix + "/icon"
 	labelPolicyFontPrefix = LabelPolicyPrefix + "/font"
+	MailTemplatePrefix    = policyPrefix + "/mail/template"
 	Dark                  = "dark"
 
 	CssPath              = LabelPolicyPrefix + "/css"
@@ -41,10 +41,12 @@ type AuthRequest struct {
 	ApplicationResourceOwner string
 	PrivateLabelingSetting   PrivateLabelingSetting
 	SelectedIDPConfigID      string
+	SessionID                string
 	LinkingUsers             []*ExternalUser
 	PossibleSteps            []NextStep
 	PasswordVerified         bool
 	MFAsVerified             []MFAType
+	ConsentDenied            bool
 	Audience                 []string
 	AuthTime                 time.Time
 	Code                     string
@@ -105,6 +107,7 @@ const (
 	MFATypeOTP MFAType = iota
 	MFATypeU2F
 	MFATypeU2FUserVerification
+	MFATypePush
 )
 
 type MFALevel int
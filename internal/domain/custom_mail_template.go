@@ -0,0 +1,43 @@
+package domain
+
+import "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+
+// CustomMailTemplate is the html layout an organization uses for the emails of a specific message type.
+// The optional MJML source is stored alongside, so the layout can be edited and compiled again later.
+type CustomMailTemplate struct {
+	models.ObjectRoot
+
+	State       PolicyState
+	MessageType string
+	Template    []byte
+	MJML        []byte
+}
+
+func (m *CustomMailTemplate) IsValid() bool {
+	return IsEmailMessageType(m.MessageType) && len(m.Template) > 0
+}
+
+// IsEmailMessageType returns if messages of the type are sent by email and therefore rendered with a mail template
+func IsEmailMessageType(messageType string) bool {
+	switch messageType {
+	case InitCodeMessageType,
+		PasswordResetMessageType,
+		VerifyEmailMessageType,
+		DomainClaimedMessageType,
+		PasswordlessRegistrationMessageType,
+		PasswordChangeMessageType,
+		MagicLinkMessageType,
+		NewDeviceLoginMessageType,
+		MFAAddedMessageType,
+		MFARemovedMessageType,
+		EmailChangedMessageType,
+		AccountLockedMessageType:
+		return true
+	default:
+		return false
+	}
+}
+
+func CustomMailTemplateObjectName(messageType, id, extension string) string {
+	return MailTemplatePrefix + "/" + messageType + "-" + id + "." + extension
+}
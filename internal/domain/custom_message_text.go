@@ -14,6 +14,14 @@ const (
 	DomainClaimedMessageType            = "DomainClaimed"
 	PasswordlessRegistrationMessageType = "PasswordlessRegistration"
 	PasswordChangeMessageType           = "PasswordChange"
+	MagicLinkMessageType                = "MagicLink"
+	PhoneOTPMessageType                 = "PhoneOTP"
+	NewDeviceLoginMessageType           = "NewDeviceLogin"
+	MFAAddedMessageType                 = "MFAAdded"
+	MFARemovedMessageType               = "MFARemoved"
+	EmailChangedMessageType             = "EmailChanged"
+	AccountLockedMessageType            = "AccountLocked"
+	MFAPushMessageType                  = "MFAPush"
 	MessageTitle                        = "Title"
 	MessagePreHeader                    = "PreHeader"
 	MessageSubject                      = "Subject"
@@ -31,6 +39,13 @@ type MessageTexts struct {
 	DomainClaimed            CustomMessageText
 	PasswordlessRegistration CustomMessageText
 	PasswordChange           CustomMessageText
+	MagicLink                CustomMessageText
+	PhoneOTP                 CustomMessageText
+	NewDeviceLogin           CustomMessageText
+	MFAAdded                 CustomMessageText
+	MFARemoved               CustomMessageText
+	EmailChanged             CustomMessageText
+	AccountLocked            CustomMessageText
 }
 
 type CustomMessageText struct {
@@ -69,6 +84,20 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *CustomMessageText {
 		return &m.PasswordlessRegistration
 	case PasswordChangeMessageType:
 		return &m.PasswordChange
+	case MagicLinkMessageType:
+		return &m.MagicLink
+	case PhoneOTPMessageType:
+		return &m.PhoneOTP
+	case NewDeviceLoginMessageType:
+		return &m.NewDeviceLogin
+	case MFAAddedMessageType:
+		return &m.MFAAdded
+	case MFARemovedMessageType:
+		return &m.MFARemoved
+	case EmailChangedMessageType:
+		return &m.EmailChanged
+	case AccountLockedMessageType:
+		return &m.AccountLocked
 	}
 	return nil
 }
@@ -80,5 +109,13 @@ func IsMessageTextType(textType string) bool {
 		textType == VerifyPhoneMessageType ||
 		textType == DomainClaimedMessageType ||
 		textType == PasswordlessRegistrationMessageType ||
-		textType == PasswordChangeMessageType
+		textType == PasswordChangeMessageType ||
+		textType == MagicLinkMessageType ||
+		textType == PhoneOTPMessageType ||
+		textType == NewDeviceLoginMessageType ||
+		textType == MFAAddedMessageType ||
+		textType == MFARemovedMessageType ||
+		textType == EmailChangedMessageType ||
+		textType == AccountLockedMessageType ||
+		textType == MFAPushMessageType
 }
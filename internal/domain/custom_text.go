@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"strings"
+
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -30,3 +32,30 @@ const (
 func (m *CustomText) IsValid() bool {
 	return m.Key != "" && m.Language != language.Und && m.Text != ""
 }
+
+// CustomTextFormat is the file format custom texts are exported to and imported from
+type CustomTextFormat int32
+
+const (
+	CustomTextFormatUnspecified CustomTextFormat = iota
+	CustomTextFormatXLIFF
+	CustomTextFormatJSON
+
+	customTextFormatCount
+)
+
+func (f CustomTextFormat) Valid() bool {
+	return f > CustomTextFormatUnspecified && f < customTextFormatCount
+}
+
+// CustomTextID joins the template and key of a custom text,
+// it's the identifier of the text in exported files (e.g. `Login.Login.Title` or `InitCode.Subject`)
+func CustomTextID(template, key string) string {
+	return template + "." + key
+}
+
+// SplitCustomTextID returns the template and key of an identifier created by CustomTextID
+func SplitCustomTextID(id string) (template, key string) {
+	template, key, _ = strings.Cut(id, ".")
+	return template, key
+}
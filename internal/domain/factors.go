@@ -6,6 +6,7 @@ const (
 	SecondFactorTypeUnspecified SecondFactorType = iota
 	SecondFactorTypeOTP
 	SecondFactorTypeU2F
+	SecondFactorTypePush
 
 	secondFactorCount
 )
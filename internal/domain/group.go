@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"strings"
+
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+type Group struct {
+	es_models.ObjectRoot
+
+	Name        string
+	Description string
+	State       GroupState
+}
+
+func (g *Group) IsValid() bool {
+	return strings.TrimSpace(g.Name) != ""
+}
+
+type GroupState int32
+
+const (
+	GroupStateUnspecified GroupState = iota
+	GroupStateActive
+	GroupStateRemoved
+	groupStateCount
+)
+
+func (s GroupState) Valid() bool {
+	return s >= 0 && s < groupStateCount
+}
+
+func (s GroupState) Exists() bool {
+	return s != GroupStateUnspecified && s != GroupStateRemoved
+}
+
+// GroupGrant grants the roles of a project (or project grant)
+// to all members of the group
+type GroupGrant struct {
+	es_models.ObjectRoot
+
+	GrantID        string
+	ProjectID      string
+	ProjectGrantID string
+	RoleKeys       []string
+	State          GroupGrantState
+}
+
+func (g *GroupGrant) IsValid() bool {
+	return g.AggregateID != "" && g.ProjectID != ""
+}
+
+func (g *GroupGrant) HasInvalidRoles(validRoles []string) bool {
+	for _, roleKey := range g.RoleKeys {
+		if !containsRoleKey(roleKey, validRoles) {
+			return true
+		}
+	}
+	return false
+}
+
+type GroupGrantState int32
+
+const (
+	GroupGrantStateUnspecified GroupGrantState = iota
+	GroupGrantStateActive
+	GroupGrantStateInactive
+	GroupGrantStateRemoved
+)
+
+func (s GroupGrantState) Exists() bool {
+	return s != GroupGrantStateUnspecified && s != GroupGrantStateRemoved
+}
@@ -0,0 +1,36 @@
+package domain
+
+type UserConsent struct {
+	ClientID  string
+	AppID     string
+	ProjectID string
+	Scopes    []string
+}
+
+type ConsentState int32
+
+const (
+	ConsentStateUnspecified ConsentState = iota
+	ConsentStateActive
+	ConsentStateRevoked
+)
+
+// MissingConsentScopes returns the requested scopes which are not covered by the granted ones
+func MissingConsentScopes(granted, requested []string) []string {
+	missing := make([]string, 0)
+	for _, scope := range requested {
+		if !containsScope(granted, scope) && !containsScope(missing, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+type MagicLinkState int32
+
+const (
+	MagicLinkStateUnspecified MagicLinkState = iota
+	MagicLinkStateRequested
+	MagicLinkStateActive
+	MagicLinkStateRemoved
+)
+
+type MagicLink struct {
+	es_models.ObjectRoot
+
+	LinkID        string
+	Code          string
+	Expiration    time.Duration
+	AuthRequestID string
+	State         MagicLinkState
+}
+
+func (m *MagicLink) Link(baseURL string) string {
+	return MagicLinkURL(baseURL, m.AuthRequestID, m.AggregateID, m.ResourceOwner, m.LinkID, m.Code)
+}
+
+func MagicLinkURL(baseURL, authRequestID, userID, resourceOwner, linkID, code string) string {
+	return fmt.Sprintf("%s?authRequestID=%s&userID=%s&orgID=%s&linkID=%s&code=%s", baseURL, authRequestID, userID, resourceOwner, linkID, code)
+}
@@ -0,0 +1,22 @@
+package domain
+
+// NotificationPreferences define which security notifications a user wants to receive,
+// a notification is only sent if it's enabled in the notification policy as well
+type NotificationPreferences struct {
+	NewDeviceLogin bool
+	MFAAdded       bool
+	MFARemoved     bool
+	EmailChange    bool
+	AccountLocked  bool
+}
+
+// DefaultNotificationPreferences are used as long as the user didn't set any preferences
+func DefaultNotificationPreferences() *NotificationPreferences {
+	return &NotificationPreferences{
+		NewDeviceLogin: true,
+		MFAAdded:       true,
+		MFARemoved:     true,
+		EmailChange:    true,
+		AccountLocked:  true,
+	}
+}
@@ -0,0 +1,10 @@
+package domain
+
+type PhoneOTPState int32
+
+const (
+	PhoneOTPStateUnspecified PhoneOTPState = iota
+	PhoneOTPStateRequested
+	PhoneOTPStateActive
+	PhoneOTPStateRemoved
+)
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// PushPlatform defines the push service the notification to approve a sign-in is sent through
+type PushPlatform int32
+
+const (
+	PushPlatformUnspecified PushPlatform = iota
+	// PushPlatformFCM sends the notification through Firebase Cloud Messaging (HTTP v1 API)
+	PushPlatformFCM
+	// PushPlatformAPNs sends the notification through the Apple Push Notification service (HTTP/2 API)
+	PushPlatformAPNs
+
+	pushPlatformCount
+)
+
+func (p PushPlatform) Valid() bool {
+	return p > PushPlatformUnspecified && p < pushPlatformCount
+}
+
+type PushDeviceState int32
+
+const (
+	PushDeviceStateUnspecified PushDeviceState = iota
+	// PushDeviceStateNotReady is the state of an added device until it signed its verification code
+	PushDeviceStateNotReady
+	PushDeviceStateActive
+	PushDeviceStateRemoved
+)
+
+// PushDevice is an installation of a mobile app of the user,
+// which is able to approve sign-ins of the user
+type PushDevice struct {
+	models.ObjectRoot
+
+	DeviceID string
+	Name     string
+	Platform PushPlatform
+	// Token is the registration token (FCM) or device token (APNs) of the app installation
+	Token string
+	// PublicKey is the PKIX (DER) encoded ECDSA P-256 key of the app installation,
+	// the device signs the verification code and the answers to challenges with its private key
+	PublicKey []byte
+	// VerificationCode has to be signed by the device to activate it
+	VerificationCode string
+	State            PushDeviceState
+}
+
+func (d *PushDevice) IsValid() bool {
+	if d.Name == "" || !d.Platform.Valid() || d.Token == "" {
+		return false
+	}
+	_, err := ParsePushPublicKey(d.PublicKey)
+	return err == nil
+}
+
+// ParsePushPublicKey parses the PKIX (DER) encoded public key of a device, only ECDSA P-256 keys are supported
+// as they can be generated in the secure hardware of Android and iOS devices
+func ParsePushPublicKey(publicKey []byte) (*ecdsa.PublicKey, error) {
+	key, err := x509.ParsePKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok || ecdsaKey.Curve != elliptic.P256() {
+		return nil, x509.ErrUnsupportedAlgorithm
+	}
+	return ecdsaKey, nil
+}
+
+// VerifyPushSignature checks the ASN.1 encoded ECDSA signature of the SHA-256 hash of the payload
+func VerifyPushSignature(publicKey, payload, signature []byte) bool {
+	key, err := ParsePushPublicKey(publicKey)
+	if err != nil {
+		return false
+	}
+	hash := sha256.Sum256(payload)
+	return ecdsa.VerifyASN1(key, hash[:], signature)
+}
+
+// PushDeviceVerificationPayload is the message the device signs to complete its registration
+func PushDeviceVerificationPayload(deviceID, code string) []byte {
+	return []byte(strings.Join([]string{"zitadel-push-device-verification", deviceID, code}, ":"))
+}
+
+// PushChallengeAnswerPayload is the message the device signs to approve or deny the sign-in of a challenge
+func PushChallengeAnswerPayload(challengeID, deviceID string, approve bool) []byte {
+	answer := "deny"
+	if approve {
+		answer = "approve"
+	}
+	return []byte(strings.Join([]string{"zitadel-push-challenge-answer", challengeID, deviceID, answer}, ":"))
+}
+
+type PushChallengeState int32
+
+const (
+	PushChallengeStateUnspecified PushChallengeState = iota
+	PushChallengeStateRequested
+	PushChallengeStateApproved
+	PushChallengeStateDenied
+)
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// ImpersonationDefaultLifetime is used if no lifetime is requested for an impersonation
+	ImpersonationDefaultLifetime = 15 * time.Minute
+	// ImpersonationMaxLifetime limits the lifetime of the tokens issued for an impersonation
+	ImpersonationMaxLifetime = 2 * time.Hour
+
+	// ImpersonationScope requests the tokens of a login for the user with the id of the suffix instead of the authenticated user
+	ImpersonationScope = "urn:zitadel:iam:user:impersonate:"
+	// ImpersonationActorScope is set by ZITADEL on verified impersonations to assert the actor claim in the tokens,
+	// it must never be accepted from a client
+	ImpersonationActorScope = "urn:zitadel:iam:user:impersonator:"
+)
+
+// ImpersonationScopes are the scopes of the tokens issued for an impersonation
+var ImpersonationScopes = []string{"openid", "profile", "email"}
+
+// ImpersonatedUserFromScopes returns the id of the user requested by the ImpersonationScope, if any
+func ImpersonatedUserFromScopes(scopes []string) string {
+	return idFromScopes(scopes, ImpersonationScope)
+}
+
+// ImpersonationActorFromScopes returns the id of the user asserted by the ImpersonationActorScope, if any
+func ImpersonationActorFromScopes(scopes []string) string {
+	return idFromScopes(scopes, ImpersonationActorScope)
+}
+
+// RemoveImpersonationActorScopes removes the ImpersonationActorScope from scopes requested by clients
+func RemoveImpersonationActorScopes(scopes []string) []string {
+	filtered := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !strings.HasPrefix(scope, ImpersonationActorScope) {
+			filtered = append(filtered, scope)
+		}
+	}
+	return filtered
+}
+
+func idFromScopes(scopes []string, prefix string) string {
+	for _, scope := range scopes {
+		if strings.HasPrefix(scope, prefix) {
+			return strings.TrimPrefix(scope, prefix)
+		}
+	}
+	return ""
+}
@@ -5,6 +5,7 @@ type NotificationType int32
 const (
 	NotificationTypeEmail NotificationType = iota
 	NotificationTypeSms
+	NotificationTypePush
 
 	notificationCount
 )
@@ -35,3 +36,27 @@ const (
 
 	notificationProviderTypeCount
 )
+
+// NotificationDeliveryState is the state of a notification in the outbox
+type NotificationDeliveryState int32
+
+const (
+	NotificationDeliveryStateUnspecified NotificationDeliveryState = iota
+	// NotificationDeliveryStateDelivered means the notification was handed over to the provider
+	NotificationDeliveryStateDelivered
+	// NotificationDeliveryStateRetrying means the delivery failed or a resend was requested
+	// and the notification will be sent again at the retry date
+	NotificationDeliveryStateRetrying
+	// NotificationDeliveryStateFailed means the delivery failed and no further retries are made
+	NotificationDeliveryStateFailed
+
+	notificationDeliveryStateCount
+)
+
+func (s NotificationDeliveryState) Valid() bool {
+	return s >= 0 && s < notificationDeliveryStateCount
+}
+
+func (s NotificationDeliveryState) Exists() bool {
+	return s != NotificationDeliveryStateUnspecified
+}
@@ -9,8 +9,9 @@ import (
 type Org struct {
 	models.ObjectRoot
 
-	State OrgState
-	Name  string
+	State    OrgState
+	Name     string
+	ParentID string
 
 	PrimaryDomain string
 	Domains       []*OrgDomain
@@ -28,6 +29,10 @@ func (o *Org) AddIAMDomain(iamDomain string) {
 	o.Domains = append(o.Domains, &OrgDomain{Domain: NewIAMDomainName(o.Name, iamDomain), Verified: true, Primary: true})
 }
 
+// OrgHierarchyMaxDepth is the maximum number of ancestors of an org,
+// policies are inherited from the ancestors up to this depth
+const OrgHierarchyMaxDepth = 10
+
 type OrgState int32
 
 const (
@@ -1,5 +1,7 @@
 package domain
 
+import "context"
+
 type Permissions struct {
 	Permissions []string
 }
@@ -21,3 +23,17 @@ func (p *Permissions) appendPermission(ctxID, permission string) {
 	}
 	p.Permissions = append(p.Permissions, permission)
 }
+
+// PermissionCheck checks if the user of the context has the permission on the organisation or the specific resource
+type PermissionCheck func(ctx context.Context, permission, orgID, resourceID string) (err error)
+
+const (
+	PermissionInstanceWrite = "iam.write"
+
+	PermissionUserWrite = "user.write"
+	PermissionUserRead  = "user.read"
+	// PermissionUserImpersonate allows to receive tokens of the user
+	PermissionUserImpersonate = "user.impersonate"
+
+	PermissionProjectAppWrite = "project.app.write"
+)
@@ -32,6 +32,9 @@ type LoginPolicy struct {
 	MultiFactorCheckLifetime   time.Duration
 	DisableLoginWithEmail      bool
 	DisableLoginWithPhone      bool
+	AllowMagicLink             bool
+	AllowPhoneOTP              bool
+	BotProtection              BotProtectionType
 }
 
 func ValidateDefaultRedirectURI(rawURL string) bool {
@@ -109,6 +112,25 @@ func (f PasswordlessType) Valid() bool {
 	return f >= 0 && f < passwordlessCount
 }
 
+// BotProtectionType defines the challenge which has to be solved on registration and password reset
+type BotProtectionType int32
+
+const (
+	BotProtectionTypeNone BotProtectionType = iota
+	// BotProtectionTypeProofOfWork lets the browser compute a hash, no user interaction is needed
+	BotProtectionTypeProofOfWork
+	// BotProtectionTypeImageCaptcha shows an image with a code the user has to type
+	BotProtectionTypeImageCaptcha
+	// BotProtectionTypeExternal verifies the response of an external widget (e.g. hCaptcha or Turnstile)
+	BotProtectionTypeExternal
+
+	botProtectionCount
+)
+
+func (t BotProtectionType) Valid() bool {
+	return t >= 0 && t < botProtectionCount
+}
+
 func (p *LoginPolicy) HasSecondFactors() bool {
 	return len(p.SecondFactors) > 0
 }
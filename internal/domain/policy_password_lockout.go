@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
@@ -9,5 +11,28 @@ type LockoutPolicy struct {
 
 	Default             bool
 	MaxPasswordAttempts uint64
+	// MaxOTPAttempts limits the failed checks of second factors and passwordless (OTP, SMS code, U2F and passkeys)
+	MaxOTPAttempts      uint64
 	ShowLockOutFailures bool
+	// LockoutDuration after which a user locked by the policy is unlocked automatically, zero requires a manual unlock
+	LockoutDuration time.Duration
+}
+
+// LockedUntil returns the time the lock of a user locked now ends,
+// nil if the user has to be unlocked manually
+func (p *LockoutPolicy) LockedUntil(now time.Time) *time.Time {
+	if p == nil || p.LockoutDuration <= 0 {
+		return nil
+	}
+	until := now.Add(p.LockoutDuration)
+	return &until
 }
+
+type UserLockReason int32
+
+const (
+	// UserLockReasonUnspecified is used for users locked manually
+	UserLockReasonUnspecified UserLockReason = iota
+	UserLockReasonPasswordAttempts
+	UserLockReasonOTPAttempts
+)
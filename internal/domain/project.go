@@ -13,6 +13,8 @@ type Project struct {
 	ProjectRoleCheck       bool
 	HasProjectCheck        bool
 	PrivateLabelingSetting PrivateLabelingSetting
+	// AllowImpersonation opts the project in to tokens issued to administrators impersonating its users
+	AllowImpersonation bool
 }
 
 type ProjectState int32
@@ -47,3 +49,17 @@ func (s PrivateLabelingSetting) Valid() bool {
 func (o *Project) IsValid() bool {
 	return o.Name != ""
 }
+
+type ProjectRegistrationTokenState int32
+
+const (
+	ProjectRegistrationTokenStateUnspecified ProjectRegistrationTokenState = iota
+	ProjectRegistrationTokenStateActive
+	ProjectRegistrationTokenStateRemoved
+
+	projectRegistrationTokenStateMax
+)
+
+func (s ProjectRegistrationTokenState) Valid() bool {
+	return s > ProjectRegistrationTokenStateUnspecified && s < projectRegistrationTokenStateMax
+}
@@ -0,0 +1,22 @@
+package domain
+
+type SessionState int32
+
+const (
+	SessionStateUnspecified SessionState = iota
+	SessionStateActive
+	SessionStateTerminated
+)
+
+type IDPIntentState int32
+
+const (
+	IDPIntentStateUnspecified IDPIntentState = iota
+	IDPIntentStateStarted
+	IDPIntentStateSucceeded
+	IDPIntentStateFailed
+)
+
+func (s IDPIntentState) Exists() bool {
+	return s != IDPIntentStateUnspecified
+}
@@ -7,3 +7,39 @@ const (
 	SMTPConfigStateActive
 	SMTPConfigStateRemoved
 )
+
+// SMTPTLSMode defines how the connection to the smtp server is secured
+type SMTPTLSMode int32
+
+const (
+	// SMTPTLSModeUnspecified uses implicit tls with a fallback to STARTTLS if tls is enabled on the config
+	SMTPTLSModeUnspecified SMTPTLSMode = iota
+	// SMTPTLSModeImplicit connects using tls
+	SMTPTLSModeImplicit
+	// SMTPTLSModeStartTLSRequired upgrades the connection using STARTTLS and fails if the server doesn't support it
+	SMTPTLSModeStartTLSRequired
+	// SMTPTLSModeStartTLSOpportunistic upgrades the connection using STARTTLS if the server supports it
+	SMTPTLSModeStartTLSOpportunistic
+
+	smtpTLSModeCount
+)
+
+func (m SMTPTLSMode) Valid() bool {
+	return m >= 0 && m < smtpTLSModeCount
+}
+
+// SMTPAuthType defines how ZITADEL authenticates on the smtp server
+type SMTPAuthType int32
+
+const (
+	// SMTPAuthTypeUnspecified authenticates using PLAIN if a user and password are set
+	SMTPAuthTypeUnspecified SMTPAuthType = iota
+	// SMTPAuthTypeXOAuth2 authenticates using an access token fetched with the client credentials grant
+	SMTPAuthTypeXOAuth2
+
+	smtpAuthTypeCount
+)
+
+func (t SMTPAuthType) Valid() bool {
+	return t >= 0 && t < smtpAuthTypeCount
+}
@@ -20,6 +20,8 @@ type Token struct {
 	Expiration        time.Time
 	Scopes            []string
 	PreferredLanguage string
+	DPoPJKT           string
+	ActorUserID       string
 }
 
 func AddAudScopeToAudience(ctx context.Context, audience, scopes []string) []string {
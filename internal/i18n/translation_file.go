@@ -0,0 +1,178 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	xliffVersion = "2.0"
+)
+
+// TranslationFile contains the texts of a language exchanged with translators.
+// The ids of the units are dot separated, the first part is used as file of the XLIFF document.
+type TranslationFile struct {
+	SourceLanguage language.Tag
+	TargetLanguage language.Tag
+	Units          []*TranslationUnit
+}
+
+type TranslationUnit struct {
+	ID     string
+	Source string
+	Target string
+}
+
+type xliffDocument struct {
+	XMLName xml.Name    `xml:"urn:oasis:names:tc:xliff:document:2.0 xliff"`
+	Version string      `xml:"version,attr"`
+	SrcLang string      `xml:"srcLang,attr"`
+	TrgLang string      `xml:"trgLang,attr,omitempty"`
+	Files   []xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	ID     string       `xml:"id,attr"`
+	Groups []xliffGroup `xml:"group"`
+	Units  []xliffUnit  `xml:"unit"`
+}
+
+type xliffGroup struct {
+	ID     string       `xml:"id,attr"`
+	Groups []xliffGroup `xml:"group"`
+	Units  []xliffUnit  `xml:"unit"`
+}
+
+type xliffUnit struct {
+	ID       string         `xml:"id,attr"`
+	Segments []xliffSegment `xml:"segment"`
+}
+
+type xliffSegment struct {
+	Source string  `xml:"source"`
+	Target *string `xml:"target"`
+}
+
+// MarshalXLIFF creates an XLIFF 2.0 document of the file.
+// Units without target are exported without target element, so translation tools recognise them as untranslated.
+func MarshalXLIFF(file *TranslationFile) ([]byte, error) {
+	doc := &xliffDocument{
+		Version: xliffVersion,
+		SrcLang: file.SourceLanguage.String(),
+	}
+	if file.TargetLanguage != language.Und {
+		doc.TrgLang = file.TargetLanguage.String()
+	}
+	files := make(map[string]*xliffFile)
+	fileIDs := make([]string, 0)
+	for _, unit := range file.Units {
+		fileID, unitID, ok := strings.Cut(unit.ID, ".")
+		if !ok {
+			return nil, errors.ThrowInvalidArgument(nil, "I18N-Xl2mf", "Errors.TranslationFile.Invalid")
+		}
+		xFile, ok := files[fileID]
+		if !ok {
+			xFile = &xliffFile{ID: fileID}
+			files[fileID] = xFile
+			fileIDs = append(fileIDs, fileID)
+		}
+		segment := xliffSegment{Source: unit.Source}
+		if unit.Target != "" {
+			target := unit.Target
+			segment.Target = &target
+		}
+		xFile.Units = append(xFile.Units, xliffUnit{ID: unitID, Segments: []xliffSegment{segment}})
+	}
+	sort.Strings(fileIDs)
+	for _, fileID := range fileIDs {
+		doc.Files = append(doc.Files, *files[fileID])
+	}
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "I18N-Xl3mf", "Errors.TranslationFile.MarshalError")
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// UnmarshalXLIFF reads an XLIFF 2.0 document, units of nested groups are part of the file they are defined in.
+// The segments of a unit are joined, units without target are returned with an empty target.
+func UnmarshalXLIFF(data []byte) (*TranslationFile, error) {
+	doc := new(xliffDocument)
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(doc); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "I18N-Xl4uf", "Errors.TranslationFile.Invalid")
+	}
+	if doc.Version != xliffVersion {
+		return nil, errors.ThrowInvalidArgument(nil, "I18N-Xl5uv", "Errors.TranslationFile.XLIFFVersionUnsupported")
+	}
+	file := new(TranslationFile)
+	var err error
+	if file.SourceLanguage, err = language.Parse(doc.SrcLang); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "I18N-Xl6us", "Errors.TranslationFile.InvalidLanguage")
+	}
+	if doc.TrgLang != "" {
+		if file.TargetLanguage, err = language.Parse(doc.TrgLang); err != nil {
+			return nil, errors.ThrowInvalidArgument(err, "I18N-Xl7ut", "Errors.TranslationFile.InvalidLanguage")
+		}
+	}
+	for _, xFile := range doc.Files {
+		file.Units = appendXLIFFUnits(file.Units, xFile.ID, xFile.Groups, xFile.Units)
+	}
+	return file, nil
+}
+
+func appendXLIFFUnits(units []*TranslationUnit, fileID string, groups []xliffGroup, xUnits []xliffUnit) []*TranslationUnit {
+	for _, xUnit := range xUnits {
+		unit := &TranslationUnit{ID: fileID + "." + xUnit.ID}
+		for _, segment := range xUnit.Segments {
+			unit.Source += segment.Source
+			if segment.Target != nil {
+				unit.Target += *segment.Target
+			}
+		}
+		units = append(units, unit)
+	}
+	for _, group := range groups {
+		units = appendXLIFFUnits(units, fileID, group.Groups, group.Units)
+	}
+	return units
+}
+
+// MarshalJSON creates a flat JSON object of the ids and the targets of the units,
+// units without target are exported with an empty string.
+func MarshalJSON(file *TranslationFile) ([]byte, error) {
+	texts := make(map[string]string, len(file.Units))
+	for _, unit := range file.Units {
+		texts[unit.ID] = unit.Target
+	}
+	data, err := json.MarshalIndent(texts, "", "  ")
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "I18N-Js2mf", "Errors.TranslationFile.MarshalError")
+	}
+	return data, nil
+}
+
+// UnmarshalJSON reads a flat JSON object of ids and texts,
+// the languages aren't part of the object and must be set by the caller.
+func UnmarshalJSON(data []byte) (*TranslationFile, error) {
+	texts := make(map[string]string)
+	if err := json.Unmarshal(data, &texts); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "I18N-Js3uf", "Errors.TranslationFile.Invalid")
+	}
+	ids := make([]string, 0, len(texts))
+	for id := range texts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	file := &TranslationFile{Units: make([]*TranslationUnit, len(ids))}
+	for i, id := range ids {
+		file.Units[i] = &TranslationUnit{ID: id, Target: texts[id]}
+	}
+	return file, nil
+}
@@ -0,0 +1,124 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestXLIFF(t *testing.T) {
+	file := &TranslationFile{
+		SourceLanguage: language.English,
+		TargetLanguage: language.German,
+		Units: []*TranslationUnit{
+			{ID: "Login.Login.Title", Source: "Welcome back!", Target: "Willkommen zurück!"},
+			{ID: "Login.Login.Description", Source: "Enter your login data."},
+			{ID: "InitCode.Subject", Source: "Initialize User", Target: "User initialisieren"},
+		},
+	}
+	data, err := MarshalXLIFF(file)
+	require.NoError(t, err)
+	got, err := UnmarshalXLIFF(data)
+	require.NoError(t, err)
+	assert.Equal(t, &TranslationFile{
+		SourceLanguage: language.English,
+		TargetLanguage: language.German,
+		Units: []*TranslationUnit{
+			{ID: "InitCode.Subject", Source: "Initialize User", Target: "User initialisieren"},
+			{ID: "Login.Login.Title", Source: "Welcome back!", Target: "Willkommen zurück!"},
+			{ID: "Login.Login.Description", Source: "Enter your login data."},
+		},
+	}, got)
+}
+
+func TestUnmarshalXLIFF(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *TranslationFile
+		wantErr func(error) bool
+	}{
+		{
+			name:    "no xml, error",
+			data:    `{"Login.Login.Title": "Willkommen zurück!"}`,
+			wantErr: errors.IsErrorInvalidArgument,
+		},
+		{
+			name:    "xliff 1.2, error",
+			data:    `<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" version="1.2" srcLang="en"></xliff>`,
+			wantErr: errors.IsErrorInvalidArgument,
+		},
+		{
+			name:    "invalid language, error",
+			data:    `<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" version="2.0" srcLang="english"></xliff>`,
+			wantErr: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "groups and segments, ok",
+			data: `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" version="2.0" srcLang="en" trgLang="pt-BR">
+  <file id="Login">
+    <group id="screens">
+      <group id="login">
+        <unit id="Login.Title">
+          <notes><note>Title of the login screen</note></notes>
+          <segment><source>Welcome </source><target>Bem-vindo </target></segment>
+          <segment><source>back!</source><target>de volta!</target></segment>
+        </unit>
+      </group>
+    </group>
+    <unit id="Login.Description">
+      <segment><source>Enter your login data.</source></segment>
+    </unit>
+  </file>
+</xliff>`,
+			want: &TranslationFile{
+				SourceLanguage: language.English,
+				TargetLanguage: language.MustParse("pt-BR"),
+				Units: []*TranslationUnit{
+					{ID: "Login.Login.Description", Source: "Enter your login data."},
+					{ID: "Login.Login.Title", Source: "Welcome back!", Target: "Bem-vindo de volta!"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalXLIFF([]byte(tt.data))
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	file := &TranslationFile{
+		Units: []*TranslationUnit{
+			{ID: "Login.Login.Title", Source: "Welcome back!", Target: "Willkommen zurück!"},
+			{ID: "InitCode.Subject", Source: "Initialize User"},
+		},
+	}
+	data, err := MarshalJSON(file)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"InitCode.Subject": "", "Login.Login.Title": "Willkommen zurück!"}`, string(data))
+
+	got, err := UnmarshalJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, &TranslationFile{
+		Units: []*TranslationUnit{
+			{ID: "InitCode.Subject"},
+			{ID: "Login.Login.Title", Target: "Willkommen zurück!"},
+		},
+	}, got)
+
+	_, err = UnmarshalJSON([]byte(`{"Login": {"Login": {"Title": "Willkommen zurück!"}}}`))
+	assert.True(t, errors.IsErrorInvalidArgument(err))
+}
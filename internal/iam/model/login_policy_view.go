@@ -108,6 +108,8 @@ func secondFactorsToDomain(types []domain.SecondFactorType) []domain.SecondFacto
 			secondfactors[i] = domain.SecondFactorTypeU2F
 		case domain.SecondFactorTypeOTP:
 			secondfactors[i] = domain.SecondFactorTypeOTP
+		case domain.SecondFactorTypePush:
+			secondfactors[i] = domain.SecondFactorTypePush
 		}
 	}
 	return secondfactors
@@ -41,6 +41,8 @@ func InitFSChannel(config Config) (channels.NotificationChannel, error) {
 			}
 		case *messages.SMS:
 			fileName = fileName + "sms_to_" + msg.RecipientPhoneNumber + ".txt"
+		case *messages.Push:
+			fileName = fileName + "push_to_" + msg.DeviceID + ".txt"
 		case *messages.JSON:
 			fileName = "message.json"
 		default:
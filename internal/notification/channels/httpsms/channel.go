@@ -0,0 +1,92 @@
+package httpsms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+// templateData is passed to the body template of the request,
+// e.g. {"to": {{json .RecipientNumber}}, "text": {{json .Content}}}
+type templateData struct {
+	RecipientNumber string
+	Content         string
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+func InitChannel(ctx context.Context, cfg Config) (channels.NotificationChannel, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	bodyTemplate, err := parseBodyTemplate(cfg.BodyTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.Debug("successfully initialized http sms channel")
+	return channels.HandleMessageFunc(func(message channels.Message) error {
+		requestCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		sms, ok := message.(*messages.SMS)
+		if !ok {
+			return caos_errs.ThrowInternal(nil, "HTTPSMS-Sfw4a", "message is not SMS")
+		}
+		content, err := sms.GetContent()
+		if err != nil {
+			return err
+		}
+		body := new(strings.Builder)
+		err = bodyTemplate.Execute(body, &templateData{
+			RecipientNumber: sms.RecipientPhoneNumber,
+			Content:         content,
+		})
+		if err != nil {
+			return caos_errs.ThrowInternal(err, "HTTPSMS-Sfw4b", "could not execute body template")
+		}
+
+		req, err := http.NewRequestWithContext(requestCtx, cfg.Method, cfg.Endpoint, strings.NewReader(body.String()))
+		if err != nil {
+			return err
+		}
+		for key, value := range cfg.Headers {
+			req.Header.Set(key, value)
+		}
+		if cfg.APIKeyHeader != "" && cfg.APIKey != "" {
+			req.Header.Set(cfg.APIKeyHeader, cfg.APIKey)
+		}
+		if cfg.Username != "" || cfg.Password != "" {
+			req.SetBasicAuth(cfg.Username, cfg.Password)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return caos_errs.ThrowInternal(err, "HTTPSMS-Sfw4c", "could not send message")
+		}
+		if err = resp.Body.Close(); err != nil {
+			return err
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return caos_errs.ThrowUnknown(fmt.Errorf("calling url %s returned %s", cfg.Endpoint, resp.Status), "HTTPSMS-Sfw4d", "sms gateway didn't return a success status")
+		}
+
+		logging.WithFields("endpoint", cfg.Endpoint, "method", cfg.Method).Debug("sms sent")
+		return nil
+	}), nil
+}
@@ -0,0 +1,226 @@
+package httpsms
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+type stubRequest struct {
+	method string
+	header http.Header
+	body   string
+}
+
+// newStub starts an sms gateway stub which records the requests and answers with the status
+func newStub(t *testing.T, status int) (*httptest.Server, *[]*stubRequest) {
+	requests := make([]*stubRequest, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, &stubRequest{method: r.Method, header: r.Header.Clone(), body: string(body)})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestChannel(t *testing.T) {
+	type args struct {
+		cfg Config
+		sms *messages.SMS
+	}
+	type res struct {
+		method   string
+		body     string
+		header   map[string]string
+		username string
+		password string
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "json body, api key",
+			args: args{
+				cfg: Config{
+					Method:       http.MethodPost,
+					Headers:      map[string]string{"Content-Type": "application/json"},
+					BodyTemplate: `{"to":{{json .RecipientNumber}},"text":{{json .Content}}}`,
+					APIKeyHeader: "X-API-Key",
+					APIKey:       "secret",
+				},
+				sms: &messages.SMS{
+					RecipientPhoneNumber: "+41791234567",
+					Content:              `Your code is "123456"`,
+				},
+			},
+			res: res{
+				method: http.MethodPost,
+				body:   `{"to":"+41791234567","text":"Your code is \"123456\""}`,
+				header: map[string]string{"Content-Type": "application/json", "X-API-Key": "secret"},
+			},
+		},
+		{
+			name: "form body, basic auth",
+			args: args{
+				cfg: Config{
+					Method:       http.MethodPut,
+					Headers:      map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+					BodyTemplate: `to={{.RecipientNumber}}&text={{.Content}}`,
+					Username:     "user",
+					Password:     "password",
+				},
+				sms: &messages.SMS{
+					RecipientPhoneNumber: "+41791234567",
+					Content:              "123456",
+				},
+			},
+			res: res{
+				method:   http.MethodPut,
+				body:     `to=+41791234567&text=123456`,
+				header:   map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+				username: "user",
+				password: "password",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newStub(t, http.StatusAccepted)
+			tt.args.cfg.Endpoint = server.URL
+			channel, err := InitChannel(context.Background(), tt.args.cfg)
+			require.NoError(t, err)
+
+			require.NoError(t, channel.HandleMessage(tt.args.sms))
+
+			require.Len(t, *requests, 1)
+			req := (*requests)[0]
+			assert.Equal(t, tt.res.method, req.method)
+			assert.Equal(t, tt.res.body, req.body)
+			for key, value := range tt.res.header {
+				assert.Equal(t, value, req.header.Get(key), key)
+			}
+			username, password, ok := (&http.Request{Header: req.header}).BasicAuth()
+			assert.Equal(t, tt.res.username != "", ok)
+			assert.Equal(t, tt.res.username, username)
+			assert.Equal(t, tt.res.password, password)
+		})
+	}
+}
+
+func TestChannel_errorStatus(t *testing.T) {
+	server, requests := newStub(t, http.StatusUnauthorized)
+	channel, err := InitChannel(context.Background(), Config{
+		Endpoint:     server.URL,
+		Method:       http.MethodPost,
+		BodyTemplate: `{{.Content}}`,
+	})
+	require.NoError(t, err)
+
+	err = channel.HandleMessage(&messages.SMS{RecipientPhoneNumber: "+41791234567", Content: "123456"})
+	assert.True(t, caos_errs.IsUnknown(err))
+	assert.Contains(t, err.Error(), "401")
+	assert.Len(t, *requests, 1)
+}
+
+func TestChannel_unreachable(t *testing.T) {
+	server, _ := newStub(t, http.StatusOK)
+	server.Close()
+	channel, err := InitChannel(context.Background(), Config{
+		Endpoint:     server.URL,
+		Method:       http.MethodPost,
+		BodyTemplate: `{{.Content}}`,
+	})
+	require.NoError(t, err)
+
+	err = channel.HandleMessage(&messages.SMS{RecipientPhoneNumber: "+41791234567", Content: "123456"})
+	assert.True(t, caos_errs.IsInternal(err))
+}
+
+func TestChannel_notSMS(t *testing.T) {
+	server, requests := newStub(t, http.StatusOK)
+	channel, err := InitChannel(context.Background(), Config{
+		Endpoint:     server.URL,
+		Method:       http.MethodPost,
+		BodyTemplate: `{{.Content}}`,
+	})
+	require.NoError(t, err)
+
+	err = channel.HandleMessage(&messages.JSON{Serializable: "123456"})
+	assert.True(t, caos_errs.IsInternal(err))
+	assert.Empty(t, *requests)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			config: Config{
+				Endpoint:     "https://sms.example.com/send",
+				Method:       http.MethodPost,
+				BodyTemplate: `{{json .Content}}`,
+				APIKeyHeader: "Authorization",
+				APIKey:       "Bearer secret",
+			},
+		},
+		{
+			name: "endpoint without scheme",
+			config: Config{
+				Endpoint: "sms.example.com/send",
+				Method:   http.MethodPost,
+			},
+			wantErr: true,
+		},
+		{
+			name: "method not allowed",
+			config: Config{
+				Endpoint: "https://sms.example.com/send",
+				Method:   http.MethodDelete,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid body template",
+			config: Config{
+				Endpoint:     "https://sms.example.com/send",
+				Method:       http.MethodPost,
+				BodyTemplate: `{{.Content`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "api key without header",
+			config: Config{
+				Endpoint: "https://sms.example.com/send",
+				Method:   http.MethodPost,
+				APIKey:   "secret",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.True(t, caos_errs.IsErrorInvalidArgument(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
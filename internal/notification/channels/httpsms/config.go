@@ -0,0 +1,45 @@
+package httpsms
+
+import (
+	"net/http"
+	"net/url"
+	"text/template"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+type Config struct {
+	Endpoint string
+	Method   string
+	// Headers are stored in clear text, secrets have to be set as APIKey
+	Headers      map[string]string
+	BodyTemplate string
+	Username     string
+	Password     string
+	// APIKeyHeader is the name of the header the APIKey is sent in (e.g. Authorization or X-API-Key)
+	APIKeyHeader string
+	APIKey       string
+}
+
+func (c *Config) Validate() error {
+	endpoint, err := url.Parse(c.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return caos_errs.ThrowInvalidArgument(err, "HTTPSMS-Sfw3a", "Errors.SMSConfig.HTTP.InvalidEndpoint")
+	}
+	switch c.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodGet:
+	default:
+		return caos_errs.ThrowInvalidArgument(nil, "HTTPSMS-Sfw3b", "Errors.SMSConfig.HTTP.InvalidMethod")
+	}
+	if _, err := parseBodyTemplate(c.BodyTemplate); err != nil {
+		return caos_errs.ThrowInvalidArgument(err, "HTTPSMS-Sfw3c", "Errors.SMSConfig.HTTP.InvalidBodyTemplate")
+	}
+	if c.APIKey != "" && c.APIKeyHeader == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "HTTPSMS-Sfw3d", "Errors.SMSConfig.HTTP.APIKeyHeaderMissing")
+	}
+	return nil
+}
+
+func parseBodyTemplate(body string) (*template.Template, error) {
+	return template.New("body").Funcs(templateFuncs).Option("missingkey=error").Parse(body)
+}
@@ -0,0 +1,137 @@
+package push
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+// apnsTokenLifetime is below the hour after which APNs rejects a provider token
+// and above the 20 minutes in which APNs rejects a refreshed token
+const apnsTokenLifetime = 50 * time.Minute
+
+// apnsTokens caches the signed provider tokens per team and key
+var apnsTokens = struct {
+	sync.Mutex
+	tokens map[string]*apnsToken
+}{
+	tokens: make(map[string]*apnsToken),
+}
+
+type apnsToken struct {
+	token    string
+	issuedAt time.Time
+}
+
+// apnsPayload is the body of a notification request of the APNs HTTP/2 API
+// https://developer.apple.com/documentation/usernotifications/setting_up_a_remote_notification_server/generating_a_remote_notification
+type apnsPayload map[string]interface{}
+
+type apnsAps struct {
+	Alert    *apnsAlert `json:"alert"`
+	Sound    string     `json:"sound,omitempty"`
+	Category string     `json:"category,omitempty"`
+}
+
+type apnsAlert struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+// apnsCategory allows the app to register actions (e.g. approve and deny) for the notification
+const apnsCategory = "ZITADEL_MFA_PUSH"
+
+func (c *APNsConfig) request(ctx context.Context, msg *messages.Push) (*http.Request, error) {
+	payload := apnsPayload{}
+	for key, value := range msg.Data {
+		payload[key] = value
+	}
+	payload["aps"] = &apnsAps{
+		Alert: &apnsAlert{
+			Title: msg.Title,
+			Body:  msg.Body,
+		},
+		Sound:    "default",
+		Category: apnsCategory,
+	}
+	req, err := jsonRequest(ctx, c.endpoint()+"/3/device/"+url.PathEscape(msg.DeviceToken), payload)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("apns-topic", c.Topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+	if !msg.Expiration.IsZero() {
+		req.Header.Set("apns-expiration", strconv.FormatInt(msg.Expiration.Unix(), 10))
+	}
+	if c.PrivateKey == "" {
+		return req, nil
+	}
+	token, err := c.providerToken()
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "bearer "+token)
+	return req, nil
+}
+
+// providerToken returns the JWT used to authorize against APNs,
+// which is signed with the private key of the team (ES256)
+func (c *APNsConfig) providerToken() (string, error) {
+	cacheKey := c.TeamID + "\x00" + c.KeyID
+	apnsTokens.Lock()
+	defer apnsTokens.Unlock()
+	if cached, ok := apnsTokens.tokens[cacheKey]; ok && time.Since(cached.issuedAt) < apnsTokenLifetime {
+		return cached.token, nil
+	}
+	key, err := parseAPNsKey(c.PrivateKey)
+	if err != nil {
+		return "", caos_errs.ThrowInvalidArgument(err, "PUSH-Ap6ta", "Errors.Notification.Push.APNsKeyInvalid")
+	}
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: key},
+		(&jose.SignerOptions{}).WithHeader("kid", c.KeyID),
+	)
+	if err != nil {
+		return "", caos_errs.ThrowInternal(err, "PUSH-Ap6tb", "could not create apns signer")
+	}
+	issuedAt := time.Now()
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{
+		Issuer:   c.TeamID,
+		IssuedAt: jwt.NewNumericDate(issuedAt),
+	}).CompactSerialize()
+	if err != nil {
+		return "", caos_errs.ThrowInternal(err, "PUSH-Ap6tc", "could not sign apns provider token")
+	}
+	apnsTokens.tokens[cacheKey] = &apnsToken{token: token, issuedAt: issuedAt}
+	return token, nil
+}
+
+func parseAPNsKey(privateKey string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKey))
+	if block == nil {
+		return nil, errors.New("no pem block found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("key is no ecdsa key")
+	}
+	return ecKey, nil
+}
@@ -0,0 +1,89 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+const (
+	requestTimeout = 5 * time.Second
+	// maxErrorBodySize limits the response body which is added to the error of a failed request
+	maxErrorBodySize = 1024
+)
+
+func InitChannel(ctx context.Context, cfg Config) (channels.NotificationChannel, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	logging.Debug("successfully initialized push channel")
+	return channels.HandleMessageFunc(func(message channels.Message) error {
+		requestCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+
+		msg, ok := message.(*messages.Push)
+		if !ok {
+			return caos_errs.ThrowInternal(nil, "PUSH-Hm4pa", "message is not push")
+		}
+		var (
+			req *http.Request
+			err error
+		)
+		switch msg.Platform {
+		case domain.PushPlatformFCM:
+			if !cfg.FCM.Enabled() {
+				return caos_errs.ThrowPreconditionFailed(nil, "PUSH-Hm4pb", "Errors.Notification.Push.PlatformNotConfigured")
+			}
+			req, err = cfg.FCM.request(requestCtx, msg)
+		case domain.PushPlatformAPNs:
+			if !cfg.APNs.Enabled() {
+				return caos_errs.ThrowPreconditionFailed(nil, "PUSH-Hm4pc", "Errors.Notification.Push.PlatformNotConfigured")
+			}
+			req, err = cfg.APNs.request(requestCtx, msg)
+		default:
+			return caos_errs.ThrowInvalidArgument(nil, "PUSH-Hm4pd", "Errors.Notification.Push.PlatformNotConfigured")
+		}
+		if err != nil {
+			return err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return caos_errs.ThrowInternal(err, "PUSH-Hm4pe", "could not send push notification")
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+			// the url is not part of the error as it might contain the device token
+			return caos_errs.ThrowUnknown(fmt.Errorf("push service returned %s: %s", resp.Status, body), "PUSH-Hm4pf", "push service didn't return a success status")
+		}
+
+		logging.WithFields("platform", msg.Platform, "device", msg.DeviceID).Debug("push notification sent")
+		return nil
+	}), nil
+}
+
+func jsonRequest(ctx context.Context, url string, body interface{}) (*http.Request, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "PUSH-Hm4pg", "could not marshal push notification")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "PUSH-Hm4ph", "could not create push request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
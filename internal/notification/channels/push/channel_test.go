@@ -0,0 +1,332 @@
+package push
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+type stubRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   []byte
+}
+
+// newStub starts a push service stub which records the requests and answers with the status
+func newStub(t *testing.T, status int, response string) (*httptest.Server, *[]*stubRequest) {
+	requests := make([]*stubRequest, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, &stubRequest{method: r.Method, path: r.URL.EscapedPath(), header: r.Header.Clone(), body: body})
+		if r.URL.Path == "/token" {
+			form, err := url.ParseQuery(string(body))
+			require.NoError(t, err)
+			assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", form.Get("grant_type"))
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token":"fcm-access-token","token_type":"Bearer","expires_in":3600}`))
+			return
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func testPush(platform domain.PushPlatform) *messages.Push {
+	return &messages.Push{
+		Platform:    platform,
+		DeviceID:    "device1",
+		DeviceToken: "token/1",
+		Title:       "Sign-in request",
+		Body:        "Approve the sign-in",
+		Data:        map[string]string{"challengeId": "challenge1"},
+		Expiration:  time.Now().Add(time.Minute),
+	}
+}
+
+func TestChannel_FCM(t *testing.T) {
+	server, requests := newStub(t, http.StatusOK, `{"name":"projects/project1/messages/1"}`)
+	channel, err := InitChannel(context.Background(), Config{
+		FCM: FCMConfig{
+			Endpoint:          server.URL,
+			ProjectID:         "project1",
+			ServiceAccountKey: testServiceAccountKey(t, server.URL+"/token"),
+		},
+	})
+	require.NoError(t, err)
+
+	msg := testPush(domain.PushPlatformFCM)
+	require.NoError(t, channel.HandleMessage(msg))
+
+	require.Len(t, *requests, 2)
+	assert.Equal(t, "/token", (*requests)[0].path)
+	req := (*requests)[1]
+	assert.Equal(t, http.MethodPost, req.method)
+	assert.Equal(t, "/v1/projects/project1/messages:send", req.path)
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, "Bearer fcm-access-token", req.header.Get("Authorization"))
+
+	body := new(fcmRequest)
+	require.NoError(t, json.Unmarshal(req.body, body))
+	assert.Equal(t, "token/1", body.Message.Token)
+	assert.Equal(t, &fcmNotification{Title: msg.Title, Body: msg.Body}, body.Message.Notification)
+	assert.Equal(t, msg.Data, body.Message.Data)
+	assert.Equal(t, "high", body.Message.Android.Priority)
+	ttl, err := strconv.Atoi(strings.TrimSuffix(body.Message.Android.TTL, "s"))
+	require.NoError(t, err)
+	assert.InDelta(t, 60, ttl, 2)
+
+	// the access token is reused for the next message
+	require.NoError(t, channel.HandleMessage(msg))
+	require.Len(t, *requests, 3)
+	assert.Equal(t, "/v1/projects/project1/messages:send", (*requests)[2].path)
+}
+
+func TestChannel_FCM_unauthorized(t *testing.T) {
+	server, requests := newStub(t, http.StatusOK, `{}`)
+	channel, err := InitChannel(context.Background(), Config{
+		FCM: FCMConfig{
+			Endpoint:  server.URL,
+			ProjectID: "project1",
+		},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, channel.HandleMessage(testPush(domain.PushPlatformFCM)))
+	require.Len(t, *requests, 1)
+	assert.Empty(t, (*requests)[0].header.Get("Authorization"))
+}
+
+func TestChannel_FCM_invalidKey(t *testing.T) {
+	server, requests := newStub(t, http.StatusOK, `{}`)
+	channel, err := InitChannel(context.Background(), Config{
+		FCM: FCMConfig{
+			Endpoint:          server.URL,
+			ProjectID:         "project1",
+			ServiceAccountKey: "invalid",
+		},
+	})
+	require.NoError(t, err)
+
+	err = channel.HandleMessage(testPush(domain.PushPlatformFCM))
+	assert.True(t, caos_errs.IsErrorInvalidArgument(err))
+	assert.Empty(t, *requests)
+}
+
+func TestChannel_APNs(t *testing.T) {
+	server, requests := newStub(t, http.StatusOK, "")
+	key, privateKey := testAPNsKey(t)
+	channel, err := InitChannel(context.Background(), Config{
+		APNs: APNsConfig{
+			Endpoint:   server.URL,
+			Topic:      "com.example.app",
+			TeamID:     "team-apns",
+			KeyID:      "key1",
+			PrivateKey: privateKey,
+		},
+	})
+	require.NoError(t, err)
+
+	msg := testPush(domain.PushPlatformAPNs)
+	require.NoError(t, channel.HandleMessage(msg))
+
+	require.Len(t, *requests, 1)
+	req := (*requests)[0]
+	assert.Equal(t, http.MethodPost, req.method)
+	assert.Equal(t, "/3/device/token%2F1", req.path)
+	assert.Equal(t, "com.example.app", req.header.Get("apns-topic"))
+	assert.Equal(t, "alert", req.header.Get("apns-push-type"))
+	assert.Equal(t, "10", req.header.Get("apns-priority"))
+	assert.Equal(t, strconv.FormatInt(msg.Expiration.Unix(), 10), req.header.Get("apns-expiration"))
+
+	authorization := req.header.Get("Authorization")
+	require.True(t, strings.HasPrefix(authorization, "bearer "))
+	token, err := jwt.ParseSigned(strings.TrimPrefix(authorization, "bearer "))
+	require.NoError(t, err)
+	require.Len(t, token.Headers, 1)
+	assert.Equal(t, "ES256", token.Headers[0].Algorithm)
+	assert.Equal(t, "key1", token.Headers[0].KeyID)
+	claims := new(jwt.Claims)
+	require.NoError(t, token.Claims(&key.PublicKey, claims))
+	assert.Equal(t, "team-apns", claims.Issuer)
+	assert.WithinDuration(t, time.Now(), claims.IssuedAt.Time(), time.Minute)
+
+	payload := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(req.body, &payload))
+	assert.Equal(t, "challenge1", payload["challengeId"])
+	assert.Equal(t, map[string]interface{}{
+		"alert": map[string]interface{}{
+			"title": msg.Title,
+			"body":  msg.Body,
+		},
+		"sound":    "default",
+		"category": apnsCategory,
+	}, payload["aps"])
+
+	// the provider token is reused for the next message
+	require.NoError(t, channel.HandleMessage(msg))
+	require.Len(t, *requests, 2)
+	assert.Equal(t, authorization, (*requests)[1].header.Get("Authorization"))
+}
+
+func TestChannel_errors(t *testing.T) {
+	type args struct {
+		status   int
+		response string
+		msg      *messages.Push
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr func(error) bool
+		wantMsg string
+	}{
+		{
+			name: "fcm error status",
+			args: args{
+				status:   http.StatusNotFound,
+				response: `{"error":{"status":"NOT_FOUND","message":"Requested entity was not found."}}`,
+				msg:      testPush(domain.PushPlatformFCM),
+			},
+			wantErr: caos_errs.IsUnknown,
+			wantMsg: "NOT_FOUND",
+		},
+		{
+			name: "apns error status",
+			args: args{
+				status:   http.StatusGone,
+				response: `{"reason":"Unregistered"}`,
+				msg:      testPush(domain.PushPlatformAPNs),
+			},
+			wantErr: caos_errs.IsUnknown,
+			wantMsg: "Unregistered",
+		},
+		{
+			name: "unspecified platform",
+			args: args{
+				status: http.StatusOK,
+				msg:    testPush(domain.PushPlatformUnspecified),
+			},
+			wantErr: caos_errs.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newStub(t, tt.args.status, tt.args.response)
+			channel, err := InitChannel(context.Background(), Config{
+				FCM:  FCMConfig{Endpoint: server.URL, ProjectID: "project1"},
+				APNs: APNsConfig{Endpoint: server.URL, Topic: "com.example.app"},
+			})
+			require.NoError(t, err)
+
+			err = channel.HandleMessage(tt.args.msg)
+			require.Error(t, err)
+			assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+			assert.Contains(t, err.Error(), tt.wantMsg)
+			assert.NotContains(t, err.Error(), tt.args.msg.DeviceToken, "device token must not be part of the error")
+		})
+	}
+}
+
+func TestChannel_platformNotConfigured(t *testing.T) {
+	server, requests := newStub(t, http.StatusOK, "")
+	channel, err := InitChannel(context.Background(), Config{
+		FCM: FCMConfig{Endpoint: server.URL, ProjectID: "project1"},
+	})
+	require.NoError(t, err)
+
+	err = channel.HandleMessage(testPush(domain.PushPlatformAPNs))
+	assert.True(t, caos_errs.IsPreconditionFailed(err))
+	assert.Empty(t, *requests)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	_, privateKey := testAPNsKey(t)
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name:   "fcm default endpoint",
+			config: Config{FCM: FCMConfig{ProjectID: "project1"}},
+		},
+		{
+			name:    "fcm invalid endpoint",
+			config:  Config{FCM: FCMConfig{ProjectID: "project1", Endpoint: "localhost"}},
+			wantErr: true,
+		},
+		{
+			name:   "apns with key",
+			config: Config{APNs: APNsConfig{Topic: "com.example.app", TeamID: "team", KeyID: "key", PrivateKey: privateKey}},
+		},
+		{
+			name:    "apns key without team",
+			config:  Config{APNs: APNsConfig{Topic: "com.example.app", KeyID: "key", PrivateKey: privateKey}},
+			wantErr: true,
+		},
+		{
+			name:    "apns invalid key",
+			config:  Config{APNs: APNsConfig{Topic: "com.example.app", TeamID: "team", KeyID: "key", PrivateKey: "invalid"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.True(t, caos_errs.IsErrorInvalidArgument(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func testServiceAccountKey(t *testing.T, tokenURI string) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	serviceAccountKey, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "project1",
+		"private_key_id": "key1",
+		"private_key":    string(privateKey),
+		"client_email":   "push@project1.iam.gserviceaccount.com",
+		"token_uri":      tokenURI,
+	})
+	require.NoError(t, err)
+	return string(serviceAccountKey)
+}
+
+func testAPNsKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
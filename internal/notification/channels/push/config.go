@@ -0,0 +1,95 @@
+package push
+
+import (
+	"net/url"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	defaultFCMEndpoint  = "https://fcm.googleapis.com"
+	defaultAPNsEndpoint = "https://api.push.apple.com"
+)
+
+// Config of the push services the notifications to approve sign-ins are sent through,
+// a service is disabled if its config is empty
+type Config struct {
+	FCM  FCMConfig
+	APNs APNsConfig
+}
+
+// FCMConfig is used to send messages through the HTTP v1 API of Firebase Cloud Messaging
+type FCMConfig struct {
+	// Endpoint defaults to https://fcm.googleapis.com, it can be changed to test against a local stub
+	Endpoint  string
+	ProjectID string
+	// ServiceAccountKey is the JSON key of a service account which is allowed to send messages,
+	// the requests are not authorized if it's empty (e.g. for a local stub)
+	ServiceAccountKey string
+}
+
+// APNsConfig is used to send notifications through the HTTP/2 API of the Apple Push Notification service
+type APNsConfig struct {
+	// Endpoint defaults to https://api.push.apple.com,
+	// use https://api.sandbox.push.apple.com for development builds of the app or a local stub for testing
+	Endpoint string
+	// Topic is the bundle ID of the app
+	Topic  string
+	TeamID string
+	KeyID  string
+	// PrivateKey is the PEM encoded signing key (.p8 file) of the KeyID,
+	// the requests are not authorized if it's empty (e.g. for a local stub)
+	PrivateKey string
+}
+
+func (c *FCMConfig) Enabled() bool {
+	return c.ProjectID != ""
+}
+
+func (c *FCMConfig) endpoint() string {
+	if c.Endpoint == "" {
+		return defaultFCMEndpoint
+	}
+	return c.Endpoint
+}
+
+func (c *APNsConfig) Enabled() bool {
+	return c.Topic != ""
+}
+
+func (c *APNsConfig) endpoint() string {
+	if c.Endpoint == "" {
+		return defaultAPNsEndpoint
+	}
+	return c.Endpoint
+}
+
+func (c *Config) Enabled() bool {
+	return c.FCM.Enabled() || c.APNs.Enabled()
+}
+
+func (c *Config) Validate() error {
+	if c.FCM.Enabled() && !validEndpoint(c.FCM.endpoint()) {
+		return caos_errs.ThrowInvalidArgument(nil, "PUSH-Fk3na", "Errors.Notification.Push.InvalidEndpoint")
+	}
+	if c.APNs.Enabled() {
+		if !validEndpoint(c.APNs.endpoint()) {
+			return caos_errs.ThrowInvalidArgument(nil, "PUSH-Fk3nb", "Errors.Notification.Push.InvalidEndpoint")
+		}
+		if c.APNs.PrivateKey == "" {
+			return nil
+		}
+		if c.APNs.TeamID == "" || c.APNs.KeyID == "" {
+			return caos_errs.ThrowInvalidArgument(nil, "PUSH-Fk3nc", "Errors.Notification.Push.APNsKeyInvalid")
+		}
+		if _, err := parseAPNsKey(c.APNs.PrivateKey); err != nil {
+			return caos_errs.ThrowInvalidArgument(err, "PUSH-Fk3nd", "Errors.Notification.Push.APNsKeyInvalid")
+		}
+	}
+	return nil
+}
+
+func validEndpoint(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
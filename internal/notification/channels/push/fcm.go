@@ -0,0 +1,108 @@
+package push
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+const (
+	fcmScope               = "https://www.googleapis.com/auth/firebase.messaging"
+	fcmTokenRequestTimeout = 10 * time.Second
+)
+
+// fcmTokenSources caches the token sources of the service account keys,
+// so the access tokens are reused until they expire
+var fcmTokenSources = struct {
+	sync.Mutex
+	sources map[string]oauth2.TokenSource
+}{
+	sources: make(map[string]oauth2.TokenSource),
+}
+
+// fcmRequest is the body of the send request of the FCM HTTP v1 API
+// https://firebase.google.com/docs/reference/fcm/rest/v1/projects.messages/send
+type fcmRequest struct {
+	Message *fcmMessage `json:"message"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification *fcmNotification  `json:"notification,omitempty"`
+	Data         map[string]string `json:"data,omitempty"`
+	Android      *fcmAndroidConfig `json:"android,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+type fcmAndroidConfig struct {
+	Priority string `json:"priority"`
+	TTL      string `json:"ttl,omitempty"`
+}
+
+func (c *FCMConfig) request(ctx context.Context, msg *messages.Push) (*http.Request, error) {
+	android := &fcmAndroidConfig{Priority: "high"}
+	if !msg.Expiration.IsZero() {
+		ttl := time.Until(msg.Expiration)
+		if ttl < 0 {
+			ttl = 0
+		}
+		android.TTL = strconv.FormatInt(int64(ttl.Seconds()), 10) + "s"
+	}
+	req, err := jsonRequest(ctx, c.endpoint()+"/v1/projects/"+url.PathEscape(c.ProjectID)+"/messages:send", &fcmRequest{
+		Message: &fcmMessage{
+			Token: msg.DeviceToken,
+			Notification: &fcmNotification{
+				Title: msg.Title,
+				Body:  msg.Body,
+			},
+			Data:    msg.Data,
+			Android: android,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if c.ServiceAccountKey == "" {
+		return req, nil
+	}
+	source, err := c.tokenSource()
+	if err != nil {
+		return nil, err
+	}
+	token, err := source.Token()
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "PUSH-Fc5ta", "could not get fcm access token")
+	}
+	token.SetAuthHeader(req)
+	return req, nil
+}
+
+func (c *FCMConfig) tokenSource() (oauth2.TokenSource, error) {
+	fcmTokenSources.Lock()
+	defer fcmTokenSources.Unlock()
+	if source, ok := fcmTokenSources.sources[c.ServiceAccountKey]; ok {
+		return source, nil
+	}
+	jwtConfig, err := google.JWTConfigFromJSON([]byte(c.ServiceAccountKey), fcmScope)
+	if err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "PUSH-Fc5tb", "Errors.Notification.Push.FCMKeyInvalid")
+	}
+	// the token source is reused for later messages, so it must not be bound to the context of a message
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: fcmTokenRequestTimeout})
+	source := jwtConfig.TokenSource(ctx)
+	fcmTokenSources.sources[c.ServiceAccountKey] = source
+	return source, nil
+}
@@ -1,14 +1,15 @@
 package smtp
 
 import (
-	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"net"
 	"net/smtp"
 
 	"github.com/pkg/errors"
 	"github.com/zitadel/logging"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/notification/messages"
@@ -17,33 +18,34 @@ import (
 var _ channels.NotificationChannel = (*Email)(nil)
 
 type Email struct {
-	smtpClient    *smtp.Client
+	smtpConfig    *Config
 	senderAddress string
 	senderName    string
 }
 
-func InitChannel(ctx context.Context, getSMTPConfig func(ctx context.Context) (*Config, error)) (*Email, error) {
-	smtpConfig, err := getSMTPConfig(ctx)
-	if err != nil {
-		return nil, err
-	}
-	client, err := smtpConfig.SMTP.connectToSMTP(smtpConfig.Tls)
-	if err != nil {
-		logging.New().WithError(err).Error("could not connect to smtp")
-		return nil, err
-	}
-
+// InitChannel creates a channel for the given config,
+// the connection to the smtp server is established when a message is handled
+func InitChannel(smtpConfig *Config) *Email {
 	logging.New().Debug("successfully initialized smtp email channel")
 
 	return &Email{
-		smtpClient:    client,
+		smtpConfig:    smtpConfig,
 		senderName:    smtpConfig.FromName,
 		senderAddress: smtpConfig.From,
-	}, nil
+	}
+}
+
+// TestConfiguration sends a test message to the receiver
+// and returns the error of the smtp dialogue if it fails
+func TestConfiguration(smtpConfig *Config, receiver string) error {
+	return InitChannel(smtpConfig).HandleMessage(&messages.Email{
+		Recipients: []string{receiver},
+		Subject:    "ZITADEL SMTP test",
+		Content:    "This is a test message to verify the SMTP configuration of your ZITADEL instance.",
+	})
 }
 
 func (email *Email) HandleMessage(message channels.Message) error {
-	defer email.smtpClient.Close()
 	emailMsg, ok := message.(*messages.Email)
 	if !ok {
 		return caos_errs.ThrowInternal(nil, "EMAIL-s8JLs", "message is not EmailMessage")
@@ -52,20 +54,26 @@ func (email *Email) HandleMessage(message channels.Message) error {
 	if emailMsg.Content == "" || emailMsg.Subject == "" || len(emailMsg.Recipients) == 0 {
 		return caos_errs.ThrowInternalf(nil, "EMAIL-zGemZ", "subject, recipients and content must be set but got subject %s, recipients length %d and content length %d", emailMsg.Subject, len(emailMsg.Recipients), len(emailMsg.Content))
 	}
+	client, err := email.smtpConfig.connectToSMTP()
+	if err != nil {
+		logging.New().WithError(err).Error("could not connect to smtp")
+		return err
+	}
+	defer client.Close()
 	emailMsg.SenderEmail = email.senderAddress
 	emailMsg.SenderName = email.senderName
 	// To && From
-	if err := email.smtpClient.Mail(emailMsg.SenderEmail); err != nil {
+	if err := client.Mail(emailMsg.SenderEmail); err != nil {
 		return caos_errs.ThrowInternalf(err, "EMAIL-s3is3", "could not set sender: %v", emailMsg.SenderEmail)
 	}
 	for _, recp := range append(append(emailMsg.Recipients, emailMsg.CC...), emailMsg.BCC...) {
-		if err := email.smtpClient.Rcpt(recp); err != nil {
+		if err := client.Rcpt(recp); err != nil {
 			return caos_errs.ThrowInternalf(err, "EMAIL-s4is4", "could not set recipient: %v", recp)
 		}
 	}
 
 	// Data
-	w, err := email.smtpClient.Data()
+	w, err := client.Data()
 	if err != nil {
 		return err
 	}
@@ -85,31 +93,65 @@ func (email *Email) HandleMessage(message channels.Message) error {
 		return err
 	}
 
-	return email.smtpClient.Quit()
+	return client.Quit()
 }
 
-func (smtpConfig SMTP) connectToSMTP(tlsRequired bool) (client *smtp.Client, err error) {
-	host, _, err := net.SplitHostPort(smtpConfig.Host)
+func (smtpConfig *Config) connectToSMTP() (client *smtp.Client, err error) {
+	host, _, err := net.SplitHostPort(smtpConfig.SMTP.Host)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "EMAIL-spR56", "could not split host and port for connect to smtp")
 	}
+	tlsConfig, err := smtpConfig.tlsConfig(host)
+	if err != nil {
+		return nil, err
+	}
 
-	if !tlsRequired {
-		client, err = smtpConfig.getSMPTClient()
-	} else {
-		client, err = smtpConfig.getSMPTClientWithTls(host)
+	switch smtpConfig.TLSMode {
+	case domain.SMTPTLSModeImplicit:
+		client, err = smtpConfig.SMTP.getSMPTClientWithTls(host, tlsConfig)
+	case domain.SMTPTLSModeStartTLSRequired:
+		client, err = smtpConfig.SMTP.getSMPTClientWithStartTls(tlsConfig, true)
+	case domain.SMTPTLSModeStartTLSOpportunistic:
+		client, err = smtpConfig.SMTP.getSMPTClientWithStartTls(tlsConfig, false)
+	default:
+		if !smtpConfig.Tls {
+			client, err = smtpConfig.SMTP.getSMPTClient()
+		} else {
+			client, err = smtpConfig.SMTP.getSMPTClientWithTlsFallback(host, tlsConfig)
+		}
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	err = smtpConfig.smtpAuth(client, host)
+	err = smtpConfig.SMTP.smtpAuth(client, host)
 	if err != nil {
+		client.Close()
 		return nil, err
 	}
 	return client, nil
 }
 
+// tlsConfig returns the tls config for the host,
+// the CA certificate of the config is trusted in addition to the system certificates
+func (smtpConfig *Config) tlsConfig(host string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName: host,
+	}
+	if smtpConfig.CACertificate == "" {
+		return tlsConfig, nil
+	}
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if !rootCAs.AppendCertsFromPEM([]byte(smtpConfig.CACertificate)) {
+		return nil, caos_errs.ThrowInternal(nil, "EMAIL-Sfw1a", "could not parse ca certificate")
+	}
+	tlsConfig.RootCAs = rootCAs
+	return tlsConfig, nil
+}
+
 func (smtpConfig SMTP) getSMPTClient() (*smtp.Client, error) {
 	client, err := smtp.Dial(smtpConfig.Host)
 	if err != nil {
@@ -118,14 +160,17 @@ func (smtpConfig SMTP) getSMPTClient() (*smtp.Client, error) {
 	return client, nil
 }
 
-func (smtpConfig SMTP) getSMPTClientWithTls(host string) (*smtp.Client, error) {
-	conn, err := tls.Dial("tcp", smtpConfig.Host, &tls.Config{})
-
+func (smtpConfig SMTP) getSMPTClientWithTlsFallback(host string, tlsConfig *tls.Config) (*smtp.Client, error) {
+	client, err := smtpConfig.getSMPTClientWithTls(host, tlsConfig)
 	if errors.As(err, &tls.RecordHeaderError{}) {
 		logging.Log("MAIN-xKIzT").OnError(err).Warn("could not connect using normal tls. trying starttls instead...")
-		return smtpConfig.getSMPTClientWithStartTls(host)
+		return smtpConfig.getSMPTClientWithStartTls(tlsConfig, true)
 	}
+	return client, err
+}
 
+func (smtpConfig SMTP) getSMPTClientWithTls(host string, tlsConfig *tls.Config) (*smtp.Client, error) {
+	conn, err := tls.Dial("tcp", smtpConfig.Host, tlsConfig)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "EMAIL-sl39s", "could not make tls dial")
 	}
@@ -137,21 +182,30 @@ func (smtpConfig SMTP) getSMPTClientWithTls(host string) (*smtp.Client, error) {
 	return client, err
 }
 
-func (smtpConfig SMTP) getSMPTClientWithStartTls(host string) (*smtp.Client, error) {
+func (smtpConfig SMTP) getSMPTClientWithStartTls(tlsConfig *tls.Config, required bool) (*smtp.Client, error) {
 	client, err := smtpConfig.getSMPTClient()
 	if err != nil {
 		return nil, err
 	}
 
-	if err := client.StartTLS(&tls.Config{
-		ServerName: host,
-	}); err != nil {
+	if ok, _ := client.Extension("STARTTLS"); !ok {
+		if !required {
+			return client, nil
+		}
+		client.Close()
+		return nil, caos_errs.ThrowInternal(nil, "EMAIL-Sfw1b", "smtp server does not support starttls")
+	}
+	if err := client.StartTLS(tlsConfig); err != nil {
+		client.Close()
 		return nil, caos_errs.ThrowInternal(err, "EMAIL-guvsQ", "could not start tls")
 	}
 	return client, nil
 }
 
 func (smtpConfig SMTP) smtpAuth(client *smtp.Client, host string) error {
+	if smtpConfig.AuthType == domain.SMTPAuthTypeXOAuth2 {
+		return smtpConfig.xoauth2Auth(client, host)
+	}
 	if !smtpConfig.HasAuth() {
 		return nil
 	}
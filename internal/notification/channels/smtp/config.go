@@ -1,16 +1,34 @@
 package smtp
 
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
 type Config struct {
 	SMTP     SMTP
 	Tls      bool
+	TLSMode  domain.SMTPTLSMode
 	From     string
 	FromName string
+	// CACertificate is a PEM encoded certificate which is trusted in addition to the system certificates
+	CACertificate string
 }
 
 type SMTP struct {
 	Host     string
 	User     string
 	Password string
+	AuthType domain.SMTPAuthType
+	XOAuth2  *XOAuth2
+}
+
+// XOAuth2 is the configuration to fetch the access token for the XOAUTH2 authentication
+// using the client credentials grant
+type XOAuth2 struct {
+	TokenEndpoint string
+	ClientID      string
+	ClientSecret  string
+	Scopes        []string
 }
 
 func (smtp *SMTP) HasAuth() bool {
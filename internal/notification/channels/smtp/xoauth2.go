@@ -0,0 +1,96 @@
+package smtp
+
+import (
+	"context"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const tokenRequestTimeout = 10 * time.Second
+
+// tokenSources caches the token sources of the XOAUTH2 configs,
+// so the access tokens are reused until they expire and refreshed afterwards
+var tokenSources = struct {
+	sync.Mutex
+	sources map[string]oauth2.TokenSource
+}{
+	sources: make(map[string]oauth2.TokenSource),
+}
+
+func (smtpConfig SMTP) xoauth2Auth(client *smtp.Client, host string) error {
+	if smtpConfig.XOAuth2 == nil {
+		return caos_errs.ThrowInternal(nil, "EMAIL-Sfw2c", "xoauth2 config missing")
+	}
+	token, err := smtpConfig.XOAuth2.tokenSource().Token()
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "EMAIL-Sfw2d", "could not get xoauth2 access token")
+	}
+	err = client.Auth(&xoauth2Auth{
+		user:  smtpConfig.User,
+		token: token.AccessToken,
+		host:  host,
+	})
+	if err != nil {
+		return caos_errs.ThrowInternalf(err, "EMAIL-Sfw2e", "could not add smtp xoauth2 auth for user %s", smtpConfig.User)
+	}
+	return nil
+}
+
+func (config *XOAuth2) tokenSource() oauth2.TokenSource {
+	key := strings.Join(append([]string{config.TokenEndpoint, config.ClientID, config.ClientSecret}, config.Scopes...), "\x00")
+
+	tokenSources.Lock()
+	defer tokenSources.Unlock()
+	if source, ok := tokenSources.sources[key]; ok {
+		return source
+	}
+	// the token source is reused for later messages, so it must not be bound to the context of a message
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: tokenRequestTimeout})
+	source := (&clientcredentials.Config{
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		TokenURL:     config.TokenEndpoint,
+		Scopes:       config.Scopes,
+	}).TokenSource(ctx)
+	tokenSources.sources[key] = source
+	return source
+}
+
+// xoauth2Auth implements the XOAUTH2 mechanism used by Microsoft 365 and Google Workspace
+type xoauth2Auth struct {
+	user  string
+	token string
+	host  string
+}
+
+func (a *xoauth2Auth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	// same as smtp.PlainAuth, the token must only be sent over encrypted connections
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, caos_errs.ThrowInternal(nil, "EMAIL-Sfw2f", "unencrypted connection")
+	}
+	if server.Name != a.host {
+		return "", nil, caos_errs.ThrowInternal(nil, "EMAIL-Sfw2g", "wrong host name")
+	}
+	return "XOAUTH2", []byte("user=" + a.user + "\x01auth=Bearer " + a.token + "\x01\x01"), nil
+}
+
+func (a *xoauth2Auth) Next(_ []byte, more bool) ([]byte, error) {
+	if more {
+		// the server sends the reason of a failed authentication as challenge,
+		// an empty response is expected to receive the final error
+		return []byte{}, nil
+	}
+	return nil, nil
+}
+
+func isLocalhost(name string) bool {
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
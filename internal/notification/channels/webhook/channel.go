@@ -2,8 +2,12 @@ package webhook
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +18,11 @@ import (
 	"github.com/zitadel/zitadel/internal/notification/messages"
 )
 
+// SignatureHeader contains the timestamp and the HMAC-SHA256 signature of the request,
+// e.g. t=1672531200,v1=<hex encoded signature>
+// the signature is computed over "<timestamp>.<body>" with the signing key of the provider
+const SignatureHeader = "ZITADEL-Signature"
+
 func InitChannel(ctx context.Context, cfg Config) (channels.NotificationChannel, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, err
@@ -39,7 +48,13 @@ func InitChannel(ctx context.Context, cfg Config) (channels.NotificationChannel,
 			return err
 		}
 
+		for key, value := range cfg.Headers {
+			req.Header.Set(key, value)
+		}
 		req.Header.Set("Content-Type", "application/json")
+		if cfg.SigningKey != "" {
+			req.Header.Set(SignatureHeader, Sign([]byte(cfg.SigningKey), time.Now(), payload))
+		}
 
 		resp, err := http.DefaultClient.Do(req)
 		if err != nil {
@@ -58,3 +73,11 @@ func InitChannel(ctx context.Context, cfg Config) (channels.NotificationChannel,
 		return nil
 	}), nil
 }
+
+// Sign returns the value of the SignatureHeader for the payload
+func Sign(key []byte, timestamp time.Time, payload string) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(t + "." + payload))
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,142 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+func TestSign(t *testing.T) {
+	type args struct {
+		key       string
+		timestamp time.Time
+		payload   string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "signature over timestamp and payload",
+			args: args{
+				key:       "key",
+				timestamp: time.Unix(1672531200, 0),
+				payload:   `{"userId":"user1"}`,
+			},
+			want: "t=1672531200,v1=8e83e33258a8c3430479bd53f5708007675572fe84beb3682e9c266b51dad8c0",
+		},
+		{
+			name: "empty payload",
+			args: args{
+				key:       "key",
+				timestamp: time.Unix(1672531200, 0),
+			},
+			want: "t=1672531200,v1=2ab6c2621f80132653b0ed8a51b07415a0cb548ebeec85e3926b9e85a19584a8",
+		},
+		{
+			name: "unix epoch",
+			args: args{
+				key:       "Jefe",
+				timestamp: time.Unix(0, 0),
+				payload:   "what do ya want for nothing?",
+			},
+			want: "t=0,v1=37f471929915ccd2cbbe79feb84ffcff4f2bb25e15fc41c2506687331ae179cc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Sign([]byte(tt.args.key), tt.args.timestamp, tt.args.payload))
+		})
+	}
+}
+
+func TestSign_differs(t *testing.T) {
+	timestamp := time.Unix(1672531200, 0)
+	signature := Sign([]byte("key"), timestamp, "payload")
+	assert.NotEqual(t, signature, Sign([]byte("other"), timestamp, "payload"), "key must be signed")
+	assert.NotEqual(t, signature, Sign([]byte("key"), timestamp.Add(time.Second), "payload"), "timestamp must be signed")
+	assert.NotEqual(t, signature, Sign([]byte("key"), timestamp, "payload2"), "payload must be signed")
+}
+
+func TestChannel_signature(t *testing.T) {
+	tests := []struct {
+		name       string
+		signingKey string
+	}{
+		{
+			name:       "signed",
+			signingKey: "key",
+		},
+		{
+			name: "unsigned",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				header http.Header
+				body   []byte
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var err error
+				body, err = io.ReadAll(r.Body)
+				require.NoError(t, err)
+				header = r.Header.Clone()
+			}))
+			defer server.Close()
+			channel, err := InitChannel(context.Background(), Config{
+				CallURL:    server.URL,
+				Method:     http.MethodPost,
+				Headers:    map[string]string{"X-Custom": "value"},
+				SigningKey: tt.signingKey,
+			})
+			require.NoError(t, err)
+
+			before := time.Now().Unix()
+			require.NoError(t, channel.HandleMessage(&messages.JSON{Serializable: map[string]string{"userId": "user1"}}))
+
+			assert.Equal(t, `{"userId":"user1"}`, string(body))
+			assert.Equal(t, "application/json", header.Get("Content-Type"))
+			assert.Equal(t, "value", header.Get("X-Custom"))
+			if tt.signingKey == "" {
+				assert.Empty(t, header.Get(SignatureHeader))
+				return
+			}
+			// the receiver verifies the signature with the timestamp of the header
+			signature := header.Get(SignatureHeader)
+			timestamp, _, found := strings.Cut(strings.TrimPrefix(signature, "t="), ",")
+			require.True(t, found)
+			unix, err := strconv.ParseInt(timestamp, 10, 64)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, unix, before)
+			assert.Equal(t, Sign([]byte(tt.signingKey), time.Unix(unix, 0), string(body)), signature)
+		})
+	}
+}
+
+func TestChannel_errorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	channel, err := InitChannel(context.Background(), Config{
+		CallURL: server.URL,
+		Method:  http.MethodPost,
+	})
+	require.NoError(t, err)
+
+	err = channel.HandleMessage(&messages.JSON{Serializable: "payload"})
+	assert.True(t, errors.IsUnknown(err))
+}
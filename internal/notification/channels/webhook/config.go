@@ -7,6 +7,10 @@ import (
 type Config struct {
 	CallURL string
 	Method  string
+	Headers map[string]string
+	// SigningKey is used to sign the payload with HMAC-SHA256,
+	// the request is not signed if no key is set
+	SigningKey string
 }
 
 func (w *Config) Validate() error {
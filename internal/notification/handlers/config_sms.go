@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
+	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/senders"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// GetActiveSMSConfig reads the active iam sms provider config
+func (n *NotificationQueries) GetActiveSMSConfig(ctx context.Context) (*senders.SMSConfig, error) {
+	active, err := query.NewSMSProviderStateQuery(domain.SMSConfigStateActive)
+	if err != nil {
+		return nil, err
+	}
+	config, err := n.SMSProviderConfig(ctx, active)
+	if err != nil {
+		return nil, err
+	}
+	if config.TwilioConfig != nil {
+		token, err := crypto.DecryptString(config.TwilioConfig.Token, n.SMSTokenCrypto)
+		if err != nil {
+			return nil, err
+		}
+		return &senders.SMSConfig{
+			TwilioConfig: &twilio.Config{
+				SID:          config.TwilioConfig.SID,
+				Token:        token,
+				SenderNumber: config.TwilioConfig.SenderNumber,
+			},
+		}, nil
+	}
+	if config.HTTPConfig != nil {
+		var password, apiKey string
+		if config.HTTPConfig.Password != nil {
+			password, err = crypto.DecryptString(config.HTTPConfig.Password, n.SMSTokenCrypto)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if config.HTTPConfig.APIKey != nil {
+			apiKey, err = crypto.DecryptString(config.HTTPConfig.APIKey, n.SMSTokenCrypto)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return &senders.SMSConfig{
+			HTTPConfig: &httpsms.Config{
+				Endpoint:     config.HTTPConfig.Endpoint,
+				Method:       config.HTTPConfig.Method,
+				Headers:      config.HTTPConfig.Headers,
+				BodyTemplate: config.HTTPConfig.BodyTemplate,
+				Username:     config.HTTPConfig.Username,
+				Password:     password,
+				APIKeyHeader: config.HTTPConfig.APIKeyHeader,
+				APIKey:       apiKey,
+			},
+		}, nil
+	}
+	return nil, errors.ThrowNotFound(nil, "HANDLER-8nfow", "Errors.SMSConfig.NotFound")
+}
@@ -3,29 +3,82 @@ package handlers
 import (
 	"context"
 
-	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	"github.com/zitadel/zitadel/internal/query"
 )
 
-// GetSMTPConfig reads the iam SMTP provider config
-func (n *NotificationQueries) GetSMTPConfig(ctx context.Context) (*smtp.Config, error) {
-	config, err := n.SMTPConfigByAggregateID(ctx, authz.GetInstance(ctx).InstanceID())
+// GetSMTPConfigs reads the SMTP provider configs of the organization sorted by priority,
+// if the organization has none, the configs of the instance are returned
+func (n *NotificationQueries) GetSMTPConfigs(ctx context.Context, orgID string) ([]*smtp.Config, error) {
+	configs, err := n.searchSMTPConfigs(ctx, orgID)
 	if err != nil {
 		return nil, err
 	}
-	password, err := crypto.DecryptString(config.Password, n.SMTPPasswordCrypto)
-	if err != nil {
-		return nil, err
+	if len(configs) == 0 && orgID != "" {
+		configs, err = n.searchSMTPConfigs(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+	smtpConfigs := make([]*smtp.Config, len(configs))
+	for i, config := range configs {
+		smtpConfigs[i], err = n.smtpConfig(config)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return &smtp.Config{
+	return smtpConfigs, nil
+}
+
+// smtpConfig maps the config and decrypts its secrets
+func (n *NotificationQueries) smtpConfig(config *query.SMTPConfig) (_ *smtp.Config, err error) {
+	smtpConfig := &smtp.Config{
 		From:     config.SenderAddress,
 		FromName: config.SenderName,
 		Tls:      config.TLS,
+		TLSMode:  config.TLSMode,
 		SMTP: smtp.SMTP{
 			Host:     config.Host,
 			User:     config.User,
-			Password: password,
+			AuthType: config.AuthType,
 		},
-	}, nil
+	}
+	smtpConfig.SMTP.Password, err = crypto.DecryptString(config.Password, n.SMTPPasswordCrypto)
+	if err != nil {
+		return nil, err
+	}
+	if config.CACertificate != nil {
+		smtpConfig.CACertificate, err = crypto.DecryptString(config.CACertificate, n.SMTPPasswordCrypto)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.AuthType == domain.SMTPAuthTypeXOAuth2 {
+		smtpConfig.SMTP.XOAuth2 = &smtp.XOAuth2{
+			TokenEndpoint: config.XOAuth2TokenEndpoint,
+			ClientID:      config.XOAuth2ClientID,
+			Scopes:        config.XOAuth2Scopes,
+		}
+		if config.XOAuth2ClientSecret != nil {
+			smtpConfig.SMTP.XOAuth2.ClientSecret, err = crypto.DecryptString(config.XOAuth2ClientSecret, n.SMTPPasswordCrypto)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return smtpConfig, nil
+}
+
+func (n *NotificationQueries) searchSMTPConfigs(ctx context.Context, orgID string) ([]*query.SMTPConfig, error) {
+	orgIDQuery, err := query.NewSMTPConfigOrgIDSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	configs, err := n.SearchSMTPConfigs(ctx, &query.SMTPConfigsSearchQueries{Queries: []query.SearchQuery{orgIDQuery}})
+	if err != nil {
+		return nil, err
+	}
+	return configs.Configs, nil
 }
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
+)
+
+// GetNotificationWebhookProvider reads the iam webhook notification provider config
+func (n *NotificationQueries) GetNotificationWebhookProvider(ctx context.Context) (*webhook.Config, error) {
+	config, err := n.NotificationWebhookProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	signingKey, err := crypto.DecryptString(config.SigningKey, n.SMSTokenCrypto)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook.Config{
+		CallURL:    config.CallURL,
+		Method:     config.Method,
+		Headers:    config.Headers,
+		SigningKey: signingKey,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// GetMailTemplate returns the layout the organization uploaded for the message type
+// and falls back to the mail template policy if there is none
+func (n *NotificationQueries) GetMailTemplate(ctx context.Context, orgID, messageType string) (string, error) {
+	customTemplate, err := n.CustomMailTemplateByOrg(ctx, orgID, messageType)
+	if err == nil {
+		template, _, err := n.static.GetObject(ctx, authz.GetInstance(ctx).InstanceID(), orgID, customTemplate.TemplateKey)
+		if err != nil {
+			return "", err
+		}
+		return string(template), nil
+	}
+	if !errors.IsNotFound(err) {
+		return "", err
+	}
+	policy, err := n.MailTemplateByOrg(ctx, orgID, false)
+	if err != nil {
+		return "", err
+	}
+	return string(policy.Template), nil
+}
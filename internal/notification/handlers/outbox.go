@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	outboxRetryInterval  = 30 * time.Second
+	outboxRetryBatchSize = 100
+	outboxLockDuration   = time.Minute
+	outboxMaxAttempts    = 5
+	outboxMinBackoff     = time.Minute
+	outboxMaxBackoff     = time.Hour
+)
+
+// notificationDelivery returns the outbox entry of the notification triggered by the event or nil if there is none
+func (n *NotificationQueries) notificationDelivery(ctx context.Context, event eventstore.Event) (*query.NotificationDelivery, error) {
+	delivery, err := n.NotificationDeliveryByTrigger(ctx, true, event.Aggregate().ID, event.Sequence())
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	return delivery, err
+}
+
+// checkIfAlreadyHandled ignores the sent events of the user if a retry or resend of the notification is due.
+// Notifications without sent events (no event types passed) are only checked against the outbox.
+func (u *userNotifier) checkIfAlreadyHandled(ctx context.Context, event eventstore.Event, data map[string]interface{}, eventTypes ...eventstore.EventType) (bool, error) {
+	delivery, err := u.queries.notificationDelivery(ctx, event)
+	if err != nil {
+		return false, err
+	}
+	if delivery != nil {
+		return delivery.State != domain.NotificationDeliveryStateRetrying || delivery.RetryAt.After(time.Now()), nil
+	}
+	if len(eventTypes) == 0 {
+		return false, nil
+	}
+	return u.queries.IsAlreadyHandled(ctx, event, data, eventTypes...)
+}
+
+// cancelDelivery stops the retries of a pending notification, e.g. if the code of the message expired
+func (u *userNotifier) cancelDelivery(ctx context.Context, event eventstore.Event, reason string) error {
+	delivery, err := u.queries.notificationDelivery(ctx, event)
+	if err != nil || delivery == nil || delivery.State != domain.NotificationDeliveryStateRetrying {
+		return err
+	}
+	_, err = u.commands.NotificationDeliveryFailed(ctx, delivery.ID, nil, reason, nil)
+	return err
+}
+
+// recordDelivery records the result of the delivery in the outbox.
+// It returns false if the delivery failed, the notification is then retried with an exponential backoff
+// until the maximum number of attempts is reached.
+func (u *userNotifier) recordDelivery(ctx context.Context, event eventstore.Event, channel domain.NotificationType, recipient, messageType string, sendErr error) (bool, error) {
+	delivery, err := u.queries.notificationDelivery(ctx, event)
+	if err != nil {
+		return false, err
+	}
+	var (
+		id       string
+		attempts uint16
+	)
+	if delivery != nil {
+		id, attempts = delivery.ID, delivery.Attempts
+	}
+	notification := &command.Notification{
+		UserID:           event.Aggregate().ID,
+		ResourceOwner:    event.Aggregate().ResourceOwner,
+		TriggerEventType: event.Type(),
+		TriggerSequence:  event.Sequence(),
+		Channel:          channel,
+		Recipient:        recipient,
+		MessageType:      messageType,
+	}
+	if sendErr == nil {
+		_, err = u.commands.NotificationDelivered(ctx, id, notification)
+		return err == nil, err
+	}
+	logging.WithFields("instance", event.Aggregate().InstanceID, "user", event.Aggregate().ID, "sequence", event.Sequence()).
+		WithError(sendErr).Warn("notification delivery failed")
+	_, err = u.commands.NotificationDeliveryFailed(ctx, id, notification, sendErr.Error(), nextDeliveryRetry(attempts+1))
+	return false, err
+}
+
+func nextDeliveryRetry(attempt uint16) *time.Time {
+	if attempt >= outboxMaxAttempts {
+		return nil
+	}
+	backoff := outboxMinBackoff << (attempt - 1)
+	if backoff > outboxMaxBackoff {
+		backoff = outboxMaxBackoff
+	}
+	retryAt := time.Now().Add(backoff)
+	return &retryAt
+}
+
+// StartOutbox periodically sends the notifications of all instances which are due for a retry or resend
+func (u *userNotifier) StartOutbox(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(outboxRetryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				u.retryDeliveries(ctx)
+			}
+		}
+	}()
+}
+
+func (u *userNotifier) retryDeliveries(ctx context.Context) {
+	deliveries, err := u.queries.NotificationDeliveriesToRetry(ctx, time.Now(), outboxRetryBatchSize)
+	if err != nil {
+		logging.WithError(err).Warn("unable to query notifications to retry")
+		return
+	}
+	instanceIDs := make([]string, 0)
+	byInstance := make(map[string][]*query.NotificationDelivery)
+	for _, delivery := range deliveries.Deliveries {
+		if _, ok := byInstance[delivery.InstanceID]; !ok {
+			instanceIDs = append(instanceIDs, delivery.InstanceID)
+		}
+		byInstance[delivery.InstanceID] = append(byInstance[delivery.InstanceID], delivery)
+	}
+	for _, instanceID := range instanceIDs {
+		u.retryInstanceDeliveries(ctx, instanceID, byInstance[instanceID])
+	}
+}
+
+// retryInstanceDeliveries locks the instance like the projection handler does,
+// so the notifications are not sent concurrently by the handler or another worker
+func (u *userNotifier) retryInstanceDeliveries(ctx context.Context, instanceID string, deliveries []*query.NotificationDelivery) {
+	lockCtx, cancel := context.WithCancel(ctx)
+	errs := u.Lock(lockCtx, outboxLockDuration, instanceID)
+	if err := <-errs; err != nil {
+		cancel()
+		logging.WithFields("instance", instanceID).WithError(err).Debug("unable to lock instance for notification retries")
+		return
+	}
+	go func() {
+		for err := range errs {
+			logging.WithFields("instance", instanceID).OnError(err).Warn("unable to renew lock for notification retries")
+		}
+	}()
+	defer func() {
+		cancel()
+		err := u.Unlock(instanceID)
+		logging.WithFields("instance", instanceID).OnError(err).Warn("unable to unlock instance after notification retries")
+	}()
+
+	for _, delivery := range deliveries {
+		err := u.retryDelivery(lockCtx, delivery)
+		logging.WithFields("instance", instanceID, "notification", delivery.ID).OnError(err).Warn("unable to retry notification")
+	}
+}
+
+// retryDelivery reduces the event which triggered the notification again
+func (u *userNotifier) retryDelivery(ctx context.Context, delivery *query.NotificationDelivery) error {
+	events, err := u.queries.es.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(delivery.InstanceID).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(delivery.UserID).
+		EventTypes(delivery.TriggerEventType).
+		SequenceGreater(delivery.TriggerSequence-1).
+		SequenceLess(delivery.TriggerSequence+1).
+		Builder(),
+	)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		ctx = authz.SetCtxData(authz.WithInstanceID(ctx, delivery.InstanceID), authz.CtxData{UserID: NotifyUserID, OrgID: delivery.ResourceOwner})
+		_, err = u.commands.NotificationDeliveryFailed(ctx, delivery.ID, nil, "triggering event not found", nil)
+		return err
+	}
+	for _, aggregateReducer := range u.reducers() {
+		for _, reducer := range aggregateReducer.EventRedusers {
+			if reducer.Event == events[0].Type() {
+				_, err = reducer.Reduce(events[0])
+				return err
+			}
+		}
+	}
+	return errors.ThrowInternalf(nil, "HANDL-Nt6fa", "no reducer for event type %s", events[0].Type())
+}
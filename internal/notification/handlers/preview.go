@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// PreviewMessage renders the email of the message type with the label policy and the texts of the organization
+// for a sample user. If a template is passed, it's used instead of the stored layout,
+// so it can be checked before it is uploaded.
+func (n *NotificationQueries) PreviewMessage(ctx context.Context, orgID, messageType string, lang language.Tag, template []byte, assetsPrefix string) (subject, content string, err error) {
+	if !domain.IsEmailMessageType(messageType) {
+		return "", "", errors.ThrowInvalidArgument(nil, "HANDL-Pv3mt", "Errors.Org.CustomMailTemplate.Invalid")
+	}
+	colors, err := n.ActiveLabelPolicyByOrg(ctx, orgID, false)
+	if err != nil {
+		return "", "", err
+	}
+	mailhtml := string(template)
+	if len(template) == 0 {
+		mailhtml, err = n.GetMailTemplate(ctx, orgID, messageType)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	translator, err := n.GetTranslatorWithOrgTexts(ctx, orgID, messageType)
+	if err != nil {
+		return "", "", err
+	}
+	ctx, origin, err := n.Origin(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	subject, content, err = types.PreviewEmail(mailhtml, translator, previewUser(orgID, lang), colors, assetsPrefix, origin, messageType, previewArgs())
+	if err != nil {
+		return "", "", errors.ThrowInvalidArgument(err, "HANDL-Pv3rn", "Errors.Org.CustomMailTemplate.Invalid")
+	}
+	return subject, content, nil
+}
+
+func previewUser(orgID string, lang language.Tag) *query.NotifyUser {
+	return &query.NotifyUser{
+		ID:                 "preview",
+		CreationDate:       time.Now(),
+		ChangeDate:         time.Now(),
+		ResourceOwner:      orgID,
+		Username:           "jane.doe",
+		LoginNames:         []string{"jane.doe@example.com"},
+		PreferredLoginName: "jane.doe@example.com",
+		FirstName:          "Jane",
+		LastName:           "Doe",
+		NickName:           "Jane",
+		DisplayName:        "Jane Doe",
+		PreferredLanguage:  lang,
+		LastEmail:          "jane.doe@example.com",
+		VerifiedEmail:      "jane.doe@example.com",
+		LastPhone:          "+41 71 000 00 00",
+		VerifiedPhone:      "+41 71 000 00 00",
+	}
+}
+
+func previewArgs() map[string]interface{} {
+	return map[string]interface{}{
+		"Code":         "ABCDEF",
+		"TempUsername": "jane.doe@example.com",
+		"Domain":       "example.com",
+		"UserAgent":    "Mozilla/5.0 (X11; Linux x86_64)",
+		"RemoteIP":     "192.0.2.1",
+	}
+}
@@ -5,8 +5,10 @@ import (
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels/push"
 	_ "github.com/zitadel/zitadel/internal/notification/statik"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/static"
 )
 
 type NotificationQueries struct {
@@ -15,10 +17,12 @@ type NotificationQueries struct {
 	externalPort       uint16
 	externalSecure     bool
 	fileSystemPath     string
+	pushConfig         push.Config
 	UserDataCrypto     crypto.EncryptionAlgorithm
 	SMTPPasswordCrypto crypto.EncryptionAlgorithm
 	SMSTokenCrypto     crypto.EncryptionAlgorithm
 	statikDir          http.FileSystem
+	static             static.Storage
 }
 
 func NewNotificationQueries(
@@ -27,10 +31,12 @@ func NewNotificationQueries(
 	externalPort uint16,
 	externalSecure bool,
 	fileSystemPath string,
+	pushConfig push.Config,
 	userDataCrypto crypto.EncryptionAlgorithm,
 	smtpPasswordCrypto crypto.EncryptionAlgorithm,
 	smsTokenCrypto crypto.EncryptionAlgorithm,
 	statikDir http.FileSystem,
+	static static.Storage,
 ) *NotificationQueries {
 	return &NotificationQueries{
 		Queries:            baseQueries,
@@ -38,9 +44,11 @@ func NewNotificationQueries(
 		externalPort:       externalPort,
 		externalSecure:     externalSecure,
 		fileSystemPath:     fileSystemPath,
+		pushConfig:         pushConfig,
 		UserDataCrypto:     userDataCrypto,
 		SMTPPasswordCrypto: smtpPasswordCrypto,
 		SMSTokenCrypto:     smsTokenCrypto,
 		statikDir:          statikDir,
+		static:             static,
 	}
 }
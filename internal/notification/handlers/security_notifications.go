@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+var loginSucceededEventTypes = []eventstore.EventType{
+	user.HumanPasswordCheckSucceededType,
+	user.HumanPasswordlessTokenCheckSucceededType,
+	user.HumanMagicLinkCheckSucceededType,
+	user.HumanPhoneOTPCheckSucceededType,
+	user.UserIDPLoginCheckSucceededType,
+}
+
+func (u *userNotifier) reduceLoginSucceeded(event eventstore.Event) (*handler.Statement, error) {
+	var info *user.AuthRequestInfo
+	switch e := event.(type) {
+	case *user.HumanPasswordCheckSucceededEvent:
+		info = e.AuthRequestInfo
+	case *user.HumanPasswordlessCheckSucceededEvent:
+		info = e.AuthRequestInfo
+	case *user.HumanMagicLinkCheckSucceededEvent:
+		info = e.AuthRequestInfo
+	case *user.HumanPhoneOTPCheckSucceededEvent:
+		info = e.AuthRequestInfo
+	case *user.UserIDPCheckSucceededEvent:
+		info = e.AuthRequestInfo
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nd4lg", "reduce.wrong.event.type %v", loginSucceededEventTypes)
+	}
+	if info == nil || info.UserAgentID == "" {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	ctx := HandlerContext(event.Aggregate())
+	knownDevice, err := u.queries.isKnownUserAgent(ctx, event, info.UserAgentID)
+	if err != nil {
+		return nil, err
+	}
+	if knownDevice {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	var userAgent, remoteIP string
+	if info.BrowserInfo != nil {
+		userAgent = info.UserAgent
+		remoteIP = info.RemoteIP.String()
+	}
+	return u.sendSecurityNotification(ctx, event, domain.NewDeviceLoginMessageType, "",
+		func(policy *query.NotificationPolicy, preferences *domain.NotificationPreferences) bool {
+			return policy.NewDeviceLogin && preferences.NewDeviceLogin
+		},
+		func(notify types.Notify, notifyUser *query.NotifyUser, origin string) error {
+			return notify.SendNewDeviceLogin(notifyUser, origin, userAgent, remoteIP)
+		},
+	)
+}
+
+func (u *userNotifier) reduceMFAAdded(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.HumanOTPVerifiedEvent,
+		*user.HumanU2FVerifiedEvent,
+		*user.HumanPasswordlessVerifiedEvent,
+		*user.HumanPushDeviceVerifiedEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Mf2ad", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanMFAOTPVerifiedType, user.HumanU2FTokenVerifiedType, user.HumanPasswordlessTokenVerifiedType, user.HumanPushDeviceVerifiedType})
+	}
+	return u.sendSecurityNotification(HandlerContext(event.Aggregate()), event, domain.MFAAddedMessageType, "",
+		func(policy *query.NotificationPolicy, preferences *domain.NotificationPreferences) bool {
+			return policy.MFAAdded && preferences.MFAAdded
+		},
+		func(notify types.Notify, notifyUser *query.NotifyUser, origin string) error {
+			return notify.SendMFAAdded(notifyUser, origin)
+		},
+	)
+}
+
+func (u *userNotifier) reduceMFARemoved(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.HumanOTPRemovedEvent,
+		*user.HumanU2FRemovedEvent,
+		*user.HumanPasswordlessRemovedEvent,
+		*user.HumanPushDeviceRemovedEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Mf8rm", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanMFAOTPRemovedType, user.HumanU2FTokenRemovedType, user.HumanPasswordlessTokenRemovedType, user.HumanPushDeviceRemovedType})
+	}
+	return u.sendSecurityNotification(HandlerContext(event.Aggregate()), event, domain.MFARemovedMessageType, "",
+		func(policy *query.NotificationPolicy, preferences *domain.NotificationPreferences) bool {
+			return policy.MFARemoved && preferences.MFARemoved
+		},
+		func(notify types.Notify, notifyUser *query.NotifyUser, origin string) error {
+			return notify.SendMFARemoved(notifyUser, origin)
+		},
+	)
+}
+
+func (u *userNotifier) reduceEmailChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanEmailChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Em5ch", "reduce.wrong.event.type %s", user.HumanEmailChangedType)
+	}
+	ctx := HandlerContext(event.Aggregate())
+	previousEmail, err := u.queries.previousVerifiedEmail(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	if previousEmail == "" || previousEmail == string(e.EmailAddress) {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	return u.sendSecurityNotification(ctx, e, domain.EmailChangedMessageType, previousEmail,
+		func(policy *query.NotificationPolicy, preferences *domain.NotificationPreferences) bool {
+			return policy.EmailChange && preferences.EmailChange
+		},
+		func(notify types.Notify, notifyUser *query.NotifyUser, origin string) error {
+			return notify.SendEmailChanged(notifyUser, origin)
+		},
+	)
+}
+
+func (u *userNotifier) reduceAccountLocked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserLockedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Al3ck", "reduce.wrong.event.type %s", user.UserLockedType)
+	}
+	return u.sendSecurityNotification(HandlerContext(event.Aggregate()), e, domain.AccountLockedMessageType, "",
+		func(policy *query.NotificationPolicy, preferences *domain.NotificationPreferences) bool {
+			return policy.AccountLocked && preferences.AccountLocked
+		},
+		func(notify types.Notify, notifyUser *query.NotifyUser, origin string) error {
+			return notify.SendAccountLocked(notifyUser, origin)
+		},
+	)
+}
+
+// sendSecurityNotification sends the security notification by email if it's enabled in the notification policy
+// and in the preferences of the user.
+// The notification is sent to the verified email of the user, unless a recipient is passed.
+// There are no sent events for security notifications, the delivery is only recorded in the outbox.
+func (u *userNotifier) sendSecurityNotification(
+	ctx context.Context,
+	event eventstore.Event,
+	messageType string,
+	recipient string,
+	enabled func(policy *query.NotificationPolicy, preferences *domain.NotificationPreferences) bool,
+	send func(notify types.Notify, notifyUser *query.NotifyUser, origin string) error,
+) (*handler.Statement, error) {
+	alreadyHandled, err := u.checkIfAlreadyHandled(ctx, event, nil)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(event), nil
+	}
+
+	notificationPolicy, err := u.queries.NotificationPolicyByOrg(ctx, true, event.Aggregate().ResourceOwner, false)
+	if errors.IsNotFound(err) {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	preferences, err := u.queries.NotificationPreferencesByUserID(ctx, event.Aggregate().ID, event.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled(notificationPolicy, &preferences.NotificationPreferences) {
+		return crdb.NewNoOpStatement(event), nil
+	}
+
+	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, event.Aggregate().ID, false)
+	if err != nil {
+		return nil, err
+	}
+	if recipient != "" {
+		previous := *notifyUser
+		previous.VerifiedEmail = recipient
+		notifyUser = &previous
+	}
+	if notifyUser.VerifiedEmail == "" {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, event.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+	template, err := u.queries.GetMailTemplate(ctx, event.Aggregate().ResourceOwner, messageType)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, messageType)
+	if err != nil {
+		return nil, err
+	}
+	ctx, origin, err := u.queries.Origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = send(
+		types.SendEmail(
+			ctx,
+			template,
+			translator,
+			notifyUser,
+			u.queries.GetSMTPConfigs,
+			u.queries.GetFileSystemProvider,
+			u.queries.GetLogProvider,
+			u.queries.GetNotificationWebhookProvider,
+			colors,
+			u.assetsPrefix(ctx),
+			event,
+			u.metricSuccessfulDeliveriesEmail,
+			u.metricFailedDeliveriesEmail,
+		),
+		notifyUser,
+		origin,
+	)
+	if _, err = u.recordDelivery(ctx, event, domain.NotificationTypeEmail, notifyUser.VerifiedEmail, messageType, err); err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(event), nil
+}
+
+// isKnownUserAgent checks if the user already signed in with the user agent before the event.
+// The first login of a user is never reported as new device.
+func (n *NotificationQueries) isKnownUserAgent(ctx context.Context, event eventstore.Event, userAgentID string) (bool, error) {
+	previousLogins, err := n.previousLogins(ctx, event, nil)
+	if err != nil || len(previousLogins) == 0 {
+		return true, err
+	}
+	previousLogins, err = n.previousLogins(ctx, event, map[string]interface{}{"userAgentID": userAgentID})
+	if err != nil {
+		return false, err
+	}
+	return len(previousLogins) > 0, nil
+}
+
+func (n *NotificationQueries) previousLogins(ctx context.Context, event eventstore.Event, data map[string]interface{}) ([]eventstore.Event, error) {
+	return n.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(event.Aggregate().InstanceID).
+			Limit(1).
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(event.Aggregate().ID).
+			SequenceLess(event.Sequence()).
+			EventTypes(loginSucceededEventTypes...).
+			EventData(data).
+			Builder(),
+	)
+}
+
+// previousVerifiedEmail returns the email of the user before the change if it was verified
+func (n *NotificationQueries) previousVerifiedEmail(ctx context.Context, event *user.HumanEmailChangedEvent) (string, error) {
+	events, err := n.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(event.Aggregate().InstanceID).
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(event.Aggregate().ID).
+			SequenceLess(event.Sequence()).
+			EventTypes(
+				user.UserV1AddedType,
+				user.UserV1RegisteredType,
+				user.UserV1EmailChangedType,
+				user.UserV1EmailVerifiedType,
+				user.HumanAddedType,
+				user.HumanRegisteredType,
+				user.HumanEmailChangedType,
+				user.HumanEmailVerifiedType,
+			).
+			Builder(),
+	)
+	if err != nil {
+		return "", err
+	}
+	var (
+		email    domain.EmailAddress
+		verified bool
+	)
+	for _, previous := range events {
+		switch e := previous.(type) {
+		case *user.HumanAddedEvent:
+			email, verified = e.EmailAddress, false
+		case *user.HumanRegisteredEvent:
+			email, verified = e.EmailAddress, false
+		case *user.HumanEmailChangedEvent:
+			email, verified = e.EmailAddress, false
+		case *user.HumanEmailVerifiedEvent:
+			verified = true
+		}
+	}
+	if !verified {
+		return "", nil
+	}
+	return string(email), nil
+}
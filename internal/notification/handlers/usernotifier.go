@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/command"
@@ -28,7 +29,9 @@ type userNotifier struct {
 	metricSuccessfulDeliveriesEmail,
 	metricFailedDeliveriesEmail,
 	metricSuccessfulDeliveriesSMS,
-	metricFailedDeliveriesSMS string
+	metricFailedDeliveriesSMS,
+	metricSuccessfulDeliveriesPush,
+	metricFailedDeliveriesPush string
 }
 
 func NewUserNotifier(
@@ -40,7 +43,9 @@ func NewUserNotifier(
 	metricSuccessfulDeliveriesEmail,
 	metricFailedDeliveriesEmail,
 	metricSuccessfulDeliveriesSMS,
-	metricFailedDeliveriesSMS string,
+	metricFailedDeliveriesSMS,
+	metricSuccessfulDeliveriesPush,
+	metricFailedDeliveriesPush string,
 ) *userNotifier {
 	p := new(userNotifier)
 	config.ProjectionName = UserNotificationsProjectionTable
@@ -53,6 +58,8 @@ func NewUserNotifier(
 	p.metricFailedDeliveriesEmail = metricFailedDeliveriesEmail
 	p.metricSuccessfulDeliveriesSMS = metricSuccessfulDeliveriesSMS
 	p.metricFailedDeliveriesSMS = metricFailedDeliveriesSMS
+	p.metricSuccessfulDeliveriesPush = metricSuccessfulDeliveriesPush
+	p.metricFailedDeliveriesPush = metricFailedDeliveriesPush
 	projection.NotificationsProjection = p
 	return p
 }
@@ -94,6 +101,18 @@ func (u *userNotifier) reducers() []handler.AggregateReducer {
 					Event:  user.HumanPasswordlessInitCodeRequestedType,
 					Reduce: u.reducePasswordlessCodeRequested,
 				},
+				{
+					Event:  user.HumanMagicLinkRequestedType,
+					Reduce: u.reduceMagicLinkRequested,
+				},
+				{
+					Event:  user.HumanPhoneOTPRequestedType,
+					Reduce: u.reducePhoneOTPRequested,
+				},
+				{
+					Event:  user.HumanPushChallengeRequestedType,
+					Reduce: u.reducePushChallengeRequested,
+				},
 				{
 					Event:  user.UserV1PhoneCodeAddedType,
 					Reduce: u.reducePhoneCodeAdded,
@@ -106,6 +125,66 @@ func (u *userNotifier) reducers() []handler.AggregateReducer {
 					Event:  user.HumanPasswordChangedType,
 					Reduce: u.reducePasswordChanged,
 				},
+				{
+					Event:  user.HumanPasswordCheckSucceededType,
+					Reduce: u.reduceLoginSucceeded,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenCheckSucceededType,
+					Reduce: u.reduceLoginSucceeded,
+				},
+				{
+					Event:  user.HumanMagicLinkCheckSucceededType,
+					Reduce: u.reduceLoginSucceeded,
+				},
+				{
+					Event:  user.HumanPhoneOTPCheckSucceededType,
+					Reduce: u.reduceLoginSucceeded,
+				},
+				{
+					Event:  user.UserIDPLoginCheckSucceededType,
+					Reduce: u.reduceLoginSucceeded,
+				},
+				{
+					Event:  user.HumanMFAOTPVerifiedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanU2FTokenVerifiedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenVerifiedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanPushDeviceVerifiedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanMFAOTPRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanU2FTokenRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanPushDeviceRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanEmailChangedType,
+					Reduce: u.reduceEmailChanged,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: u.reduceAccountLocked,
+				},
 			},
 		},
 	}
@@ -135,7 +214,7 @@ func (u *userNotifier) reduceInitCodeAdded(event eventstore.Event) (*handler.Sta
 		return nil, err
 	}
 
-	template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	template, err := u.queries.GetMailTemplate(ctx, e.Aggregate().ResourceOwner, domain.InitCodeMessageType)
 	if err != nil {
 		return nil, err
 	}
@@ -155,21 +234,26 @@ func (u *userNotifier) reduceInitCodeAdded(event eventstore.Event) (*handler.Sta
 	}
 	err = types.SendEmail(
 		ctx,
-		string(template.Template),
+		template,
 		translator,
 		notifyUser,
-		u.queries.GetSMTPConfig,
+		u.queries.GetSMTPConfigs,
 		u.queries.GetFileSystemProvider,
 		u.queries.GetLogProvider,
+		u.queries.GetNotificationWebhookProvider,
 		colors,
 		u.assetsPrefix(ctx),
 		e,
 		u.metricSuccessfulDeliveriesEmail,
 		u.metricFailedDeliveriesEmail,
 	).SendUserInitCode(notifyUser, origin, code)
+	delivered, err := u.recordDelivery(ctx, e, domain.NotificationTypeEmail, notifyUser.LastEmail, domain.InitCodeMessageType, err)
 	if err != nil {
 		return nil, err
 	}
+	if !delivered {
+		return crdb.NewNoOpStatement(e), nil
+	}
 	err = u.commands.HumanInitCodeSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
 	if err != nil {
 		return nil, err
@@ -201,7 +285,7 @@ func (u *userNotifier) reduceEmailCodeAdded(event eventstore.Event) (*handler.St
 		return nil, err
 	}
 
-	template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	template, err := u.queries.GetMailTemplate(ctx, e.Aggregate().ResourceOwner, domain.VerifyEmailMessageType)
 	if err != nil {
 		return nil, err
 	}
@@ -221,21 +305,26 @@ func (u *userNotifier) reduceEmailCodeAdded(event eventstore.Event) (*handler.St
 	}
 	err = types.SendEmail(
 		ctx,
-		string(template.Template),
+		template,
 		translator,
 		notifyUser,
-		u.queries.GetSMTPConfig,
+		u.queries.GetSMTPConfigs,
 		u.queries.GetFileSystemProvider,
 		u.queries.GetLogProvider,
+		u.queries.GetNotificationWebhookProvider,
 		colors,
 		u.assetsPrefix(ctx),
 		e,
 		u.metricSuccessfulDeliveriesEmail,
 		u.metricFailedDeliveriesEmail,
 	).SendEmailVerificationCode(notifyUser, origin, code)
+	delivered, err := u.recordDelivery(ctx, e, domain.NotificationTypeEmail, notifyUser.LastEmail, domain.VerifyEmailMessageType, err)
 	if err != nil {
 		return nil, err
 	}
+	if !delivered {
+		return crdb.NewNoOpStatement(e), nil
+	}
 	err = u.commands.HumanEmailVerificationCodeSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
 	if err != nil {
 		return nil, err
@@ -267,7 +356,7 @@ func (u *userNotifier) reducePasswordCodeAdded(event eventstore.Event) (*handler
 		return nil, err
 	}
 
-	template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	template, err := u.queries.GetMailTemplate(ctx, e.Aggregate().ResourceOwner, domain.PasswordResetMessageType)
 	if err != nil {
 		return nil, err
 	}
@@ -285,14 +374,16 @@ func (u *userNotifier) reducePasswordCodeAdded(event eventstore.Event) (*handler
 	if err != nil {
 		return nil, err
 	}
+	channel, recipient := domain.NotificationTypeEmail, notifyUser.LastEmail
 	notify := types.SendEmail(
 		ctx,
-		string(template.Template),
+		template,
 		translator,
 		notifyUser,
-		u.queries.GetSMTPConfig,
+		u.queries.GetSMTPConfigs,
 		u.queries.GetFileSystemProvider,
 		u.queries.GetLogProvider,
+		u.queries.GetNotificationWebhookProvider,
 		colors,
 		u.assetsPrefix(ctx),
 		e,
@@ -300,13 +391,15 @@ func (u *userNotifier) reducePasswordCodeAdded(event eventstore.Event) (*handler
 		u.metricFailedDeliveriesEmail,
 	)
 	if e.NotificationType == domain.NotificationTypeSms {
-		notify = types.SendSMSTwilio(
+		channel, recipient = domain.NotificationTypeSms, notifyUser.LastPhone
+		notify = types.SendSMS(
 			ctx,
 			translator,
 			notifyUser,
-			u.queries.GetTwilioConfig,
+			u.queries.GetActiveSMSConfig,
 			u.queries.GetFileSystemProvider,
 			u.queries.GetLogProvider,
+			u.queries.GetNotificationWebhookProvider,
 			colors,
 			u.assetsPrefix(ctx),
 			e,
@@ -315,9 +408,13 @@ func (u *userNotifier) reducePasswordCodeAdded(event eventstore.Event) (*handler
 		)
 	}
 	err = notify.SendPasswordCode(notifyUser, origin, code)
+	delivered, err := u.recordDelivery(ctx, e, channel, recipient, domain.PasswordResetMessageType, err)
 	if err != nil {
 		return nil, err
 	}
+	if !delivered {
+		return crdb.NewNoOpStatement(e), nil
+	}
 	err = u.commands.PasswordCodeSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
 	if err != nil {
 		return nil, err
@@ -331,7 +428,7 @@ func (u *userNotifier) reduceDomainClaimed(event eventstore.Event) (*handler.Sta
 		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Drh5w", "reduce.wrong.event.type %s", user.UserDomainClaimedType)
 	}
 	ctx := HandlerContext(event.Aggregate())
-	alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, nil,
+	alreadyHandled, err := u.checkIfAlreadyHandled(ctx, event, nil,
 		user.UserDomainClaimedType, user.UserDomainClaimedSentType)
 	if err != nil {
 		return nil, err
@@ -344,7 +441,7 @@ func (u *userNotifier) reduceDomainClaimed(event eventstore.Event) (*handler.Sta
 		return nil, err
 	}
 
-	template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	template, err := u.queries.GetMailTemplate(ctx, e.Aggregate().ResourceOwner, domain.DomainClaimedMessageType)
 	if err != nil {
 		return nil, err
 	}
@@ -364,21 +461,26 @@ func (u *userNotifier) reduceDomainClaimed(event eventstore.Event) (*handler.Sta
 	}
 	err = types.SendEmail(
 		ctx,
-		string(template.Template),
+		template,
 		translator,
 		notifyUser,
-		u.queries.GetSMTPConfig,
+		u.queries.GetSMTPConfigs,
 		u.queries.GetFileSystemProvider,
 		u.queries.GetLogProvider,
+		u.queries.GetNotificationWebhookProvider,
 		colors,
 		u.assetsPrefix(ctx),
 		e,
 		u.metricSuccessfulDeliveriesEmail,
 		u.metricFailedDeliveriesEmail,
 	).SendDomainClaimed(notifyUser, origin, e.UserName)
+	delivered, err := u.recordDelivery(ctx, e, domain.NotificationTypeEmail, notifyUser.LastEmail, domain.DomainClaimedMessageType, err)
 	if err != nil {
 		return nil, err
 	}
+	if !delivered {
+		return crdb.NewNoOpStatement(e), nil
+	}
 	err = u.commands.UserDomainClaimedSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
 	if err != nil {
 		return nil, err
@@ -408,7 +510,7 @@ func (u *userNotifier) reducePasswordlessCodeRequested(event eventstore.Event) (
 		return nil, err
 	}
 
-	template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	template, err := u.queries.GetMailTemplate(ctx, e.Aggregate().ResourceOwner, domain.PasswordlessRegistrationMessageType)
 	if err != nil {
 		return nil, err
 	}
@@ -428,21 +530,26 @@ func (u *userNotifier) reducePasswordlessCodeRequested(event eventstore.Event) (
 	}
 	err = types.SendEmail(
 		ctx,
-		string(template.Template),
+		template,
 		translator,
 		notifyUser,
-		u.queries.GetSMTPConfig,
+		u.queries.GetSMTPConfigs,
 		u.queries.GetFileSystemProvider,
 		u.queries.GetLogProvider,
+		u.queries.GetNotificationWebhookProvider,
 		colors,
 		u.assetsPrefix(ctx),
 		e,
 		u.metricSuccessfulDeliveriesEmail,
 		u.metricFailedDeliveriesEmail,
 	).SendPasswordlessRegistrationLink(notifyUser, origin, code, e.ID)
+	delivered, err := u.recordDelivery(ctx, e, domain.NotificationTypeEmail, notifyUser.LastEmail, domain.PasswordlessRegistrationMessageType, err)
 	if err != nil {
 		return nil, err
 	}
+	if !delivered {
+		return crdb.NewNoOpStatement(e), nil
+	}
 	err = u.commands.HumanPasswordlessInitCodeSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner, e.ID)
 	if err != nil {
 		return nil, err
@@ -450,13 +557,85 @@ func (u *userNotifier) reducePasswordlessCodeRequested(event eventstore.Event) (
 	return crdb.NewNoOpStatement(e), nil
 }
 
+func (u *userNotifier) reduceMagicLinkRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanMagicLinkRequestedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rk2sL", "reduce.wrong.event.type %s", user.HumanMagicLinkRequestedType)
+	}
+	if e.AuthRequestInfo == nil {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	ctx := HandlerContext(event.Aggregate())
+	alreadyHandled, err := u.checkIfCodeAlreadyHandledOrExpired(ctx, event, e.Expiry, map[string]interface{}{"linkID": e.LinkID}, user.HumanMagicLinkSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	code, err := crypto.DecryptString(e.Code, u.queries.UserDataCrypto)
+	if err != nil {
+		return nil, err
+	}
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := u.queries.GetMailTemplate(ctx, e.Aggregate().ResourceOwner, domain.MagicLinkMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID, false)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.MagicLinkMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := u.queries.Origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = types.SendEmail(
+		ctx,
+		template,
+		translator,
+		notifyUser,
+		u.queries.GetSMTPConfigs,
+		u.queries.GetFileSystemProvider,
+		u.queries.GetLogProvider,
+		u.queries.GetNotificationWebhookProvider,
+		colors,
+		u.assetsPrefix(ctx),
+		e,
+		u.metricSuccessfulDeliveriesEmail,
+		u.metricFailedDeliveriesEmail,
+	).SendMagicLink(notifyUser, origin, e.AuthRequestInfo.ID, e.LinkID, code)
+	delivered, err := u.recordDelivery(ctx, e, domain.NotificationTypeEmail, notifyUser.LastEmail, domain.MagicLinkMessageType, err)
+	if err != nil {
+		return nil, err
+	}
+	if !delivered {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	err = u.commands.HumanMagicLinkSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner, e.LinkID)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
 func (u *userNotifier) reducePasswordChanged(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*user.HumanPasswordChangedEvent)
 	if !ok {
 		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Yko2z8", "reduce.wrong.event.type %s", user.HumanPasswordChangedType)
 	}
 	ctx := HandlerContext(event.Aggregate())
-	alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, nil, user.HumanPasswordChangeSentType)
+	alreadyHandled, err := u.checkIfAlreadyHandled(ctx, event, nil, user.HumanPasswordChangeSentType)
 	if err != nil {
 		return nil, err
 	}
@@ -478,7 +657,7 @@ func (u *userNotifier) reducePasswordChanged(event eventstore.Event) (*handler.S
 			return nil, err
 		}
 
-		template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		template, err := u.queries.GetMailTemplate(ctx, e.Aggregate().ResourceOwner, domain.PasswordChangeMessageType)
 		if err != nil {
 			return nil, err
 		}
@@ -498,21 +677,26 @@ func (u *userNotifier) reducePasswordChanged(event eventstore.Event) (*handler.S
 		}
 		err = types.SendEmail(
 			ctx,
-			string(template.Template),
+			template,
 			translator,
 			notifyUser,
-			u.queries.GetSMTPConfig,
+			u.queries.GetSMTPConfigs,
 			u.queries.GetFileSystemProvider,
 			u.queries.GetLogProvider,
+			u.queries.GetNotificationWebhookProvider,
 			colors,
 			u.assetsPrefix(ctx),
 			e,
 			u.metricSuccessfulDeliveriesEmail,
 			u.metricFailedDeliveriesEmail,
 		).SendPasswordChange(notifyUser, origin)
+		delivered, err := u.recordDelivery(ctx, e, domain.NotificationTypeEmail, notifyUser.LastEmail, domain.PasswordChangeMessageType, err)
 		if err != nil {
 			return nil, err
 		}
+		if !delivered {
+			return crdb.NewNoOpStatement(e), nil
+		}
 		err = u.commands.PasswordChangeSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
 		if err != nil {
 			return nil, err
@@ -558,22 +742,27 @@ func (u *userNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.St
 	if err != nil {
 		return nil, err
 	}
-	err = types.SendSMSTwilio(
+	err = types.SendSMS(
 		ctx,
 		translator,
 		notifyUser,
-		u.queries.GetTwilioConfig,
+		u.queries.GetActiveSMSConfig,
 		u.queries.GetFileSystemProvider,
 		u.queries.GetLogProvider,
+		u.queries.GetNotificationWebhookProvider,
 		colors,
 		u.assetsPrefix(ctx),
 		e,
 		u.metricSuccessfulDeliveriesSMS,
 		u.metricFailedDeliveriesSMS,
 	).SendPhoneVerificationCode(notifyUser, origin, code)
+	delivered, err := u.recordDelivery(ctx, e, domain.NotificationTypeSms, notifyUser.LastPhone, domain.VerifyPhoneMessageType, err)
 	if err != nil {
 		return nil, err
 	}
+	if !delivered {
+		return crdb.NewNoOpStatement(e), nil
+	}
 	err = u.commands.HumanPhoneVerificationCodeSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
 	if err != nil {
 		return nil, err
@@ -581,9 +770,161 @@ func (u *userNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.St
 	return crdb.NewNoOpStatement(e), nil
 }
 
+func (u *userNotifier) reducePhoneOTPRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPhoneOTPRequestedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Gk3fe", "reduce.wrong.event.type %s", user.HumanPhoneOTPRequestedType)
+	}
+	if e.AuthRequestInfo == nil {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	ctx := HandlerContext(event.Aggregate())
+	alreadyHandled, err := u.checkIfCodeAlreadyHandledOrExpired(ctx, event, e.Expiry, nil,
+		user.HumanPhoneOTPRequestedType, user.HumanPhoneOTPSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	code, err := crypto.DecryptString(e.Code, u.queries.UserDataCrypto)
+	if err != nil {
+		return nil, err
+	}
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID, false)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.PhoneOTPMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := u.queries.Origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = types.SendSMS(
+		ctx,
+		translator,
+		notifyUser,
+		u.queries.GetActiveSMSConfig,
+		u.queries.GetFileSystemProvider,
+		u.queries.GetLogProvider,
+		u.queries.GetNotificationWebhookProvider,
+		colors,
+		u.assetsPrefix(ctx),
+		e,
+		u.metricSuccessfulDeliveriesSMS,
+		u.metricFailedDeliveriesSMS,
+	).SendPhoneOTP(notifyUser, origin, code)
+	delivered, err := u.recordDelivery(ctx, e, domain.NotificationTypeSms, notifyUser.LastPhone, domain.PhoneOTPMessageType, err)
+	if err != nil {
+		return nil, err
+	}
+	if !delivered {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	err = u.commands.HumanPhoneOTPSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
+func (u *userNotifier) reducePushChallengeRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPushChallengeRequestedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Pu5ca", "reduce.wrong.event.type %s", user.HumanPushChallengeRequestedType)
+	}
+	if e.AuthRequestInfo == nil {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	ctx := HandlerContext(event.Aggregate())
+	alreadyHandled, err := u.checkIfCodeAlreadyHandledOrExpired(ctx, event, e.Expiry, map[string]interface{}{"challengeId": e.ChallengeID},
+		user.HumanPushChallengeSentType, user.HumanPushCheckSucceededType, user.HumanPushCheckFailedType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	recipients := make([]*types.PushRecipient, 0, len(e.Targets))
+	deviceIDs := make([]string, 0, len(e.Targets))
+	for _, target := range e.Targets {
+		token, err := crypto.DecryptString(target.Token, u.queries.UserDataCrypto)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, &types.PushRecipient{
+			DeviceID: target.DeviceID,
+			Platform: target.Platform,
+			Token:    token,
+		})
+		deviceIDs = append(deviceIDs, target.DeviceID)
+	}
+
+	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID, false)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.MFAPushMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make(map[string]interface{})
+	data := map[string]string{
+		"type":        domain.MFAPushMessageType,
+		"challengeId": e.ChallengeID,
+		"userId":      e.Aggregate().ID,
+		"orgId":       e.Aggregate().ResourceOwner,
+	}
+	if e.BrowserInfo != nil {
+		args["UserAgent"] = e.BrowserInfo.UserAgent
+		data["userAgent"] = e.BrowserInfo.UserAgent
+		if e.BrowserInfo.RemoteIP != nil {
+			args["RemoteIP"] = e.BrowserInfo.RemoteIP.String()
+			data["remoteIp"] = e.BrowserInfo.RemoteIP.String()
+		}
+	}
+	err = types.SendMFAPush(
+		ctx,
+		translator,
+		notifyUser,
+		u.queries.pushConfig,
+		u.queries.GetFileSystemProvider,
+		u.queries.GetLogProvider,
+		recipients,
+		args,
+		data,
+		e.CreationDate().Add(e.Expiry),
+		e,
+		u.metricSuccessfulDeliveriesPush,
+		u.metricFailedDeliveriesPush,
+	)
+	delivered, err := u.recordDelivery(ctx, e, domain.NotificationTypePush, strings.Join(deviceIDs, ","), domain.MFAPushMessageType, err)
+	if err != nil {
+		return nil, err
+	}
+	if !delivered {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	err = u.commands.HumanPushChallengeSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner, e.ChallengeID)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
 func (u *userNotifier) checkIfCodeAlreadyHandledOrExpired(ctx context.Context, event eventstore.Event, expiry time.Duration, data map[string]interface{}, eventTypes ...eventstore.EventType) (bool, error) {
 	if event.CreationDate().Add(expiry).Before(time.Now().UTC()) {
-		return true, nil
+		return true, u.cancelDelivery(ctx, event, "code expired")
 	}
-	return u.queries.IsAlreadyHandled(ctx, event, data, eventTypes...)
+	return u.checkIfAlreadyHandled(ctx, event, data, eventTypes...)
 }
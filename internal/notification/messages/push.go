@@ -0,0 +1,32 @@
+package messages
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+)
+
+var _ channels.Message = (*Push)(nil)
+
+type Push struct {
+	Platform    domain.PushPlatform
+	DeviceID    string
+	DeviceToken string
+	Title       string
+	Body        string
+	// Data is passed to the app alongside the notification, e.g. the id of the challenge to answer
+	Data map[string]string
+	// Expiration is the time after which the push service can discard the notification
+	Expiration      time.Time
+	TriggeringEvent eventstore.Event
+}
+
+func (msg *Push) GetContent() (string, error) {
+	return msg.Body, nil
+}
+
+func (msg *Push) GetTriggeringEvent() eventstore.Event {
+	return msg.TriggeringEvent
+}
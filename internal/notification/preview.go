@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"context"
+
+	statik_fs "github.com/rakyll/statik/fs"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/notification/channels/push"
+	"github.com/zitadel/zitadel/internal/notification/handlers"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/static"
+)
+
+// MessagePreviewer renders emails of an organization with sample data, e.g. to check custom mail templates
+type MessagePreviewer struct {
+	queries      *handlers.NotificationQueries
+	assetsPrefix func(context.Context) string
+}
+
+func NewMessagePreviewer(
+	queries *query.Queries,
+	externalPort uint16,
+	externalSecure bool,
+	assetsPrefix func(context.Context) string,
+	storage static.Storage,
+) (*MessagePreviewer, error) {
+	statikFS, err := statik_fs.NewWithNamespace("notification")
+	if err != nil {
+		return nil, err
+	}
+	return &MessagePreviewer{
+		queries:      handlers.NewNotificationQueries(queries, nil, externalPort, externalSecure, "", push.Config{}, nil, nil, nil, statikFS, storage),
+		assetsPrefix: assetsPrefix,
+	}, nil
+}
+
+// PreviewMessage returns the subject and the html content of the message type,
+// the template is optional and replaces the stored layout of the organization
+func (p *MessagePreviewer) PreviewMessage(ctx context.Context, orgID, messageType string, lang language.Tag, template []byte) (subject, content string, err error) {
+	return p.queries.PreviewMessage(ctx, orgID, messageType, lang, template, p.assetsPrefix(ctx))
+}
@@ -9,10 +9,12 @@ import (
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels/push"
 	"github.com/zitadel/zitadel/internal/notification/handlers"
 	_ "github.com/zitadel/zitadel/internal/notification/statik"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/static"
 	"github.com/zitadel/zitadel/internal/telemetry/metrics"
 )
 
@@ -21,6 +23,8 @@ const (
 	metricFailedDeliveriesEmail     = "failed_deliveries_email"
 	metricSuccessfulDeliveriesSMS   = "successful_deliveries_sms"
 	metricFailedDeliveriesSMS       = "failed_deliveries_sms"
+	metricSuccessfulDeliveriesPush  = "successful_deliveries_push"
+	metricFailedDeliveriesPush      = "failed_deliveries_push"
 	metricSuccessfulDeliveriesJSON  = "successful_deliveries_json"
 	metricFailedDeliveriesJSON      = "failed_deliveries_json"
 )
@@ -36,9 +40,11 @@ func Start(
 	es *eventstore.Eventstore,
 	assetsPrefix func(context.Context) string,
 	fileSystemPath string,
+	pushConfig push.Config,
 	userEncryption,
 	smtpEncryption,
 	smsEncryption crypto.EncryptionAlgorithm,
+	storage static.Storage,
 ) {
 	statikFS, err := statik_fs.NewWithNamespace("notification")
 	logging.OnError(err).Panic("unable to start listener")
@@ -50,12 +56,16 @@ func Start(
 	logging.WithFields("metric", metricSuccessfulDeliveriesSMS).OnError(err).Panic("unable to register counter")
 	err = metrics.RegisterCounter(metricFailedDeliveriesSMS, "Failed SMS deliveries")
 	logging.WithFields("metric", metricFailedDeliveriesSMS).OnError(err).Panic("unable to register counter")
+	err = metrics.RegisterCounter(metricSuccessfulDeliveriesPush, "Successfully delivered push notifications")
+	logging.WithFields("metric", metricSuccessfulDeliveriesPush).OnError(err).Panic("unable to register counter")
+	err = metrics.RegisterCounter(metricFailedDeliveriesPush, "Failed push notification deliveries")
+	logging.WithFields("metric", metricFailedDeliveriesPush).OnError(err).Panic("unable to register counter")
 	err = metrics.RegisterCounter(metricSuccessfulDeliveriesJSON, "Successfully delivered JSON messages")
 	logging.WithFields("metric", metricSuccessfulDeliveriesJSON).OnError(err).Panic("unable to register counter")
 	err = metrics.RegisterCounter(metricFailedDeliveriesJSON, "Failed JSON message deliveries")
 	logging.WithFields("metric", metricFailedDeliveriesJSON).OnError(err).Panic("unable to register counter")
-	q := handlers.NewNotificationQueries(queries, es, externalPort, externalSecure, fileSystemPath, userEncryption, smtpEncryption, smsEncryption, statikFS)
-	handlers.NewUserNotifier(
+	q := handlers.NewNotificationQueries(queries, es, externalPort, externalSecure, fileSystemPath, pushConfig, userEncryption, smtpEncryption, smsEncryption, statikFS, storage)
+	userNotifier := handlers.NewUserNotifier(
 		ctx,
 		projection.ApplyCustomConfig(userHandlerCustomConfig),
 		commands,
@@ -65,7 +75,11 @@ func Start(
 		metricFailedDeliveriesEmail,
 		metricSuccessfulDeliveriesSMS,
 		metricFailedDeliveriesSMS,
-	).Start()
+		metricSuccessfulDeliveriesPush,
+		metricFailedDeliveriesPush,
+	)
+	userNotifier.Start()
+	userNotifier.StartOutbox(ctx)
 	handlers.NewQuotaNotifier(
 		ctx,
 		projection.ApplyCustomConfig(quotaHandlerCustomConfig),
@@ -1,8 +1,15 @@
 package senders
 
-import "github.com/zitadel/zitadel/internal/notification/channels"
+import (
+	"github.com/zitadel/logging"
 
-var _ channels.NotificationChannel = (*Chain)(nil)
+	"github.com/zitadel/zitadel/internal/notification/channels"
+)
+
+var (
+	_ channels.NotificationChannel = (*Chain)(nil)
+	_ channels.NotificationChannel = (*Failover)(nil)
+)
 
 type Chain struct {
 	channels []channels.NotificationChannel
@@ -26,3 +33,23 @@ func (c *Chain) HandleMessage(message channels.Message) error {
 func (c *Chain) Len() int {
 	return len(c.channels)
 }
+
+type Failover struct {
+	channels []channels.NotificationChannel
+}
+
+func failoverChannels(channel ...channels.NotificationChannel) *Failover {
+	return &Failover{channels: channel}
+}
+
+// HandleMessage sends the message to the channels in the same order they were provided to failoverChannels()
+// until one of them succeeds, the error of the last channel is returned if all of them fail
+func (f *Failover) HandleMessage(message channels.Message) (err error) {
+	for i := range f.channels {
+		if err = f.channels[i].HandleMessage(message); err == nil {
+			return nil
+		}
+		logging.WithFields("channel", i).WithError(err).Warn("sending message failed, trying next channel")
+	}
+	return err
+}
@@ -15,31 +15,34 @@ import (
 
 const smtpSpanName = "smtp.NotificationChannel"
 
+// EmailChannels sends the message to the first of the passed smtp configs which succeeds,
+// the configs must be sorted by priority
 func EmailChannels(
 	ctx context.Context,
-	emailConfig func(ctx context.Context) (*smtp.Config, error),
+	emailConfigs []*smtp.Config,
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
 	successMetricName,
 	failureMetricName string,
 ) (chain *Chain, err error) {
-	channels := make([]channels.NotificationChannel, 0, 3)
-	p, err := smtp.InitChannel(ctx, emailConfig)
-	logging.WithFields(
-		"instance", authz.GetInstance(ctx).InstanceID(),
-	).OnError(err).Debug("initializing SMTP channel failed")
-	if err == nil {
-		channels = append(
-			channels,
-			instrumenting.Wrap(
-				ctx,
-				p,
-				smtpSpanName,
-				successMetricName,
-				failureMetricName,
-			),
+	smtpChannels := make([]channels.NotificationChannel, len(emailConfigs))
+	for i, emailConfig := range emailConfigs {
+		smtpChannels[i] = instrumenting.Wrap(
+			ctx,
+			smtp.InitChannel(emailConfig),
+			smtpSpanName,
+			successMetricName,
+			failureMetricName,
 		)
 	}
+	channels := make([]channels.NotificationChannel, 0, 3)
+	if len(smtpChannels) > 0 {
+		channels = append(channels, failoverChannels(smtpChannels...))
+	} else {
+		logging.WithFields(
+			"instance", authz.GetInstance(ctx).InstanceID(),
+		).Debug("no SMTP config found")
+	}
 	channels = append(channels, debugChannels(ctx, getFileSystemProvider, getLogProvider)...)
 	return chainChannels(channels...), nil
 }
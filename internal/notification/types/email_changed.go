@@ -0,0 +1,13 @@
+package types
+
+import (
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// SendEmailChanged is sent to the previous verified email address of the user
+func (notify Notify) SendEmailChanged(user *query.NotifyUser, origin string) error {
+	url := console.LoginHintLink(origin, user.PreferredLoginName)
+	return notify(url, nil, domain.EmailChangedMessageType, false)
+}
@@ -0,0 +1,12 @@
+package types
+
+import (
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendMagicLink(user *query.NotifyUser, origin, authRequestID, linkID, code string) error {
+	url := domain.MagicLinkURL(origin+login.HandlerPrefix+login.EndpointMagicLink, authRequestID, user.ID, user.ResourceOwner, linkID, code)
+	return notify(url, nil, domain.MagicLinkMessageType, true)
+}
@@ -0,0 +1,17 @@
+package types
+
+import (
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendMFAAdded(user *query.NotifyUser, origin string) error {
+	url := console.LoginHintLink(origin, user.PreferredLoginName)
+	return notify(url, nil, domain.MFAAddedMessageType, false)
+}
+
+func (notify Notify) SendMFARemoved(user *query.NotifyUser, origin string) error {
+	url := console.LoginHintLink(origin, user.PreferredLoginName)
+	return notify(url, nil, domain.MFARemovedMessageType, false)
+}
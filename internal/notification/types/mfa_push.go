@@ -0,0 +1,85 @@
+package types
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/notification/channels/fs"
+	"github.com/zitadel/zitadel/internal/notification/channels/log"
+	"github.com/zitadel/zitadel/internal/notification/channels/push"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/notification/senders"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// PushRecipient is a device of the user the push notification is sent to
+type PushRecipient struct {
+	DeviceID string
+	Platform domain.PushPlatform
+	Token    string
+}
+
+// SendMFAPush asks the user to approve a sign-in on all of the passed devices,
+// it only fails if the notification couldn't be sent to any of them
+func SendMFAPush(
+	ctx context.Context,
+	translator *i18n.Translator,
+	user *query.NotifyUser,
+	pushConfig push.Config,
+	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
+	getLogProvider func(ctx context.Context) (*log.Config, error),
+	recipients []*PushRecipient,
+	args map[string]interface{},
+	data map[string]string,
+	expiration time.Time,
+	triggeringEvent eventstore.Event,
+	successMetricName,
+	failureMetricName string,
+) error {
+	args = mapNotifyUserToArgs(user, args)
+	texts := new(templates.TemplateData)
+	texts.Translate(translator, domain.MFAPushMessageType, args, user.PreferredLanguage.String())
+
+	channelChain, err := senders.PushChannels(
+		ctx,
+		pushConfig,
+		getFileSystemProvider,
+		getLogProvider,
+		successMetricName,
+		failureMetricName,
+	)
+	logging.OnError(err).Error("could not create push channel")
+	if channelChain.Len() == 0 {
+		return errors.ThrowPreconditionFailed(nil, "PUSH-Nt5cp", "Errors.Notification.Channels.NotPresent")
+	}
+	if len(recipients) == 0 {
+		return errors.ThrowPreconditionFailed(nil, "PUSH-Nt5rc", "Errors.Notification.NoRecipient")
+	}
+
+	var sent bool
+	for _, recipient := range recipients {
+		err = channelChain.HandleMessage(&messages.Push{
+			Platform:        recipient.Platform,
+			DeviceID:        recipient.DeviceID,
+			DeviceToken:     recipient.Token,
+			Title:           texts.Subject,
+			Body:            texts.Text,
+			Data:            data,
+			Expiration:      expiration,
+			TriggeringEvent: triggeringEvent,
+		})
+		logging.WithFields("device", recipient.DeviceID).OnError(err).Warn("could not send push notification")
+		sent = sent || err == nil
+	}
+	if !sent {
+		return err
+	}
+	return nil
+}
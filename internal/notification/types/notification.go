@@ -8,8 +8,8 @@ import (
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
-	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
+	"github.com/zitadel/zitadel/internal/notification/senders"
 	"github.com/zitadel/zitadel/internal/notification/templates"
 	"github.com/zitadel/zitadel/internal/query"
 )
@@ -26,9 +26,10 @@ func SendEmail(
 	mailhtml string,
 	translator *i18n.Translator,
 	user *query.NotifyUser,
-	emailConfig func(ctx context.Context) (*smtp.Config, error),
+	emailConfigs func(ctx context.Context, orgID string) ([]*smtp.Config, error),
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
+	getWebhookProvider func(ctx context.Context) (*webhook.Config, error),
 	colors *query.LabelPolicy,
 	assetsPrefix string,
 	triggeringEvent eventstore.Event,
@@ -47,12 +48,38 @@ func SendEmail(
 		if err != nil {
 			return err
 		}
+		webhookConfig, err := webhookProvider(ctx, getWebhookProvider)
+		if err != nil {
+			return err
+		}
+		if webhookConfig != nil {
+			recipient := user.VerifiedEmail
+			if allowUnverifiedNotificationChannel {
+				recipient = user.LastEmail
+			}
+			return generateUserNotificationJSON(
+				ctx,
+				webhookConfig,
+				user,
+				userNotificationChannelEmail,
+				recipient,
+				messageType,
+				template,
+				data,
+				args,
+				getFileSystemProvider,
+				getLogProvider,
+				triggeringEvent,
+				successMetricName,
+				failureMetricName,
+			)
+		}
 		return generateEmail(
 			ctx,
 			user,
 			data.Subject,
 			template,
-			emailConfig,
+			emailConfigs,
 			getFileSystemProvider,
 			getLogProvider,
 			allowUnverifiedNotificationChannel,
@@ -63,13 +90,14 @@ func SendEmail(
 	}
 }
 
-func SendSMSTwilio(
+func SendSMS(
 	ctx context.Context,
 	translator *i18n.Translator,
 	user *query.NotifyUser,
-	twilioConfig func(ctx context.Context) (*twilio.Config, error),
+	smsConfig func(ctx context.Context) (*senders.SMSConfig, error),
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
+	getWebhookProvider func(ctx context.Context) (*webhook.Config, error),
 	colors *query.LabelPolicy,
 	assetsPrefix string,
 	triggeringEvent eventstore.Event,
@@ -84,11 +112,37 @@ func SendSMSTwilio(
 	) error {
 		args = mapNotifyUserToArgs(user, args)
 		data := GetTemplateData(translator, args, assetsPrefix, url, messageType, user.PreferredLanguage.String(), colors)
+		webhookConfig, err := webhookProvider(ctx, getWebhookProvider)
+		if err != nil {
+			return err
+		}
+		if webhookConfig != nil {
+			recipient := user.VerifiedPhone
+			if allowUnverifiedNotificationChannel {
+				recipient = user.LastPhone
+			}
+			return generateUserNotificationJSON(
+				ctx,
+				webhookConfig,
+				user,
+				userNotificationChannelSMS,
+				recipient,
+				messageType,
+				"",
+				data,
+				args,
+				getFileSystemProvider,
+				getLogProvider,
+				triggeringEvent,
+				successMetricName,
+				failureMetricName,
+			)
+		}
 		return generateSms(
 			ctx,
 			user,
 			data.Text,
-			twilioConfig,
+			smsConfig,
 			getFileSystemProvider,
 			getLogProvider,
 			allowUnverifiedNotificationChannel,
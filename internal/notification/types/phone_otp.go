@@ -0,0 +1,12 @@
+package types
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendPhoneOTP(user *query.NotifyUser, origin, code string) error {
+	args := make(map[string]interface{})
+	args["Code"] = code
+	return notify("", args, domain.PhoneOTPMessageType, true)
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"html"
+
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// PreviewEmail renders the email of the message type the same way SendEmail does, without sending it
+func PreviewEmail(
+	mailhtml string,
+	translator *i18n.Translator,
+	user *query.NotifyUser,
+	colors *query.LabelPolicy,
+	assetsPrefix,
+	url,
+	messageType string,
+	args map[string]interface{},
+) (subject, content string, err error) {
+	args = mapNotifyUserToArgs(user, args)
+	data := GetTemplateData(translator, args, assetsPrefix, url, messageType, user.PreferredLanguage.String(), colors)
+	content, err = templates.GetParsedTemplate(mailhtml, data)
+	if err != nil {
+		return "", "", err
+	}
+	return data.Subject, html.UnescapeString(content), nil
+}
@@ -19,7 +19,7 @@ func generateEmail(
 	user *query.NotifyUser,
 	subject,
 	content string,
-	smtpConfig func(ctx context.Context) (*smtp.Config, error),
+	smtpConfigs func(ctx context.Context, orgID string) ([]*smtp.Config, error),
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
 	lastEmail bool,
@@ -38,9 +38,13 @@ func generateEmail(
 		message.Recipients = []string{user.LastEmail}
 	}
 
+	configs, err := smtpConfigs(ctx, user.ResourceOwner)
+	if err != nil {
+		return err
+	}
 	channelChain, err := senders.EmailChannels(
 		ctx,
-		smtpConfig,
+		configs,
 		getFileSystemProvider,
 		getLogProvider,
 		successMetricName,
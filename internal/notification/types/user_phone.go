@@ -9,7 +9,6 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
-	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/notification/messages"
 	"github.com/zitadel/zitadel/internal/notification/senders"
 	"github.com/zitadel/zitadel/internal/query"
@@ -19,7 +18,7 @@ func generateSms(
 	ctx context.Context,
 	user *query.NotifyUser,
 	content string,
-	getTwilioProvider func(ctx context.Context) (*twilio.Config, error),
+	getSMSProvider func(ctx context.Context) (*senders.SMSConfig, error),
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
 	lastPhone bool,
@@ -28,9 +27,9 @@ func generateSms(
 	failureMetricName string,
 ) error {
 	number := ""
-	twilioConfig, err := getTwilioProvider(ctx)
-	if err == nil {
-		number = twilioConfig.SenderNumber
+	smsConfig, err := getSMSProvider(ctx)
+	if err == nil && smsConfig.TwilioConfig != nil {
+		number = smsConfig.TwilioConfig.SenderNumber
 	}
 	message := &messages.SMS{
 		SenderPhoneNumber:    number,
@@ -44,7 +43,7 @@ func generateSms(
 
 	channelChain, err := senders.SMSChannels(
 		ctx,
-		twilioConfig,
+		smsConfig,
 		getFileSystemProvider,
 		getLogProvider,
 		successMetricName,
@@ -0,0 +1,107 @@
+package types
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels/fs"
+	"github.com/zitadel/zitadel/internal/notification/channels/log"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	userNotificationChannelEmail = "email"
+	userNotificationChannelSMS   = "sms"
+)
+
+// UserNotification is sent as JSON to the webhook notification provider of the instance
+// instead of sending the notification through the smtp or sms provider,
+// the channel is the one the notification would have been sent through (email or sms)
+type UserNotification struct {
+	InstanceID    string                 `json:"instanceId"`
+	EventType     string                 `json:"eventType"`
+	AggregateID   string                 `json:"aggregateId"`
+	Sequence      uint64                 `json:"sequence"`
+	MessageType   string                 `json:"messageType"`
+	Channel       string                 `json:"channel"`
+	UserID        string                 `json:"userId"`
+	ResourceOwner string                 `json:"resourceOwner"`
+	Language      string                 `json:"language"`
+	Recipient     string                 `json:"recipient"`
+	Title         string                 `json:"title,omitempty"`
+	Subject       string                 `json:"subject,omitempty"`
+	Greeting      string                 `json:"greeting,omitempty"`
+	Text          string                 `json:"text"`
+	ButtonText    string                 `json:"buttonText,omitempty"`
+	URL           string                 `json:"url,omitempty"`
+	HTML          string                 `json:"html,omitempty"`
+	Args          map[string]interface{} `json:"args,omitempty"`
+}
+
+// webhookProvider returns the webhook notification provider of the instance
+// or nil if none is configured
+func webhookProvider(
+	ctx context.Context,
+	getWebhookProvider func(ctx context.Context) (*webhook.Config, error),
+) (*webhook.Config, error) {
+	config, err := getWebhookProvider(ctx)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	return config, err
+}
+
+func generateUserNotificationJSON(
+	ctx context.Context,
+	webhookConfig *webhook.Config,
+	user *query.NotifyUser,
+	channel,
+	recipient,
+	messageType,
+	content string,
+	data templates.TemplateData,
+	args map[string]interface{},
+	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
+	getLogProvider func(ctx context.Context) (*log.Config, error),
+	triggeringEvent eventstore.Event,
+	successMetricName,
+	failureMetricName string,
+) error {
+	if recipient == "" {
+		return errors.ThrowPreconditionFailed(nil, "WEBH-Wh7rc", "Errors.Notification.NoRecipient")
+	}
+	notification := &UserNotification{
+		InstanceID:    authz.GetInstance(ctx).InstanceID(),
+		EventType:     string(triggeringEvent.Type()),
+		AggregateID:   triggeringEvent.Aggregate().ID,
+		Sequence:      triggeringEvent.Sequence(),
+		MessageType:   messageType,
+		Channel:       channel,
+		UserID:        user.ID,
+		ResourceOwner: user.ResourceOwner,
+		Language:      user.PreferredLanguage.String(),
+		Recipient:     recipient,
+		Title:         data.Title,
+		Subject:       data.Subject,
+		Greeting:      data.Greeting,
+		Text:          data.Text,
+		ButtonText:    data.ButtonText,
+		URL:           data.URL,
+		HTML:          content,
+		Args:          args,
+	}
+	return handleJSON(
+		ctx,
+		*webhookConfig,
+		getFileSystemProvider,
+		getLogProvider,
+		notification,
+		triggeringEvent,
+		successMetricName,
+		failureMetricName,
+	)
+}
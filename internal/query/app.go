@@ -58,12 +58,20 @@ type OIDCApp struct {
 	AdditionalOrigins        database.StringArray
 	AllowedOrigins           database.StringArray
 	SkipNativeAppSuccessPage bool
+	RequireConsent           bool
 }
 
 type SAMLApp struct {
-	Metadata    []byte
-	MetadataURL string
-	EntityID    string
+	Metadata           []byte
+	MetadataURL        string
+	EntityID           string
+	IDPInitiatedSSO    bool
+	DefaultRelayState  string
+	SignatureAlgorithm domain.SAMLSignatureAlgorithm
+	SigningMode        domain.SAMLSigningMode
+	EncryptAssertion   bool
+	NameIDFormat       domain.SAMLNameIDFormat
+	AttributeMappings  domain.SAMLAttributeMappings
 }
 
 type APIApp struct {
@@ -152,6 +160,34 @@ var (
 		name:  projection.AppSAMLConfigColumnMetadataURL,
 		table: appSAMLConfigsTable,
 	}
+	AppSAMLConfigColumnIDPInitiatedSSO = Column{
+		name:  projection.AppSAMLConfigColumnIDPInitiatedSSO,
+		table: appSAMLConfigsTable,
+	}
+	AppSAMLConfigColumnDefaultRelayState = Column{
+		name:  projection.AppSAMLConfigColumnDefaultRelayState,
+		table: appSAMLConfigsTable,
+	}
+	AppSAMLConfigColumnSignatureAlgorithm = Column{
+		name:  projection.AppSAMLConfigColumnSignatureAlgorithm,
+		table: appSAMLConfigsTable,
+	}
+	AppSAMLConfigColumnSigningMode = Column{
+		name:  projection.AppSAMLConfigColumnSigningMode,
+		table: appSAMLConfigsTable,
+	}
+	AppSAMLConfigColumnEncryptAssertion = Column{
+		name:  projection.AppSAMLConfigColumnEncryptAssertion,
+		table: appSAMLConfigsTable,
+	}
+	AppSAMLConfigColumnNameIDFormat = Column{
+		name:  projection.AppSAMLConfigColumnNameIDFormat,
+		table: appSAMLConfigsTable,
+	}
+	AppSAMLConfigColumnAttributeMappings = Column{
+		name:  projection.AppSAMLConfigColumnAttributeMappings,
+		table: appSAMLConfigsTable,
+	}
 )
 
 var (
@@ -246,6 +282,10 @@ var (
 		name:  projection.AppOIDCConfigColumnSkipNativeAppSuccessPage,
 		table: appOIDCConfigsTable,
 	}
+	AppOIDCConfigColumnRequireConsent = Column{
+		name:  projection.AppOIDCConfigColumnRequireConsent,
+		table: appOIDCConfigsTable,
+	}
 )
 
 func (q *Queries) AppByProjectAndAppID(ctx context.Context, shouldTriggerBulk bool, projectID, appID string, withOwnerRemoved bool) (_ *App, err error) {
@@ -541,11 +581,19 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnRequireConsent.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
 			AppSAMLConfigColumnMetadata.identifier(),
 			AppSAMLConfigColumnMetadataURL.identifier(),
+			AppSAMLConfigColumnIDPInitiatedSSO.identifier(),
+			AppSAMLConfigColumnDefaultRelayState.identifier(),
+			AppSAMLConfigColumnSignatureAlgorithm.identifier(),
+			AppSAMLConfigColumnSigningMode.identifier(),
+			AppSAMLConfigColumnEncryptAssertion.identifier(),
+			AppSAMLConfigColumnNameIDFormat.identifier(),
+			AppSAMLConfigColumnAttributeMappings.identifier(),
 		).From(appsTable.identifier()).
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
 			LeftJoin(join(AppOIDCConfigColumnAppID, AppColumnID)).
@@ -590,11 +638,19 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 				&oidcConfig.clockSkew,
 				&oidcConfig.additionalOrigins,
 				&oidcConfig.skipNativeAppSuccessPage,
+				&oidcConfig.requireConsent,
 
 				&samlConfig.appID,
 				&samlConfig.entityID,
 				&samlConfig.metadata,
 				&samlConfig.metadataURL,
+				&samlConfig.idpInitiatedSSO,
+				&samlConfig.defaultRelayState,
+				&samlConfig.signatureAlgorithm,
+				&samlConfig.signingMode,
+				&samlConfig.encryptAssertion,
+				&samlConfig.nameIDFormat,
+				&samlConfig.attributeMappings,
 			)
 
 			if err != nil {
@@ -711,11 +767,19 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnRequireConsent.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
 			AppSAMLConfigColumnMetadata.identifier(),
 			AppSAMLConfigColumnMetadataURL.identifier(),
+			AppSAMLConfigColumnIDPInitiatedSSO.identifier(),
+			AppSAMLConfigColumnDefaultRelayState.identifier(),
+			AppSAMLConfigColumnSignatureAlgorithm.identifier(),
+			AppSAMLConfigColumnSigningMode.identifier(),
+			AppSAMLConfigColumnEncryptAssertion.identifier(),
+			AppSAMLConfigColumnNameIDFormat.identifier(),
+			AppSAMLConfigColumnAttributeMappings.identifier(),
 			countColumn.identifier(),
 		).From(appsTable.identifier()).
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
@@ -763,11 +827,19 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 					&oidcConfig.clockSkew,
 					&oidcConfig.additionalOrigins,
 					&oidcConfig.skipNativeAppSuccessPage,
+					&oidcConfig.requireConsent,
 
 					&samlConfig.appID,
 					&samlConfig.entityID,
 					&samlConfig.metadata,
 					&samlConfig.metadataURL,
+					&samlConfig.idpInitiatedSSO,
+					&samlConfig.defaultRelayState,
+					&samlConfig.signatureAlgorithm,
+					&samlConfig.signingMode,
+					&samlConfig.encryptAssertion,
+					&samlConfig.nameIDFormat,
+					&samlConfig.attributeMappings,
 
 					&apps.Count,
 				)
@@ -835,6 +907,7 @@ type sqlOIDCConfig struct {
 	responseTypes            database.EnumArray[domain.OIDCResponseType]
 	grantTypes               database.EnumArray[domain.OIDCGrantType]
 	skipNativeAppSuccessPage sql.NullBool
+	requireConsent           sql.NullBool
 }
 
 func (c sqlOIDCConfig) set(app *App) {
@@ -858,6 +931,7 @@ func (c sqlOIDCConfig) set(app *App) {
 		ResponseTypes:            c.responseTypes,
 		GrantTypes:               c.grantTypes,
 		SkipNativeAppSuccessPage: c.skipNativeAppSuccessPage.Bool,
+		RequireConsent:           c.requireConsent.Bool,
 	}
 	compliance := domain.GetOIDCCompliance(app.OIDCConfig.Version, app.OIDCConfig.AppType, app.OIDCConfig.GrantTypes, app.OIDCConfig.ResponseTypes, app.OIDCConfig.AuthMethodType, app.OIDCConfig.RedirectURIs)
 	app.OIDCConfig.ComplianceProblems = compliance.Problems
@@ -868,10 +942,17 @@ func (c sqlOIDCConfig) set(app *App) {
 }
 
 type sqlSAMLConfig struct {
-	appID       sql.NullString
-	entityID    sql.NullString
-	metadataURL sql.NullString
-	metadata    []byte
+	appID              sql.NullString
+	entityID           sql.NullString
+	metadataURL        sql.NullString
+	metadata           []byte
+	idpInitiatedSSO    sql.NullBool
+	defaultRelayState  sql.NullString
+	signatureAlgorithm sql.NullInt16
+	signingMode        sql.NullInt16
+	encryptAssertion   sql.NullBool
+	nameIDFormat       sql.NullInt16
+	attributeMappings  domain.SAMLAttributeMappings
 }
 
 func (c sqlSAMLConfig) set(app *App) {
@@ -879,9 +960,16 @@ func (c sqlSAMLConfig) set(app *App) {
 		return
 	}
 	app.SAMLConfig = &SAMLApp{
-		MetadataURL: c.metadataURL.String,
-		Metadata:    c.metadata,
-		EntityID:    c.entityID.String,
+		MetadataURL:        c.metadataURL.String,
+		Metadata:           c.metadata,
+		EntityID:           c.entityID.String,
+		IDPInitiatedSSO:    c.idpInitiatedSSO.Bool,
+		DefaultRelayState:  c.defaultRelayState.String,
+		SignatureAlgorithm: domain.SAMLSignatureAlgorithm(c.signatureAlgorithm.Int16),
+		SigningMode:        domain.SAMLSigningMode(c.signingMode.Int16),
+		EncryptAssertion:   c.encryptAssertion.Bool,
+		NameIDFormat:       domain.SAMLNameIDFormat(c.nameIDFormat.Int16),
+		AttributeMappings:  c.attributeMappings,
 	}
 }
 
@@ -15,115 +15,131 @@ import (
 )
 
 var (
-	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps5.id,` +
-		` projections.apps5.name,` +
-		` projections.apps5.project_id,` +
-		` projections.apps5.creation_date,` +
-		` projections.apps5.change_date,` +
-		` projections.apps5.resource_owner,` +
-		` projections.apps5.state,` +
-		` projections.apps5.sequence,` +
+	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps9.id,` +
+		` projections.apps9.name,` +
+		` projections.apps9.project_id,` +
+		` projections.apps9.creation_date,` +
+		` projections.apps9.change_date,` +
+		` projections.apps9.resource_owner,` +
+		` projections.apps9.state,` +
+		` projections.apps9.sequence,` +
 		// api config
-		` projections.apps5_api_configs.app_id,` +
-		` projections.apps5_api_configs.client_id,` +
-		` projections.apps5_api_configs.auth_method,` +
+		` projections.apps9_api_configs.app_id,` +
+		` projections.apps9_api_configs.client_id,` +
+		` projections.apps9_api_configs.auth_method,` +
 		// oidc config
-		` projections.apps5_oidc_configs.app_id,` +
-		` projections.apps5_oidc_configs.version,` +
-		` projections.apps5_oidc_configs.client_id,` +
-		` projections.apps5_oidc_configs.redirect_uris,` +
-		` projections.apps5_oidc_configs.response_types,` +
-		` projections.apps5_oidc_configs.grant_types,` +
-		` projections.apps5_oidc_configs.application_type,` +
-		` projections.apps5_oidc_configs.auth_method_type,` +
-		` projections.apps5_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps5_oidc_configs.is_dev_mode,` +
-		` projections.apps5_oidc_configs.access_token_type,` +
-		` projections.apps5_oidc_configs.access_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps5_oidc_configs.clock_skew,` +
-		` projections.apps5_oidc_configs.additional_origins,` +
-		` projections.apps5_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps9_oidc_configs.app_id,` +
+		` projections.apps9_oidc_configs.version,` +
+		` projections.apps9_oidc_configs.client_id,` +
+		` projections.apps9_oidc_configs.redirect_uris,` +
+		` projections.apps9_oidc_configs.response_types,` +
+		` projections.apps9_oidc_configs.grant_types,` +
+		` projections.apps9_oidc_configs.application_type,` +
+		` projections.apps9_oidc_configs.auth_method_type,` +
+		` projections.apps9_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps9_oidc_configs.is_dev_mode,` +
+		` projections.apps9_oidc_configs.access_token_type,` +
+		` projections.apps9_oidc_configs.access_token_role_assertion,` +
+		` projections.apps9_oidc_configs.id_token_role_assertion,` +
+		` projections.apps9_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps9_oidc_configs.clock_skew,` +
+		` projections.apps9_oidc_configs.additional_origins,` +
+		` projections.apps9_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps9_oidc_configs.require_consent,` +
 		//saml config
-		` projections.apps5_saml_configs.app_id,` +
-		` projections.apps5_saml_configs.entity_id,` +
-		` projections.apps5_saml_configs.metadata,` +
-		` projections.apps5_saml_configs.metadata_url` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+		` projections.apps9_saml_configs.app_id,` +
+		` projections.apps9_saml_configs.entity_id,` +
+		` projections.apps9_saml_configs.metadata,` +
+		` projections.apps9_saml_configs.metadata_url,` +
+		` projections.apps9_saml_configs.idp_initiated_sso,` +
+		` projections.apps9_saml_configs.default_relay_state,` +
+		` projections.apps9_saml_configs.signature_algorithm,` +
+		` projections.apps9_saml_configs.signing_mode,` +
+		` projections.apps9_saml_configs.encrypt_assertion,` +
+		` projections.apps9_saml_configs.name_id_format,` +
+		` projections.apps9_saml_configs.attribute_mappings` +
+		` FROM projections.apps9` +
+		` LEFT JOIN projections.apps9_api_configs ON projections.apps9.id = projections.apps9_api_configs.app_id AND projections.apps9.instance_id = projections.apps9_api_configs.instance_id` +
+		` LEFT JOIN projections.apps9_oidc_configs ON projections.apps9.id = projections.apps9_oidc_configs.app_id AND projections.apps9.instance_id = projections.apps9_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps9_saml_configs ON projections.apps9.id = projections.apps9_saml_configs.app_id AND projections.apps9.instance_id = projections.apps9_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps5.id,` +
-		` projections.apps5.name,` +
-		` projections.apps5.project_id,` +
-		` projections.apps5.creation_date,` +
-		` projections.apps5.change_date,` +
-		` projections.apps5.resource_owner,` +
-		` projections.apps5.state,` +
-		` projections.apps5.sequence,` +
+	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps9.id,` +
+		` projections.apps9.name,` +
+		` projections.apps9.project_id,` +
+		` projections.apps9.creation_date,` +
+		` projections.apps9.change_date,` +
+		` projections.apps9.resource_owner,` +
+		` projections.apps9.state,` +
+		` projections.apps9.sequence,` +
 		// api config
-		` projections.apps5_api_configs.app_id,` +
-		` projections.apps5_api_configs.client_id,` +
-		` projections.apps5_api_configs.auth_method,` +
+		` projections.apps9_api_configs.app_id,` +
+		` projections.apps9_api_configs.client_id,` +
+		` projections.apps9_api_configs.auth_method,` +
 		// oidc config
-		` projections.apps5_oidc_configs.app_id,` +
-		` projections.apps5_oidc_configs.version,` +
-		` projections.apps5_oidc_configs.client_id,` +
-		` projections.apps5_oidc_configs.redirect_uris,` +
-		` projections.apps5_oidc_configs.response_types,` +
-		` projections.apps5_oidc_configs.grant_types,` +
-		` projections.apps5_oidc_configs.application_type,` +
-		` projections.apps5_oidc_configs.auth_method_type,` +
-		` projections.apps5_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps5_oidc_configs.is_dev_mode,` +
-		` projections.apps5_oidc_configs.access_token_type,` +
-		` projections.apps5_oidc_configs.access_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps5_oidc_configs.clock_skew,` +
-		` projections.apps5_oidc_configs.additional_origins,` +
-		` projections.apps5_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps9_oidc_configs.app_id,` +
+		` projections.apps9_oidc_configs.version,` +
+		` projections.apps9_oidc_configs.client_id,` +
+		` projections.apps9_oidc_configs.redirect_uris,` +
+		` projections.apps9_oidc_configs.response_types,` +
+		` projections.apps9_oidc_configs.grant_types,` +
+		` projections.apps9_oidc_configs.application_type,` +
+		` projections.apps9_oidc_configs.auth_method_type,` +
+		` projections.apps9_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps9_oidc_configs.is_dev_mode,` +
+		` projections.apps9_oidc_configs.access_token_type,` +
+		` projections.apps9_oidc_configs.access_token_role_assertion,` +
+		` projections.apps9_oidc_configs.id_token_role_assertion,` +
+		` projections.apps9_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps9_oidc_configs.clock_skew,` +
+		` projections.apps9_oidc_configs.additional_origins,` +
+		` projections.apps9_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps9_oidc_configs.require_consent,` +
 		//saml config
-		` projections.apps5_saml_configs.app_id,` +
-		` projections.apps5_saml_configs.entity_id,` +
-		` projections.apps5_saml_configs.metadata,` +
-		` projections.apps5_saml_configs.metadata_url,` +
+		` projections.apps9_saml_configs.app_id,` +
+		` projections.apps9_saml_configs.entity_id,` +
+		` projections.apps9_saml_configs.metadata,` +
+		` projections.apps9_saml_configs.metadata_url,` +
+		` projections.apps9_saml_configs.idp_initiated_sso,` +
+		` projections.apps9_saml_configs.default_relay_state,` +
+		` projections.apps9_saml_configs.signature_algorithm,` +
+		` projections.apps9_saml_configs.signing_mode,` +
+		` projections.apps9_saml_configs.encrypt_assertion,` +
+		` projections.apps9_saml_configs.name_id_format,` +
+		` projections.apps9_saml_configs.attribute_mappings,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+		` FROM projections.apps9` +
+		` LEFT JOIN projections.apps9_api_configs ON projections.apps9.id = projections.apps9_api_configs.app_id AND projections.apps9.instance_id = projections.apps9_api_configs.instance_id` +
+		` LEFT JOIN projections.apps9_oidc_configs ON projections.apps9.id = projections.apps9_oidc_configs.app_id AND projections.apps9.instance_id = projections.apps9_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps9_saml_configs ON projections.apps9.id = projections.apps9_saml_configs.app_id AND projections.apps9.instance_id = projections.apps9_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps5_api_configs.client_id,` +
-		` projections.apps5_oidc_configs.client_id` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
+	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps9_api_configs.client_id,` +
+		` projections.apps9_oidc_configs.client_id` +
+		` FROM projections.apps9` +
+		` LEFT JOIN projections.apps9_api_configs ON projections.apps9.id = projections.apps9_api_configs.app_id AND projections.apps9.instance_id = projections.apps9_api_configs.instance_id` +
+		` LEFT JOIN projections.apps9_oidc_configs ON projections.apps9.id = projections.apps9_oidc_configs.app_id AND projections.apps9.instance_id = projections.apps9_oidc_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps5.project_id` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps9.project_id` +
+		` FROM projections.apps9` +
+		` LEFT JOIN projections.apps9_api_configs ON projections.apps9.id = projections.apps9_api_configs.app_id AND projections.apps9.instance_id = projections.apps9_api_configs.instance_id` +
+		` LEFT JOIN projections.apps9_oidc_configs ON projections.apps9.id = projections.apps9_oidc_configs.app_id AND projections.apps9.instance_id = projections.apps9_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps9_saml_configs ON projections.apps9.id = projections.apps9_saml_configs.app_id AND projections.apps9.instance_id = projections.apps9_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedProjectByAppQuery = regexp.QuoteMeta(`SELECT projections.projects3.id,` +
-		` projections.projects3.creation_date,` +
-		` projections.projects3.change_date,` +
-		` projections.projects3.resource_owner,` +
-		` projections.projects3.state,` +
-		` projections.projects3.sequence,` +
-		` projections.projects3.name,` +
-		` projections.projects3.project_role_assertion,` +
-		` projections.projects3.project_role_check,` +
-		` projections.projects3.has_project_check,` +
-		` projections.projects3.private_labeling_setting` +
-		` FROM projections.projects3` +
-		` JOIN projections.apps5 ON projections.projects3.id = projections.apps5.project_id AND projections.projects3.instance_id = projections.apps5.instance_id` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+	expectedProjectByAppQuery = regexp.QuoteMeta(`SELECT projections.projects4.id,` +
+		` projections.projects4.creation_date,` +
+		` projections.projects4.change_date,` +
+		` projections.projects4.resource_owner,` +
+		` projections.projects4.state,` +
+		` projections.projects4.sequence,` +
+		` projections.projects4.name,` +
+		` projections.projects4.project_role_assertion,` +
+		` projections.projects4.project_role_check,` +
+		` projections.projects4.has_project_check,` +
+		` projections.projects4.private_labeling_setting` +
+		` FROM projections.projects4` +
+		` JOIN projections.apps9 ON projections.projects4.id = projections.apps9.project_id AND projections.projects4.instance_id = projections.apps9.instance_id` +
+		` LEFT JOIN projections.apps9_api_configs ON projections.apps9.id = projections.apps9_api_configs.app_id AND projections.apps9.instance_id = projections.apps9_api_configs.instance_id` +
+		` LEFT JOIN projections.apps9_oidc_configs ON projections.apps9.id = projections.apps9_oidc_configs.app_id AND projections.apps9.instance_id = projections.apps9_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps9_saml_configs ON projections.apps9.id = projections.apps9_saml_configs.app_id AND projections.apps9.instance_id = projections.apps9_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 
 	appCols = database.StringArray{
@@ -157,11 +173,19 @@ var (
 		"clock_skew",
 		"additional_origins",
 		"skip_native_app_success_page",
+		"require_consent",
 		//saml config
 		"app_id",
 		"entity_id",
 		"metadata",
 		"metadata_url",
+		"idp_initiated_sso",
+		"default_relay_state",
+		"signature_algorithm",
+		"signing_mode",
+		"encrypt_assertion",
+		"name_id_format",
+		"attribute_mappings",
 	}
 	appsCols = append(appCols, "count")
 )
@@ -228,11 +252,19 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -294,11 +326,19 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -363,11 +403,19 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
 							[]byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
 							"https://test.com/saml/metadata",
+							true,
+							"https://test.com/home",
+							domain.SAMLSignatureAlgorithmRSASHA256,
+							domain.SAMLSigningModeResponseAndAssertion,
+							true,
+							domain.SAMLNameIDFormatPersistent,
+							[]byte(`[{"name":"mail","source":3}]`),
 						},
 					},
 				),
@@ -387,9 +435,18 @@ func Test_AppsPrepare(t *testing.T) {
 						Name:          "app-name",
 						ProjectID:     "project-id",
 						SAMLConfig: &SAMLApp{
-							Metadata:    []byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
-							MetadataURL: "https://test.com/saml/metadata",
-							EntityID:    "https://test.com/saml/metadata",
+							Metadata:           []byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
+							MetadataURL:        "https://test.com/saml/metadata",
+							EntityID:           "https://test.com/saml/metadata",
+							IDPInitiatedSSO:    true,
+							DefaultRelayState:  "https://test.com/home",
+							SignatureAlgorithm: domain.SAMLSignatureAlgorithmRSASHA256,
+							SigningMode:        domain.SAMLSigningModeResponseAndAssertion,
+							EncryptAssertion:   true,
+							NameIDFormat:       domain.SAMLNameIDFormatPersistent,
+							AttributeMappings: domain.SAMLAttributeMappings{
+								{Name: "mail", Source: domain.SAMLAttributeSourceEmail},
+							},
 						},
 					},
 				},
@@ -434,11 +491,19 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -520,11 +585,19 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -606,11 +679,19 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -692,11 +773,19 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -778,11 +867,19 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -864,11 +961,19 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							true,
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -950,11 +1055,19 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"api-app-id",
@@ -987,11 +1100,19 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"saml-app-id",
@@ -1024,11 +1145,19 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// saml config
 							"saml-app-id",
 							"https://test.com/saml/metadata",
 							[]byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
 							"https://test.com/saml/metadata",
+							false,
+							"",
+							domain.SAMLSignatureAlgorithmUnspecified,
+							domain.SAMLSigningModeAssertion,
+							false,
+							domain.SAMLNameIDFormatUnspecified,
+							nil,
 						},
 					},
 				),
@@ -1192,11 +1321,19 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// saml config
 						nil,
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1250,11 +1387,19 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1313,11 +1458,19 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1391,11 +1544,19 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
 							[]byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
 							"https://test.com/saml/metadata",
+							false,
+							"",
+							domain.SAMLSignatureAlgorithmUnspecified,
+							domain.SAMLSigningModeAssertion,
+							false,
+							domain.SAMLNameIDFormatUnspecified,
+							nil,
 						},
 					},
 				),
@@ -1455,11 +1616,19 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1534,11 +1703,19 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1613,11 +1790,19 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1692,11 +1877,19 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -0,0 +1,128 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// CustomMailTemplate references the stored layout an organization uses for the emails of a message type
+type CustomMailTemplate struct {
+	AggregateID  string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Sequence     uint64
+	MessageType  string
+	TemplateKey  string
+	TemplateHash string
+	MJMLKey      string
+}
+
+var (
+	customMailTemplateTable = table{
+		name:          projection.CustomMailTemplateTable,
+		instanceIDCol: projection.CustomMailTemplateInstanceIDCol,
+	}
+	CustomMailTemplateColAggregateID = Column{
+		name:  projection.CustomMailTemplateAggregateIDCol,
+		table: customMailTemplateTable,
+	}
+	CustomMailTemplateColInstanceID = Column{
+		name:  projection.CustomMailTemplateInstanceIDCol,
+		table: customMailTemplateTable,
+	}
+	CustomMailTemplateColCreationDate = Column{
+		name:  projection.CustomMailTemplateCreationDateCol,
+		table: customMailTemplateTable,
+	}
+	CustomMailTemplateColChangeDate = Column{
+		name:  projection.CustomMailTemplateChangeDateCol,
+		table: customMailTemplateTable,
+	}
+	CustomMailTemplateColSequence = Column{
+		name:  projection.CustomMailTemplateSequenceCol,
+		table: customMailTemplateTable,
+	}
+	CustomMailTemplateColMessageType = Column{
+		name:  projection.CustomMailTemplateMessageTypeCol,
+		table: customMailTemplateTable,
+	}
+	CustomMailTemplateColTemplateKey = Column{
+		name:  projection.CustomMailTemplateTemplateKeyCol,
+		table: customMailTemplateTable,
+	}
+	CustomMailTemplateColTemplateHash = Column{
+		name:  projection.CustomMailTemplateTemplateHashCol,
+		table: customMailTemplateTable,
+	}
+	CustomMailTemplateColMJMLKey = Column{
+		name:  projection.CustomMailTemplateMJMLKeyCol,
+		table: customMailTemplateTable,
+	}
+)
+
+// CustomMailTemplateByOrg returns the layout the organization uploaded for the message type
+func (q *Queries) CustomMailTemplateByOrg(ctx context.Context, orgID, messageType string) (_ *CustomMailTemplate, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareCustomMailTemplateQuery(ctx, q.client)
+	stmt, args, err := query.Where(
+		sq.Eq{
+			CustomMailTemplateColInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+			CustomMailTemplateColAggregateID.identifier(): orgID,
+			CustomMailTemplateColMessageType.identifier(): messageType,
+		}).
+		ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Mt5sq", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func prepareCustomMailTemplateQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*CustomMailTemplate, error)) {
+	return sq.Select(
+			CustomMailTemplateColAggregateID.identifier(),
+			CustomMailTemplateColCreationDate.identifier(),
+			CustomMailTemplateColChangeDate.identifier(),
+			CustomMailTemplateColSequence.identifier(),
+			CustomMailTemplateColMessageType.identifier(),
+			CustomMailTemplateColTemplateKey.identifier(),
+			CustomMailTemplateColTemplateHash.identifier(),
+			CustomMailTemplateColMJMLKey.identifier(),
+		).From(customMailTemplateTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*CustomMailTemplate, error) {
+			template := new(CustomMailTemplate)
+			mjmlKey := sql.NullString{}
+			err := row.Scan(
+				&template.AggregateID,
+				&template.CreationDate,
+				&template.ChangeDate,
+				&template.Sequence,
+				&template.MessageType,
+				&template.TemplateKey,
+				&template.TemplateHash,
+				&mjmlKey,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Mt5nf", "Errors.Org.CustomMailTemplate.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Mt5in", "Errors.Internal")
+			}
+			template.MJMLKey = mjmlKey.String
+			return template, nil
+		}
+}
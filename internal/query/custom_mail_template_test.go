@@ -0,0 +1,148 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareCustomMailTemplateStmt = `SELECT projections.custom_mail_templates.aggregate_id,` +
+		` projections.custom_mail_templates.creation_date,` +
+		` projections.custom_mail_templates.change_date,` +
+		` projections.custom_mail_templates.sequence,` +
+		` projections.custom_mail_templates.message_type,` +
+		` projections.custom_mail_templates.template_key,` +
+		` projections.custom_mail_templates.template_hash,` +
+		` projections.custom_mail_templates.mjml_key` +
+		` FROM projections.custom_mail_templates` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareCustomMailTemplateCols = []string{
+		"aggregate_id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"message_type",
+		"template_key",
+		"template_hash",
+		"mjml_key",
+	}
+)
+
+func Test_CustomMailTemplatePrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareCustomMailTemplateQuery no result",
+			prepare: prepareCustomMailTemplateQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareCustomMailTemplateStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*CustomMailTemplate)(nil),
+		},
+		{
+			name:    "prepareCustomMailTemplateQuery found",
+			prepare: prepareCustomMailTemplateQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareCustomMailTemplateStmt),
+					prepareCustomMailTemplateCols,
+					[]driver.Value{
+						"org-id",
+						testNow,
+						testNow,
+						uint64(20211109),
+						"InitCode",
+						"policy/mail/template/InitCode-id.html",
+						"hash",
+						"policy/mail/template/InitCode-id.mjml",
+					},
+				),
+			},
+			object: &CustomMailTemplate{
+				AggregateID:  "org-id",
+				CreationDate: testNow,
+				ChangeDate:   testNow,
+				Sequence:     20211109,
+				MessageType:  "InitCode",
+				TemplateKey:  "policy/mail/template/InitCode-id.html",
+				TemplateHash: "hash",
+				MJMLKey:      "policy/mail/template/InitCode-id.mjml",
+			},
+		},
+		{
+			name:    "prepareCustomMailTemplateQuery found without mjml",
+			prepare: prepareCustomMailTemplateQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareCustomMailTemplateStmt),
+					prepareCustomMailTemplateCols,
+					[]driver.Value{
+						"org-id",
+						testNow,
+						testNow,
+						uint64(20211109),
+						"InitCode",
+						"policy/mail/template/InitCode-id.html",
+						"hash",
+						nil,
+					},
+				),
+			},
+			object: &CustomMailTemplate{
+				AggregateID:  "org-id",
+				CreationDate: testNow,
+				ChangeDate:   testNow,
+				Sequence:     20211109,
+				MessageType:  "InitCode",
+				TemplateKey:  "policy/mail/template/InitCode-id.html",
+				TemplateHash: "hash",
+			},
+		},
+		{
+			name:    "prepareCustomMailTemplateQuery sql err",
+			prepare: prepareCustomMailTemplateQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareCustomMailTemplateStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -36,6 +36,12 @@ var (
 		"text",
 		"count",
 	}
+	prepareCustomTextLanguagesStmt = `SELECT DISTINCT projections.custom_texts2.language` +
+		` FROM projections.custom_texts2` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareCustomTextLanguagesCols = []string{
+		"language",
+	}
 )
 
 func Test_CustomTextPrepares(t *testing.T) {
@@ -182,6 +188,38 @@ func Test_CustomTextPrepares(t *testing.T) {
 			},
 			object: nil,
 		},
+		{
+			name:    "prepareCustomTextLanguagesQuery multiple result",
+			prepare: prepareCustomTextLanguagesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareCustomTextLanguagesStmt),
+					prepareCustomTextLanguagesCols,
+					[][]driver.Value{
+						{"de"},
+						{"pt-BR"},
+					},
+				),
+			},
+			object: []language.Tag{language.German, language.MustParse("pt-BR")},
+		},
+		{
+			name:    "prepareCustomTextLanguagesQuery sql err",
+			prepare: prepareCustomTextLanguagesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareCustomTextLanguagesStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,303 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"golang.org/x/text/language"
+	"sigs.k8s.io/yaml"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// CustomTextTranslations are all texts of the login and the messages,
+// which can be customized in the language on an instance or organization
+type CustomTextTranslations struct {
+	SourceLanguage language.Tag
+	Language       language.Tag
+	Translations   []*CustomTextTranslation
+}
+
+type CustomTextTranslation struct {
+	Template string
+	Key      string
+	// Source is the text in the default language of the instance
+	Source string
+	// Default is the text shown in the language if it isn't customized,
+	// it's empty if the language isn't part of the translation files and not customized on the instance
+	Default string
+	Custom  string
+}
+
+func (t *CustomTextTranslation) ID() string {
+	return domain.CustomTextID(t.Template, t.Key)
+}
+
+// Text returns the text shown in the language
+func (t *CustomTextTranslation) Text() string {
+	if t.Custom != "" {
+		return t.Custom
+	}
+	return t.Default
+}
+
+// CustomTextTranslations returns the known texts of the translation files
+// with the custom texts of the instance and the aggregate (instance or organization) in the language
+func (q *Queries) CustomTextTranslations(ctx context.Context, aggregateID string, lang language.Tag) (_ *CustomTextTranslations, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instance := authz.GetInstance(ctx)
+	sourceLang := instance.DefaultLanguage()
+	sourceTexts, err := q.builtInCustomTexts(sourceLang)
+	if err != nil {
+		return nil, err
+	}
+	if len(sourceTexts) == 0 {
+		sourceLang = language.English
+		if sourceTexts, err = q.builtInCustomTexts(sourceLang); err != nil {
+			return nil, err
+		}
+	}
+	defaultTexts, err := q.builtInCustomTexts(lang)
+	if err != nil {
+		return nil, err
+	}
+	if err = q.overlayCustomTexts(ctx, sourceTexts, instance.InstanceID(), sourceLang); err != nil {
+		return nil, err
+	}
+	if aggregateID != instance.InstanceID() {
+		if err = q.overlayCustomTexts(ctx, defaultTexts, instance.InstanceID(), lang); err != nil {
+			return nil, err
+		}
+	}
+	customTexts := make(map[string]string)
+	if err = q.overlayCustomTexts(ctx, customTexts, aggregateID, lang); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(sourceTexts))
+	for id := range sourceTexts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	translations := &CustomTextTranslations{
+		SourceLanguage: sourceLang,
+		Language:       lang,
+		Translations:   make([]*CustomTextTranslation, len(ids)),
+	}
+	for i, id := range ids {
+		template, key := domain.SplitCustomTextID(id)
+		translations.Translations[i] = &CustomTextTranslation{
+			Template: template,
+			Key:      key,
+			Source:   sourceTexts[id],
+			Default:  defaultTexts[id],
+			Custom:   customTexts[id],
+		}
+	}
+	return translations, nil
+}
+
+// File returns the translations as file for translators,
+// the source is the text in the default language and the target the text shown in the language
+func (t *CustomTextTranslations) File() *i18n.TranslationFile {
+	file := &i18n.TranslationFile{
+		SourceLanguage: t.SourceLanguage,
+		TargetLanguage: t.Language,
+		Units:          make([]*i18n.TranslationUnit, len(t.Translations)),
+	}
+	for i, translation := range t.Translations {
+		file.Units[i] = &i18n.TranslationUnit{
+			ID:     translation.ID(),
+			Source: translation.Source,
+			Target: translation.Text(),
+		}
+	}
+	return file
+}
+
+// Changes returns the custom texts of the file which differ from the texts shown in the language.
+// Units without target are ignored, so untranslated texts of the file don't override anything.
+// All ids of the file must be known texts of the translation files.
+func (t *CustomTextTranslations) Changes(file *i18n.TranslationFile) ([]*domain.CustomText, error) {
+	translations := make(map[string]*CustomTextTranslation, len(t.Translations))
+	for _, translation := range t.Translations {
+		translations[translation.ID()] = translation
+	}
+	unknown := make([]string, 0)
+	changes := make([]*domain.CustomText, 0)
+	for _, unit := range file.Units {
+		translation, ok := translations[unit.ID]
+		if !ok {
+			unknown = append(unknown, unit.ID)
+			continue
+		}
+		if unit.Target == "" || unit.Target == translation.Text() {
+			continue
+		}
+		changes = append(changes, &domain.CustomText{
+			Template: translation.Template,
+			Key:      translation.Key,
+			Language: t.Language,
+			Text:     unit.Target,
+		})
+	}
+	if len(unknown) > 0 {
+		return nil, errors.ThrowInvalidArgument(fmt.Errorf("unknown texts: %s", strings.Join(unknown, ", ")), "QUERY-Ct3uk", "Errors.CustomText.KeyUnknown")
+	}
+	return changes, nil
+}
+
+// builtInCustomTexts returns the texts of the login and message translation files in the language,
+// the result is empty if there is no translation file for the language
+func (q *Queries) builtInCustomTexts(lang language.Tag) (map[string]string, error) {
+	texts := make(map[string]string)
+	loginContents, err := q.readTranslationFile(q.LoginDir, fmt.Sprintf("/i18n/%s.yaml", lang.String()))
+	if errors.IsNotFound(err) {
+		return texts, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	notificationContents, err := q.readTranslationFile(q.NotificationDir, fmt.Sprintf("/i18n/%s.yaml", lang.String()))
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	loginTexts := make(map[string]interface{})
+	if err := yaml.Unmarshal(loginContents, &loginTexts); err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ct4lr", "Errors.TranslationFile.ReadError")
+	}
+	for screen, screenTexts := range loginTexts {
+		for key, text := range translationFileTexts(screenTexts) {
+			texts[domain.CustomTextID(domain.LoginCustomText, screen+"."+key)] = text
+		}
+	}
+	if len(notificationContents) == 0 {
+		return texts, nil
+	}
+	notificationTexts := make(map[string]interface{})
+	if err := yaml.Unmarshal(notificationContents, &notificationTexts); err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ct4nr", "Errors.TranslationFile.ReadError")
+	}
+	for messageType, messageTexts := range notificationTexts {
+		if !domain.IsMessageTextType(messageType) {
+			continue
+		}
+		for key, text := range translationFileTexts(messageTexts) {
+			texts[domain.CustomTextID(messageType, key)] = text
+		}
+	}
+	return texts, nil
+}
+
+// translationFileTexts returns the texts of a section of a translation file,
+// nested sections (e.g. the errors of the login) can't be customized and are skipped
+func translationFileTexts(section interface{}) map[string]string {
+	texts := make(map[string]string)
+	sectionTexts, ok := section.(map[string]interface{})
+	if !ok {
+		return texts
+	}
+	for key, text := range sectionTexts {
+		if s, ok := text.(string); ok {
+			texts[key] = s
+		}
+	}
+	return texts
+}
+
+// overlayCustomTexts sets the custom texts of the aggregate in the language on the passed texts
+func (q *Queries) overlayCustomTexts(ctx context.Context, texts map[string]string, aggregateID string, lang language.Tag) error {
+	customTexts, err := q.CustomTextListByLanguage(ctx, aggregateID, lang.String(), false)
+	if err != nil {
+		return err
+	}
+	for _, text := range customTexts.CustomTexts {
+		texts[domain.CustomTextID(text.Template, text.Key)] = text.Text
+	}
+	return nil
+}
+
+func (q *Queries) CustomTextListByLanguage(ctx context.Context, aggregateID, language string, withOwnerRemoved bool) (texts *CustomTexts, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareCustomTextsQuery(ctx, q.client)
+	eq := sq.Eq{
+		CustomTextColAggregateID.identifier(): aggregateID,
+		CustomTextColLanguage.identifier():    language,
+		CustomTextColInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[CustomTextOwnerRemoved.identifier()] = false
+	}
+	query, args, err := stmt.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ct5sq", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ct5qe", "Errors.Internal")
+	}
+	texts, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	texts.LatestSequence, err = q.latestSequence(ctx, customTextTable)
+	return texts, err
+}
+
+// customTextLanguages returns the languages the aggregate has custom texts for
+func (q *Queries) customTextLanguages(ctx context.Context, aggregateID string) (langs []language.Tag, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareCustomTextLanguagesQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		CustomTextColAggregateID.identifier(): aggregateID,
+		CustomTextColInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+		CustomTextOwnerRemoved.identifier():   false,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ct6sq", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ct6qe", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+func prepareCustomTextLanguagesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]language.Tag, error)) {
+	return sq.Select(CustomTextColLanguage.identifier()).
+			Distinct().
+			From(customTextTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]language.Tag, error) {
+			langs := make([]language.Tag, 0)
+			for rows.Next() {
+				var lang string
+				if err := rows.Scan(&lang); err != nil {
+					return nil, err
+				}
+				langs = append(langs, language.Make(lang))
+			}
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ct6cr", "Errors.Query.CloseRows")
+			}
+			return langs, nil
+		}
+}
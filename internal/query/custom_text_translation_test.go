@@ -0,0 +1,70 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/i18n"
+)
+
+func TestCustomTextTranslations_Changes(t *testing.T) {
+	translations := &CustomTextTranslations{
+		SourceLanguage: language.English,
+		Language:       language.German,
+		Translations: []*CustomTextTranslation{
+			{Template: domain.InitCodeMessageType, Key: domain.MessageSubject, Source: "Initialize User", Default: "User initialisieren"},
+			{Template: domain.LoginCustomText, Key: domain.LoginKeyLoginDescription, Source: "Enter your login data.", Default: "Gib deine Benutzerdaten ein."},
+			{Template: domain.LoginCustomText, Key: domain.LoginKeyLoginTitle, Source: "Welcome back!", Default: "Willkommen zurück!", Custom: "Hallo!"},
+		},
+	}
+	tests := []struct {
+		name    string
+		units   []*i18n.TranslationUnit
+		want    []*domain.CustomText
+		wantErr func(error) bool
+	}{
+		{
+			name: "unknown text, error",
+			units: []*i18n.TranslationUnit{
+				{ID: "Login.Login.Title", Target: "Anmeldung"},
+				{ID: "Login.Errors.Internal", Target: "Interner Fehler"},
+			},
+			wantErr: errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "texts shown and untranslated texts skipped",
+			units: []*i18n.TranslationUnit{
+				{ID: "InitCode.Subject", Target: "User initialisieren"},
+				{ID: "Login.Login.Description"},
+				{ID: "Login.Login.Title", Target: "Hallo!"},
+			},
+			want: []*domain.CustomText{},
+		},
+		{
+			name: "changed texts",
+			units: []*i18n.TranslationUnit{
+				{ID: "InitCode.Subject", Target: "Benutzer initialisieren"},
+				{ID: "Login.Login.Title", Target: "Willkommen zurück!"},
+			},
+			want: []*domain.CustomText{
+				{Template: domain.InitCodeMessageType, Key: domain.MessageSubject, Language: language.German, Text: "Benutzer initialisieren"},
+				{Template: domain.LoginCustomText, Key: domain.LoginKeyLoginTitle, Language: language.German, Text: "Willkommen zurück!"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := translations.Changes(&i18n.TranslationFile{Units: tt.units})
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,917 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	groupTable = table{
+		name:          projection.GroupProjectionTable,
+		instanceIDCol: projection.GroupColumnInstanceID,
+	}
+	GroupColumnID = Column{
+		name:  projection.GroupColumnID,
+		table: groupTable,
+	}
+	GroupColumnCreationDate = Column{
+		name:  projection.GroupColumnCreationDate,
+		table: groupTable,
+	}
+	GroupColumnChangeDate = Column{
+		name:  projection.GroupColumnChangeDate,
+		table: groupTable,
+	}
+	GroupColumnSequence = Column{
+		name:  projection.GroupColumnSequence,
+		table: groupTable,
+	}
+	GroupColumnState = Column{
+		name:  projection.GroupColumnState,
+		table: groupTable,
+	}
+	GroupColumnResourceOwner = Column{
+		name:  projection.GroupColumnResourceOwner,
+		table: groupTable,
+	}
+	GroupColumnInstanceID = Column{
+		name:  projection.GroupColumnInstanceID,
+		table: groupTable,
+	}
+	GroupColumnName = Column{
+		name:           projection.GroupColumnName,
+		table:          groupTable,
+		isOrderByLower: true,
+	}
+	GroupColumnDescription = Column{
+		name:  projection.GroupColumnDescription,
+		table: groupTable,
+	}
+	GroupColumnOwnerRemoved = Column{
+		name:  projection.GroupColumnOwnerRemoved,
+		table: groupTable,
+	}
+)
+
+var (
+	groupMemberTable = table{
+		name:          projection.GroupMemberTable,
+		instanceIDCol: projection.GroupMemberColumnInstanceID,
+	}
+	GroupMemberColumnGroupID = Column{
+		name:  projection.GroupMemberColumnGroupID,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnUserID = Column{
+		name:  projection.GroupMemberColumnUserID,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnInstanceID = Column{
+		name:  projection.GroupMemberColumnInstanceID,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnCreationDate = Column{
+		name:  projection.GroupMemberColumnCreationDate,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnSequence = Column{
+		name:  projection.GroupMemberColumnSequence,
+		table: groupMemberTable,
+	}
+)
+
+var (
+	groupMetadataTable = table{
+		name:          projection.GroupMetadataTable,
+		instanceIDCol: projection.GroupMetadataColumnInstanceID,
+	}
+	GroupMetadataColumnGroupID = Column{
+		name:  projection.GroupMetadataColumnGroupID,
+		table: groupMetadataTable,
+	}
+	GroupMetadataColumnKey = Column{
+		name:  projection.GroupMetadataColumnKey,
+		table: groupMetadataTable,
+	}
+	GroupMetadataColumnValue = Column{
+		name:  projection.GroupMetadataColumnValue,
+		table: groupMetadataTable,
+	}
+	GroupMetadataColumnInstanceID = Column{
+		name:  projection.GroupMetadataColumnInstanceID,
+		table: groupMetadataTable,
+	}
+	GroupMetadataColumnCreationDate = Column{
+		name:  projection.GroupMetadataColumnCreationDate,
+		table: groupMetadataTable,
+	}
+	GroupMetadataColumnChangeDate = Column{
+		name:  projection.GroupMetadataColumnChangeDate,
+		table: groupMetadataTable,
+	}
+	GroupMetadataColumnSequence = Column{
+		name:  projection.GroupMetadataColumnSequence,
+		table: groupMetadataTable,
+	}
+)
+
+var (
+	groupGrantTable = table{
+		name:          projection.GroupGrantTable,
+		instanceIDCol: projection.GroupGrantColumnInstanceID,
+	}
+	GroupGrantColumnID = Column{
+		name:  projection.GroupGrantColumnID,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnGroupID = Column{
+		name:  projection.GroupGrantColumnGroupID,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnInstanceID = Column{
+		name:  projection.GroupGrantColumnInstanceID,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnCreationDate = Column{
+		name:  projection.GroupGrantColumnCreationDate,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnChangeDate = Column{
+		name:  projection.GroupGrantColumnChangeDate,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnSequence = Column{
+		name:  projection.GroupGrantColumnSequence,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnProjectID = Column{
+		name:  projection.GroupGrantColumnProjectID,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnProjectGrantID = Column{
+		name:  projection.GroupGrantColumnProjectGrantID,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnRoles = Column{
+		name:  projection.GroupGrantColumnRoles,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnState = Column{
+		name:  projection.GroupGrantColumnState,
+		table: groupGrantTable,
+	}
+)
+
+type Groups struct {
+	SearchResponse
+	Groups []*Group
+}
+
+type Group struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.GroupState
+	Sequence      uint64
+
+	Name        string
+	Description string
+}
+
+type GroupSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *GroupSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+type GroupMembers struct {
+	SearchResponse
+	Members []*GroupMember
+}
+
+type GroupMember struct {
+	GroupID      string
+	UserID       string
+	CreationDate time.Time
+	Sequence     uint64
+
+	PreferredLoginName string
+	DisplayName        string
+	UserType           domain.UserType
+	UserResourceOwner  string
+}
+
+type GroupMetadataList struct {
+	SearchResponse
+	Metadata []*GroupMetadata
+}
+
+type GroupMetadata struct {
+	GroupID      string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Sequence     uint64
+	Key          string
+	Value        []byte
+}
+
+type GroupGrants struct {
+	SearchResponse
+	Grants []*GroupGrant
+}
+
+type GroupGrant struct {
+	ID           string
+	GroupID      string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Sequence     uint64
+
+	ProjectID      string
+	ProjectName    string
+	ProjectGrantID string
+	Roles          database.StringArray
+	State          domain.GroupGrantState
+}
+
+type GroupSubSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *GroupSubSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) GroupByID(ctx context.Context, shouldTriggerBulk bool, id, resourceOwner string, withOwnerRemoved bool) (_ *Group, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		logging.OnError(
+			projection.GroupProjection.Trigger(ctx),
+		).Debug("unable to trigger")
+	}
+
+	stmt, scan := prepareGroupQuery(ctx, q.client)
+	eq := sq.Eq{
+		GroupColumnID.identifier():            id,
+		GroupColumnResourceOwner.identifier(): resourceOwner,
+		GroupColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[GroupColumnOwnerRemoved.identifier()] = false
+	}
+	query, args, err := stmt.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ks8am", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (q *Queries) SearchGroups(ctx context.Context, queries *GroupSearchQueries, withOwnerRemoved bool) (groups *Groups, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareGroupsQuery(ctx, q.client)
+	eq := sq.Eq{
+		GroupColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[GroupColumnOwnerRemoved.identifier()] = false
+	}
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Zp3xe", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ml2sc", "Errors.Internal")
+	}
+	groups, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	groups.LatestSequence, err = q.latestSequence(ctx, groupTable)
+	return groups, err
+}
+
+func (q *Queries) SearchGroupMembers(ctx context.Context, groupID string, queries *GroupSubSearchQueries, withOwnerRemoved bool) (members *GroupMembers, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareGroupMembersQuery(ctx, q.client)
+	eq := sq.Eq{
+		GroupMemberColumnGroupID.identifier():    groupID,
+		GroupMemberColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[GroupColumnOwnerRemoved.identifier()] = false
+	}
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Vn3zs", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Jq9wd", "Errors.Internal")
+	}
+	members, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	members.LatestSequence, err = q.latestSequence(ctx, groupTable)
+	return members, err
+}
+
+func (q *Queries) SearchGroupMetadata(ctx context.Context, groupID string, queries *GroupSubSearchQueries, withOwnerRemoved bool) (metadata *GroupMetadataList, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareGroupMetadataListQuery(ctx, q.client)
+	eq := sq.Eq{
+		GroupMetadataColumnGroupID.identifier():    groupID,
+		GroupMetadataColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[GroupColumnOwnerRemoved.identifier()] = false
+	}
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ro5ta", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Hx4bm", "Errors.Internal")
+	}
+	metadata, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	metadata.LatestSequence, err = q.latestSequence(ctx, groupTable)
+	return metadata, err
+}
+
+func (q *Queries) SearchGroupGrants(ctx context.Context, groupID string, queries *GroupSubSearchQueries, withOwnerRemoved bool) (grants *GroupGrants, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareGroupGrantsQuery(ctx, q.client)
+	eq := sq.Eq{
+		GroupGrantColumnGroupID.identifier():    groupID,
+		GroupGrantColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[GroupColumnOwnerRemoved.identifier()] = false
+	}
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ub8qe", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ye1cv", "Errors.Internal")
+	}
+	grants, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	grants.LatestSequence, err = q.latestSequence(ctx, groupTable)
+	return grants, err
+}
+
+// GroupNamesOfUser returns the names of the groups the user is member of
+func (q *Queries) GroupNamesOfUser(ctx context.Context, userID string) (_ []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	userQuery, err := NewGroupMemberUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := q.SearchGroups(ctx, &GroupSearchQueries{Queries: []SearchQuery{userQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(groups.Groups))
+	for i, group := range groups.Groups {
+		names[i] = group.Name
+	}
+	return names, nil
+}
+
+func NewGroupResourceOwnerSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(GroupColumnResourceOwner, id, TextEquals)
+}
+
+func NewGroupNameSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(GroupColumnName, value, method)
+}
+
+func NewGroupIDsSearchQuery(ids []string) (SearchQuery, error) {
+	list := make([]interface{}, len(ids))
+	for i, value := range ids {
+		list[i] = value
+	}
+	return NewListQuery(GroupColumnID, list, ListIn)
+}
+
+// NewGroupMemberUserIDSearchQuery filters the groups the user is member of
+func NewGroupMemberUserIDSearchQuery(userID string) (SearchQuery, error) {
+	userQuery, err := NewTextQuery(GroupMemberColumnUserID, userID, TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	subSelect, err := NewSubSelect(GroupMemberColumnGroupID, []SearchQuery{userQuery})
+	if err != nil {
+		return nil, err
+	}
+	return NewListQuery(GroupColumnID, subSelect, ListIn)
+}
+
+func NewGroupMetadataKeySearchQuery(value string, comparison TextComparison) (SearchQuery, error) {
+	return NewTextQuery(GroupMetadataColumnKey, value, comparison)
+}
+
+func NewGroupGrantProjectIDSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(GroupGrantColumnProjectID, id, TextEquals)
+}
+
+// groupUserGrants returns the grants the user inherits from its groups,
+// the queries of the user grant search must restrict it to a specific user
+func (q *Queries) groupUserGrants(ctx context.Context, queries *UserGrantsQueries, withOwnerRemoved bool) (_ []*UserGrant, err error) {
+	groupQueries, err := groupGrantQueriesOfUserGrantQueries(queries.Queries)
+	if err != nil {
+		return nil, err
+	}
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareGroupUserGrantsQuery(ctx, q.client)
+	eq := sq.Eq{GroupGrantColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}
+	if !withOwnerRemoved {
+		eq[GroupColumnOwnerRemoved.identifier()] = false
+	}
+	for _, groupQuery := range groupQueries {
+		query = groupQuery.toQuery(query)
+	}
+	stmt, args, err := query.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Tk2vo", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Pe6rz", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+// groupUserGrantColumns maps the columns of a user grant search to the columns of the group grant search,
+// columns of joined tables which are joined in both searches are used as is
+var groupUserGrantColumns = map[Column]Column{
+	UserGrantUserID:            GroupMemberColumnUserID,
+	UserGrantProjectID:         GroupGrantColumnProjectID,
+	UserGrantGrantID:           GroupGrantColumnProjectGrantID,
+	UserGrantRoles:             GroupGrantColumnRoles,
+	UserGrantResourceOwner:     GroupColumnResourceOwner,
+	UserGrantState:             GroupGrantColumnState,
+	ProjectColumnName:          ProjectColumnName,
+	ProjectColumnResourceOwner: ProjectColumnResourceOwner,
+	OrgColumnName:              OrgColumnName,
+	OrgColumnDomain:            OrgColumnDomain,
+}
+
+// groupGrantQueriesOfUserGrantQueries maps the queries of a user grant search to group grants.
+// The search must contain a query for a specific user,
+// queries on columns which don't exist on group grants (e.g. user attributes) are rejected
+func groupGrantQueriesOfUserGrantQueries(queries []SearchQuery) ([]SearchQuery, error) {
+	var hasUser bool
+	for _, query := range queries {
+		if q, ok := query.(*TextQuery); ok && q.Column == UserGrantUserID && q.Compare == TextEquals {
+			hasUser = true
+			break
+		}
+	}
+	if !hasUser {
+		return nil, errors.ThrowInvalidArgument(nil, "QUERY-Gq1nu", "Errors.Group.Grant.QueryUnsupported")
+	}
+	return groupGrantQueries(queries)
+}
+
+func groupGrantQueries(queries []SearchQuery) ([]SearchQuery, error) {
+	groupQueries := make([]SearchQuery, len(queries))
+	for i, query := range queries {
+		groupQuery, err := groupGrantQuery(query)
+		if err != nil {
+			return nil, err
+		}
+		groupQueries[i] = groupQuery
+	}
+	return groupQueries, nil
+}
+
+func groupGrantQuery(query SearchQuery) (SearchQuery, error) {
+	switch q := query.(type) {
+	case *TextQuery:
+		column, ok := groupUserGrantColumns[q.Column]
+		if !ok {
+			break
+		}
+		return &TextQuery{Column: column, Text: q.Text, Compare: q.Compare}, nil
+	case *ListQuery:
+		column, ok := groupUserGrantColumns[q.Column]
+		if !ok {
+			break
+		}
+		return &ListQuery{Column: column, Data: q.Data, Compare: q.Compare}, nil
+	case *NumberQuery:
+		column, ok := groupUserGrantColumns[q.Column]
+		if !ok {
+			break
+		}
+		number := q.Number
+		if state, ok := number.(domain.UserGrantState); ok {
+			number = groupGrantStateOfUserGrantState(state)
+		}
+		return &NumberQuery{Column: column, Number: number, Compare: q.Compare}, nil
+	case *orQuery:
+		orQueries, err := groupGrantQueries(q.queries)
+		if err != nil {
+			return nil, err
+		}
+		return &orQuery{queries: orQueries}, nil
+	}
+	return nil, errors.ThrowInvalidArgument(nil, "QUERY-Gq2nu", "Errors.Group.Grant.QueryUnsupported")
+}
+
+func groupGrantStateOfUserGrantState(state domain.UserGrantState) domain.GroupGrantState {
+	switch state {
+	case domain.UserGrantStateActive:
+		return domain.GroupGrantStateActive
+	case domain.UserGrantStateInactive:
+		return domain.GroupGrantStateInactive
+	case domain.UserGrantStateRemoved:
+		return domain.GroupGrantStateRemoved
+	default:
+		return domain.GroupGrantStateUnspecified
+	}
+}
+
+func userGrantStateOfGroupGrantState(state domain.GroupGrantState) domain.UserGrantState {
+	switch state {
+	case domain.GroupGrantStateActive:
+		return domain.UserGrantStateActive
+	case domain.GroupGrantStateInactive:
+		return domain.UserGrantStateInactive
+	case domain.GroupGrantStateRemoved:
+		return domain.UserGrantStateRemoved
+	default:
+		return domain.UserGrantStateUnspecified
+	}
+}
+
+func prepareGroupQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(row *sql.Row) (*Group, error)) {
+	return sq.Select(
+			GroupColumnID.identifier(),
+			GroupColumnCreationDate.identifier(),
+			GroupColumnChangeDate.identifier(),
+			GroupColumnResourceOwner.identifier(),
+			GroupColumnSequence.identifier(),
+			GroupColumnState.identifier(),
+			GroupColumnName.identifier(),
+			GroupColumnDescription.identifier(),
+		).From(groupTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*Group, error) {
+			group := new(Group)
+			err := row.Scan(
+				&group.ID,
+				&group.CreationDate,
+				&group.ChangeDate,
+				&group.ResourceOwner,
+				&group.Sequence,
+				&group.State,
+				&group.Name,
+				&group.Description,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Wk7bs", "Errors.Group.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Cx0aq", "Errors.Internal")
+			}
+			return group, nil
+		}
+}
+
+func prepareGroupsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*Groups, error)) {
+	return sq.Select(
+			GroupColumnID.identifier(),
+			GroupColumnCreationDate.identifier(),
+			GroupColumnChangeDate.identifier(),
+			GroupColumnResourceOwner.identifier(),
+			GroupColumnSequence.identifier(),
+			GroupColumnState.identifier(),
+			GroupColumnName.identifier(),
+			GroupColumnDescription.identifier(),
+			countColumn.identifier(),
+		).From(groupTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Groups, error) {
+			groups := make([]*Group, 0)
+			var count uint64
+			for rows.Next() {
+				group := new(Group)
+				err := rows.Scan(
+					&group.ID,
+					&group.CreationDate,
+					&group.ChangeDate,
+					&group.ResourceOwner,
+					&group.Sequence,
+					&group.State,
+					&group.Name,
+					&group.Description,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				groups = append(groups, group)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ei9sl", "Errors.Query.CloseRows")
+			}
+
+			return &Groups{
+				Groups: groups,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareGroupMembersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*GroupMembers, error)) {
+	return sq.Select(
+			GroupMemberColumnGroupID.identifier(),
+			GroupMemberColumnUserID.identifier(),
+			GroupMemberColumnCreationDate.identifier(),
+			GroupMemberColumnSequence.identifier(),
+			LoginNameNameCol.identifier(),
+			HumanDisplayNameCol.identifier(),
+			UserTypeCol.identifier(),
+			UserResourceOwnerCol.identifier(),
+			countColumn.identifier(),
+		).From(groupMemberTable.identifier()).
+			LeftJoin(join(GroupColumnID, GroupMemberColumnGroupID)).
+			LeftJoin(join(UserIDCol, GroupMemberColumnUserID)).
+			LeftJoin(join(HumanUserIDCol, GroupMemberColumnUserID)).
+			LeftJoin(join(LoginNameUserIDCol, GroupMemberColumnUserID) + db.Timetravel(call.Took(ctx))).
+			Where(
+				sq.Eq{LoginNameIsPrimaryCol.identifier(): true},
+			).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*GroupMembers, error) {
+			members := make([]*GroupMember, 0)
+			var count uint64
+			for rows.Next() {
+				member := new(GroupMember)
+				var (
+					preferredLoginName sql.NullString
+					displayName        sql.NullString
+					userType           sql.NullInt32
+					userOwner          sql.NullString
+				)
+				err := rows.Scan(
+					&member.GroupID,
+					&member.UserID,
+					&member.CreationDate,
+					&member.Sequence,
+					&preferredLoginName,
+					&displayName,
+					&userType,
+					&userOwner,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				member.PreferredLoginName = preferredLoginName.String
+				member.DisplayName = displayName.String
+				member.UserType = domain.UserType(userType.Int32)
+				member.UserResourceOwner = userOwner.String
+				members = append(members, member)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Na4ug", "Errors.Query.CloseRows")
+			}
+
+			return &GroupMembers{
+				Members: members,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareGroupMetadataListQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*GroupMetadataList, error)) {
+	return sq.Select(
+			GroupMetadataColumnGroupID.identifier(),
+			GroupMetadataColumnCreationDate.identifier(),
+			GroupMetadataColumnChangeDate.identifier(),
+			GroupMetadataColumnSequence.identifier(),
+			GroupMetadataColumnKey.identifier(),
+			GroupMetadataColumnValue.identifier(),
+			countColumn.identifier(),
+		).From(groupMetadataTable.identifier()).
+			LeftJoin(join(GroupColumnID, GroupMetadataColumnGroupID) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*GroupMetadataList, error) {
+			metadata := make([]*GroupMetadata, 0)
+			var count uint64
+			for rows.Next() {
+				m := new(GroupMetadata)
+				err := rows.Scan(
+					&m.GroupID,
+					&m.CreationDate,
+					&m.ChangeDate,
+					&m.Sequence,
+					&m.Key,
+					&m.Value,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				metadata = append(metadata, m)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Gd5lf", "Errors.Query.CloseRows")
+			}
+
+			return &GroupMetadataList{
+				Metadata: metadata,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareGroupGrantsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*GroupGrants, error)) {
+	return sq.Select(
+			GroupGrantColumnID.identifier(),
+			GroupGrantColumnGroupID.identifier(),
+			GroupGrantColumnCreationDate.identifier(),
+			GroupGrantColumnChangeDate.identifier(),
+			GroupGrantColumnSequence.identifier(),
+			GroupGrantColumnProjectID.identifier(),
+			ProjectColumnName.identifier(),
+			GroupGrantColumnProjectGrantID.identifier(),
+			GroupGrantColumnRoles.identifier(),
+			GroupGrantColumnState.identifier(),
+			countColumn.identifier(),
+		).From(groupGrantTable.identifier()).
+			LeftJoin(join(GroupColumnID, GroupGrantColumnGroupID)).
+			LeftJoin(join(ProjectColumnID, GroupGrantColumnProjectID) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*GroupGrants, error) {
+			grants := make([]*GroupGrant, 0)
+			var count uint64
+			for rows.Next() {
+				grant := new(GroupGrant)
+				var projectName sql.NullString
+				err := rows.Scan(
+					&grant.ID,
+					&grant.GroupID,
+					&grant.CreationDate,
+					&grant.ChangeDate,
+					&grant.Sequence,
+					&grant.ProjectID,
+					&projectName,
+					&grant.ProjectGrantID,
+					&grant.Roles,
+					&grant.State,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				grant.ProjectName = projectName.String
+				grants = append(grants, grant)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ox7dn", "Errors.Query.CloseRows")
+			}
+
+			return &GroupGrants{
+				Grants: grants,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+// prepareGroupUserGrantsQuery selects the group grants of the groups of a user as user grants
+func prepareGroupUserGrantsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*UserGrant, error)) {
+	return sq.Select(
+			GroupGrantColumnID.identifier(),
+			GroupGrantColumnCreationDate.identifier(),
+			GroupGrantColumnChangeDate.identifier(),
+			GroupGrantColumnSequence.identifier(),
+			GroupGrantColumnProjectGrantID.identifier(),
+			GroupGrantColumnRoles.identifier(),
+			GroupGrantColumnState.identifier(),
+			GroupGrantColumnGroupID.identifier(),
+			GroupMemberColumnUserID.identifier(),
+			GroupColumnResourceOwner.identifier(),
+			OrgColumnName.identifier(),
+			OrgColumnDomain.identifier(),
+			GroupGrantColumnProjectID.identifier(),
+			ProjectColumnName.identifier(),
+		).
+			From(groupGrantTable.identifier()).
+			Join(join(GroupMemberColumnGroupID, GroupGrantColumnGroupID)).
+			Join(join(GroupColumnID, GroupGrantColumnGroupID)).
+			LeftJoin(join(OrgColumnID, GroupColumnResourceOwner)).
+			LeftJoin(join(ProjectColumnID, GroupGrantColumnProjectID) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*UserGrant, error) {
+			userGrants := make([]*UserGrant, 0)
+			for rows.Next() {
+				g := new(UserGrant)
+				var (
+					state       domain.GroupGrantState
+					orgName     sql.NullString
+					orgDomain   sql.NullString
+					projectName sql.NullString
+				)
+				err := rows.Scan(
+					&g.ID,
+					&g.CreationDate,
+					&g.ChangeDate,
+					&g.Sequence,
+					&g.GrantID,
+					&g.Roles,
+					&state,
+					&g.GroupID,
+					&g.UserID,
+					&g.ResourceOwner,
+					&orgName,
+					&orgDomain,
+					&g.ProjectID,
+					&projectName,
+				)
+				if err != nil {
+					return nil, err
+				}
+				g.State = userGrantStateOfGroupGrantState(state)
+				g.OrgName = orgName.String
+				g.OrgPrimaryDomain = orgDomain.String
+				g.ProjectName = projectName.String
+				userGrants = append(userGrants, g)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Li3mv", "Errors.Query.CloseRows")
+			}
+			return userGrants, nil
+		}
+}
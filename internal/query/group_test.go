@@ -0,0 +1,465 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	groupStmt = regexp.QuoteMeta(
+		"SELECT projections.groups.id" +
+			", projections.groups.creation_date" +
+			", projections.groups.change_date" +
+			", projections.groups.resource_owner" +
+			", projections.groups.sequence" +
+			", projections.groups.state" +
+			", projections.groups.name" +
+			", projections.groups.description" +
+			" FROM projections.groups" +
+			` AS OF SYSTEM TIME '-1 ms'`,
+	)
+	groupCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"state",
+		"name",
+		"description",
+	}
+	groupsStmt = regexp.QuoteMeta(
+		"SELECT projections.groups.id" +
+			", projections.groups.creation_date" +
+			", projections.groups.change_date" +
+			", projections.groups.resource_owner" +
+			", projections.groups.sequence" +
+			", projections.groups.state" +
+			", projections.groups.name" +
+			", projections.groups.description" +
+			", COUNT(*) OVER ()" +
+			" FROM projections.groups" +
+			` AS OF SYSTEM TIME '-1 ms'`,
+	)
+	groupsCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"state",
+		"name",
+		"description",
+		"count",
+	}
+	groupMembersStmt = regexp.QuoteMeta(
+		"SELECT projections.groups_members.group_id" +
+			", projections.groups_members.user_id" +
+			", projections.groups_members.creation_date" +
+			", projections.groups_members.sequence" +
+			", projections.login_names2.login_name" +
+			", projections.users8_humans.display_name" +
+			", projections.users8.type" +
+			", projections.users8.resource_owner" +
+			", COUNT(*) OVER ()" +
+			" FROM projections.groups_members" +
+			" LEFT JOIN projections.groups ON projections.groups_members.group_id = projections.groups.id AND projections.groups_members.instance_id = projections.groups.instance_id" +
+			" LEFT JOIN projections.users8 ON projections.groups_members.user_id = projections.users8.id AND projections.groups_members.instance_id = projections.users8.instance_id" +
+			" LEFT JOIN projections.users8_humans ON projections.groups_members.user_id = projections.users8_humans.user_id AND projections.groups_members.instance_id = projections.users8_humans.instance_id" +
+			" LEFT JOIN projections.login_names2 ON projections.groups_members.user_id = projections.login_names2.user_id AND projections.groups_members.instance_id = projections.login_names2.instance_id" +
+			` AS OF SYSTEM TIME '-1 ms'` +
+			" WHERE projections.login_names2.is_primary = $1",
+	)
+	groupMembersCols = []string{
+		"group_id",
+		"user_id",
+		"creation_date",
+		"sequence",
+		"login_name",
+		"display_name",
+		"type",
+		"resource_owner",
+		"count",
+	}
+	groupGrantsStmt = regexp.QuoteMeta(
+		"SELECT projections.groups_grants.id" +
+			", projections.groups_grants.group_id" +
+			", projections.groups_grants.creation_date" +
+			", projections.groups_grants.change_date" +
+			", projections.groups_grants.sequence" +
+			", projections.groups_grants.project_id" +
+			", projections.projects4.name" +
+			", projections.groups_grants.project_grant_id" +
+			", projections.groups_grants.roles" +
+			", projections.groups_grants.state" +
+			", COUNT(*) OVER ()" +
+			" FROM projections.groups_grants" +
+			" LEFT JOIN projections.groups ON projections.groups_grants.group_id = projections.groups.id AND projections.groups_grants.instance_id = projections.groups.instance_id" +
+			" LEFT JOIN projections.projects4 ON projections.groups_grants.project_id = projections.projects4.id AND projections.groups_grants.instance_id = projections.projects4.instance_id" +
+			` AS OF SYSTEM TIME '-1 ms'`,
+	)
+	groupGrantsCols = []string{
+		"id",
+		"group_id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"project_id",
+		"name",
+		"project_grant_id",
+		"roles",
+		"state",
+		"count",
+	}
+)
+
+func Test_GroupPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareGroupQuery no result",
+			prepare: prepareGroupQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					groupStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Group)(nil),
+		},
+		{
+			name:    "prepareGroupQuery found",
+			prepare: prepareGroupQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					groupStmt,
+					groupCols,
+					[]driver.Value{
+						"group-id",
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211108),
+						domain.GroupStateActive,
+						"name",
+						"description",
+					},
+				),
+			},
+			object: &Group{
+				ID:            "group-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				Sequence:      20211108,
+				State:         domain.GroupStateActive,
+				Name:          "name",
+				Description:   "description",
+			},
+		},
+		{
+			name:    "prepareGroupQuery sql err",
+			prepare: prepareGroupQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					groupStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareGroupsQuery no result",
+			prepare: prepareGroupsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					groupsStmt,
+					nil,
+					nil,
+				),
+			},
+			object: &Groups{Groups: []*Group{}},
+		},
+		{
+			name:    "prepareGroupsQuery multiple results",
+			prepare: prepareGroupsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					groupsStmt,
+					groupsCols,
+					[][]driver.Value{
+						{
+							"group-id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211108),
+							domain.GroupStateActive,
+							"name",
+							"description",
+						},
+						{
+							"group-id2",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211108),
+							domain.GroupStateActive,
+							"name2",
+							"",
+						},
+					},
+				),
+			},
+			object: &Groups{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Groups: []*Group{
+					{
+						ID:            "group-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211108,
+						State:         domain.GroupStateActive,
+						Name:          "name",
+						Description:   "description",
+					},
+					{
+						ID:            "group-id2",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211108,
+						State:         domain.GroupStateActive,
+						Name:          "name2",
+						Description:   "",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareGroupsQuery sql err",
+			prepare: prepareGroupsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					groupsStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareGroupMembersQuery one result",
+			prepare: prepareGroupMembersQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					groupMembersStmt,
+					groupMembersCols,
+					[][]driver.Value{
+						{
+							"group-id",
+							"user-id",
+							testNow,
+							uint64(20211108),
+							"gigi@caos-ch.zitadel.ch",
+							"Gigi Giraffe",
+							domain.UserTypeHuman,
+							"org-id",
+						},
+					},
+				),
+			},
+			object: &GroupMembers{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Members: []*GroupMember{
+					{
+						GroupID:            "group-id",
+						UserID:             "user-id",
+						CreationDate:       testNow,
+						Sequence:           20211108,
+						PreferredLoginName: "gigi@caos-ch.zitadel.ch",
+						DisplayName:        "Gigi Giraffe",
+						UserType:           domain.UserTypeHuman,
+						UserResourceOwner:  "org-id",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareGroupMembersQuery sql err",
+			prepare: prepareGroupMembersQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					groupMembersStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareGroupGrantsQuery one result",
+			prepare: prepareGroupGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					groupGrantsStmt,
+					groupGrantsCols,
+					[][]driver.Value{
+						{
+							"grant-id",
+							"group-id",
+							testNow,
+							testNow,
+							uint64(20211108),
+							"project-id",
+							"project-name",
+							"",
+							database.StringArray{"role-key"},
+							domain.GroupGrantStateInactive,
+						},
+					},
+				),
+			},
+			object: &GroupGrants{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Grants: []*GroupGrant{
+					{
+						ID:           "grant-id",
+						GroupID:      "group-id",
+						CreationDate: testNow,
+						ChangeDate:   testNow,
+						Sequence:     20211108,
+						ProjectID:    "project-id",
+						ProjectName:  "project-name",
+						Roles:        database.StringArray{"role-key"},
+						State:        domain.GroupGrantStateInactive,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareGroupGrantsQuery sql err",
+			prepare: prepareGroupGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					groupGrantsStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func Test_groupGrantQueriesOfUserGrantQueries(t *testing.T) {
+	userQuery, _ := NewUserGrantUserIDSearchQuery("user-id")
+	projectQuery, _ := NewUserGrantProjectIDsSearchQuery([]string{"project-id"})
+	grantedQuery, _ := NewUserGrantWithGrantedQuery("org-id")
+	stateQuery, _ := NewNumberQuery(UserGrantState, domain.UserGrantStateInactive, NumberEquals)
+	emailQuery, _ := NewUserGrantEmailQuery("user@zitadel.ch", TextEquals)
+	tests := []struct {
+		name    string
+		queries []SearchQuery
+		want    []SearchQuery
+		wantErr func(error) bool
+	}{
+		{
+			name:    "no user query, invalid argument error",
+			queries: []SearchQuery{projectQuery},
+			wantErr: errs.IsErrorInvalidArgument,
+		},
+		{
+			name:    "unsupported column, invalid argument error",
+			queries: []SearchQuery{userQuery, emailQuery},
+			wantErr: errs.IsErrorInvalidArgument,
+		},
+		{
+			name:    "supported queries, translated",
+			queries: []SearchQuery{userQuery, projectQuery, grantedQuery, stateQuery},
+			want: []SearchQuery{
+				&TextQuery{Column: GroupMemberColumnUserID, Text: "user-id", Compare: TextEquals},
+				&ListQuery{Column: GroupGrantColumnProjectID, Data: []interface{}{"project-id"}, Compare: ListIn},
+				&orQuery{queries: []SearchQuery{
+					&TextQuery{Column: GroupColumnResourceOwner, Text: "org-id", Compare: TextEquals},
+					&TextQuery{Column: ProjectColumnResourceOwner, Text: "org-id", Compare: TextEquals},
+				}},
+				&NumberQuery{Column: GroupGrantColumnState, Number: domain.GroupGrantStateInactive, Compare: NumberEquals},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := groupGrantQueriesOfUserGrantQueries(tt.queries)
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -205,14 +205,42 @@ func (q *Queries) InstanceByHost(ctx context.Context, host string) (_ authz.Inst
 	defer func() { span.EndWithError(err) }()
 
 	stmt, scan := prepareAuthzInstanceQuery(ctx, q.client, host)
-	host = strings.Split(host, ":")[0] //remove possible port
+	domain := strings.Split(host, ":")[0] //remove possible port
 	query, args, err := stmt.Where(sq.Eq{
-		InstanceDomainDomainCol.identifier(): host,
+		InstanceDomainDomainCol.identifier(): domain,
 	}).ToSql()
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "QUERY-SAfg2", "Errors.Query.SQLStatement")
 	}
 
+	row, err := q.client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	instance, err := scan(row)
+	if err == nil {
+		return instance, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+	return q.instanceByLoginDomain(ctx, host, domain)
+}
+
+// instanceByLoginDomain resolves the instance of a login domain of an organization
+func (q *Queries) instanceByLoginDomain(ctx context.Context, host, loginDomain string) (_ authz.Instance, err error) {
+	instanceID, err := q.instanceIDByLoginDomain(ctx, loginDomain)
+	if err != nil {
+		return nil, err
+	}
+	stmt, scan := prepareAuthzInstanceQuery(ctx, q.client, host)
+	query, args, err := stmt.Where(sq.Eq{
+		InstanceColumnID.identifier(): instanceID,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Lg0sq", "Errors.Query.SQLStatement")
+	}
+
 	row, err := q.client.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
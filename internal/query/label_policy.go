@@ -46,6 +46,25 @@ func (q *Queries) ActiveLabelPolicyByOrg(ctx context.Context, orgID string, with
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	policy, err := q.activeLabelPolicyByOrg(ctx, orgID, withOwnerRemoved)
+	if err != nil || !policy.IsDefault {
+		return policy, err
+	}
+	// orgs without a custom policy inherit the policy of the nearest ancestor defining one
+	ancestorIDs, err := q.OrgAncestorIDs(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ancestorID := range ancestorIDs {
+		ancestorPolicy, err := q.activeLabelPolicyByOrg(ctx, ancestorID, withOwnerRemoved)
+		if err != nil || !ancestorPolicy.IsDefault {
+			return ancestorPolicy, err
+		}
+	}
+	return policy, nil
+}
+
+func (q *Queries) activeLabelPolicyByOrg(ctx context.Context, orgID string, withOwnerRemoved bool) (*LabelPolicy, error) {
 	stmt, scan := prepareLabelPolicyQuery(ctx, q.client)
 	eq := sq.Eq{
 		LabelPolicyColState.identifier():      domain.LabelPolicyStateActive,
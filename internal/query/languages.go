@@ -6,9 +6,12 @@ import (
 	"github.com/zitadel/logging"
 	"golang.org/x/text/language"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/i18n"
 )
 
+// Languages returns the languages of the translation files
+// and the languages added to the instance by custom texts
 func (q *Queries) Languages(ctx context.Context) ([]language.Tag, error) {
 	if len(q.supportedLangs) == 0 {
 		langs, err := i18n.SupportedLanguages(q.LoginDir)
@@ -18,5 +21,24 @@ func (q *Queries) Languages(ctx context.Context) ([]language.Tag, error) {
 		}
 		q.supportedLangs = langs
 	}
-	return q.supportedLangs, nil
+	customLangs, err := q.customTextLanguages(ctx, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	langs := append(make([]language.Tag, 0, len(q.supportedLangs)+len(customLangs)), q.supportedLangs...)
+	for _, customLang := range customLangs {
+		if !containsLanguage(langs, customLang) {
+			langs = append(langs, customLang)
+		}
+	}
+	return langs, nil
+}
+
+func containsLanguage(langs []language.Tag, lang language.Tag) bool {
+	for _, l := range langs {
+		if l == lang {
+			return true
+		}
+	}
+	return false
 }
@@ -35,6 +35,9 @@ type LoginPolicy struct {
 	AllowDomainDiscovery       bool
 	DisableLoginWithEmail      bool
 	DisableLoginWithPhone      bool
+	AllowMagicLink             bool
+	AllowPhoneOTP              bool
+	BotProtection              domain.BotProtectionType
 	DefaultRedirectURI         string
 	PasswordCheckLifetime      time.Duration
 	ExternalLoginCheckLifetime time.Duration
@@ -131,6 +134,18 @@ var (
 		name:  projection.DisableLoginWithPhone,
 		table: loginPolicyTable,
 	}
+	LoginPolicyColumnAllowMagicLink = Column{
+		name:  projection.AllowMagicLink,
+		table: loginPolicyTable,
+	}
+	LoginPolicyColumnAllowPhoneOTP = Column{
+		name:  projection.AllowPhoneOTP,
+		table: loginPolicyTable,
+	}
+	LoginPolicyColumnBotProtection = Column{
+		name:  projection.BotProtectionCol,
+		table: loginPolicyTable,
+	}
 	LoginPolicyColumnDefaultRedirectURI = Column{
 		name:  projection.DefaultRedirectURI,
 		table: loginPolicyTable,
@@ -168,6 +183,31 @@ func (q *Queries) LoginPolicyByID(ctx context.Context, shouldTriggerBulk bool, o
 	if shouldTriggerBulk {
 		projection.LoginPolicyProjection.Trigger(ctx)
 	}
+	policy, err := q.loginPolicyByOrg(ctx, orgID, withOwnerRemoved)
+	if err != nil {
+		return nil, err
+	}
+	if policy.IsDefault {
+		// orgs without a custom policy inherit the policy of the nearest ancestor defining one
+		ancestorIDs, err := q.OrgAncestorIDs(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		for _, ancestorID := range ancestorIDs {
+			ancestorPolicy, err := q.loginPolicyByOrg(ctx, ancestorID, withOwnerRemoved)
+			if err != nil {
+				return nil, err
+			}
+			if !ancestorPolicy.IsDefault {
+				policy = ancestorPolicy
+				break
+			}
+		}
+	}
+	return q.addLinksToLoginPolicy(ctx, policy)
+}
+
+func (q *Queries) loginPolicyByOrg(ctx context.Context, orgID string, withOwnerRemoved bool) (*LoginPolicy, error) {
 	eq := sq.Eq{LoginPolicyColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}
 	if !withOwnerRemoved {
 		eq[LoginPolicyColumnOwnerRemoved.identifier()] = false
@@ -190,7 +230,7 @@ func (q *Queries) LoginPolicyByID(ctx context.Context, shouldTriggerBulk bool, o
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "QUERY-SWgr3", "Errors.Internal")
 	}
-	return q.scanAndAddLinksToLoginPolicy(ctx, rows, scan)
+	return scan(rows)
 }
 
 func (q *Queries) scanAndAddLinksToLoginPolicy(ctx context.Context, rows *sql.Rows, scan func(*sql.Rows) (*LoginPolicy, error)) (*LoginPolicy, error) {
@@ -198,7 +238,10 @@ func (q *Queries) scanAndAddLinksToLoginPolicy(ctx context.Context, rows *sql.Ro
 	if err != nil {
 		return nil, err
 	}
+	return q.addLinksToLoginPolicy(ctx, policy)
+}
 
+func (q *Queries) addLinksToLoginPolicy(ctx context.Context, policy *LoginPolicy) (*LoginPolicy, error) {
 	links, err := q.IDPLoginPolicyLinks(ctx, policy.OrgID, &IDPLoginPolicyLinksSearchQuery{}, false)
 	if err != nil {
 		return nil, err
@@ -360,6 +403,9 @@ func prepareLoginPolicyQuery(ctx context.Context, db prepareDatabase) (sq.Select
 			LoginPolicyColumnAllowDomainDiscovery.identifier(),
 			LoginPolicyColumnDisableLoginWithEmail.identifier(),
 			LoginPolicyColumnDisableLoginWithPhone.identifier(),
+			LoginPolicyColumnAllowMagicLink.identifier(),
+			LoginPolicyColumnAllowPhoneOTP.identifier(),
+			LoginPolicyColumnBotProtection.identifier(),
 			LoginPolicyColumnDefaultRedirectURI.identifier(),
 			LoginPolicyColumnPasswordCheckLifetime.identifier(),
 			LoginPolicyColumnExternalLoginCheckLifetime.identifier(),
@@ -390,6 +436,9 @@ func prepareLoginPolicyQuery(ctx context.Context, db prepareDatabase) (sq.Select
 					&p.AllowDomainDiscovery,
 					&p.DisableLoginWithEmail,
 					&p.DisableLoginWithPhone,
+					&p.AllowMagicLink,
+					&p.AllowPhoneOTP,
+					&p.BotProtection,
 					&defaultRedirectURI,
 					&p.PasswordCheckLifetime,
 					&p.ExternalLoginCheckLifetime,
@@ -31,6 +31,13 @@ type MessageTexts struct {
 	DomainClaimed            MessageText
 	PasswordlessRegistration MessageText
 	PasswordChange           MessageText
+	MagicLink                MessageText
+	PhoneOTP                 MessageText
+	NewDeviceLogin           MessageText
+	MFAAdded                 MessageText
+	MFARemoved               MessageText
+	EmailChanged             MessageText
+	AccountLocked            MessageText
 }
 
 type MessageText struct {
@@ -335,6 +342,20 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *MessageText {
 		return &m.PasswordlessRegistration
 	case domain.PasswordChangeMessageType:
 		return &m.PasswordChange
+	case domain.MagicLinkMessageType:
+		return &m.MagicLink
+	case domain.PhoneOTPMessageType:
+		return &m.PhoneOTP
+	case domain.NewDeviceLoginMessageType:
+		return &m.NewDeviceLogin
+	case domain.MFAAddedMessageType:
+		return &m.MFAAdded
+	case domain.MFARemovedMessageType:
+		return &m.MFARemoved
+	case domain.EmailChangedMessageType:
+		return &m.EmailChanged
+	case domain.AccountLockedMessageType:
+		return &m.AccountLocked
 	}
 	return nil
 }
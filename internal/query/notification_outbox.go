@@ -0,0 +1,352 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	notificationOutboxTable = table{
+		name:          projection.NotificationOutboxProjectionTable,
+		instanceIDCol: projection.NotificationOutboxColumnInstanceID,
+	}
+	NotificationOutboxColumnID = Column{
+		name:  projection.NotificationOutboxColumnID,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnCreationDate = Column{
+		name:  projection.NotificationOutboxColumnCreationDate,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnChangeDate = Column{
+		name:  projection.NotificationOutboxColumnChangeDate,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnSequence = Column{
+		name:  projection.NotificationOutboxColumnSequence,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnResourceOwner = Column{
+		name:  projection.NotificationOutboxColumnResourceOwner,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnInstanceID = Column{
+		name:  projection.NotificationOutboxColumnInstanceID,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnUserID = Column{
+		name:  projection.NotificationOutboxColumnUserID,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnTriggerEventType = Column{
+		name:  projection.NotificationOutboxColumnTriggerEventType,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnTriggerSequence = Column{
+		name:  projection.NotificationOutboxColumnTriggerSequence,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnChannel = Column{
+		name:  projection.NotificationOutboxColumnChannel,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnRecipient = Column{
+		name:  projection.NotificationOutboxColumnRecipient,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnMessageType = Column{
+		name:  projection.NotificationOutboxColumnMessageType,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnState = Column{
+		name:  projection.NotificationOutboxColumnState,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnAttempts = Column{
+		name:  projection.NotificationOutboxColumnAttempts,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnLastError = Column{
+		name:  projection.NotificationOutboxColumnLastError,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnRetryAt = Column{
+		name:  projection.NotificationOutboxColumnRetryAt,
+		table: notificationOutboxTable,
+	}
+)
+
+type NotificationDeliveries struct {
+	SearchResponse
+	Deliveries []*NotificationDelivery
+}
+
+type NotificationDelivery struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+	InstanceID    string
+
+	UserID           string
+	TriggerEventType eventstore.EventType
+	TriggerSequence  uint64
+	Channel          domain.NotificationType
+	Recipient        string
+	MessageType      string
+	State            domain.NotificationDeliveryState
+	Attempts         uint16
+	LastError        string
+	RetryAt          time.Time
+}
+
+type NotificationDeliverySearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *NotificationDeliverySearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewNotificationDeliveryUserIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(NotificationOutboxColumnUserID, value, TextEquals)
+}
+
+func NewNotificationDeliveryResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(NotificationOutboxColumnResourceOwner, value, TextEquals)
+}
+
+func NewNotificationDeliveryStateSearchQuery(value domain.NotificationDeliveryState) (SearchQuery, error) {
+	return NewNumberQuery(NotificationOutboxColumnState, value, NumberEquals)
+}
+
+func (q *Queries) NotificationDeliveryByID(ctx context.Context, shouldTriggerBulk bool, id string, queries ...SearchQuery) (_ *NotificationDelivery, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		projection.NotificationOutboxProjection.Trigger(ctx)
+	}
+
+	query, scan := prepareNotificationDeliveryQuery(ctx, q.client)
+	for _, q := range queries {
+		query = q.toQuery(query)
+	}
+	stmt, args, err := query.Where(sq.Eq{
+		NotificationOutboxColumnID.identifier():         id,
+		NotificationOutboxColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Nt5fa", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+// NotificationDeliveryByTrigger returns the outbox entry of the notification sent for the event of the user
+func (q *Queries) NotificationDeliveryByTrigger(ctx context.Context, shouldTriggerBulk bool, userID string, triggerSequence uint64) (_ *NotificationDelivery, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		projection.NotificationOutboxProjection.Trigger(ctx)
+	}
+
+	query, scan := prepareNotificationDeliveryQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		NotificationOutboxColumnUserID.identifier():          userID,
+		NotificationOutboxColumnTriggerSequence.identifier(): triggerSequence,
+		NotificationOutboxColumnInstanceID.identifier():      authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Nt5fb", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func (q *Queries) SearchNotificationDeliveries(ctx context.Context, queries *NotificationDeliverySearchQueries) (deliveries *NotificationDeliveries, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareNotificationDeliveriesQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		NotificationOutboxColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Nt5fc", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Nt5fd", "Errors.Internal")
+	}
+	deliveries, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	deliveries.LatestSequence, err = q.latestSequence(ctx, notificationOutboxTable)
+	return deliveries, err
+}
+
+// NotificationDeliveriesToRetry returns the outbox entries of all instances which are due for a retry
+func (q *Queries) NotificationDeliveriesToRetry(ctx context.Context, dueBefore time.Time, limit uint64) (deliveries *NotificationDeliveries, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareNotificationDeliveriesQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.And{
+		sq.Eq{NotificationOutboxColumnState.identifier(): domain.NotificationDeliveryStateRetrying},
+		sq.LtOrEq{NotificationOutboxColumnRetryAt.identifier(): dueBefore},
+	}).
+		OrderBy(NotificationOutboxColumnRetryAt.identifier()).
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Nt5fe", "Errors.Query.SQLStatment")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Nt5ff", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+func prepareNotificationDeliveryQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*NotificationDelivery, error)) {
+	return sq.Select(
+			NotificationOutboxColumnID.identifier(),
+			NotificationOutboxColumnCreationDate.identifier(),
+			NotificationOutboxColumnChangeDate.identifier(),
+			NotificationOutboxColumnSequence.identifier(),
+			NotificationOutboxColumnResourceOwner.identifier(),
+			NotificationOutboxColumnInstanceID.identifier(),
+			NotificationOutboxColumnUserID.identifier(),
+			NotificationOutboxColumnTriggerEventType.identifier(),
+			NotificationOutboxColumnTriggerSequence.identifier(),
+			NotificationOutboxColumnChannel.identifier(),
+			NotificationOutboxColumnRecipient.identifier(),
+			NotificationOutboxColumnMessageType.identifier(),
+			NotificationOutboxColumnState.identifier(),
+			NotificationOutboxColumnAttempts.identifier(),
+			NotificationOutboxColumnLastError.identifier(),
+			NotificationOutboxColumnRetryAt.identifier(),
+		).From(notificationOutboxTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*NotificationDelivery, error) {
+			delivery := new(NotificationDelivery)
+			var retryAt sql.NullTime
+			err := row.Scan(
+				&delivery.ID,
+				&delivery.CreationDate,
+				&delivery.ChangeDate,
+				&delivery.Sequence,
+				&delivery.ResourceOwner,
+				&delivery.InstanceID,
+				&delivery.UserID,
+				&delivery.TriggerEventType,
+				&delivery.TriggerSequence,
+				&delivery.Channel,
+				&delivery.Recipient,
+				&delivery.MessageType,
+				&delivery.State,
+				&delivery.Attempts,
+				&delivery.LastError,
+				&retryAt,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Nt5fg", "Errors.Notification.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Nt5fh", "Errors.Internal")
+			}
+			delivery.RetryAt = retryAt.Time
+			return delivery, nil
+		}
+}
+
+func prepareNotificationDeliveriesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*NotificationDeliveries, error)) {
+	return sq.Select(
+			NotificationOutboxColumnID.identifier(),
+			NotificationOutboxColumnCreationDate.identifier(),
+			NotificationOutboxColumnChangeDate.identifier(),
+			NotificationOutboxColumnSequence.identifier(),
+			NotificationOutboxColumnResourceOwner.identifier(),
+			NotificationOutboxColumnInstanceID.identifier(),
+			NotificationOutboxColumnUserID.identifier(),
+			NotificationOutboxColumnTriggerEventType.identifier(),
+			NotificationOutboxColumnTriggerSequence.identifier(),
+			NotificationOutboxColumnChannel.identifier(),
+			NotificationOutboxColumnRecipient.identifier(),
+			NotificationOutboxColumnMessageType.identifier(),
+			NotificationOutboxColumnState.identifier(),
+			NotificationOutboxColumnAttempts.identifier(),
+			NotificationOutboxColumnLastError.identifier(),
+			NotificationOutboxColumnRetryAt.identifier(),
+			countColumn.identifier(),
+		).From(notificationOutboxTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*NotificationDeliveries, error) {
+			deliveries := make([]*NotificationDelivery, 0)
+			var count uint64
+			for rows.Next() {
+				delivery := new(NotificationDelivery)
+				var retryAt sql.NullTime
+				err := rows.Scan(
+					&delivery.ID,
+					&delivery.CreationDate,
+					&delivery.ChangeDate,
+					&delivery.Sequence,
+					&delivery.ResourceOwner,
+					&delivery.InstanceID,
+					&delivery.UserID,
+					&delivery.TriggerEventType,
+					&delivery.TriggerSequence,
+					&delivery.Channel,
+					&delivery.Recipient,
+					&delivery.MessageType,
+					&delivery.State,
+					&delivery.Attempts,
+					&delivery.LastError,
+					&retryAt,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				delivery.RetryAt = retryAt.Time
+				deliveries = append(deliveries, delivery)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Nt5fi", "Errors.Query.CloseRows")
+			}
+
+			return &NotificationDeliveries{
+				Deliveries: deliveries,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,276 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	notificationDeliveryStmt = regexp.QuoteMeta(
+		"SELECT projections.notification_outbox.id," +
+			" projections.notification_outbox.creation_date," +
+			" projections.notification_outbox.change_date," +
+			" projections.notification_outbox.sequence," +
+			" projections.notification_outbox.resource_owner," +
+			" projections.notification_outbox.instance_id," +
+			" projections.notification_outbox.user_id," +
+			" projections.notification_outbox.trigger_event_type," +
+			" projections.notification_outbox.trigger_sequence," +
+			" projections.notification_outbox.channel," +
+			" projections.notification_outbox.recipient," +
+			" projections.notification_outbox.message_type," +
+			" projections.notification_outbox.state," +
+			" projections.notification_outbox.attempts," +
+			" projections.notification_outbox.last_error," +
+			" projections.notification_outbox.retry_at" +
+			" FROM projections.notification_outbox" +
+			` AS OF SYSTEM TIME '-1 ms'`)
+	notificationDeliveryCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"instance_id",
+		"user_id",
+		"trigger_event_type",
+		"trigger_sequence",
+		"channel",
+		"recipient",
+		"message_type",
+		"state",
+		"attempts",
+		"last_error",
+		"retry_at",
+	}
+	notificationDeliveriesStmt = regexp.QuoteMeta(
+		"SELECT projections.notification_outbox.id," +
+			" projections.notification_outbox.creation_date," +
+			" projections.notification_outbox.change_date," +
+			" projections.notification_outbox.sequence," +
+			" projections.notification_outbox.resource_owner," +
+			" projections.notification_outbox.instance_id," +
+			" projections.notification_outbox.user_id," +
+			" projections.notification_outbox.trigger_event_type," +
+			" projections.notification_outbox.trigger_sequence," +
+			" projections.notification_outbox.channel," +
+			" projections.notification_outbox.recipient," +
+			" projections.notification_outbox.message_type," +
+			" projections.notification_outbox.state," +
+			" projections.notification_outbox.attempts," +
+			" projections.notification_outbox.last_error," +
+			" projections.notification_outbox.retry_at," +
+			" COUNT(*) OVER ()" +
+			" FROM projections.notification_outbox" +
+			` AS OF SYSTEM TIME '-1 ms'`)
+	notificationDeliveriesCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"instance_id",
+		"user_id",
+		"trigger_event_type",
+		"trigger_sequence",
+		"channel",
+		"recipient",
+		"message_type",
+		"state",
+		"attempts",
+		"last_error",
+		"retry_at",
+		"count",
+	}
+)
+
+func Test_NotificationDeliveryPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareNotificationDeliveryQuery no result",
+			prepare: prepareNotificationDeliveryQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					notificationDeliveryStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*NotificationDelivery)(nil),
+		},
+		{
+			name:    "prepareNotificationDeliveryQuery found",
+			prepare: prepareNotificationDeliveryQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					notificationDeliveryStmt,
+					notificationDeliveryCols,
+					[]driver.Value{
+						"notification-id",
+						testNow,
+						testNow,
+						uint64(20211109),
+						"ro",
+						"instance-id",
+						"user-id",
+						"user.human.email.code.added",
+						uint64(5),
+						domain.NotificationTypeEmail,
+						"email@test.ch",
+						"VerifyEmail",
+						domain.NotificationDeliveryStateRetrying,
+						uint16(1),
+						"connection refused",
+						testNow,
+					},
+				),
+			},
+			object: &NotificationDelivery{
+				ID:               "notification-id",
+				CreationDate:     testNow,
+				ChangeDate:       testNow,
+				Sequence:         20211109,
+				ResourceOwner:    "ro",
+				InstanceID:       "instance-id",
+				UserID:           "user-id",
+				TriggerEventType: "user.human.email.code.added",
+				TriggerSequence:  5,
+				Channel:          domain.NotificationTypeEmail,
+				Recipient:        "email@test.ch",
+				MessageType:      "VerifyEmail",
+				State:            domain.NotificationDeliveryStateRetrying,
+				Attempts:         1,
+				LastError:        "connection refused",
+				RetryAt:          testNow,
+			},
+		},
+		{
+			name:    "prepareNotificationDeliveryQuery sql err",
+			prepare: prepareNotificationDeliveryQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					notificationDeliveryStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareNotificationDeliveriesQuery no result",
+			prepare: prepareNotificationDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					notificationDeliveriesStmt,
+					nil,
+					nil,
+				),
+			},
+			object: &NotificationDeliveries{Deliveries: []*NotificationDelivery{}},
+		},
+		{
+			name:    "prepareNotificationDeliveriesQuery one result",
+			prepare: prepareNotificationDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					notificationDeliveriesStmt,
+					notificationDeliveriesCols,
+					[][]driver.Value{
+						{
+							"notification-id",
+							testNow,
+							testNow,
+							uint64(20211109),
+							"ro",
+							"instance-id",
+							"user-id",
+							"user.human.email.code.added",
+							uint64(5),
+							domain.NotificationTypeEmail,
+							"email@test.ch",
+							"VerifyEmail",
+							domain.NotificationDeliveryStateRetrying,
+							uint16(1),
+							"connection refused",
+							testNow,
+						},
+					},
+				),
+			},
+			object: &NotificationDeliveries{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Deliveries: []*NotificationDelivery{
+					{
+						ID:               "notification-id",
+						CreationDate:     testNow,
+						ChangeDate:       testNow,
+						Sequence:         20211109,
+						ResourceOwner:    "ro",
+						InstanceID:       "instance-id",
+						UserID:           "user-id",
+						TriggerEventType: "user.human.email.code.added",
+						TriggerSequence:  5,
+						Channel:          domain.NotificationTypeEmail,
+						Recipient:        "email@test.ch",
+						MessageType:      "VerifyEmail",
+						State:            domain.NotificationDeliveryStateRetrying,
+						Attempts:         1,
+						LastError:        "connection refused",
+						RetryAt:          testNow,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareNotificationDeliveriesQuery sql err",
+			prepare: prepareNotificationDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					notificationDeliveriesStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
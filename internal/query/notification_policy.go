@@ -25,6 +25,11 @@ type NotificationPolicy struct {
 	State         domain.PolicyState
 
 	PasswordChange bool
+	NewDeviceLogin bool
+	MFAAdded       bool
+	MFARemoved     bool
+	EmailChange    bool
+	AccountLocked  bool
 
 	IsDefault bool
 }
@@ -62,6 +67,26 @@ var (
 		name:  projection.NotificationPolicyColumnPasswordChange,
 		table: notificationPolicyTable,
 	}
+	NotificationPolicyColNewDeviceLogin = Column{
+		name:  projection.NotificationPolicyColumnNewDeviceLogin,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColMFAAdded = Column{
+		name:  projection.NotificationPolicyColumnMFAAdded,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColMFARemoved = Column{
+		name:  projection.NotificationPolicyColumnMFARemoved,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColEmailChange = Column{
+		name:  projection.NotificationPolicyColumnEmailChange,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColAccountLocked = Column{
+		name:  projection.NotificationPolicyColumnAccountLocked,
+		table: notificationPolicyTable,
+	}
 	NotificationPolicyColIsDefault = Column{
 		name:  projection.NotificationPolicyColumnIsDefault,
 		table: notificationPolicyTable,
@@ -140,6 +165,11 @@ func prepareNotificationPolicyQuery(ctx context.Context, db prepareDatabase) (sq
 			NotificationPolicyColChangeDate.identifier(),
 			NotificationPolicyColResourceOwner.identifier(),
 			NotificationPolicyColPasswordChange.identifier(),
+			NotificationPolicyColNewDeviceLogin.identifier(),
+			NotificationPolicyColMFAAdded.identifier(),
+			NotificationPolicyColMFARemoved.identifier(),
+			NotificationPolicyColEmailChange.identifier(),
+			NotificationPolicyColAccountLocked.identifier(),
 			NotificationPolicyColIsDefault.identifier(),
 			NotificationPolicyColState.identifier(),
 		).
@@ -154,6 +184,11 @@ func prepareNotificationPolicyQuery(ctx context.Context, db prepareDatabase) (sq
 				&policy.ChangeDate,
 				&policy.ResourceOwner,
 				&policy.PasswordChange,
+				&policy.NewDeviceLogin,
+				&policy.MFAAdded,
+				&policy.MFARemoved,
+				&policy.EmailChange,
+				&policy.AccountLocked,
 				&policy.IsDefault,
 				&policy.State,
 			)
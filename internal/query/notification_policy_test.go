@@ -13,15 +13,20 @@ import (
 )
 
 var (
-	notificationPolicyStmt = regexp.QuoteMeta(`SELECT projections.notification_policies.id,` +
-		` projections.notification_policies.sequence,` +
-		` projections.notification_policies.creation_date,` +
-		` projections.notification_policies.change_date,` +
-		` projections.notification_policies.resource_owner,` +
-		` projections.notification_policies.password_change,` +
-		` projections.notification_policies.is_default,` +
-		` projections.notification_policies.state` +
-		` FROM projections.notification_policies` +
+	notificationPolicyStmt = regexp.QuoteMeta(`SELECT projections.notification_policies2.id,` +
+		` projections.notification_policies2.sequence,` +
+		` projections.notification_policies2.creation_date,` +
+		` projections.notification_policies2.change_date,` +
+		` projections.notification_policies2.resource_owner,` +
+		` projections.notification_policies2.password_change,` +
+		` projections.notification_policies2.new_device_login,` +
+		` projections.notification_policies2.mfa_added,` +
+		` projections.notification_policies2.mfa_removed,` +
+		` projections.notification_policies2.email_change,` +
+		` projections.notification_policies2.account_locked,` +
+		` projections.notification_policies2.is_default,` +
+		` projections.notification_policies2.state` +
+		` FROM projections.notification_policies2` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 	notificationPolicyCols = []string{
 		"id",
@@ -30,6 +35,11 @@ var (
 		"change_date",
 		"resource_owner",
 		"password_change",
+		"new_device_login",
+		"mfa_added",
+		"mfa_removed",
+		"email_change",
+		"account_locked",
 		"is_default",
 		"state",
 	}
@@ -79,6 +89,11 @@ func Test_NotificationPolicyPrepares(t *testing.T) {
 						"ro",
 						true,
 						true,
+						false,
+						true,
+						true,
+						false,
+						true,
 						domain.PolicyStateActive,
 					},
 				),
@@ -91,6 +106,11 @@ func Test_NotificationPolicyPrepares(t *testing.T) {
 				ResourceOwner:  "ro",
 				State:          domain.PolicyStateActive,
 				PasswordChange: true,
+				NewDeviceLogin: true,
+				MFAAdded:       false,
+				MFARemoved:     true,
+				EmailChange:    true,
+				AccountLocked:  false,
 				IsDefault:      true,
 			},
 		},
@@ -0,0 +1,139 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type NotificationWebhookProvider struct {
+	AggregateID   string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+	CallURL       string
+	Method        string
+	Headers       map[string]string
+	SigningKey    *crypto.CryptoValue
+}
+
+var (
+	notificationWebhookProviderTable = table{
+		name:          projection.NotificationWebhookProviderTable,
+		instanceIDCol: projection.NotificationWebhookProviderInstanceIDCol,
+	}
+	NotificationWebhookProviderColumnAggID = Column{
+		name:  projection.NotificationWebhookProviderAggIDCol,
+		table: notificationWebhookProviderTable,
+	}
+	NotificationWebhookProviderColumnCreationDate = Column{
+		name:  projection.NotificationWebhookProviderCreationDateCol,
+		table: notificationWebhookProviderTable,
+	}
+	NotificationWebhookProviderColumnChangeDate = Column{
+		name:  projection.NotificationWebhookProviderChangeDateCol,
+		table: notificationWebhookProviderTable,
+	}
+	NotificationWebhookProviderColumnSequence = Column{
+		name:  projection.NotificationWebhookProviderSequenceCol,
+		table: notificationWebhookProviderTable,
+	}
+	NotificationWebhookProviderColumnResourceOwner = Column{
+		name:  projection.NotificationWebhookProviderResourceOwnerCol,
+		table: notificationWebhookProviderTable,
+	}
+	NotificationWebhookProviderColumnInstanceID = Column{
+		name:  projection.NotificationWebhookProviderInstanceIDCol,
+		table: notificationWebhookProviderTable,
+	}
+	NotificationWebhookProviderColumnCallURL = Column{
+		name:  projection.NotificationWebhookProviderCallURLCol,
+		table: notificationWebhookProviderTable,
+	}
+	NotificationWebhookProviderColumnMethod = Column{
+		name:  projection.NotificationWebhookProviderMethodCol,
+		table: notificationWebhookProviderTable,
+	}
+	NotificationWebhookProviderColumnHeaders = Column{
+		name:  projection.NotificationWebhookProviderHeadersCol,
+		table: notificationWebhookProviderTable,
+	}
+	NotificationWebhookProviderColumnSigningKey = Column{
+		name:  projection.NotificationWebhookProviderSigningKeyCol,
+		table: notificationWebhookProviderTable,
+	}
+)
+
+// NotificationWebhookProvider returns the webhook notification provider of the instance
+func (q *Queries) NotificationWebhookProvider(ctx context.Context) (_ *NotificationWebhookProvider, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareNotificationWebhookProviderQuery(ctx, q.client)
+	stmt, args, err := query.Where(
+		sq.Eq{
+			NotificationWebhookProviderColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+			NotificationWebhookProviderColumnAggID.identifier():      authz.GetInstance(ctx).InstanceID(),
+		}).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Wh6sq", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func prepareNotificationWebhookProviderQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*NotificationWebhookProvider, error)) {
+	return sq.Select(
+			NotificationWebhookProviderColumnAggID.identifier(),
+			NotificationWebhookProviderColumnCreationDate.identifier(),
+			NotificationWebhookProviderColumnChangeDate.identifier(),
+			NotificationWebhookProviderColumnSequence.identifier(),
+			NotificationWebhookProviderColumnResourceOwner.identifier(),
+			NotificationWebhookProviderColumnCallURL.identifier(),
+			NotificationWebhookProviderColumnMethod.identifier(),
+			NotificationWebhookProviderColumnHeaders.identifier(),
+			NotificationWebhookProviderColumnSigningKey.identifier(),
+		).From(notificationWebhookProviderTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*NotificationWebhookProvider, error) {
+			p := new(NotificationWebhookProvider)
+			var headers []byte
+			err := row.Scan(
+				&p.AggregateID,
+				&p.CreationDate,
+				&p.ChangeDate,
+				&p.Sequence,
+				&p.ResourceOwner,
+				&p.CallURL,
+				&p.Method,
+				&headers,
+				&p.SigningKey,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Wh6nf", "Errors.IAM.NotificationWebhookProvider.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Wh6in", "Errors.Internal")
+			}
+			if len(headers) > 0 {
+				if err := json.Unmarshal(headers, &p.Headers); err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Wh6hd", "Errors.Internal")
+				}
+			}
+			return p, nil
+		}
+}
@@ -0,0 +1,124 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareNotificationWebhookProviderStmt = `SELECT projections.notification_webhook_providers.aggregate_id,` +
+		` projections.notification_webhook_providers.creation_date,` +
+		` projections.notification_webhook_providers.change_date,` +
+		` projections.notification_webhook_providers.sequence,` +
+		` projections.notification_webhook_providers.resource_owner,` +
+		` projections.notification_webhook_providers.call_url,` +
+		` projections.notification_webhook_providers.method,` +
+		` projections.notification_webhook_providers.headers,` +
+		` projections.notification_webhook_providers.signing_key` +
+		` FROM projections.notification_webhook_providers` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareNotificationWebhookProviderCols = []string{
+		"aggregate_id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"call_url",
+		"method",
+		"headers",
+		"signing_key",
+	}
+)
+
+func Test_NotificationWebhookProviderPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareNotificationWebhookProviderQuery no result",
+			prepare: prepareNotificationWebhookProviderQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareNotificationWebhookProviderStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*NotificationWebhookProvider)(nil),
+		},
+		{
+			name:    "prepareNotificationWebhookProviderQuery found",
+			prepare: prepareNotificationWebhookProviderQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareNotificationWebhookProviderStmt),
+					prepareNotificationWebhookProviderCols,
+					[]driver.Value{
+						"agg-id",
+						testNow,
+						testNow,
+						uint64(20211109),
+						"ro-id",
+						"https://webhook.example.com",
+						"POST",
+						[]byte(`{"Authorization":"Bearer token"}`),
+						&crypto.CryptoValue{},
+					},
+				),
+			},
+			object: &NotificationWebhookProvider{
+				AggregateID:   "agg-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211109,
+				ResourceOwner: "ro-id",
+				CallURL:       "https://webhook.example.com",
+				Method:        "POST",
+				Headers:       map[string]string{"Authorization": "Bearer token"},
+				SigningKey:    &crypto.CryptoValue{},
+			},
+		},
+		{
+			name:    "prepareNotificationWebhookProviderQuery sql err",
+			prepare: prepareNotificationWebhookProviderQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareNotificationWebhookProviderStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -57,6 +57,10 @@ var (
 		name:  projection.OrgColumnDomain,
 		table: orgsTable,
 	}
+	OrgColumnParentID = Column{
+		name:  projection.OrgColumnParentID,
+		table: orgsTable,
+	}
 )
 
 type Orgs struct {
@@ -72,8 +76,9 @@ type Org struct {
 	State         domain_pkg.OrgState
 	Sequence      uint64
 
-	Name   string
-	Domain string
+	Name     string
+	Domain   string
+	ParentID string
 }
 
 type OrgSearchQueries struct {
@@ -188,6 +193,70 @@ func (q *Queries) ExistsOrg(ctx context.Context, id string) (err error) {
 	return err
 }
 
+// OrgAncestorIDs returns the ids of the ancestors of the org, starting with the parent.
+// The hierarchy ends at removed orgs
+func (q *Queries) OrgAncestorIDs(ctx context.Context, orgID string) (_ []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	rows, err := q.client.QueryContext(ctx, orgAncestorsStmt, authz.GetInstance(ctx).InstanceID(), orgID, domain_pkg.OrgStateRemoved, domain_pkg.OrgHierarchyMaxDepth)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Oa3nc", "Errors.Internal")
+	}
+	return scanOrgIDs(rows)
+}
+
+// orgAncestorsStmt selects the ancestors of the org recursively, ordered by their distance to the org
+var orgAncestorsStmt = `WITH RECURSIVE ancestors (id, parent_id, depth) AS (` +
+	`SELECT ` + projection.OrgColumnID + `, ` + projection.OrgColumnParentID + `, 0 FROM ` + projection.OrgProjectionTable +
+	` WHERE ` + projection.OrgColumnInstanceID + ` = $1 AND ` + projection.OrgColumnID + ` = $2 AND ` + projection.OrgColumnState + ` <> $3` +
+	` UNION ALL ` +
+	`SELECT o.` + projection.OrgColumnID + `, o.` + projection.OrgColumnParentID + `, a.depth + 1 FROM ` + projection.OrgProjectionTable + ` AS o` +
+	` JOIN ancestors AS a ON o.` + projection.OrgColumnID + ` = a.parent_id` +
+	` WHERE o.` + projection.OrgColumnInstanceID + ` = $1 AND o.` + projection.OrgColumnState + ` <> $3 AND a.depth < $4` +
+	`) SELECT id FROM ancestors WHERE depth > 0 ORDER BY depth`
+
+// orgDescendantIDs returns the ids of the descendants of the orgs, which are not removed
+func (q *Queries) orgDescendantIDs(ctx context.Context, orgIDs []string) (_ []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	descendantsQuery, err := NewOrgDescendantsSearchQuery(orgIDs...)
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Od3ia", "Errors.Query.InvalidRequest")
+	}
+	stmt, args, err := descendantsQuery.toQuery(
+		sq.Select(OrgColumnID.identifier()).
+			From(orgsTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+	).Where(sq.Eq{
+		OrgColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Od3sq", "Errors.Query.SQLStatement")
+	}
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Od3nc", "Errors.Internal")
+	}
+	return scanOrgIDs(rows)
+}
+
+func scanOrgIDs(rows *sql.Rows) ([]string, error) {
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.ThrowInternal(err, "QUERY-Od3sc", "Errors.Internal")
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Od3cl", "Errors.Query.CloseRows")
+	}
+	return ids, nil
+}
+
 func (q *Queries) SearchOrgs(ctx context.Context, queries *OrgSearchQueries) (orgs *Orgs, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -225,6 +294,41 @@ func NewOrgStateSearchQuery(value domain_pkg.OrgState) (SearchQuery, error) {
 	return NewNumberQuery(OrgColumnState, value, NumberEquals)
 }
 
+func NewOrgParentIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(OrgColumnParentID, value, TextEquals)
+}
+
+// OrgDescendantsQuery matches the whole subtree below the orgs,
+// which are all descendants (not only the direct children) that are not removed
+type OrgDescendantsQuery struct {
+	ParentIDs []string
+}
+
+func NewOrgDescendantsSearchQuery(parentIDs ...string) (SearchQuery, error) {
+	if len(parentIDs) == 0 {
+		return nil, ErrNothingSelected
+	}
+	return &OrgDescendantsQuery{ParentIDs: parentIDs}, nil
+}
+
+func (q *OrgDescendantsQuery) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+// comp selects the descendants by a single recursive query instead of one query per level
+func (q *OrgDescendantsQuery) comp() sq.Sqlizer {
+	parents, args, _ := sq.Eq{projection.OrgColumnParentID: q.ParentIDs}.ToSql()
+	args = append(args, domain_pkg.OrgStateRemoved, domain_pkg.OrgStateRemoved, domain_pkg.OrgHierarchyMaxDepth)
+	return sq.Expr(OrgColumnID.identifier()+` IN (WITH RECURSIVE descendants (id, depth) AS (`+
+		`SELECT `+projection.OrgColumnID+`, 1 FROM `+projection.OrgProjectionTable+
+		` WHERE `+parents+` AND `+projection.OrgColumnState+` <> ?`+
+		` UNION ALL `+
+		`SELECT o.`+projection.OrgColumnID+`, d.depth + 1 FROM `+projection.OrgProjectionTable+` AS o`+
+		` JOIN descendants AS d ON o.`+projection.OrgColumnParentID+` = d.id`+
+		` WHERE o.`+projection.OrgColumnState+` <> ? AND d.depth < ?`+
+		`) SELECT id FROM descendants)`, args...)
+}
+
 func NewOrgIDsSearchQuery(ids ...string) (SearchQuery, error) {
 	list := make([]interface{}, len(ids))
 	for i, value := range ids {
@@ -243,6 +347,7 @@ func prepareOrgsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 			OrgColumnSequence.identifier(),
 			OrgColumnName.identifier(),
 			OrgColumnDomain.identifier(),
+			OrgColumnParentID.identifier(),
 			countColumn.identifier()).
 			From(orgsTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
@@ -260,6 +365,7 @@ func prepareOrgsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 					&org.Sequence,
 					&org.Name,
 					&org.Domain,
+					&org.ParentID,
 					&count,
 				)
 				if err != nil {
@@ -291,6 +397,7 @@ func prepareOrgQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 			OrgColumnSequence.identifier(),
 			OrgColumnName.identifier(),
 			OrgColumnDomain.identifier(),
+			OrgColumnParentID.identifier(),
 		).
 			From(orgsTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
@@ -305,6 +412,7 @@ func prepareOrgQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 				&o.Sequence,
 				&o.Name,
 				&o.Domain,
+				&o.ParentID,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
@@ -326,6 +434,7 @@ func prepareOrgWithDomainsQuery(ctx context.Context, db prepareDatabase) (sq.Sel
 			OrgColumnSequence.identifier(),
 			OrgColumnName.identifier(),
 			OrgColumnDomain.identifier(),
+			OrgColumnParentID.identifier(),
 		).
 			From(orgsTable.identifier()).
 			LeftJoin(join(OrgDomainOrgIDCol, OrgColumnID) + db.Timetravel(call.Took(ctx))).
@@ -341,6 +450,7 @@ func prepareOrgWithDomainsQuery(ctx context.Context, db prepareDatabase) (sq.Sel
 				&o.Sequence,
 				&o.Name,
 				&o.Domain,
+				&o.ParentID,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
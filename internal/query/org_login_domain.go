@@ -0,0 +1,225 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// OrgLoginDomain is a verified domain of an organization,
+// which routes the login UI and the OIDC issuer to the organization
+type OrgLoginDomain struct {
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Sequence     uint64
+	Domain       string
+	OrgID        string
+}
+
+type OrgLoginDomains struct {
+	SearchResponse
+	Domains []*OrgLoginDomain
+}
+
+func (q *Queries) OrgLoginDomainsByOrgID(ctx context.Context, orgID string) (domains *OrgLoginDomains, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareOrgLoginDomainsQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		OrgLoginDomainOrgIDCol.identifier():      orgID,
+		OrgLoginDomainInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).OrderBy(OrgLoginDomainDomainCol.identifier()).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Lg7sq", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Lg7qe", "Errors.Internal")
+	}
+	domains, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	domains.LatestSequence, err = q.latestSequence(ctx, orgLoginDomainsTable)
+	return domains, err
+}
+
+// OrgByLoginDomain returns the active organization the login domain is routed to
+func (q *Queries) OrgByLoginDomain(ctx context.Context, loginDomain string) (_ *Org, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareOrgWithLoginDomainQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		OrgLoginDomainDomainCol.identifier(): loginDomain,
+		OrgColumnInstanceID.identifier():     authz.GetInstance(ctx).InstanceID(),
+		OrgColumnState.identifier():          domain.OrgStateActive,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Lg8sq", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+// instanceIDByLoginDomain returns the instance of the login domain,
+// it's used to resolve the instance of a request before the instance is known
+func (q *Queries) instanceIDByLoginDomain(ctx context.Context, loginDomain string) (_ string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareLoginDomainInstanceIDQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		OrgLoginDomainDomainCol.identifier(): loginDomain,
+	}).ToSql()
+	if err != nil {
+		return "", errors.ThrowInternal(err, "QUERY-Lg9sq", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func prepareOrgLoginDomainsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*OrgLoginDomains, error)) {
+	return sq.Select(
+			OrgLoginDomainCreationDateCol.identifier(),
+			OrgLoginDomainChangeDateCol.identifier(),
+			OrgLoginDomainSequenceCol.identifier(),
+			OrgLoginDomainDomainCol.identifier(),
+			OrgLoginDomainOrgIDCol.identifier(),
+			countColumn.identifier(),
+		).From(orgLoginDomainsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*OrgLoginDomains, error) {
+			domains := make([]*OrgLoginDomain, 0)
+			var count uint64
+			for rows.Next() {
+				domain := new(OrgLoginDomain)
+				err := rows.Scan(
+					&domain.CreationDate,
+					&domain.ChangeDate,
+					&domain.Sequence,
+					&domain.Domain,
+					&domain.OrgID,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				domains = append(domains, domain)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Lg7cr", "Errors.Query.CloseRows")
+			}
+
+			return &OrgLoginDomains{
+				Domains: domains,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareOrgWithLoginDomainQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*Org, error)) {
+	return sq.Select(
+			OrgColumnID.identifier(),
+			OrgColumnCreationDate.identifier(),
+			OrgColumnChangeDate.identifier(),
+			OrgColumnResourceOwner.identifier(),
+			OrgColumnState.identifier(),
+			OrgColumnSequence.identifier(),
+			OrgColumnName.identifier(),
+			OrgColumnDomain.identifier(),
+			OrgColumnParentID.identifier(),
+		).
+			From(orgsTable.identifier()).
+			Join(join(OrgLoginDomainOrgIDCol, OrgColumnID) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*Org, error) {
+			o := new(Org)
+			err := row.Scan(
+				&o.ID,
+				&o.CreationDate,
+				&o.ChangeDate,
+				&o.ResourceOwner,
+				&o.State,
+				&o.Sequence,
+				&o.Name,
+				&o.Domain,
+				&o.ParentID,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Lg8nf", "Errors.Org.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Lg8sc", "Errors.Internal")
+			}
+			return o, nil
+		}
+}
+
+func prepareLoginDomainInstanceIDQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (string, error)) {
+	return sq.Select(
+			OrgLoginDomainInstanceIDCol.identifier(),
+		).
+			From(orgLoginDomainsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (string, error) {
+			var instanceID string
+			err := row.Scan(&instanceID)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return "", errors.ThrowNotFound(err, "QUERY-Lg9nf", "Errors.IAM.NotFound")
+				}
+				return "", errors.ThrowInternal(err, "QUERY-Lg9sc", "Errors.Internal")
+			}
+			return instanceID, nil
+		}
+}
+
+var (
+	orgLoginDomainsTable = table{
+		name:          projection.OrgLoginDomainTable,
+		instanceIDCol: projection.OrgLoginDomainInstanceIDCol,
+	}
+
+	OrgLoginDomainCreationDateCol = Column{
+		name:  projection.OrgLoginDomainCreationDateCol,
+		table: orgLoginDomainsTable,
+	}
+	OrgLoginDomainChangeDateCol = Column{
+		name:  projection.OrgLoginDomainChangeDateCol,
+		table: orgLoginDomainsTable,
+	}
+	OrgLoginDomainSequenceCol = Column{
+		name:  projection.OrgLoginDomainSequenceCol,
+		table: orgLoginDomainsTable,
+	}
+	OrgLoginDomainDomainCol = Column{
+		name:  projection.OrgLoginDomainDomainCol,
+		table: orgLoginDomainsTable,
+	}
+	OrgLoginDomainOrgIDCol = Column{
+		name:  projection.OrgLoginDomainOrgIDCol,
+		table: orgLoginDomainsTable,
+	}
+	OrgLoginDomainInstanceIDCol = Column{
+		name:  projection.OrgLoginDomainInstanceIDCol,
+		table: orgLoginDomainsTable,
+	}
+)
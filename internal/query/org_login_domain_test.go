@@ -0,0 +1,211 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	errs "errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareOrgLoginDomainsStmt = `SELECT projections.org_login_domains.creation_date,` +
+		` projections.org_login_domains.change_date,` +
+		` projections.org_login_domains.sequence,` +
+		` projections.org_login_domains.domain,` +
+		` projections.org_login_domains.org_id,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.org_login_domains` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareOrgLoginDomainsCols = []string{
+		"creation_date",
+		"change_date",
+		"sequence",
+		"domain",
+		"org_id",
+		"count",
+	}
+
+	prepareOrgWithLoginDomainStmt = `SELECT projections.orgs1.id,` +
+		` projections.orgs1.creation_date,` +
+		` projections.orgs1.change_date,` +
+		` projections.orgs1.resource_owner,` +
+		` projections.orgs1.org_state,` +
+		` projections.orgs1.sequence,` +
+		` projections.orgs1.name,` +
+		` projections.orgs1.primary_domain,` +
+		` projections.orgs1.parent_id` +
+		` FROM projections.orgs1` +
+		` JOIN projections.org_login_domains ON projections.orgs1.id = projections.org_login_domains.org_id AND projections.orgs1.instance_id = projections.org_login_domains.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms'`
+
+	prepareLoginDomainInstanceIDStmt = `SELECT projections.org_login_domains.instance_id` +
+		` FROM projections.org_login_domains` +
+		` AS OF SYSTEM TIME '-1 ms'`
+)
+
+func Test_OrgLoginDomainPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareOrgLoginDomainsQuery no result",
+			prepare: prepareOrgLoginDomainsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareOrgLoginDomainsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &OrgLoginDomains{Domains: []*OrgLoginDomain{}},
+		},
+		{
+			name:    "prepareOrgLoginDomainsQuery one result",
+			prepare: prepareOrgLoginDomainsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareOrgLoginDomainsStmt),
+					prepareOrgLoginDomainsCols,
+					[][]driver.Value{
+						{
+							testNow,
+							testNow,
+							uint64(20211109),
+							"login.zitadel.ch",
+							"org-id",
+						},
+					},
+				),
+			},
+			object: &OrgLoginDomains{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Domains: []*OrgLoginDomain{
+					{
+						CreationDate: testNow,
+						ChangeDate:   testNow,
+						Sequence:     20211109,
+						Domain:       "login.zitadel.ch",
+						OrgID:        "org-id",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareOrgLoginDomainsQuery sql err",
+			prepare: prepareOrgLoginDomainsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareOrgLoginDomainsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareOrgWithLoginDomainQuery no result",
+			prepare: prepareOrgWithLoginDomainQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareOrgWithLoginDomainStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Org)(nil),
+		},
+		{
+			name:    "prepareOrgWithLoginDomainQuery found",
+			prepare: prepareOrgWithLoginDomainQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareOrgWithLoginDomainStmt),
+					prepareOrgQueryCols,
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						"ro",
+						domain.OrgStateActive,
+						uint64(20211108),
+						"org-name",
+						"zitadel.ch",
+						"",
+					},
+				),
+			},
+			object: &Org{
+				ID:            "id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				State:         domain.OrgStateActive,
+				Sequence:      20211108,
+				Name:          "org-name",
+				Domain:        "zitadel.ch",
+			},
+		},
+		{
+			name:    "prepareLoginDomainInstanceIDQuery no result",
+			prepare: prepareLoginDomainInstanceIDQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareLoginDomainInstanceIDStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: "",
+		},
+		{
+			name:    "prepareLoginDomainInstanceIDQuery found",
+			prepare: prepareLoginDomainInstanceIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareLoginDomainInstanceIDStmt),
+					[]string{"instance_id"},
+					[]driver.Value{
+						"instance-id",
+					},
+				),
+			},
+			object: "instance-id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -17,19 +18,20 @@ import (
 )
 
 var (
-	orgUniqueQuery = "SELECT COUNT(*) = 0 FROM projections.orgs LEFT JOIN projections.org_domains2 ON projections.orgs.id = projections.org_domains2.org_id AND projections.orgs.instance_id = projections.org_domains2.instance_id AS OF SYSTEM TIME '-1 ms' WHERE (projections.org_domains2.is_verified = $1 AND projections.orgs.instance_id = $2 AND (projections.org_domains2.domain ILIKE $3 OR projections.orgs.name ILIKE $4) AND projections.orgs.org_state <> $5)"
+	orgUniqueQuery = "SELECT COUNT(*) = 0 FROM projections.orgs1 LEFT JOIN projections.org_domains2 ON projections.orgs1.id = projections.org_domains2.org_id AND projections.orgs1.instance_id = projections.org_domains2.instance_id AS OF SYSTEM TIME '-1 ms' WHERE (projections.org_domains2.is_verified = $1 AND projections.orgs1.instance_id = $2 AND (projections.org_domains2.domain ILIKE $3 OR projections.orgs1.name ILIKE $4) AND projections.orgs1.org_state <> $5)"
 	orgUniqueCols  = []string{"is_unique"}
 
-	prepareOrgsQueryStmt = `SELECT projections.orgs.id,` +
-		` projections.orgs.creation_date,` +
-		` projections.orgs.change_date,` +
-		` projections.orgs.resource_owner,` +
-		` projections.orgs.org_state,` +
-		` projections.orgs.sequence,` +
-		` projections.orgs.name,` +
-		` projections.orgs.primary_domain,` +
+	prepareOrgsQueryStmt = `SELECT projections.orgs1.id,` +
+		` projections.orgs1.creation_date,` +
+		` projections.orgs1.change_date,` +
+		` projections.orgs1.resource_owner,` +
+		` projections.orgs1.org_state,` +
+		` projections.orgs1.sequence,` +
+		` projections.orgs1.name,` +
+		` projections.orgs1.primary_domain,` +
+		` projections.orgs1.parent_id,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.orgs` +
+		` FROM projections.orgs1` +
 		` AS OF SYSTEM TIME '-1 ms' `
 	prepareOrgsQueryCols = []string{
 		"id",
@@ -40,18 +42,20 @@ var (
 		"sequence",
 		"name",
 		"primary_domain",
+		"parent_id",
 		"count",
 	}
 
-	prepareOrgQueryStmt = `SELECT projections.orgs.id,` +
-		` projections.orgs.creation_date,` +
-		` projections.orgs.change_date,` +
-		` projections.orgs.resource_owner,` +
-		` projections.orgs.org_state,` +
-		` projections.orgs.sequence,` +
-		` projections.orgs.name,` +
-		` projections.orgs.primary_domain` +
-		` FROM projections.orgs` +
+	prepareOrgQueryStmt = `SELECT projections.orgs1.id,` +
+		` projections.orgs1.creation_date,` +
+		` projections.orgs1.change_date,` +
+		` projections.orgs1.resource_owner,` +
+		` projections.orgs1.org_state,` +
+		` projections.orgs1.sequence,` +
+		` projections.orgs1.name,` +
+		` projections.orgs1.primary_domain,` +
+		` projections.orgs1.parent_id` +
+		` FROM projections.orgs1` +
 		` AS OF SYSTEM TIME '-1 ms' `
 	prepareOrgQueryCols = []string{
 		"id",
@@ -62,11 +66,12 @@ var (
 		"sequence",
 		"name",
 		"primary_domain",
+		"parent_id",
 	}
 
 	prepareOrgUniqueStmt = `SELECT COUNT(*) = 0` +
-		` FROM projections.orgs` +
-		` LEFT JOIN projections.org_domains2 ON projections.orgs.id = projections.org_domains2.org_id AND projections.orgs.instance_id = projections.org_domains2.instance_id` +
+		` FROM projections.orgs1` +
+		` LEFT JOIN projections.org_domains2 ON projections.orgs1.id = projections.org_domains2.org_id AND projections.orgs1.instance_id = projections.org_domains2.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms' `
 	prepareOrgUniqueCols = []string{
 		"count",
@@ -113,6 +118,7 @@ func Test_OrgPrepares(t *testing.T) {
 							uint64(20211109),
 							"org-name",
 							"zitadel.ch",
+							"",
 						},
 					},
 				),
@@ -152,6 +158,7 @@ func Test_OrgPrepares(t *testing.T) {
 							uint64(20211108),
 							"org-name-1",
 							"zitadel.ch",
+							"",
 						},
 						{
 							"id-2",
@@ -162,6 +169,7 @@ func Test_OrgPrepares(t *testing.T) {
 							uint64(20211108),
 							"org-name-2",
 							"caos.ch",
+							"id-1",
 						},
 					},
 				),
@@ -190,6 +198,7 @@ func Test_OrgPrepares(t *testing.T) {
 						Sequence:      20211108,
 						Name:          "org-name-2",
 						Domain:        "caos.ch",
+						ParentID:      "id-1",
 					},
 				},
 			},
@@ -245,6 +254,7 @@ func Test_OrgPrepares(t *testing.T) {
 						uint64(20211108),
 						"org-name",
 						"zitadel.ch",
+						"parent-id",
 					},
 				),
 			},
@@ -257,6 +267,7 @@ func Test_OrgPrepares(t *testing.T) {
 				Sequence:      20211108,
 				Name:          "org-name",
 				Domain:        "zitadel.ch",
+				ParentID:      "parent-id",
 			},
 		},
 		{
@@ -437,3 +448,104 @@ func TestQueries_IsOrgUnique(t *testing.T) {
 
 	}
 }
+
+func TestQueries_OrgAncestorIDs(t *testing.T) {
+	ancestorsStmt := `WITH RECURSIVE ancestors (id, parent_id, depth) AS (` +
+		`SELECT id, parent_id, 0 FROM projections.orgs1 WHERE instance_id = $1 AND id = $2 AND org_state <> $3` +
+		` UNION ALL ` +
+		`SELECT o.id, o.parent_id, a.depth + 1 FROM projections.orgs1 AS o JOIN ancestors AS a ON o.id = a.parent_id` +
+		` WHERE o.instance_id = $1 AND o.org_state <> $3 AND a.depth < $4` +
+		`) SELECT id FROM ancestors WHERE depth > 0 ORDER BY depth`
+	tests := []struct {
+		name            string
+		sqlExpectations sqlExpectation
+		want            []string
+		err             func(error) bool
+	}{
+		{
+			name:            "no ancestors",
+			sqlExpectations: mockQueries(ancestorsStmt, []string{"id"}, nil, "", "org", domain.OrgStateRemoved, domain.OrgHierarchyMaxDepth),
+			want:            []string{},
+		},
+		{
+			name: "ancestors ordered by depth",
+			sqlExpectations: mockQueries(ancestorsStmt, []string{"id"}, [][]driver.Value{
+				{"parent"},
+				{"grandparent"},
+			}, "", "org", domain.OrgStateRemoved, domain.OrgHierarchyMaxDepth),
+			want: []string{"parent", "grandparent"},
+		},
+		{
+			name:            "sql err",
+			sqlExpectations: mockQueryErr(ancestorsStmt, sql.ErrConnDone, "", "org", domain.OrgStateRemoved, domain.OrgHierarchyMaxDepth),
+			err:             errors.IsInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("unable to mock db: %v", err)
+			}
+			tt.sqlExpectations(mock)
+			q := &Queries{
+				client: &database.DB{
+					DB:       client,
+					Database: new(prepareDB),
+				},
+			}
+			got, err := q.OrgAncestorIDs(context.Background(), "org")
+			if tt.err == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.err != nil && !tt.err(err) {
+				t.Fatalf("wrong error: %v", err)
+			}
+			if tt.err == nil {
+				assert.Equal(t, tt.want, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expectation was met: %v", err)
+			}
+		})
+	}
+}
+
+func TestQueries_orgDescendantIDs(t *testing.T) {
+	descendantsStmt := `SELECT projections.orgs1.id FROM projections.orgs1` +
+		` WHERE projections.orgs1.id IN (WITH RECURSIVE descendants (id, depth) AS (` +
+		`SELECT id, 1 FROM projections.orgs1 WHERE parent_id IN ($1,$2) AND org_state <> $3` +
+		` UNION ALL ` +
+		`SELECT o.id, d.depth + 1 FROM projections.orgs1 AS o JOIN descendants AS d ON o.parent_id = d.id` +
+		` WHERE o.org_state <> $4 AND d.depth < $5` +
+		`) SELECT id FROM descendants)` +
+		` AND projections.orgs1.instance_id = $6`
+
+	client, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("unable to mock db: %v", err)
+	}
+	mockQueries(descendantsStmt, []string{"id"}, [][]driver.Value{
+		{"child"},
+		{"grandchild"},
+	}, "org1", "org2", domain.OrgStateRemoved, domain.OrgStateRemoved, domain.OrgHierarchyMaxDepth, "")(mock)
+	q := &Queries{
+		client: &database.DB{
+			DB:       client,
+			Database: new(prepareDB),
+		},
+	}
+	got, err := q.orgDescendantIDs(context.Background(), []string{"org1", "org2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Equal(t, []string{"child", "grandchild"}, got)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectation was met: %v", err)
+	}
+}
+
+func TestNewOrgDescendantsSearchQuery(t *testing.T) {
+	_, err := NewOrgDescendantsSearchQuery()
+	assert.ErrorIs(t, err, ErrNothingSelected)
+}
@@ -40,6 +40,25 @@ func (q *Queries) PasswordComplexityPolicyByOrg(ctx context.Context, shouldTrigg
 	if shouldTriggerBulk {
 		projection.PasswordComplexityProjection.Trigger(ctx)
 	}
+	policy, err := q.passwordComplexityPolicyByOrg(ctx, orgID, withOwnerRemoved)
+	if err != nil || !policy.IsDefault {
+		return policy, err
+	}
+	// orgs without a custom policy inherit the policy of the nearest ancestor defining one
+	ancestorIDs, err := q.OrgAncestorIDs(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ancestorID := range ancestorIDs {
+		ancestorPolicy, err := q.passwordComplexityPolicyByOrg(ctx, ancestorID, withOwnerRemoved)
+		if err != nil || !ancestorPolicy.IsDefault {
+			return ancestorPolicy, err
+		}
+	}
+	return policy, nil
+}
+
+func (q *Queries) passwordComplexityPolicyByOrg(ctx context.Context, orgID string, withOwnerRemoved bool) (*PasswordComplexityPolicy, error) {
 	eq := sq.Eq{PasswordComplexityColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}
 	if !withOwnerRemoved {
 		eq[PasswordComplexityColOwnerRemoved.identifier()] = false
@@ -45,6 +45,10 @@ var (
 		name:  projection.ProjectColumnPrivateLabelingSetting,
 		table: projectsTable,
 	}
+	ProjectColumnAllowImpersonation = Column{
+		name:  projection.ProjectColumnAllowImpersonation,
+		table: projectsTable,
+	}
 	ProjectColumnCreationDate = Column{
 		name:  projection.ProjectColumnCreationDate,
 		table: projectsTable,
@@ -93,6 +97,7 @@ type Project struct {
 	ProjectRoleCheck       bool
 	HasProjectCheck        bool
 	PrivateLabelingSetting domain.PrivateLabelingSetting
+	AllowImpersonation     bool
 }
 
 type ProjectSearchQueries struct {
@@ -208,7 +213,8 @@ func prepareProjectQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			ProjectColumnProjectRoleAssertion.identifier(),
 			ProjectColumnProjectRoleCheck.identifier(),
 			ProjectColumnHasProjectCheck.identifier(),
-			ProjectColumnPrivateLabelingSetting.identifier()).
+			ProjectColumnPrivateLabelingSetting.identifier(),
+			ProjectColumnAllowImpersonation.identifier()).
 			From(projectsTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*Project, error) {
@@ -225,6 +231,7 @@ func prepareProjectQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 				&p.ProjectRoleCheck,
 				&p.HasProjectCheck,
 				&p.PrivateLabelingSetting,
+				&p.AllowImpersonation,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
@@ -249,6 +256,7 @@ func prepareProjectsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 			ProjectColumnProjectRoleCheck.identifier(),
 			ProjectColumnHasProjectCheck.identifier(),
 			ProjectColumnPrivateLabelingSetting.identifier(),
+			ProjectColumnAllowImpersonation.identifier(),
 			countColumn.identifier()).
 			From(projectsTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
@@ -269,6 +277,7 @@ func prepareProjectsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 					&project.ProjectRoleCheck,
 					&project.HasProjectCheck,
 					&project.PrivateLabelingSetting,
+					&project.AllowImpersonation,
 					&count,
 				)
 				if err != nil {
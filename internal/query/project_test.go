@@ -27,19 +27,20 @@ var (
 		"private_labeling_setting",
 	}
 
-	prepareProjectsStmt = `SELECT projections.projects3.id,` +
-		` projections.projects3.creation_date,` +
-		` projections.projects3.change_date,` +
-		` projections.projects3.resource_owner,` +
-		` projections.projects3.state,` +
-		` projections.projects3.sequence,` +
-		` projections.projects3.name,` +
-		` projections.projects3.project_role_assertion,` +
-		` projections.projects3.project_role_check,` +
-		` projections.projects3.has_project_check,` +
-		` projections.projects3.private_labeling_setting,` +
+	prepareProjectsStmt = `SELECT projections.projects4.id,` +
+		` projections.projects4.creation_date,` +
+		` projections.projects4.change_date,` +
+		` projections.projects4.resource_owner,` +
+		` projections.projects4.state,` +
+		` projections.projects4.sequence,` +
+		` projections.projects4.name,` +
+		` projections.projects4.project_role_assertion,` +
+		` projections.projects4.project_role_check,` +
+		` projections.projects4.has_project_check,` +
+		` projections.projects4.private_labeling_setting,` +
+		` projections.projects4.allow_impersonation,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.projects3` +
+		` FROM projections.projects4` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareProjectsCols = []string{
 		"id",
@@ -53,21 +54,23 @@ var (
 		"project_role_check",
 		"has_project_check",
 		"private_labeling_setting",
+		"allow_impersonation",
 		"count",
 	}
 
-	prepareProjectStmt = `SELECT projections.projects3.id,` +
-		` projections.projects3.creation_date,` +
-		` projections.projects3.change_date,` +
-		` projections.projects3.resource_owner,` +
-		` projections.projects3.state,` +
-		` projections.projects3.sequence,` +
-		` projections.projects3.name,` +
-		` projections.projects3.project_role_assertion,` +
-		` projections.projects3.project_role_check,` +
-		` projections.projects3.has_project_check,` +
-		` projections.projects3.private_labeling_setting` +
-		` FROM projections.projects3` +
+	prepareProjectStmt = `SELECT projections.projects4.id,` +
+		` projections.projects4.creation_date,` +
+		` projections.projects4.change_date,` +
+		` projections.projects4.resource_owner,` +
+		` projections.projects4.state,` +
+		` projections.projects4.sequence,` +
+		` projections.projects4.name,` +
+		` projections.projects4.project_role_assertion,` +
+		` projections.projects4.project_role_check,` +
+		` projections.projects4.has_project_check,` +
+		` projections.projects4.private_labeling_setting,` +
+		` projections.projects4.allow_impersonation` +
+		` FROM projections.projects4` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareProjectCols = []string{
 		"id",
@@ -81,6 +84,7 @@ var (
 		"project_role_check",
 		"has_project_check",
 		"private_labeling_setting",
+		"allow_impersonation",
 	}
 )
 
@@ -127,6 +131,7 @@ func Test_ProjectPrepares(t *testing.T) {
 							true,
 							true,
 							domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+							false,
 						},
 					},
 				),
@@ -172,6 +177,7 @@ func Test_ProjectPrepares(t *testing.T) {
 							true,
 							true,
 							domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+							false,
 						},
 						{
 							"id-2",
@@ -185,6 +191,7 @@ func Test_ProjectPrepares(t *testing.T) {
 							false,
 							false,
 							domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+							false,
 						},
 					},
 				),
@@ -277,6 +284,7 @@ func Test_ProjectPrepares(t *testing.T) {
 						true,
 						true,
 						domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+						true,
 					},
 				),
 			},
@@ -292,6 +300,7 @@ func Test_ProjectPrepares(t *testing.T) {
 				ProjectRoleCheck:       true,
 				HasProjectCheck:        true,
 				PrivateLabelingSetting: domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+				AllowImpersonation:     true,
 			},
 		},
 		{
@@ -15,7 +15,7 @@ import (
 )
 
 const (
-	AppProjectionTable = "projections.apps5"
+	AppProjectionTable = "projections.apps9"
 	AppAPITable        = AppProjectionTable + "_" + appAPITableSuffix
 	AppOIDCTable       = AppProjectionTable + "_" + appOIDCTableSuffix
 	AppSAMLTable       = AppProjectionTable + "_" + appSAMLTableSuffix
@@ -58,13 +58,21 @@ const (
 	AppOIDCConfigColumnClockSkew                = "clock_skew"
 	AppOIDCConfigColumnAdditionalOrigins        = "additional_origins"
 	AppOIDCConfigColumnSkipNativeAppSuccessPage = "skip_native_app_success_page"
-
-	appSAMLTableSuffix             = "saml_configs"
-	AppSAMLConfigColumnAppID       = "app_id"
-	AppSAMLConfigColumnInstanceID  = "instance_id"
-	AppSAMLConfigColumnEntityID    = "entity_id"
-	AppSAMLConfigColumnMetadata    = "metadata"
-	AppSAMLConfigColumnMetadataURL = "metadata_url"
+	AppOIDCConfigColumnRequireConsent           = "require_consent"
+
+	appSAMLTableSuffix                    = "saml_configs"
+	AppSAMLConfigColumnAppID              = "app_id"
+	AppSAMLConfigColumnInstanceID         = "instance_id"
+	AppSAMLConfigColumnEntityID           = "entity_id"
+	AppSAMLConfigColumnMetadata           = "metadata"
+	AppSAMLConfigColumnMetadataURL        = "metadata_url"
+	AppSAMLConfigColumnIDPInitiatedSSO    = "idp_initiated_sso"
+	AppSAMLConfigColumnDefaultRelayState  = "default_relay_state"
+	AppSAMLConfigColumnSignatureAlgorithm = "signature_algorithm"
+	AppSAMLConfigColumnSigningMode        = "signing_mode"
+	AppSAMLConfigColumnEncryptAssertion   = "encrypt_assertion"
+	AppSAMLConfigColumnNameIDFormat       = "name_id_format"
+	AppSAMLConfigColumnAttributeMappings  = "attribute_mappings"
 )
 
 type appProjection struct {
@@ -124,6 +132,7 @@ func newAppProjection(ctx context.Context, config crdb.StatementHandlerConfig) *
 			crdb.NewColumn(AppOIDCConfigColumnClockSkew, crdb.ColumnTypeInt64, crdb.Default(0)),
 			crdb.NewColumn(AppOIDCConfigColumnAdditionalOrigins, crdb.ColumnTypeTextArray, crdb.Nullable()),
 			crdb.NewColumn(AppOIDCConfigColumnSkipNativeAppSuccessPage, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(AppOIDCConfigColumnRequireConsent, crdb.ColumnTypeBool, crdb.Default(false)),
 		},
 			crdb.NewPrimaryKey(AppOIDCConfigColumnInstanceID, AppOIDCConfigColumnAppID),
 			appOIDCTableSuffix,
@@ -136,6 +145,13 @@ func newAppProjection(ctx context.Context, config crdb.StatementHandlerConfig) *
 			crdb.NewColumn(AppSAMLConfigColumnEntityID, crdb.ColumnTypeText),
 			crdb.NewColumn(AppSAMLConfigColumnMetadata, crdb.ColumnTypeBytes),
 			crdb.NewColumn(AppSAMLConfigColumnMetadataURL, crdb.ColumnTypeText),
+			crdb.NewColumn(AppSAMLConfigColumnIDPInitiatedSSO, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(AppSAMLConfigColumnDefaultRelayState, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(AppSAMLConfigColumnSignatureAlgorithm, crdb.ColumnTypeEnum, crdb.Default(0)),
+			crdb.NewColumn(AppSAMLConfigColumnSigningMode, crdb.ColumnTypeEnum, crdb.Default(0)),
+			crdb.NewColumn(AppSAMLConfigColumnEncryptAssertion, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(AppSAMLConfigColumnNameIDFormat, crdb.ColumnTypeEnum, crdb.Default(0)),
+			crdb.NewColumn(AppSAMLConfigColumnAttributeMappings, crdb.ColumnTypeJSONB, crdb.Nullable()),
 		},
 			crdb.NewPrimaryKey(AppSAMLConfigColumnInstanceID, AppSAMLConfigColumnAppID),
 			appSAMLTableSuffix,
@@ -466,6 +482,7 @@ func (p *appProjection) reduceOIDCConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppOIDCConfigColumnClockSkew, e.ClockSkew),
 				handler.NewCol(AppOIDCConfigColumnAdditionalOrigins, database.StringArray(e.AdditionalOrigins)),
 				handler.NewCol(AppOIDCConfigColumnSkipNativeAppSuccessPage, e.SkipNativeAppSuccessPage),
+				handler.NewCol(AppOIDCConfigColumnRequireConsent, e.RequireConsent),
 			},
 			crdb.WithTableSuffix(appOIDCTableSuffix),
 		),
@@ -488,7 +505,7 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-GNHU1", "reduce.wrong.event.type %s", project.OIDCConfigChangedType)
 	}
 
-	cols := make([]handler.Column, 0, 15)
+	cols := make([]handler.Column, 0, 16)
 	if e.Version != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnVersion, *e.Version))
 	}
@@ -534,6 +551,9 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 	if e.SkipNativeAppSuccessPage != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnSkipNativeAppSuccessPage, *e.SkipNativeAppSuccessPage))
 	}
+	if e.RequireConsent != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRequireConsent, *e.RequireConsent))
+	}
 
 	if len(cols) == 0 {
 		return crdb.NewNoOpStatement(e), nil
@@ -626,6 +646,13 @@ func (p *appProjection) reduceSAMLConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppSAMLConfigColumnEntityID, e.EntityID),
 				handler.NewCol(AppSAMLConfigColumnMetadata, e.Metadata),
 				handler.NewCol(AppSAMLConfigColumnMetadataURL, e.MetadataURL),
+				handler.NewCol(AppSAMLConfigColumnIDPInitiatedSSO, e.IDPInitiatedSSO),
+				handler.NewCol(AppSAMLConfigColumnDefaultRelayState, e.DefaultRelayState),
+				handler.NewCol(AppSAMLConfigColumnSignatureAlgorithm, e.SignatureAlgorithm),
+				handler.NewCol(AppSAMLConfigColumnSigningMode, e.SigningMode),
+				handler.NewCol(AppSAMLConfigColumnEncryptAssertion, e.EncryptAssertion),
+				handler.NewCol(AppSAMLConfigColumnNameIDFormat, e.NameIDFormat),
+				handler.NewCol(AppSAMLConfigColumnAttributeMappings, e.AttributeMappings),
 			},
 			crdb.WithTableSuffix(appSAMLTableSuffix),
 		),
@@ -648,7 +675,7 @@ func (p *appProjection) reduceSAMLConfigChanged(event eventstore.Event) (*handle
 		return nil, errors.ThrowInvalidArgument(nil, "HANDL-GMHU2", "reduce.wrong.event.type")
 	}
 
-	cols := make([]handler.Column, 0, 3)
+	cols := make([]handler.Column, 0, 5)
 	if e.Metadata != nil {
 		cols = append(cols, handler.NewCol(AppSAMLConfigColumnMetadata, e.Metadata))
 	}
@@ -658,6 +685,27 @@ func (p *appProjection) reduceSAMLConfigChanged(event eventstore.Event) (*handle
 	if e.EntityID != "" {
 		cols = append(cols, handler.NewCol(AppSAMLConfigColumnEntityID, e.EntityID))
 	}
+	if e.IDPInitiatedSSO != nil {
+		cols = append(cols, handler.NewCol(AppSAMLConfigColumnIDPInitiatedSSO, *e.IDPInitiatedSSO))
+	}
+	if e.DefaultRelayState != nil {
+		cols = append(cols, handler.NewCol(AppSAMLConfigColumnDefaultRelayState, *e.DefaultRelayState))
+	}
+	if e.SignatureAlgorithm != nil {
+		cols = append(cols, handler.NewCol(AppSAMLConfigColumnSignatureAlgorithm, *e.SignatureAlgorithm))
+	}
+	if e.SigningMode != nil {
+		cols = append(cols, handler.NewCol(AppSAMLConfigColumnSigningMode, *e.SigningMode))
+	}
+	if e.EncryptAssertion != nil {
+		cols = append(cols, handler.NewCol(AppSAMLConfigColumnEncryptAssertion, *e.EncryptAssertion))
+	}
+	if e.NameIDFormat != nil {
+		cols = append(cols, handler.NewCol(AppSAMLConfigColumnNameIDFormat, *e.NameIDFormat))
+	}
+	if e.AttributeMappings != nil {
+		cols = append(cols, handler.NewCol(AppSAMLConfigColumnAttributeMappings, *e.AttributeMappings))
+	}
 
 	if len(cols) == 0 {
 		return crdb.NewNoOpStatement(e), nil
@@ -0,0 +1,136 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	CustomMailTemplateTable = "projections.custom_mail_templates"
+
+	CustomMailTemplateAggregateIDCol  = "aggregate_id"
+	CustomMailTemplateInstanceIDCol   = "instance_id"
+	CustomMailTemplateCreationDateCol = "creation_date"
+	CustomMailTemplateChangeDateCol   = "change_date"
+	CustomMailTemplateSequenceCol     = "sequence"
+	CustomMailTemplateMessageTypeCol  = "message_type"
+	CustomMailTemplateTemplateKeyCol  = "template_key"
+	CustomMailTemplateTemplateHashCol = "template_hash"
+	CustomMailTemplateMJMLKeyCol      = "mjml_key"
+)
+
+type customMailTemplateProjection struct {
+	crdb.StatementHandler
+}
+
+func newCustomMailTemplateProjection(ctx context.Context, config crdb.StatementHandlerConfig) *customMailTemplateProjection {
+	p := new(customMailTemplateProjection)
+	config.ProjectionName = CustomMailTemplateTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(CustomMailTemplateAggregateIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(CustomMailTemplateInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(CustomMailTemplateCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(CustomMailTemplateChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(CustomMailTemplateSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(CustomMailTemplateMessageTypeCol, crdb.ColumnTypeText),
+			crdb.NewColumn(CustomMailTemplateTemplateKeyCol, crdb.ColumnTypeText),
+			crdb.NewColumn(CustomMailTemplateTemplateHashCol, crdb.ColumnTypeText),
+			crdb.NewColumn(CustomMailTemplateMJMLKeyCol, crdb.ColumnTypeText, crdb.Nullable()),
+		},
+			crdb.NewPrimaryKey(CustomMailTemplateInstanceIDCol, CustomMailTemplateAggregateIDCol, CustomMailTemplateMessageTypeCol),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *customMailTemplateProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.CustomMailTemplateSetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  org.CustomMailTemplateRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(CustomMailTemplateInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *customMailTemplateProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.CustomMailTemplateSetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Mt4se", "reduce.wrong.event.type %s", org.CustomMailTemplateSetEventType)
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(CustomMailTemplateInstanceIDCol, nil),
+			handler.NewCol(CustomMailTemplateAggregateIDCol, nil),
+			handler.NewCol(CustomMailTemplateMessageTypeCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(CustomMailTemplateAggregateIDCol, e.Aggregate().ID),
+			handler.NewCol(CustomMailTemplateInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(CustomMailTemplateCreationDateCol, e.CreationDate()),
+			handler.NewCol(CustomMailTemplateChangeDateCol, e.CreationDate()),
+			handler.NewCol(CustomMailTemplateSequenceCol, e.Sequence()),
+			handler.NewCol(CustomMailTemplateMessageTypeCol, e.MessageType),
+			handler.NewCol(CustomMailTemplateTemplateKeyCol, e.TemplateKey),
+			handler.NewCol(CustomMailTemplateTemplateHashCol, e.TemplateHash),
+			handler.NewCol(CustomMailTemplateMJMLKeyCol, e.MJMLKey),
+		}), nil
+}
+
+func (p *customMailTemplateProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.CustomMailTemplateRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Mt4re", "reduce.wrong.event.type %s", org.CustomMailTemplateRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(CustomMailTemplateAggregateIDCol, e.Aggregate().ID),
+			handler.NewCond(CustomMailTemplateMessageTypeCol, e.MessageType),
+			handler.NewCond(CustomMailTemplateInstanceIDCol, e.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *customMailTemplateProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Mt4or", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(CustomMailTemplateInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(CustomMailTemplateAggregateIDCol, e.Aggregate().ID),
+		}), nil
+}
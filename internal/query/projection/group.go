@@ -0,0 +1,625 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	GroupProjectionTable = "projections.groups"
+	GroupMemberTable     = GroupProjectionTable + "_" + groupMemberTableSuffix
+	GroupMetadataTable   = GroupProjectionTable + "_" + groupMetadataTableSuffix
+	GroupGrantTable      = GroupProjectionTable + "_" + groupGrantTableSuffix
+
+	GroupColumnID            = "id"
+	GroupColumnCreationDate  = "creation_date"
+	GroupColumnChangeDate    = "change_date"
+	GroupColumnSequence      = "sequence"
+	GroupColumnState         = "state"
+	GroupColumnResourceOwner = "resource_owner"
+	GroupColumnInstanceID    = "instance_id"
+	GroupColumnName          = "name"
+	GroupColumnDescription   = "description"
+	GroupColumnOwnerRemoved  = "owner_removed"
+
+	groupMemberTableSuffix        = "members"
+	GroupMemberColumnGroupID      = "group_id"
+	GroupMemberColumnUserID       = "user_id"
+	GroupMemberColumnInstanceID   = "instance_id"
+	GroupMemberColumnCreationDate = "creation_date"
+	GroupMemberColumnSequence     = "sequence"
+
+	groupMetadataTableSuffix        = "metadata"
+	GroupMetadataColumnGroupID      = "group_id"
+	GroupMetadataColumnKey          = "key"
+	GroupMetadataColumnValue        = "value"
+	GroupMetadataColumnInstanceID   = "instance_id"
+	GroupMetadataColumnCreationDate = "creation_date"
+	GroupMetadataColumnChangeDate   = "change_date"
+	GroupMetadataColumnSequence     = "sequence"
+
+	groupGrantTableSuffix          = "grants"
+	GroupGrantColumnID             = "id"
+	GroupGrantColumnGroupID        = "group_id"
+	GroupGrantColumnInstanceID     = "instance_id"
+	GroupGrantColumnCreationDate   = "creation_date"
+	GroupGrantColumnChangeDate     = "change_date"
+	GroupGrantColumnSequence       = "sequence"
+	GroupGrantColumnProjectID      = "project_id"
+	GroupGrantColumnProjectGrantID = "project_grant_id"
+	GroupGrantColumnRoles          = "roles"
+	GroupGrantColumnState          = "state"
+)
+
+type groupProjection struct {
+	crdb.StatementHandler
+}
+
+func newGroupProjection(ctx context.Context, config crdb.StatementHandlerConfig) *groupProjection {
+	p := new(groupProjection)
+	config.ProjectionName = GroupProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewMultiTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(GroupColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(GroupColumnState, crdb.ColumnTypeEnum),
+			crdb.NewColumn(GroupColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupColumnName, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupColumnDescription, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(GroupColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(GroupColumnInstanceID, GroupColumnID),
+			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{GroupColumnResourceOwner})),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{GroupColumnOwnerRemoved})),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(GroupMemberColumnGroupID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupMemberColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupMemberColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupMemberColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupMemberColumnSequence, crdb.ColumnTypeInt64),
+		},
+			crdb.NewPrimaryKey(GroupMemberColumnInstanceID, GroupMemberColumnGroupID, GroupMemberColumnUserID),
+			groupMemberTableSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKey("group", []string{GroupMemberColumnInstanceID, GroupMemberColumnGroupID}, []string{GroupColumnInstanceID, GroupColumnID})),
+			crdb.WithIndex(crdb.NewIndex("user_id", []string{GroupMemberColumnUserID})),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(GroupMetadataColumnGroupID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupMetadataColumnKey, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupMetadataColumnValue, crdb.ColumnTypeBytes, crdb.Nullable()),
+			crdb.NewColumn(GroupMetadataColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupMetadataColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupMetadataColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupMetadataColumnSequence, crdb.ColumnTypeInt64),
+		},
+			crdb.NewPrimaryKey(GroupMetadataColumnInstanceID, GroupMetadataColumnGroupID, GroupMetadataColumnKey),
+			groupMetadataTableSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKey("group", []string{GroupMetadataColumnInstanceID, GroupMetadataColumnGroupID}, []string{GroupColumnInstanceID, GroupColumnID})),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(GroupGrantColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupGrantColumnGroupID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupGrantColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupGrantColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupGrantColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupGrantColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(GroupGrantColumnProjectID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupGrantColumnProjectGrantID, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(GroupGrantColumnRoles, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(GroupGrantColumnState, crdb.ColumnTypeEnum),
+		},
+			crdb.NewPrimaryKey(GroupGrantColumnInstanceID, GroupGrantColumnID),
+			groupGrantTableSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKey("group", []string{GroupGrantColumnInstanceID, GroupGrantColumnGroupID}, []string{GroupColumnInstanceID, GroupColumnID})),
+			crdb.WithIndex(crdb.NewIndex("group_id", []string{GroupGrantColumnGroupID})),
+			crdb.WithIndex(crdb.NewIndex("project_id", []string{GroupGrantColumnProjectID})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *groupProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: group.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  group.AddedEventType,
+					Reduce: p.reduceGroupAdded,
+				},
+				{
+					Event:  group.ChangedEventType,
+					Reduce: p.reduceGroupChanged,
+				},
+				{
+					Event:  group.RemovedEventType,
+					Reduce: p.reduceGroupRemoved,
+				},
+				{
+					Event:  group.MemberAddedEventType,
+					Reduce: p.reduceMemberAdded,
+				},
+				{
+					Event:  group.MemberRemovedEventType,
+					Reduce: p.reduceMemberRemoved,
+				},
+				{
+					Event:  group.MetadataSetType,
+					Reduce: p.reduceMetadataSet,
+				},
+				{
+					Event:  group.MetadataRemovedType,
+					Reduce: p.reduceMetadataRemoved,
+				},
+				{
+					Event:  group.MetadataRemovedAllType,
+					Reduce: p.reduceMetadataRemovedAll,
+				},
+				{
+					Event:  group.GrantAddedEventType,
+					Reduce: p.reduceGrantAdded,
+				},
+				{
+					Event:  group.GrantChangedEventType,
+					Reduce: p.reduceGrantChanged,
+				},
+				{
+					Event:  group.GrantRemovedEventType,
+					Reduce: p.reduceGrantRemoved,
+				},
+				{
+					Event:  group.GrantDeactivatedEventType,
+					Reduce: p.reduceGrantDeactivated,
+				},
+				{
+					Event:  group.GrantReactivatedEventType,
+					Reduce: p.reduceGrantReactivated,
+				},
+			},
+		},
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: project.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  project.ProjectRemovedType,
+					Reduce: p.reduceProjectRemoved,
+				},
+				{
+					Event:  project.GrantRemovedType,
+					Reduce: p.reduceProjectGrantRemoved,
+				},
+				{
+					Event:  project.RoleRemovedType,
+					Reduce: p.reduceRoleRemoved,
+				},
+				{
+					Event:  project.GrantChangedType,
+					Reduce: p.reduceProjectGrantChanged,
+				},
+				{
+					Event:  project.GrantCascadeChangedType,
+					Reduce: p.reduceProjectGrantChanged,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(GroupColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *groupProjection) reduceGroupAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.AddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ra8ws", "reduce.wrong.event.type %s", group.AddedEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupColumnID, e.Aggregate().ID),
+			handler.NewCol(GroupColumnCreationDate, e.CreationDate()),
+			handler.NewCol(GroupColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupColumnSequence, e.Sequence()),
+			handler.NewCol(GroupColumnState, domain.GroupStateActive),
+			handler.NewCol(GroupColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(GroupColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(GroupColumnName, e.Name),
+			handler.NewCol(GroupColumnDescription, e.Description),
+		},
+	), nil
+}
+
+func (p *groupProjection) reduceGroupChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.ChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Lz0sa", "reduce.wrong.event.type %s", group.ChangedEventType)
+	}
+	values := []handler.Column{
+		handler.NewCol(GroupColumnChangeDate, e.CreationDate()),
+		handler.NewCol(GroupColumnSequence, e.Sequence()),
+	}
+	if e.Name != nil {
+		values = append(values, handler.NewCol(GroupColumnName, *e.Name))
+	}
+	if e.Description != nil {
+		values = append(values, handler.NewCol(GroupColumnDescription, *e.Description))
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		values,
+		[]handler.Condition{
+			handler.NewCond(GroupColumnID, e.Aggregate().ID),
+			handler.NewCond(GroupColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *groupProjection) reduceGroupRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.RemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Vb2kq", "reduce.wrong.event.type %s", group.RemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupColumnID, e.Aggregate().ID),
+			handler.NewCond(GroupColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *groupProjection) reduceMemberAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.MemberAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Xo9fe", "reduce.wrong.event.type %s", group.MemberAddedEventType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(GroupMemberColumnGroupID, e.Aggregate().ID),
+				handler.NewCol(GroupMemberColumnUserID, e.UserID),
+				handler.NewCol(GroupMemberColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(GroupMemberColumnCreationDate, e.CreationDate()),
+				handler.NewCol(GroupMemberColumnSequence, e.Sequence()),
+			},
+			crdb.WithTableSuffix(groupMemberTableSuffix),
+		),
+		p.changedStatement(e),
+	), nil
+}
+
+func (p *groupProjection) reduceMemberRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.MemberRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Mf7xw", "reduce.wrong.event.type %s", group.MemberRemovedEventType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(GroupMemberColumnGroupID, e.Aggregate().ID),
+				handler.NewCond(GroupMemberColumnUserID, e.UserID),
+				handler.NewCond(GroupMemberColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(groupMemberTableSuffix),
+		),
+		p.changedStatement(e),
+	), nil
+}
+
+func (p *groupProjection) reduceMetadataSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.MetadataSetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ep3xh", "reduce.wrong.event.type %s", group.MetadataSetType)
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupMetadataColumnInstanceID, nil),
+			handler.NewCol(GroupMetadataColumnGroupID, nil),
+			handler.NewCol(GroupMetadataColumnKey, e.Key),
+		},
+		[]handler.Column{
+			handler.NewCol(GroupMetadataColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(GroupMetadataColumnGroupID, e.Aggregate().ID),
+			handler.NewCol(GroupMetadataColumnKey, e.Key),
+			handler.NewCol(GroupMetadataColumnCreationDate, e.CreationDate()),
+			handler.NewCol(GroupMetadataColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupMetadataColumnSequence, e.Sequence()),
+			handler.NewCol(GroupMetadataColumnValue, e.Value),
+		},
+		crdb.WithTableSuffix(groupMetadataTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceMetadataRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.MetadataRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Uq1zc", "reduce.wrong.event.type %s", group.MetadataRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupMetadataColumnGroupID, e.Aggregate().ID),
+			handler.NewCond(GroupMetadataColumnKey, e.Key),
+			handler.NewCond(GroupMetadataColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(groupMetadataTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceMetadataRemovedAll(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.MetadataRemovedAllEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Oh6dn", "reduce.wrong.event.type %s", group.MetadataRemovedAllType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupMetadataColumnGroupID, e.Aggregate().ID),
+			handler.NewCond(GroupMetadataColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(groupMetadataTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceGrantAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.GrantAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Gk4bt", "reduce.wrong.event.type %s", group.GrantAddedEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupGrantColumnID, e.GrantID),
+			handler.NewCol(GroupGrantColumnGroupID, e.Aggregate().ID),
+			handler.NewCol(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(GroupGrantColumnCreationDate, e.CreationDate()),
+			handler.NewCol(GroupGrantColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupGrantColumnSequence, e.Sequence()),
+			handler.NewCol(GroupGrantColumnProjectID, e.ProjectID),
+			handler.NewCol(GroupGrantColumnProjectGrantID, e.ProjectGrantID),
+			handler.NewCol(GroupGrantColumnRoles, database.StringArray(e.RoleKeys)),
+			handler.NewCol(GroupGrantColumnState, domain.GroupGrantStateActive),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceGrantChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.GrantChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Yt5nm", "reduce.wrong.event.type %s", group.GrantChangedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupGrantColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupGrantColumnSequence, e.Sequence()),
+			handler.NewCol(GroupGrantColumnRoles, database.StringArray(e.RoleKeys)),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnID, e.GrantID),
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceGrantDeactivated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.GrantDeactivatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Gd2kw", "reduce.wrong.event.type %s", group.GrantDeactivatedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupGrantColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupGrantColumnSequence, e.Sequence()),
+			handler.NewCol(GroupGrantColumnState, domain.GroupGrantStateInactive),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnID, e.GrantID),
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceGrantReactivated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.GrantReactivatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Gr2kw", "reduce.wrong.event.type %s", group.GrantReactivatedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupGrantColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupGrantColumnSequence, e.Sequence()),
+			handler.NewCol(GroupGrantColumnState, domain.GroupGrantStateActive),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnID, e.GrantID),
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceGrantRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.GrantRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nw6cj", "reduce.wrong.event.type %s", group.GrantRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnID, e.GrantID),
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Jc8vu", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupMemberColumnUserID, e.Aggregate().ID),
+			handler.NewCond(GroupMemberColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(groupMemberTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceProjectRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ProjectRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ti2fs", "reduce.wrong.event.type %s", project.ProjectRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnProjectID, e.Aggregate().ID),
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceProjectGrantRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.GrantRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Qe3rk", "reduce.wrong.event.type %s", project.GrantRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnProjectGrantID, e.GrantID),
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceRoleRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.RoleRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Bd7kp", "reduce.wrong.event.type %s", project.RoleRemovedType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			crdb.NewArrayRemoveCol(GroupGrantColumnRoles, e.Key),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnProjectID, e.Aggregate().ID),
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceProjectGrantChanged(event eventstore.Event) (*handler.Statement, error) {
+	var grantID string
+	var keys []string
+	switch e := event.(type) {
+	case *project.GrantChangedEvent:
+		grantID = e.GrantID
+		keys = e.RoleKeys
+	case *project.GrantCascadeChangedEvent:
+		grantID = e.GrantID
+		keys = e.RoleKeys
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Zu4ha", "reduce.wrong.event.type %v", []eventstore.EventType{project.GrantChangedType, project.GrantCascadeChangedType})
+	}
+	return crdb.NewUpdateStatement(
+		event,
+		[]handler.Column{
+			crdb.NewArrayIntersectCol(GroupGrantColumnRoles, database.StringArray(keys)),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnProjectGrantID, grantID),
+			handler.NewCond(GroupGrantColumnInstanceID, event.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Cs1lo", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupColumnSequence, e.Sequence()),
+			handler.NewCol(GroupColumnOwnerRemoved, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(GroupColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *groupProjection) changedStatement(event eventstore.Event) func(eventstore.Event) crdb.Exec {
+	return crdb.AddUpdateStatement(
+		[]handler.Column{
+			handler.NewCol(GroupColumnChangeDate, event.CreationDate()),
+			handler.NewCol(GroupColumnSequence, event.Sequence()),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupColumnID, event.Aggregate().ID),
+			handler.NewCond(GroupColumnInstanceID, event.Aggregate().InstanceID),
+		},
+	)
+}
@@ -13,7 +13,7 @@ import (
 )
 
 const (
-	LoginPolicyTable = "projections.login_policies4"
+	LoginPolicyTable = "projections.login_policies7"
 
 	LoginPolicyIDCol                    = "aggregate_id"
 	LoginPolicyInstanceIDCol            = "instance_id"
@@ -33,6 +33,9 @@ const (
 	AllowDomainDiscovery                = "allow_domain_discovery"
 	DisableLoginWithEmail               = "disable_login_with_email"
 	DisableLoginWithPhone               = "disable_login_with_phone"
+	AllowMagicLink                      = "allow_magic_link"
+	AllowPhoneOTP                       = "allow_phone_otp"
+	BotProtectionCol                    = "bot_protection"
 	DefaultRedirectURI                  = "default_redirect_uri"
 	PasswordCheckLifetimeCol            = "password_check_lifetime"
 	ExternalLoginCheckLifetimeCol       = "external_login_check_lifetime"
@@ -70,6 +73,9 @@ func newLoginPolicyProjection(ctx context.Context, config crdb.StatementHandlerC
 			crdb.NewColumn(AllowDomainDiscovery, crdb.ColumnTypeBool),
 			crdb.NewColumn(DisableLoginWithEmail, crdb.ColumnTypeBool),
 			crdb.NewColumn(DisableLoginWithPhone, crdb.ColumnTypeBool),
+			crdb.NewColumn(AllowMagicLink, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(AllowPhoneOTP, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(BotProtectionCol, crdb.ColumnTypeEnum, crdb.Default(0)),
 			crdb.NewColumn(DefaultRedirectURI, crdb.ColumnTypeText, crdb.Nullable()),
 			crdb.NewColumn(PasswordCheckLifetimeCol, crdb.ColumnTypeInt64),
 			crdb.NewColumn(ExternalLoginCheckLifetimeCol, crdb.ColumnTypeInt64),
@@ -192,6 +198,9 @@ func (p *loginPolicyProjection) reduceLoginPolicyAdded(event eventstore.Event) (
 		handler.NewCol(AllowDomainDiscovery, policyEvent.AllowDomainDiscovery),
 		handler.NewCol(DisableLoginWithEmail, policyEvent.DisableLoginWithEmail),
 		handler.NewCol(DisableLoginWithPhone, policyEvent.DisableLoginWithPhone),
+		handler.NewCol(AllowMagicLink, policyEvent.AllowMagicLink),
+		handler.NewCol(AllowPhoneOTP, policyEvent.AllowPhoneOTP),
+		handler.NewCol(BotProtectionCol, policyEvent.BotProtection),
 		handler.NewCol(DefaultRedirectURI, policyEvent.DefaultRedirectURI),
 		handler.NewCol(PasswordCheckLifetimeCol, policyEvent.PasswordCheckLifetime),
 		handler.NewCol(ExternalLoginCheckLifetimeCol, policyEvent.ExternalLoginCheckLifetime),
@@ -246,6 +255,15 @@ func (p *loginPolicyProjection) reduceLoginPolicyChanged(event eventstore.Event)
 	if policyEvent.DisableLoginWithPhone != nil {
 		cols = append(cols, handler.NewCol(DisableLoginWithPhone, *policyEvent.DisableLoginWithPhone))
 	}
+	if policyEvent.AllowMagicLink != nil {
+		cols = append(cols, handler.NewCol(AllowMagicLink, *policyEvent.AllowMagicLink))
+	}
+	if policyEvent.AllowPhoneOTP != nil {
+		cols = append(cols, handler.NewCol(AllowPhoneOTP, *policyEvent.AllowPhoneOTP))
+	}
+	if policyEvent.BotProtection != nil {
+		cols = append(cols, handler.NewCol(BotProtectionCol, *policyEvent.BotProtection))
+	}
 	if policyEvent.DefaultRedirectURI != nil {
 		cols = append(cols, handler.NewCol(DefaultRedirectURI, *policyEvent.DefaultRedirectURI))
 	}
@@ -274,7 +274,14 @@ func isMessageTemplate(template string) bool {
 		template == domain.VerifyPhoneMessageType ||
 		template == domain.DomainClaimedMessageType ||
 		template == domain.PasswordlessRegistrationMessageType ||
-		template == domain.PasswordChangeMessageType
+		template == domain.PasswordChangeMessageType ||
+		template == domain.MagicLinkMessageType ||
+		template == domain.PhoneOTPMessageType ||
+		template == domain.NewDeviceLoginMessageType ||
+		template == domain.MFAAddedMessageType ||
+		template == domain.MFARemovedMessageType ||
+		template == domain.EmailChangedMessageType ||
+		template == domain.AccountLockedMessageType
 }
 func isTitle(key string) bool {
 	return key == domain.MessageTitle
@@ -0,0 +1,248 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	NotificationOutboxProjectionTable = "projections.notification_outbox"
+
+	NotificationOutboxColumnID               = "id"
+	NotificationOutboxColumnCreationDate     = "creation_date"
+	NotificationOutboxColumnChangeDate       = "change_date"
+	NotificationOutboxColumnSequence         = "sequence"
+	NotificationOutboxColumnResourceOwner    = "resource_owner"
+	NotificationOutboxColumnInstanceID       = "instance_id"
+	NotificationOutboxColumnUserID           = "user_id"
+	NotificationOutboxColumnTriggerEventType = "trigger_event_type"
+	NotificationOutboxColumnTriggerSequence  = "trigger_sequence"
+	NotificationOutboxColumnChannel          = "channel"
+	NotificationOutboxColumnRecipient        = "recipient"
+	NotificationOutboxColumnMessageType      = "message_type"
+	NotificationOutboxColumnState            = "state"
+	NotificationOutboxColumnAttempts         = "attempts"
+	NotificationOutboxColumnLastError        = "last_error"
+	NotificationOutboxColumnRetryAt          = "retry_at"
+)
+
+type notificationOutboxProjection struct {
+	crdb.StatementHandler
+}
+
+func newNotificationOutboxProjection(ctx context.Context, config crdb.StatementHandlerConfig) *notificationOutboxProjection {
+	p := new(notificationOutboxProjection)
+	config.ProjectionName = NotificationOutboxProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(NotificationOutboxColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationOutboxColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(NotificationOutboxColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(NotificationOutboxColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(NotificationOutboxColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationOutboxColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationOutboxColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationOutboxColumnTriggerEventType, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationOutboxColumnTriggerSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(NotificationOutboxColumnChannel, crdb.ColumnTypeEnum),
+			crdb.NewColumn(NotificationOutboxColumnRecipient, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationOutboxColumnMessageType, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationOutboxColumnState, crdb.ColumnTypeEnum),
+			crdb.NewColumn(NotificationOutboxColumnAttempts, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(NotificationOutboxColumnLastError, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(NotificationOutboxColumnRetryAt, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+		},
+			crdb.NewPrimaryKey(NotificationOutboxColumnInstanceID, NotificationOutboxColumnID),
+			crdb.WithIndex(crdb.NewIndex("user_id", []string{NotificationOutboxColumnUserID})),
+			crdb.WithIndex(crdb.NewIndex("retry_at", []string{NotificationOutboxColumnRetryAt})),
+		),
+	)
+
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *notificationOutboxProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: notification.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  notification.AddedType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  notification.DeliveredType,
+					Reduce: p.reduceDelivered,
+				},
+				{
+					Event:  notification.DeliveryFailedType,
+					Reduce: p.reduceDeliveryFailed,
+				},
+				{
+					Event:  notification.ResendRequestedType,
+					Reduce: p.reduceResendRequested,
+				},
+			},
+		},
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(NotificationOutboxColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *notificationOutboxProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.AddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nt4ga", "reduce.wrong.event.type %s", notification.AddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationOutboxColumnID, e.Aggregate().ID),
+			handler.NewCol(NotificationOutboxColumnCreationDate, e.CreationDate()),
+			handler.NewCol(NotificationOutboxColumnChangeDate, e.CreationDate()),
+			handler.NewCol(NotificationOutboxColumnSequence, e.Sequence()),
+			handler.NewCol(NotificationOutboxColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(NotificationOutboxColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(NotificationOutboxColumnUserID, e.UserID),
+			handler.NewCol(NotificationOutboxColumnTriggerEventType, e.TriggerEventType),
+			handler.NewCol(NotificationOutboxColumnTriggerSequence, e.TriggerSequence),
+			handler.NewCol(NotificationOutboxColumnChannel, e.Channel),
+			handler.NewCol(NotificationOutboxColumnRecipient, e.Recipient),
+			handler.NewCol(NotificationOutboxColumnMessageType, e.MessageType),
+			handler.NewCol(NotificationOutboxColumnState, domain.NotificationDeliveryStateRetrying),
+		},
+	), nil
+}
+
+func (p *notificationOutboxProjection) reduceDelivered(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.DeliveredEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nt4gb", "reduce.wrong.event.type %s", notification.DeliveredType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationOutboxColumnChangeDate, e.CreationDate()),
+			handler.NewCol(NotificationOutboxColumnSequence, e.Sequence()),
+			handler.NewCol(NotificationOutboxColumnState, domain.NotificationDeliveryStateDelivered),
+			handler.NewCol(NotificationOutboxColumnAttempts, e.Attempt),
+			handler.NewCol(NotificationOutboxColumnRetryAt, nil),
+		},
+		[]handler.Condition{
+			handler.NewCond(NotificationOutboxColumnID, e.Aggregate().ID),
+			handler.NewCond(NotificationOutboxColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *notificationOutboxProjection) reduceDeliveryFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.DeliveryFailedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nt4gc", "reduce.wrong.event.type %s", notification.DeliveryFailedType)
+	}
+	state := domain.NotificationDeliveryStateFailed
+	var retryAt interface{}
+	if e.RetryAt != nil {
+		state = domain.NotificationDeliveryStateRetrying
+		retryAt = *e.RetryAt
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationOutboxColumnChangeDate, e.CreationDate()),
+			handler.NewCol(NotificationOutboxColumnSequence, e.Sequence()),
+			handler.NewCol(NotificationOutboxColumnState, state),
+			handler.NewCol(NotificationOutboxColumnAttempts, e.Attempt),
+			handler.NewCol(NotificationOutboxColumnLastError, e.Error),
+			handler.NewCol(NotificationOutboxColumnRetryAt, retryAt),
+		},
+		[]handler.Condition{
+			handler.NewCond(NotificationOutboxColumnID, e.Aggregate().ID),
+			handler.NewCond(NotificationOutboxColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *notificationOutboxProjection) reduceResendRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.ResendRequestedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nt4gd", "reduce.wrong.event.type %s", notification.ResendRequestedType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationOutboxColumnChangeDate, e.CreationDate()),
+			handler.NewCol(NotificationOutboxColumnSequence, e.Sequence()),
+			handler.NewCol(NotificationOutboxColumnState, domain.NotificationDeliveryStateRetrying),
+			handler.NewCol(NotificationOutboxColumnRetryAt, e.CreationDate()),
+		},
+		[]handler.Condition{
+			handler.NewCond(NotificationOutboxColumnID, e.Aggregate().ID),
+			handler.NewCond(NotificationOutboxColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *notificationOutboxProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nt4ge", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(NotificationOutboxColumnUserID, e.Aggregate().ID),
+			handler.NewCond(NotificationOutboxColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *notificationOutboxProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nt4gf", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(NotificationOutboxColumnResourceOwner, e.Aggregate().ID),
+			handler.NewCond(NotificationOutboxColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
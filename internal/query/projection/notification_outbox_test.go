@@ -0,0 +1,305 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestNotificationOutboxProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.AddedType),
+					notification.AggregateType,
+					[]byte(`{
+						"userID": "user-id",
+						"triggerEventType": "user.human.email.code.added",
+						"triggerSequence": 5,
+						"channel": 0,
+						"recipient": "email@test.ch",
+						"messageType": "VerifyEmail"
+					}`),
+				), eventstore.GenericEventMapper[notification.AddedEvent]),
+			},
+			reduce: (&notificationOutboxProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("notification"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.notification_outbox (id, creation_date, change_date, sequence, resource_owner, instance_id, user_id, trigger_event_type, trigger_sequence, channel, recipient, message_type, state) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								"user-id",
+								eventstore.EventType("user.human.email.code.added"),
+								uint64(5),
+								domain.NotificationTypeEmail,
+								"email@test.ch",
+								"VerifyEmail",
+								domain.NotificationDeliveryStateRetrying,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDelivered",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.DeliveredType),
+					notification.AggregateType,
+					[]byte(`{
+						"attempt": 2
+					}`),
+				), eventstore.GenericEventMapper[notification.DeliveredEvent]),
+			},
+			reduce: (&notificationOutboxProjection{}).reduceDelivered,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("notification"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_outbox SET (change_date, sequence, state, attempts, retry_at) = ($1, $2, $3, $4, $5) WHERE (id = $6) AND (instance_id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationDeliveryStateDelivered,
+								uint16(2),
+								nil,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDeliveryFailed retry",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.DeliveryFailedType),
+					notification.AggregateType,
+					[]byte(`{
+						"attempt": 1,
+						"error": "connection refused",
+						"retryAt": "2023-05-04T00:00:00Z"
+					}`),
+				), eventstore.GenericEventMapper[notification.DeliveryFailedEvent]),
+			},
+			reduce: (&notificationOutboxProjection{}).reduceDeliveryFailed,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("notification"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_outbox SET (change_date, sequence, state, attempts, last_error, retry_at) = ($1, $2, $3, $4, $5, $6) WHERE (id = $7) AND (instance_id = $8)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationDeliveryStateRetrying,
+								uint16(1),
+								"connection refused",
+								time.Date(2023, time.May, 4, 0, 0, 0, 0, time.UTC),
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDeliveryFailed final",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.DeliveryFailedType),
+					notification.AggregateType,
+					[]byte(`{
+						"attempt": 5,
+						"error": "connection refused"
+					}`),
+				), eventstore.GenericEventMapper[notification.DeliveryFailedEvent]),
+			},
+			reduce: (&notificationOutboxProjection{}).reduceDeliveryFailed,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("notification"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_outbox SET (change_date, sequence, state, attempts, last_error, retry_at) = ($1, $2, $3, $4, $5, $6) WHERE (id = $7) AND (instance_id = $8)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationDeliveryStateFailed,
+								uint16(5),
+								"connection refused",
+								nil,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceResendRequested",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.ResendRequestedType),
+					notification.AggregateType,
+					[]byte(`{}`),
+				), eventstore.GenericEventMapper[notification.ResendRequestedEvent]),
+			},
+			reduce: (&notificationOutboxProjection{}).reduceResendRequested,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("notification"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_outbox SET (change_date, sequence, state, retry_at) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationDeliveryStateRetrying,
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "user reduceUserRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserRemovedType),
+					user.AggregateType,
+					nil,
+				), user.UserRemovedEventMapper),
+			},
+			reduce: (&notificationOutboxProjection{}).reduceUserRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_outbox WHERE (user_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&notificationOutboxProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_outbox WHERE (resource_owner = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(NotificationOutboxColumnInstanceID),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_outbox WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !errors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, NotificationOutboxProjectionTable, tt.want)
+		})
+	}
+}
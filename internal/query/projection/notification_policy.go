@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	NotificationPolicyProjectionTable = "projections.notification_policies"
+	NotificationPolicyProjectionTable = "projections.notification_policies2"
 
 	NotificationPolicyColumnID             = "id"
 	NotificationPolicyColumnCreationDate   = "creation_date"
@@ -25,6 +25,11 @@ const (
 	NotificationPolicyColumnStateCol       = "state"
 	NotificationPolicyColumnIsDefault      = "is_default"
 	NotificationPolicyColumnPasswordChange = "password_change"
+	NotificationPolicyColumnNewDeviceLogin = "new_device_login"
+	NotificationPolicyColumnMFAAdded       = "mfa_added"
+	NotificationPolicyColumnMFARemoved     = "mfa_removed"
+	NotificationPolicyColumnEmailChange    = "email_change"
+	NotificationPolicyColumnAccountLocked  = "account_locked"
 	NotificationPolicyColumnOwnerRemoved   = "owner_removed"
 )
 
@@ -47,6 +52,11 @@ func newNotificationPolicyProjection(ctx context.Context, config crdb.StatementH
 			crdb.NewColumn(NotificationPolicyColumnStateCol, crdb.ColumnTypeEnum),
 			crdb.NewColumn(NotificationPolicyColumnIsDefault, crdb.ColumnTypeBool),
 			crdb.NewColumn(NotificationPolicyColumnPasswordChange, crdb.ColumnTypeBool),
+			crdb.NewColumn(NotificationPolicyColumnNewDeviceLogin, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(NotificationPolicyColumnMFAAdded, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(NotificationPolicyColumnMFARemoved, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(NotificationPolicyColumnEmailChange, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(NotificationPolicyColumnAccountLocked, crdb.ColumnTypeBool, crdb.Default(false)),
 			crdb.NewColumn(NotificationPolicyColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
 		},
 			crdb.NewPrimaryKey(NotificationPolicyColumnInstanceID, NotificationPolicyColumnID),
@@ -121,6 +131,11 @@ func (p *notificationPolicyProjection) reduceAdded(event eventstore.Event) (*han
 			handler.NewCol(NotificationPolicyColumnID, policyEvent.Aggregate().ID),
 			handler.NewCol(NotificationPolicyColumnStateCol, domain.PolicyStateActive),
 			handler.NewCol(NotificationPolicyColumnPasswordChange, policyEvent.PasswordChange),
+			handler.NewCol(NotificationPolicyColumnNewDeviceLogin, policyEvent.NewDeviceLogin),
+			handler.NewCol(NotificationPolicyColumnMFAAdded, policyEvent.MFAAdded),
+			handler.NewCol(NotificationPolicyColumnMFARemoved, policyEvent.MFARemoved),
+			handler.NewCol(NotificationPolicyColumnEmailChange, policyEvent.EmailChange),
+			handler.NewCol(NotificationPolicyColumnAccountLocked, policyEvent.AccountLocked),
 			handler.NewCol(NotificationPolicyColumnIsDefault, isDefault),
 			handler.NewCol(NotificationPolicyColumnResourceOwner, policyEvent.Aggregate().ResourceOwner),
 			handler.NewCol(NotificationPolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
@@ -144,6 +159,21 @@ func (p *notificationPolicyProjection) reduceChanged(event eventstore.Event) (*h
 	if policyEvent.PasswordChange != nil {
 		cols = append(cols, handler.NewCol(NotificationPolicyColumnPasswordChange, *policyEvent.PasswordChange))
 	}
+	if policyEvent.NewDeviceLogin != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnNewDeviceLogin, *policyEvent.NewDeviceLogin))
+	}
+	if policyEvent.MFAAdded != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnMFAAdded, *policyEvent.MFAAdded))
+	}
+	if policyEvent.MFARemoved != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnMFARemoved, *policyEvent.MFARemoved))
+	}
+	if policyEvent.EmailChange != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnEmailChange, *policyEvent.EmailChange))
+	}
+	if policyEvent.AccountLocked != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnAccountLocked, *policyEvent.AccountLocked))
+	}
 	return crdb.NewUpdateStatement(
 		&policyEvent,
 		cols,
@@ -0,0 +1,148 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+const (
+	NotificationWebhookProviderTable = "projections.notification_webhook_providers"
+
+	NotificationWebhookProviderAggIDCol         = "aggregate_id"
+	NotificationWebhookProviderCreationDateCol  = "creation_date"
+	NotificationWebhookProviderChangeDateCol    = "change_date"
+	NotificationWebhookProviderSequenceCol      = "sequence"
+	NotificationWebhookProviderResourceOwnerCol = "resource_owner"
+	NotificationWebhookProviderInstanceIDCol    = "instance_id"
+	NotificationWebhookProviderCallURLCol       = "call_url"
+	NotificationWebhookProviderMethodCol        = "method"
+	NotificationWebhookProviderHeadersCol       = "headers"
+	NotificationWebhookProviderSigningKeyCol    = "signing_key"
+)
+
+type notificationWebhookProviderProjection struct {
+	crdb.StatementHandler
+}
+
+func newNotificationWebhookProviderProjection(ctx context.Context, config crdb.StatementHandlerConfig) *notificationWebhookProviderProjection {
+	p := new(notificationWebhookProviderProjection)
+	config.ProjectionName = NotificationWebhookProviderTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(NotificationWebhookProviderAggIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationWebhookProviderCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(NotificationWebhookProviderChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(NotificationWebhookProviderSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(NotificationWebhookProviderResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationWebhookProviderInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationWebhookProviderCallURLCol, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationWebhookProviderMethodCol, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationWebhookProviderHeadersCol, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(NotificationWebhookProviderSigningKeyCol, crdb.ColumnTypeJSONB),
+		},
+			crdb.NewPrimaryKey(NotificationWebhookProviderInstanceIDCol, NotificationWebhookProviderAggIDCol),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *notificationWebhookProviderProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.NotificationWebhookProviderAddedEventType,
+					Reduce: p.reduceNotificationWebhookProviderAdded,
+				},
+				{
+					Event:  instance.NotificationWebhookProviderChangedEventType,
+					Reduce: p.reduceNotificationWebhookProviderChanged,
+				},
+				{
+					Event:  instance.NotificationWebhookProviderRemovedEventType,
+					Reduce: p.reduceNotificationWebhookProviderRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(NotificationWebhookProviderInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *notificationWebhookProviderProjection) reduceNotificationWebhookProviderAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.NotificationWebhookProviderAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Wh5ad", "reduce.wrong.event.type %s", instance.NotificationWebhookProviderAddedEventType)
+	}
+
+	return crdb.NewCreateStatement(e, []handler.Column{
+		handler.NewCol(NotificationWebhookProviderAggIDCol, e.Aggregate().ID),
+		handler.NewCol(NotificationWebhookProviderCreationDateCol, e.CreationDate()),
+		handler.NewCol(NotificationWebhookProviderChangeDateCol, e.CreationDate()),
+		handler.NewCol(NotificationWebhookProviderSequenceCol, e.Sequence()),
+		handler.NewCol(NotificationWebhookProviderResourceOwnerCol, e.Aggregate().ResourceOwner),
+		handler.NewCol(NotificationWebhookProviderInstanceIDCol, e.Aggregate().InstanceID),
+		handler.NewCol(NotificationWebhookProviderCallURLCol, e.CallURL),
+		handler.NewCol(NotificationWebhookProviderMethodCol, e.Method),
+		handler.NewJSONCol(NotificationWebhookProviderHeadersCol, e.Headers),
+		handler.NewCol(NotificationWebhookProviderSigningKeyCol, e.SigningKey),
+	}), nil
+}
+
+func (p *notificationWebhookProviderProjection) reduceNotificationWebhookProviderChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.NotificationWebhookProviderChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Wh5ch", "reduce.wrong.event.type %s", instance.NotificationWebhookProviderChangedEventType)
+	}
+
+	cols := []handler.Column{
+		handler.NewCol(NotificationWebhookProviderChangeDateCol, e.CreationDate()),
+		handler.NewCol(NotificationWebhookProviderSequenceCol, e.Sequence()),
+	}
+	if e.CallURL != nil {
+		cols = append(cols, handler.NewCol(NotificationWebhookProviderCallURLCol, *e.CallURL))
+	}
+	if e.Method != nil {
+		cols = append(cols, handler.NewCol(NotificationWebhookProviderMethodCol, *e.Method))
+	}
+	if e.Headers != nil {
+		cols = append(cols, handler.NewJSONCol(NotificationWebhookProviderHeadersCol, e.Headers))
+	}
+	if e.SigningKey != nil {
+		cols = append(cols, handler.NewCol(NotificationWebhookProviderSigningKeyCol, e.SigningKey))
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		cols,
+		[]handler.Condition{
+			handler.NewCond(NotificationWebhookProviderAggIDCol, e.Aggregate().ID),
+			handler.NewCond(NotificationWebhookProviderInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *notificationWebhookProviderProjection) reduceNotificationWebhookProviderRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.NotificationWebhookProviderRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Wh5rm", "reduce.wrong.event.type %s", instance.NotificationWebhookProviderRemovedEventType)
+	}
+
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(NotificationWebhookProviderAggIDCol, e.Aggregate().ID),
+			handler.NewCond(NotificationWebhookProviderInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
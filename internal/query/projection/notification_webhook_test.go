@@ -0,0 +1,175 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func TestNotificationWebhookProviderProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name:   "instance reduceNotificationWebhookProviderAdded",
+			reduce: (&notificationWebhookProviderProjection{}).reduceNotificationWebhookProviderAdded,
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.NotificationWebhookProviderAddedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"callURL": "https://webhook.example.com",
+						"method": "POST",
+						"headers": {"Authorization": "Bearer token"},
+						"signingKey": {
+							"cryptoType": 0,
+							"algorithm": "RSA-265",
+							"keyId": "key-id",
+							"crypted": "Y3J5cHRlZA=="
+						}
+					}`),
+				), instance.NotificationWebhookProviderAddedEventMapper),
+			},
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.notification_webhook_providers (aggregate_id, creation_date, change_date, sequence, resource_owner, instance_id, call_url, method, headers, signing_key) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								"https://webhook.example.com",
+								"POST",
+								[]byte(`{"Authorization":"Bearer token"}`),
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "RSA-265",
+									KeyID:      "key-id",
+									Crypted:    []byte("crypted"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "instance reduceNotificationWebhookProviderChanged",
+			reduce: (&notificationWebhookProviderProjection{}).reduceNotificationWebhookProviderChanged,
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.NotificationWebhookProviderChangedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"callURL": "https://webhook.example.com",
+						"headers": {}
+					}`),
+				), instance.NotificationWebhookProviderChangedEventMapper),
+			},
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_webhook_providers SET (change_date, sequence, call_url, headers) = ($1, $2, $3, $4) WHERE (aggregate_id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"https://webhook.example.com",
+								[]byte(`{}`),
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "instance reduceNotificationWebhookProviderRemoved",
+			reduce: (&notificationWebhookProviderProjection{}).reduceNotificationWebhookProviderRemoved,
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.NotificationWebhookProviderRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.NotificationWebhookProviderRemovedEventMapper),
+			},
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_webhook_providers WHERE (aggregate_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(NotificationWebhookProviderInstanceIDCol),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_webhook_providers WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, NotificationWebhookProviderTable, tt.want)
+		})
+	}
+}
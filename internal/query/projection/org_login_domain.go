@@ -0,0 +1,125 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	OrgLoginDomainTable = "projections.org_login_domains"
+
+	OrgLoginDomainOrgIDCol        = "org_id"
+	OrgLoginDomainInstanceIDCol   = "instance_id"
+	OrgLoginDomainCreationDateCol = "creation_date"
+	OrgLoginDomainChangeDateCol   = "change_date"
+	OrgLoginDomainSequenceCol     = "sequence"
+	OrgLoginDomainDomainCol       = "domain"
+)
+
+type orgLoginDomainProjection struct {
+	crdb.StatementHandler
+}
+
+func newOrgLoginDomainProjection(ctx context.Context, config crdb.StatementHandlerConfig) *orgLoginDomainProjection {
+	p := new(orgLoginDomainProjection)
+	config.ProjectionName = OrgLoginDomainTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(OrgLoginDomainOrgIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(OrgLoginDomainInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(OrgLoginDomainCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(OrgLoginDomainChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(OrgLoginDomainSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(OrgLoginDomainDomainCol, crdb.ColumnTypeText),
+		},
+			crdb.NewPrimaryKey(OrgLoginDomainInstanceIDCol, OrgLoginDomainDomainCol),
+			crdb.WithIndex(crdb.NewIndex("login_domain", []string{OrgLoginDomainDomainCol})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *orgLoginDomainProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgDomainLoginAddedEventType,
+					Reduce: p.reduceLoginDomainAdded,
+				},
+				{
+					Event:  org.OrgDomainLoginRemovedEventType,
+					Reduce: p.reduceLoginDomainRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(OrgLoginDomainInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *orgLoginDomainProjection) reduceLoginDomainAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.DomainLoginAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Lg6ad", "reduce.wrong.event.type %s", org.OrgDomainLoginAddedEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(OrgLoginDomainCreationDateCol, e.CreationDate()),
+			handler.NewCol(OrgLoginDomainChangeDateCol, e.CreationDate()),
+			handler.NewCol(OrgLoginDomainSequenceCol, e.Sequence()),
+			handler.NewCol(OrgLoginDomainDomainCol, e.Domain),
+			handler.NewCol(OrgLoginDomainOrgIDCol, e.Aggregate().ID),
+			handler.NewCol(OrgLoginDomainInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *orgLoginDomainProjection) reduceLoginDomainRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.DomainLoginRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Lg6rm", "reduce.wrong.event.type %s", org.OrgDomainLoginRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(OrgLoginDomainDomainCol, e.Domain),
+			handler.NewCond(OrgLoginDomainInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *orgLoginDomainProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Lg6or", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(OrgLoginDomainOrgIDCol, e.Aggregate().ID),
+			handler.NewCond(OrgLoginDomainInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	ProjectProjectionTable = "projections.projects3"
+	ProjectProjectionTable = "projections.projects4"
 
 	ProjectColumnID                     = "id"
 	ProjectColumnCreationDate           = "creation_date"
@@ -28,6 +28,7 @@ const (
 	ProjectColumnProjectRoleCheck       = "project_role_check"
 	ProjectColumnHasProjectCheck        = "has_project_check"
 	ProjectColumnPrivateLabelingSetting = "private_labeling_setting"
+	ProjectColumnAllowImpersonation     = "allow_impersonation"
 	ProjectColumnOwnerRemoved           = "owner_removed"
 )
 
@@ -53,6 +54,7 @@ func newProjectProjection(ctx context.Context, config crdb.StatementHandlerConfi
 			crdb.NewColumn(ProjectColumnProjectRoleCheck, crdb.ColumnTypeBool),
 			crdb.NewColumn(ProjectColumnHasProjectCheck, crdb.ColumnTypeBool),
 			crdb.NewColumn(ProjectColumnPrivateLabelingSetting, crdb.ColumnTypeEnum),
+			crdb.NewColumn(ProjectColumnAllowImpersonation, crdb.ColumnTypeBool, crdb.Default(false)),
 			crdb.NewColumn(ProjectColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
 		},
 			crdb.NewPrimaryKey(ProjectColumnInstanceID, ProjectColumnID),
@@ -141,11 +143,11 @@ func (p *projectProjection) reduceProjectChanged(event eventstore.Event) (*handl
 	if !ok {
 		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-s00Fs", "reduce.wrong.event.type %s", project.ProjectChangedType)
 	}
-	if e.Name == nil && e.HasProjectCheck == nil && e.ProjectRoleAssertion == nil && e.ProjectRoleCheck == nil && e.PrivateLabelingSetting == nil {
+	if e.Name == nil && e.HasProjectCheck == nil && e.ProjectRoleAssertion == nil && e.ProjectRoleCheck == nil && e.PrivateLabelingSetting == nil && e.AllowImpersonation == nil {
 		return crdb.NewNoOpStatement(e), nil
 	}
 
-	columns := make([]handler.Column, 0, 7)
+	columns := make([]handler.Column, 0, 8)
 	columns = append(columns, handler.NewCol(ProjectColumnChangeDate, e.CreationDate()),
 		handler.NewCol(ProjectColumnSequence, e.Sequence()))
 	if e.Name != nil {
@@ -163,6 +165,9 @@ func (p *projectProjection) reduceProjectChanged(event eventstore.Event) (*handl
 	if e.PrivateLabelingSetting != nil {
 		columns = append(columns, handler.NewCol(ProjectColumnPrivateLabelingSetting, *e.PrivateLabelingSetting))
 	}
+	if e.AllowImpersonation != nil {
+		columns = append(columns, handler.NewCol(ProjectColumnAllowImpersonation, *e.AllowImpersonation))
+	}
 	return crdb.NewUpdateStatement(
 		e,
 		columns,
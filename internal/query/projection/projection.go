@@ -17,54 +17,61 @@ const (
 )
 
 var (
-	projectionConfig                    crdb.StatementHandlerConfig
-	OrgProjection                       *orgProjection
-	OrgMetadataProjection               *orgMetadataProjection
-	ActionProjection                    *actionProjection
-	FlowProjection                      *flowProjection
-	ProjectProjection                   *projectProjection
-	PasswordComplexityProjection        *passwordComplexityProjection
-	PasswordAgeProjection               *passwordAgeProjection
-	LockoutPolicyProjection             *lockoutPolicyProjection
-	PrivacyPolicyProjection             *privacyPolicyProjection
-	DomainPolicyProjection              *domainPolicyProjection
-	LabelPolicyProjection               *labelPolicyProjection
-	ProjectGrantProjection              *projectGrantProjection
-	ProjectRoleProjection               *projectRoleProjection
-	OrgDomainProjection                 *orgDomainProjection
-	LoginPolicyProjection               *loginPolicyProjection
-	IDPProjection                       *idpProjection
-	AppProjection                       *appProjection
-	IDPUserLinkProjection               *idpUserLinkProjection
-	IDPLoginPolicyLinkProjection        *idpLoginPolicyLinkProjection
-	IDPTemplateProjection               *idpTemplateProjection
-	MailTemplateProjection              *mailTemplateProjection
-	MessageTextProjection               *messageTextProjection
-	CustomTextProjection                *customTextProjection
-	UserProjection                      *userProjection
-	LoginNameProjection                 *loginNameProjection
-	OrgMemberProjection                 *orgMemberProjection
-	InstanceDomainProjection            *instanceDomainProjection
-	InstanceMemberProjection            *instanceMemberProjection
-	ProjectMemberProjection             *projectMemberProjection
-	ProjectGrantMemberProjection        *projectGrantMemberProjection
-	AuthNKeyProjection                  *authNKeyProjection
-	PersonalAccessTokenProjection       *personalAccessTokenProjection
-	UserGrantProjection                 *userGrantProjection
-	UserMetadataProjection              *userMetadataProjection
-	UserAuthMethodProjection            *userAuthMethodProjection
-	InstanceProjection                  *instanceProjection
-	SecretGeneratorProjection           *secretGeneratorProjection
-	SMTPConfigProjection                *smtpConfigProjection
-	SMSConfigProjection                 *smsConfigProjection
-	OIDCSettingsProjection              *oidcSettingsProjection
-	DebugNotificationProviderProjection *debugNotificationProviderProjection
-	KeyProjection                       *keyProjection
-	SecurityPolicyProjection            *securityPolicyProjection
-	NotificationPolicyProjection        *notificationPolicyProjection
-	NotificationsProjection             interface{}
-	NotificationsQuotaProjection        interface{}
-	DeviceAuthProjection                *deviceAuthProjection
+	projectionConfig                      crdb.StatementHandlerConfig
+	OrgProjection                         *orgProjection
+	OrgMetadataProjection                 *orgMetadataProjection
+	ActionProjection                      *actionProjection
+	FlowProjection                        *flowProjection
+	ProjectProjection                     *projectProjection
+	PasswordComplexityProjection          *passwordComplexityProjection
+	PasswordAgeProjection                 *passwordAgeProjection
+	LockoutPolicyProjection               *lockoutPolicyProjection
+	PrivacyPolicyProjection               *privacyPolicyProjection
+	DomainPolicyProjection                *domainPolicyProjection
+	LabelPolicyProjection                 *labelPolicyProjection
+	ProjectGrantProjection                *projectGrantProjection
+	ProjectRoleProjection                 *projectRoleProjection
+	OrgDomainProjection                   *orgDomainProjection
+	OrgLoginDomainProjection              *orgLoginDomainProjection
+	LoginPolicyProjection                 *loginPolicyProjection
+	IDPProjection                         *idpProjection
+	AppProjection                         *appProjection
+	IDPUserLinkProjection                 *idpUserLinkProjection
+	IDPLoginPolicyLinkProjection          *idpLoginPolicyLinkProjection
+	IDPTemplateProjection                 *idpTemplateProjection
+	MailTemplateProjection                *mailTemplateProjection
+	CustomMailTemplateProjection          *customMailTemplateProjection
+	MessageTextProjection                 *messageTextProjection
+	CustomTextProjection                  *customTextProjection
+	UserProjection                        *userProjection
+	LoginNameProjection                   *loginNameProjection
+	OrgMemberProjection                   *orgMemberProjection
+	InstanceDomainProjection              *instanceDomainProjection
+	InstanceMemberProjection              *instanceMemberProjection
+	ProjectMemberProjection               *projectMemberProjection
+	ProjectGrantMemberProjection          *projectGrantMemberProjection
+	AuthNKeyProjection                    *authNKeyProjection
+	PersonalAccessTokenProjection         *personalAccessTokenProjection
+	UserGrantProjection                   *userGrantProjection
+	UserMetadataProjection                *userMetadataProjection
+	UserAuthMethodProjection              *userAuthMethodProjection
+	InstanceProjection                    *instanceProjection
+	SecretGeneratorProjection             *secretGeneratorProjection
+	SMTPConfigProjection                  *smtpConfigProjection
+	SMSConfigProjection                   *smsConfigProjection
+	OIDCSettingsProjection                *oidcSettingsProjection
+	DebugNotificationProviderProjection   *debugNotificationProviderProjection
+	NotificationWebhookProviderProjection *notificationWebhookProviderProjection
+	KeyProjection                         *keyProjection
+	SecurityPolicyProjection              *securityPolicyProjection
+	NotificationPolicyProjection          *notificationPolicyProjection
+	NotificationsProjection               interface{}
+	NotificationsQuotaProjection          interface{}
+	DeviceAuthProjection                  *deviceAuthProjection
+	GroupProjection                       *groupProjection
+	UserConsentProjection                 *userConsentProjection
+	SessionProjection                     *sessionProjection
+	NotificationOutboxProjection          *notificationOutboxProjection
 )
 
 type projection interface {
@@ -110,6 +117,7 @@ func Create(ctx context.Context, sqlClient *database.DB, es *eventstore.Eventsto
 	ProjectGrantProjection = newProjectGrantProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["project_grants"]))
 	ProjectRoleProjection = newProjectRoleProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["project_roles"]))
 	OrgDomainProjection = newOrgDomainProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["org_domains"]))
+	OrgLoginDomainProjection = newOrgLoginDomainProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["org_login_domains"]))
 	LoginPolicyProjection = newLoginPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["login_policies"]))
 	IDPProjection = newIDPProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idps"]))
 	AppProjection = newAppProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["apps"]))
@@ -117,6 +125,7 @@ func Create(ctx context.Context, sqlClient *database.DB, es *eventstore.Eventsto
 	IDPLoginPolicyLinkProjection = newIDPLoginPolicyLinkProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_login_policy_links"]))
 	IDPTemplateProjection = newIDPTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_templates"]))
 	MailTemplateProjection = newMailTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["mail_templates"]))
+	CustomMailTemplateProjection = newCustomMailTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["custom_mail_templates"]))
 	MessageTextProjection = newMessageTextProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["message_texts"]))
 	CustomTextProjection = newCustomTextProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["custom_texts"]))
 	UserProjection = newUserProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["users"]))
@@ -137,10 +146,15 @@ func Create(ctx context.Context, sqlClient *database.DB, es *eventstore.Eventsto
 	SMSConfigProjection = newSMSConfigProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sms_config"]))
 	OIDCSettingsProjection = newOIDCSettingsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["oidc_settings"]))
 	DebugNotificationProviderProjection = newDebugNotificationProviderProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["debug_notification_provider"]))
+	NotificationWebhookProviderProjection = newNotificationWebhookProviderProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_webhook_providers"]))
 	KeyProjection = newKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["keys"]), keyEncryptionAlgorithm, certEncryptionAlgorithm)
 	SecurityPolicyProjection = newSecurityPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["security_policies"]))
 	NotificationPolicyProjection = newNotificationPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_policies"]))
 	DeviceAuthProjection = newDeviceAuthProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["device_auth"]))
+	GroupProjection = newGroupProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["groups"]))
+	UserConsentProjection = newUserConsentProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_consents"]))
+	SessionProjection = newSessionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sessions"]))
+	NotificationOutboxProjection = newNotificationOutboxProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_outbox"]))
 	newProjectionsList()
 	return nil
 }
@@ -206,6 +220,7 @@ func newProjectionsList() {
 		ProjectGrantProjection,
 		ProjectRoleProjection,
 		OrgDomainProjection,
+		OrgLoginDomainProjection,
 		LoginPolicyProjection,
 		IDPProjection,
 		IDPTemplateProjection,
@@ -213,6 +228,7 @@ func newProjectionsList() {
 		IDPUserLinkProjection,
 		IDPLoginPolicyLinkProjection,
 		MailTemplateProjection,
+		CustomMailTemplateProjection,
 		MessageTextProjection,
 		CustomTextProjection,
 		UserProjection,
@@ -233,9 +249,14 @@ func newProjectionsList() {
 		SMSConfigProjection,
 		OIDCSettingsProjection,
 		DebugNotificationProviderProjection,
+		NotificationWebhookProviderProjection,
 		KeyProjection,
 		SecurityPolicyProjection,
 		NotificationPolicyProjection,
 		DeviceAuthProjection,
+		GroupProjection,
+		UserConsentProjection,
+		SessionProjection,
+		NotificationOutboxProjection,
 	}
 }
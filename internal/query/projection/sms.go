@@ -12,8 +12,9 @@ import (
 )
 
 const (
-	SMSConfigProjectionTable = "projections.sms_configs2"
+	SMSConfigProjectionTable = "projections.sms_configs3"
 	SMSTwilioTable           = SMSConfigProjectionTable + "_" + smsTwilioTableSuffix
+	SMSHTTPTable             = SMSConfigProjectionTable + "_" + smsHTTPTableSuffix
 
 	SMSColumnID            = "id"
 	SMSColumnAggregateID   = "aggregate_id"
@@ -30,6 +31,18 @@ const (
 	SMSTwilioConfigColumnSID          = "sid"
 	SMSTwilioConfigColumnSenderNumber = "sender_number"
 	SMSTwilioConfigColumnToken        = "token"
+
+	smsHTTPTableSuffix              = "http"
+	SMSHTTPConfigColumnSMSID        = "sms_id"
+	SMSHTTPColumnInstanceID         = "instance_id"
+	SMSHTTPConfigColumnEndpoint     = "endpoint"
+	SMSHTTPConfigColumnMethod       = "method"
+	SMSHTTPConfigColumnHeaders      = "headers"
+	SMSHTTPConfigColumnBodyTemplate = "body_template"
+	SMSHTTPConfigColumnUsername     = "username"
+	SMSHTTPConfigColumnPassword     = "password"
+	SMSHTTPConfigColumnAPIKeyHeader = "api_key_header"
+	SMSHTTPConfigColumnAPIKey       = "api_key"
 )
 
 type smsConfigProjection struct {
@@ -64,6 +77,22 @@ func newSMSConfigProjection(ctx context.Context, config crdb.StatementHandlerCon
 			smsTwilioTableSuffix,
 			crdb.WithForeignKey(crdb.NewForeignKeyOfPublicKeys()),
 		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(SMSHTTPConfigColumnSMSID, crdb.ColumnTypeText),
+			crdb.NewColumn(SMSHTTPColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(SMSHTTPConfigColumnEndpoint, crdb.ColumnTypeText),
+			crdb.NewColumn(SMSHTTPConfigColumnMethod, crdb.ColumnTypeText),
+			crdb.NewColumn(SMSHTTPConfigColumnHeaders, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(SMSHTTPConfigColumnBodyTemplate, crdb.ColumnTypeText),
+			crdb.NewColumn(SMSHTTPConfigColumnUsername, crdb.ColumnTypeText),
+			crdb.NewColumn(SMSHTTPConfigColumnPassword, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(SMSHTTPConfigColumnAPIKeyHeader, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(SMSHTTPConfigColumnAPIKey, crdb.ColumnTypeJSONB, crdb.Nullable()),
+		},
+			crdb.NewPrimaryKey(SMSHTTPColumnInstanceID, SMSHTTPConfigColumnSMSID),
+			smsHTTPTableSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKeyOfPublicKeys()),
+		),
 	)
 	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
 	return p
@@ -86,6 +115,14 @@ func (p *smsConfigProjection) reducers() []handler.AggregateReducer {
 					Event:  instance.SMSConfigTwilioTokenChangedEventType,
 					Reduce: p.reduceSMSConfigTwilioTokenChanged,
 				},
+				{
+					Event:  instance.SMSConfigHTTPAddedEventType,
+					Reduce: p.reduceSMSConfigHTTPAdded,
+				},
+				{
+					Event:  instance.SMSConfigHTTPChangedEventType,
+					Reduce: p.reduceSMSConfigHTTPChanged,
+				},
 				{
 					Event:  instance.SMSConfigActivatedEventType,
 					Reduce: p.reduceSMSConfigActivated,
@@ -209,6 +246,98 @@ func (p *smsConfigProjection) reduceSMSConfigTwilioTokenChanged(event eventstore
 	), nil
 }
 
+func (p *smsConfigProjection) reduceSMSConfigHTTPAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SMSConfigHTTPAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Sfw5a", "reduce.wrong.event.type %s", instance.SMSConfigHTTPAddedEventType)
+	}
+
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSColumnID, e.ID),
+				handler.NewCol(SMSColumnAggregateID, e.Aggregate().ID),
+				handler.NewCol(SMSColumnCreationDate, e.CreationDate()),
+				handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMSColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCol(SMSColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSColumnState, domain.SMSConfigStateInactive),
+				handler.NewCol(SMSColumnSequence, e.Sequence()),
+			},
+		),
+		crdb.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSHTTPConfigColumnSMSID, e.ID),
+				handler.NewCol(SMSHTTPColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSHTTPConfigColumnEndpoint, e.Endpoint),
+				handler.NewCol(SMSHTTPConfigColumnMethod, e.Method),
+				handler.NewJSONCol(SMSHTTPConfigColumnHeaders, e.Headers),
+				handler.NewCol(SMSHTTPConfigColumnBodyTemplate, e.BodyTemplate),
+				handler.NewCol(SMSHTTPConfigColumnUsername, e.Username),
+				handler.NewCol(SMSHTTPConfigColumnPassword, e.Password),
+				handler.NewCol(SMSHTTPConfigColumnAPIKeyHeader, e.APIKeyHeader),
+				handler.NewCol(SMSHTTPConfigColumnAPIKey, e.APIKey),
+			},
+			crdb.WithTableSuffix(smsHTTPTableSuffix),
+		),
+	), nil
+}
+
+func (p *smsConfigProjection) reduceSMSConfigHTTPChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SMSConfigHTTPChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Sfw5b", "reduce.wrong.event.type %s", instance.SMSConfigHTTPChangedEventType)
+	}
+	columns := make([]handler.Column, 0, 8)
+	if e.Endpoint != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnEndpoint, *e.Endpoint))
+	}
+	if e.Method != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnMethod, *e.Method))
+	}
+	if e.Headers != nil {
+		columns = append(columns, handler.NewJSONCol(SMSHTTPConfigColumnHeaders, e.Headers))
+	}
+	if e.BodyTemplate != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnBodyTemplate, *e.BodyTemplate))
+	}
+	if e.Username != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnUsername, *e.Username))
+	}
+	if e.Password != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnPassword, e.Password))
+	}
+	if e.APIKeyHeader != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnAPIKeyHeader, *e.APIKeyHeader))
+	}
+	if e.APIKey != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnAPIKey, e.APIKey))
+	}
+
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddUpdateStatement(
+			columns,
+			[]handler.Condition{
+				handler.NewCond(SMSHTTPConfigColumnSMSID, e.ID),
+				handler.NewCond(SMSHTTPColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(smsHTTPTableSuffix),
+		),
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMSColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(SMSColumnID, e.ID),
+				handler.NewCond(SMSColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	), nil
+}
+
 func (p *smsConfigProjection) reduceSMSConfigActivated(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*instance.SMSConfigActivatedEvent)
 	if !ok {
@@ -3,28 +3,42 @@ package projection
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
 	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
 )
 
 const (
-	SMTPConfigProjectionTable = "projections.smtp_configs"
+	SMTPConfigProjectionTable = "projections.smtp_configs2"
 
 	SMTPConfigColumnAggregateID   = "aggregate_id"
+	SMTPConfigColumnID            = "id"
 	SMTPConfigColumnCreationDate  = "creation_date"
 	SMTPConfigColumnChangeDate    = "change_date"
 	SMTPConfigColumnSequence      = "sequence"
 	SMTPConfigColumnResourceOwner = "resource_owner"
 	SMTPConfigColumnInstanceID    = "instance_id"
+	SMTPConfigColumnOrgID         = "org_id"
+	SMTPConfigColumnDescription   = "description"
+	SMTPConfigColumnPriority      = "priority"
 	SMTPConfigColumnTLS           = "tls"
 	SMTPConfigColumnSenderAddress = "sender_address"
 	SMTPConfigColumnSenderName    = "sender_name"
 	SMTPConfigColumnSMTPHost      = "host"
 	SMTPConfigColumnSMTPUser      = "username"
 	SMTPConfigColumnSMTPPassword  = "password"
+	SMTPConfigColumnTLSMode       = "tls_mode"
+	SMTPConfigColumnAuthType      = "auth_type"
+	SMTPConfigColumnCACertificate = "ca_certificate"
+
+	SMTPConfigColumnXOAuth2TokenEndpoint = "xoauth2_token_endpoint"
+	SMTPConfigColumnXOAuth2ClientID      = "xoauth2_client_id"
+	SMTPConfigColumnXOAuth2ClientSecret  = "xoauth2_client_secret"
+	SMTPConfigColumnXOAuth2Scopes        = "xoauth2_scopes"
 )
 
 type smtpConfigProjection struct {
@@ -38,19 +52,30 @@ func newSMTPConfigProjection(ctx context.Context, config crdb.StatementHandlerCo
 	config.InitCheck = crdb.NewTableCheck(
 		crdb.NewTable([]*crdb.Column{
 			crdb.NewColumn(SMTPConfigColumnAggregateID, crdb.ColumnTypeText),
+			crdb.NewColumn(SMTPConfigColumnID, crdb.ColumnTypeText),
 			crdb.NewColumn(SMTPConfigColumnCreationDate, crdb.ColumnTypeTimestamp),
 			crdb.NewColumn(SMTPConfigColumnChangeDate, crdb.ColumnTypeTimestamp),
 			crdb.NewColumn(SMTPConfigColumnSequence, crdb.ColumnTypeInt64),
 			crdb.NewColumn(SMTPConfigColumnResourceOwner, crdb.ColumnTypeText),
 			crdb.NewColumn(SMTPConfigColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(SMTPConfigColumnOrgID, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(SMTPConfigColumnDescription, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(SMTPConfigColumnPriority, crdb.ColumnTypeInt64, crdb.Default(0)),
 			crdb.NewColumn(SMTPConfigColumnTLS, crdb.ColumnTypeBool),
 			crdb.NewColumn(SMTPConfigColumnSenderAddress, crdb.ColumnTypeText),
 			crdb.NewColumn(SMTPConfigColumnSenderName, crdb.ColumnTypeText),
 			crdb.NewColumn(SMTPConfigColumnSMTPHost, crdb.ColumnTypeText),
 			crdb.NewColumn(SMTPConfigColumnSMTPUser, crdb.ColumnTypeText),
 			crdb.NewColumn(SMTPConfigColumnSMTPPassword, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(SMTPConfigColumnTLSMode, crdb.ColumnTypeEnum, crdb.Default(0)),
+			crdb.NewColumn(SMTPConfigColumnAuthType, crdb.ColumnTypeEnum, crdb.Default(0)),
+			crdb.NewColumn(SMTPConfigColumnXOAuth2TokenEndpoint, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(SMTPConfigColumnXOAuth2ClientID, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(SMTPConfigColumnXOAuth2ClientSecret, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(SMTPConfigColumnXOAuth2Scopes, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(SMTPConfigColumnCACertificate, crdb.ColumnTypeJSONB, crdb.Nullable()),
 		},
-			crdb.NewPrimaryKey(SMTPConfigColumnInstanceID, SMTPConfigColumnAggregateID),
+			crdb.NewPrimaryKey(SMTPConfigColumnInstanceID, SMTPConfigColumnID),
 		),
 	)
 	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
@@ -74,12 +99,29 @@ func (p *smtpConfigProjection) reducers() []handler.AggregateReducer {
 					Event:  instance.SMTPConfigPasswordChangedEventType,
 					Reduce: p.reduceSMTPConfigPasswordChanged,
 				},
+				{
+					Event:  instance.SMTPConfigCACertificateChangedEventType,
+					Reduce: p.reduceSMTPConfigCACertificateChanged,
+				},
+				{
+					Event:  instance.SMTPConfigRemovedEventType,
+					Reduce: p.reduceSMTPConfigRemoved,
+				},
 				{
 					Event:  instance.InstanceRemovedEventType,
 					Reduce: reduceInstanceRemovedHelper(SMTPConfigColumnInstanceID),
 				},
 			},
 		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
 	}
 }
 
@@ -88,22 +130,31 @@ func (p *smtpConfigProjection) reduceSMTPConfigAdded(event eventstore.Event) (*h
 	if !ok {
 		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-sk99F", "reduce.wrong.event.type %s", instance.SMTPConfigAddedEventType)
 	}
+	columns := []handler.Column{
+		handler.NewCol(SMTPConfigColumnAggregateID, e.Aggregate().ID),
+		handler.NewCol(SMTPConfigColumnID, e.ID),
+		handler.NewCol(SMTPConfigColumnCreationDate, e.CreationDate()),
+		handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+		handler.NewCol(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
+		handler.NewCol(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+		handler.NewCol(SMTPConfigColumnOrgID, e.OrgID),
+		handler.NewCol(SMTPConfigColumnDescription, e.Description),
+		handler.NewCol(SMTPConfigColumnPriority, e.Priority),
+		handler.NewCol(SMTPConfigColumnTLS, e.TLS),
+		handler.NewCol(SMTPConfigColumnSenderAddress, e.SenderAddress),
+		handler.NewCol(SMTPConfigColumnSenderName, e.SenderName),
+		handler.NewCol(SMTPConfigColumnSMTPHost, e.Host),
+		handler.NewCol(SMTPConfigColumnSMTPUser, e.User),
+		handler.NewCol(SMTPConfigColumnSMTPPassword, e.Password),
+		handler.NewCol(SMTPConfigColumnTLSMode, e.TLSMode),
+		handler.NewCol(SMTPConfigColumnAuthType, e.AuthType),
+		handler.NewCol(SMTPConfigColumnCACertificate, e.CACertificate),
+	}
+	columns = append(columns, smtpConfigXOAuth2Columns(e.XOAuth2)...)
 	return crdb.NewCreateStatement(
 		e,
-		[]handler.Column{
-			handler.NewCol(SMTPConfigColumnAggregateID, e.Aggregate().ID),
-			handler.NewCol(SMTPConfigColumnCreationDate, e.CreationDate()),
-			handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
-			handler.NewCol(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
-			handler.NewCol(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
-			handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
-			handler.NewCol(SMTPConfigColumnTLS, e.TLS),
-			handler.NewCol(SMTPConfigColumnSenderAddress, e.SenderAddress),
-			handler.NewCol(SMTPConfigColumnSenderName, e.SenderName),
-			handler.NewCol(SMTPConfigColumnSMTPHost, e.Host),
-			handler.NewCol(SMTPConfigColumnSMTPUser, e.User),
-			handler.NewCol(SMTPConfigColumnSMTPPassword, e.Password),
-		},
+		columns,
 	), nil
 }
 
@@ -113,9 +164,15 @@ func (p *smtpConfigProjection) reduceSMTPConfigChanged(event eventstore.Event) (
 		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-wl0wd", "reduce.wrong.event.type %s", instance.SMTPConfigChangedEventType)
 	}
 
-	columns := make([]handler.Column, 0, 7)
+	columns := make([]handler.Column, 0, 15)
 	columns = append(columns, handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
 		handler.NewCol(SMTPConfigColumnSequence, e.Sequence()))
+	if e.Description != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnDescription, *e.Description))
+	}
+	if e.Priority != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnPriority, *e.Priority))
+	}
 	if e.TLS != nil {
 		columns = append(columns, handler.NewCol(SMTPConfigColumnTLS, *e.TLS))
 	}
@@ -131,11 +188,20 @@ func (p *smtpConfigProjection) reduceSMTPConfigChanged(event eventstore.Event) (
 	if e.User != nil {
 		columns = append(columns, handler.NewCol(SMTPConfigColumnSMTPUser, *e.User))
 	}
+	if e.TLSMode != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnTLSMode, *e.TLSMode))
+	}
+	if e.AuthType != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnAuthType, *e.AuthType))
+	}
+	if e.XOAuth2 != nil {
+		columns = append(columns, smtpConfigXOAuth2Columns(e.XOAuth2)...)
+	}
 	return crdb.NewUpdateStatement(
 		e,
 		columns,
 		[]handler.Condition{
-			handler.NewCond(SMTPConfigColumnAggregateID, e.Aggregate().ID),
+			handler.NewCond(SMTPConfigColumnID, e.ID),
 			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
 		},
 	), nil
@@ -155,8 +221,68 @@ func (p *smtpConfigProjection) reduceSMTPConfigPasswordChanged(event eventstore.
 			handler.NewCol(SMTPConfigColumnSMTPPassword, e.Password),
 		},
 		[]handler.Condition{
-			handler.NewCond(SMTPConfigColumnAggregateID, e.Aggregate().ID),
+			handler.NewCond(SMTPConfigColumnID, e.ID),
+			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceSMTPConfigCACertificateChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SMTPConfigCACertificateChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Sfw8c", "reduce.wrong.event.type %s", instance.SMTPConfigCACertificateChangedEventType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+			handler.NewCol(SMTPConfigColumnCACertificate, e.CACertificate),
+		},
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnID, e.ID),
+			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceSMTPConfigRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SMTPConfigRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Sfw8a", "reduce.wrong.event.type %s", instance.SMTPConfigRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnID, e.ID),
+			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Sfw8b", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnOrgID, e.Aggregate().ID),
 			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
 		},
 	), nil
 }
+
+func smtpConfigXOAuth2Columns(xoauth2 *instance.SMTPXOAuth2) []handler.Column {
+	if xoauth2 == nil {
+		xoauth2 = new(instance.SMTPXOAuth2)
+	}
+	return []handler.Column{
+		handler.NewCol(SMTPConfigColumnXOAuth2TokenEndpoint, xoauth2.TokenEndpoint),
+		handler.NewCol(SMTPConfigColumnXOAuth2ClientID, xoauth2.ClientID),
+		handler.NewCol(SMTPConfigColumnXOAuth2ClientSecret, xoauth2.ClientSecret),
+		handler.NewCol(SMTPConfigColumnXOAuth2Scopes, database.StringArray(xoauth2.Scopes)),
+	}
+}
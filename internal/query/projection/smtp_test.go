@@ -3,11 +3,14 @@ package projection
 import (
 	"testing"
 
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
 	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
 )
 
 func TestSMTPConfigProjection_reduces(t *testing.T) {
@@ -44,7 +47,7 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.smtp_configs SET (change_date, sequence, tls, sender_address, sender_name, host, username) = ($1, $2, $3, $4, $5, $6, $7) WHERE (aggregate_id = $8) AND (instance_id = $9)",
+							expectedStmt: "UPDATE projections.smtp_configs2 SET (change_date, sequence, tls, sender_address, sender_name, host, username) = ($1, $2, $3, $4, $5, $6, $7) WHERE (id = $8) AND (instance_id = $9)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -89,20 +92,31 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.smtp_configs (aggregate_id, creation_date, change_date, resource_owner, instance_id, sequence, tls, sender_address, sender_name, host, username, password) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+							expectedStmt: "INSERT INTO projections.smtp_configs2 (aggregate_id, id, creation_date, change_date, resource_owner, instance_id, sequence, org_id, description, priority, tls, sender_address, sender_name, host, username, password, tls_mode, auth_type, ca_certificate, xoauth2_token_endpoint, xoauth2_client_id, xoauth2_client_secret, xoauth2_scopes) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)",
 							expectedArgs: []interface{}{
+								"agg-id",
 								"agg-id",
 								anyArg{},
 								anyArg{},
 								"ro-id",
 								"instance-id",
 								uint64(15),
+								"",
+								"",
+								uint32(0),
 								true,
 								"sender",
 								"name",
 								"host",
 								"user",
 								anyArg{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								anyArg{},
+								"",
+								"",
+								anyArg{},
+								database.StringArray(nil),
 							},
 						},
 					},
@@ -132,11 +146,318 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.smtp_configs SET (change_date, sequence, password) = ($1, $2, $3) WHERE (aggregate_id = $4) AND (instance_id = $5)",
+							expectedStmt: "UPDATE projections.smtp_configs2 SET (change_date, sequence, password) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSMTPConfigChanged with id",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.SMTPConfigChangedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "config-id",
+						"description": "description",
+						"priority": 1
+					}`,
+					),
+				), instance.SMTPConfigChangedEventMapper),
+			},
+			reduce: (&smtpConfigProjection{}).reduceSMTPConfigChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.smtp_configs2 SET (change_date, sequence, description, priority) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"description",
+								uint32(1),
+								"config-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSMTPConfigAdded org config",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.SMTPConfigAddedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "config-id",
+						"orgID": "org-id",
+						"description": "description",
+						"priority": 1,
+						"tls": true,
+						"senderAddress": "sender",
+						"senderName": "name",
+						"host": "host",
+						"user": "user"
+					}`),
+				), instance.SMTPConfigAddedEventMapper),
+			},
+			reduce: (&smtpConfigProjection{}).reduceSMTPConfigAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.smtp_configs2 (aggregate_id, id, creation_date, change_date, resource_owner, instance_id, sequence, org_id, description, priority, tls, sender_address, sender_name, host, username, password, tls_mode, auth_type, ca_certificate, xoauth2_token_endpoint, xoauth2_client_id, xoauth2_client_secret, xoauth2_scopes) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"config-id",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								uint64(15),
+								"org-id",
+								"description",
+								uint32(1),
+								true,
+								"sender",
+								"name",
+								"host",
+								"user",
+								anyArg{},
+								domain.SMTPTLSModeUnspecified,
+								domain.SMTPAuthTypeUnspecified,
+								anyArg{},
+								"",
+								"",
+								anyArg{},
+								database.StringArray(nil),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSMTPConfigAdded xoauth2",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.SMTPConfigAddedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "config-id",
+						"senderAddress": "sender",
+						"senderName": "name",
+						"host": "host",
+						"user": "user",
+						"tlsMode": 2,
+						"authType": 1,
+						"xoauth2": {
+							"tokenEndpoint": "https://login.domain.ch/oauth2/token",
+							"clientId": "client-id",
+							"clientSecret": {
+								"cryptoType": 0,
+								"algorithm": "RSA-265",
+								"keyId": "key-id"
+							},
+							"scopes": ["scope"]
+						},
+						"caCertificate": {
+							"cryptoType": 0,
+							"algorithm": "RSA-265",
+							"keyId": "key-id"
+						}
+					}`),
+				), instance.SMTPConfigAddedEventMapper),
+			},
+			reduce: (&smtpConfigProjection{}).reduceSMTPConfigAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.smtp_configs2 (aggregate_id, id, creation_date, change_date, resource_owner, instance_id, sequence, org_id, description, priority, tls, sender_address, sender_name, host, username, password, tls_mode, auth_type, ca_certificate, xoauth2_token_endpoint, xoauth2_client_id, xoauth2_client_secret, xoauth2_scopes) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"config-id",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								uint64(15),
+								"",
+								"",
+								uint32(0),
+								false,
+								"sender",
+								"name",
+								"host",
+								"user",
+								anyArg{},
+								domain.SMTPTLSModeStartTLSRequired,
+								domain.SMTPAuthTypeXOAuth2,
+								anyArg{},
+								"https://login.domain.ch/oauth2/token",
+								"client-id",
+								anyArg{},
+								database.StringArray{"scope"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSMTPConfigChanged xoauth2",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.SMTPConfigChangedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "config-id",
+						"tlsMode": 1,
+						"authType": 1,
+						"xoauth2": {
+							"tokenEndpoint": "https://login.domain.ch/oauth2/token",
+							"clientId": "client-id",
+							"clientSecret": {
+								"cryptoType": 0,
+								"algorithm": "RSA-265",
+								"keyId": "key-id"
+							}
+						}
+					}`,
+					),
+				), instance.SMTPConfigChangedEventMapper),
+			},
+			reduce: (&smtpConfigProjection{}).reduceSMTPConfigChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.smtp_configs2 SET (change_date, sequence, tls_mode, auth_type, xoauth2_token_endpoint, xoauth2_client_id, xoauth2_client_secret, xoauth2_scopes) = ($1, $2, $3, $4, $5, $6, $7, $8) WHERE (id = $9) AND (instance_id = $10)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
+								domain.SMTPTLSModeImplicit,
+								domain.SMTPAuthTypeXOAuth2,
+								"https://login.domain.ch/oauth2/token",
+								"client-id",
 								anyArg{},
+								database.StringArray(nil),
+								"config-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSMTPConfigCACertificateChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.SMTPConfigCACertificateChangedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "config-id",
+						"caCertificate": {
+							"cryptoType": 0,
+							"algorithm": "RSA-265",
+							"keyId": "key-id"
+						}
+					}`),
+				), instance.SMTPConfigCACertificateChangedEventMapper),
+			},
+			reduce: (&smtpConfigProjection{}).reduceSMTPConfigCACertificateChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.smtp_configs2 SET (change_date, sequence, ca_certificate) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								"config-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSMTPConfigRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.SMTPConfigRemovedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "config-id"
+					}`),
+				), instance.SMTPConfigRemovedEventMapper),
+			},
+			reduce: (&smtpConfigProjection{}).reduceSMTPConfigRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.smtp_configs2 WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"config-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&smtpConfigProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.smtp_configs2 WHERE (org_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
 							},
@@ -162,7 +483,7 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.smtp_configs WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.smtp_configs2 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
@@ -418,6 +418,46 @@ func (q *or) comp() sq.Sqlizer {
 	return sq.Or(queries)
 }
 
+type and struct {
+	queries []SearchQuery
+}
+
+func And(queries ...SearchQuery) *and {
+	return &and{
+		queries: queries,
+	}
+}
+
+func (q *and) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+func (q *and) comp() sq.Sqlizer {
+	queries := make([]sq.Sqlizer, 0)
+	for _, query := range q.queries {
+		queries = append(queries, query.comp())
+	}
+	return sq.And(queries)
+}
+
+type not struct {
+	query SearchQuery
+}
+
+func Not(query SearchQuery) *not {
+	return &not{
+		query: query,
+	}
+}
+
+func (q *not) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+func (q *not) comp() sq.Sqlizer {
+	return sq.Expr("NOT (?)", q.query.comp())
+}
+
 type BoolQuery struct {
 	Column Column
 	Value  bool
@@ -1382,3 +1382,59 @@ func TestNumberComparisonFromMethod(t *testing.T) {
 		})
 	}
 }
+
+func TestCombinedQueries_comp(t *testing.T) {
+	textQuery, err := NewTextQuery(testCol, "Hurst", TextEquals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	numberQuery, err := NewNumberQuery(testCol2, 42, NumberEquals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		query    SearchQuery
+		wantStmt string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "or",
+			query:    Or(textQuery, numberQuery),
+			wantStmt: "(test_table.test_col = ? OR test_table2.test_col2 = ?)",
+			wantArgs: []interface{}{"Hurst", 42},
+		},
+		{
+			name:     "and",
+			query:    And(textQuery, numberQuery),
+			wantStmt: "(test_table.test_col = ? AND test_table2.test_col2 = ?)",
+			wantArgs: []interface{}{"Hurst", 42},
+		},
+		{
+			name:     "not",
+			query:    Not(textQuery),
+			wantStmt: "NOT (test_table.test_col = ?)",
+			wantArgs: []interface{}{"Hurst"},
+		},
+		{
+			name:     "not or",
+			query:    Not(Or(textQuery, numberQuery)),
+			wantStmt: "NOT ((test_table.test_col = ? OR test_table2.test_col2 = ?))",
+			wantArgs: []interface{}{"Hurst", 42},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, args, err := tt.query.comp().ToSql()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if stmt != tt.wantStmt {
+				t.Errorf("wrong stmt: want %q, got %q", tt.wantStmt, stmt)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("wrong args: want %v, got %v", tt.wantArgs, args)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package query
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	errs "errors"
 	"time"
 
@@ -32,6 +33,7 @@ type SMSConfig struct {
 	Sequence      uint64
 
 	TwilioConfig *Twilio
+	HTTPConfig   *HTTP
 }
 
 type Twilio struct {
@@ -40,6 +42,17 @@ type Twilio struct {
 	SenderNumber string
 }
 
+type HTTP struct {
+	Endpoint     string
+	Method       string
+	Headers      map[string]string
+	BodyTemplate string
+	Username     string
+	Password     *crypto.CryptoValue
+	APIKeyHeader string
+	APIKey       *crypto.CryptoValue
+}
+
 type SMSConfigsSearchQueries struct {
 	SearchRequest
 	Queries []SearchQuery
@@ -115,6 +128,49 @@ var (
 	}
 )
 
+var (
+	smsHTTPConfigsTable = table{
+		name:          projection.SMSHTTPTable,
+		instanceIDCol: projection.SMSHTTPColumnInstanceID,
+	}
+	SMSHTTPConfigColumnSMSID = Column{
+		name:  projection.SMSHTTPConfigColumnSMSID,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnEndpoint = Column{
+		name:  projection.SMSHTTPConfigColumnEndpoint,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnMethod = Column{
+		name:  projection.SMSHTTPConfigColumnMethod,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnHeaders = Column{
+		name:  projection.SMSHTTPConfigColumnHeaders,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnBodyTemplate = Column{
+		name:  projection.SMSHTTPConfigColumnBodyTemplate,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnUsername = Column{
+		name:  projection.SMSHTTPConfigColumnUsername,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnPassword = Column{
+		name:  projection.SMSHTTPConfigColumnPassword,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnAPIKeyHeader = Column{
+		name:  projection.SMSHTTPConfigColumnAPIKeyHeader,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnAPIKey = Column{
+		name:  projection.SMSHTTPConfigColumnAPIKey,
+		table: smsHTTPConfigsTable,
+	}
+)
+
 func (q *Queries) SMSProviderConfigByID(ctx context.Context, id string) (_ *SMSConfig, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -198,13 +254,25 @@ func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 			SMSTwilioConfigColumnSID.identifier(),
 			SMSTwilioConfigColumnToken.identifier(),
 			SMSTwilioConfigColumnSenderNumber.identifier(),
+
+			SMSHTTPConfigColumnSMSID.identifier(),
+			SMSHTTPConfigColumnEndpoint.identifier(),
+			SMSHTTPConfigColumnMethod.identifier(),
+			SMSHTTPConfigColumnHeaders.identifier(),
+			SMSHTTPConfigColumnBodyTemplate.identifier(),
+			SMSHTTPConfigColumnUsername.identifier(),
+			SMSHTTPConfigColumnPassword.identifier(),
+			SMSHTTPConfigColumnAPIKeyHeader.identifier(),
+			SMSHTTPConfigColumnAPIKey.identifier(),
 		).From(smsConfigsTable.identifier()).
-			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
+			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID)).
+			LeftJoin(join(SMSHTTPConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*SMSConfig, error) {
 			config := new(SMSConfig)
 
 			var (
 				twilioConfig = sqlTwilioConfig{}
+				httpConfig   = sqlHTTPConfig{}
 			)
 
 			err := row.Scan(
@@ -220,6 +288,16 @@ func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 				&twilioConfig.sid,
 				&twilioConfig.token,
 				&twilioConfig.senderNumber,
+
+				&httpConfig.smsID,
+				&httpConfig.endpoint,
+				&httpConfig.method,
+				&httpConfig.headers,
+				&httpConfig.bodyTemplate,
+				&httpConfig.username,
+				&httpConfig.password,
+				&httpConfig.apiKeyHeader,
+				&httpConfig.apiKey,
 			)
 
 			if err != nil {
@@ -230,6 +308,9 @@ func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 			}
 
 			twilioConfig.set(config)
+			if err = httpConfig.set(config); err != nil {
+				return nil, err
+			}
 
 			return config, nil
 		}
@@ -249,9 +330,20 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			SMSTwilioConfigColumnSID.identifier(),
 			SMSTwilioConfigColumnToken.identifier(),
 			SMSTwilioConfigColumnSenderNumber.identifier(),
+
+			SMSHTTPConfigColumnSMSID.identifier(),
+			SMSHTTPConfigColumnEndpoint.identifier(),
+			SMSHTTPConfigColumnMethod.identifier(),
+			SMSHTTPConfigColumnHeaders.identifier(),
+			SMSHTTPConfigColumnBodyTemplate.identifier(),
+			SMSHTTPConfigColumnUsername.identifier(),
+			SMSHTTPConfigColumnPassword.identifier(),
+			SMSHTTPConfigColumnAPIKeyHeader.identifier(),
+			SMSHTTPConfigColumnAPIKey.identifier(),
 			countColumn.identifier(),
 		).From(smsConfigsTable.identifier()).
-			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
+			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID)).
+			LeftJoin(join(SMSHTTPConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Rows) (*SMSConfigs, error) {
 			configs := &SMSConfigs{Configs: []*SMSConfig{}}
 
@@ -259,6 +351,7 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				config := new(SMSConfig)
 				var (
 					twilioConfig = sqlTwilioConfig{}
+					httpConfig   = sqlHTTPConfig{}
 				)
 
 				err := row.Scan(
@@ -274,6 +367,16 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 					&twilioConfig.sid,
 					&twilioConfig.token,
 					&twilioConfig.senderNumber,
+
+					&httpConfig.smsID,
+					&httpConfig.endpoint,
+					&httpConfig.method,
+					&httpConfig.headers,
+					&httpConfig.bodyTemplate,
+					&httpConfig.username,
+					&httpConfig.password,
+					&httpConfig.apiKeyHeader,
+					&httpConfig.apiKey,
 					&configs.Count,
 				)
 
@@ -282,6 +385,9 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				}
 
 				twilioConfig.set(config)
+				if err = httpConfig.set(config); err != nil {
+					return nil, err
+				}
 
 				configs.Configs = append(configs.Configs, config)
 			}
@@ -307,3 +413,37 @@ func (c sqlTwilioConfig) set(smsConfig *SMSConfig) {
 		SenderNumber: c.senderNumber.String,
 	}
 }
+
+type sqlHTTPConfig struct {
+	smsID        sql.NullString
+	endpoint     sql.NullString
+	method       sql.NullString
+	headers      []byte
+	bodyTemplate sql.NullString
+	username     sql.NullString
+	password     *crypto.CryptoValue
+	apiKeyHeader sql.NullString
+	apiKey       *crypto.CryptoValue
+}
+
+func (c sqlHTTPConfig) set(smsConfig *SMSConfig) error {
+	if !c.smsID.Valid {
+		return nil
+	}
+	smsConfig.HTTPConfig = &HTTP{
+		Endpoint:     c.endpoint.String,
+		Method:       c.method.String,
+		BodyTemplate: c.bodyTemplate.String,
+		Username:     c.username.String,
+		Password:     c.password,
+		APIKeyHeader: c.apiKeyHeader.String,
+		APIKey:       c.apiKey,
+	}
+	if len(c.headers) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(c.headers, &smsConfig.HTTPConfig.Headers); err != nil {
+		return errors.ThrowInternal(err, "QUERY-Sfw6a", "Errors.Internal")
+	}
+	return nil
+}
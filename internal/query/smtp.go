@@ -11,6 +11,8 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -25,6 +27,10 @@ var (
 		name:  projection.SMTPConfigColumnAggregateID,
 		table: smtpConfigsTable,
 	}
+	SMTPConfigColumnID = Column{
+		name:  projection.SMTPConfigColumnID,
+		table: smtpConfigsTable,
+	}
 	SMTPConfigColumnCreationDate = Column{
 		name:  projection.SMTPConfigColumnCreationDate,
 		table: smtpConfigsTable,
@@ -45,6 +51,18 @@ var (
 		name:  projection.SMTPConfigColumnSequence,
 		table: smtpConfigsTable,
 	}
+	SMTPConfigColumnOrgID = Column{
+		name:  projection.SMTPConfigColumnOrgID,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnDescription = Column{
+		name:  projection.SMTPConfigColumnDescription,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnPriority = Column{
+		name:  projection.SMTPConfigColumnPriority,
+		table: smtpConfigsTable,
+	}
 	SMTPConfigColumnTLS = Column{
 		name:  projection.SMTPConfigColumnTLS,
 		table: smtpConfigsTable,
@@ -69,36 +87,89 @@ var (
 		name:  projection.SMTPConfigColumnSMTPPassword,
 		table: smtpConfigsTable,
 	}
+	SMTPConfigColumnTLSMode = Column{
+		name:  projection.SMTPConfigColumnTLSMode,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnAuthType = Column{
+		name:  projection.SMTPConfigColumnAuthType,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnXOAuth2TokenEndpoint = Column{
+		name:  projection.SMTPConfigColumnXOAuth2TokenEndpoint,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnXOAuth2ClientID = Column{
+		name:  projection.SMTPConfigColumnXOAuth2ClientID,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnXOAuth2ClientSecret = Column{
+		name:  projection.SMTPConfigColumnXOAuth2ClientSecret,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnXOAuth2Scopes = Column{
+		name:  projection.SMTPConfigColumnXOAuth2Scopes,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnCACertificate = Column{
+		name:  projection.SMTPConfigColumnCACertificate,
+		table: smtpConfigsTable,
+	}
 )
 
 type SMTPConfigs struct {
 	SearchResponse
-	SMTPConfigs []*SMTPConfig
+	Configs []*SMTPConfig
 }
 
 type SMTPConfig struct {
 	AggregateID   string
+	ID            string
 	CreationDate  time.Time
 	ChangeDate    time.Time
 	ResourceOwner string
 	Sequence      uint64
 
+	OrgID         string
+	Description   string
+	Priority      uint32
 	TLS           bool
 	SenderAddress string
 	SenderName    string
 	Host          string
 	User          string
 	Password      *crypto.CryptoValue
+	TLSMode       domain.SMTPTLSMode
+	AuthType      domain.SMTPAuthType
+	CACertificate *crypto.CryptoValue
+
+	XOAuth2TokenEndpoint string
+	XOAuth2ClientID      string
+	XOAuth2ClientSecret  *crypto.CryptoValue
+	XOAuth2Scopes        database.StringArray
+}
+
+type SMTPConfigsSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *SMTPConfigsSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
 }
 
-func (q *Queries) SMTPConfigByAggregateID(ctx context.Context, aggregateID string) (_ *SMTPConfig, err error) {
+func (q *Queries) SMTPConfigByID(ctx context.Context, id string) (_ *SMTPConfig, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
 	stmt, scan := prepareSMTPConfigQuery(ctx, q.client)
 	query, args, err := stmt.Where(sq.Eq{
-		SMTPConfigColumnAggregateID.identifier(): aggregateID,
-		SMTPConfigColumnInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+		SMTPConfigColumnID.identifier():         id,
+		SMTPConfigColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
 	}).ToSql()
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "QUERY-3m9sl", "Errors.Query.SQLStatment")
@@ -108,37 +179,97 @@ func (q *Queries) SMTPConfigByAggregateID(ctx context.Context, aggregateID strin
 	return scan(row)
 }
 
-func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*SMTPConfig, error)) {
-	password := new(crypto.CryptoValue)
+// SearchSMTPConfigs returns the smtp configs of the instance
+// ordered by priority and creation date if no other sorting is requested
+func (q *Queries) SearchSMTPConfigs(ctx context.Context, queries *SMTPConfigsSearchQueries) (_ *SMTPConfigs, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
 
+	query, scan := prepareSMTPConfigsQuery(ctx, q.client)
+	query = queries.toQuery(query)
+	if queries.SortingColumn.isZero() {
+		query = query.OrderBy(SMTPConfigColumnPriority.identifier(), SMTPConfigColumnCreationDate.identifier())
+	}
+	stmt, args, err := query.
+		Where(sq.Eq{
+			SMTPConfigColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Sfw9a", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Sfw9b", "Errors.Internal")
+	}
+	configs, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	configs.LatestSequence, err = q.latestSequence(ctx, smtpConfigsTable)
+	return configs, err
+}
+
+// NewSMTPConfigOrgIDSearchQuery filters the configs of an organization,
+// an empty orgID returns the configs of the instance
+func NewSMTPConfigOrgIDSearchQuery(orgID string) (SearchQuery, error) {
+	return NewTextQuery(SMTPConfigColumnOrgID, orgID, TextEquals)
+}
+
+func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*SMTPConfig, error)) {
 	return sq.Select(
 			SMTPConfigColumnAggregateID.identifier(),
+			SMTPConfigColumnID.identifier(),
 			SMTPConfigColumnCreationDate.identifier(),
 			SMTPConfigColumnChangeDate.identifier(),
 			SMTPConfigColumnResourceOwner.identifier(),
 			SMTPConfigColumnSequence.identifier(),
+			SMTPConfigColumnOrgID.identifier(),
+			SMTPConfigColumnDescription.identifier(),
+			SMTPConfigColumnPriority.identifier(),
 			SMTPConfigColumnTLS.identifier(),
 			SMTPConfigColumnSenderAddress.identifier(),
 			SMTPConfigColumnSenderName.identifier(),
 			SMTPConfigColumnSMTPHost.identifier(),
 			SMTPConfigColumnSMTPUser.identifier(),
-			SMTPConfigColumnSMTPPassword.identifier()).
+			SMTPConfigColumnSMTPPassword.identifier(),
+			SMTPConfigColumnTLSMode.identifier(),
+			SMTPConfigColumnAuthType.identifier(),
+			SMTPConfigColumnXOAuth2TokenEndpoint.identifier(),
+			SMTPConfigColumnXOAuth2ClientID.identifier(),
+			SMTPConfigColumnXOAuth2ClientSecret.identifier(),
+			SMTPConfigColumnXOAuth2Scopes.identifier(),
+			SMTPConfigColumnCACertificate.identifier()).
 			From(smtpConfigsTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*SMTPConfig, error) {
 			config := new(SMTPConfig)
+			password := new(crypto.CryptoValue)
+			clientSecret := new(crypto.CryptoValue)
+			caCertificate := new(crypto.CryptoValue)
 			err := row.Scan(
 				&config.AggregateID,
+				&config.ID,
 				&config.CreationDate,
 				&config.ChangeDate,
 				&config.ResourceOwner,
 				&config.Sequence,
+				&config.OrgID,
+				&config.Description,
+				&config.Priority,
 				&config.TLS,
 				&config.SenderAddress,
 				&config.SenderName,
 				&config.Host,
 				&config.User,
 				&password,
+				&config.TLSMode,
+				&config.AuthType,
+				&config.XOAuth2TokenEndpoint,
+				&config.XOAuth2ClientID,
+				&clientSecret,
+				&config.XOAuth2Scopes,
+				&caCertificate,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
@@ -147,6 +278,82 @@ func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				return nil, errors.ThrowInternal(err, "QUERY-9k87F", "Errors.Internal")
 			}
 			config.Password = password
+			config.XOAuth2ClientSecret = clientSecret
+			config.CACertificate = caCertificate
 			return config, nil
 		}
 }
+
+func prepareSMTPConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*SMTPConfigs, error)) {
+	return sq.Select(
+			SMTPConfigColumnAggregateID.identifier(),
+			SMTPConfigColumnID.identifier(),
+			SMTPConfigColumnCreationDate.identifier(),
+			SMTPConfigColumnChangeDate.identifier(),
+			SMTPConfigColumnResourceOwner.identifier(),
+			SMTPConfigColumnSequence.identifier(),
+			SMTPConfigColumnOrgID.identifier(),
+			SMTPConfigColumnDescription.identifier(),
+			SMTPConfigColumnPriority.identifier(),
+			SMTPConfigColumnTLS.identifier(),
+			SMTPConfigColumnSenderAddress.identifier(),
+			SMTPConfigColumnSenderName.identifier(),
+			SMTPConfigColumnSMTPHost.identifier(),
+			SMTPConfigColumnSMTPUser.identifier(),
+			SMTPConfigColumnSMTPPassword.identifier(),
+			SMTPConfigColumnTLSMode.identifier(),
+			SMTPConfigColumnAuthType.identifier(),
+			SMTPConfigColumnXOAuth2TokenEndpoint.identifier(),
+			SMTPConfigColumnXOAuth2ClientID.identifier(),
+			SMTPConfigColumnXOAuth2ClientSecret.identifier(),
+			SMTPConfigColumnXOAuth2Scopes.identifier(),
+			SMTPConfigColumnCACertificate.identifier(),
+			countColumn.identifier()).
+			From(smtpConfigsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*SMTPConfigs, error) {
+			configs := &SMTPConfigs{Configs: []*SMTPConfig{}}
+			for rows.Next() {
+				config := new(SMTPConfig)
+				password := new(crypto.CryptoValue)
+				clientSecret := new(crypto.CryptoValue)
+				caCertificate := new(crypto.CryptoValue)
+				err := rows.Scan(
+					&config.AggregateID,
+					&config.ID,
+					&config.CreationDate,
+					&config.ChangeDate,
+					&config.ResourceOwner,
+					&config.Sequence,
+					&config.OrgID,
+					&config.Description,
+					&config.Priority,
+					&config.TLS,
+					&config.SenderAddress,
+					&config.SenderName,
+					&config.Host,
+					&config.User,
+					&password,
+					&config.TLSMode,
+					&config.AuthType,
+					&config.XOAuth2TokenEndpoint,
+					&config.XOAuth2ClientID,
+					&clientSecret,
+					&config.XOAuth2Scopes,
+					&caCertificate,
+					&configs.Count,
+				)
+				if err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Sfw9c", "Errors.Internal")
+				}
+				config.Password = password
+				config.XOAuth2ClientSecret = clientSecret
+				config.CACertificate = caCertificate
+				configs.Configs = append(configs.Configs, config)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Sfw9d", "Errors.Query.CloseRows")
+			}
+			return configs, nil
+		}
+}
@@ -9,36 +9,86 @@ import (
 	"testing"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	errs "github.com/zitadel/zitadel/internal/errors"
 )
 
 var (
-	prepareSMTPConfigStmt = `SELECT projections.smtp_configs.aggregate_id,` +
-		` projections.smtp_configs.creation_date,` +
-		` projections.smtp_configs.change_date,` +
-		` projections.smtp_configs.resource_owner,` +
-		` projections.smtp_configs.sequence,` +
-		` projections.smtp_configs.tls,` +
-		` projections.smtp_configs.sender_address,` +
-		` projections.smtp_configs.sender_name,` +
-		` projections.smtp_configs.host,` +
-		` projections.smtp_configs.username,` +
-		` projections.smtp_configs.password` +
-		` FROM projections.smtp_configs` +
+	prepareSMTPConfigStmt = `SELECT projections.smtp_configs2.aggregate_id,` +
+		` projections.smtp_configs2.id,` +
+		` projections.smtp_configs2.creation_date,` +
+		` projections.smtp_configs2.change_date,` +
+		` projections.smtp_configs2.resource_owner,` +
+		` projections.smtp_configs2.sequence,` +
+		` projections.smtp_configs2.org_id,` +
+		` projections.smtp_configs2.description,` +
+		` projections.smtp_configs2.priority,` +
+		` projections.smtp_configs2.tls,` +
+		` projections.smtp_configs2.sender_address,` +
+		` projections.smtp_configs2.sender_name,` +
+		` projections.smtp_configs2.host,` +
+		` projections.smtp_configs2.username,` +
+		` projections.smtp_configs2.password,` +
+		` projections.smtp_configs2.tls_mode,` +
+		` projections.smtp_configs2.auth_type,` +
+		` projections.smtp_configs2.xoauth2_token_endpoint,` +
+		` projections.smtp_configs2.xoauth2_client_id,` +
+		` projections.smtp_configs2.xoauth2_client_secret,` +
+		` projections.smtp_configs2.xoauth2_scopes,` +
+		` projections.smtp_configs2.ca_certificate` +
+		` FROM projections.smtp_configs2` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareSMTPConfigCols = []string{
 		"aggregate_id",
+		"id",
 		"creation_date",
 		"change_date",
 		"resource_owner",
 		"sequence",
+		"org_id",
+		"description",
+		"priority",
 		"tls",
 		"sender_address",
 		"sender_name",
 		"smtp_host",
 		"smtp_user",
 		"smtp_password",
+		"tls_mode",
+		"auth_type",
+		"xoauth2_token_endpoint",
+		"xoauth2_client_id",
+		"xoauth2_client_secret",
+		"xoauth2_scopes",
+		"ca_certificate",
 	}
+	prepareSMTPConfigsStmt = `SELECT projections.smtp_configs2.aggregate_id,` +
+		` projections.smtp_configs2.id,` +
+		` projections.smtp_configs2.creation_date,` +
+		` projections.smtp_configs2.change_date,` +
+		` projections.smtp_configs2.resource_owner,` +
+		` projections.smtp_configs2.sequence,` +
+		` projections.smtp_configs2.org_id,` +
+		` projections.smtp_configs2.description,` +
+		` projections.smtp_configs2.priority,` +
+		` projections.smtp_configs2.tls,` +
+		` projections.smtp_configs2.sender_address,` +
+		` projections.smtp_configs2.sender_name,` +
+		` projections.smtp_configs2.host,` +
+		` projections.smtp_configs2.username,` +
+		` projections.smtp_configs2.password,` +
+		` projections.smtp_configs2.tls_mode,` +
+		` projections.smtp_configs2.auth_type,` +
+		` projections.smtp_configs2.xoauth2_token_endpoint,` +
+		` projections.smtp_configs2.xoauth2_client_id,` +
+		` projections.smtp_configs2.xoauth2_client_secret,` +
+		` projections.smtp_configs2.xoauth2_scopes,` +
+		` projections.smtp_configs2.ca_certificate,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.smtp_configs2` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareSMTPConfigsCols = append(prepareSMTPConfigCols, "count")
 )
 
 func Test_SMTPConfigsPrepares(t *testing.T) {
@@ -79,25 +129,39 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 					prepareSMTPConfigCols,
 					[]driver.Value{
 						"agg-id",
+						"config-id",
 						testNow,
 						testNow,
 						"ro",
 						uint64(20211108),
+						"",
+						"description",
+						uint32(1),
 						true,
 						"sender",
 						"name",
 						"host",
 						"user",
 						&crypto.CryptoValue{},
+						domain.SMTPTLSModeUnspecified,
+						domain.SMTPAuthTypeUnspecified,
+						"",
+						"",
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
 			object: &SMTPConfig{
 				AggregateID:   "agg-id",
+				ID:            "config-id",
 				CreationDate:  testNow,
 				ChangeDate:    testNow,
 				ResourceOwner: "ro",
 				Sequence:      20211108,
+				Description:   "description",
+				Priority:      1,
 				TLS:           true,
 				SenderAddress: "sender",
 				SenderName:    "name",
@@ -123,6 +187,143 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 			},
 			object: nil,
 		},
+		{
+			name:    "prepareSMTPConfigsQuery no result",
+			prepare: prepareSMTPConfigsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSMTPConfigsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &SMTPConfigs{Configs: []*SMTPConfig{}},
+		},
+		{
+			name:    "prepareSMTPConfigsQuery found",
+			prepare: prepareSMTPConfigsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSMTPConfigsStmt),
+					prepareSMTPConfigsCols,
+					[][]driver.Value{
+						{
+							"agg-id",
+							"config-id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211108),
+							"",
+							"description",
+							uint32(1),
+							true,
+							"sender",
+							"name",
+							"host",
+							"user",
+							&crypto.CryptoValue{},
+							domain.SMTPTLSModeUnspecified,
+							domain.SMTPAuthTypeUnspecified,
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+						},
+						{
+							"agg-id",
+							"config-id2",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211109),
+							"org-id",
+							"org config",
+							uint32(2),
+							false,
+							"sender2",
+							"name2",
+							"host2",
+							"user2",
+							&crypto.CryptoValue{},
+							domain.SMTPTLSModeStartTLSRequired,
+							domain.SMTPAuthTypeXOAuth2,
+							"https://login.domain.ch/oauth2/token",
+							"client-id",
+							&crypto.CryptoValue{},
+							database.StringArray{"scope"},
+							&crypto.CryptoValue{},
+						},
+					},
+				),
+			},
+			object: &SMTPConfigs{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Configs: []*SMTPConfig{
+					{
+						AggregateID:   "agg-id",
+						ID:            "config-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211108,
+						Description:   "description",
+						Priority:      1,
+						TLS:           true,
+						SenderAddress: "sender",
+						SenderName:    "name",
+						Host:          "host",
+						User:          "user",
+						Password:      &crypto.CryptoValue{},
+					},
+					{
+						AggregateID:   "agg-id",
+						ID:            "config-id2",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211109,
+						OrgID:         "org-id",
+						Description:   "org config",
+						Priority:      2,
+						TLS:           false,
+						SenderAddress: "sender2",
+						SenderName:    "name2",
+						Host:          "host2",
+						User:          "user2",
+						Password:      &crypto.CryptoValue{},
+						TLSMode:       domain.SMTPTLSModeStartTLSRequired,
+						AuthType:      domain.SMTPAuthTypeXOAuth2,
+						CACertificate: &crypto.CryptoValue{},
+
+						XOAuth2TokenEndpoint: "https://login.domain.ch/oauth2/token",
+						XOAuth2ClientID:      "client-id",
+						XOAuth2ClientSecret:  &crypto.CryptoValue{},
+						XOAuth2Scopes:        database.StringArray{"scope"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareSMTPConfigsQuery sql err",
+			prepare: prepareSMTPConfigsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareSMTPConfigsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -546,6 +546,85 @@ func (q *Queries) SearchUsers(ctx context.Context, queries *UserSearchQueries, w
 	return users, err
 }
 
+// GetUserByIDWithPermission returns the user,
+// if the user of the context is allowed to read it (or it's its own user)
+func (q *Queries) GetUserByIDWithPermission(ctx context.Context, shouldTriggerBulk bool, userID string) (_ *User, err error) {
+	user, err := q.GetUserByID(ctx, shouldTriggerBulk, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	if err = q.checkPermissionReadUser(ctx, user.ResourceOwner, user.ID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// SearchUsersWithPermission searches the users the user of the context is allowed to read (and its own user).
+// The permission is part of the query, so pagination and the total result only contain the permitted users
+func (q *Queries) SearchUsersWithPermission(ctx context.Context, queries *UserSearchQueries) (_ *Users, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	permissionQuery, err := q.userReadPermissionQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if permissionQuery == nil {
+		return q.SearchUsers(ctx, queries, false)
+	}
+	permittedQueries := &UserSearchQueries{
+		SearchRequest: queries.SearchRequest,
+		Queries:       append(append(make([]SearchQuery, 0, len(queries.Queries)+1), queries.Queries...), permissionQuery),
+	}
+	return q.SearchUsers(ctx, permittedQueries, false)
+}
+
+func (q *Queries) checkPermissionReadUser(ctx context.Context, resourceOwner, userID string) error {
+	if userID == authz.GetCtxData(ctx).UserID {
+		return nil
+	}
+	return q.checkPermission(ctx, domain.PermissionUserRead, resourceOwner, userID)
+}
+
+// userReadPermissionQuery returns a query restricting the users to the own user of the context
+// and the users of the organisations (and their descendants) it's allowed to read.
+// It returns nil if the permission is granted on the instance.
+func (q *Queries) userReadPermissionQuery(ctx context.Context) (_ SearchQuery, err error) {
+	ctxData := authz.GetCtxData(ctx)
+	userIDQuery, err := NewMembershipUserIDQuery(ctxData.UserID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := q.Memberships(ctx, &MembershipSearchQuery{Queries: []SearchQuery{userIDQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	orgIDs, onInstance := q.permittedOrgIDs(memberships.Memberships, domain.PermissionUserRead)
+	if onInstance {
+		return nil, nil
+	}
+	ownUserQuery, err := NewUserInUserIDsSearchQuery([]string{ctxData.UserID})
+	if err != nil {
+		return nil, err
+	}
+	if len(orgIDs) == 0 {
+		return ownUserQuery, nil
+	}
+	descendantIDs, err := q.orgDescendantIDs(ctx, orgIDs)
+	if err != nil {
+		return nil, err
+	}
+	resourceOwners := make([]interface{}, 0, len(orgIDs)+len(descendantIDs))
+	for _, id := range append(orgIDs, descendantIDs...) {
+		resourceOwners = append(resourceOwners, id)
+	}
+	resourceOwnersQuery, err := NewListQuery(UserResourceOwnerCol, resourceOwners, ListIn)
+	if err != nil {
+		return nil, err
+	}
+	return Or(ownUserQuery, resourceOwnersQuery), nil
+}
+
 func (q *Queries) IsUserUnique(ctx context.Context, username, email, resourceOwner string, withOwnerRemoved bool) (_ bool, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -609,6 +688,14 @@ func NewUserResourceOwnerSearchQuery(value string, comparison TextComparison) (S
 	return NewTextQuery(UserResourceOwnerCol, value, comparison)
 }
 
+func NewUserInUserIDsSearchQuery(values []string) (SearchQuery, error) {
+	list := make([]interface{}, len(values))
+	for i, value := range values {
+		list[i] = value
+	}
+	return NewListQuery(UserIDCol, list, ListIn)
+}
+
 func NewUserUsernameSearchQuery(value string, comparison TextComparison) (SearchQuery, error) {
 	return NewTextQuery(UserUsernameCol, value, comparison)
 }
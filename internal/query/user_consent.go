@@ -0,0 +1,269 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type UserConsents struct {
+	SearchResponse
+	Consents []*UserConsent
+}
+
+type UserConsent struct {
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+	UserID        string
+	ClientID      string
+	AppID         string
+	AppName       string
+	ProjectID     string
+	ProjectName   string
+	Scopes        database.StringArray
+}
+
+type UserConsentSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+var (
+	userConsentTable = table{
+		name:          projection.UserConsentProjectionTable,
+		instanceIDCol: projection.UserConsentColumnInstanceID,
+	}
+	UserConsentUserIDCol = Column{
+		name:  projection.UserConsentColumnUserID,
+		table: userConsentTable,
+	}
+	UserConsentClientIDCol = Column{
+		name:  projection.UserConsentColumnClientID,
+		table: userConsentTable,
+	}
+	UserConsentAppIDCol = Column{
+		name:  projection.UserConsentColumnAppID,
+		table: userConsentTable,
+	}
+	UserConsentProjectIDCol = Column{
+		name:  projection.UserConsentColumnProjectID,
+		table: userConsentTable,
+	}
+	UserConsentScopesCol = Column{
+		name:  projection.UserConsentColumnScopes,
+		table: userConsentTable,
+	}
+	UserConsentCreationDateCol = Column{
+		name:  projection.UserConsentColumnCreationDate,
+		table: userConsentTable,
+	}
+	UserConsentChangeDateCol = Column{
+		name:  projection.UserConsentColumnChangeDate,
+		table: userConsentTable,
+	}
+	UserConsentSequenceCol = Column{
+		name:  projection.UserConsentColumnSequence,
+		table: userConsentTable,
+	}
+	UserConsentResourceOwnerCol = Column{
+		name:  projection.UserConsentColumnResourceOwner,
+		table: userConsentTable,
+	}
+	UserConsentInstanceIDCol = Column{
+		name:  projection.UserConsentColumnInstanceID,
+		table: userConsentTable,
+	}
+	UserConsentOwnerRemovedCol = Column{
+		name:  projection.UserConsentColumnOwnerRemoved,
+		table: userConsentTable,
+	}
+)
+
+// UserConsentByClientID returns the scopes the user consented to for the client
+func (q *Queries) UserConsentByClientID(ctx context.Context, shouldTriggerBulk bool, userID, clientID string) (_ *UserConsent, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		projection.UserConsentProjection.Trigger(ctx)
+	}
+
+	query, scan := prepareUserConsentQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		UserConsentUserIDCol.identifier():       userID,
+		UserConsentClientIDCol.identifier():     clientID,
+		UserConsentInstanceIDCol.identifier():   authz.GetInstance(ctx).InstanceID(),
+		UserConsentOwnerRemovedCol.identifier(): false,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Vr4yk", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func (q *Queries) SearchUserConsents(ctx context.Context, queries *UserConsentSearchQueries) (_ *UserConsents, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareUserConsentsQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		UserConsentInstanceIDCol.identifier():   authz.GetInstance(ctx).InstanceID(),
+		UserConsentOwnerRemovedCol.identifier(): false,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Nz6ts", "Errors.Query.SQLStatment")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ci2wb", "Errors.Internal")
+	}
+	consents, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	consents.LatestSequence, err = q.latestSequence(ctx, userConsentTable)
+	return consents, err
+}
+
+func (q *UserConsentSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewUserConsentUserIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(UserConsentUserIDCol, value, TextEquals)
+}
+
+func NewUserConsentClientIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(UserConsentClientIDCol, value, TextEquals)
+}
+
+func prepareUserConsentQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*UserConsent, error)) {
+	return sq.Select(
+			UserConsentCreationDateCol.identifier(),
+			UserConsentChangeDateCol.identifier(),
+			UserConsentResourceOwnerCol.identifier(),
+			UserConsentSequenceCol.identifier(),
+			UserConsentUserIDCol.identifier(),
+			UserConsentClientIDCol.identifier(),
+			UserConsentAppIDCol.identifier(),
+			AppColumnName.identifier(),
+			UserConsentProjectIDCol.identifier(),
+			ProjectColumnName.identifier(),
+			UserConsentScopesCol.identifier(),
+		).
+			From(userConsentTable.identifier()).
+			LeftJoin(join(AppColumnID, UserConsentAppIDCol)).
+			LeftJoin(join(ProjectColumnID, UserConsentProjectIDCol) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*UserConsent, error) {
+			consent := new(UserConsent)
+			var (
+				appName     sql.NullString
+				projectName sql.NullString
+			)
+			err := row.Scan(
+				&consent.CreationDate,
+				&consent.ChangeDate,
+				&consent.ResourceOwner,
+				&consent.Sequence,
+				&consent.UserID,
+				&consent.ClientID,
+				&consent.AppID,
+				&appName,
+				&consent.ProjectID,
+				&projectName,
+				&consent.Scopes,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Ks8qn", "Errors.User.Consent.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Dz1fe", "Errors.Internal")
+			}
+			consent.AppName = appName.String
+			consent.ProjectName = projectName.String
+			return consent, nil
+		}
+}
+
+func prepareUserConsentsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*UserConsents, error)) {
+	return sq.Select(
+			UserConsentCreationDateCol.identifier(),
+			UserConsentChangeDateCol.identifier(),
+			UserConsentResourceOwnerCol.identifier(),
+			UserConsentSequenceCol.identifier(),
+			UserConsentUserIDCol.identifier(),
+			UserConsentClientIDCol.identifier(),
+			UserConsentAppIDCol.identifier(),
+			AppColumnName.identifier(),
+			UserConsentProjectIDCol.identifier(),
+			ProjectColumnName.identifier(),
+			UserConsentScopesCol.identifier(),
+			countColumn.identifier(),
+		).
+			From(userConsentTable.identifier()).
+			LeftJoin(join(AppColumnID, UserConsentAppIDCol)).
+			LeftJoin(join(ProjectColumnID, UserConsentProjectIDCol) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*UserConsents, error) {
+			consents := make([]*UserConsent, 0)
+			var count uint64
+			for rows.Next() {
+				consent := new(UserConsent)
+				var (
+					appName     sql.NullString
+					projectName sql.NullString
+				)
+				err := rows.Scan(
+					&consent.CreationDate,
+					&consent.ChangeDate,
+					&consent.ResourceOwner,
+					&consent.Sequence,
+					&consent.UserID,
+					&consent.ClientID,
+					&consent.AppID,
+					&appName,
+					&consent.ProjectID,
+					&projectName,
+					&consent.Scopes,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				consent.AppName = appName.String
+				consent.ProjectName = projectName.String
+				consents = append(consents, consent)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Hm7xw", "Errors.Query.CloseRows")
+			}
+
+			return &UserConsents{
+				Consents: consents,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,223 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	userConsentStmt = `SELECT projections.user_consents.creation_date,` +
+		` projections.user_consents.change_date,` +
+		` projections.user_consents.resource_owner,` +
+		` projections.user_consents.sequence,` +
+		` projections.user_consents.user_id,` +
+		` projections.user_consents.client_id,` +
+		` projections.user_consents.app_id,` +
+		` projections.apps9.name,` +
+		` projections.user_consents.project_id,` +
+		` projections.projects4.name,` +
+		` projections.user_consents.scopes` +
+		` FROM projections.user_consents` +
+		` LEFT JOIN projections.apps9 ON projections.user_consents.app_id = projections.apps9.id AND projections.user_consents.instance_id = projections.apps9.instance_id` +
+		` LEFT JOIN projections.projects4 ON projections.user_consents.project_id = projections.projects4.id AND projections.user_consents.instance_id = projections.projects4.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	userConsentCols = []string{
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"user_id",
+		"client_id",
+		"app_id",
+		"name",
+		"project_id",
+		"name",
+		"scopes",
+	}
+	userConsentsStmt = `SELECT projections.user_consents.creation_date,` +
+		` projections.user_consents.change_date,` +
+		` projections.user_consents.resource_owner,` +
+		` projections.user_consents.sequence,` +
+		` projections.user_consents.user_id,` +
+		` projections.user_consents.client_id,` +
+		` projections.user_consents.app_id,` +
+		` projections.apps9.name,` +
+		` projections.user_consents.project_id,` +
+		` projections.projects4.name,` +
+		` projections.user_consents.scopes,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.user_consents` +
+		` LEFT JOIN projections.apps9 ON projections.user_consents.app_id = projections.apps9.id AND projections.user_consents.instance_id = projections.apps9.instance_id` +
+		` LEFT JOIN projections.projects4 ON projections.user_consents.project_id = projections.projects4.id AND projections.user_consents.instance_id = projections.projects4.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	userConsentsCols = append(userConsentCols, "count")
+)
+
+func Test_UserConsentPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareUserConsentQuery no result",
+			prepare: prepareUserConsentQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(userConsentStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*UserConsent)(nil),
+		},
+		{
+			name:    "prepareUserConsentQuery found",
+			prepare: prepareUserConsentQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(userConsentStmt),
+					userConsentCols,
+					[]driver.Value{
+						testNow,
+						testNow,
+						"resource_owner",
+						uint64(20211108),
+						"user-id",
+						"client-id",
+						"app-id",
+						"app-name",
+						"project-id",
+						"project-name",
+						database.StringArray{"openid", "email"},
+					},
+				),
+			},
+			object: &UserConsent{
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "resource_owner",
+				Sequence:      20211108,
+				UserID:        "user-id",
+				ClientID:      "client-id",
+				AppID:         "app-id",
+				AppName:       "app-name",
+				ProjectID:     "project-id",
+				ProjectName:   "project-name",
+				Scopes:        database.StringArray{"openid", "email"},
+			},
+		},
+		{
+			name:    "prepareUserConsentQuery sql err",
+			prepare: prepareUserConsentQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(userConsentStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareUserConsentsQuery no result",
+			prepare: prepareUserConsentsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(userConsentsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &UserConsents{Consents: []*UserConsent{}},
+		},
+		{
+			name:    "prepareUserConsentsQuery one result",
+			prepare: prepareUserConsentsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(userConsentsStmt),
+					userConsentsCols,
+					[][]driver.Value{
+						{
+							testNow,
+							testNow,
+							"resource_owner",
+							uint64(20211108),
+							"user-id",
+							"client-id",
+							"app-id",
+							nil,
+							"project-id",
+							nil,
+							database.StringArray{"openid"},
+						},
+					},
+				),
+			},
+			object: &UserConsents{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Consents: []*UserConsent{
+					{
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "resource_owner",
+						Sequence:      20211108,
+						UserID:        "user-id",
+						ClientID:      "client-id",
+						AppID:         "app-id",
+						ProjectID:     "project-id",
+						Scopes:        database.StringArray{"openid"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareUserConsentsQuery sql err",
+			prepare: prepareUserConsentsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(userConsentsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
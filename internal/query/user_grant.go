@@ -47,6 +47,9 @@ type UserGrant struct {
 
 	ProjectID   string
 	ProjectName string
+
+	// GroupID is set if the grant is inherited from a group grant
+	GroupID string
 }
 
 type UserGrants struct {
@@ -57,6 +60,10 @@ type UserGrants struct {
 type UserGrantsQueries struct {
 	SearchRequest
 	Queries []SearchQuery
+	// WithGroupGrants adds the grants the user inherits from its groups,
+	// the queries must restrict the search to a user and only use columns
+	// which exist on group grants, otherwise an InvalidArgument error is returned
+	WithGroupGrants bool
 }
 
 func (q *UserGrantsQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
@@ -288,6 +295,14 @@ func (q *Queries) UserGrants(ctx context.Context, queries *UserGrantsQueries, sh
 	if err != nil {
 		return nil, err
 	}
+	if queries.WithGroupGrants {
+		groupGrants, err := q.groupUserGrants(ctx, queries, withOwnerRemoved)
+		if err != nil {
+			return nil, err
+		}
+		grants.UserGrants = append(grants.UserGrants, groupGrants...)
+		grants.Count += uint64(len(groupGrants))
+	}
 
 	grants.LatestSequence = latestSequence
 	return grants, nil
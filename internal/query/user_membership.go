@@ -81,6 +81,14 @@ func NewMembershipResourceOwnersSearchQuery(ids ...string) (SearchQuery, error)
 	return NewListQuery(membershipResourceOwner, list, ListIn)
 }
 
+func NewMembershipOrgIDsSearchQuery(ids ...string) (SearchQuery, error) {
+	list := make([]interface{}, len(ids))
+	for i, value := range ids {
+		list[i] = value
+	}
+	return NewListQuery(membershipOrgID, list, ListIn)
+}
+
 func NewMembershipGrantedOrgIDSearchQuery(id string) (SearchQuery, error) {
 	return NewTextQuery(ProjectGrantColumnGrantedOrgID, id, TextEquals)
 }
@@ -0,0 +1,88 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type NotificationPreferences struct {
+	domain.NotificationPreferences
+
+	Sequence      uint64
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+}
+
+// NotificationPreferencesByUserID returns the security notifications the user wants to receive,
+// all are enabled as long as the user didn't set any preferences
+func (q *Queries) NotificationPreferencesByUserID(ctx context.Context, userID, resourceOwner string) (_ *NotificationPreferences, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, errors.ThrowPreconditionFailed(nil, "QUERY-Np4gw", "Errors.User.UserIDMissing")
+	}
+	readModel := NewHumanNotificationPreferencesReadModel(userID, resourceOwner)
+	err = q.eventstore.FilterToQueryReducer(ctx, readModel)
+	if err != nil {
+		return nil, err
+	}
+	return &NotificationPreferences{
+		NotificationPreferences: readModel.NotificationPreferences,
+		Sequence:                readModel.ProcessedSequence,
+		CreationDate:            readModel.CreationDate,
+		ChangeDate:              readModel.ChangeDate,
+		ResourceOwner:           readModel.ResourceOwner,
+	}, nil
+}
+
+type HumanNotificationPreferencesReadModel struct {
+	*eventstore.ReadModel
+
+	domain.NotificationPreferences
+}
+
+func NewHumanNotificationPreferencesReadModel(userID, resourceOwner string) *HumanNotificationPreferencesReadModel {
+	return &HumanNotificationPreferencesReadModel{
+		ReadModel: &eventstore.ReadModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		NotificationPreferences: *domain.DefaultNotificationPreferences(),
+	}
+}
+
+func (rm *HumanNotificationPreferencesReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *user.HumanNotificationPreferencesSetEvent:
+			rm.NewDeviceLogin = e.NewDeviceLogin
+			rm.MFAAdded = e.MFAAdded
+			rm.MFARemoved = e.MFARemoved
+			rm.EmailChange = e.EmailChange
+			rm.AccountLocked = e.AccountLocked
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *HumanNotificationPreferencesReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(user.HumanNotificationPreferencesSetType).
+		Builder()
+
+	if rm.ResourceOwner != "" {
+		query.ResourceOwner(rm.ResourceOwner)
+	}
+	return query
+}
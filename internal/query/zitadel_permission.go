@@ -53,3 +53,39 @@ func (q *Queries) mapRoleToPermission(permissions *domain.Permissions, membershi
 	}
 	return permissions
 }
+
+// permittedOrgIDs returns the ids of the organisations, on which the memberships grant the permission.
+// onInstance is true if an instance membership grants it, the permission is given on all organisations then.
+// Project memberships are ignored, as they only grant permissions on the project
+func (q *Queries) permittedOrgIDs(memberships []*Membership, permission string) (orgIDs []string, onInstance bool) {
+	orgIDs = make([]string, 0)
+	for _, membership := range memberships {
+		if !q.rolesContainPermission(membership.Roles, permission) {
+			continue
+		}
+		if membership.IAM != nil {
+			return nil, true
+		}
+		if membership.Org != nil {
+			orgIDs = append(orgIDs, membership.Org.OrgID)
+		}
+	}
+	return orgIDs, false
+}
+
+// rolesContainPermission returns true if any of the roles is mapped to the permission
+func (q *Queries) rolesContainPermission(roles []string, permission string) bool {
+	for _, role := range roles {
+		for _, mapping := range q.zitadelRoles {
+			if mapping.Role != role {
+				continue
+			}
+			for _, p := range mapping.Permissions {
+				if p == permission {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func TestQueries_permittedOrgIDs(t *testing.T) {
+	roles := []authz.RoleMapping{
+		{Role: "IAM_OWNER", Permissions: []string{domain.PermissionUserRead, domain.PermissionUserWrite}},
+		{Role: "ORG_OWNER", Permissions: []string{domain.PermissionUserRead, domain.PermissionUserWrite}},
+		{Role: "ORG_PROJECT_CREATOR", Permissions: []string{"project.write"}},
+		{Role: "PROJECT_OWNER", Permissions: []string{domain.PermissionUserRead}},
+	}
+	tests := []struct {
+		name           string
+		memberships    []*Membership
+		wantOrgIDs     []string
+		wantOnInstance bool
+	}{
+		{
+			name:       "no memberships",
+			wantOrgIDs: []string{},
+		},
+		{
+			name: "org memberships with and without permission",
+			memberships: []*Membership{
+				{Roles: []string{"ORG_OWNER"}, Org: &OrgMembership{OrgID: "org1"}},
+				{Roles: []string{"ORG_PROJECT_CREATOR"}, Org: &OrgMembership{OrgID: "org2"}},
+				{Roles: []string{"ORG_PROJECT_CREATOR", "ORG_OWNER"}, Org: &OrgMembership{OrgID: "org3"}},
+			},
+			wantOrgIDs: []string{"org1", "org3"},
+		},
+		{
+			name: "project membership ignored",
+			memberships: []*Membership{
+				{Roles: []string{"PROJECT_OWNER"}, Project: &ProjectMembership{ProjectID: "project1"}},
+			},
+			wantOrgIDs: []string{},
+		},
+		{
+			name: "instance membership",
+			memberships: []*Membership{
+				{Roles: []string{"ORG_OWNER"}, Org: &OrgMembership{OrgID: "org1"}},
+				{Roles: []string{"IAM_OWNER"}, IAM: &IAMMembership{IAMID: "instance1"}},
+			},
+			wantOnInstance: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Queries{zitadelRoles: roles}
+			orgIDs, onInstance := q.permittedOrgIDs(tt.memberships, domain.PermissionUserRead)
+			assert.Equal(t, tt.wantOrgIDs, orgIDs)
+			assert.Equal(t, tt.wantOnInstance, onInstance)
+		})
+	}
+}
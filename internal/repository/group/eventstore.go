@@ -0,0 +1,19 @@
+package group
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, AddedEventType, AddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ChangedEventType, ChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, RemovedEventType, RemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, MemberAddedEventType, MemberAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, MemberRemovedEventType, MemberRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, MetadataSetType, MetadataSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, MetadataRemovedType, MetadataRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, MetadataRemovedAllType, MetadataRemovedAllEventMapper).
+		RegisterFilterEventMapper(AggregateType, GrantAddedEventType, GrantAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, GrantChangedEventType, GrantChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, GrantRemovedEventType, GrantRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, GrantDeactivatedEventType, GrantDeactivatedEventMapper).
+		RegisterFilterEventMapper(AggregateType, GrantReactivatedEventType, GrantReactivatedEventMapper)
+}
@@ -0,0 +1,9 @@
+package idpintent
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, StartedEventType, eventstore.GenericEventMapper[StartedEvent]).
+		RegisterFilterEventMapper(AggregateType, SucceededEventType, eventstore.GenericEventMapper[SucceededEvent]).
+		RegisterFilterEventMapper(AggregateType, FailedEventType, eventstore.GenericEventMapper[FailedEvent])
+}
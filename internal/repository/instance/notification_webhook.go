@@ -0,0 +1,179 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	notificationWebhookPrefix                   = "notification.provider.webhook."
+	NotificationWebhookProviderAddedEventType   = instanceEventTypePrefix + notificationWebhookPrefix + "added"
+	NotificationWebhookProviderChangedEventType = instanceEventTypePrefix + notificationWebhookPrefix + "changed"
+	NotificationWebhookProviderRemovedEventType = instanceEventTypePrefix + notificationWebhookPrefix + "removed"
+)
+
+type NotificationWebhookProviderAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CallURL    string              `json:"callURL,omitempty"`
+	Method     string              `json:"method,omitempty"`
+	Headers    map[string]string   `json:"headers,omitempty"`
+	SigningKey *crypto.CryptoValue `json:"signingKey,omitempty"`
+}
+
+func NewNotificationWebhookProviderAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	callURL,
+	method string,
+	headers map[string]string,
+	signingKey *crypto.CryptoValue,
+) *NotificationWebhookProviderAddedEvent {
+	return &NotificationWebhookProviderAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			NotificationWebhookProviderAddedEventType,
+		),
+		CallURL:    callURL,
+		Method:     method,
+		Headers:    headers,
+		SigningKey: signingKey,
+	}
+}
+
+func (e *NotificationWebhookProviderAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *NotificationWebhookProviderAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NotificationWebhookProviderAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	webhookAdded := &NotificationWebhookProviderAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, webhookAdded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Wh3ad", "unable to unmarshal notification webhook provider added")
+	}
+
+	return webhookAdded, nil
+}
+
+type NotificationWebhookProviderChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CallURL *string `json:"callURL,omitempty"`
+	Method  *string `json:"method,omitempty"`
+	// Headers are only changed if not nil, an empty map removes all headers
+	Headers    map[string]string   `json:"headers"`
+	SigningKey *crypto.CryptoValue `json:"signingKey,omitempty"`
+}
+
+func NewNotificationWebhookProviderChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []NotificationWebhookProviderChanges,
+) (*NotificationWebhookProviderChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "IAM-Wh3ch", "Errors.NoChangesFound")
+	}
+	changeEvent := &NotificationWebhookProviderChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			NotificationWebhookProviderChangedEventType,
+		),
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type NotificationWebhookProviderChanges func(event *NotificationWebhookProviderChangedEvent)
+
+func ChangeNotificationWebhookProviderCallURL(callURL string) func(event *NotificationWebhookProviderChangedEvent) {
+	return func(e *NotificationWebhookProviderChangedEvent) {
+		e.CallURL = &callURL
+	}
+}
+
+func ChangeNotificationWebhookProviderMethod(method string) func(event *NotificationWebhookProviderChangedEvent) {
+	return func(e *NotificationWebhookProviderChangedEvent) {
+		e.Method = &method
+	}
+}
+
+func ChangeNotificationWebhookProviderHeaders(headers map[string]string) func(event *NotificationWebhookProviderChangedEvent) {
+	return func(e *NotificationWebhookProviderChangedEvent) {
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		e.Headers = headers
+	}
+}
+
+func ChangeNotificationWebhookProviderSigningKey(signingKey *crypto.CryptoValue) func(event *NotificationWebhookProviderChangedEvent) {
+	return func(e *NotificationWebhookProviderChangedEvent) {
+		e.SigningKey = signingKey
+	}
+}
+
+func (e *NotificationWebhookProviderChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *NotificationWebhookProviderChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NotificationWebhookProviderChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	webhookChanged := &NotificationWebhookProviderChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, webhookChanged)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Wh3cm", "unable to unmarshal notification webhook provider changed")
+	}
+
+	return webhookChanged, nil
+}
+
+type NotificationWebhookProviderRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func NewNotificationWebhookProviderRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *NotificationWebhookProviderRemovedEvent {
+	return &NotificationWebhookProviderRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			NotificationWebhookProviderRemovedEventType,
+		),
+	}
+}
+
+func (e *NotificationWebhookProviderRemovedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *NotificationWebhookProviderRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NotificationWebhookProviderRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &NotificationWebhookProviderRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
@@ -31,8 +31,11 @@ func NewLoginPolicyAddedEvent(
 	ignoreUnknownUsernames,
 	allowDomainDiscovery,
 	disableLoginWithEmail,
-	disableLoginWithPhone bool,
+	disableLoginWithPhone,
+	allowMagicLink,
+	allowPhoneOTP bool,
 	passwordlessType domain.PasswordlessType,
+	botProtection domain.BotProtectionType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
 	externalLoginCheckLifetime,
@@ -55,7 +58,10 @@ func NewLoginPolicyAddedEvent(
 			allowDomainDiscovery,
 			disableLoginWithEmail,
 			disableLoginWithPhone,
+			allowMagicLink,
+			allowPhoneOTP,
 			passwordlessType,
+			botProtection,
 			defaultRedirectURI,
 			passwordCheckLifetime,
 			externalLoginCheckLifetime,
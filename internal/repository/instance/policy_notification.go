@@ -20,7 +20,12 @@ type NotificationPolicyAddedEvent struct {
 func NewNotificationPolicyAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	passwordChange,
+	newDeviceLogin,
+	mfaAdded,
+	mfaRemoved,
+	emailChange,
+	accountLocked bool,
 ) *NotificationPolicyAddedEvent {
 	return &NotificationPolicyAddedEvent{
 		NotificationPolicyAddedEvent: *policy.NewNotificationPolicyAddedEvent(
@@ -28,7 +33,12 @@ func NewNotificationPolicyAddedEvent(
 				ctx,
 				aggregate,
 				NotificationPolicyAddedEventType),
-			passwordChange),
+			passwordChange,
+			newDeviceLogin,
+			mfaAdded,
+			mfaRemoved,
+			emailChange,
+			accountLocked),
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
@@ -16,28 +17,54 @@ const (
 	SMTPConfigChangedEventType         = instanceEventTypePrefix + smtpConfigPrefix + "changed"
 	SMTPConfigPasswordChangedEventType = instanceEventTypePrefix + smtpConfigPrefix + "password.changed"
 	SMTPConfigRemovedEventType         = instanceEventTypePrefix + smtpConfigPrefix + "removed"
+
+	SMTPConfigCACertificateChangedEventType = instanceEventTypePrefix + smtpConfigPrefix + "cacertificate.changed"
 )
 
+// SMTPXOAuth2 is the client used to fetch the access token for the XOAUTH2 authentication
+type SMTPXOAuth2 struct {
+	TokenEndpoint string              `json:"tokenEndpoint,omitempty"`
+	ClientID      string              `json:"clientId,omitempty"`
+	ClientSecret  *crypto.CryptoValue `json:"clientSecret,omitempty"`
+	Scopes        []string            `json:"scopes,omitempty"`
+}
+
 type SMTPConfigAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
+	ID            string              `json:"id,omitempty"`
+	OrgID         string              `json:"orgID,omitempty"`
+	Description   string              `json:"description,omitempty"`
+	Priority      uint32              `json:"priority,omitempty"`
 	SenderAddress string              `json:"senderAddress,omitempty"`
 	SenderName    string              `json:"senderName,omitempty"`
 	TLS           bool                `json:"tls,omitempty"`
 	Host          string              `json:"host,omitempty"`
 	User          string              `json:"user,omitempty"`
 	Password      *crypto.CryptoValue `json:"password,omitempty"`
+	TLSMode       domain.SMTPTLSMode  `json:"tlsMode,omitempty"`
+	AuthType      domain.SMTPAuthType `json:"authType,omitempty"`
+	XOAuth2       *SMTPXOAuth2        `json:"xoauth2,omitempty"`
+	CACertificate *crypto.CryptoValue `json:"caCertificate,omitempty"`
 }
 
 func NewSMTPConfigAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id,
+	orgID,
+	description string,
+	priority uint32,
 	tls bool,
 	senderAddress,
 	senderName,
 	host,
 	user string,
 	password *crypto.CryptoValue,
+	tlsMode domain.SMTPTLSMode,
+	authType domain.SMTPAuthType,
+	xoauth2 *SMTPXOAuth2,
+	caCertificate *crypto.CryptoValue,
 ) *SMTPConfigAddedEvent {
 	return &SMTPConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -45,12 +72,20 @@ func NewSMTPConfigAddedEvent(
 			aggregate,
 			SMTPConfigAddedEventType,
 		),
+		ID:            id,
+		OrgID:         orgID,
+		Description:   description,
+		Priority:      priority,
 		TLS:           tls,
 		SenderAddress: senderAddress,
 		SenderName:    senderName,
 		Host:          host,
 		User:          user,
 		Password:      password,
+		TLSMode:       tlsMode,
+		AuthType:      authType,
+		XOAuth2:       xoauth2,
+		CACertificate: caCertificate,
 	}
 }
 
@@ -70,6 +105,7 @@ func SMTPConfigAddedEventMapper(event *repository.Event) (eventstore.Event, erro
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "IAM-39fks", "unable to unmarshal smtp config added")
 	}
+	smtpConfigAdded.ID = smtpConfigID(smtpConfigAdded.ID, event)
 
 	return smtpConfigAdded, nil
 }
@@ -77,11 +113,17 @@ func SMTPConfigAddedEventMapper(event *repository.Event) (eventstore.Event, erro
 type SMTPConfigChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	FromAddress *string `json:"senderAddress,omitempty"`
-	FromName    *string `json:"senderName,omitempty"`
-	TLS         *bool   `json:"tls,omitempty"`
-	Host        *string `json:"host,omitempty"`
-	User        *string `json:"user,omitempty"`
+	ID          string               `json:"id,omitempty"`
+	Description *string              `json:"description,omitempty"`
+	Priority    *uint32              `json:"priority,omitempty"`
+	TLSMode     *domain.SMTPTLSMode  `json:"tlsMode,omitempty"`
+	AuthType    *domain.SMTPAuthType `json:"authType,omitempty"`
+	XOAuth2     *SMTPXOAuth2         `json:"xoauth2,omitempty"`
+	FromAddress *string              `json:"senderAddress,omitempty"`
+	FromName    *string              `json:"senderName,omitempty"`
+	TLS         *bool                `json:"tls,omitempty"`
+	Host        *string              `json:"host,omitempty"`
+	User        *string              `json:"user,omitempty"`
 }
 
 func (e *SMTPConfigChangedEvent) Data() interface{} {
@@ -95,6 +137,7 @@ func (e *SMTPConfigChangedEvent) UniqueConstraints() []*eventstore.EventUniqueCo
 func NewSMTPConfigChangeEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id string,
 	changes []SMTPConfigChanges,
 ) (*SMTPConfigChangedEvent, error) {
 	if len(changes) == 0 {
@@ -106,6 +149,7 @@ func NewSMTPConfigChangeEvent(
 			aggregate,
 			SMTPConfigChangedEventType,
 		),
+		ID: id,
 	}
 	for _, change := range changes {
 		change(changeEvent)
@@ -115,6 +159,37 @@ func NewSMTPConfigChangeEvent(
 
 type SMTPConfigChanges func(event *SMTPConfigChangedEvent)
 
+func ChangeSMTPConfigDescription(description string) func(event *SMTPConfigChangedEvent) {
+	return func(e *SMTPConfigChangedEvent) {
+		e.Description = &description
+	}
+}
+
+func ChangeSMTPConfigPriority(priority uint32) func(event *SMTPConfigChangedEvent) {
+	return func(e *SMTPConfigChangedEvent) {
+		e.Priority = &priority
+	}
+}
+
+func ChangeSMTPConfigTLSMode(tlsMode domain.SMTPTLSMode) func(event *SMTPConfigChangedEvent) {
+	return func(e *SMTPConfigChangedEvent) {
+		e.TLSMode = &tlsMode
+	}
+}
+
+func ChangeSMTPConfigAuthType(authType domain.SMTPAuthType) func(event *SMTPConfigChangedEvent) {
+	return func(e *SMTPConfigChangedEvent) {
+		e.AuthType = &authType
+	}
+}
+
+// ChangeSMTPConfigXOAuth2 replaces the whole client config including the secret
+func ChangeSMTPConfigXOAuth2(xoauth2 *SMTPXOAuth2) func(event *SMTPConfigChangedEvent) {
+	return func(e *SMTPConfigChangedEvent) {
+		e.XOAuth2 = xoauth2
+	}
+}
+
 func ChangeSMTPConfigTLS(tls bool) func(event *SMTPConfigChangedEvent) {
 	return func(e *SMTPConfigChangedEvent) {
 		e.TLS = &tls
@@ -154,6 +229,7 @@ func SMTPConfigChangedEventMapper(event *repository.Event) (eventstore.Event, er
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "IAM-m09oo", "unable to unmarshal smtp changed")
 	}
+	e.ID = smtpConfigID(e.ID, event)
 
 	return e, nil
 }
@@ -161,12 +237,14 @@ func SMTPConfigChangedEventMapper(event *repository.Event) (eventstore.Event, er
 type SMTPConfigPasswordChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
+	ID       string              `json:"id,omitempty"`
 	Password *crypto.CryptoValue `json:"password,omitempty"`
 }
 
 func NewSMTPConfigPasswordChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id string,
 	password *crypto.CryptoValue,
 ) *SMTPConfigPasswordChangedEvent {
 	return &SMTPConfigPasswordChangedEvent{
@@ -175,6 +253,7 @@ func NewSMTPConfigPasswordChangedEvent(
 			aggregate,
 			SMTPConfigPasswordChangedEventType,
 		),
+		ID:       id,
 		Password: password,
 	}
 }
@@ -195,17 +274,66 @@ func SMTPConfigPasswordChangedEventMapper(event *repository.Event) (eventstore.E
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "IAM-99iNF", "unable to unmarshal smtp config password changed")
 	}
+	smtpConfigPasswordChagned.ID = smtpConfigID(smtpConfigPasswordChagned.ID, event)
 
 	return smtpConfigPasswordChagned, nil
 }
 
+type SMTPConfigCACertificateChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID            string              `json:"id,omitempty"`
+	CACertificate *crypto.CryptoValue `json:"caCertificate,omitempty"`
+}
+
+func NewSMTPConfigCACertificateChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	caCertificate *crypto.CryptoValue,
+) *SMTPConfigCACertificateChangedEvent {
+	return &SMTPConfigCACertificateChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigCACertificateChangedEventType,
+		),
+		ID:            id,
+		CACertificate: caCertificate,
+	}
+}
+
+func (e *SMTPConfigCACertificateChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *SMTPConfigCACertificateChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func SMTPConfigCACertificateChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &SMTPConfigCACertificateChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Sfw1c", "unable to unmarshal smtp config ca certificate changed")
+	}
+	e.ID = smtpConfigID(e.ID, event)
+
+	return e, nil
+}
+
 type SMTPConfigRemovedEvent struct {
 	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id,omitempty"`
 }
 
 func NewSMTPConfigRemovedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id string,
 ) *SMTPConfigRemovedEvent {
 	return &SMTPConfigRemovedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -213,6 +341,7 @@ func NewSMTPConfigRemovedEvent(
 			aggregate,
 			SMTPConfigRemovedEventType,
 		),
+		ID: id,
 	}
 }
 
@@ -232,6 +361,17 @@ func SMTPConfigRemovedEventMapper(event *repository.Event) (eventstore.Event, er
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "IAM-DVw1s", "unable to unmarshal smtp config removed")
 	}
+	smtpConfigRemoved.ID = smtpConfigID(smtpConfigRemoved.ID, event)
 
 	return smtpConfigRemoved, nil
 }
+
+// smtpConfigID returns the id of the config the event belongs to.
+// The events of the single smtp config an instance could have before
+// multiple configs were supported have no id, the instance id is used instead.
+func smtpConfigID(id string, event *repository.Event) string {
+	if id != "" {
+		return id
+	}
+	return event.AggregateID
+}
@@ -0,0 +1,10 @@
+package notification
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, AddedType, eventstore.GenericEventMapper[AddedEvent]).
+		RegisterFilterEventMapper(AggregateType, DeliveredType, eventstore.GenericEventMapper[DeliveredEvent]).
+		RegisterFilterEventMapper(AggregateType, DeliveryFailedType, eventstore.GenericEventMapper[DeliveryFailedEvent]).
+		RegisterFilterEventMapper(AggregateType, ResendRequestedType, eventstore.GenericEventMapper[ResendRequestedEvent])
+}
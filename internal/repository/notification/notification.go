@@ -0,0 +1,171 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	notificationEventPrefix = "notification."
+	AddedType               = notificationEventPrefix + "added"
+	DeliveredType           = notificationEventPrefix + "delivered"
+	DeliveryFailedType      = notificationEventPrefix + "delivery.failed"
+	ResendRequestedType     = notificationEventPrefix + "resend.requested"
+)
+
+// AddedEvent adds a notification to the outbox,
+// the triggering event is used to render the message again on a retry
+type AddedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	UserID           string                  `json:"userID"`
+	TriggerEventType eventstore.EventType    `json:"triggerEventType"`
+	TriggerSequence  uint64                  `json:"triggerSequence"`
+	Channel          domain.NotificationType `json:"channel"`
+	Recipient        string                  `json:"recipient"`
+	MessageType      string                  `json:"messageType"`
+}
+
+func (e *AddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func (e *AddedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID string,
+	triggerEventType eventstore.EventType,
+	triggerSequence uint64,
+	channel domain.NotificationType,
+	recipient,
+	messageType string,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AddedType,
+		),
+		UserID:           userID,
+		TriggerEventType: triggerEventType,
+		TriggerSequence:  triggerSequence,
+		Channel:          channel,
+		Recipient:        recipient,
+		MessageType:      messageType,
+	}
+}
+
+type DeliveredEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Attempt uint16 `json:"attempt"`
+}
+
+func (e *DeliveredEvent) Data() interface{} {
+	return e
+}
+
+func (e *DeliveredEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func (e *DeliveredEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func NewDeliveredEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attempt uint16,
+) *DeliveredEvent {
+	return &DeliveredEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DeliveredType,
+		),
+		Attempt: attempt,
+	}
+}
+
+// DeliveryFailedEvent records a failed delivery attempt,
+// no further retries are made if RetryAt is nil
+type DeliveryFailedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Attempt uint16     `json:"attempt"`
+	Error   string     `json:"error"`
+	RetryAt *time.Time `json:"retryAt,omitempty"`
+}
+
+func (e *DeliveryFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *DeliveryFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func (e *DeliveryFailedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func NewDeliveryFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attempt uint16,
+	err string,
+	retryAt *time.Time,
+) *DeliveryFailedEvent {
+	return &DeliveryFailedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DeliveryFailedType,
+		),
+		Attempt: attempt,
+		Error:   err,
+		RetryAt: retryAt,
+	}
+}
+
+// ResendRequestedEvent schedules the notification to be sent again immediately
+type ResendRequestedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+}
+
+func (e *ResendRequestedEvent) Data() interface{} {
+	return e
+}
+
+func (e *ResendRequestedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func (e *ResendRequestedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func NewResendRequestedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *ResendRequestedEvent {
+	return &ResendRequestedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ResendRequestedType,
+		),
+	}
+}
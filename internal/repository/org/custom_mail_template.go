@@ -0,0 +1,109 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	customMailTemplatePrefix           = orgEventTypePrefix + "custommailtemplate."
+	CustomMailTemplateSetEventType     = customMailTemplatePrefix + "set"
+	CustomMailTemplateRemovedEventType = customMailTemplatePrefix + "removed"
+)
+
+// CustomMailTemplateSetEvent references the stored html (and optional mjml) layout of a message type,
+// every upload is stored as new object and identified by its hash, so the events keep track of all versions
+type CustomMailTemplateSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType  string `json:"messageType,omitempty"`
+	TemplateKey  string `json:"templateKey,omitempty"`
+	TemplateHash string `json:"templateHash,omitempty"`
+	MJMLKey      string `json:"mjmlKey,omitempty"`
+}
+
+func NewCustomMailTemplateSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType,
+	templateKey,
+	templateHash,
+	mjmlKey string,
+) *CustomMailTemplateSetEvent {
+	return &CustomMailTemplateSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			CustomMailTemplateSetEventType,
+		),
+		MessageType:  messageType,
+		TemplateKey:  templateKey,
+		TemplateHash: templateHash,
+		MJMLKey:      mjmlKey,
+	}
+}
+
+func (e *CustomMailTemplateSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *CustomMailTemplateSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func CustomMailTemplateSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &CustomMailTemplateSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Mt8sa", "unable to unmarshal custom mail template set")
+	}
+
+	return e, nil
+}
+
+type CustomMailTemplateRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType string `json:"messageType,omitempty"`
+}
+
+func NewCustomMailTemplateRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+) *CustomMailTemplateRemovedEvent {
+	return &CustomMailTemplateRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			CustomMailTemplateRemovedEventType,
+		),
+		MessageType: messageType,
+	}
+}
+
+func (e *CustomMailTemplateRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *CustomMailTemplateRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func CustomMailTemplateRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &CustomMailTemplateRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Mt8ra", "unable to unmarshal custom mail template removed")
+	}
+
+	return e, nil
+}
@@ -21,6 +21,12 @@ const (
 	OrgDomainVerifiedEventType           = domainEventPrefix + "verified"
 	OrgDomainPrimarySetEventType         = domainEventPrefix + "primary.set"
 	OrgDomainRemovedEventType            = domainEventPrefix + "removed"
+	OrgDomainLoginAddedEventType         = domainEventPrefix + "login.added"
+	OrgDomainLoginRemovedEventType       = domainEventPrefix + "login.removed"
+
+	// UniqueOrgLoginDomain is shared with the instance domains,
+	// because the instance is resolved by the requested host for both of them
+	UniqueOrgLoginDomain = "instance_domain"
 )
 
 func NewAddOrgDomainUniqueConstraint(orgDomain string) *eventstore.EventUniqueConstraint {
@@ -36,6 +42,19 @@ func NewRemoveOrgDomainUniqueConstraint(orgDomain string) *eventstore.EventUniqu
 		orgDomain)
 }
 
+func NewAddOrgLoginDomainUniqueConstraint(domain string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewAddGlobalEventUniqueConstraint(
+		UniqueOrgLoginDomain,
+		domain,
+		"Errors.Org.Domain.LoginAlreadyExists")
+}
+
+func NewRemoveOrgLoginDomainUniqueConstraint(domain string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewRemoveGlobalEventUniqueConstraint(
+		UniqueOrgLoginDomain,
+		domain)
+}
+
 type DomainAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -271,3 +290,78 @@ func DomainRemovedEventMapper(event *repository.Event) (eventstore.Event, error)
 
 	return orgDomainRemoved, nil
 }
+
+// DomainLoginAddedEvent routes the login UI and the OIDC issuer of the (verified) domain to the organization
+type DomainLoginAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Domain string `json:"domain,omitempty"`
+}
+
+func (e *DomainLoginAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *DomainLoginAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewAddOrgLoginDomainUniqueConstraint(e.Domain)}
+}
+
+func NewDomainLoginAddedEvent(ctx context.Context, aggregate *eventstore.Aggregate, domain string) *DomainLoginAddedEvent {
+	return &DomainLoginAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OrgDomainLoginAddedEventType,
+		),
+		Domain: domain,
+	}
+}
+
+func DomainLoginAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	orgDomainLoginAdded := &DomainLoginAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, orgDomainLoginAdded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Lg2kd", "unable to unmarshal org domain login added")
+	}
+
+	return orgDomainLoginAdded, nil
+}
+
+type DomainLoginRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Domain string `json:"domain,omitempty"`
+}
+
+func (e *DomainLoginRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *DomainLoginRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewRemoveOrgLoginDomainUniqueConstraint(e.Domain)}
+}
+
+func NewDomainLoginRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate, domain string) *DomainLoginRemovedEvent {
+	return &DomainLoginRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OrgDomainLoginRemovedEventType,
+		),
+		Domain: domain,
+	}
+}
+
+func DomainLoginRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	orgDomainLoginRemoved := &DomainLoginRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, orgDomainLoginRemoved)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Lg3rd", "unable to unmarshal org domain login removed")
+	}
+
+	return orgDomainLoginRemoved, nil
+}
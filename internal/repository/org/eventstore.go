@@ -11,12 +11,15 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, OrgDeactivatedEventType, OrgDeactivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, OrgReactivatedEventType, OrgReactivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, OrgRemovedEventType, OrgRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, OrgParentSetEventType, OrgParentSetEventMapper).
 		RegisterFilterEventMapper(AggregateType, OrgDomainAddedEventType, DomainAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, OrgDomainVerificationAddedEventType, DomainVerificationAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, OrgDomainVerificationFailedEventType, DomainVerificationFailedEventMapper).
 		RegisterFilterEventMapper(AggregateType, OrgDomainVerifiedEventType, DomainVerifiedEventMapper).
 		RegisterFilterEventMapper(AggregateType, OrgDomainPrimarySetEventType, DomainPrimarySetEventMapper).
 		RegisterFilterEventMapper(AggregateType, OrgDomainRemovedEventType, DomainRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, OrgDomainLoginAddedEventType, DomainLoginAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, OrgDomainLoginRemovedEventType, DomainLoginRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MemberAddedEventType, MemberAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MemberChangedEventType, MemberChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MemberRemovedEventType, MemberRemovedEventMapper).
@@ -70,6 +73,8 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, CustomTextSetEventType, CustomTextSetEventMapper).
 		RegisterFilterEventMapper(AggregateType, CustomTextRemovedEventType, CustomTextRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, CustomTextTemplateRemovedEventType, CustomTextTemplateRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, CustomMailTemplateSetEventType, CustomMailTemplateSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, CustomMailTemplateRemovedEventType, CustomMailTemplateRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, IDPConfigAddedEventType, IDPConfigAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, IDPConfigChangedEventType, IDPConfigChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, IDPConfigRemovedEventType, IDPConfigRemovedEventMapper).
@@ -19,6 +19,7 @@ const (
 	OrgDeactivatedEventType = orgEventTypePrefix + "deactivated"
 	OrgReactivatedEventType = orgEventTypePrefix + "reactivated"
 	OrgRemovedEventType     = orgEventTypePrefix + "removed"
+	OrgParentSetEventType   = orgEventTypePrefix + "parent.set"
 )
 
 func NewAddOrgNameUniqueConstraint(orgName string) *eventstore.EventUniqueConstraint {
@@ -223,3 +224,42 @@ func OrgRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
 	}, nil
 }
+
+// OrgParentSetEvent moves the org below the parent org,
+// an empty ParentID moves the org to the top level of the instance
+type OrgParentSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ParentID string `json:"parentId,omitempty"`
+}
+
+func (e *OrgParentSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *OrgParentSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewOrgParentSetEvent(ctx context.Context, aggregate *eventstore.Aggregate, parentID string) *OrgParentSetEvent {
+	return &OrgParentSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OrgParentSetEventType,
+		),
+		ParentID: parentID,
+	}
+}
+
+func OrgParentSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	parentSet := &OrgParentSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, parentSet)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Wq3nv", "unable to unmarshal org parent set")
+	}
+
+	return parentSet, nil
+}
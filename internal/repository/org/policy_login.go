@@ -32,8 +32,11 @@ func NewLoginPolicyAddedEvent(
 	ignoreUnknownUsernames,
 	allowDomainDiscovery,
 	disableLoginWithEmail,
-	disableLoginWithPhone bool,
+	disableLoginWithPhone,
+	allowMagicLink,
+	allowPhoneOTP bool,
 	passwordlessType domain.PasswordlessType,
+	botProtection domain.BotProtectionType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
 	externalLoginCheckLifetime,
@@ -56,7 +59,10 @@ func NewLoginPolicyAddedEvent(
 			allowDomainDiscovery,
 			disableLoginWithEmail,
 			disableLoginWithPhone,
+			allowMagicLink,
+			allowPhoneOTP,
 			passwordlessType,
+			botProtection,
 			defaultRedirectURI,
 			passwordCheckLifetime,
 			externalLoginCheckLifetime,
@@ -21,7 +21,12 @@ type NotificationPolicyAddedEvent struct {
 func NewNotificationPolicyAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	passwordChange,
+	newDeviceLogin,
+	mfaAdded,
+	mfaRemoved,
+	emailChange,
+	accountLocked bool,
 ) *NotificationPolicyAddedEvent {
 	return &NotificationPolicyAddedEvent{
 		NotificationPolicyAddedEvent: *policy.NewNotificationPolicyAddedEvent(
@@ -30,6 +35,11 @@ func NewNotificationPolicyAddedEvent(
 				aggregate,
 				NotificationPolicyAddedEventType),
 			passwordChange,
+			newDeviceLogin,
+			mfaAdded,
+			mfaRemoved,
+			emailChange,
+			accountLocked,
 		),
 	}
 }
@@ -20,22 +20,25 @@ const (
 type LoginPolicyAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	AllowUserNamePassword      bool                    `json:"allowUsernamePassword,omitempty"`
-	AllowRegister              bool                    `json:"allowRegister,omitempty"`
-	AllowExternalIDP           bool                    `json:"allowExternalIdp,omitempty"`
-	ForceMFA                   bool                    `json:"forceMFA,omitempty"`
-	HidePasswordReset          bool                    `json:"hidePasswordReset,omitempty"`
-	IgnoreUnknownUsernames     bool                    `json:"ignoreUnknownUsernames,omitempty"`
-	AllowDomainDiscovery       bool                    `json:"allowDomainDiscovery,omitempty"`
-	DisableLoginWithEmail      bool                    `json:"disableLoginWithEmail,omitempty"`
-	DisableLoginWithPhone      bool                    `json:"disableLoginWithPhone,omitempty"`
-	PasswordlessType           domain.PasswordlessType `json:"passwordlessType,omitempty"`
-	DefaultRedirectURI         string                  `json:"defaultRedirectURI,omitempty"`
-	PasswordCheckLifetime      time.Duration           `json:"passwordCheckLifetime,omitempty"`
-	ExternalLoginCheckLifetime time.Duration           `json:"externalLoginCheckLifetime,omitempty"`
-	MFAInitSkipLifetime        time.Duration           `json:"mfaInitSkipLifetime,omitempty"`
-	SecondFactorCheckLifetime  time.Duration           `json:"secondFactorCheckLifetime,omitempty"`
-	MultiFactorCheckLifetime   time.Duration           `json:"multiFactorCheckLifetime,omitempty"`
+	AllowUserNamePassword      bool                     `json:"allowUsernamePassword,omitempty"`
+	AllowRegister              bool                     `json:"allowRegister,omitempty"`
+	AllowExternalIDP           bool                     `json:"allowExternalIdp,omitempty"`
+	ForceMFA                   bool                     `json:"forceMFA,omitempty"`
+	HidePasswordReset          bool                     `json:"hidePasswordReset,omitempty"`
+	IgnoreUnknownUsernames     bool                     `json:"ignoreUnknownUsernames,omitempty"`
+	AllowDomainDiscovery       bool                     `json:"allowDomainDiscovery,omitempty"`
+	DisableLoginWithEmail      bool                     `json:"disableLoginWithEmail,omitempty"`
+	DisableLoginWithPhone      bool                     `json:"disableLoginWithPhone,omitempty"`
+	AllowMagicLink             bool                     `json:"allowMagicLink,omitempty"`
+	AllowPhoneOTP              bool                     `json:"allowPhoneOTP,omitempty"`
+	PasswordlessType           domain.PasswordlessType  `json:"passwordlessType,omitempty"`
+	BotProtection              domain.BotProtectionType `json:"botProtection,omitempty"`
+	DefaultRedirectURI         string                   `json:"defaultRedirectURI,omitempty"`
+	PasswordCheckLifetime      time.Duration            `json:"passwordCheckLifetime,omitempty"`
+	ExternalLoginCheckLifetime time.Duration            `json:"externalLoginCheckLifetime,omitempty"`
+	MFAInitSkipLifetime        time.Duration            `json:"mfaInitSkipLifetime,omitempty"`
+	SecondFactorCheckLifetime  time.Duration            `json:"secondFactorCheckLifetime,omitempty"`
+	MultiFactorCheckLifetime   time.Duration            `json:"multiFactorCheckLifetime,omitempty"`
 }
 
 func (e *LoginPolicyAddedEvent) Data() interface{} {
@@ -56,8 +59,11 @@ func NewLoginPolicyAddedEvent(
 	ignoreUnknownUsernames,
 	allowDomainDiscovery,
 	disableLoginWithEmail,
-	disableLoginWithPhone bool,
+	disableLoginWithPhone,
+	allowMagicLink,
+	allowPhoneOTP bool,
 	passwordlessType domain.PasswordlessType,
+	botProtection domain.BotProtectionType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
 	externalLoginCheckLifetime,
@@ -83,6 +89,9 @@ func NewLoginPolicyAddedEvent(
 		MultiFactorCheckLifetime:   multiFactorCheckLifetime,
 		DisableLoginWithEmail:      disableLoginWithEmail,
 		DisableLoginWithPhone:      disableLoginWithPhone,
+		AllowMagicLink:             allowMagicLink,
+		AllowPhoneOTP:              allowPhoneOTP,
+		BotProtection:              botProtection,
 	}
 }
 
@@ -102,22 +111,25 @@ func LoginPolicyAddedEventMapper(event *repository.Event) (eventstore.Event, err
 type LoginPolicyChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	AllowUserNamePassword      *bool                    `json:"allowUsernamePassword,omitempty"`
-	AllowRegister              *bool                    `json:"allowRegister,omitempty"`
-	AllowExternalIDP           *bool                    `json:"allowExternalIdp,omitempty"`
-	ForceMFA                   *bool                    `json:"forceMFA,omitempty"`
-	HidePasswordReset          *bool                    `json:"hidePasswordReset,omitempty"`
-	IgnoreUnknownUsernames     *bool                    `json:"ignoreUnknownUsernames,omitempty"`
-	AllowDomainDiscovery       *bool                    `json:"allowDomainDiscovery,omitempty"`
-	DisableLoginWithEmail      *bool                    `json:"disableLoginWithEmail,omitempty"`
-	DisableLoginWithPhone      *bool                    `json:"disableLoginWithPhone,omitempty"`
-	PasswordlessType           *domain.PasswordlessType `json:"passwordlessType,omitempty"`
-	DefaultRedirectURI         *string                  `json:"defaultRedirectURI,omitempty"`
-	PasswordCheckLifetime      *time.Duration           `json:"passwordCheckLifetime,omitempty"`
-	ExternalLoginCheckLifetime *time.Duration           `json:"externalLoginCheckLifetime,omitempty"`
-	MFAInitSkipLifetime        *time.Duration           `json:"mfaInitSkipLifetime,omitempty"`
-	SecondFactorCheckLifetime  *time.Duration           `json:"secondFactorCheckLifetime,omitempty"`
-	MultiFactorCheckLifetime   *time.Duration           `json:"multiFactorCheckLifetime,omitempty"`
+	AllowUserNamePassword      *bool                     `json:"allowUsernamePassword,omitempty"`
+	AllowRegister              *bool                     `json:"allowRegister,omitempty"`
+	AllowExternalIDP           *bool                     `json:"allowExternalIdp,omitempty"`
+	ForceMFA                   *bool                     `json:"forceMFA,omitempty"`
+	HidePasswordReset          *bool                     `json:"hidePasswordReset,omitempty"`
+	IgnoreUnknownUsernames     *bool                     `json:"ignoreUnknownUsernames,omitempty"`
+	AllowDomainDiscovery       *bool                     `json:"allowDomainDiscovery,omitempty"`
+	DisableLoginWithEmail      *bool                     `json:"disableLoginWithEmail,omitempty"`
+	DisableLoginWithPhone      *bool                     `json:"disableLoginWithPhone,omitempty"`
+	AllowMagicLink             *bool                     `json:"allowMagicLink,omitempty"`
+	AllowPhoneOTP              *bool                     `json:"allowPhoneOTP,omitempty"`
+	PasswordlessType           *domain.PasswordlessType  `json:"passwordlessType,omitempty"`
+	BotProtection              *domain.BotProtectionType `json:"botProtection,omitempty"`
+	DefaultRedirectURI         *string                   `json:"defaultRedirectURI,omitempty"`
+	PasswordCheckLifetime      *time.Duration            `json:"passwordCheckLifetime,omitempty"`
+	ExternalLoginCheckLifetime *time.Duration            `json:"externalLoginCheckLifetime,omitempty"`
+	MFAInitSkipLifetime        *time.Duration            `json:"mfaInitSkipLifetime,omitempty"`
+	SecondFactorCheckLifetime  *time.Duration            `json:"secondFactorCheckLifetime,omitempty"`
+	MultiFactorCheckLifetime   *time.Duration            `json:"multiFactorCheckLifetime,omitempty"`
 }
 
 func (e *LoginPolicyChangedEvent) Data() interface{} {
@@ -242,6 +254,24 @@ func ChangeDisableLoginWithPhone(DisableLoginWithPhone bool) func(*LoginPolicyCh
 	}
 }
 
+func ChangeAllowMagicLink(allowMagicLink bool) func(*LoginPolicyChangedEvent) {
+	return func(e *LoginPolicyChangedEvent) {
+		e.AllowMagicLink = &allowMagicLink
+	}
+}
+
+func ChangeAllowPhoneOTP(allowPhoneOTP bool) func(*LoginPolicyChangedEvent) {
+	return func(e *LoginPolicyChangedEvent) {
+		e.AllowPhoneOTP = &allowPhoneOTP
+	}
+}
+
+func ChangeBotProtection(botProtection domain.BotProtectionType) func(*LoginPolicyChangedEvent) {
+	return func(e *LoginPolicyChangedEvent) {
+		e.BotProtection = &botProtection
+	}
+}
+
 func LoginPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &LoginPolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
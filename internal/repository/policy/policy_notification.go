@@ -18,6 +18,11 @@ type NotificationPolicyAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
 	PasswordChange bool `json:"passwordChange,omitempty"`
+	NewDeviceLogin bool `json:"newDeviceLogin,omitempty"`
+	MFAAdded       bool `json:"mfaAdded,omitempty"`
+	MFARemoved     bool `json:"mfaRemoved,omitempty"`
+	EmailChange    bool `json:"emailChange,omitempty"`
+	AccountLocked  bool `json:"accountLocked,omitempty"`
 }
 
 func (e *NotificationPolicyAddedEvent) Data() interface{} {
@@ -30,11 +35,21 @@ func (e *NotificationPolicyAddedEvent) UniqueConstraints() []*eventstore.EventUn
 
 func NewNotificationPolicyAddedEvent(
 	base *eventstore.BaseEvent,
-	passwordChange bool,
+	passwordChange,
+	newDeviceLogin,
+	mfaAdded,
+	mfaRemoved,
+	emailChange,
+	accountLocked bool,
 ) *NotificationPolicyAddedEvent {
 	return &NotificationPolicyAddedEvent{
 		BaseEvent:      *base,
 		PasswordChange: passwordChange,
+		NewDeviceLogin: newDeviceLogin,
+		MFAAdded:       mfaAdded,
+		MFARemoved:     mfaRemoved,
+		EmailChange:    emailChange,
+		AccountLocked:  accountLocked,
 	}
 }
 
@@ -55,6 +70,11 @@ type NotificationPolicyChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
 	PasswordChange *bool `json:"passwordChange,omitempty"`
+	NewDeviceLogin *bool `json:"newDeviceLogin,omitempty"`
+	MFAAdded       *bool `json:"mfaAdded,omitempty"`
+	MFARemoved     *bool `json:"mfaRemoved,omitempty"`
+	EmailChange    *bool `json:"emailChange,omitempty"`
+	AccountLocked  *bool `json:"accountLocked,omitempty"`
 }
 
 func (e *NotificationPolicyChangedEvent) Data() interface{} {
@@ -89,6 +109,36 @@ func ChangePasswordChange(passwordChange bool) func(*NotificationPolicyChangedEv
 	}
 }
 
+func ChangeNewDeviceLogin(newDeviceLogin bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.NewDeviceLogin = &newDeviceLogin
+	}
+}
+
+func ChangeMFAAdded(mfaAdded bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.MFAAdded = &mfaAdded
+	}
+}
+
+func ChangeMFARemoved(mfaRemoved bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.MFARemoved = &mfaRemoved
+	}
+}
+
+func ChangeEmailChange(emailChange bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.EmailChange = &emailChange
+	}
+}
+
+func ChangeAccountLocked(accountLocked bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.AccountLocked = &accountLocked
+	}
+}
+
 func NotificationPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &NotificationPolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
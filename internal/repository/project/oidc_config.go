@@ -16,6 +16,7 @@ const (
 	OIDCConfigAddedType                = applicationEventTypePrefix + "config.oidc.added"
 	OIDCConfigChangedType              = applicationEventTypePrefix + "config.oidc.changed"
 	OIDCConfigSecretChangedType        = applicationEventTypePrefix + "config.oidc.secret.changed"
+	OIDCConfigRegistrationTokenSetType = applicationEventTypePrefix + "config.oidc.registration.token.set"
 	OIDCClientSecretCheckSucceededType = applicationEventTypePrefix + "oidc.secret.check.succeeded"
 	OIDCClientSecretCheckFailedType    = applicationEventTypePrefix + "oidc.secret.check.failed"
 )
@@ -41,6 +42,7 @@ type OIDCConfigAddedEvent struct {
 	ClockSkew                time.Duration              `json:"clockSkew,omitempty"`
 	AdditionalOrigins        []string                   `json:"additionalOrigins,omitempty"`
 	SkipNativeAppSuccessPage bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	RequireConsent           bool                       `json:"requireConsent,omitempty"`
 }
 
 func (e *OIDCConfigAddedEvent) Data() interface{} {
@@ -72,6 +74,7 @@ func NewOIDCConfigAddedEvent(
 	clockSkew time.Duration,
 	additionalOrigins []string,
 	skipNativeAppSuccessPage bool,
+	requireConsent bool,
 ) *OIDCConfigAddedEvent {
 	return &OIDCConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -97,6 +100,7 @@ func NewOIDCConfigAddedEvent(
 		ClockSkew:                clockSkew,
 		AdditionalOrigins:        additionalOrigins,
 		SkipNativeAppSuccessPage: skipNativeAppSuccessPage,
+		RequireConsent:           requireConsent,
 	}
 }
 
@@ -182,7 +186,10 @@ func (e *OIDCConfigAddedEvent) Validate(cmd eventstore.Command) bool {
 			return false
 		}
 	}
-	return e.SkipNativeAppSuccessPage == c.SkipNativeAppSuccessPage
+	if e.SkipNativeAppSuccessPage != c.SkipNativeAppSuccessPage {
+		return false
+	}
+	return e.RequireConsent == c.RequireConsent
 }
 
 func OIDCConfigAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
@@ -217,6 +224,7 @@ type OIDCConfigChangedEvent struct {
 	ClockSkew                *time.Duration              `json:"clockSkew,omitempty"`
 	AdditionalOrigins        *[]string                   `json:"additionalOrigins,omitempty"`
 	SkipNativeAppSuccessPage *bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	RequireConsent           *bool                       `json:"requireConsent,omitempty"`
 }
 
 func (e *OIDCConfigChangedEvent) Data() interface{} {
@@ -343,6 +351,12 @@ func ChangeSkipNativeAppSuccessPage(skipNativeAppSuccessPage bool) func(event *O
 	}
 }
 
+func ChangeRequireConsent(requireConsent bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RequireConsent = &requireConsent
+	}
+}
+
 func OIDCConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &OIDCConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -401,6 +415,51 @@ func OIDCConfigSecretChangedEventMapper(event *repository.Event) (eventstore.Eve
 	return e, nil
 }
 
+type OIDCConfigRegistrationTokenSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID                   string              `json:"appId"`
+	RegistrationAccessToken *crypto.CryptoValue `json:"registrationAccessToken,omitempty"`
+}
+
+func (e *OIDCConfigRegistrationTokenSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *OIDCConfigRegistrationTokenSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewOIDCConfigRegistrationTokenSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID string,
+	registrationAccessToken *crypto.CryptoValue,
+) *OIDCConfigRegistrationTokenSetEvent {
+	return &OIDCConfigRegistrationTokenSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OIDCConfigRegistrationTokenSetType,
+		),
+		AppID:                   appID,
+		RegistrationAccessToken: registrationAccessToken,
+	}
+}
+
+func OIDCConfigRegistrationTokenSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &OIDCConfigRegistrationTokenSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "OIDC-Rg2tS", "unable to unmarshal oidc config registration token")
+	}
+
+	return e, nil
+}
+
 type OIDCConfigSecretCheckSucceededEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
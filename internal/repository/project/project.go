@@ -96,6 +96,7 @@ type ProjectChangeEvent struct {
 	ProjectRoleCheck       *bool                          `json:"projectRoleCheck,omitempty"`
 	HasProjectCheck        *bool                          `json:"hasProjectCheck,omitempty"`
 	PrivateLabelingSetting *domain.PrivateLabelingSetting `json:"privateLabelingSetting,omitempty"`
+	AllowImpersonation     *bool                          `json:"allowImpersonation,omitempty"`
 	oldName                string
 }
 
@@ -168,6 +169,12 @@ func ChangePrivateLabelingSetting(ChangePrivateLabelingSetting domain.PrivateLab
 	}
 }
 
+func ChangeAllowImpersonation(allowImpersonation bool) func(event *ProjectChangeEvent) {
+	return func(e *ProjectChangeEvent) {
+		e.AllowImpersonation = &allowImpersonation
+	}
+}
+
 func ProjectChangeEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &ProjectChangeEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -0,0 +1,105 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	registrationTokenEventTypePrefix = projectEventTypePrefix + "registration.token."
+	RegistrationTokenAddedType       = registrationTokenEventTypePrefix + "added"
+	RegistrationTokenRemovedType     = registrationTokenEventTypePrefix + "removed"
+)
+
+type RegistrationTokenAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID        string    `json:"tokenId"`
+	ExpirationDate time.Time `json:"expirationDate,omitempty"`
+}
+
+func (e *RegistrationTokenAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *RegistrationTokenAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewRegistrationTokenAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+	expirationDate time.Time,
+) *RegistrationTokenAddedEvent {
+	return &RegistrationTokenAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RegistrationTokenAddedType,
+		),
+		TokenID:        tokenID,
+		ExpirationDate: expirationDate,
+	}
+}
+
+func RegistrationTokenAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &RegistrationTokenAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-Rg8tk", "unable to unmarshal registration token")
+	}
+
+	return e, nil
+}
+
+type RegistrationTokenRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID string `json:"tokenId"`
+}
+
+func (e *RegistrationTokenRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *RegistrationTokenRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewRegistrationTokenRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+) *RegistrationTokenRemovedEvent {
+	return &RegistrationTokenRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RegistrationTokenRemovedType,
+		),
+		TokenID: tokenID,
+	}
+}
+
+func RegistrationTokenRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &RegistrationTokenRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-Rg9tk", "unable to unmarshal registration token removed")
+	}
+
+	return e, nil
+}
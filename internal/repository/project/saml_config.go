@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
@@ -18,10 +19,17 @@ const (
 type SAMLConfigAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	AppID       string `json:"appId"`
-	EntityID    string `json:"entityId"`
-	Metadata    []byte `json:"metadata,omitempty"`
-	MetadataURL string `json:"metadata_url,omitempty"`
+	AppID              string                        `json:"appId"`
+	EntityID           string                        `json:"entityId"`
+	Metadata           []byte                        `json:"metadata,omitempty"`
+	MetadataURL        string                        `json:"metadata_url,omitempty"`
+	IDPInitiatedSSO    bool                          `json:"idpInitiatedSSO,omitempty"`
+	DefaultRelayState  string                        `json:"defaultRelayState,omitempty"`
+	SignatureAlgorithm domain.SAMLSignatureAlgorithm `json:"signatureAlgorithm,omitempty"`
+	SigningMode        domain.SAMLSigningMode        `json:"signingMode,omitempty"`
+	EncryptAssertion   bool                          `json:"encryptAssertion,omitempty"`
+	NameIDFormat       domain.SAMLNameIDFormat       `json:"nameIdFormat,omitempty"`
+	AttributeMappings  domain.SAMLAttributeMappings  `json:"attributeMappings,omitempty"`
 }
 
 func (e *SAMLConfigAddedEvent) Data() interface{} {
@@ -52,6 +60,13 @@ func NewSAMLConfigAddedEvent(
 	entityID string,
 	metadata []byte,
 	metadataURL string,
+	idpInitiatedSSO bool,
+	defaultRelayState string,
+	signatureAlgorithm domain.SAMLSignatureAlgorithm,
+	signingMode domain.SAMLSigningMode,
+	encryptAssertion bool,
+	nameIDFormat domain.SAMLNameIDFormat,
+	attributeMappings domain.SAMLAttributeMappings,
 ) *SAMLConfigAddedEvent {
 	return &SAMLConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -59,10 +74,17 @@ func NewSAMLConfigAddedEvent(
 			aggregate,
 			SAMLConfigAddedType,
 		),
-		AppID:       appID,
-		EntityID:    entityID,
-		Metadata:    metadata,
-		MetadataURL: metadataURL,
+		AppID:              appID,
+		EntityID:           entityID,
+		Metadata:           metadata,
+		MetadataURL:        metadataURL,
+		IDPInitiatedSSO:    idpInitiatedSSO,
+		DefaultRelayState:  defaultRelayState,
+		SignatureAlgorithm: signatureAlgorithm,
+		SigningMode:        signingMode,
+		EncryptAssertion:   encryptAssertion,
+		NameIDFormat:       nameIDFormat,
+		AttributeMappings:  attributeMappings,
 	}
 }
 
@@ -82,11 +104,18 @@ func SAMLConfigAddedEventMapper(event *repository.Event) (eventstore.Event, erro
 type SAMLConfigChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	AppID       string  `json:"appId"`
-	EntityID    string  `json:"entityId"`
-	Metadata    []byte  `json:"metadata,omitempty"`
-	MetadataURL *string `json:"metadata_url,omitempty"`
-	oldEntityID string
+	AppID              string                         `json:"appId"`
+	EntityID           string                         `json:"entityId"`
+	Metadata           []byte                         `json:"metadata,omitempty"`
+	MetadataURL        *string                        `json:"metadata_url,omitempty"`
+	IDPInitiatedSSO    *bool                          `json:"idpInitiatedSSO,omitempty"`
+	DefaultRelayState  *string                        `json:"defaultRelayState,omitempty"`
+	SignatureAlgorithm *domain.SAMLSignatureAlgorithm `json:"signatureAlgorithm,omitempty"`
+	SigningMode        *domain.SAMLSigningMode        `json:"signingMode,omitempty"`
+	EncryptAssertion   *bool                          `json:"encryptAssertion,omitempty"`
+	NameIDFormat       *domain.SAMLNameIDFormat       `json:"nameIdFormat,omitempty"`
+	AttributeMappings  *domain.SAMLAttributeMappings  `json:"attributeMappings,omitempty"`
+	oldEntityID        string
 }
 
 func (e *SAMLConfigChangedEvent) Data() interface{} {
@@ -149,6 +178,36 @@ func ChangeEntityID(entityID string) func(event *SAMLConfigChangedEvent) {
 	}
 }
 
+func ChangeIDPInitiatedSSO(idpInitiatedSSO bool) func(event *SAMLConfigChangedEvent) {
+	return func(e *SAMLConfigChangedEvent) {
+		e.IDPInitiatedSSO = &idpInitiatedSSO
+	}
+}
+
+func ChangeDefaultRelayState(defaultRelayState string) func(event *SAMLConfigChangedEvent) {
+	return func(e *SAMLConfigChangedEvent) {
+		e.DefaultRelayState = &defaultRelayState
+	}
+}
+
+func ChangeSignatureAlgorithm(signatureAlgorithm domain.SAMLSignatureAlgorithm) func(event *SAMLConfigChangedEvent) {
+	return func(e *SAMLConfigChangedEvent) {
+		e.SignatureAlgorithm = &signatureAlgorithm
+	}
+}
+
+func ChangeSigningMode(signingMode domain.SAMLSigningMode) func(event *SAMLConfigChangedEvent) {
+	return func(e *SAMLConfigChangedEvent) {
+		e.SigningMode = &signingMode
+	}
+}
+
+func ChangeEncryptAssertion(encryptAssertion bool) func(event *SAMLConfigChangedEvent) {
+	return func(e *SAMLConfigChangedEvent) {
+		e.EncryptAssertion = &encryptAssertion
+	}
+}
+
 func SAMLConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &SAMLConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -161,3 +220,15 @@ func SAMLConfigChangedEventMapper(event *repository.Event) (eventstore.Event, er
 
 	return e, nil
 }
+
+func ChangeNameIDFormat(nameIDFormat domain.SAMLNameIDFormat) func(event *SAMLConfigChangedEvent) {
+	return func(e *SAMLConfigChangedEvent) {
+		e.NameIDFormat = &nameIDFormat
+	}
+}
+
+func ChangeAttributeMappings(attributeMappings domain.SAMLAttributeMappings) func(event *SAMLConfigChangedEvent) {
+	return func(e *SAMLConfigChangedEvent) {
+		e.AttributeMappings = &attributeMappings
+	}
+}
@@ -0,0 +1,15 @@
+package session
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, AddedType, eventstore.GenericEventMapper[AddedEvent]).
+		RegisterFilterEventMapper(AggregateType, UserCheckedType, eventstore.GenericEventMapper[UserCheckedEvent]).
+		RegisterFilterEventMapper(AggregateType, PasswordCheckedType, eventstore.GenericEventMapper[PasswordCheckedEvent]).
+		RegisterFilterEventMapper(AggregateType, IntentCheckedType, eventstore.GenericEventMapper[IntentCheckedEvent]).
+		RegisterFilterEventMapper(AggregateType, WebAuthNChallengedType, eventstore.GenericEventMapper[WebAuthNChallengedEvent]).
+		RegisterFilterEventMapper(AggregateType, WebAuthNCheckedType, eventstore.GenericEventMapper[WebAuthNCheckedEvent]).
+		RegisterFilterEventMapper(AggregateType, TokenSetType, eventstore.GenericEventMapper[TokenSetEvent]).
+		RegisterFilterEventMapper(AggregateType, MetadataSetType, eventstore.GenericEventMapper[MetadataSetEvent]).
+		RegisterFilterEventMapper(AggregateType, TerminateType, eventstore.GenericEventMapper[TerminateEvent])
+}
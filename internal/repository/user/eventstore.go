@@ -43,6 +43,8 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, UserRemovedType, UserRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserTokenAddedType, UserTokenAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserTokenRemovedType, UserTokenRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserImpersonationStartedType, UserImpersonationStartedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserImpersonationEndedType, UserImpersonationEndedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserDomainClaimedType, DomainClaimedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserDomainClaimedSentType, DomainClaimedSentEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserUserNameChangedType, UsernameChangedEventMapper).
@@ -56,6 +58,7 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanInitializedCheckSucceededType, HumanInitializedCheckSucceededEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanInitializedCheckFailedType, HumanInitializedCheckFailedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanSignedOutType, HumanSignedOutEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanSAMLSessionAddedType, HumanSAMLSessionAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordChangedType, HumanPasswordChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordCodeAddedType, HumanPasswordCodeAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordCodeSentType, HumanPasswordCodeSentEventMapper).
@@ -81,6 +84,7 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanAvatarAddedType, HumanAvatarAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanAvatarRemovedType, HumanAvatarRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanAddressChangedType, HumanAddressChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanNotificationPreferencesSetType, HumanNotificationPreferencesSetEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanMFAInitSkippedType, HumanMFAInitSkippedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanMFAOTPAddedType, HumanOTPAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanMFAOTPVerifiedType, HumanOTPVerifiedEventMapper).
@@ -106,6 +110,24 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanPasswordlessInitCodeSentType, HumanPasswordlessInitCodeSentEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordlessInitCodeCheckFailedType, HumanPasswordlessInitCodeCodeCheckFailedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordlessInitCodeCheckSucceededType, HumanPasswordlessInitCodeCodeCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMagicLinkRequestedType, HumanMagicLinkRequestedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMagicLinkSentType, HumanMagicLinkSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMagicLinkCheckSucceededType, HumanMagicLinkCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMagicLinkCheckFailedType, HumanMagicLinkCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPhoneOTPRequestedType, HumanPhoneOTPRequestedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPhoneOTPSentType, HumanPhoneOTPSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPhoneOTPCheckSucceededType, HumanPhoneOTPCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPhoneOTPCheckFailedType, HumanPhoneOTPCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPushDeviceAddedType, HumanPushDeviceAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPushDeviceVerifiedType, HumanPushDeviceVerifiedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPushDeviceRemovedType, HumanPushDeviceRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPushChallengeRequestedType, HumanPushChallengeRequestedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPushChallengeSentType, HumanPushChallengeSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPushCheckSucceededType, HumanPushCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPushCheckFailedType, HumanPushCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanConsentGrantedType, HumanConsentGrantedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanConsentRevokedType, HumanConsentRevokedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanConsentDeniedType, HumanConsentDeniedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanRefreshTokenAddedType, HumanRefreshTokenAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanRefreshTokenRenewedType, HumanRefreshTokenRenewedEventEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanRefreshTokenRemovedType, HumanRefreshTokenRemovedEventEventMapper).
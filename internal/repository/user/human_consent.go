@@ -0,0 +1,147 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	consentEventPrefix      = humanEventPrefix + "consent."
+	HumanConsentGrantedType = consentEventPrefix + "granted"
+	HumanConsentRevokedType = consentEventPrefix + "revoked"
+	HumanConsentDeniedType  = consentEventPrefix + "denied"
+)
+
+type HumanConsentGrantedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID  string   `json:"clientID"`
+	AppID     string   `json:"appID,omitempty"`
+	ProjectID string   `json:"projectID,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
+	*AuthRequestInfo
+}
+
+func (e *HumanConsentGrantedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanConsentGrantedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanConsentGrantedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID,
+	appID,
+	projectID string,
+	scopes []string,
+	info *AuthRequestInfo,
+) *HumanConsentGrantedEvent {
+	return &HumanConsentGrantedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanConsentGrantedType,
+		),
+		ClientID:        clientID,
+		AppID:           appID,
+		ProjectID:       projectID,
+		Scopes:          scopes,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanConsentGrantedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	granted := &HumanConsentGrantedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, granted)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-p4Hxr", "unable to unmarshal human consent granted")
+	}
+	return granted, nil
+}
+
+type HumanConsentRevokedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID string `json:"clientID"`
+}
+
+func (e *HumanConsentRevokedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanConsentRevokedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanConsentRevokedEvent(ctx context.Context, aggregate *eventstore.Aggregate, clientID string) *HumanConsentRevokedEvent {
+	return &HumanConsentRevokedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanConsentRevokedType,
+		),
+		ClientID: clientID,
+	}
+}
+
+func HumanConsentRevokedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	revoked := &HumanConsentRevokedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, revoked)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Ka9vd", "unable to unmarshal human consent revoked")
+	}
+	return revoked, nil
+}
+
+// HumanConsentDeniedEvent records that the user denied the scopes requested by the client in an auth request,
+// an existing consent of the user is not changed
+type HumanConsentDeniedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID string   `json:"clientID"`
+	Scopes   []string `json:"scopes,omitempty"`
+	*AuthRequestInfo
+}
+
+func (e *HumanConsentDeniedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanConsentDeniedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanConsentDeniedEvent(ctx context.Context, aggregate *eventstore.Aggregate, clientID string, scopes []string, info *AuthRequestInfo) *HumanConsentDeniedEvent {
+	return &HumanConsentDeniedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanConsentDeniedType,
+		),
+		ClientID:        clientID,
+		Scopes:          scopes,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanConsentDeniedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	denied := &HumanConsentDeniedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, denied)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Qd7nw", "unable to unmarshal human consent denied")
+	}
+	return denied, nil
+}
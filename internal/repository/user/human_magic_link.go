@@ -0,0 +1,195 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	magicLinkEventPrefix             = humanEventPrefix + "magiclink."
+	HumanMagicLinkRequestedType      = magicLinkEventPrefix + "requested"
+	HumanMagicLinkSentType           = magicLinkEventPrefix + "sent"
+	HumanMagicLinkCheckSucceededType = magicLinkEventPrefix + "check.succeeded"
+	HumanMagicLinkCheckFailedType    = magicLinkEventPrefix + "check.failed"
+)
+
+type HumanMagicLinkRequestedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	LinkID string              `json:"linkID"`
+	Code   *crypto.CryptoValue `json:"code"`
+	Expiry time.Duration       `json:"expiry"`
+	*AuthRequestInfo
+}
+
+func (e *HumanMagicLinkRequestedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanMagicLinkRequestedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanMagicLinkRequestedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	linkID string,
+	code *crypto.CryptoValue,
+	expiry time.Duration,
+	info *AuthRequestInfo,
+) *HumanMagicLinkRequestedEvent {
+	return &HumanMagicLinkRequestedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMagicLinkRequestedType,
+		),
+		LinkID:          linkID,
+		Code:            code,
+		Expiry:          expiry,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanMagicLinkRequestedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	requested := &HumanMagicLinkRequestedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, requested)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Mq8fz", "unable to unmarshal human magic link requested")
+	}
+	return requested, nil
+}
+
+type HumanMagicLinkSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	LinkID string `json:"linkID"`
+}
+
+func (e *HumanMagicLinkSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanMagicLinkSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanMagicLinkSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	linkID string,
+) *HumanMagicLinkSentEvent {
+	return &HumanMagicLinkSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMagicLinkSentType,
+		),
+		LinkID: linkID,
+	}
+}
+
+func HumanMagicLinkSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	sent := &HumanMagicLinkSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, sent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-2Hd9s", "unable to unmarshal human magic link sent")
+	}
+	return sent, nil
+}
+
+type HumanMagicLinkCheckSucceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	LinkID string `json:"linkID"`
+	*AuthRequestInfo
+}
+
+func (e *HumanMagicLinkCheckSucceededEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanMagicLinkCheckSucceededEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanMagicLinkCheckSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	linkID string,
+	info *AuthRequestInfo,
+) *HumanMagicLinkCheckSucceededEvent {
+	return &HumanMagicLinkCheckSucceededEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMagicLinkCheckSucceededType,
+		),
+		LinkID:          linkID,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanMagicLinkCheckSucceededEventMapper(event *repository.Event) (eventstore.Event, error) {
+	succeeded := &HumanMagicLinkCheckSucceededEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, succeeded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Vb3kq", "unable to unmarshal human magic link check succeeded")
+	}
+	return succeeded, nil
+}
+
+type HumanMagicLinkCheckFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	LinkID string `json:"linkID"`
+	*AuthRequestInfo
+}
+
+func (e *HumanMagicLinkCheckFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanMagicLinkCheckFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanMagicLinkCheckFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	linkID string,
+	info *AuthRequestInfo,
+) *HumanMagicLinkCheckFailedEvent {
+	return &HumanMagicLinkCheckFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMagicLinkCheckFailedType,
+		),
+		LinkID:          linkID,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanMagicLinkCheckFailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	failed := &HumanMagicLinkCheckFailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, failed)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-0pLs7", "unable to unmarshal human magic link check failed")
+	}
+	return failed, nil
+}
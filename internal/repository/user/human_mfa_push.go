@@ -0,0 +1,352 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	pushEventPrefix                 = mfaEventPrefix + "push."
+	HumanPushDeviceAddedType        = pushEventPrefix + "device.added"
+	HumanPushDeviceVerifiedType     = pushEventPrefix + "device.verified"
+	HumanPushDeviceRemovedType      = pushEventPrefix + "device.removed"
+	HumanPushChallengeRequestedType = pushEventPrefix + "challenge.requested"
+	HumanPushChallengeSentType      = pushEventPrefix + "challenge.sent"
+	HumanPushCheckSucceededType     = pushEventPrefix + "check.succeeded"
+	HumanPushCheckFailedType        = pushEventPrefix + "check.failed"
+)
+
+// HumanPushDeviceAddedEvent registers a device, which can only be used after it signed the verification code
+type HumanPushDeviceAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DeviceID  string              `json:"deviceId"`
+	Name      string              `json:"name"`
+	Platform  domain.PushPlatform `json:"platform"`
+	Token     *crypto.CryptoValue `json:"token"`
+	PublicKey []byte              `json:"publicKey"`
+	Code      *crypto.CryptoValue `json:"code"`
+	Expiry    time.Duration       `json:"expiry"`
+}
+
+func (e *HumanPushDeviceAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanPushDeviceAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanPushDeviceAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	deviceID,
+	name string,
+	platform domain.PushPlatform,
+	token *crypto.CryptoValue,
+	publicKey []byte,
+	code *crypto.CryptoValue,
+	expiry time.Duration,
+) *HumanPushDeviceAddedEvent {
+	return &HumanPushDeviceAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPushDeviceAddedType,
+		),
+		DeviceID:  deviceID,
+		Name:      name,
+		Platform:  platform,
+		Token:     token,
+		PublicKey: publicKey,
+		Code:      code,
+		Expiry:    expiry,
+	}
+}
+
+func HumanPushDeviceAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	added := &HumanPushDeviceAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, added)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Pu3da", "unable to unmarshal human push device added")
+	}
+	return added, nil
+}
+
+type HumanPushDeviceVerifiedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DeviceID string `json:"deviceId"`
+}
+
+func (e *HumanPushDeviceVerifiedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanPushDeviceVerifiedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanPushDeviceVerifiedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	deviceID string,
+) *HumanPushDeviceVerifiedEvent {
+	return &HumanPushDeviceVerifiedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPushDeviceVerifiedType,
+		),
+		DeviceID: deviceID,
+	}
+}
+
+func HumanPushDeviceVerifiedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	verified := &HumanPushDeviceVerifiedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, verified)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Pu3dv", "unable to unmarshal human push device verified")
+	}
+	return verified, nil
+}
+
+type HumanPushDeviceRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DeviceID string `json:"deviceId"`
+}
+
+func (e *HumanPushDeviceRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanPushDeviceRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanPushDeviceRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	deviceID string,
+) *HumanPushDeviceRemovedEvent {
+	return &HumanPushDeviceRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPushDeviceRemovedType,
+		),
+		DeviceID: deviceID,
+	}
+}
+
+func HumanPushDeviceRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	removed := &HumanPushDeviceRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, removed)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Pu3dr", "unable to unmarshal human push device removed")
+	}
+	return removed, nil
+}
+
+// PushTarget is a device the push notification of a challenge is sent to
+type PushTarget struct {
+	DeviceID string              `json:"deviceId"`
+	Platform domain.PushPlatform `json:"platform"`
+	Token    *crypto.CryptoValue `json:"token"`
+}
+
+type HumanPushChallengeRequestedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ChallengeID string        `json:"challengeId"`
+	Expiry      time.Duration `json:"expiry"`
+	Targets     []*PushTarget `json:"targets"`
+	*AuthRequestInfo
+}
+
+func (e *HumanPushChallengeRequestedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanPushChallengeRequestedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanPushChallengeRequestedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	challengeID string,
+	expiry time.Duration,
+	targets []*PushTarget,
+	info *AuthRequestInfo,
+) *HumanPushChallengeRequestedEvent {
+	return &HumanPushChallengeRequestedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPushChallengeRequestedType,
+		),
+		ChallengeID:     challengeID,
+		Expiry:          expiry,
+		Targets:         targets,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanPushChallengeRequestedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	requested := &HumanPushChallengeRequestedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, requested)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Pu3cq", "unable to unmarshal human push challenge requested")
+	}
+	return requested, nil
+}
+
+type HumanPushChallengeSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ChallengeID string `json:"challengeId"`
+}
+
+func (e *HumanPushChallengeSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanPushChallengeSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanPushChallengeSentEvent(ctx context.Context, aggregate *eventstore.Aggregate, challengeID string) *HumanPushChallengeSentEvent {
+	return &HumanPushChallengeSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPushChallengeSentType,
+		),
+		ChallengeID: challengeID,
+	}
+}
+
+func HumanPushChallengeSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	sent := &HumanPushChallengeSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, sent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Pu3cs", "unable to unmarshal human push challenge sent")
+	}
+	return sent, nil
+}
+
+// HumanPushCheckSucceededEvent is pushed when the user approved the sign-in in the app,
+// the info of the auth request is the one of the challenge, so the user session of the login gets verified
+type HumanPushCheckSucceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ChallengeID string `json:"challengeId"`
+	// DeviceID is the device which signed the answer
+	DeviceID string `json:"deviceId"`
+	*AuthRequestInfo
+}
+
+func (e *HumanPushCheckSucceededEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanPushCheckSucceededEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanPushCheckSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	challengeID,
+	deviceID string,
+	info *AuthRequestInfo,
+) *HumanPushCheckSucceededEvent {
+	return &HumanPushCheckSucceededEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPushCheckSucceededType,
+		),
+		ChallengeID:     challengeID,
+		DeviceID:        deviceID,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanPushCheckSucceededEventMapper(event *repository.Event) (eventstore.Event, error) {
+	succeeded := &HumanPushCheckSucceededEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, succeeded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Pu3ss", "unable to unmarshal human push check succeeded")
+	}
+	return succeeded, nil
+}
+
+// HumanPushCheckFailedEvent is pushed when the user denied the sign-in in the app
+type HumanPushCheckFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ChallengeID string `json:"challengeId"`
+	// DeviceID is the device which signed the answer
+	DeviceID string `json:"deviceId"`
+	*AuthRequestInfo
+}
+
+func (e *HumanPushCheckFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanPushCheckFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanPushCheckFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	challengeID,
+	deviceID string,
+	info *AuthRequestInfo,
+) *HumanPushCheckFailedEvent {
+	return &HumanPushCheckFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPushCheckFailedType,
+		),
+		ChallengeID:     challengeID,
+		DeviceID:        deviceID,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanPushCheckFailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	failed := &HumanPushCheckFailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, failed)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Pu3sf", "unable to unmarshal human push check failed")
+	}
+	return failed, nil
+}
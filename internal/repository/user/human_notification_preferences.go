@@ -0,0 +1,69 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	notificationPreferencesEventPrefix  = humanEventPrefix + "notification.preferences."
+	HumanNotificationPreferencesSetType = notificationPreferencesEventPrefix + "set"
+)
+
+// HumanNotificationPreferencesSetEvent contains all preferences of the user,
+// the security notifications are only sent if they are enabled in the notification policy as well
+type HumanNotificationPreferencesSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	NewDeviceLogin bool `json:"newDeviceLogin"`
+	MFAAdded       bool `json:"mfaAdded"`
+	MFARemoved     bool `json:"mfaRemoved"`
+	EmailChange    bool `json:"emailChange"`
+	AccountLocked  bool `json:"accountLocked"`
+}
+
+func (e *HumanNotificationPreferencesSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanNotificationPreferencesSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanNotificationPreferencesSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	newDeviceLogin,
+	mfaAdded,
+	mfaRemoved,
+	emailChange,
+	accountLocked bool,
+) *HumanNotificationPreferencesSetEvent {
+	return &HumanNotificationPreferencesSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanNotificationPreferencesSetType,
+		),
+		NewDeviceLogin: newDeviceLogin,
+		MFAAdded:       mfaAdded,
+		MFARemoved:     mfaRemoved,
+		EmailChange:    emailChange,
+		AccountLocked:  accountLocked,
+	}
+}
+
+func HumanNotificationPreferencesSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	preferences := &HumanNotificationPreferencesSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, preferences)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Nt8pf", "unable to unmarshal human notification preferences")
+	}
+	return preferences, nil
+}
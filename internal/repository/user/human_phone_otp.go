@@ -0,0 +1,172 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	phoneOTPEventPrefix             = phoneEventPrefix + "otp."
+	HumanPhoneOTPRequestedType      = phoneOTPEventPrefix + "requested"
+	HumanPhoneOTPSentType           = phoneOTPEventPrefix + "sent"
+	HumanPhoneOTPCheckSucceededType = phoneOTPEventPrefix + "check.succeeded"
+	HumanPhoneOTPCheckFailedType    = phoneOTPEventPrefix + "check.failed"
+)
+
+type HumanPhoneOTPRequestedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Code   *crypto.CryptoValue `json:"code"`
+	Expiry time.Duration       `json:"expiry"`
+	*AuthRequestInfo
+}
+
+func (e *HumanPhoneOTPRequestedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanPhoneOTPRequestedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanPhoneOTPRequestedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	code *crypto.CryptoValue,
+	expiry time.Duration,
+	info *AuthRequestInfo,
+) *HumanPhoneOTPRequestedEvent {
+	return &HumanPhoneOTPRequestedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPhoneOTPRequestedType,
+		),
+		Code:            code,
+		Expiry:          expiry,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanPhoneOTPRequestedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	requested := &HumanPhoneOTPRequestedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, requested)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Qp4ns", "unable to unmarshal human phone otp requested")
+	}
+	return requested, nil
+}
+
+type HumanPhoneOTPSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *HumanPhoneOTPSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanPhoneOTPSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanPhoneOTPSentEvent(ctx context.Context, aggregate *eventstore.Aggregate) *HumanPhoneOTPSentEvent {
+	return &HumanPhoneOTPSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPhoneOTPSentType,
+		),
+	}
+}
+
+func HumanPhoneOTPSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &HumanPhoneOTPSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+type HumanPhoneOTPCheckSucceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	*AuthRequestInfo
+}
+
+func (e *HumanPhoneOTPCheckSucceededEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanPhoneOTPCheckSucceededEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanPhoneOTPCheckSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	info *AuthRequestInfo,
+) *HumanPhoneOTPCheckSucceededEvent {
+	return &HumanPhoneOTPCheckSucceededEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPhoneOTPCheckSucceededType,
+		),
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanPhoneOTPCheckSucceededEventMapper(event *repository.Event) (eventstore.Event, error) {
+	succeeded := &HumanPhoneOTPCheckSucceededEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, succeeded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Zl0am", "unable to unmarshal human phone otp check succeeded")
+	}
+	return succeeded, nil
+}
+
+type HumanPhoneOTPCheckFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	*AuthRequestInfo
+}
+
+func (e *HumanPhoneOTPCheckFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanPhoneOTPCheckFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanPhoneOTPCheckFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	info *AuthRequestInfo,
+) *HumanPhoneOTPCheckFailedEvent {
+	return &HumanPhoneOTPCheckFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPhoneOTPCheckFailedType,
+		),
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanPhoneOTPCheckFailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	failed := &HumanPhoneOTPCheckFailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, failed)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-8Wcgt", "unable to unmarshal human phone otp check failed")
+	}
+	return failed, nil
+}
@@ -31,6 +31,7 @@ type HumanRefreshTokenAddedEvent struct {
 	IdleExpiration        time.Duration `json:"idleExpiration"`
 	Expiration            time.Duration `json:"expiration"`
 	PreferredLanguage     string        `json:"preferredLanguage"`
+	DPoPJKT               string        `json:"dpopJkt,omitempty"`
 }
 
 func (e *HumanRefreshTokenAddedEvent) Data() interface{} {
@@ -51,7 +52,8 @@ func NewHumanRefreshTokenAddedEvent(
 	tokenID,
 	clientID,
 	userAgentID,
-	preferredLanguage string,
+	preferredLanguage,
+	dpopJKT string,
 	audience,
 	scopes,
 	authMethodsReferences []string,
@@ -75,6 +77,7 @@ func NewHumanRefreshTokenAddedEvent(
 		IdleExpiration:        idleExpiration,
 		Expiration:            expiration,
 		PreferredLanguage:     preferredLanguage,
+		DPoPJKT:               dpopJKT,
 	}
 }
 
@@ -0,0 +1,65 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	samlSessionEventPrefix    = humanEventPrefix + "saml.session."
+	HumanSAMLSessionAddedType = samlSessionEventPrefix + "added"
+)
+
+type HumanSAMLSessionAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserAgentID   string `json:"userAgentID"`
+	ApplicationID string `json:"applicationID"`
+	EntityID      string `json:"entityID"`
+	NameID        string `json:"nameID"`
+}
+
+func (e *HumanSAMLSessionAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanSAMLSessionAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanSAMLSessionAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userAgentID,
+	applicationID,
+	entityID,
+	nameID string,
+) *HumanSAMLSessionAddedEvent {
+	return &HumanSAMLSessionAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanSAMLSessionAddedType,
+		),
+		UserAgentID:   userAgentID,
+		ApplicationID: applicationID,
+		EntityID:      entityID,
+		NameID:        nameID,
+	}
+}
+
+func HumanSAMLSessionAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	sessionAdded := &HumanSAMLSessionAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, sessionAdded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Sm2lq", "unable to unmarshal saml session added")
+	}
+
+	return sessionAdded, nil
+}
@@ -0,0 +1,114 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	impersonationEventPrefix     = userEventTypePrefix + "impersonation."
+	UserImpersonationStartedType = impersonationEventPrefix + "started"
+	UserImpersonationEndedType   = impersonationEventPrefix + "ended"
+)
+
+type UserImpersonationStartedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID            string    `json:"tokenId"`
+	ActorUserID        string    `json:"actorUserId"`
+	ActorResourceOwner string    `json:"actorResourceOwner"`
+	ProjectID          string    `json:"projectId"`
+	Reason             string    `json:"reason,omitempty"`
+	Expiration         time.Time `json:"expiration"`
+}
+
+func (e *UserImpersonationStartedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserImpersonationStartedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserImpersonationStartedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID,
+	actorUserID,
+	actorResourceOwner,
+	projectID,
+	reason string,
+	expiration time.Time,
+) *UserImpersonationStartedEvent {
+	return &UserImpersonationStartedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserImpersonationStartedType,
+		),
+		TokenID:            tokenID,
+		ActorUserID:        actorUserID,
+		ActorResourceOwner: actorResourceOwner,
+		ProjectID:          projectID,
+		Reason:             reason,
+		Expiration:         expiration,
+	}
+}
+
+func UserImpersonationStartedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	started := &UserImpersonationStartedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, started)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Rq8mf", "unable to unmarshal impersonation started")
+	}
+
+	return started, nil
+}
+
+type UserImpersonationEndedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID string `json:"tokenId"`
+}
+
+func (e *UserImpersonationEndedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserImpersonationEndedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserImpersonationEndedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+) *UserImpersonationEndedEvent {
+	return &UserImpersonationEndedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserImpersonationEndedType,
+		),
+		TokenID: tokenID,
+	}
+}
+
+func UserImpersonationEndedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	ended := &UserImpersonationEndedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, ended)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Wc4vd", "unable to unmarshal impersonation ended")
+	}
+
+	return ended, nil
+}
@@ -50,10 +50,20 @@ func NewRemoveUsernameUniqueConstraint(userName, resourceOwner string, userLogin
 
 type UserLockedEvent struct {
 	eventstore.BaseEvent `json:"-"`
+
+	// Reason is unspecified if the user was locked manually
+	Reason         domain.UserLockReason `json:"reason,omitempty"`
+	FailedAttempts uint64                `json:"failedAttempts,omitempty"`
+	// LockedUntil is set if the user is unlocked automatically after the lockout duration of the policy
+	LockedUntil *time.Time `json:"lockedUntil,omitempty"`
+	*AuthRequestInfo
 }
 
 func (e *UserLockedEvent) Data() interface{} {
-	return nil
+	if e.Reason == domain.UserLockReasonUnspecified {
+		return nil
+	}
+	return e
 }
 
 func (e *UserLockedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
@@ -70,10 +80,41 @@ func NewUserLockedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *U
 	}
 }
 
-func UserLockedEventMapper(event *repository.Event) (eventstore.Event, error) {
+// NewUserLockedByPolicyEvent locks the user after too many failed checks,
+// the reason and the request of the last failed check are stored for the admins
+func NewUserLockedByPolicyEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	reason domain.UserLockReason,
+	failedAttempts uint64,
+	lockedUntil *time.Time,
+	info *AuthRequestInfo,
+) *UserLockedEvent {
 	return &UserLockedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserLockedType,
+		),
+		Reason:          reason,
+		FailedAttempts:  failedAttempts,
+		LockedUntil:     lockedUntil,
+		AuthRequestInfo: info,
+	}
+}
+
+func UserLockedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &UserLockedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
-	}, nil
+	}
+	if len(event.Data) == 0 {
+		return e, nil
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Wq3ba", "unable to unmarshal user locked")
+	}
+	return e, nil
 }
 
 type UserUnlockedEvent struct {
@@ -219,6 +260,9 @@ type UserTokenAddedEvent struct {
 	Scopes            []string  `json:"scopes"`
 	Expiration        time.Time `json:"expiration"`
 	PreferredLanguage string    `json:"preferredLanguage"`
+	DPoPJKT           string    `json:"dpopJkt,omitempty"`
+	// ActorUserID is set on tokens issued to an administrator impersonating the user
+	ActorUserID string `json:"actorUserId,omitempty"`
 }
 
 func (e *UserTokenAddedEvent) Data() interface{} {
@@ -236,7 +280,8 @@ func NewUserTokenAddedEvent(
 	applicationID,
 	userAgentID,
 	preferredLanguage,
-	refreshTokenID string,
+	refreshTokenID,
+	dpopJKT string,
 	audience,
 	scopes []string,
 	expiration time.Time,
@@ -255,6 +300,7 @@ func NewUserTokenAddedEvent(
 		Scopes:            scopes,
 		Expiration:        expiration,
 		PreferredLanguage: preferredLanguage,
+		DPoPJKT:           dpopJKT,
 	}
 }
 
@@ -21,6 +21,8 @@ type TokenView struct {
 	Sequence          uint64
 	PreferredLanguage string
 	RefreshTokenID    string
+	DPoPJKT           string
+	ActorUserID       string
 	IsPAT             bool
 }
 
@@ -51,6 +51,7 @@ type HumanView struct {
 	OTPState                 MFAState
 	U2FTokens                []*WebAuthNView
 	PasswordlessTokens       []*WebAuthNView
+	PushDevices              []*PushDeviceView
 	MFAMaxSetUp              domain.MFALevel
 	MFAInitSkipped           time.Time
 	InitRequired             bool
@@ -63,6 +64,12 @@ type WebAuthNView struct {
 	State   MFAState
 }
 
+type PushDeviceView struct {
+	DeviceID string
+	Name     string
+	State    MFAState
+}
+
 type MachineView struct {
 	LastKeyAdded time.Time
 	Name         string
@@ -189,6 +196,10 @@ func (u *UserView) MFATypesAllowed(level domain.MFALevel, policy *domain.LoginPo
 					if u.IsU2FReady() {
 						types = append(types, domain.MFATypeU2F)
 					}
+				case domain.SecondFactorTypePush:
+					if u.IsPushReady() {
+						types = append(types, domain.MFATypePush)
+					}
 				}
 			}
 		}
@@ -206,6 +217,15 @@ func (u *UserView) IsU2FReady() bool {
 	return false
 }
 
+func (u *UserView) IsPushReady() bool {
+	for _, device := range u.PushDevices {
+		if device.State == MFAStateReady {
+			return true
+		}
+	}
+	return false
+}
+
 func (u *UserView) IsPasswordlessReady() bool {
 	for _, token := range u.PasswordlessTokens {
 		if token.State == MFAStateReady {
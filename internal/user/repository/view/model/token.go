@@ -39,6 +39,8 @@ type TokenView struct {
 	Sequence          uint64               `json:"-" gorm:"column:sequence"`
 	PreferredLanguage string               `json:"preferredLanguage" gorm:"column:preferred_language"`
 	RefreshTokenID    string               `json:"refreshTokenID,omitempty" gorm:"refresh_token_id"`
+	DPoPJKT           string               `json:"dpopJkt,omitempty" gorm:"column:dpop_jkt"`
+	ActorUserID       string               `json:"actorUserId,omitempty" gorm:"column:actor_user_id"`
 	IsPAT             bool                 `json:"-" gorm:"is_pat"`
 	Deactivated       bool                 `json:"-" gorm:"-"`
 	InstanceID        string               `json:"instanceID" gorm:"column:instance_id;primary_key"`
@@ -59,6 +61,8 @@ func TokenViewToModel(token *TokenView) *usr_model.TokenView {
 		Sequence:          token.Sequence,
 		PreferredLanguage: token.PreferredLanguage,
 		RefreshTokenID:    token.RefreshTokenID,
+		DPoPJKT:           token.DPoPJKT,
+		ActorUserID:       token.ActorUserID,
 		IsPAT:             token.IsPAT,
 	}
 }
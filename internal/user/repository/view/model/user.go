@@ -100,6 +100,7 @@ type HumanView struct {
 	UsernameChangeRequired   bool           `json:"-" gorm:"column:username_change_required"`
 	PasswordChanged          time.Time      `json:"-" gorm:"column:password_change"`
 	PasswordlessTokens       WebAuthNTokens `json:"-" gorm:"column:passwordless_tokens"`
+	PushDevices              PushDevices    `json:"-" gorm:"column:push_devices"`
 }
 
 type WebAuthNTokens []*WebAuthNView
@@ -127,6 +128,31 @@ func (t *WebAuthNTokens) Scan(src interface{}) error {
 	return nil
 }
 
+type PushDevices []*PushDeviceView
+
+type PushDeviceView struct {
+	ID    string `json:"deviceId"`
+	Name  string `json:"name,omitempty"`
+	State int32  `json:"state,omitempty"`
+}
+
+func (d PushDevices) Value() (driver.Value, error) {
+	if d == nil {
+		return nil, nil
+	}
+	return json.Marshal(&d)
+}
+
+func (d *PushDevices) Scan(src interface{}) error {
+	if b, ok := src.([]byte); ok {
+		return json.Unmarshal(b, d)
+	}
+	if s, ok := src.(string); ok {
+		return json.Unmarshal([]byte(s), d)
+	}
+	return nil
+}
+
 func (h *HumanView) IsZero() bool {
 	return h == nil || h.FirstName == ""
 }
@@ -161,6 +187,7 @@ func UserToModel(user *UserView) *model.UserView {
 			PasswordChanged:          user.PasswordChanged,
 			PasswordlessTokens:       WebauthnTokensToModel(user.PasswordlessTokens),
 			U2FTokens:                WebauthnTokensToModel(user.U2FTokens),
+			PushDevices:              PushDevicesToModel(user.PushDevices),
 			FirstName:                user.FirstName,
 			LastName:                 user.LastName,
 			NickName:                 user.NickName,
@@ -213,6 +240,21 @@ func WebauthnTokenToModel(token *WebAuthNView) *model.WebAuthNView {
 	}
 }
 
+func PushDevicesToModel(devices []*PushDeviceView) []*model.PushDeviceView {
+	if devices == nil {
+		return nil
+	}
+	result := make([]*model.PushDeviceView, len(devices))
+	for i, d := range devices {
+		result[i] = &model.PushDeviceView{
+			DeviceID: d.ID,
+			Name:     d.Name,
+			State:    model.MFAState(d.State),
+		}
+	}
+	return result
+}
+
 func (u *UserView) GenerateLoginName(domain string, appendDomain bool) string {
 	if !appendDomain {
 		return u.UserName
@@ -336,6 +378,16 @@ func (u *UserView) AppendEvent(event *models.Event) (err error) {
 		u.MFAInitSkipped = time.Time{}
 	case user.HumanU2FTokenRemovedType:
 		err = u.removeU2FToken(event)
+	case user.HumanPushDeviceAddedType:
+		err = u.addPushDevice(event)
+	case user.HumanPushDeviceVerifiedType:
+		err = u.verifyPushDevice(event)
+		if err != nil {
+			return err
+		}
+		u.MFAInitSkipped = time.Time{}
+	case user.HumanPushDeviceRemovedType:
+		err = u.removePushDevice(event)
 	case user.UserV1MFAInitSkippedType,
 		user.HumanMFAInitSkippedType:
 		u.MFAInitSkipped = event.CreationDate
@@ -480,6 +532,52 @@ func (u *UserView) removeU2FToken(event *models.Event) error {
 	return nil
 }
 
+func (u *UserView) addPushDevice(event *models.Event) error {
+	if u.HumanView == nil {
+		logging.WithFields("sequence", event.Sequence, "instance", event.InstanceID).Warn("event is ignored because human not exists")
+		return errors.ThrowInvalidArgument(nil, "MODEL-Pu4da", "event ignored: human not exists")
+	}
+	device := new(PushDeviceView)
+	err := json.Unmarshal(event.Data, device)
+	if err != nil {
+		return errors.ThrowInternal(err, "MODEL-Pu4db", "could not unmarshal data")
+	}
+	device.State = int32(model.MFAStateNotReady)
+	u.PushDevices = append(u.PushDevices, device)
+	return nil
+}
+
+func (u *UserView) verifyPushDevice(event *models.Event) error {
+	device := new(PushDeviceView)
+	err := json.Unmarshal(event.Data, device)
+	if err != nil {
+		return errors.ThrowInternal(err, "MODEL-Pu4dv", "could not unmarshal data")
+	}
+	for _, d := range u.PushDevices {
+		if d.ID == device.ID {
+			d.State = int32(model.MFAStateReady)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (u *UserView) removePushDevice(event *models.Event) error {
+	device := new(PushDeviceView)
+	err := json.Unmarshal(event.Data, device)
+	if err != nil {
+		return errors.ThrowInternal(err, "MODEL-Pu4dc", "could not unmarshal data")
+	}
+	for i := len(u.PushDevices) - 1; i >= 0; i-- {
+		if u.PushDevices[i].ID == device.ID {
+			u.PushDevices[i] = u.PushDevices[len(u.PushDevices)-1]
+			u.PushDevices[len(u.PushDevices)-1] = nil
+			u.PushDevices = u.PushDevices[:len(u.PushDevices)-1]
+		}
+	}
+	return nil
+}
+
 func webAuthNViewFromEvent(event *models.Event) (*WebAuthNView, error) {
 	token := new(WebAuthNView)
 	err := json.Unmarshal(event.Data, token)
@@ -520,6 +618,12 @@ func (u *UserView) ComputeMFAMaxSetUp() {
 			return
 		}
 	}
+	for _, device := range u.PushDevices {
+		if device.State == int32(model.MFAStateReady) {
+			u.MFAMaxSetUp = int32(domain.MFALevelSecondFactor)
+			return
+		}
+	}
 	if u.OTPState == int32(model.MFAStateReady) {
 		u.MFAMaxSetUp = int32(domain.MFALevelSecondFactor)
 		return
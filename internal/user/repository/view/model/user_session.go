@@ -93,7 +93,9 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 	v.ChangeDate = event.CreationDate
 	switch eventstore.EventType(event.Type) {
 	case user.UserV1PasswordCheckSucceededType,
-		user.HumanPasswordCheckSucceededType:
+		user.HumanPasswordCheckSucceededType,
+		user.HumanMagicLinkCheckSucceededType,
+		user.HumanPhoneOTPCheckSucceededType:
 		v.PasswordVerification = event.CreationDate
 		v.State = int32(domain.UserSessionStateActive)
 	case user.UserIDPLoginCheckSucceededType:
@@ -115,7 +117,9 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 		v.PasswordlessVerification = time.Time{}
 		v.MultiFactorVerification = time.Time{}
 	case user.UserV1PasswordCheckFailedType,
-		user.HumanPasswordCheckFailedType:
+		user.HumanPasswordCheckFailedType,
+		user.HumanMagicLinkCheckFailedType,
+		user.HumanPhoneOTPCheckFailedType:
 		v.PasswordVerification = time.Time{}
 	case user.UserV1PasswordChangedType,
 		user.HumanPasswordChangedType:
@@ -144,7 +148,9 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 		user.HumanMFAOTPCheckFailedType,
 		user.HumanMFAOTPRemovedType,
 		user.HumanU2FTokenCheckFailedType,
-		user.HumanU2FTokenRemovedType:
+		user.HumanU2FTokenRemovedType,
+		user.HumanPushCheckFailedType,
+		user.HumanPushDeviceRemovedType:
 		v.SecondFactorVerification = time.Time{}
 	case user.HumanU2FTokenVerifiedType:
 		data := new(es_model.WebAuthNVerify)
@@ -157,6 +163,8 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 		}
 	case user.HumanU2FTokenCheckSucceededType:
 		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeU2F)
+	case user.HumanPushCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypePush)
 	case user.UserV1SignedOutType,
 		user.HumanSignedOutType,
 		user.UserLockedType,